@@ -0,0 +1,102 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/code_processing"
+	"beam.apache.org/playground/backend/internal/logger"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// activePipelinesPath is the path of the admin endpoint which lists the pipelines in progress for the operator dashboard.
+// It exposes ids of all pipelines which are the only credentials to receive the output or cancel the pipeline,
+//	so it is served only if the admin token is set and only to the requests with this token.
+const activePipelinesPath = "/admin/pipelines"
+
+// bearerPrefix is a prefix of the Authorization header which is followed by the admin token
+const bearerPrefix = "Bearer "
+
+// activePipeline is a JSON description of the pipeline in progress
+type activePipeline struct {
+	PipelineUuid string    `json:"pipelineUuid"`
+	Sdk          string    `json:"sdk"`
+	Status       string    `json:"status"`
+	StartTime    time.Time `json:"startTime"`
+}
+
+// activePipelinesHandler lists the pipelines which code processing isn't finished yet
+type activePipelinesHandler struct {
+	cacheService cache.Cache
+	adminToken   string
+}
+
+// newActivePipelinesHandler returns http.Handler which responds with JSON list of activePipeline ordered by the start time.
+// In case the request doesn't have "Authorization: Bearer <adminToken>" header or adminToken is empty
+//	responds with http.StatusUnauthorized.
+// In case the cache doesn't keep the index of the pipelines in progress responds with http.StatusNotImplemented.
+func newActivePipelinesHandler(cacheService cache.Cache, adminToken string) http.Handler {
+	return &activePipelinesHandler{cacheService: cacheService, adminToken: adminToken}
+}
+
+func (h *activePipelinesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !isAdminRequest(r, h.adminToken) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "admin token is missing or incorrect", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method isn't allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	pipelines, err := code_processing.ActivePipelines(r.Context(), h.cacheService)
+	if errors.Is(err, cache.ErrNotSupported) {
+		http.Error(w, "active pipelines aren't listed by the cache", http.StatusNotImplemented)
+		return
+	}
+	if err != nil {
+		logger.Errorf("ActivePipelines: error during list active pipelines: %s", err.Error())
+		http.Error(w, "error during list active pipelines", http.StatusInternalServerError)
+		return
+	}
+	response := make([]activePipeline, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		response = append(response, activePipeline{
+			PipelineUuid: pipeline.PipelineId.String(),
+			Sdk:          pipeline.Sdk.String(),
+			Status:       pipeline.Status.String(),
+			StartTime:    pipeline.StartTime,
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Errorf("ActivePipelines: error during write response: %s", err.Error())
+	}
+}
+
+// isAdminRequest checks if the request has the admin token in the Authorization header.
+// The tokens are compared in constant time, so the token couldn't be guessed by the time of the response.
+func isAdminRequest(r *http.Request, adminToken string) bool {
+	token := r.Header.Get("Authorization")
+	if adminToken == "" || !strings.HasPrefix(token, bearerPrefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(token, bearerPrefix)), []byte(adminToken)) == 1
+}
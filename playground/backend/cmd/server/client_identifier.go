@@ -0,0 +1,79 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"strings"
+)
+
+// forwardedForHeader is the header with the addresses of the client and the proxies the request is received through
+const forwardedForHeader = "x-forwarded-for"
+
+// clientIdentifier returns the identifier of the client which sent the request.
+// It is the IP address of the peer unless the peer is one of trustedProxies (i.e. a load balancer).
+// Only then the X-Forwarded-For header is used: the addresses which are appended by the trusted proxies are skipped
+//	from the right, and the first untrusted address is the client. The addresses on the left of it are sent by the client
+//	itself and could be spoofed, so they are never used.
+// Empty string is returned if the client couldn't be identified.
+func clientIdentifier(ctx context.Context, trustedProxies []*net.IPNet) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	client, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		client = p.Addr.String()
+	}
+	if !isTrustedProxy(client, trustedProxies) {
+		return client
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return client
+	}
+	var hops []string
+	for _, value := range md.Get(forwardedForHeader) {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		client = hops[i]
+		if !isTrustedProxy(client, trustedProxies) {
+			break
+		}
+	}
+	return client
+}
+
+// isTrustedProxy checks if the address is in one of trustedProxies
+func isTrustedProxy(address string, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, trustedProxy := range trustedProxies {
+		if trustedProxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"testing"
+)
+
+func Test_clientIdentifier(t *testing.T) {
+	_, trustedProxies, _ := net.ParseCIDR("10.0.0.0/24")
+	peerCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("198.51.100.1"), Port: 54321}})
+	proxyCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 54321}})
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			// Test case with calling clientIdentifier method with the request received directly from the client.
+			// As a result, want to receive IP address of the peer without the port.
+			name: "peer address",
+			ctx:  peerCtx,
+			want: "198.51.100.1",
+		},
+		{
+			// Test case with calling clientIdentifier method with the request with X-Forwarded-For header
+			//	which is received directly from the client.
+			// As a result, want to receive IP address of the peer since the header could be spoofed by the client.
+			name: "forwarded for header from untrusted peer",
+			ctx:  metadata.NewIncomingContext(peerCtx, metadata.Pairs(forwardedForHeader, "203.0.113.7")),
+			want: "198.51.100.1",
+		},
+		{
+			// Test case with calling clientIdentifier method with the request received through trusted proxies.
+			// As a result, want to receive the right-most address of the X-Forwarded-For header which isn't a trusted proxy.
+			name: "forwarded for header from trusted proxy",
+			ctx:  metadata.NewIncomingContext(proxyCtx, metadata.Pairs(forwardedForHeader, "192.0.2.1, 203.0.113.7, 10.0.0.2")),
+			want: "203.0.113.7",
+		},
+		{
+			// Test case with calling clientIdentifier method with the request received through trusted proxies
+			//	with X-Forwarded-For header which is split into several values.
+			// As a result, want to receive the right-most address of the last value which isn't a trusted proxy.
+			name: "several forwarded for headers from trusted proxy",
+			ctx:  metadata.NewIncomingContext(proxyCtx, metadata.Pairs(forwardedForHeader, "192.0.2.1", forwardedForHeader, "203.0.113.7, 10.0.0.2")),
+			want: "203.0.113.7",
+		},
+		{
+			// Test case with calling clientIdentifier method with the request received through trusted proxies only.
+			// As a result, want to receive the left-most address of the X-Forwarded-For header.
+			name: "forwarded for header with trusted proxies only",
+			ctx:  metadata.NewIncomingContext(proxyCtx, metadata.Pairs(forwardedForHeader, "10.0.0.3, 10.0.0.2")),
+			want: "10.0.0.3",
+		},
+		{
+			// Test case with calling clientIdentifier method with the empty X-Forwarded-For header from trusted proxy.
+			// As a result, want to receive IP address of the peer.
+			name: "empty forwarded for header from trusted proxy",
+			ctx:  metadata.NewIncomingContext(proxyCtx, metadata.Pairs(forwardedForHeader, "")),
+			want: "10.0.0.1",
+		},
+		{
+			// Test case with calling clientIdentifier method without the peer and the metadata.
+			// As a result, want to receive empty identifier.
+			name: "unknown client",
+			ctx:  context.Background(),
+			want: "",
+		},
+		{
+			// Test case with calling clientIdentifier method without the peer, but with X-Forwarded-For header.
+			// As a result, want to receive empty identifier since the sender of the header is unknown.
+			name: "forwarded for header without peer",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs(forwardedForHeader, "203.0.113.7")),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clientIdentifier(tt.ctx, []*net.IPNet{trustedProxies}); got != tt.want {
+				t.Errorf("clientIdentifier() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
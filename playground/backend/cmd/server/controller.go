@@ -16,62 +16,212 @@ package main
 
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/archive"
 	"beam.apache.org/playground/backend/internal/cache"
 	"beam.apache.org/playground/backend/internal/cloud_bucket"
 	"beam.apache.org/playground/backend/internal/code_processing"
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/errors"
+	"beam.apache.org/playground/backend/internal/examples"
+	"beam.apache.org/playground/backend/internal/executors"
+	"beam.apache.org/playground/backend/internal/fs_tool"
 	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/rate_limiter"
 	"beam.apache.org/playground/backend/internal/setup_tools/life_cycle"
 	"beam.apache.org/playground/backend/internal/utils"
 	"context"
+	stderrors "errors"
 	"github.com/google/uuid"
+	"time"
 )
 
+// streamPauseDuration is a pause between checks of new output for streaming endpoints
+const streamPauseDuration = 200 * time.Millisecond
+
 // playgroundController processes `gRPC' requests from clients.
 // Contains methods to process receiving code, monitor current status of code processing and receive compile/run output.
 type playgroundController struct {
 	env          *environment.Environment
 	cacheService cache.Cache
+	workerPool   *code_processing.WorkerPool
+
+	// examplesCatalog lists the examples from the examples directory
+	examplesCatalog *examples.Catalog
+
+	// processingTracker runs the code processing, so it is stopped when the server is shutting down
+	processingTracker *code_processing.ProcessingTracker
+
+	// idleSweeper removes pipelines from the cache if their results aren't read for the idle timeout
+	idleSweeper *code_processing.IdleSweeper
+
+	// archiveStorage keeps results of the finished code processing after they are removed from the cache.
+	// It is nil if results aren't archived.
+	archiveStorage archive.Storage
+
+	// rateLimiter limits the number of the code processing requests received from each client.
+	// Requests are not limited if it is nil.
+	rateLimiter *rate_limiter.RateLimiter
+
+	// jvmWarmPool keeps pre-started JVMs which run the Java code without the cold start of the JVM.
+	// The code is run by a new JVM if it is nil.
+	jvmWarmPool *executors.JvmWarmPool
+
+	// compileCache keeps the compiled artifacts, so the same code isn't compiled again.
+	// The code is always compiled if it is nil.
+	compileCache *code_processing.CompileCache
+
+	// executionBackend runs the code, i.e. in a subprocess of the server or in a container.
+	// The code is run by the default backend of the SDK (see code_processing.Process) if it is nil.
+	executionBackend executors.ExecutionBackend
 
 	pb.UnimplementedPlaygroundServiceServer
 }
 
 // RunCode is running code from requests using a particular SDK
-// - In case of incorrect sdk returns codes.InvalidArgument
+// - In case the client has sent more requests than the rate limit allows returns codes.ResourceExhausted.
+//   The request is rejected before the code is saved.
+// - In case the client already has the max pipelines per client in progress returns codes.ResourceExhausted.
+//   The request is rejected before the code is saved. The pipeline is counted for the client until it reaches the final status.
+// - In case of incorrect sdk or sdk which isn't served by the server returns codes.InvalidArgument.
+//   The server could serve several SDKs, the code is processed with BeamEnvs of the SDK of the request.
+// - In case the JSON logs are requested for the SDK which doesn't support them (only Java and Python SDKs do) returns codes.InvalidArgument.
+// - In case the example with the received name isn't presented in the examples catalog returns codes.NotFound
+// - If the idempotency key is set and the code processing with the same key is already started (i.e. by the previous try
+//   of the request), returns its pipelineId without processing the code again. The key is kept as long as the cache values.
+//   The key is scoped by the client, so the requests of other clients with the same key are processed separately.
+//   The concurrent request with the same key waits until the code processing is started by the first one.
+// - In case the idempotency key is already used by the client for the request with other code or parameters returns codes.InvalidArgument.
 // - In case of error during preparing files/folders returns codes.Internal
-// - In case of no errors saves playground.Status_STATUS_EXECUTING as cache.Status into cache and sets expiration time
+// - In case of no errors saves playground.Status_STATUS_PREPARING as cache.Status, the SDK as cache.Sdk
+//   and the time of the request as cache.StartTime into cache and sets expiration time
 //   for all cache values which will be saved into cache during processing received code.
 //   Returns id of code processing (pipelineId)
+// - If the archive is configured, the result of the successfully finished code processing is saved into it by pipelineId,
+//   so it could be received by GetArchivedResult after it is removed from the cache.
 func (controller *playgroundController) RunCode(ctx context.Context, info *pb.RunCodeRequest) (*pb.RunCodeResponse, error) {
+	clientId := clientIdentifier(ctx, controller.env.ApplicationEnvs.TrustedProxies())
+	if !controller.rateLimiter.Allow(clientId) {
+		logger.Warnf("RunCode(): rate limit is exceeded by client %s\n", clientId)
+		return nil, errors.ResourceExhaustedError("Run code()", "too many requests, try again later")
+	}
 	// check for correct sdk
-	if info.Sdk != controller.env.BeamSdkEnvs.ApacheBeamSdk {
+	sdkEnv, ok := controller.env.GetBeamSdkEnvs(info.Sdk)
+	if !ok {
 		logger.Errorf("RunCode(): request contains incorrect sdk: %s\n", info.Sdk)
 		return nil, errors.InvalidArgumentError("Run code()", "incorrect sdk: %s", info.Sdk.String())
 	}
+	pipelineOptions := info.PipelineOptions
+	if info.ExampleName != "" {
+		exampleOptions, err := controller.examplesCatalog.GetPipelineOptions(ctx, info.Sdk, info.ExampleName)
+		if err == examples.ErrExampleNotFound {
+			return nil, errors.NotFoundError("Run code()", "example %s isn't found for %s", info.ExampleName, info.Sdk.String())
+		}
+		if err != nil {
+			logger.Errorf("RunCode(): error during getting pipeline options of example %s: %s\n", info.ExampleName, err.Error())
+			return nil, errors.InternalError("Run code()", "error during getting pipeline options of example")
+		}
+		pipelineOptions = utils.MergePipelineOptions(exampleOptions, pipelineOptions)
+	}
+	if info.FileName != "" {
+		if _, err := fs_tool.ValidateSourceFileName(info.Sdk, info.FileName); err != nil {
+			logger.Errorf("RunCode(): incorrect name of the source file: %s\n", err.Error())
+			return nil, errors.InvalidArgumentError("Run code()", "incorrect name of the source file: %s", err.Error())
+		}
+	}
+	if info.JsonLogs && info.Sdk != pb.Sdk_SDK_JAVA && info.Sdk != pb.Sdk_SDK_PYTHON {
+		logger.Errorf("RunCode(): json logs aren't supported for sdk: %s\n", info.Sdk)
+		return nil, errors.InvalidArgumentError("Run code()", "json logs aren't supported for %s", info.Sdk.String())
+	}
 	switch info.Sdk {
-	case pb.Sdk_SDK_UNSPECIFIED, pb.Sdk_SDK_SCIO:
+	case pb.Sdk_SDK_UNSPECIFIED:
 		logger.Errorf("RunCode(): unimplemented sdk: %s\n", info.Sdk)
 		return nil, errors.InvalidArgumentError("Run code()", "unimplemented sdk: %s", info.Sdk.String())
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_SCIO, pb.Sdk_SDK_KOTLIN:
+		if _, _, err := utils.SplitJvmArgs(pipelineOptions); err != nil {
+			logger.Errorf("RunCode(): incorrect pipeline options: %s\n", err.Error())
+			return nil, errors.InvalidArgumentError("Run code()", "incorrect pipeline options: %s", err.Error())
+		}
 	}
 
 	cacheExpirationTime := controller.env.ApplicationEnvs.CacheEnvs().KeyExpirationTime()
+	var started bool
+	idempotencyKey := code_processing.IdempotencyKey{ClientId: clientId, Key: info.IdempotencyKey}
+	if info.IdempotencyKey != "" {
+		payloadHash, err := code_processing.IdempotencyPayloadHash(info)
+		if err != nil {
+			logger.Errorf("RunCode(): error during hashing the request: %s\n", err.Error())
+			return nil, errors.InternalError("Run code()", "Error during claiming the idempotency key: %s", err.Error())
+		}
+		existingPipelineId, claimed, err := code_processing.ClaimIdempotencyKey(ctx, controller.cacheService, idempotencyKey, payloadHash, cacheExpirationTime)
+		if stderrors.Is(err, code_processing.ErrIdempotencyKeyReused) {
+			logger.Warnf("RunCode(): idempotency key is reused by client %s with another request\n", clientId)
+			return nil, errors.InvalidArgumentError("Run code()", "idempotency key is already used for another request")
+		}
+		if err != nil {
+			logger.Errorf("RunCode(): error during claiming the idempotency key: %s\n", err.Error())
+			return nil, errors.InternalError("Run code()", "Error during claiming the idempotency key: %s", err.Error())
+		}
+		if !claimed {
+			logger.Infof("%s: RunCode(): the code with the same idempotency key is already processed\n", existingPipelineId)
+			return &pb.RunCodeResponse{PipelineUuid: existingPipelineId.String()}, nil
+		}
+		defer func() {
+			if started {
+				return
+			}
+			if err := code_processing.ReleaseIdempotencyKey(ctx, controller.cacheService, idempotencyKey); err != nil {
+				logger.Errorf("RunCode(): error during releasing the idempotency key: %s\n", err.Error())
+			}
+		}()
+	}
+	maxPipelinesPerClient := controller.env.ApplicationEnvs.MaxPipelinesPerClient()
+	acquired, err := code_processing.AcquireClientSlot(ctx, controller.cacheService, clientId, maxPipelinesPerClient, cacheExpirationTime)
+	if err != nil {
+		logger.Errorf("RunCode(): error during acquiring the slot of client %s: %s\n", clientId, err.Error())
+		return nil, errors.InternalError("Run code()", "Error during acquiring the slot of the client: %s", err.Error())
+	}
+	if !acquired {
+		logger.Warnf("RunCode(): max pipelines per client is exceeded by client %s\n", clientId)
+		return nil, errors.ResourceExhaustedError("Run code()", "too many pipelines are in progress, wait until one of them is finished")
+	}
+	defer func() {
+		if started {
+			return
+		}
+		if err := code_processing.ReleaseClientSlot(ctx, controller.cacheService, clientId, maxPipelinesPerClient); err != nil {
+			logger.Errorf("RunCode(): error during releasing the slot of client %s: %s\n", clientId, err.Error())
+		}
+	}()
 	pipelineId := uuid.New()
 
-	lc, err := life_cycle.Setup(info.Sdk, info.Code, pipelineId, controller.env.ApplicationEnvs.WorkingDir(), controller.env.BeamSdkEnvs.PreparedModDir())
+	files := []fs_tool.CodeFile{{Content: info.Code, IsMain: true}}
+	for _, file := range info.AdditionalFiles {
+		files = append(files, fs_tool.CodeFile{Name: file.Name, Content: file.Content})
+	}
+	lc, err := life_cycle.Setup(info.Sdk, files, info.FileName, pipelineId, controller.env.ApplicationEnvs.WorkingDir(), controller.env.ApplicationEnvs.ExecutionRoot(), sdkEnv.PreparedModDir(), info.JsonLogs)
 	if err != nil {
 		logger.Errorf("RunCode(): error during setup file system: %s\n", err.Error())
 		return nil, errors.InternalError("Run code", "Error during setup file system: %s", err.Error())
 	}
 
-	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.Status, pb.Status_STATUS_VALIDATING); err != nil {
+	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.Status, pb.Status_STATUS_PREPARING); err != nil {
+		code_processing.DeleteFolders(pipelineId, lc)
+		return nil, errors.InternalError("Run code()", "Error during set value to cache: %s", err.Error())
+	}
+	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.Sdk, info.Sdk); err != nil {
+		code_processing.DeleteFolders(pipelineId, lc)
+		return nil, errors.InternalError("Run code()", "Error during set value to cache: %s", err.Error())
+	}
+	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.StartTime, time.Now()); err != nil {
 		code_processing.DeleteFolders(pipelineId, lc)
 		return nil, errors.InternalError("Run code()", "Error during set value to cache: %s", err.Error())
 	}
 	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.RunOutputIndex, 0); err != nil {
+		code_processing.DeleteFolders(pipelineId, lc)
 		return nil, errors.InternalError("Run code()", "Error during set value to cache: %s", err.Error())
 	}
 	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.LogsIndex, 0); err != nil {
+		code_processing.DeleteFolders(pipelineId, lc)
 		return nil, errors.InternalError("Run code()", "Error during set value to cache: %s", err.Error())
 	}
 	if err = controller.cacheService.SetExpTime(ctx, pipelineId, cacheExpirationTime); err != nil {
@@ -79,15 +229,41 @@ func (controller *playgroundController) RunCode(ctx context.Context, info *pb.Ru
 		code_processing.DeleteFolders(pipelineId, lc)
 		return nil, errors.InternalError("Run code()", "Error during set expiration to cache: %s", err.Error())
 	}
+	if err = controller.idleSweeper.Track(ctx, pipelineId); err != nil {
+		logger.Errorf("%s: RunCode(): idleSweeper.Track(): %s\n", pipelineId, err.Error())
+	}
 
-	// TODO change using of context.TODO() to context.Background()
-	go code_processing.Process(context.TODO(), controller.cacheService, lc, pipelineId, &controller.env.ApplicationEnvs, &controller.env.BeamSdkEnvs, info.PipelineOptions)
+	started = controller.processingTracker.Go(pipelineId, lc, func(processingCtx context.Context) {
+		code_processing.Process(processingCtx, controller.cacheService, controller.workerPool, lc, pipelineId, &controller.env.ApplicationEnvs, sdkEnv, pipelineOptions, info.Stdin, info.SdkVersion, info.EntryPoint, controller.jvmWarmPool, controller.compileCache, controller.executionBackend)
+		// the code processing has reached the final status, so it isn't counted as active for the client
+		if err := code_processing.ReleaseClientSlot(context.Background(), controller.cacheService, clientId, maxPipelinesPerClient); err != nil {
+			logger.Errorf("%s: RunCode(): error during releasing the slot of client %s: %s\n", pipelineId, clientId, err.Error())
+		}
+		if controller.archiveStorage != nil {
+			if err := code_processing.ArchiveResult(processingCtx, controller.cacheService, controller.archiveStorage, pipelineId); err != nil {
+				logger.Errorf("%s: RunCode(): error during archiving the result: %s\n", pipelineId, err.Error())
+			}
+		}
+	})
+	if !started {
+		logger.Errorf("%s: RunCode(): server is shutting down\n", pipelineId)
+		code_processing.DeleteFolders(pipelineId, lc)
+		return nil, errors.InternalError("Run code()", "Server is shutting down")
+	}
+	if info.IdempotencyKey != "" {
+		if err = code_processing.SetIdempotentPipelineId(ctx, controller.cacheService, idempotencyKey, pipelineId, cacheExpirationTime); err != nil {
+			// the code is processed, so the key isn't released and the retries of the request wait for the id until the timeout
+			logger.Errorf("%s: RunCode(): error during saving the pipeline id by the idempotency key: %s\n", pipelineId, err.Error())
+		}
+	}
 
 	pipelineInfo := pb.RunCodeResponse{PipelineUuid: pipelineId.String()}
 	return &pipelineInfo, nil
 }
 
-// CheckStatus is checking status for the specific pipeline by PipelineUuid
+// CheckStatus is checking status for the specific pipeline by PipelineUuid.
+// If the status is final, the response also contains durations of the compile and run steps
+// and the category of the error if the code processing is failed.
 func (controller *playgroundController) CheckStatus(ctx context.Context, info *pb.CheckStatusRequest) (*pb.CheckStatusResponse, error) {
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	if err != nil {
@@ -98,7 +274,21 @@ func (controller *playgroundController) CheckStatus(ctx context.Context, info *p
 	if err != nil {
 		return nil, err
 	}
-	return &pb.CheckStatusResponse{Status: status}, nil
+	code_processing.MarkAccessed(ctx, controller.cacheService, pipelineId)
+	response := &pb.CheckStatusResponse{Status: status}
+	// durations are kept only after the corresponding step is completed, so they are returned for the final statuses
+	if code_processing.IsFinalStatus(status) {
+		if compileTime, err := code_processing.GetCompileTime(ctx, controller.cacheService, pipelineId, "CheckStatus"); err == nil {
+			response.CompileTimeMs = compileTime.Milliseconds()
+		}
+		if runTime, err := code_processing.GetRunTime(ctx, controller.cacheService, pipelineId, "CheckStatus"); err == nil {
+			response.RunTimeMs = runTime.Milliseconds()
+		}
+		if category, err := code_processing.GetErrorCategory(ctx, controller.cacheService, pipelineId, "CheckStatus"); err == nil {
+			response.ErrorCategory = category
+		}
+	}
+	return response, nil
 }
 
 // GetRunOutput is returning output of execution for specific pipeline by PipelineUuid
@@ -108,27 +298,60 @@ func (controller *playgroundController) GetRunOutput(ctx context.Context, info *
 		logger.Errorf("%s: GetRunOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError("GetRunOutput", "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
 	}
-	lastIndex, err := code_processing.GetLastIndex(ctx, controller.cacheService, pipelineId, cache.RunOutputIndex, "GetRunOutput")
+	newRunOutput, err := code_processing.GetNewOutput(ctx, controller.cacheService, pipelineId, cache.RunOutput, cache.RunOutputIndex, "GetRunOutput")
 	if err != nil {
 		return nil, err
 	}
-	runOutput, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.RunOutput, "GetRunOutput")
-	if err != nil {
-		return nil, err
-	}
-	newRunOutput := ""
-	if len(runOutput) > lastIndex {
-		newRunOutput = runOutput[lastIndex:]
-		if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.RunOutputIndex, lastIndex+len(newRunOutput)); err != nil {
-			return nil, errors.InternalError("GetRunOutput", "Error during set value to cache: %s", err.Error())
-		}
-	}
+	code_processing.MarkAccessed(ctx, controller.cacheService, pipelineId)
 
 	pipelineResult := pb.GetRunOutputResponse{Output: newRunOutput}
 
 	return &pipelineResult, nil
 }
 
+// GetRunOutputStream is sending output of execution for specific pipeline by PipelineUuid as soon as it is received.
+// The stream keeps its own offset of the sent output, so each stream receives the whole run output
+//	regardless of the output which has been received by GetRunOutput or other streams.
+// The stream is closed after the code processing reaches one of the final statuses and the rest of the output is sent.
+func (controller *playgroundController) GetRunOutputStream(info *pb.GetRunOutputRequest, stream pb.PlaygroundService_GetRunOutputStreamServer) error {
+	errorTitle := "GetRunOutputStream"
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: %s(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, errorTitle, err.Error())
+		return errors.InvalidArgumentError(errorTitle, "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
+	}
+	return code_processing.WatchRunOutput(stream.Context(), controller.cacheService, pipelineId, streamPauseDuration, errorTitle, func(status pb.Status, newRunOutput string) error {
+		if newRunOutput == "" {
+			return nil
+		}
+		if err := stream.Send(&pb.GetRunOutputResponse{Output: newRunOutput}); err != nil {
+			logger.Errorf("%s: %s(): error during send run output: %s", pipelineId, errorTitle, err.Error())
+			return err
+		}
+		code_processing.MarkAccessed(stream.Context(), controller.cacheService, pipelineId)
+		return nil
+	})
+}
+
+// GetCompileOutputStream is sending the compile output for specific pipeline by PipelineUuid as soon as it is received
+//	while the code is compiling
+func (controller *playgroundController) GetCompileOutputStream(info *pb.GetCompileOutputRequest, stream pb.PlaygroundService_GetCompileOutputStreamServer) error {
+	errorTitle := "GetCompileOutputStream"
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: %s(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, errorTitle, err.Error())
+		return errors.InvalidArgumentError(errorTitle, "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
+	}
+	return code_processing.WatchCompileOutput(stream.Context(), controller.cacheService, pipelineId, streamPauseDuration, errorTitle, func(status pb.Status, newCompileOutput string) error {
+		if err := stream.Send(&pb.GetCompileOutputResponse{Output: newCompileOutput, CompilationStatus: status}); err != nil {
+			logger.Errorf("%s: %s(): error during send compile output: %s", pipelineId, errorTitle, err.Error())
+			return err
+		}
+		code_processing.MarkAccessed(stream.Context(), controller.cacheService, pipelineId)
+		return nil
+	})
+}
+
 // GetLogs is returning logs of execution for specific pipeline by PipelineUuid
 func (controller *playgroundController) GetLogs(ctx context.Context, info *pb.GetLogsRequest) (*pb.GetLogsResponse, error) {
 	errorTitle := utils.GetFuncName(controller.GetRunOutput)
@@ -137,21 +360,11 @@ func (controller *playgroundController) GetLogs(ctx context.Context, info *pb.Ge
 		logger.Errorf("%s: %s: pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, errorTitle, err.Error())
 		return nil, errors.InvalidArgumentError(errorTitle, "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
 	}
-	lastIndex, err := code_processing.GetLastIndex(ctx, controller.cacheService, pipelineId, cache.LogsIndex, errorTitle)
-	if err != nil {
-		return nil, err
-	}
-	logs, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.Logs, errorTitle)
+	newLogs, err := code_processing.GetNewOutput(ctx, controller.cacheService, pipelineId, cache.Logs, cache.LogsIndex, errorTitle)
 	if err != nil {
 		return nil, err
 	}
-	newLogs := ""
-	if len(logs) > lastIndex {
-		newLogs = logs[lastIndex:]
-		if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.LogsIndex, lastIndex+len(newLogs)); err != nil {
-			return nil, errors.InternalError(errorTitle, "Error during set value to cache: %s", err.Error())
-		}
-	}
+	code_processing.MarkAccessed(ctx, controller.cacheService, pipelineId)
 
 	pipelineResult := pb.GetLogsResponse{Output: newLogs}
 
@@ -169,6 +382,7 @@ func (controller *playgroundController) GetRunError(ctx context.Context, info *p
 	if err != nil {
 		return nil, err
 	}
+	code_processing.MarkAccessed(ctx, controller.cacheService, pipelineId)
 	return &pb.GetRunErrorResponse{Output: runError}, nil
 }
 
@@ -183,18 +397,186 @@ func (controller *playgroundController) GetCompileOutput(ctx context.Context, in
 	if err != nil {
 		return nil, err
 	}
+	code_processing.MarkAccessed(ctx, controller.cacheService, pipelineId)
 	return &pb.GetCompileOutputResponse{Output: compileOutput}, nil
 }
 
+// GetCompileErrors is returning errors of the compilation parsed from the compile output for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetCompileErrors(ctx context.Context, info *pb.GetCompileErrorsRequest) (*pb.GetCompileErrorsResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetCompileErrors(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetCompileErrors", "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
+	}
+	compileErrors, err := code_processing.GetCompileErrors(ctx, controller.cacheService, pipelineId, "GetCompileErrors")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetCompileErrorsResponse{CompileErrors: compileErrors}, nil
+}
+
+// GetCompileWarnings is returning the warnings of the compiler for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetCompileWarnings(ctx context.Context, info *pb.GetCompileWarningsRequest) (*pb.GetCompileWarningsResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetCompileWarnings(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetCompileWarnings", "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
+	}
+	compileWarnings, err := code_processing.GetCompileWarnings(ctx, controller.cacheService, pipelineId, "GetCompileWarnings")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetCompileWarningsResponse{CompileWarnings: compileWarnings}, nil
+}
+
+// GetWarnings is returning warnings about the code found by the validation for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetWarnings(ctx context.Context, info *pb.GetWarningsRequest) (*pb.GetWarningsResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetWarnings(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetWarnings", "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
+	}
+	warnings, err := code_processing.GetWarnings(ctx, controller.cacheService, pipelineId, "GetWarnings")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetWarningsResponse{Warnings: warnings}, nil
+}
+
+// GetPipelineSnapshot is returning status, outputs and errors of the code processing for specific pipeline by PipelineUuid in one call
+func (controller *playgroundController) GetPipelineSnapshot(ctx context.Context, info *pb.GetPipelineSnapshotRequest) (*pb.GetPipelineSnapshotResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetPipelineSnapshot(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetPipelineSnapshot", "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
+	}
+	snapshot, err := code_processing.GetPipelineSnapshot(ctx, controller.cacheService, pipelineId, "GetPipelineSnapshot")
+	if err != nil {
+		return nil, err
+	}
+	code_processing.MarkAccessed(ctx, controller.cacheService, pipelineId)
+	return snapshot, nil
+}
+
+// GetPipelineMetrics is returning metrics (counters, distributions and gauges) of the executed pipeline for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetPipelineMetrics(ctx context.Context, info *pb.GetPipelineMetricsRequest) (*pb.GetPipelineMetricsResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetPipelineMetrics(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetPipelineMetrics", "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
+	}
+	pipelineMetrics, err := code_processing.GetPipelineMetrics(ctx, controller.cacheService, pipelineId, "GetPipelineMetrics")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetPipelineMetricsResponse{Metrics: pipelineMetrics}, nil
+}
+
+// GetTestResults is returning results of the unit tests parsed from the test runner output for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetTestResults(ctx context.Context, info *pb.GetTestResultsRequest) (*pb.GetTestResultsResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetTestResults(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetTestResults", "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
+	}
+	testResults, err := code_processing.GetTestResults(ctx, controller.cacheService, pipelineId, "GetTestResults")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetTestResultsResponse{TestResults: testResults}, nil
+}
+
+// GetRunExitCode is returning exit code of the executed code for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetRunExitCode(ctx context.Context, info *pb.GetRunExitCodeRequest) (*pb.GetRunExitCodeResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetRunExitCode(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetRunExitCode", "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
+	}
+	exitCode, err := code_processing.GetRunExitCode(ctx, controller.cacheService, pipelineId, "GetRunExitCode")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetRunExitCodeResponse{ExitCode: int32(exitCode)}, nil
+}
+
+// GetGraph is returning graph of the executed pipeline in DOT format for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetGraph(ctx context.Context, info *pb.GetGraphRequest) (*pb.GetGraphResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetGraph(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetGraph", "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
+	}
+	graph, err := code_processing.GetGraph(ctx, controller.cacheService, pipelineId, "GetGraph")
+	if err != nil {
+		return nil, err
+	}
+	code_processing.MarkAccessed(ctx, controller.cacheService, pipelineId)
+	return &pb.GetGraphResponse{Graph: graph}, nil
+}
+
+// GetRunCommand is returning command line of the run step for specific pipeline by PipelineUuid.
+// Values of sensitive environment variables are redacted in the command line.
+// - In case the server isn't run in the debug mode returns codes.PermissionDenied
+func (controller *playgroundController) GetRunCommand(ctx context.Context, info *pb.GetRunCommandRequest) (*pb.GetRunCommandResponse, error) {
+	if !controller.env.ApplicationEnvs.DebugMode() {
+		return nil, errors.PermissionDeniedError("GetRunCommand", "run command is available only in the debug mode")
+	}
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetRunCommand(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetRunCommand", "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
+	}
+	command, err := code_processing.GetRunCommand(ctx, controller.cacheService, pipelineId, "GetRunCommand")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetRunCommandResponse{Command: command}, nil
+}
+
+// GetArchivedResult is returning status and outputs of the finished code processing for specific pipeline by PipelineUuid.
+// The result is read from the cache while the pipeline is kept there and from the archive after it is removed from the cache.
+func (controller *playgroundController) GetArchivedResult(ctx context.Context, info *pb.GetArchivedResultRequest) (*pb.GetArchivedResultResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetArchivedResult(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetArchivedResult", "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
+	}
+	result, err := code_processing.GetArchivedResult(ctx, controller.cacheService, controller.archiveStorage, pipelineId, "GetArchivedResult")
+	if err != nil {
+		return nil, err
+	}
+	exitCode := int32(-1)
+	if result.ExitCode != nil {
+		exitCode = int32(*result.ExitCode)
+	}
+	return &pb.GetArchivedResultResponse{
+		Status:        result.Status,
+		Output:        result.RunOutput,
+		CompileOutput: result.CompileOutput,
+		Graph:         result.Graph,
+		RunError:      result.RunError,
+		ExitCode:      exitCode,
+		CompileTimeMs: result.CompileTime.Milliseconds(),
+		RunTimeMs:     result.RunTime.Milliseconds(),
+	}, nil
+}
+
 // Cancel is setting cancel flag to stop code processing
+// - In case pipelineId doesn't exist in cache returns codes.NotFound
+// - In case the code processing is already finished returns codes.FailedPrecondition
 func (controller *playgroundController) Cancel(ctx context.Context, info *pb.CancelRequest) (*pb.CancelResponse, error) {
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	if err != nil {
 		logger.Errorf("%s: Cancel(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError("Cancel", "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
 	}
-	if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.Canceled, true); err != nil {
-		return nil, errors.InternalError("Cancel", "error during set cancel flag to cache")
+	if _, ok := pb.CancelPhase_name[int32(info.Phase)]; !ok {
+		logger.Errorf("%s: Cancel(): incorrect phase: %d", info.PipelineUuid, info.Phase)
+		return nil, errors.InvalidArgumentError("Cancel", "incorrect phase: %d", info.Phase)
+	}
+	if err := code_processing.CancelPipeline(ctx, controller.cacheService, pipelineId, info.Phase); err != nil {
+		return nil, err
 	}
 	return &pb.CancelResponse{}, nil
 }
@@ -241,3 +623,27 @@ func (controller *playgroundController) GetPrecompiledObjectOutput(ctx context.C
 	response := pb.GetRunOutputResponse{Output: *output}
 	return &response, nil
 }
+
+// ListExamples returns the examples from the examples directory for the sdk. All examples are returned if sdk is unspecified.
+func (controller *playgroundController) ListExamples(ctx context.Context, info *pb.ListExamplesRequest) (*pb.ListExamplesResponse, error) {
+	examplesList, err := controller.examplesCatalog.ListExamples(ctx, info.Sdk)
+	if err != nil {
+		logger.Errorf("ListExamples(): error during reading examples: %s", err.Error())
+		return nil, errors.InternalError("ListExamples", "error during reading examples")
+	}
+	return &pb.ListExamplesResponse{Examples: examplesList}, nil
+}
+
+// GetExample returns the source code of the example from the examples directory
+// - In case the example doesn't exist returns codes.NotFound
+func (controller *playgroundController) GetExample(ctx context.Context, info *pb.GetExampleRequest) (*pb.GetExampleResponse, error) {
+	code, err := controller.examplesCatalog.GetExample(ctx, info.Sdk, info.Name)
+	if err == examples.ErrExampleNotFound {
+		return nil, errors.NotFoundError("GetExample", "example %s isn't found for %s", info.Name, info.Sdk.String())
+	}
+	if err != nil {
+		logger.Errorf("GetExample(): error during reading example %s: %s", info.Name, err.Error())
+		return nil, errors.InternalError("GetExample", "error during reading example")
+	}
+	return &pb.GetExampleResponse{Code: code}, nil
+}
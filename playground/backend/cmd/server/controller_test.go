@@ -16,22 +16,33 @@ package main
 
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/archive"
 	"beam.apache.org/playground/backend/internal/cache"
 	"beam.apache.org/playground/backend/internal/cache/local"
+	"beam.apache.org/playground/backend/internal/code_processing"
 	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/examples"
+	"beam.apache.org/playground/backend/internal/rate_limiter"
 	"context"
 	"fmt"
 	"github.com/google/uuid"
 	"go.uber.org/goleak"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"io"
 	"io/fs"
 	"log"
 	"net"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -42,10 +53,16 @@ const (
 	javaLogConfigFilename = "logging.properties"
 	baseFileFolder        = "executable_files"
 	configFolder          = "configs"
+	examplesFolder        = "examples"
+	archiveFolder         = "archive"
+	exampleName           = "MinimalWordCount"
+	exampleCode           = "class MinimalWordCount {}"
+	exampleMetaInfo       = "{\"description\": \"Minimal word count\", \"pipeline_options\": \"--output output.txt\"}"
 )
 
 var lis *bufconn.Listener
 var cacheService cache.Cache
+var archiveStorage archive.Storage
 var opt goleak.Option
 
 func TestMain(m *testing.M) {
@@ -71,6 +88,21 @@ func setup() *grpc.Server {
 		panic(err)
 	}
 
+	// create java example
+	exampleFolder := filepath.Join(examplesFolder, pb.Sdk_SDK_JAVA.String(), exampleName)
+	err = os.MkdirAll(exampleFolder, fs.ModePerm)
+	if err != nil {
+		panic(err)
+	}
+	err = os.WriteFile(filepath.Join(exampleFolder, exampleName+".java"), []byte(exampleCode), 0600)
+	if err != nil {
+		panic(err)
+	}
+	err = os.WriteFile(filepath.Join(exampleFolder, "meta.info"), []byte(exampleMetaInfo), 0600)
+	if err != nil {
+		panic(err)
+	}
+
 	// create log config file
 	_, err = os.Create(javaLogConfigFilename)
 	if err != nil {
@@ -80,6 +112,12 @@ func setup() *grpc.Server {
 	// setup cache
 	cacheService = local.New(context.Background())
 
+	// setup archive
+	archiveStorage, err = archive.NewFileStorage(archiveFolder)
+	if err != nil {
+		panic(err)
+	}
+
 	path, err := os.Getwd()
 	if err != nil {
 		panic(err)
@@ -99,13 +137,18 @@ func setup() *grpc.Server {
 	if err != nil {
 		panic(err)
 	}
-	sdkEnv, err := environment.ConfigureBeamEnvs(appEnv.WorkingDir())
+	sdkEnvs, err := environment.ConfigureBeamEnvs(appEnv.WorkingDir())
 	if err != nil {
 		panic(err)
 	}
 	pb.RegisterPlaygroundServiceServer(s, &playgroundController{
-		env:          environment.NewEnvironment(*networkEnv, *sdkEnv, *appEnv),
-		cacheService: cacheService,
+		env:               environment.NewEnvironment(*networkEnv, sdkEnvs, *appEnv),
+		cacheService:      cacheService,
+		workerPool:        code_processing.NewWorkerPool(appEnv.MaxConcurrentPipelines()),
+		examplesCatalog:   examples.New(examplesFolder, cacheService, time.Minute),
+		processingTracker: code_processing.NewProcessingTracker(context.Background(), cacheService),
+		idleSweeper:       code_processing.NewIdleSweeper(cacheService, 0),
+		archiveStorage:    archiveStorage,
 	})
 	go func() {
 		if err := s.Serve(lis); err != nil {
@@ -121,6 +164,8 @@ func teardown(server *grpc.Server) {
 	removeDir(configFolder)
 	removeDir(javaLogConfigFilename)
 	removeDir(baseFileFolder)
+	removeDir(examplesFolder)
+	removeDir(archiveFolder)
 }
 
 func removeDir(dir string) {
@@ -157,6 +202,34 @@ func TestPlaygroundController_RunCode(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			// Test case with calling RunCode method with JVM flag which isn't allowed.
+			// As a result, want to receive an error.
+			name: "RunCode with forbidden jvm flag",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:            "MOCK_CODE",
+					Sdk:             pb.Sdk_SDK_JAVA,
+					PipelineOptions: "-agentpath:/tmp/agent.so",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with example which isn't presented in the examples catalog.
+			// As a result, want to receive an error.
+			name: "RunCode with unknown example",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:        "MOCK_CODE",
+					Sdk:         pb.Sdk_SDK_JAVA,
+					ExampleName: "UnknownExample",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			// Test case with calling RunCode method with correct SDK.
 			// As a result, want to receive response with pipelineId and status into cache should be set as Status_STATUS_COMPILING.
@@ -196,16 +269,471 @@ func TestPlaygroundController_RunCode(t *testing.T) {
 					if err != nil {
 						t.Errorf("PlaygroundController_RunCode() status should exist")
 					}
+					sdk, err := cacheService.GetValue(tt.args.ctx, uuid.MustParse(response.PipelineUuid), cache.Sdk)
+					if err != nil || sdk != tt.args.request.Sdk {
+						t.Errorf("PlaygroundController_RunCode() sdk = %v, want %v", sdk, tt.args.request.Sdk)
+					}
+					if _, err := cacheService.GetValue(tt.args.ctx, uuid.MustParse(response.PipelineUuid), cache.StartTime); err != nil {
+						t.Errorf("PlaygroundController_RunCode() start time should exist")
+					}
 				}
 			}
 		})
 	}
 }
 
+func TestPlaygroundController_RunCodeWithRateLimit(t *testing.T) {
+	controller := &playgroundController{
+		env:         environment.NewEnvironment(environment.NetworkEnvs{}, map[pb.Sdk]*environment.BeamEnvs{pb.Sdk_SDK_JAVA: {ApacheBeamSdk: pb.Sdk_SDK_JAVA}}, environment.ApplicationEnvs{}),
+		rateLimiter: rate_limiter.New(1, 1),
+	}
+	firstClientCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1}})
+	secondClientCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 1}})
+	// the request with incorrect sdk is rejected before the code is saved, but it is counted by the rate limiter
+	request := &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_UNSPECIFIED}
+
+	if _, err := controller.RunCode(firstClientCtx, request); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("PlaygroundController_RunCode() error of the first request = %v, want code %s", err, codes.InvalidArgument)
+	}
+	if _, err := controller.RunCode(firstClientCtx, request); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("PlaygroundController_RunCode() error of the request over the limit = %v, want code %s", err, codes.ResourceExhausted)
+	}
+	if _, err := controller.RunCode(secondClientCtx, request); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("PlaygroundController_RunCode() error of the request of another client = %v, want code %s", err, codes.InvalidArgument)
+	}
+}
+
+func TestPlaygroundController_RunCodeWithClientQuota(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skipf("%s isn't installed", "python3")
+	}
+	workingDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(workingDir, configFolder), fs.ModePerm); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	config := "{\n  \"compile_cmd\": \"\",\n  \"run_cmd\": \"python3\",\n  \"compile_args\": [],\n  \"run_args\": []\n}"
+	if err := os.WriteFile(filepath.Join(workingDir, configFolder, pb.Sdk_SDK_PYTHON.String()+".json"), []byte(config), 0600); err != nil {
+		t.Fatalf("error during write config: %s", err.Error())
+	}
+	if err := os.Setenv("BEAM_SDK", pb.Sdk_SDK_PYTHON.String()); err != nil {
+		t.Fatalf("error during set env: %s", err.Error())
+	}
+	defer os.Setenv("BEAM_SDK", pb.Sdk_SDK_JAVA.String())
+	if err := os.Setenv("MAX_PIPELINES_PER_CLIENT", "1"); err != nil {
+		t.Fatalf("error during set env: %s", err.Error())
+	}
+	defer os.Unsetenv("MAX_PIPELINES_PER_CLIENT")
+	sdkEnvs, err := environment.ConfigureBeamEnvs(workingDir)
+	if err != nil {
+		t.Fatalf("ConfigureBeamEnvs() error = %v", err)
+	}
+	appEnv, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		t.Fatalf("GetApplicationEnvsFromOsEnvs() error = %v", err)
+	}
+	controller := &playgroundController{
+		env:               environment.NewEnvironment(environment.NetworkEnvs{}, sdkEnvs, *appEnv),
+		cacheService:      cacheService,
+		workerPool:        code_processing.NewWorkerPool(appEnv.MaxConcurrentPipelines()),
+		processingTracker: code_processing.NewProcessingTracker(context.Background(), cacheService),
+		idleSweeper:       code_processing.NewIdleSweeper(cacheService, 0),
+	}
+	firstClientCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.1.1"), Port: 1}})
+	secondClientCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.1.2"), Port: 1}})
+	// the code runs long enough to be in progress while the next requests are sent
+	request := &pb.RunCodeRequest{Code: "import time\ntime.sleep(1)\nprint('Hello from Python')\n", Sdk: pb.Sdk_SDK_PYTHON}
+	waitFinished := func(pipelineUuid string) {
+		pipelineId := uuid.MustParse(pipelineUuid)
+		processingStatus := pb.Status_STATUS_UNSPECIFIED
+		for i := 0; i < 600 && !code_processing.IsFinalStatus(processingStatus); i++ {
+			time.Sleep(100 * time.Millisecond)
+			value, _ := cacheService.GetValue(context.Background(), pipelineId, cache.Status)
+			processingStatus, _ = value.(pb.Status)
+		}
+		if processingStatus != pb.Status_STATUS_FINISHED {
+			t.Fatalf("PlaygroundController_RunCode() status = %s, want %s", processingStatus, pb.Status_STATUS_FINISHED)
+		}
+	}
+	pipelinesFolder := filepath.Join(appEnv.ExecutionRoot(), "executable_files")
+	countPipelineFolders := func() int {
+		entries, _ := os.ReadDir(pipelinesFolder)
+		return len(entries)
+	}
+
+	// Test case with calling RunCode method by the client which has no pipelines in progress.
+	// As a result, want to receive pipelineId.
+	firstResponse, err := controller.RunCode(firstClientCtx, request)
+	if err != nil {
+		t.Fatalf("PlaygroundController_RunCode() error = %v", err)
+	}
+
+	// Test case with calling RunCode method by the same client while its pipeline is in progress.
+	// As a result, want to receive codes.ResourceExhausted without creating the files of the pipeline.
+	pipelineFolders := countPipelineFolders()
+	if _, err := controller.RunCode(firstClientCtx, request); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("PlaygroundController_RunCode() error of the request over the quota = %v, want code %s", err, codes.ResourceExhausted)
+	}
+	if folders := countPipelineFolders(); folders > pipelineFolders {
+		t.Errorf("PlaygroundController_RunCode() created %d pipeline folders for the request over the quota, want 0", folders-pipelineFolders)
+	}
+
+	// Test case with calling RunCode method by another client while the pipeline of the first client is in progress.
+	// As a result, want to receive pipelineId since the quota of another client isn't affected.
+	secondResponse, err := controller.RunCode(secondClientCtx, request)
+	if err != nil {
+		t.Fatalf("PlaygroundController_RunCode() error of the request of another client = %v", err)
+	}
+
+	// Test case with calling RunCode method by the first client after its pipeline is finished.
+	// As a result, want to receive pipelineId since the slot of the finished pipeline is released.
+	waitFinished(firstResponse.PipelineUuid)
+	var nextResponse *pb.RunCodeResponse
+	// the slot is released right after the final status is saved
+	for i := 0; i < 50; i++ {
+		if nextResponse, err = controller.RunCode(firstClientCtx, request); status.Code(err) != codes.ResourceExhausted {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("PlaygroundController_RunCode() error of the request after the pipeline is finished = %v", err)
+	}
+	waitFinished(secondResponse.PipelineUuid)
+	waitFinished(nextResponse.PipelineUuid)
+}
+
+func TestPlaygroundController_RunCodeWithExecutionRoot(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skipf("%s isn't installed", "python3")
+	}
+	// the configs are kept in the working dir, the files of the code are created in the execution root
+	workingDir := t.TempDir()
+	executionRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(workingDir, configFolder), fs.ModePerm); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	config := "{\n  \"compile_cmd\": \"\",\n  \"run_cmd\": \"python3\",\n  \"compile_args\": [],\n  \"run_args\": []\n}"
+	if err := os.WriteFile(filepath.Join(workingDir, configFolder, pb.Sdk_SDK_PYTHON.String()+".json"), []byte(config), 0600); err != nil {
+		t.Fatalf("error during write config: %s", err.Error())
+	}
+	if err := os.Setenv("BEAM_SDK", pb.Sdk_SDK_PYTHON.String()); err != nil {
+		t.Fatalf("error during set env: %s", err.Error())
+	}
+	defer os.Setenv("BEAM_SDK", pb.Sdk_SDK_JAVA.String())
+	if err := os.Setenv("EXECUTION_ROOT", executionRoot); err != nil {
+		t.Fatalf("error during set env: %s", err.Error())
+	}
+	defer os.Unsetenv("EXECUTION_ROOT")
+	sdkEnvs, err := environment.ConfigureBeamEnvs(workingDir)
+	if err != nil {
+		t.Fatalf("ConfigureBeamEnvs() error = %v", err)
+	}
+	appEnv, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		t.Fatalf("GetApplicationEnvsFromOsEnvs() error = %v", err)
+	}
+	controller := &playgroundController{
+		env:               environment.NewEnvironment(environment.NetworkEnvs{}, sdkEnvs, *appEnv),
+		cacheService:      cacheService,
+		workerPool:        code_processing.NewWorkerPool(appEnv.MaxConcurrentPipelines()),
+		processingTracker: code_processing.NewProcessingTracker(context.Background(), cacheService),
+		idleSweeper:       code_processing.NewIdleSweeper(cacheService, 0),
+	}
+	ctx := context.Background()
+
+	// Test case with calling RunCode method on the server with the execution root which differs from the working dir.
+	// As a result, want to receive the code processed in the folder of the pipeline in the execution root.
+	response, err := controller.RunCode(ctx, &pb.RunCodeRequest{Code: "import os\nprint(os.getcwd())\n", Sdk: pb.Sdk_SDK_PYTHON})
+	if err != nil {
+		t.Fatalf("PlaygroundController_RunCode() error = %v", err)
+	}
+	pipelineId := uuid.MustParse(response.PipelineUuid)
+	processingStatus := pb.Status_STATUS_UNSPECIFIED
+	for i := 0; i < 600 && !code_processing.IsFinalStatus(processingStatus); i++ {
+		time.Sleep(100 * time.Millisecond)
+		value, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+		processingStatus, _ = value.(pb.Status)
+	}
+	if processingStatus != pb.Status_STATUS_FINISHED {
+		t.Fatalf("PlaygroundController_RunCode() status = %s, want %s", processingStatus, pb.Status_STATUS_FINISHED)
+	}
+	runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+	expectedRunOutput := filepath.Join(executionRoot, "executable_files", pipelineId.String()) + "\n"
+	if runOutput != expectedRunOutput {
+		t.Errorf("PlaygroundController_RunCode() run output = %q, want %q", runOutput, expectedRunOutput)
+	}
+}
+
+func TestPlaygroundController_RunCodeWithSeveralSdks(t *testing.T) {
+	for _, cmd := range []string{"go", "python3"} {
+		if _, err := exec.LookPath(cmd); err != nil {
+			t.Skipf("%s isn't installed", cmd)
+		}
+	}
+	// configs of Go and Python SDKs are loaded by one server
+	workingDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(workingDir, configFolder), fs.ModePerm); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	configs := map[pb.Sdk]string{
+		pb.Sdk_SDK_GO:     "{\n  \"compile_cmd\": \"go\",\n  \"run_cmd\": \"\",\n  \"compile_args\": [\"build\", \"-o\"],\n  \"run_args\": []\n}",
+		pb.Sdk_SDK_PYTHON: "{\n  \"compile_cmd\": \"\",\n  \"run_cmd\": \"python3\",\n  \"compile_args\": [],\n  \"run_args\": []\n}",
+	}
+	for sdk, config := range configs {
+		if err := os.WriteFile(filepath.Join(workingDir, configFolder, sdk.String()+".json"), []byte(config), 0600); err != nil {
+			t.Fatalf("error during write config: %s", err.Error())
+		}
+	}
+	preparedModDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(preparedModDir, "go.mod"), []byte("module playground\n\ngo 1.16\n"), 0600); err != nil {
+		t.Fatalf("error during write go.mod: %s", err.Error())
+	}
+	if err := os.WriteFile(filepath.Join(preparedModDir, "go.sum"), []byte{}, 0600); err != nil {
+		t.Fatalf("error during write go.sum: %s", err.Error())
+	}
+	if err := os.Setenv("BEAM_SDK", pb.Sdk_SDK_GO.String()+","+pb.Sdk_SDK_PYTHON.String()); err != nil {
+		t.Fatalf("error during set env: %s", err.Error())
+	}
+	defer os.Setenv("BEAM_SDK", pb.Sdk_SDK_JAVA.String())
+	if err := os.Setenv("PREPARED_MOD_DIR", preparedModDir); err != nil {
+		t.Fatalf("error during set env: %s", err.Error())
+	}
+	defer os.Unsetenv("PREPARED_MOD_DIR")
+	sdkEnvs, err := environment.ConfigureBeamEnvs(workingDir)
+	if err != nil {
+		t.Fatalf("ConfigureBeamEnvs() error = %v", err)
+	}
+	appEnv, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		t.Fatalf("GetApplicationEnvsFromOsEnvs() error = %v", err)
+	}
+	controller := &playgroundController{
+		env:               environment.NewEnvironment(environment.NetworkEnvs{}, sdkEnvs, *appEnv),
+		cacheService:      cacheService,
+		workerPool:        code_processing.NewWorkerPool(appEnv.MaxConcurrentPipelines()),
+		processingTracker: code_processing.NewProcessingTracker(context.Background(), cacheService),
+		idleSweeper:       code_processing.NewIdleSweeper(cacheService, 0),
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		request           *pb.RunCodeRequest
+		wantErr           bool
+		expectedRunOutput string
+		expectedRunLogs   string
+	}{
+		{
+			// Test case with calling RunCode method with Go code on the server which serves Go and Python SDKs.
+			// As a result, want to receive the code processed with the config of Go SDK.
+			name:              "go code",
+			request:           &pb.RunCodeRequest{Code: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello from Go\")\n}\n", Sdk: pb.Sdk_SDK_GO},
+			expectedRunOutput: "Hello from Go\n",
+		},
+		{
+			// Test case with calling RunCode method with Python code on the server which serves Go and Python SDKs.
+			// As a result, want to receive the code processed with the config of Python SDK.
+			name:              "python code",
+			request:           &pb.RunCodeRequest{Code: "print('Hello from Python')\n", Sdk: pb.Sdk_SDK_PYTHON},
+			expectedRunOutput: "Hello from Python\n",
+		},
+		{
+			// Test case with calling RunCode method with Java code on the server which serves Go and Python SDKs.
+			// As a result, want to receive an error.
+			name:    "java code",
+			request: &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with Go code which requests JSON logs.
+			// As a result, want to receive an error since JSON logs aren't supported for Go SDK.
+			name:    "go code with json logs",
+			request: &pb.RunCodeRequest{Code: "package main\n\nfunc main() {\n}\n", Sdk: pb.Sdk_SDK_GO, JsonLogs: true},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with Python code which requests JSON logs.
+			// As a result, want to receive the code processed and its logs printed as JSON lines.
+			name:              "python code with json logs",
+			request:           &pb.RunCodeRequest{Code: "import logging\nlogging.warning('Hello from logs')\nprint('Hello from Python')\n", Sdk: pb.Sdk_SDK_PYTHON, JsonLogs: true},
+			expectedRunOutput: "Hello from Python\n",
+			expectedRunLogs:   "\"message\": \"Hello from logs\"",
+		},
+		{
+			// Test case with calling RunCode method with Python code which file name is set.
+			// As a result, want to receive the code processed from the file with this name.
+			name:              "python code with file name",
+			request:           &pb.RunCodeRequest{Code: "import os\nprint(os.path.basename(__file__))\n", Sdk: pb.Sdk_SDK_PYTHON, FileName: "word_count"},
+			expectedRunOutput: "word_count.py\n",
+		},
+		{
+			// Test case with calling RunCode method with Python code which file name isn't a valid module name.
+			// As a result, want to receive an error.
+			name:    "python code with incorrect file name",
+			request: &pb.RunCodeRequest{Code: "print('Hello from Python')\n", Sdk: pb.Sdk_SDK_PYTHON, FileName: "word-count.py"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := controller.RunCode(ctx, tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlaygroundController_RunCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			pipelineId := uuid.MustParse(response.PipelineUuid)
+			processingStatus := pb.Status_STATUS_UNSPECIFIED
+			for i := 0; i < 600 && !code_processing.IsFinalStatus(processingStatus); i++ {
+				time.Sleep(100 * time.Millisecond)
+				value, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+				processingStatus, _ = value.(pb.Status)
+			}
+			if processingStatus != pb.Status_STATUS_FINISHED {
+				t.Fatalf("PlaygroundController_RunCode() status = %s, want %s", processingStatus, pb.Status_STATUS_FINISHED)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if runOutput != tt.expectedRunOutput {
+				t.Errorf("PlaygroundController_RunCode() run output = %q, want %q", runOutput, tt.expectedRunOutput)
+			}
+			runLogs, _ := cacheService.GetValue(ctx, pipelineId, cache.RunLogs)
+			if runLogsString, _ := runLogs.(string); !strings.Contains(runLogsString, tt.expectedRunLogs) {
+				t.Errorf("PlaygroundController_RunCode() run logs = %q, want them to contain %q", runLogs, tt.expectedRunLogs)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_RunCodeWithIdempotencyKey(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skipf("%s isn't installed", "python3")
+	}
+	workingDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(workingDir, configFolder), fs.ModePerm); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	config := "{\n  \"compile_cmd\": \"\",\n  \"run_cmd\": \"python3\",\n  \"compile_args\": [],\n  \"run_args\": []\n}"
+	if err := os.WriteFile(filepath.Join(workingDir, configFolder, pb.Sdk_SDK_PYTHON.String()+".json"), []byte(config), 0600); err != nil {
+		t.Fatalf("error during write config: %s", err.Error())
+	}
+	if err := os.Setenv("BEAM_SDK", pb.Sdk_SDK_PYTHON.String()); err != nil {
+		t.Fatalf("error during set env: %s", err.Error())
+	}
+	defer os.Setenv("BEAM_SDK", pb.Sdk_SDK_JAVA.String())
+	sdkEnvs, err := environment.ConfigureBeamEnvs(workingDir)
+	if err != nil {
+		t.Fatalf("ConfigureBeamEnvs() error = %v", err)
+	}
+	appEnv, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		t.Fatalf("GetApplicationEnvsFromOsEnvs() error = %v", err)
+	}
+	controller := &playgroundController{
+		env:               environment.NewEnvironment(environment.NetworkEnvs{}, sdkEnvs, *appEnv),
+		cacheService:      cacheService,
+		workerPool:        code_processing.NewWorkerPool(appEnv.MaxConcurrentPipelines()),
+		processingTracker: code_processing.NewProcessingTracker(context.Background(), cacheService),
+		idleSweeper:       code_processing.NewIdleSweeper(cacheService, 0),
+	}
+	ctx := context.Background()
+	// each execution of the code adds a line to the file, so the number of the executions could be counted
+	executionsFile := filepath.Join(t.TempDir(), "executions")
+	code := fmt.Sprintf("with open(%q, 'a') as f:\n    f.write('executed\\n')\nprint('Hello from Python')\n", executionsFile)
+	waitFinished := func(pipelineUuid string) {
+		pipelineId := uuid.MustParse(pipelineUuid)
+		processingStatus := pb.Status_STATUS_UNSPECIFIED
+		for i := 0; i < 600 && !code_processing.IsFinalStatus(processingStatus); i++ {
+			time.Sleep(100 * time.Millisecond)
+			value, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			processingStatus, _ = value.(pb.Status)
+		}
+		if processingStatus != pb.Status_STATUS_FINISHED {
+			t.Fatalf("PlaygroundController_RunCode() status = %s, want %s", processingStatus, pb.Status_STATUS_FINISHED)
+		}
+	}
+	countExecutions := func() int {
+		executions, err := os.ReadFile(executionsFile)
+		if err != nil {
+			t.Fatalf("error during read executions: %s", err.Error())
+		}
+		return strings.Count(string(executions), "executed")
+	}
+
+	// Test case with calling RunCode method several times concurrently with the same idempotency key.
+	// As a result, want to receive the same pipelineId for all requests and the code to be executed once.
+	request := &pb.RunCodeRequest{Code: code, Sdk: pb.Sdk_SDK_PYTHON, IdempotencyKey: uuid.New().String()}
+	requests := 10
+	pipelineUuids := make(chan string, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := controller.RunCode(ctx, request)
+			if err != nil {
+				t.Errorf("PlaygroundController_RunCode() error = %v", err)
+				return
+			}
+			pipelineUuids <- response.PipelineUuid
+		}()
+	}
+	wg.Wait()
+	close(pipelineUuids)
+	var pipelineUuid string
+	for receivedUuid := range pipelineUuids {
+		if pipelineUuid == "" {
+			pipelineUuid = receivedUuid
+		}
+		if receivedUuid != pipelineUuid {
+			t.Errorf("PlaygroundController_RunCode() pipelineId = %s, want %s", receivedUuid, pipelineUuid)
+		}
+	}
+	if pipelineUuid == "" {
+		t.Fatalf("PlaygroundController_RunCode() hasn't returned pipelineId")
+	}
+	waitFinished(pipelineUuid)
+	if executions := countExecutions(); executions != 1 {
+		t.Errorf("PlaygroundController_RunCode() executed the code %d times, want 1", executions)
+	}
+
+	// Test case with calling RunCode method again with the same idempotency key after the code processing is finished.
+	// As a result, want to receive pipelineId of the finished code processing without executing the code again.
+	response, err := controller.RunCode(ctx, request)
+	if err != nil {
+		t.Fatalf("PlaygroundController_RunCode() error = %v", err)
+	}
+	if response.PipelineUuid != pipelineUuid {
+		t.Errorf("PlaygroundController_RunCode() pipelineId = %s, want %s", response.PipelineUuid, pipelineUuid)
+	}
+
+	// Test case with calling RunCode method with the same idempotency key, but with other pipeline options.
+	// As a result, want to receive codes.InvalidArgument without executing the code again.
+	reusedKeyRequest := &pb.RunCodeRequest{Code: code, Sdk: pb.Sdk_SDK_PYTHON, PipelineOptions: "--name=other", IdempotencyKey: request.IdempotencyKey}
+	if _, err := controller.RunCode(ctx, reusedKeyRequest); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("PlaygroundController_RunCode() error = %v, want code %s", err, codes.InvalidArgument)
+	}
+
+	// Test case with calling RunCode method with another idempotency key.
+	// As a result, want to receive a new pipelineId and the code to be executed again.
+	response, err = controller.RunCode(ctx, &pb.RunCodeRequest{Code: code, Sdk: pb.Sdk_SDK_PYTHON, IdempotencyKey: uuid.New().String()})
+	if err != nil {
+		t.Fatalf("PlaygroundController_RunCode() error = %v", err)
+	}
+	if response.PipelineUuid == pipelineUuid {
+		t.Errorf("PlaygroundController_RunCode() pipelineId = %s, want a new one", response.PipelineUuid)
+	}
+	waitFinished(response.PipelineUuid)
+	if executions := countExecutions(); executions != 2 {
+		t.Errorf("PlaygroundController_RunCode() executed the code %d times, want 2", executions)
+	}
+}
+
 func TestPlaygroundController_CheckStatus(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	ctx := context.Background()
 	pipelineId := uuid.New()
+	timedPipelineId := uuid.New()
 	wantStatus := pb.Status_STATUS_FINISHED
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
@@ -219,11 +747,13 @@ func TestPlaygroundController_CheckStatus(t *testing.T) {
 		request *pb.CheckStatusRequest
 	}
 	tests := []struct {
-		name       string
-		prepare    func()
-		args       args
-		wantStatus *pb.Status
-		wantErr    bool
+		name              string
+		prepare           func()
+		args              args
+		wantStatus        *pb.Status
+		wantCompileTimeMs int64
+		wantRunTimeMs     int64
+		wantErr           bool
 	}{
 		{
 			// Test case with calling CheckStatus method with incorrect pipelineId.
@@ -263,6 +793,24 @@ func TestPlaygroundController_CheckStatus(t *testing.T) {
 			wantStatus: &wantStatus,
 			wantErr:    false,
 		},
+		{
+			// Test case with calling CheckStatus method with pipelineId which contains final status and durations of the steps.
+			// As a result, want to receive an expected status with durations of the steps.
+			name: "status with compile and run time",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, timedPipelineId, cache.Status, wantStatus)
+				_ = cacheService.SetValue(ctx, timedPipelineId, cache.CompileTime, 1500*time.Millisecond)
+				_ = cacheService.SetValue(ctx, timedPipelineId, cache.RunTime, 2*time.Second)
+			},
+			args: args{
+				ctx:     ctx,
+				request: &pb.CheckStatusRequest{PipelineUuid: timedPipelineId.String()},
+			},
+			wantStatus:        &wantStatus,
+			wantCompileTimeMs: 1500,
+			wantRunTimeMs:     2000,
+			wantErr:           false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -277,6 +825,9 @@ func TestPlaygroundController_CheckStatus(t *testing.T) {
 			if got != nil && !reflect.DeepEqual(got.Status, *tt.wantStatus) {
 				t.Errorf("PlaygroundController_CheckStatus() return status = %v, want status %v", got.Status, tt.wantStatus)
 			}
+			if got != nil && (got.CompileTimeMs != tt.wantCompileTimeMs || got.RunTimeMs != tt.wantRunTimeMs) {
+				t.Errorf("PlaygroundController_CheckStatus() return compile time = %v, run time = %v, want %v, %v", got.CompileTimeMs, got.RunTimeMs, tt.wantCompileTimeMs, tt.wantRunTimeMs)
+			}
 		})
 	}
 }
@@ -469,11 +1020,11 @@ func TestPlaygroundController_GetRunOutput(t *testing.T) {
 	}
 }
 
-func TestPlaygroundController_GetLogs(t *testing.T) {
+func TestPlaygroundController_GetRunOutputStream(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	ctx := context.Background()
 	pipelineId := uuid.New()
-	logs := "MOCK_LOGS"
+	finishedPipelineId := uuid.New()
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatalf("Failed to dial bufnet: %v", err)
@@ -483,95 +1034,325 @@ func TestPlaygroundController_GetLogs(t *testing.T) {
 
 	type args struct {
 		ctx  context.Context
-		info *pb.GetLogsRequest
+		info *pb.GetRunOutputRequest
 	}
 	tests := []struct {
 		name    string
 		prepare func()
 		args    args
-		want    *pb.GetLogsResponse
+		want    string
 		wantErr bool
 	}{
 		{
-			// Test case with calling GetLogs method with incorrect pipelineId.
-			// As a result, want to receive an error
+			// Test case with calling GetRunOutputStream method with incorrect pipelineId.
+			// As a result, want to receive an error.
 			name:    "incorrect pipelineId",
 			prepare: func() {},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetLogsRequest{PipelineUuid: "NO_UUID_STRING"},
+				info: &pb.GetRunOutputRequest{PipelineUuid: "NO_UUID_STRING"},
 			},
-			want:    nil,
+			want:    "",
 			wantErr: true,
 		},
 		{
-			// Test case with calling GetRLogs method with pipelineId which doesn't exist.
+			// Test case with calling GetRunOutputStream method with pipelineId which doesn't exist.
 			// As a result, want to receive an error.
 			name:    "pipelineId doesn't exist",
 			prepare: func() {},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
-			},
-			want:    nil,
-			wantErr: true,
-		},
-		{
-			// Test case with calling GetLogs method with pipelineId which doesn't contain logs.
-			// As a result, want to receive an error.
-			name: "logs don't exist",
-			prepare: func() {
-				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
-			},
-			args: args{
-				ctx:  ctx,
-				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String()},
 			},
-			want:    nil,
+			want:    "",
 			wantErr: true,
 		},
 		{
-			// Test case with calling GetLogs method with pipelineId which contains logs.
-			// As a result want to receive response with an expected logs.
-			name: "logs exist",
+			// Test case with calling GetRunOutputStream method with pipelineId which is finished without run output.
+			// As a result, want to receive the stream which is closed without any output.
+			name: "finished without run output",
 			prepare: func() {
-				_ = cacheService.SetValue(ctx, pipelineId, cache.LogsIndex, 0)
-				_ = cacheService.SetValue(ctx, pipelineId, cache.Logs, logs)
+				_ = cacheService.SetValue(ctx, finishedPipelineId, cache.RunOutputIndex, 0)
+				_ = cacheService.SetValue(ctx, finishedPipelineId, cache.Status, pb.Status_STATUS_COMPILE_ERROR)
 			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetRunOutputRequest{PipelineUuid: finishedPipelineId.String()},
 			},
-			want:    &pb.GetLogsResponse{Output: logs},
+			want:    "",
 			wantErr: false,
 		},
 		{
-			// Test case with calling GetLogs method with pipelineId which contain logs and index of logs is 1.
-			// As a result want to receive response with correct logs (logs[1:]).
-			name: "get the second part",
+			// Test case with calling GetRunOutputStream method with pipelineId which run output is written during
+			// the streaming and the first part of which has been already received by GetRunOutput (index of run output is 5).
+			// As a result, want to receive the whole run output and the stream which is closed after
+			// the code processing is finished.
+			name: "run output is written during streaming",
 			prepare: func() {
-				_ = cacheService.SetValue(ctx, pipelineId, cache.LogsIndex, 1)
-				_ = cacheService.SetValue(ctx, pipelineId, cache.Logs, logs)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 5)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT\n")
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+				go func() {
+					time.Sleep(2 * streamPauseDuration)
+					_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT\nMOCK_NEXT_RUN_OUTPUT\n")
+					time.Sleep(2 * streamPauseDuration)
+					_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+				}()
 			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String()},
 			},
-			want:    &pb.GetLogsResponse{Output: logs[1:]},
+			want:    "MOCK_RUN_OUTPUT\nMOCK_NEXT_RUN_OUTPUT\n",
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.prepare()
-			got, err := client.GetLogs(tt.args.ctx, tt.args.info)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("GetLogs() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			stream, err := client.GetRunOutputStream(tt.args.ctx, tt.args.info)
+			if err != nil {
+				t.Fatalf("GetRunOutputStream() error = %v", err)
 			}
-			if !tt.wantErr {
-				if !strings.EqualFold(got.Output, tt.want.Output) {
-					t.Errorf("GetLogs() got = %v, want %v", got.Output, tt.want.Output)
+			got := ""
+			for {
+				response, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					if !tt.wantErr {
+						t.Errorf("GetRunOutputStream() error = %v, wantErr %v", err, tt.wantErr)
+					}
+					return
+				}
+				got += response.Output
+			}
+			if tt.wantErr {
+				t.Errorf("GetRunOutputStream() error = nil, wantErr %v", tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetRunOutputStream() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetCompileOutputStream(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	compiledPipelineId := uuid.New()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetCompileOutputRequest
+	}
+	tests := []struct {
+		name       string
+		prepare    func()
+		args       args
+		want       string
+		wantStatus pb.Status
+		wantErr    bool
+	}{
+		{
+			// Test case with calling GetCompileOutputStream method with incorrect pipelineId.
+			// As a result, want to receive an error.
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileOutputRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetCompileOutputStream method with pipelineId which is already compiled.
+			// As a result, want to receive the status after the compile step and the stream which is closed without any output.
+			name: "already compiled",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, compiledPipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileOutputRequest{PipelineUuid: compiledPipelineId.String()},
+			},
+			want:       "",
+			wantStatus: pb.Status_STATUS_EXECUTING,
+			wantErr:    false,
+		},
+		{
+			// Test case with calling GetCompileOutputStream method with pipelineId which compile errors are written during the streaming.
+			// As a result, want to receive all compile errors and the stream which is closed after the compile step is failed.
+			name: "compile output is written during streaming",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.CompileOutputStreamIndex, 0)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.CompileOutputStream, "MOCK_COMPILE_ERROR\n")
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_COMPILING)
+				go func() {
+					time.Sleep(2 * streamPauseDuration)
+					_ = cacheService.SetValue(ctx, pipelineId, cache.CompileOutputStream, "MOCK_COMPILE_ERROR\nMOCK_NEXT_COMPILE_ERROR\n")
+					time.Sleep(2 * streamPauseDuration)
+					_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_COMPILE_ERROR)
+				}()
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileOutputRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:       "MOCK_COMPILE_ERROR\nMOCK_NEXT_COMPILE_ERROR\n",
+			wantStatus: pb.Status_STATUS_COMPILE_ERROR,
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			stream, err := client.GetCompileOutputStream(tt.args.ctx, tt.args.info)
+			if err != nil {
+				t.Fatalf("GetCompileOutputStream() error = %v", err)
+			}
+			got := ""
+			gotStatus := pb.Status_STATUS_UNSPECIFIED
+			for {
+				response, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					if !tt.wantErr {
+						t.Errorf("GetCompileOutputStream() error = %v, wantErr %v", err, tt.wantErr)
+					}
+					return
+				}
+				got += response.Output
+				gotStatus = response.CompilationStatus
+			}
+			if tt.wantErr {
+				t.Errorf("GetCompileOutputStream() error = nil, wantErr %v", tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetCompileOutputStream() got = %v, want %v", got, tt.want)
+			}
+			if gotStatus != tt.wantStatus {
+				t.Errorf("GetCompileOutputStream() got status = %v, want %v", gotStatus, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetLogs(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	logs := "MOCK_LOGS"
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetLogsRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetLogsResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetLogs method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetLogsRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRLogs method with pipelineId which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "pipelineId doesn't exist",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetLogs method with pipelineId which doesn't contain logs.
+			// As a result, want to receive an error.
+			name: "logs don't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetLogs method with pipelineId which contains logs.
+			// As a result want to receive response with an expected logs.
+			name: "logs exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.LogsIndex, 0)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Logs, logs)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetLogsResponse{Output: logs},
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetLogs method with pipelineId which contain logs and index of logs is 1.
+			// As a result want to receive response with correct logs (logs[1:]).
+			name: "get the second part",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.LogsIndex, 1)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Logs, logs)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetLogsResponse{Output: logs[1:]},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetLogs(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLogs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				if !strings.EqualFold(got.Output, tt.want.Output) {
+					t.Errorf("GetLogs() got = %v, want %v", got.Output, tt.want.Output)
 				}
 			}
 		})
@@ -671,10 +1452,91 @@ func TestPlaygroundController_GetRunError(t *testing.T) {
 	}
 }
 
-func TestPlaygroundController_Cancel(t *testing.T) {
+func TestPlaygroundController_GetRunCommand(t *testing.T) {
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	command := "HOME=/root java -cp bin: HelloWorld"
+	if err := cacheService.SetValue(ctx, pipelineId, cache.RunCommand, command); err != nil {
+		t.Fatalf("error during prepare cache: %s", err.Error())
+	}
+	if err := os.Setenv("DEBUG_MODE", "true"); err != nil {
+		t.Fatalf("error during set env: %s", err.Error())
+	}
+	debugAppEnv, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		t.Fatalf("error during get application envs: %s", err.Error())
+	}
+	if err := os.Unsetenv("DEBUG_MODE"); err != nil {
+		t.Fatalf("error during unset env: %s", err.Error())
+	}
+
+	tests := []struct {
+		name      string
+		debugMode bool
+		info      *pb.GetRunCommandRequest
+		want      string
+		wantCode  codes.Code
+	}{
+		{
+			// Test case with calling GetRunCommand method when the server isn't run in the debug mode.
+			// As a result, want to receive PermissionDenied error.
+			name:      "debug mode is disabled",
+			debugMode: false,
+			info:      &pb.GetRunCommandRequest{PipelineUuid: pipelineId.String()},
+			wantCode:  codes.PermissionDenied,
+		},
+		{
+			// Test case with calling GetRunCommand method with incorrect pipelineId.
+			// As a result, want to receive InvalidArgument error.
+			name:      "incorrect pipelineId",
+			debugMode: true,
+			info:      &pb.GetRunCommandRequest{PipelineUuid: "NO_UUID_STRING"},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			// Test case with calling GetRunCommand method with pipelineId which doesn't exist.
+			// As a result, want to receive NotFound error.
+			name:      "pipelineId doesn't exist",
+			debugMode: true,
+			info:      &pb.GetRunCommandRequest{PipelineUuid: uuid.New().String()},
+			wantCode:  codes.NotFound,
+		},
+		{
+			// Test case with calling GetRunCommand method with pipelineId which contains the run command.
+			// As a result, want to receive the run command.
+			name:      "run command exists",
+			debugMode: true,
+			info:      &pb.GetRunCommandRequest{PipelineUuid: pipelineId.String()},
+			want:      command,
+			wantCode:  codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appEnv := environment.ApplicationEnvs{}
+			if tt.debugMode {
+				appEnv = *debugAppEnv
+			}
+			controller := &playgroundController{
+				env:          environment.NewEnvironment(environment.NetworkEnvs{}, nil, appEnv),
+				cacheService: cacheService,
+			}
+			got, err := controller.GetRunCommand(ctx, tt.info)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("GetRunCommand() error = %v, want code %s", err, tt.wantCode)
+			}
+			if err == nil && got.Command != tt.want {
+				t.Errorf("GetRunCommand() got = %s, want %s", got.Command, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetGraph(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	ctx := context.Background()
 	pipelineId := uuid.New()
+	graph := "digraph {\n}\n"
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatalf("Failed to dial bufnet: %v", err)
@@ -684,55 +1546,1026 @@ func TestPlaygroundController_Cancel(t *testing.T) {
 
 	type args struct {
 		ctx  context.Context
-		info *pb.CancelRequest
+		info *pb.GetGraphRequest
 	}
 	tests := []struct {
-		name      string
-		args      args
-		want      *pb.CancelResponse
-		checkFunc func() bool
-		wantErr   bool
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetGraphResponse
+		wantErr bool
 	}{
 		{
-			// Test case with calling Cancel method with incorrect pipelineId.
+			// Test case with calling GetGraph method with incorrect pipelineId.
 			// As a result, want to receive an error
-			name: "incorrect pipelineId",
+			name:    "incorrect pipelineId",
+			prepare: func() {},
 			args: args{
 				ctx:  ctx,
-				info: &pb.CancelRequest{PipelineUuid: "NO_UUID_STRING"},
+				info: &pb.GetGraphRequest{PipelineUuid: "NO_UUID_STRING"},
 			},
-			checkFunc: func() bool {
-				return true
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetGraph method with pipelineId which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "pipelineId doesn't exist",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetGraphRequest{PipelineUuid: pipelineId.String()},
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			// Test case with calling Cancel method.
-			// As a result, want to find value in cache for cache.Canceled subKey.
-			name: "set cancel without error",
+			// Test case with calling GetGraph method with pipelineId which doesn't contain graph.
+			// As a result, want to receive an error.
+			name: "graph doesn't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.CancelRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetGraphRequest{PipelineUuid: pipelineId.String()},
 			},
-			checkFunc: func() bool {
-				value, err := cacheService.GetValue(context.Background(), pipelineId, cache.Canceled)
-				if err != nil {
-					return false
-				}
-				return value.(bool)
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetGraph method with pipelineId which contains graph.
+			// As a result want to receive response with an expected graph.
+			name: "graph exists",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Graph, graph)
 			},
-			want:    &pb.CancelResponse{},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetGraphRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetGraphResponse{Graph: graph},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if _, err := client.Cancel(tt.args.ctx, tt.args.info); (err != nil) != tt.wantErr {
-				t.Errorf("Cancel() error = %v, wantErr %v", err, tt.wantErr)
-			}
-			if !tt.checkFunc() {
-				t.Error("Cancel() doesn't set canceled flag")
+			tt.prepare()
+			got, err := client.GetGraph(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetGraph() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				if !strings.EqualFold(got.Graph, tt.want.Graph) {
+					t.Errorf("GetGraph() got = %v, want %v", got.Graph, tt.want.Graph)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetArchivedResult(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	archivedPipelineId := uuid.New()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetArchivedResultRequest
+	}
+	tests := []struct {
+		name     string
+		prepare  func()
+		args     args
+		want     *pb.GetArchivedResultResponse
+		wantCode codes.Code
+	}{
+		{
+			// Test case with calling GetArchivedResult method with incorrect pipelineId.
+			// As a result, want to receive an error.
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetArchivedResultRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:     nil,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			// Test case with calling GetArchivedResult method with pipelineId which doesn't exist neither in the cache nor in the archive.
+			// As a result, want to receive an error.
+			name:    "pipelineId doesn't exist",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetArchivedResultRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:     nil,
+			wantCode: codes.NotFound,
+		},
+		{
+			// Test case with calling GetArchivedResult method with pipelineId which is still processing.
+			// As a result, want to receive an error.
+			name: "code processing isn't finished",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetArchivedResultRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:     nil,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			// Test case with calling GetArchivedResult method with pipelineId which is finished and kept in the cache.
+			// As a result, want to receive the result from the cache.
+			name: "finished code processing in the cache",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT")
+				_ = cacheService.SetValue(ctx, pipelineId, cache.CompileOutput, "MOCK_COMPILE_OUTPUT")
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Graph, "MOCK_GRAPH")
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunExitCode, 0)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.CompileTime, 2*time.Second)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunTime, 3*time.Second)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetArchivedResultRequest{PipelineUuid: pipelineId.String()},
+			},
+			want: &pb.GetArchivedResultResponse{
+				Status:        pb.Status_STATUS_FINISHED,
+				Output:        "MOCK_RUN_OUTPUT",
+				CompileOutput: "MOCK_COMPILE_OUTPUT",
+				Graph:         "MOCK_GRAPH",
+				ExitCode:      0,
+				CompileTimeMs: 2000,
+				RunTimeMs:     3000,
+			},
+		},
+		{
+			// Test case with calling GetArchivedResult method with pipelineId which is removed from the cache but kept in the archive.
+			// As a result, want to receive the result from the archive.
+			name: "finished code processing in the archive",
+			prepare: func() {
+				_ = archive.Archive(ctx, archiveStorage, archivedPipelineId.String(), &archive.Result{
+					Status:    pb.Status_STATUS_FINISHED,
+					RunOutput: "MOCK_ARCHIVED_RUN_OUTPUT",
+				})
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetArchivedResultRequest{PipelineUuid: archivedPipelineId.String()},
+			},
+			want: &pb.GetArchivedResultResponse{
+				Status:   pb.Status_STATUS_FINISHED,
+				Output:   "MOCK_ARCHIVED_RUN_OUTPUT",
+				ExitCode: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetArchivedResult(tt.args.ctx, tt.args.info)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("GetArchivedResult() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			if tt.want != nil && !proto.Equal(got, tt.want) {
+				t.Errorf("GetArchivedResult() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetCompileErrors(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	compileErrors := []*pb.CompileError{{File: "MOCK_FILE", Line: 1, Column: 1, Severity: "error", Message: "MOCK_MESSAGE"}}
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetCompileErrorsRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetCompileErrorsResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetCompileErrors method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileErrorsRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetCompileErrors method with pipelineId which doesn't contain compile errors.
+			// As a result, want to receive an error.
+			name: "compile errors don't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_COMPILING)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileErrorsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetCompileErrors method with pipelineId which contains compile errors.
+			// As a result want to receive response with expected compile errors.
+			name: "compile errors exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.CompileErrors, compileErrors)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileErrorsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetCompileErrorsResponse{CompileErrors: compileErrors},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetCompileErrors(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetCompileErrors() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !proto.Equal(got, tt.want) {
+				t.Errorf("GetCompileErrors() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetCompileWarnings(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	compileWarnings := []*pb.CompileError{{File: "MOCK_FILE", Line: 1, Column: 1, Severity: "warning", Message: "MOCK_MESSAGE"}}
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetCompileWarningsRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetCompileWarningsResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetCompileWarnings method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileWarningsRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetCompileWarnings method with pipelineId which doesn't contain compile warnings.
+			// As a result, want to receive an error.
+			name: "compile warnings don't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_COMPILING)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileWarningsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetCompileWarnings method with pipelineId which contains compile warnings.
+			// As a result want to receive response with expected compile warnings.
+			name: "compile warnings exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.CompileWarnings, compileWarnings)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileWarningsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetCompileWarningsResponse{CompileWarnings: compileWarnings},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetCompileWarnings(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetCompileWarnings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !proto.Equal(got, tt.want) {
+				t.Errorf("GetCompileWarnings() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetWarnings(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	warnings := []string{"MOCK_WARNING"}
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetWarningsRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetWarningsResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetWarnings method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetWarningsRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetWarnings method with pipelineId which isn't validated yet.
+			// As a result, want to receive an error.
+			name: "warnings don't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_VALIDATING)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetWarningsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetWarnings method with pipelineId which contains warnings.
+			// As a result want to receive response with expected warnings.
+			name: "warnings exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Warnings, warnings)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetWarningsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetWarningsResponse{Warnings: warnings},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetWarnings(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetWarnings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !proto.Equal(got, tt.want) {
+				t.Errorf("GetWarnings() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetPipelineSnapshot(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetPipelineSnapshotRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetPipelineSnapshotResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetPipelineSnapshot method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetPipelineSnapshotRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetPipelineSnapshot method with pipelineId which doesn't exist in cache.
+			// As a result, want to receive an error.
+			name:    "pipeline doesn't exist",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetPipelineSnapshotRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetPipelineSnapshot method with pipelineId which contains status and run output.
+			// As a result want to receive response with expected status and run output.
+			name: "pipeline exists",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT")
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetPipelineSnapshotRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetPipelineSnapshotResponse{Status: pb.Status_STATUS_EXECUTING, RunOutput: "MOCK_RUN_OUTPUT"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetPipelineSnapshot(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetPipelineSnapshot() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !proto.Equal(got, tt.want) {
+				t.Errorf("GetPipelineSnapshot() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetTestResults(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	testResults := []*pb.TestCase{{Name: "MOCK_NAME", Passed: false, FailureMessage: "MOCK_MESSAGE"}}
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetTestResultsRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetTestResultsResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetTestResults method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetTestResultsRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetTestResults method with pipelineId which doesn't contain test results.
+			// As a result, want to receive an error.
+			name: "test results don't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetTestResultsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetTestResults method with pipelineId which contains test results.
+			// As a result want to receive response with expected test results.
+			name: "test results exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.TestResults, testResults)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetTestResultsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetTestResultsResponse{TestResults: testResults},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetTestResults(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTestResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !proto.Equal(got, tt.want) {
+				t.Errorf("GetTestResults() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetPipelineMetrics(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	pipelineMetrics := []*pb.PipelineMetric{{Namespace: "MOCK_NAMESPACE", Name: "MOCK_NAME", Type: pb.PipelineMetricType_PIPELINE_METRIC_TYPE_COUNTER, Value: 1}}
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetPipelineMetricsRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetPipelineMetricsResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetPipelineMetrics method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetPipelineMetricsRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetPipelineMetrics method with pipelineId which doesn't contain pipeline metrics.
+			// As a result, want to receive an error.
+			name: "pipeline metrics don't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetPipelineMetricsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetPipelineMetrics method with pipelineId which contains pipeline metrics.
+			// As a result want to receive response with expected pipeline metrics.
+			name: "pipeline metrics exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.PipelineMetrics, pipelineMetrics)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetPipelineMetricsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetPipelineMetricsResponse{Metrics: pipelineMetrics},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetPipelineMetrics(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetPipelineMetrics() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !proto.Equal(got, tt.want) {
+				t.Errorf("GetPipelineMetrics() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetRunExitCode(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetRunExitCodeRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetRunExitCodeResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetRunExitCode method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunExitCodeRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRunExitCode method with pipelineId which doesn't contain exit code.
+			// As a result, want to receive an error.
+			name: "exit code doesn't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunExitCodeRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRunExitCode method with pipelineId which contains exit code.
+			// As a result want to receive response with expected exit code.
+			name: "exit code exists",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunExitCode, 137)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunExitCodeRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetRunExitCodeResponse{ExitCode: 137},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetRunExitCode(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetRunExitCode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !proto.Equal(got, tt.want) {
+				t.Errorf("GetRunExitCode() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_Cancel(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	finishedPipelineId := uuid.New()
+	_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+	_ = cacheService.SetValue(ctx, finishedPipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.CancelRequest
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      *pb.CancelResponse
+		checkFunc func() bool
+		wantErr   bool
+		wantCode  codes.Code
+	}{
+		{
+			// Test case with calling Cancel method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name: "incorrect pipelineId",
+			args: args{
+				ctx:  ctx,
+				info: &pb.CancelRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			checkFunc: func() bool {
+				return true
+			},
+			want:     nil,
+			wantErr:  true,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			// Test case with calling Cancel method with pipelineId which doesn't exist in cache.
+			// As a result, want to receive NotFound error.
+			name: "unknown pipelineId",
+			args: args{
+				ctx:  ctx,
+				info: &pb.CancelRequest{PipelineUuid: uuid.New().String()},
+			},
+			checkFunc: func() bool {
+				return true
+			},
+			want:     nil,
+			wantErr:  true,
+			wantCode: codes.NotFound,
+		},
+		{
+			// Test case with calling Cancel method with pipelineId of the finished code processing.
+			// As a result, want to receive FailedPrecondition error and no value in cache for cache.Canceled subKey.
+			name: "finished pipeline",
+			args: args{
+				ctx:  ctx,
+				info: &pb.CancelRequest{PipelineUuid: finishedPipelineId.String()},
+			},
+			checkFunc: func() bool {
+				_, err := cacheService.GetValue(context.Background(), finishedPipelineId, cache.Canceled)
+				return err != nil
+			},
+			want:     nil,
+			wantErr:  true,
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			// Test case with calling Cancel method with the phase which doesn't exist.
+			// As a result, want to receive InvalidArgument error.
+			name: "incorrect phase",
+			args: args{
+				ctx:  ctx,
+				info: &pb.CancelRequest{PipelineUuid: pipelineId.String(), Phase: pb.CancelPhase(10)},
+			},
+			checkFunc: func() bool {
+				_, err := cacheService.GetValue(context.Background(), pipelineId, cache.CanceledPhase)
+				return err != nil
+			},
+			want:     nil,
+			wantErr:  true,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			// Test case with calling Cancel method with the compile phase of the code which is already running.
+			// As a result, want to receive FailedPrecondition error and no value in cache for cache.CanceledPhase subKey.
+			name: "compile phase of the running code",
+			args: args{
+				ctx:  ctx,
+				info: &pb.CancelRequest{PipelineUuid: pipelineId.String(), Phase: pb.CancelPhase_CANCEL_PHASE_COMPILE},
+			},
+			checkFunc: func() bool {
+				_, err := cacheService.GetValue(context.Background(), pipelineId, cache.CanceledPhase)
+				return err != nil
+			},
+			want:     nil,
+			wantErr:  true,
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			// Test case with calling Cancel method with the run phase of the running code processing.
+			// As a result, want to find the run phase in cache for cache.CanceledPhase subKey.
+			name: "set cancel of the run phase without error",
+			args: args{
+				ctx:  ctx,
+				info: &pb.CancelRequest{PipelineUuid: pipelineId.String(), Phase: pb.CancelPhase_CANCEL_PHASE_RUN},
+			},
+			checkFunc: func() bool {
+				value, err := cacheService.GetValue(context.Background(), pipelineId, cache.CanceledPhase)
+				return err == nil && value == pb.CancelPhase_CANCEL_PHASE_RUN
+			},
+			want:    &pb.CancelResponse{},
+			wantErr: false,
+		},
+		{
+			// Test case with calling Cancel method with pipelineId of the running code processing.
+			// As a result, want to find value in cache for cache.Canceled subKey.
+			name: "set cancel without error",
+			args: args{
+				ctx:  ctx,
+				info: &pb.CancelRequest{PipelineUuid: pipelineId.String()},
+			},
+			checkFunc: func() bool {
+				value, err := cacheService.GetValue(context.Background(), pipelineId, cache.Canceled)
+				if err != nil {
+					return false
+				}
+				return value.(bool)
+			},
+			want:    &pb.CancelResponse{},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Cancel(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Cancel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && status.Code(err) != tt.wantCode {
+				t.Errorf("Cancel() error code = %s, want %s", status.Code(err), tt.wantCode)
+			}
+			if !tt.checkFunc() {
+				t.Error("Cancel() doesn't set canceled flag as expected")
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_ListExamples(t *testing.T) {
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	javaExample := &pb.Example{
+		Name:            exampleName,
+		Description:     "Minimal word count",
+		Sdk:             pb.Sdk_SDK_JAVA,
+		PipelineOptions: "--output output.txt",
+	}
+	tests := []struct {
+		name    string
+		info    *pb.ListExamplesRequest
+		want    []*pb.Example
+		wantErr bool
+	}{
+		{
+			// Test case with calling ListExamples method without sdk.
+			// As a result, want to receive all examples.
+			name:    "all examples",
+			info:    &pb.ListExamplesRequest{},
+			want:    []*pb.Example{javaExample},
+			wantErr: false,
+		},
+		{
+			// Test case with calling ListExamples method with sdk which has examples.
+			// As a result, want to receive examples of this sdk.
+			name:    "java examples",
+			info:    &pb.ListExamplesRequest{Sdk: pb.Sdk_SDK_JAVA},
+			want:    []*pb.Example{javaExample},
+			wantErr: false,
+		},
+		{
+			// Test case with calling ListExamples method with sdk which doesn't have examples.
+			// As a result, want to receive an empty list.
+			name:    "go examples",
+			info:    &pb.ListExamplesRequest{Sdk: pb.Sdk_SDK_GO},
+			want:    []*pb.Example{},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ListExamples(ctx, tt.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListExamples() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got.Examples) != len(tt.want) {
+				t.Errorf("ListExamples() got = %v, want %v", got.Examples, tt.want)
+				return
+			}
+			for i := range tt.want {
+				if !proto.Equal(got.Examples[i], tt.want[i]) {
+					t.Errorf("ListExamples() got = %v, want %v", got.Examples[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetExample(t *testing.T) {
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	tests := []struct {
+		name     string
+		info     *pb.GetExampleRequest
+		want     string
+		wantCode codes.Code
+	}{
+		{
+			// Test case with calling GetExample method with existing example.
+			// As a result, want to receive the source code of the example.
+			name:     "existing example",
+			info:     &pb.GetExampleRequest{Sdk: pb.Sdk_SDK_JAVA, Name: exampleName},
+			want:     exampleCode,
+			wantCode: codes.OK,
+		},
+		{
+			// Test case with calling GetExample method with example which doesn't exist for the sdk.
+			// As a result, want to receive codes.NotFound.
+			name:     "example of another sdk",
+			info:     &pb.GetExampleRequest{Sdk: pb.Sdk_SDK_GO, Name: exampleName},
+			wantCode: codes.NotFound,
+		},
+		{
+			// Test case with calling GetExample method with path instead of the example name.
+			// As a result, want to receive codes.NotFound.
+			name:     "path as example name",
+			info:     &pb.GetExampleRequest{Sdk: pb.Sdk_SDK_JAVA, Name: "../../" + configFolder},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetExample(ctx, tt.info)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("GetExample() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			if err == nil && got.Code != tt.want {
+				t.Errorf("GetExample() got = %v, want %v", got.Code, tt.want)
 			}
 		})
 	}
@@ -17,19 +17,46 @@ package main
 
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/archive"
 	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/cache/encrypted"
+	"beam.apache.org/playground/backend/internal/cache/file"
 	"beam.apache.org/playground/backend/internal/cache/local"
+	cacheMetrics "beam.apache.org/playground/backend/internal/cache/metrics"
 	"beam.apache.org/playground/backend/internal/cache/redis"
+	"beam.apache.org/playground/backend/internal/code_processing"
 	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/examples"
+	"beam.apache.org/playground/backend/internal/executors"
+	"beam.apache.org/playground/backend/internal/health"
 	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/metrics"
+	"beam.apache.org/playground/backend/internal/rate_limiter"
 	"context"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"google.golang.org/grpc"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 )
 
-// runServer is starting http server wrapped on grpc
+// shutdownGracePeriod is the time which is given to the running code processing to finish after the interrupt signal
+const shutdownGracePeriod = 30 * time.Second
+
+// jvmWarmPoolDir is the directory in the working directory where the launcher of the pre-started JVMs is compiled
+const jvmWarmPoolDir = "jvm_warm_pool"
+
+// compileCacheDir is the directory in the working directory where the compiled artifacts are kept to be reused
+const compileCacheDir = "compile_cache"
+
+// runServer is starting http server wrapped on grpc.
+// On SIGTERM or SIGINT the running code processing is canceled and the server waits until it is finished,
+//	but not longer than shutdownGracePeriod.
 func runServer() error {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
 	envService, err := setupEnvironment()
@@ -42,9 +69,33 @@ func runServer() error {
 	if err != nil {
 		return err
 	}
+	archiveStorage, err := archive.NewStorage(ctx, envService.ApplicationEnvs.ArchiveLocation())
+	if err != nil {
+		return err
+	}
+	jvmWarmPool, err := setupJvmWarmPool(envService)
+	if err != nil {
+		return err
+	}
+	defer jvmWarmPool.Close()
+	compileCache, err := code_processing.NewCompileCache(filepath.Join(envService.ApplicationEnvs.WorkingDir(), compileCacheDir), envService.ApplicationEnvs.CompileCacheSize())
+	if err != nil {
+		return err
+	}
+	processingTracker := code_processing.NewProcessingTracker(context.Background(), cacheService)
+	idleSweeper := code_processing.NewIdleSweeper(cacheService, envService.ApplicationEnvs.CacheEnvs().IdleTimeout())
+	go idleSweeper.Run(ctx)
 	pb.RegisterPlaygroundServiceServer(grpcServer, &playgroundController{
-		env:          envService,
-		cacheService: cacheService,
+		env:               envService,
+		cacheService:      cacheService,
+		workerPool:        code_processing.NewWorkerPool(envService.ApplicationEnvs.MaxConcurrentPipelines()),
+		examplesCatalog:   examples.New(envService.ApplicationEnvs.ExamplesDir(), cacheService, envService.ApplicationEnvs.ExamplesRefreshInterval()),
+		processingTracker: processingTracker,
+		idleSweeper:       idleSweeper,
+		archiveStorage:    archiveStorage,
+		rateLimiter:       rate_limiter.New(envService.ApplicationEnvs.RateLimitPerMinute(), envService.ApplicationEnvs.RateLimitBurst()),
+		jvmWarmPool:       jvmWarmPool,
+		compileCache:      compileCache,
 	})
 
 	errChan := make(chan error)
@@ -53,8 +104,16 @@ func runServer() error {
 	case "TCP":
 		go listenTcp(ctx, errChan, envService.NetworkEnvs, grpcServer)
 	case "HTTP":
-		handler := Wrap(grpcServer, getGrpcWebOptions())
-		go listenHttp(ctx, errChan, envService.NetworkEnvs, handler)
+		mux := http.NewServeMux()
+		mux.Handle(metrics.MetricsPath, metrics.Handler())
+		mux.Handle(health.ReadinessPath, health.Handler(envService.SortedBeamSdkEnvs()...))
+		mux.Handle(runOutputWebSocketPath, newRunOutputWebSocketHandler(cacheService, envService.ApplicationEnvs.WebSocketOriginPatterns()))
+		// the admin endpoint is disabled by default, since it exposes ids of all pipelines
+		if adminToken := envService.ApplicationEnvs.AdminToken(); adminToken != "" {
+			mux.Handle(activePipelinesPath, newActivePipelinesHandler(cacheService, adminToken))
+		}
+		mux.Handle("/", Wrap(grpcServer, getGrpcWebOptions()))
+		go listenHttp(ctx, errChan, envService.NetworkEnvs, mux)
 	}
 
 	for {
@@ -63,6 +122,9 @@ func runServer() error {
 			return err
 		case <-ctx.Done():
 			logger.Info("interrupt signal received; stopping...")
+			if !processingTracker.Shutdown(shutdownGracePeriod) {
+				logger.Errorf("code processing isn't finished in %s; its folders are deleted\n", shutdownGracePeriod)
+			}
 			return nil
 		}
 	}
@@ -81,7 +143,7 @@ func setupEnvironment() (*environment.Environment, error) {
 	if err != nil {
 		return nil, err
 	}
-	return environment.NewEnvironment(*networkEnvs, *beamEnvs, *appEnvs), nil
+	return environment.NewEnvironment(*networkEnvs, beamEnvs, *appEnvs), nil
 }
 
 // getGrpcWebOptions returns grpcweb options needed to configure wrapper
@@ -96,19 +158,69 @@ func getGrpcWebOptions() []grpcweb.Option {
 
 }
 
-// setupCache constructs required cache by application environment
+// setupCache constructs required cache by application environment.
+// If the cache encryption key is provided, the values are encrypted before they are kept in the cache.
+// The cache is wrapped to count hits and misses of the cache as metrics.
 func setupCache(ctx context.Context, appEnv environment.ApplicationEnvs) (cache.Cache, error) {
+	var cacheService cache.Cache
 	switch appEnv.CacheEnvs().CacheType() {
 	case "remote":
-		return redis.New(ctx, appEnv.CacheEnvs().Address())
+		redisCache, err := redis.New(ctx, appEnv.CacheEnvs().Address())
+		if err != nil {
+			return nil, err
+		}
+		cacheService = redisCache
+	case "file":
+		fileCache, err := file.NewWithRecovery(ctx, appEnv.CacheEnvs().Address(), isCompletedStatus, appEnv.CacheEnvs().KeyExpirationTime())
+		if err != nil {
+			return nil, err
+		}
+		cacheService = fileCache
 	default:
-		return local.New(ctx), nil
+		cacheService = local.NewWithLimits(ctx, appEnv.CacheEnvs().MaxPipelines(), appEnv.CacheEnvs().MaxOutputBytes(), isCompletedStatus)
 	}
+	if key := appEnv.CacheEnvs().EncryptionKey(); len(key) > 0 {
+		encryptedCache, err := encrypted.New(cacheService, key)
+		if err != nil {
+			return nil, err
+		}
+		cacheService = encryptedCache
+	}
+	return cacheMetrics.New(cacheService), nil
+}
+
+// setupJvmWarmPool constructs the pool of pre-started JVMs for the Java SDK.
+// Returns nil if the size of the pool isn't set, Java SDK isn't served by the server
+//	or the Java code is run in the containers (the pre-started JVMs are subprocesses of the server).
+// JVMs of the pool aren't stopped by the interrupt signal, so the code which is running is finished during the shutdown.
+func setupJvmWarmPool(envService *environment.Environment) (*executors.JvmWarmPool, error) {
+	appEnv := envService.ApplicationEnvs
+	sdkEnv, ok := envService.GetBeamSdkEnvs(pb.Sdk_SDK_JAVA)
+	if appEnv.JvmWarmPoolSize() == 0 || !ok {
+		return nil, nil
+	}
+	executorConfig := sdkEnv.ExecutorConfig
+	if executorConfig.DockerImage != "" {
+		return nil, nil
+	}
+	return executors.NewJvmWarmPool(context.Background(), appEnv.JvmWarmPoolSize(), filepath.Join(appEnv.WorkingDir(), jvmWarmPoolDir),
+		executorConfig.CompileCmd, executorConfig.RunCmd, executorConfig.RunArgs, executorConfig.Env,
+		appEnv.PipelineMemoryLimit(), appEnv.PipelineCpuTimeLimit(), appEnv.NetworkIsolation(), appEnv.SandboxCmd())
+}
+
+// isCompletedStatus checks if the value of the status from cache is a final status of the code processing,
+//	so the outputs of the pipeline could be evicted from the local cache (the outputs of the pipelines in progress aren't evicted)
+//	and the pipeline loaded by the file cache isn't considered interrupted by the restart of the server
+func isCompletedStatus(status interface{}) bool {
+	value, ok := status.(pb.Status)
+	return ok && code_processing.IsFinalStatus(value)
 }
 
+// main runs the server. If the server couldn't be started (i.e. the config of the SDK is incorrect)
+//	or is stopped because of an error, logs the error and exits with non-zero code.
 func main() {
 	err := runServer()
 	if err != nil {
-		panic(err)
+		logger.Fatalf("server is stopped: %s", err.Error())
 	}
 }
@@ -0,0 +1,121 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/code_processing"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"github.com/google/uuid"
+	"net/http"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+const (
+	// runOutputWebSocketPath is the path of the WebSocket endpoint which streams the run output to the browser clients
+	runOutputWebSocketPath = "/ws/run-output"
+	// pipelineUuidParam is the query parameter of the WebSocket endpoint which contains the pipeline uuid
+	pipelineUuidParam = "pipelineUuid"
+)
+
+// runOutputFrame is a JSON frame which is sent by the WebSocket endpoint each time the status of the code processing
+//	is changed or the new run output is received
+type runOutputFrame struct {
+	Status      string `json:"status"`
+	OutputDelta string `json:"outputDelta"`
+	Error       string `json:"error,omitempty"`
+}
+
+// runOutputWebSocketHandler streams the run output of the code processing over WebSocket for clients which can't consume gRPC streams.
+// The same cache watching as in GetRunOutputStream is used, so each WebSocket receives the whole run output
+//	regardless of the output which has been received by GetRunOutput, GetRunOutputStream or other WebSockets.
+type runOutputWebSocketHandler struct {
+	cacheService   cache.Cache
+	originPatterns []string
+}
+
+// newRunOutputWebSocketHandler returns http.Handler which upgrades the connection to WebSocket and sends runOutputFrame
+//	as the code processing by pipelineUuid query parameter progresses.
+// The connection is closed after the code processing reaches one of the final statuses and the rest of the output is sent,
+//	or when the client disconnects.
+// Browser clients from other origins than the host of the server are accepted only if their origins match originPatterns.
+func newRunOutputWebSocketHandler(cacheService cache.Cache, originPatterns []string) http.Handler {
+	return &runOutputWebSocketHandler{cacheService: cacheService, originPatterns: originPatterns}
+}
+
+func (h *runOutputWebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	errorTitle := "RunOutputWebSocket"
+	pipelineId, err := uuid.Parse(r.URL.Query().Get(pipelineUuidParam))
+	if err != nil {
+		logger.Errorf("%s: %s(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", r.URL.Query().Get(pipelineUuidParam), errorTitle, err.Error())
+		http.Error(w, "pipelineId has incorrect value and couldn't be parsed as uuid value", http.StatusBadRequest)
+		return
+	}
+	if _, err := code_processing.GetProcessingStatus(r.Context(), h.cacheService, pipelineId, errorTitle); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	// the cookies of the browser are sent with the WebSocket handshake, so cross-origin clients are verified
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{OriginPatterns: h.originPatterns})
+	if err != nil {
+		logger.Errorf("%s: %s(): error during accept WebSocket connection: %s", pipelineId, errorTitle, err.Error())
+		return
+	}
+	// messages of the client aren't expected, the context is done as soon as the client disconnects
+	ctx := conn.CloseRead(r.Context())
+	err = code_processing.WatchRunOutput(ctx, h.cacheService, pipelineId, streamPauseDuration, errorTitle, func(status pb.Status, newRunOutput string) error {
+		frame := runOutputFrame{Status: status.String(), OutputDelta: newRunOutput}
+		if code_processing.IsFinalStatus(status) {
+			frame.Error = h.processingError(ctx, pipelineId, status, errorTitle)
+		}
+		if err := wsjson.Write(ctx, conn, frame); err != nil {
+			return err
+		}
+		code_processing.MarkAccessed(ctx, h.cacheService, pipelineId)
+		return nil
+	})
+	// the error of the send to the disconnected client is expected, so it isn't reported
+	if err != nil && ctx.Err() == nil {
+		logger.Errorf("%s: %s(): error during stream run output: %s", pipelineId, errorTitle, err.Error())
+		_ = conn.Close(websocket.StatusInternalError, "error during stream run output")
+		return
+	}
+	_ = conn.Close(websocket.StatusNormalClosure, "")
+}
+
+// processingError returns the error of the failed code processing which corresponds to its final status,
+//	i.e. the run error for playground.Status_STATUS_RUN_ERROR. Returns empty string for the successful code processing.
+func (h *runOutputWebSocketHandler) processingError(ctx context.Context, pipelineId uuid.UUID, status pb.Status, errorTitle string) string {
+	var subKey cache.SubKey
+	switch status {
+	case pb.Status_STATUS_VALIDATION_ERROR:
+		subKey = cache.ValidationOutput
+	case pb.Status_STATUS_COMPILE_ERROR, pb.Status_STATUS_COMPILE_TIMEOUT:
+		subKey = cache.CompileOutput
+	case pb.Status_STATUS_RUN_ERROR, pb.Status_STATUS_RUN_TIMEOUT:
+		subKey = cache.RunError
+	default:
+		return ""
+	}
+	processingError, err := code_processing.GetProcessingOutput(ctx, h.cacheService, pipelineId, subKey, errorTitle)
+	if err != nil {
+		return ""
+	}
+	return processingError
+}
@@ -0,0 +1,193 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/goleak"
+	"net/http"
+	"net/http/httptest"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dialRunOutputWebSocket connects to the WebSocket endpoint of the server for the pipeline uuid.
+// The Origin header is sent as by the browser if origin isn't empty.
+func dialRunOutputWebSocket(ctx context.Context, server *httptest.Server, pipelineUuid, origin string) (*websocket.Conn, *http.Response, error) {
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + runOutputWebSocketPath + "?" + pipelineUuidParam + "=" + pipelineUuid
+	opts := &websocket.DialOptions{HTTPHeader: http.Header{}}
+	if origin != "" {
+		opts.HTTPHeader.Set("Origin", origin)
+	}
+	return websocket.Dial(ctx, url, opts)
+}
+
+func TestRunOutputWebSocketHandler(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	pipelineId := uuid.New()
+	finishedPipelineId := uuid.New()
+	_ = cacheService.SetValue(ctx, finishedPipelineId, cache.RunOutputIndex, 0)
+	_ = cacheService.SetValue(ctx, finishedPipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT\n")
+	_ = cacheService.SetValue(ctx, finishedPipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+	mux := http.NewServeMux()
+	mux.Handle(runOutputWebSocketPath, newRunOutputWebSocketHandler(cacheService, []string{"*.example.com"}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		prepare        func()
+		pipelineUuid   string
+		origin         string
+		wantHttpStatus int
+		want           []runOutputFrame
+	}{
+		{
+			// Test case with connecting to the WebSocket endpoint with incorrect pipelineId.
+			// As a result, want to receive the response with 400 status instead of the WebSocket connection.
+			name:           "incorrect pipelineId",
+			prepare:        func() {},
+			pipelineUuid:   "NO_UUID_STRING",
+			wantHttpStatus: http.StatusBadRequest,
+		},
+		{
+			// Test case with connecting to the WebSocket endpoint with pipelineId which doesn't exist.
+			// As a result, want to receive the response with 404 status instead of the WebSocket connection.
+			name:           "pipelineId doesn't exist",
+			prepare:        func() {},
+			pipelineUuid:   uuid.New().String(),
+			wantHttpStatus: http.StatusNotFound,
+		},
+		{
+			// Test case with connecting to the WebSocket endpoint from the origin which doesn't match the origin patterns.
+			// As a result, want to receive the response with 403 status instead of the WebSocket connection.
+			name:           "origin isn't allowed",
+			prepare:        func() {},
+			pipelineUuid:   finishedPipelineId.String(),
+			origin:         "https://attacker.com",
+			wantHttpStatus: http.StatusForbidden,
+		},
+		{
+			// Test case with connecting to the WebSocket endpoint from the origin which matches the origin patterns.
+			// As a result, want to receive the run output of the finished code processing.
+			name:           "origin is allowed",
+			prepare:        func() {},
+			pipelineUuid:   finishedPipelineId.String(),
+			origin:         "https://playground.example.com",
+			wantHttpStatus: http.StatusSwitchingProtocols,
+			want: []runOutputFrame{
+				{Status: pb.Status_STATUS_FINISHED.String(), OutputDelta: "MOCK_RUN_OUTPUT\n"},
+			},
+		},
+		{
+			// Test case with connecting to the WebSocket endpoint with pipelineId which run output is written during
+			// the streaming and which is finished with the run error.
+			// As a result, want to receive frames with the changed statuses, the new parts of the output and the run error,
+			// and the connection which is closed after the code processing is finished.
+			name: "run output is written during streaming",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 0)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT\n")
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+				go func() {
+					time.Sleep(2 * streamPauseDuration)
+					_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT\nMOCK_NEXT_RUN_OUTPUT\n")
+					time.Sleep(2 * streamPauseDuration)
+					_ = cacheService.SetValue(ctx, pipelineId, cache.RunError, "MOCK_RUN_ERROR")
+					_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_RUN_ERROR)
+				}()
+			},
+			pipelineUuid:   pipelineId.String(),
+			wantHttpStatus: http.StatusSwitchingProtocols,
+			want: []runOutputFrame{
+				{Status: pb.Status_STATUS_EXECUTING.String(), OutputDelta: "MOCK_RUN_OUTPUT\n"},
+				{Status: pb.Status_STATUS_EXECUTING.String(), OutputDelta: "MOCK_NEXT_RUN_OUTPUT\n"},
+				{Status: pb.Status_STATUS_RUN_ERROR.String(), Error: "MOCK_RUN_ERROR"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			conn, resp, err := dialRunOutputWebSocket(ctx, server, tt.pipelineUuid, tt.origin)
+			if resp == nil || resp.StatusCode != tt.wantHttpStatus {
+				t.Fatalf("Dial() response = %v, error = %v, want status %d", resp, err, tt.wantHttpStatus)
+			}
+			if err != nil {
+				return
+			}
+			var got []runOutputFrame
+			for {
+				var frame runOutputFrame
+				if err := wsjson.Read(ctx, conn, &frame); err != nil {
+					if websocket.CloseStatus(err) != websocket.StatusNormalClosure {
+						t.Errorf("Read() error = %v, want normal closure", err)
+					}
+					break
+				}
+				got = append(got, frame)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunOutputWebSocketHandler_ClientDisconnect(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	pipelineId := uuid.New()
+	_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 0)
+	_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+	handlerFinished := make(chan struct{})
+	handler := newRunOutputWebSocketHandler(cacheService, nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(handlerFinished)
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	conn, _, err := dialRunOutputWebSocket(ctx, server, pipelineId.String(), "")
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	var frame runOutputFrame
+	if err := wsjson.Read(ctx, conn, &frame); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if frame.Status != pb.Status_STATUS_EXECUTING.String() {
+		t.Errorf("Read() status = %v, want %v", frame.Status, pb.Status_STATUS_EXECUTING.String())
+	}
+	// the code processing isn't finished, so the watching is stopped only because the client disconnects
+	_ = conn.Close(websocket.StatusNormalClosure, "")
+
+	select {
+	case <-handlerFinished:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("handler isn't finished after the client disconnects")
+	}
+}
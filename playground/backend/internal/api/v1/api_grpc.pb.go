@@ -41,13 +41,40 @@ type PlaygroundServiceClient interface {
 	CheckStatus(ctx context.Context, in *CheckStatusRequest, opts ...grpc.CallOption) (*CheckStatusResponse, error)
 	// Get the result of pipeline execution.
 	GetRunOutput(ctx context.Context, in *GetRunOutputRequest, opts ...grpc.CallOption) (*GetRunOutputResponse, error)
+	// Get the result of pipeline execution as a stream which sends new output as soon as it is received.
+	// The stream is closed when the pipeline execution is finished.
+	GetRunOutputStream(ctx context.Context, in *GetRunOutputRequest, opts ...grpc.CallOption) (PlaygroundService_GetRunOutputStreamClient, error)
 	// Get the logs of pipeline execution.
 	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsResponse, error)
 	// Get the error of pipeline execution.
 	GetRunError(ctx context.Context, in *GetRunErrorRequest, opts ...grpc.CallOption) (*GetRunErrorResponse, error)
+	// Get the exit code of pipeline execution.
+	GetRunExitCode(ctx context.Context, in *GetRunExitCodeRequest, opts ...grpc.CallOption) (*GetRunExitCodeResponse, error)
 	// Get the result of pipeline compilation.
 	GetCompileOutput(ctx context.Context, in *GetCompileOutputRequest, opts ...grpc.CallOption) (*GetCompileOutputResponse, error)
-	// Cancel code processing
+	// Get the errors of pipeline compilation as a stream which sends new compile output as soon as it is received.
+	// The stream is closed when the pipeline compilation is finished.
+	GetCompileOutputStream(ctx context.Context, in *GetCompileOutputRequest, opts ...grpc.CallOption) (PlaygroundService_GetCompileOutputStreamClient, error)
+	// Get the errors of pipeline compilation parsed from the compiler output.
+	GetCompileErrors(ctx context.Context, in *GetCompileErrorsRequest, opts ...grpc.CallOption) (*GetCompileErrorsResponse, error)
+	// Get the warnings of the compiler. They are kept even if the code is compiled successfully.
+	GetCompileWarnings(ctx context.Context, in *GetCompileWarningsRequest, opts ...grpc.CallOption) (*GetCompileWarningsResponse, error)
+	// Get the warnings about the code found by the validation, i.e. the code doesn't construct a Beam pipeline.
+	GetWarnings(ctx context.Context, in *GetWarningsRequest, opts ...grpc.CallOption) (*GetWarningsResponse, error)
+	// Get the results of the executed unit tests parsed from the test runner output.
+	GetTestResults(ctx context.Context, in *GetTestResultsRequest, opts ...grpc.CallOption) (*GetTestResultsResponse, error)
+	// Get the status, outputs and errors of pipeline execution in one call.
+	GetPipelineSnapshot(ctx context.Context, in *GetPipelineSnapshotRequest, opts ...grpc.CallOption) (*GetPipelineSnapshotResponse, error)
+	// Get the graph of the executed pipeline in DOT format.
+	GetGraph(ctx context.Context, in *GetGraphRequest, opts ...grpc.CallOption) (*GetGraphResponse, error)
+	// Get the metrics (counters, distributions and gauges) of the executed pipeline.
+	GetPipelineMetrics(ctx context.Context, in *GetPipelineMetricsRequest, opts ...grpc.CallOption) (*GetPipelineMetricsResponse, error)
+	// Get the command line of the run step. It is available only if the server is run in the debug mode.
+	GetRunCommand(ctx context.Context, in *GetRunCommandRequest, opts ...grpc.CallOption) (*GetRunCommandResponse, error)
+	// Get the result of the finished pipeline execution.
+	// The result is kept in the archive, so it could be received after the pipeline is removed from the cache.
+	GetArchivedResult(ctx context.Context, in *GetArchivedResultRequest, opts ...grpc.CallOption) (*GetArchivedResultResponse, error)
+	// Cancel code processing. Returns NOT_FOUND for an unknown pipeline and FAILED_PRECONDITION for the finished one.
 	Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error)
 	// Get all precompiled objects from the cloud storage.
 	GetPrecompiledObjects(ctx context.Context, in *GetPrecompiledObjectsRequest, opts ...grpc.CallOption) (*GetPrecompiledObjectsResponse, error)
@@ -55,6 +82,10 @@ type PlaygroundServiceClient interface {
 	GetPrecompiledObjectCode(ctx context.Context, in *GetPrecompiledObjectRequest, opts ...grpc.CallOption) (*GetPrecompiledObjectCodeResponse, error)
 	// Get the precompiled details of an PrecompiledObject.
 	GetPrecompiledObjectOutput(ctx context.Context, in *GetPrecompiledObjectRequest, opts ...grpc.CallOption) (*GetRunOutputResponse, error)
+	// Get metadata of the examples from the examples directory.
+	ListExamples(ctx context.Context, in *ListExamplesRequest, opts ...grpc.CallOption) (*ListExamplesResponse, error)
+	// Get the code of an example from the examples directory.
+	GetExample(ctx context.Context, in *GetExampleRequest, opts ...grpc.CallOption) (*GetExampleResponse, error)
 }
 
 type playgroundServiceClient struct {
@@ -92,6 +123,38 @@ func (c *playgroundServiceClient) GetRunOutput(ctx context.Context, in *GetRunOu
 	return out, nil
 }
 
+func (c *playgroundServiceClient) GetRunOutputStream(ctx context.Context, in *GetRunOutputRequest, opts ...grpc.CallOption) (PlaygroundService_GetRunOutputStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PlaygroundService_ServiceDesc.Streams[0], "/api.v1.PlaygroundService/GetRunOutputStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &playgroundServiceGetRunOutputStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PlaygroundService_GetRunOutputStreamClient interface {
+	Recv() (*GetRunOutputResponse, error)
+	grpc.ClientStream
+}
+
+type playgroundServiceGetRunOutputStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *playgroundServiceGetRunOutputStreamClient) Recv() (*GetRunOutputResponse, error) {
+	m := new(GetRunOutputResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *playgroundServiceClient) GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsResponse, error) {
 	out := new(GetLogsResponse)
 	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetLogs", in, out, opts...)
@@ -110,6 +173,15 @@ func (c *playgroundServiceClient) GetRunError(ctx context.Context, in *GetRunErr
 	return out, nil
 }
 
+func (c *playgroundServiceClient) GetRunExitCode(ctx context.Context, in *GetRunExitCodeRequest, opts ...grpc.CallOption) (*GetRunExitCodeResponse, error) {
+	out := new(GetRunExitCodeResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetRunExitCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *playgroundServiceClient) GetCompileOutput(ctx context.Context, in *GetCompileOutputRequest, opts ...grpc.CallOption) (*GetCompileOutputResponse, error) {
 	out := new(GetCompileOutputResponse)
 	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetCompileOutput", in, out, opts...)
@@ -119,6 +191,119 @@ func (c *playgroundServiceClient) GetCompileOutput(ctx context.Context, in *GetC
 	return out, nil
 }
 
+func (c *playgroundServiceClient) GetCompileOutputStream(ctx context.Context, in *GetCompileOutputRequest, opts ...grpc.CallOption) (PlaygroundService_GetCompileOutputStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PlaygroundService_ServiceDesc.Streams[1], "/api.v1.PlaygroundService/GetCompileOutputStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &playgroundServiceGetCompileOutputStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PlaygroundService_GetCompileOutputStreamClient interface {
+	Recv() (*GetCompileOutputResponse, error)
+	grpc.ClientStream
+}
+
+type playgroundServiceGetCompileOutputStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *playgroundServiceGetCompileOutputStreamClient) Recv() (*GetCompileOutputResponse, error) {
+	m := new(GetCompileOutputResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *playgroundServiceClient) GetCompileErrors(ctx context.Context, in *GetCompileErrorsRequest, opts ...grpc.CallOption) (*GetCompileErrorsResponse, error) {
+	out := new(GetCompileErrorsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetCompileErrors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetCompileWarnings(ctx context.Context, in *GetCompileWarningsRequest, opts ...grpc.CallOption) (*GetCompileWarningsResponse, error) {
+	out := new(GetCompileWarningsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetCompileWarnings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetWarnings(ctx context.Context, in *GetWarningsRequest, opts ...grpc.CallOption) (*GetWarningsResponse, error) {
+	out := new(GetWarningsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetWarnings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetTestResults(ctx context.Context, in *GetTestResultsRequest, opts ...grpc.CallOption) (*GetTestResultsResponse, error) {
+	out := new(GetTestResultsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetTestResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetPipelineSnapshot(ctx context.Context, in *GetPipelineSnapshotRequest, opts ...grpc.CallOption) (*GetPipelineSnapshotResponse, error) {
+	out := new(GetPipelineSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetPipelineSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetGraph(ctx context.Context, in *GetGraphRequest, opts ...grpc.CallOption) (*GetGraphResponse, error) {
+	out := new(GetGraphResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetGraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetPipelineMetrics(ctx context.Context, in *GetPipelineMetricsRequest, opts ...grpc.CallOption) (*GetPipelineMetricsResponse, error) {
+	out := new(GetPipelineMetricsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetPipelineMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetRunCommand(ctx context.Context, in *GetRunCommandRequest, opts ...grpc.CallOption) (*GetRunCommandResponse, error) {
+	out := new(GetRunCommandResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetRunCommand", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetArchivedResult(ctx context.Context, in *GetArchivedResultRequest, opts ...grpc.CallOption) (*GetArchivedResultResponse, error) {
+	out := new(GetArchivedResultResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetArchivedResult", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *playgroundServiceClient) Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error) {
 	out := new(CancelResponse)
 	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/Cancel", in, out, opts...)
@@ -155,6 +340,24 @@ func (c *playgroundServiceClient) GetPrecompiledObjectOutput(ctx context.Context
 	return out, nil
 }
 
+func (c *playgroundServiceClient) ListExamples(ctx context.Context, in *ListExamplesRequest, opts ...grpc.CallOption) (*ListExamplesResponse, error) {
+	out := new(ListExamplesResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/ListExamples", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetExample(ctx context.Context, in *GetExampleRequest, opts ...grpc.CallOption) (*GetExampleResponse, error) {
+	out := new(GetExampleResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetExample", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PlaygroundServiceServer is the server API for PlaygroundService service.
 // All implementations should embed UnimplementedPlaygroundServiceServer
 // for forward compatibility
@@ -165,13 +368,40 @@ type PlaygroundServiceServer interface {
 	CheckStatus(context.Context, *CheckStatusRequest) (*CheckStatusResponse, error)
 	// Get the result of pipeline execution.
 	GetRunOutput(context.Context, *GetRunOutputRequest) (*GetRunOutputResponse, error)
+	// Get the result of pipeline execution as a stream which sends new output as soon as it is received.
+	// The stream is closed when the pipeline execution is finished.
+	GetRunOutputStream(*GetRunOutputRequest, PlaygroundService_GetRunOutputStreamServer) error
 	// Get the logs of pipeline execution.
 	GetLogs(context.Context, *GetLogsRequest) (*GetLogsResponse, error)
 	// Get the error of pipeline execution.
 	GetRunError(context.Context, *GetRunErrorRequest) (*GetRunErrorResponse, error)
+	// Get the exit code of pipeline execution.
+	GetRunExitCode(context.Context, *GetRunExitCodeRequest) (*GetRunExitCodeResponse, error)
 	// Get the result of pipeline compilation.
 	GetCompileOutput(context.Context, *GetCompileOutputRequest) (*GetCompileOutputResponse, error)
-	// Cancel code processing
+	// Get the errors of pipeline compilation as a stream which sends new compile output as soon as it is received.
+	// The stream is closed when the pipeline compilation is finished.
+	GetCompileOutputStream(*GetCompileOutputRequest, PlaygroundService_GetCompileOutputStreamServer) error
+	// Get the errors of pipeline compilation parsed from the compiler output.
+	GetCompileErrors(context.Context, *GetCompileErrorsRequest) (*GetCompileErrorsResponse, error)
+	// Get the warnings of the compiler. They are kept even if the code is compiled successfully.
+	GetCompileWarnings(context.Context, *GetCompileWarningsRequest) (*GetCompileWarningsResponse, error)
+	// Get the warnings about the code found by the validation, i.e. the code doesn't construct a Beam pipeline.
+	GetWarnings(context.Context, *GetWarningsRequest) (*GetWarningsResponse, error)
+	// Get the results of the executed unit tests parsed from the test runner output.
+	GetTestResults(context.Context, *GetTestResultsRequest) (*GetTestResultsResponse, error)
+	// Get the status, outputs and errors of pipeline execution in one call.
+	GetPipelineSnapshot(context.Context, *GetPipelineSnapshotRequest) (*GetPipelineSnapshotResponse, error)
+	// Get the graph of the executed pipeline in DOT format.
+	GetGraph(context.Context, *GetGraphRequest) (*GetGraphResponse, error)
+	// Get the metrics (counters, distributions and gauges) of the executed pipeline.
+	GetPipelineMetrics(context.Context, *GetPipelineMetricsRequest) (*GetPipelineMetricsResponse, error)
+	// Get the command line of the run step. It is available only if the server is run in the debug mode.
+	GetRunCommand(context.Context, *GetRunCommandRequest) (*GetRunCommandResponse, error)
+	// Get the result of the finished pipeline execution.
+	// The result is kept in the archive, so it could be received after the pipeline is removed from the cache.
+	GetArchivedResult(context.Context, *GetArchivedResultRequest) (*GetArchivedResultResponse, error)
+	// Cancel code processing. Returns NOT_FOUND for an unknown pipeline and FAILED_PRECONDITION for the finished one.
 	Cancel(context.Context, *CancelRequest) (*CancelResponse, error)
 	// Get all precompiled objects from the cloud storage.
 	GetPrecompiledObjects(context.Context, *GetPrecompiledObjectsRequest) (*GetPrecompiledObjectsResponse, error)
@@ -179,6 +409,10 @@ type PlaygroundServiceServer interface {
 	GetPrecompiledObjectCode(context.Context, *GetPrecompiledObjectRequest) (*GetPrecompiledObjectCodeResponse, error)
 	// Get the precompiled details of an PrecompiledObject.
 	GetPrecompiledObjectOutput(context.Context, *GetPrecompiledObjectRequest) (*GetRunOutputResponse, error)
+	// Get metadata of the examples from the examples directory.
+	ListExamples(context.Context, *ListExamplesRequest) (*ListExamplesResponse, error)
+	// Get the code of an example from the examples directory.
+	GetExample(context.Context, *GetExampleRequest) (*GetExampleResponse, error)
 }
 
 // UnimplementedPlaygroundServiceServer should be embedded to have forward compatible implementations.
@@ -194,15 +428,51 @@ func (UnimplementedPlaygroundServiceServer) CheckStatus(context.Context, *CheckS
 func (UnimplementedPlaygroundServiceServer) GetRunOutput(context.Context, *GetRunOutputRequest) (*GetRunOutputResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRunOutput not implemented")
 }
+func (UnimplementedPlaygroundServiceServer) GetRunOutputStream(*GetRunOutputRequest, PlaygroundService_GetRunOutputStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetRunOutputStream not implemented")
+}
 func (UnimplementedPlaygroundServiceServer) GetLogs(context.Context, *GetLogsRequest) (*GetLogsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
 }
 func (UnimplementedPlaygroundServiceServer) GetRunError(context.Context, *GetRunErrorRequest) (*GetRunErrorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRunError not implemented")
 }
+func (UnimplementedPlaygroundServiceServer) GetRunExitCode(context.Context, *GetRunExitCodeRequest) (*GetRunExitCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunExitCode not implemented")
+}
 func (UnimplementedPlaygroundServiceServer) GetCompileOutput(context.Context, *GetCompileOutputRequest) (*GetCompileOutputResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCompileOutput not implemented")
 }
+func (UnimplementedPlaygroundServiceServer) GetCompileOutputStream(*GetCompileOutputRequest, PlaygroundService_GetCompileOutputStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetCompileOutputStream not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetCompileErrors(context.Context, *GetCompileErrorsRequest) (*GetCompileErrorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompileErrors not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetCompileWarnings(context.Context, *GetCompileWarningsRequest) (*GetCompileWarningsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompileWarnings not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetWarnings(context.Context, *GetWarningsRequest) (*GetWarningsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWarnings not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetTestResults(context.Context, *GetTestResultsRequest) (*GetTestResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTestResults not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetPipelineSnapshot(context.Context, *GetPipelineSnapshotRequest) (*GetPipelineSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPipelineSnapshot not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetGraph(context.Context, *GetGraphRequest) (*GetGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGraph not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetPipelineMetrics(context.Context, *GetPipelineMetricsRequest) (*GetPipelineMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPipelineMetrics not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetRunCommand(context.Context, *GetRunCommandRequest) (*GetRunCommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunCommand not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetArchivedResult(context.Context, *GetArchivedResultRequest) (*GetArchivedResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArchivedResult not implemented")
+}
 func (UnimplementedPlaygroundServiceServer) Cancel(context.Context, *CancelRequest) (*CancelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
 }
@@ -215,6 +485,12 @@ func (UnimplementedPlaygroundServiceServer) GetPrecompiledObjectCode(context.Con
 func (UnimplementedPlaygroundServiceServer) GetPrecompiledObjectOutput(context.Context, *GetPrecompiledObjectRequest) (*GetRunOutputResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPrecompiledObjectOutput not implemented")
 }
+func (UnimplementedPlaygroundServiceServer) ListExamples(context.Context, *ListExamplesRequest) (*ListExamplesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExamples not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetExample(context.Context, *GetExampleRequest) (*GetExampleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExample not implemented")
+}
 
 // UnsafePlaygroundServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to PlaygroundServiceServer will
@@ -281,6 +557,27 @@ func _PlaygroundService_GetRunOutput_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlaygroundService_GetRunOutputStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRunOutputRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlaygroundServiceServer).GetRunOutputStream(m, &playgroundServiceGetRunOutputStreamServer{stream})
+}
+
+type PlaygroundService_GetRunOutputStreamServer interface {
+	Send(*GetRunOutputResponse) error
+	grpc.ServerStream
+}
+
+type playgroundServiceGetRunOutputStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *playgroundServiceGetRunOutputStreamServer) Send(m *GetRunOutputResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _PlaygroundService_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetLogsRequest)
 	if err := dec(in); err != nil {
@@ -317,6 +614,24 @@ func _PlaygroundService_GetRunError_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlaygroundService_GetRunExitCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunExitCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetRunExitCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetRunExitCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetRunExitCode(ctx, req.(*GetRunExitCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PlaygroundService_GetCompileOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCompileOutputRequest)
 	if err := dec(in); err != nil {
@@ -335,6 +650,189 @@ func _PlaygroundService_GetCompileOutput_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlaygroundService_GetCompileOutputStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetCompileOutputRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlaygroundServiceServer).GetCompileOutputStream(m, &playgroundServiceGetCompileOutputStreamServer{stream})
+}
+
+type PlaygroundService_GetCompileOutputStreamServer interface {
+	Send(*GetCompileOutputResponse) error
+	grpc.ServerStream
+}
+
+type playgroundServiceGetCompileOutputStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *playgroundServiceGetCompileOutputStreamServer) Send(m *GetCompileOutputResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PlaygroundService_GetCompileErrors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCompileErrorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetCompileErrors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetCompileErrors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetCompileErrors(ctx, req.(*GetCompileErrorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetCompileWarnings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCompileWarningsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetCompileWarnings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetCompileWarnings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetCompileWarnings(ctx, req.(*GetCompileWarningsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetWarnings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWarningsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetWarnings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetWarnings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetWarnings(ctx, req.(*GetWarningsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetTestResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTestResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetTestResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetTestResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetTestResults(ctx, req.(*GetTestResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetPipelineSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPipelineSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetPipelineSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetPipelineSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetPipelineSnapshot(ctx, req.(*GetPipelineSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetGraph(ctx, req.(*GetGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetPipelineMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPipelineMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetPipelineMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetPipelineMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetPipelineMetrics(ctx, req.(*GetPipelineMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetRunCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetRunCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetRunCommand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetRunCommand(ctx, req.(*GetRunCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetArchivedResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArchivedResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetArchivedResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetArchivedResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetArchivedResult(ctx, req.(*GetArchivedResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PlaygroundService_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CancelRequest)
 	if err := dec(in); err != nil {
@@ -407,6 +905,42 @@ func _PlaygroundService_GetPrecompiledObjectOutput_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlaygroundService_ListExamples_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExamplesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).ListExamples(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/ListExamples",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).ListExamples(ctx, req.(*ListExamplesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetExample_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExampleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetExample(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetExample",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetExample(ctx, req.(*GetExampleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PlaygroundService_ServiceDesc is the grpc.ServiceDesc for PlaygroundService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -434,10 +968,50 @@ var PlaygroundService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRunError",
 			Handler:    _PlaygroundService_GetRunError_Handler,
 		},
+		{
+			MethodName: "GetRunExitCode",
+			Handler:    _PlaygroundService_GetRunExitCode_Handler,
+		},
 		{
 			MethodName: "GetCompileOutput",
 			Handler:    _PlaygroundService_GetCompileOutput_Handler,
 		},
+		{
+			MethodName: "GetCompileErrors",
+			Handler:    _PlaygroundService_GetCompileErrors_Handler,
+		},
+		{
+			MethodName: "GetCompileWarnings",
+			Handler:    _PlaygroundService_GetCompileWarnings_Handler,
+		},
+		{
+			MethodName: "GetWarnings",
+			Handler:    _PlaygroundService_GetWarnings_Handler,
+		},
+		{
+			MethodName: "GetTestResults",
+			Handler:    _PlaygroundService_GetTestResults_Handler,
+		},
+		{
+			MethodName: "GetPipelineSnapshot",
+			Handler:    _PlaygroundService_GetPipelineSnapshot_Handler,
+		},
+		{
+			MethodName: "GetGraph",
+			Handler:    _PlaygroundService_GetGraph_Handler,
+		},
+		{
+			MethodName: "GetPipelineMetrics",
+			Handler:    _PlaygroundService_GetPipelineMetrics_Handler,
+		},
+		{
+			MethodName: "GetRunCommand",
+			Handler:    _PlaygroundService_GetRunCommand_Handler,
+		},
+		{
+			MethodName: "GetArchivedResult",
+			Handler:    _PlaygroundService_GetArchivedResult_Handler,
+		},
 		{
 			MethodName: "Cancel",
 			Handler:    _PlaygroundService_Cancel_Handler,
@@ -454,7 +1028,26 @@ var PlaygroundService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPrecompiledObjectOutput",
 			Handler:    _PlaygroundService_GetPrecompiledObjectOutput_Handler,
 		},
+		{
+			MethodName: "ListExamples",
+			Handler:    _PlaygroundService_ListExamples_Handler,
+		},
+		{
+			MethodName: "GetExample",
+			Handler:    _PlaygroundService_GetExample_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetRunOutputStream",
+			Handler:       _PlaygroundService_GetRunOutputStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetCompileOutputStream",
+			Handler:       _PlaygroundService_GetCompileOutputStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/api.proto",
 }
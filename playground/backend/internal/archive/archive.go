@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// gcsScheme is a prefix of the archive location which means that the results are kept in the Google Cloud Storage bucket
+const gcsScheme = "gs://"
+
+// ErrNotFound is returned when there is no archived result with the requested id
+var ErrNotFound = errors.New("archived result not found")
+
+// Result is a result of the finished code processing which is kept in the archive.
+// Fields which aren't set for the code processing (i.e. the compile output of the Python code) are omitted.
+type Result struct {
+	Status        pb.Status `json:"status"`
+	RunOutput     string    `json:"run_output,omitempty"`
+	RunError      string    `json:"run_error,omitempty"`
+	CompileOutput string    `json:"compile_output,omitempty"`
+	Graph         string    `json:"graph,omitempty"`
+	// ExitCode is nil if the code hasn't been run (i.e. the compile step is failed)
+	ExitCode    *int          `json:"exit_code,omitempty"`
+	CompileTime time.Duration `json:"compile_time,omitempty"`
+	RunTime     time.Duration `json:"run_time,omitempty"`
+	ArchivedAt  time.Time     `json:"archived_at"`
+}
+
+// Storage keeps serialized results of the code processing by their ids
+type Storage interface {
+	// Put saves data by id. The data which has been saved by the same id before is replaced.
+	Put(ctx context.Context, id string, data []byte) error
+
+	// Get returns data saved by id. In case there is no data for id returns ErrNotFound.
+	Get(ctx context.Context, id string) ([]byte, error)
+}
+
+// NewStorage returns Storage by location of the archive:
+//	- "gs://bucket/prefix" - results are kept in the Google Cloud Storage bucket under the prefix
+//	- any other location - results are kept in the local directory
+// In case the location is empty returns nil, so results aren't archived.
+func NewStorage(ctx context.Context, location string) (Storage, error) {
+	switch {
+	case location == "":
+		return nil, nil
+	case strings.HasPrefix(location, gcsScheme):
+		bucket, prefix := splitBucketPath(strings.TrimPrefix(location, gcsScheme))
+		if bucket == "" {
+			return nil, fmt.Errorf("bucket isn't set in the archive location: %s", location)
+		}
+		return NewGcsStorage(ctx, bucket, prefix)
+	default:
+		return NewFileStorage(location)
+	}
+}
+
+// Archive serializes result and saves it into storage by id
+func Archive(ctx context.Context, storage Storage, id string, result *Result) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return storage.Put(ctx, id, data)
+}
+
+// GetArchivedResult reads the result saved by id from storage.
+// In case there is no result for id returns ErrNotFound.
+func GetArchivedResult(ctx context.Context, storage Storage, id string) (*Result, error) {
+	data, err := storage.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("archived result %s couldn't be parsed: %s", id, err.Error())
+	}
+	return result, nil
+}
+
+// splitBucketPath splits "bucket/prefix" path to the name of the bucket and the prefix of the objects
+func splitBucketPath(path string) (string, string) {
+	parts := strings.SplitN(strings.Trim(path, "/"), "/", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,245 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFileStorage(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(os.TempDir(), "archive_test")
+	defer os.RemoveAll(dir)
+	storage, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("NewFileStorage() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		prepare func() error
+		id      string
+		want    []byte
+		wantErr error
+	}{
+		{
+			// Test case with reading data which hasn't been saved.
+			// As a result, want to receive ErrNotFound.
+			name:    "data doesn't exist",
+			prepare: func() error { return nil },
+			id:      "MOCK_ID",
+			want:    nil,
+			wantErr: ErrNotFound,
+		},
+		{
+			// Test case with reading data which has been saved.
+			// As a result, want to receive the saved data.
+			name:    "data exists",
+			prepare: func() error { return storage.Put(ctx, "MOCK_ID", []byte("MOCK_DATA")) },
+			id:      "MOCK_ID",
+			want:    []byte("MOCK_DATA"),
+			wantErr: nil,
+		},
+		{
+			// Test case with reading data which has been replaced.
+			// As a result, want to receive the latest data.
+			name:    "data is replaced",
+			prepare: func() error { return storage.Put(ctx, "MOCK_ID", []byte("MOCK_NEW_DATA")) },
+			id:      "MOCK_ID",
+			want:    []byte("MOCK_NEW_DATA"),
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.prepare(); err != nil {
+				t.Fatalf("prepare() error = %v", err)
+			}
+			got, err := storage.Get(ctx, tt.id)
+			if err != tt.wantErr {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileStorage_path(t *testing.T) {
+	storage := &FileStorage{dir: "MOCK_DIR"}
+	tests := []struct {
+		name    string
+		id      string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "correct id",
+			id:      "MOCK_ID",
+			want:    filepath.Join("MOCK_DIR", "MOCK_ID.json"),
+			wantErr: false,
+		},
+		{
+			name:    "empty id",
+			id:      "",
+			wantErr: true,
+		},
+		{
+			name:    "id with path separator",
+			id:      "../MOCK_ID",
+			wantErr: true,
+		},
+		{
+			name:    "parent directory id",
+			id:      "..",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.path(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("path() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("path() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArchive(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(os.TempDir(), "archive_result_test")
+	defer os.RemoveAll(dir)
+	storage, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("NewFileStorage() error = %v", err)
+	}
+	result := &Result{
+		Status:        pb.Status_STATUS_FINISHED,
+		RunOutput:     "MOCK_RUN_OUTPUT",
+		CompileOutput: "MOCK_COMPILE_OUTPUT",
+		Graph:         "MOCK_GRAPH",
+		ArchivedAt:    time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if err := Archive(ctx, storage, "MOCK_ID", result); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	got, err := GetArchivedResult(ctx, storage, "MOCK_ID")
+	if err != nil {
+		t.Fatalf("GetArchivedResult() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, result) {
+		t.Errorf("GetArchivedResult() got = %v, want %v", got, result)
+	}
+	if _, err := GetArchivedResult(ctx, storage, "UNKNOWN_ID"); err != ErrNotFound {
+		t.Errorf("GetArchivedResult() error = %v, wantErr %v", err, ErrNotFound)
+	}
+}
+
+func TestNewStorage(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "archive_storage_test")
+	defer os.RemoveAll(dir)
+	tests := []struct {
+		name     string
+		location string
+		wantType reflect.Type
+		wantErr  bool
+	}{
+		{
+			// Test case with empty location.
+			// As a result, want to receive nil storage.
+			name:     "empty location",
+			location: "",
+			wantType: nil,
+			wantErr:  false,
+		},
+		{
+			// Test case with location of the local directory.
+			// As a result, want to receive FileStorage.
+			name:     "local directory",
+			location: dir,
+			wantType: reflect.TypeOf(&FileStorage{}),
+			wantErr:  false,
+		},
+		{
+			// Test case with location of the bucket without name.
+			// As a result, want to receive an error.
+			name:     "bucket without name",
+			location: "gs://",
+			wantType: nil,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewStorage(context.Background(), tt.location)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewStorage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if reflect.TypeOf(got) != tt.wantType {
+				t.Errorf("NewStorage() got = %T, want %v", got, tt.wantType)
+			}
+		})
+	}
+}
+
+func Test_splitBucketPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantBucket string
+		wantPrefix string
+	}{
+		{
+			name:       "bucket only",
+			path:       "bucket",
+			wantBucket: "bucket",
+			wantPrefix: "",
+		},
+		{
+			name:       "bucket with prefix",
+			path:       "bucket/results/",
+			wantBucket: "bucket",
+			wantPrefix: "results",
+		},
+		{
+			name:       "bucket with nested prefix",
+			path:       "bucket/playground/results",
+			wantBucket: "bucket",
+			wantPrefix: "playground/results",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket, prefix := splitBucketPath(tt.path)
+			if bucket != tt.wantBucket || prefix != tt.wantPrefix {
+				t.Errorf("splitBucketPath() got = %v, %v, want %v, %v", bucket, prefix, tt.wantBucket, tt.wantPrefix)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	resultExtension = ".json"
+	dirPermission   = 0755
+	filePermission  = 0644
+)
+
+// FileStorage keeps results as files in the local directory.
+// It is used for the local development and tests.
+type FileStorage struct {
+	dir string
+}
+
+// NewFileStorage returns FileStorage which keeps results in dir. The directory is created if it doesn't exist.
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, dirPermission); err != nil {
+		return nil, err
+	}
+	return &FileStorage{dir: dir}, nil
+}
+
+// Put writes data to the file of id. The data is written to a temporary file first,
+//	so readers never see a partially written result.
+func (s *FileStorage) Put(_ context.Context, id string, data []byte) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	tmpFile, err := ioutil.TempFile(s.dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpFile.Name(), filePermission); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), path)
+}
+
+// Get reads data from the file of id
+func (s *FileStorage) Get(_ context.Context, id string) ([]byte, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// path returns path to the file of id. Ids which could point outside of the directory are rejected.
+func (s *FileStorage) path(id string) (string, error) {
+	if id == "" || id != filepath.Base(id) || id == "." || id == ".." {
+		return "", fmt.Errorf("incorrect id of the result: %s", id)
+	}
+	return filepath.Join(s.dir, id+resultExtension), nil
+}
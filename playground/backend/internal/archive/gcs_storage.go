@@ -0,0 +1,69 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"cloud.google.com/go/storage"
+	"context"
+	"io/ioutil"
+	"path"
+)
+
+// GcsStorage keeps results as objects in the Google Cloud Storage bucket.
+// The credentials of the server (i.e. the service account) should allow to read and write objects of the bucket.
+type GcsStorage struct {
+	client *storage.Client
+	bucket string
+	prefix string
+}
+
+// NewGcsStorage returns GcsStorage which keeps results in bucket under prefix
+func NewGcsStorage(ctx context.Context, bucket, prefix string) (*GcsStorage, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &GcsStorage{client: client, bucket: bucket, prefix: prefix}, nil
+}
+
+// Put uploads data as the object of id
+func (s *GcsStorage) Put(ctx context.Context, id string, data []byte) error {
+	writer := s.client.Bucket(s.bucket).Object(s.objectName(id)).NewWriter(ctx)
+	writer.ContentType = "application/json"
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+// Get downloads data of the object of id
+func (s *GcsStorage) Get(ctx context.Context, id string) ([]byte, error) {
+	reader, err := s.client.Bucket(s.bucket).Object(s.objectName(id)).NewReader(ctx)
+	if err == storage.ErrObjectNotExist {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// objectName returns name of the object of id
+func (s *GcsStorage) objectName(id string) string {
+	return path.Join(s.prefix, id+resultExtension)
+}
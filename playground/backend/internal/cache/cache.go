@@ -16,11 +16,26 @@
 package cache
 
 import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"context"
+	"encoding/json"
+	"errors"
 	"github.com/google/uuid"
+	"reflect"
 	"time"
 )
 
+// ErrNotFound is wrapped by the error which is returned by GetValue in case there is no value by pipelineId and subKey
+var ErrNotFound = errors.New("not found")
+
+// ErrNotSupported is wrapped by the error which is returned by the optional methods of the cache
+//	in case the wrapped cache doesn't implement them
+var ErrNotSupported = errors.New("not supported by the cache")
+
+// ErrIncompatibleVersion is wrapped by the error which is returned by GetValue and GetValues in case the value
+//	is saved in the format of the newer version of the cache, i.e. by the newer binary during the rolling deploy
+var ErrIncompatibleVersion = errors.New("incompatible cache version")
+
 // SubKey is used to keep value with Cache using nested structure like pipelineId:subKey:value
 type SubKey string
 
@@ -29,18 +44,92 @@ const (
 	// Status is used to keep playground.Status value
 	Status SubKey = "STATUS"
 
+	// Sdk is used to keep playground.Sdk of the code
+	Sdk SubKey = "SDK"
+
+	// StartTime is used to keep time.Time when the code processing is requested
+	StartTime SubKey = "START_TIME"
+
 	// RunOutput is used to keep run code output value
 	RunOutput SubKey = "RUN_OUTPUT"
 
+	// RunOutputFile is used to keep the path of the file with the full run output which exceeds the max output size,
+	//	the output in the cache is truncated in this case
+	RunOutputFile SubKey = "RUN_OUTPUT_FILE"
+
+	// SourcePipelineId is used to keep id of the finished pipeline whose code is run again by the pipeline with new pipeline options
+	SourcePipelineId SubKey = "SOURCE_PIPELINE_ID"
+
+	// Stdin is used to keep the standard input of the executed code, it isn't kept if the input is empty
+	Stdin SubKey = "STDIN"
+
+	// SdkVersion is used to keep the version of the SDK whose toolchain processes the code, it isn't kept for the latest version
+	SdkVersion SubKey = "SDK_VERSION"
+
+	// EntryPoint is used to keep the fully qualified name of the class which is run instead of the inferred main class,
+	//	it isn't kept if the main class is inferred
+	EntryPoint SubKey = "ENTRY_POINT"
+
 	// RunError is used to keep run code error value
 	RunError SubKey = "RUN_ERROR"
 
+	// RunLogs is used to keep stderr output of the run code, it is kept even if the code is finished successfully
+	RunLogs SubKey = "RUN_LOGS"
+
+	// CombinedLogs is used to keep stdout and stderr output of the run code merged in the order they were printed,
+	// each line is prefixed with the time it was read and tagged with [out] or [err]
+	CombinedLogs SubKey = "COMBINED_LOGS"
+
+	// RunCommand is used to keep command line of the run step with the values of sensitive environment variables redacted
+	RunCommand SubKey = "RUN_COMMAND"
+
+	// RunExitCode is used to keep exit code of the run code
+	RunExitCode SubKey = "RUN_EXIT_CODE"
+
+	// ErrorCategory is used to keep playground.ErrorCategory of the failed code processing
+	ErrorCategory SubKey = "ERROR_CATEGORY"
+
+	// CompileTime is used to keep duration of the compile step as time.Duration
+	CompileTime SubKey = "COMPILE_TIME"
+
+	// RunTime is used to keep duration of the run step as time.Duration
+	RunTime SubKey = "RUN_TIME"
+
+	// Warnings is used to keep list of warnings about the code which doesn't fail the code processing,
+	//	i.e. the code doesn't construct a Beam pipeline
+	Warnings SubKey = "WARNINGS"
+
+	// ValidationOutput is used to keep the reason of the failed validation
+	ValidationOutput SubKey = "VALIDATION_OUTPUT"
+
 	// CompileOutput is used to keep compilation output value
 	CompileOutput SubKey = "COMPILE_OUTPUT"
 
+	// CompileOutputStream is used to keep the error output of the compiler which is streamed while the code is compiling
+	CompileOutputStream SubKey = "COMPILE_OUTPUT_STREAM"
+
+	// CompileOutputStreamIndex is the index of the start of the compile output stream
+	CompileOutputStreamIndex SubKey = "COMPILE_OUTPUT_STREAM_INDEX"
+
+	// CompileErrors is used to keep list of playground.CompileError parsed from compilation output
+	CompileErrors SubKey = "COMPILE_ERRORS"
+
+	// CompileWarnings is used to keep list of playground.CompileError with the warnings of the compiler,
+	//	i.e. the messages parsed from compilation output which don't fail the compilation
+	CompileWarnings SubKey = "COMPILE_WARNINGS"
+
+	// TestResults is used to keep list of playground.TestCase parsed from the output of the test runner
+	TestResults SubKey = "TEST_RESULTS"
+
+	// PipelineMetrics is used to keep list of playground.PipelineMetric reported by the runner of the executed pipeline
+	PipelineMetrics SubKey = "PIPELINE_METRICS"
+
 	// Canceled is used to keep the canceled status
 	Canceled SubKey = "CANCELED"
 
+	// CanceledPhase is used to keep playground.CancelPhase which is requested to be canceled
+	CanceledPhase SubKey = "CANCELED_PHASE"
+
 	// RunOutputIndex is the index of the start of the run step's output
 	RunOutputIndex SubKey = "RUN_OUTPUT_INDEX"
 
@@ -49,6 +138,27 @@ const (
 
 	// LogsIndex is the index of the start of the log
 	LogsIndex SubKey = "LOGS_INDEX"
+
+	// Graph is used to keep graph of the pipeline in DOT format
+	Graph SubKey = "GRAPH"
+
+	// LastAccessed is used to keep time.Time of the last read of the status or the output of the pipeline
+	LastAccessed SubKey = "LAST_ACCESSED"
+
+	// ExamplesCatalog is used to keep list of playground.Example scanned from the examples directory
+	ExamplesCatalog SubKey = "EXAMPLES_CATALOG"
+
+	// IdempotencyClaims is used to keep the count of the requests with the same idempotency key
+	IdempotencyClaims SubKey = "IDEMPOTENCY_CLAIMS"
+
+	// IdempotentPipelineId is used to keep id of the pipeline which is started by the request with the idempotency key
+	IdempotentPipelineId SubKey = "IDEMPOTENT_PIPELINE_ID"
+
+	// IdempotencyPayloadHash is used to keep the hash of the request which has claimed the idempotency key
+	IdempotencyPayloadHash SubKey = "IDEMPOTENCY_PAYLOAD_HASH"
+
+	// ClientActivePipelines is used to keep the count of the pipelines of the client which are in progress
+	ClientActivePipelines SubKey = "CLIENT_ACTIVE_PIPELINES"
 )
 
 // Cache is used to store states and outputs for Apache Beam pipelines that running in Playground
@@ -62,11 +172,97 @@ const (
 // pipelineId is uuid that calculates in the controller when the server takes new request to run code
 type Cache interface {
 	// GetValue returns value from cache by pipelineId and subKey.
+	// In case the value doesn't exist (or is expired) returns an error which wraps ErrNotFound.
+	// In case the value is saved in the format which isn't supported returns an error which wraps ErrIncompatibleVersion.
 	GetValue(ctx context.Context, pipelineId uuid.UUID, subKey SubKey) (interface{}, error)
 
+	// GetValues returns values from cache by pipelineId and subKeys atomically, so all values are read at the same moment.
+	// Values which don't exist aren't added to the result.
+	// In case there are no values by pipelineId and subKeys (or they are expired) returns an error which wraps ErrNotFound.
+	// In case any value is saved in the format which isn't supported returns an error which wraps ErrIncompatibleVersion.
+	GetValues(ctx context.Context, pipelineId uuid.UUID, subKeys []SubKey) (map[SubKey]interface{}, error)
+
 	// SetValue adds value to cache by pipelineId and subKey.
 	SetValue(ctx context.Context, pipelineId uuid.UUID, subKey SubKey, value interface{}) error
 
+	// UpdateValue updates value in cache by pipelineId and subKey only if the pipeline exists,
+	//	so the removed (or expired) pipeline isn't created again without expiration time.
+	// In case the pipeline doesn't exist (or is expired) returns an error which wraps ErrNotFound.
+	UpdateValue(ctx context.Context, pipelineId uuid.UUID, subKey SubKey, value interface{}) error
+
+	// IncrementValue atomically adds delta to the integer value by pipelineId and subKey and returns the new value.
+	// If the value doesn't exist it is considered as 0.
+	IncrementValue(ctx context.Context, pipelineId uuid.UUID, subKey SubKey, delta int) (int, error)
+
 	// SetExpTime adds expiration time of the pipeline to cache by pipelineId.
 	SetExpTime(ctx context.Context, pipelineId uuid.UUID, expTime time.Duration) error
 }
+
+// StatusNotifier is implemented by caches which notify about changes of the Status value,
+//	so the change of the status could be waited for without polling the cache.
+type StatusNotifier interface {
+	// StatusChanged returns a channel which is closed when the Status value by pipelineId is set next time
+	//	or the pipeline is removed from the cache.
+	// Returns nil if the cache doesn't notify about changes of the status.
+	StatusChanged(pipelineId uuid.UUID) <-chan struct{}
+}
+
+// ActivePipelinesLister is implemented by caches which keep the index of the pipelines in progress,
+//	so they could be listed without scanning the whole cache.
+type ActivePipelinesLister interface {
+	// ActivePipelines returns ids of the pipelines which Status value isn't final.
+	// Returns an error which wraps ErrNotSupported if the cache doesn't keep the index of the pipelines.
+	ActivePipelines(ctx context.Context) ([]uuid.UUID, error)
+}
+
+// UnmarshalValue unmarshals the JSON value by subKey into the type of the values which are kept by subKey
+//	(i.e. playground.Status for Status), so the caches which keep the values as JSON return them with the same types as they are set.
+// Values by unknown subKeys are unmarshalled as is (i.e. numbers become float64).
+func UnmarshalValue(subKey SubKey, data []byte) (interface{}, error) {
+	typed := newValueBySubKey(subKey)
+	if typed == nil {
+		var result interface{}
+		err := json.Unmarshal(data, &result)
+		return result, err
+	}
+	// values are unmarshalled into typed pointers so numbers don't become float64
+	if err := json.Unmarshal(data, typed); err != nil {
+		return nil, err
+	}
+	return reflect.ValueOf(typed).Elem().Interface(), nil
+}
+
+// newValueBySubKey returns a pointer to the new value of the type which is kept by subKey or nil if subKey is unknown
+func newValueBySubKey(subKey SubKey) interface{} {
+	switch subKey {
+	case Status:
+		return new(pb.Status)
+	case Sdk:
+		return new(pb.Sdk)
+	case ErrorCategory:
+		return new(pb.ErrorCategory)
+	case CanceledPhase:
+		return new(pb.CancelPhase)
+	case RunOutput, RunOutputFile, RunError, RunLogs, CombinedLogs, RunCommand, ValidationOutput, CompileOutput, Logs, Graph, IdempotentPipelineId, IdempotencyPayloadHash, SourcePipelineId, Stdin, SdkVersion, EntryPoint:
+		return new(string)
+	case CompileErrors, CompileWarnings:
+		return new([]*pb.CompileError)
+	case Warnings:
+		return new([]string)
+	case TestResults:
+		return new([]*pb.TestCase)
+	case PipelineMetrics:
+		return new([]*pb.PipelineMetric)
+	case ExamplesCatalog:
+		return new([]*pb.Example)
+	case Canceled:
+		return new(bool)
+	case CompileTime, RunTime:
+		return new(time.Duration)
+	case LastAccessed, StartTime:
+		return new(time.Time)
+	case RunOutputIndex, CompileOutputStreamIndex, LogsIndex, RunExitCode, IdempotencyClaims, ClientActivePipelines:
+		return new(int)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package encrypted
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"strings"
+)
+
+// encryptedValuePrefix marks the string values which are encrypted by Cache,
+//	so the values kept before the encryption is enabled are still read as is
+const encryptedValuePrefix = "ENCRYPTED:v1:"
+
+// Cache is a decorator of cache.Cache which encrypts values kept in the wrapped cache with AES-GCM.
+// String values (i.e. the code, outputs and logs of the pipeline) are encrypted on SetValue and UpdateValue and decrypted
+//	on GetValue and GetValues, so the wrapped cache keeps only the ciphertext of them.
+// Other values (i.e. playground.Status or counters) are passed to the wrapped cache as is,
+//	so they are kept in the format which is expected by the wrapped cache.
+// Errors of the wrapped cache are kept, i.e. GetValue returns an error which wraps cache.ErrNotFound for missing values.
+type Cache struct {
+	cache.Cache
+	aead cipher.AEAD
+}
+
+// New returns cache.Cache which wraps cacheService and encrypts its string values with key.
+// The key should be 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+func New(cacheService cache.Cache, key []byte) (*Cache, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create cipher for the cache: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create cipher for the cache: %w", err)
+	}
+	return &Cache{Cache: cacheService, aead: aead}, nil
+}
+
+// GetValue returns value from the wrapped cache by pipelineId and subKey and decrypts it if it is encrypted.
+// In case the value couldn't be decrypted (i.e. it is encrypted with another key) returns an error.
+func (c *Cache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey) (interface{}, error) {
+	value, err := c.Cache.GetValue(ctx, pipelineId, subKey)
+	if err != nil {
+		return value, err
+	}
+	return c.decrypt(pipelineId, subKey, value)
+}
+
+// GetValues returns values from the wrapped cache by pipelineId and subKeys and decrypts the encrypted ones.
+// In case any value couldn't be decrypted returns an error.
+func (c *Cache) GetValues(ctx context.Context, pipelineId uuid.UUID, subKeys []cache.SubKey) (map[cache.SubKey]interface{}, error) {
+	values, err := c.Cache.GetValues(ctx, pipelineId, subKeys)
+	if err != nil {
+		return values, err
+	}
+	for subKey, value := range values {
+		decrypted, err := c.decrypt(pipelineId, subKey, value)
+		if err != nil {
+			return nil, err
+		}
+		values[subKey] = decrypted
+	}
+	return values, nil
+}
+
+// SetValue encrypts value if it is a string and adds it to the wrapped cache by pipelineId and subKey
+func (c *Cache) SetValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) error {
+	value, err := c.encryptValue(pipelineId, subKey, value)
+	if err != nil {
+		return err
+	}
+	return c.Cache.SetValue(ctx, pipelineId, subKey, value)
+}
+
+// UpdateValue encrypts value if it is a string and updates it in the wrapped cache by pipelineId and subKey
+//	only if the pipeline exists
+func (c *Cache) UpdateValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) error {
+	value, err := c.encryptValue(pipelineId, subKey, value)
+	if err != nil {
+		return err
+	}
+	return c.Cache.UpdateValue(ctx, pipelineId, subKey, value)
+}
+
+// StatusChanged returns the channel of the wrapped cache which is closed when the status of the pipeline is set.
+// Returns nil if the wrapped cache doesn't notify about changes of the status.
+func (c *Cache) StatusChanged(pipelineId uuid.UUID) <-chan struct{} {
+	if notifier, ok := c.Cache.(cache.StatusNotifier); ok {
+		return notifier.StatusChanged(pipelineId)
+	}
+	return nil
+}
+
+// ActivePipelines returns ids of the pipelines in progress from the wrapped cache.
+// Returns an error which wraps cache.ErrNotSupported if the wrapped cache doesn't keep the index of the pipelines.
+func (c *Cache) ActivePipelines(ctx context.Context) ([]uuid.UUID, error) {
+	if lister, ok := c.Cache.(cache.ActivePipelinesLister); ok {
+		return lister.ActivePipelines(ctx)
+	}
+	return nil, fmt.Errorf("active pipelines: %w", cache.ErrNotSupported)
+}
+
+// encryptValue returns the encrypted value if it is a string and value as is otherwise
+func (c *Cache) encryptValue(pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) (interface{}, error) {
+	plaintext, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	return c.encrypt(pipelineId, subKey, plaintext)
+}
+
+// encrypt returns the base64 encoded nonce and ciphertext of plaintext prefixed with encryptedValuePrefix.
+// pipelineId and subKey are authenticated with the ciphertext, so the value couldn't be moved to another key.
+func (c *Cache) encrypt(pipelineId uuid.UUID, subKey cache.SubKey, plaintext string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("couldn't generate nonce to encrypt %s: %w", subKey, err)
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), additionalData(pipelineId, subKey))
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt returns the plaintext of value if it is encrypted by encrypt and value as is otherwise
+func (c *Cache) decrypt(pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) (interface{}, error) {
+	encrypted, ok := value.(string)
+	if !ok || !strings.HasPrefix(encrypted, encryptedValuePrefix) {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(encrypted, encryptedValuePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decrypt %s: %w", subKey, err)
+	}
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, fmt.Errorf("couldn't decrypt %s: ciphertext is too short", subKey)
+	}
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], additionalData(pipelineId, subKey))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decrypt %s: %w", subKey, err)
+	}
+	return string(plaintext), nil
+}
+
+// additionalData returns the data which is authenticated with the ciphertext of the value by pipelineId and subKey
+func additionalData(pipelineId uuid.UUID, subKey cache.SubKey) []byte {
+	return []byte(pipelineId.String() + ":" + string(subKey))
+}
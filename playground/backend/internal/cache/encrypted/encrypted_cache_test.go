@@ -0,0 +1,322 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package encrypted
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/cache/local"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     []byte
+		wantErr bool
+	}{
+		{
+			// Test case with calling New method with the key of AES-256.
+			// As a result, want to receive the cache without an error.
+			name:    "aes-256 key",
+			key:     testKey,
+			wantErr: false,
+		},
+		{
+			// Test case with calling New method with the key of incorrect length.
+			// As a result, want to receive an error.
+			name:    "incorrect key length",
+			key:     []byte("MOCK_KEY"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(local.New(context.Background()), tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCache_SetValue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	localCache := local.New(ctx)
+	encryptedCache, err := New(localCache, testKey)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		subKey        cache.SubKey
+		value         interface{}
+		wantEncrypted bool
+	}{
+		{
+			// Test case with calling SetValue method with the output of the pipeline.
+			// As a result, want to keep the ciphertext in the wrapped cache and to read the output back.
+			name:          "string value",
+			subKey:        cache.RunOutput,
+			value:         "MOCK_RUN_OUTPUT",
+			wantEncrypted: true,
+		},
+		{
+			// Test case with calling SetValue method with the empty output of the pipeline.
+			// As a result, want to keep the ciphertext in the wrapped cache and to read the empty output back.
+			name:          "empty string value",
+			subKey:        cache.RunError,
+			value:         "",
+			wantEncrypted: true,
+		},
+		{
+			// Test case with calling SetValue method with the status of the pipeline.
+			// As a result, want to keep the status in the wrapped cache as is and to read it back.
+			name:          "status value",
+			subKey:        cache.Status,
+			value:         pb.Status_STATUS_FINISHED,
+			wantEncrypted: false,
+		},
+		{
+			// Test case with calling SetValue method with the list of compile errors.
+			// As a result, want to keep the list in the wrapped cache as is and to read it back.
+			name:          "list value",
+			subKey:        cache.CompileErrors,
+			value:         []*pb.CompileError{{File: "MOCK_FILE", Line: 1, Severity: "error", Message: "MOCK_MESSAGE"}},
+			wantEncrypted: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			if err := encryptedCache.SetValue(ctx, pipelineId, tt.subKey, tt.value); err != nil {
+				t.Fatalf("SetValue() error = %v", err)
+			}
+			stored, err := localCache.GetValue(ctx, pipelineId, tt.subKey)
+			if err != nil {
+				t.Fatalf("GetValue() of the wrapped cache error = %v", err)
+			}
+			if tt.wantEncrypted {
+				ciphertext, ok := stored.(string)
+				if !ok || !strings.HasPrefix(ciphertext, encryptedValuePrefix) || ciphertext == tt.value {
+					t.Errorf("SetValue() kept %v in the wrapped cache, want the ciphertext", stored)
+				}
+				if tt.value != "" && strings.Contains(ciphertext, tt.value.(string)) {
+					t.Errorf("SetValue() kept %v in the wrapped cache which contains the plaintext", stored)
+				}
+			} else if !reflect.DeepEqual(stored, tt.value) {
+				t.Errorf("SetValue() kept %v in the wrapped cache, want %v", stored, tt.value)
+			}
+			got, err := encryptedCache.GetValue(ctx, pipelineId, tt.subKey)
+			if err != nil {
+				t.Fatalf("GetValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.value) {
+				t.Errorf("GetValue() = %v, want %v", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestCache_UpdateValue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	localCache := local.New(ctx)
+	encryptedCache, err := New(localCache, testKey)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	pipelineId := uuid.New()
+	if err := encryptedCache.UpdateValue(ctx, pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT"); !errors.Is(err, cache.ErrNotFound) {
+		t.Errorf("UpdateValue() for the pipeline which isn't in the cache error = %v, want %v", err, cache.ErrNotFound)
+	}
+
+	if err := encryptedCache.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	if err := encryptedCache.UpdateValue(ctx, pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT"); err != nil {
+		t.Fatalf("UpdateValue() error = %v", err)
+	}
+	stored, _ := localCache.GetValue(ctx, pipelineId, cache.RunOutput)
+	if ciphertext, ok := stored.(string); !ok || !strings.HasPrefix(ciphertext, encryptedValuePrefix) {
+		t.Errorf("UpdateValue() kept %v in the wrapped cache, want the ciphertext", stored)
+	}
+	got, err := encryptedCache.GetValue(ctx, pipelineId, cache.RunOutput)
+	if err != nil || got != "MOCK_RUN_OUTPUT" {
+		t.Errorf("GetValue() = %v, %v, want %v", got, err, "MOCK_RUN_OUTPUT")
+	}
+}
+
+func TestCache_GetValue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	localCache := local.New(ctx)
+	encryptedCache, err := New(localCache, testKey)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	anotherKeyCache, err := New(localCache, []byte("fedcba9876543210"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	pipelineId := uuid.New()
+	if err := encryptedCache.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	if err := localCache.SetValue(ctx, pipelineId, cache.Logs, "MOCK_LOGS"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	// the ciphertext of the run output is moved to the run error of another pipeline
+	movedPipelineId := uuid.New()
+	ciphertext, _ := localCache.GetValue(ctx, pipelineId, cache.RunOutput)
+	if err := localCache.SetValue(ctx, movedPipelineId, cache.RunError, ciphertext); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		cacheService *Cache
+		pipelineId   uuid.UUID
+		subKey       cache.SubKey
+		want         interface{}
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			// Test case with calling GetValue method with the subKey which value is encrypted.
+			// As a result, want to receive the plaintext of the value.
+			name:         "encrypted value",
+			cacheService: encryptedCache,
+			pipelineId:   pipelineId,
+			subKey:       cache.RunOutput,
+			want:         "MOCK_RUN_OUTPUT",
+			wantErr:      false,
+		},
+		{
+			// Test case with calling GetValue method with the subKey which value is kept before the encryption is enabled.
+			// As a result, want to receive the value as is.
+			name:         "unencrypted value",
+			cacheService: encryptedCache,
+			pipelineId:   pipelineId,
+			subKey:       cache.Logs,
+			want:         "MOCK_LOGS",
+			wantErr:      false,
+		},
+		{
+			// Test case with calling GetValue method with the subKey which value doesn't exist.
+			// As a result, want to receive an error which wraps cache.ErrNotFound.
+			name:         "missing value",
+			cacheService: encryptedCache,
+			pipelineId:   pipelineId,
+			subKey:       cache.Graph,
+			want:         nil,
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			// Test case with calling GetValue method of the cache with another key.
+			// As a result, want to receive an error.
+			name:         "another key",
+			cacheService: anotherKeyCache,
+			pipelineId:   pipelineId,
+			subKey:       cache.RunOutput,
+			want:         nil,
+			wantErr:      true,
+		},
+		{
+			// Test case with calling GetValue method with the subKey which value is encrypted for another pipeline and subKey.
+			// As a result, want to receive an error.
+			name:         "moved value",
+			cacheService: encryptedCache,
+			pipelineId:   movedPipelineId,
+			subKey:       cache.RunError,
+			want:         nil,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cacheService.GetValue(ctx, tt.pipelineId, tt.subKey)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, cache.ErrNotFound) != tt.wantNotFound {
+				t.Errorf("GetValue() error = %v, want cache.ErrNotFound %v", err, tt.wantNotFound)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCache_GetValues(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	encryptedCache, err := New(local.New(ctx), testKey)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	pipelineId := uuid.New()
+	if err := encryptedCache.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_RUN_ERROR); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	if err := encryptedCache.SetValue(ctx, pipelineId, cache.RunError, "MOCK_RUN_ERROR"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+
+	got, err := encryptedCache.GetValues(ctx, pipelineId, []cache.SubKey{cache.Status, cache.RunError, cache.RunOutput})
+	if err != nil {
+		t.Fatalf("GetValues() error = %v", err)
+	}
+	want := map[cache.SubKey]interface{}{cache.Status: pb.Status_STATUS_RUN_ERROR, cache.RunError: "MOCK_RUN_ERROR"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetValues() = %v, want %v", got, want)
+	}
+
+	if _, err := encryptedCache.GetValues(ctx, uuid.New(), []cache.SubKey{cache.Status}); !errors.Is(err, cache.ErrNotFound) {
+		t.Errorf("GetValues() error = %v, want cache.ErrNotFound", err)
+	}
+}
+
+func TestCache_ActivePipelines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	encryptedCache, err := New(local.New(ctx), testKey)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	pipelineId := uuid.New()
+	if err := encryptedCache.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+
+	got, err := encryptedCache.ActivePipelines(ctx)
+	if err != nil {
+		t.Fatalf("ActivePipelines() error = %v", err)
+	}
+	if want := []uuid.UUID{pipelineId}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ActivePipelines() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,665 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// fileVersion is the version of the format of the files of the pipelines.
+	// It should be incremented each time the format of the files is changed incompatibly.
+	// The outputs of the pipelines are kept in the output files since version 2.
+	fileVersion = 2
+	// fileExt is the extension of the files of the pipelines, the name of the file is the id of the pipeline
+	fileExt = ".json"
+	// outputFileExt is the extension of the output files of the pipelines,
+	//	the name of the output file is the id of the pipeline and the subKey of the output, i.e. "{pipelineId}.RUN_OUTPUT.out"
+	outputFileExt = ".out"
+	// tempFileExt is the extension of the file which is written before it replaces the file of the pipeline,
+	//	so the file of the pipeline is never written partially
+	tempFileExt = ".tmp"
+	// corruptFileExt is added to the name of the file which couldn't be loaded, so it is kept for investigation
+	//	but isn't loaded again
+	corruptFileExt  = ".corrupt"
+	dirPermissions  = 0700
+	filePermissions = 0600
+	cleanupInterval = 5 * time.Second
+)
+
+// outputSubKeys are the subKeys which string values are kept in the output files of the pipelines instead of the files of the pipelines.
+// The outputs grow while the code is processed, so only the appended text is written to the end of the output file
+//	instead of rewriting all values of the pipeline each time the output is set.
+var outputSubKeys = map[cache.SubKey]bool{
+	cache.RunOutput:        true,
+	cache.RunError:         true,
+	cache.RunLogs:          true,
+	cache.CombinedLogs:     true,
+	cache.CompileOutput:    true,
+	cache.ValidationOutput: true,
+	cache.Logs:             true,
+	cache.Graph:            true,
+}
+
+// pipelineFile is the content of the file of the pipeline
+type pipelineFile struct {
+	Version int `json:"version"`
+	// Expiration is the time after which the pipeline is removed from the cache, the pipeline isn't expired if it isn't set
+	Expiration *time.Time `json:"expiration,omitempty"`
+	// Values are the marshalled values of the pipeline by subKeys except outputSubKeys
+	Values map[cache.SubKey]json.RawMessage `json:"values"`
+}
+
+// isExpired checks if the expiration time of the pipeline is passed
+func (pf *pipelineFile) isExpired() bool {
+	return pf.Expiration != nil && pf.Expiration.Before(time.Now())
+}
+
+// pipeline keeps the values of the pipeline as they are saved in its files.
+// The values are read and written under the lock of the pipeline, so the pipelines are accessed concurrently.
+type pipeline struct {
+	sync.Mutex
+	file pipelineFile
+	// outputs are the values by outputSubKeys as they are saved in the output files of the pipeline
+	outputs map[cache.SubKey]string
+	// saved is true if the file of the pipeline is written, so the output files of the pipeline are loaded after the restart
+	saved bool
+	// deleted is true if the pipeline is removed from the cache, so the values shouldn't be set to it anymore
+	deleted bool
+}
+
+type Cache struct {
+	// mu guards pipelines. It is taken before the lock of the pipeline if both of them are needed.
+	mu              sync.RWMutex
+	dir             string
+	cleanupInterval time.Duration
+	pipelines       map[uuid.UUID]*pipeline
+	// isCompleted checks if the value of cache.Status is the final status of the pipeline
+	isCompleted func(status interface{}) bool
+	// interruptedExpiration is the expiration time of the pipelines which processing is interrupted by the restart of the server
+	interruptedExpiration time.Duration
+}
+
+// New returns file implementation of Cache interface which keeps the values of each pipeline as JSON
+//	in a separate file in dir, so the values survive the restart of the server.
+// The pipelines which processing is interrupted by the restart of the server aren't recovered (see NewWithRecovery).
+func New(ctx context.Context, dir string) (*Cache, error) {
+	return NewWithRecovery(ctx, dir, nil, 0)
+}
+
+// NewWithRecovery returns file implementation of Cache interface which keeps the values of each pipeline as JSON
+//	in a separate file in dir, so the values survive the restart of the server.
+// The outputs of the pipelines (see outputSubKeys) are kept in the separate output files which are appended while the outputs grow.
+// The files of the pipelines which are kept in dir are loaded, the files of expired pipelines are removed.
+// The files which couldn't be loaded (i.e. corrupt files) are skipped with an error in the logs and renamed,
+//	so they don't prevent the start of the server and aren't loaded again.
+// The loaded pipelines which cache.Status isn't completed according to isCompleted are processed by the server which is stopped,
+//	so they are never completed. They get playground.Status_STATUS_ERROR status with the internal error category
+//	and are removed from the cache after interruptedExpiration.
+//	If isCompleted isn't set, the loaded pipelines are kept as is.
+// In case dir couldn't be created or read returns an error.
+func NewWithRecovery(ctx context.Context, dir string, isCompleted func(status interface{}) bool, interruptedExpiration time.Duration) (*Cache, error) {
+	if err := os.MkdirAll(dir, dirPermissions); err != nil {
+		logger.Errorf("File Cache: create directory: error during creating directory: %s, err: %s\n", dir, err.Error())
+		return nil, err
+	}
+	fc := &Cache{
+		dir:                   dir,
+		cleanupInterval:       cleanupInterval,
+		pipelines:             make(map[uuid.UUID]*pipeline),
+		isCompleted:           isCompleted,
+		interruptedExpiration: interruptedExpiration,
+	}
+	if err := fc.load(); err != nil {
+		return nil, err
+	}
+
+	go fc.startGC(ctx)
+	return fc, nil
+}
+
+// GetValue returns value from cache. If not found or the pipeline is expired, GetValue returns an error.
+func (fc *Cache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey) (interface{}, error) {
+	values, err := fc.values(pipelineId, []cache.SubKey{subKey})
+	if err != nil {
+		return nil, err
+	}
+	value, found := values[subKey]
+	if !found {
+		return nil, fmt.Errorf("value with pipelineId: %s and subKey: %s %w", pipelineId, subKey, cache.ErrNotFound)
+	}
+	return value, nil
+}
+
+// GetValues returns values from cache by subKeys. All values are read under the lock of the pipeline, so they are consistent
+//	with each other even if they are changed concurrently. Values which don't exist aren't added to the result.
+// If there are no values by subKeys or the pipeline is expired, GetValues returns an error.
+func (fc *Cache) GetValues(ctx context.Context, pipelineId uuid.UUID, subKeys []cache.SubKey) (map[cache.SubKey]interface{}, error) {
+	values, err := fc.values(pipelineId, subKeys)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("values with pipelineId: %s and subKeys: %s %w", pipelineId, subKeys, cache.ErrNotFound)
+	}
+	return values, nil
+}
+
+// SetValue puts element to cache and saves the file of the pipeline.
+// The value by the subKey of the output (see outputSubKeys) should be a string, it is saved to the output file of the pipeline.
+// If a particular pipelineId does not contain in the cache (or is expired), SetValue creates a new element for this pipelineId
+//	without expiration time. Use SetExpTime to set expiration time for cache elements.
+func (fc *Cache) SetValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) error {
+	return fc.setValue(pipelineId, subKey, value, true)
+}
+
+// UpdateValue puts element to cache and saves the file of the pipeline only if the pipeline is contained in the cache
+//	and isn't expired. If the pipeline doesn't exist or is expired, UpdateValue returns an error.
+func (fc *Cache) UpdateValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) error {
+	return fc.setValue(pipelineId, subKey, value, false)
+}
+
+// setValue puts element to cache as described for SetValue.
+// If the pipeline doesn't exist or is expired, it is created if create is true, otherwise returns an error.
+func (fc *Cache) setValue(pipelineId uuid.UUID, subKey cache.SubKey, value interface{}, create bool) error {
+	if outputSubKeys[subKey] {
+		output, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("value with pipelineId: %s and subKey: %s isn't a string", pipelineId, subKey)
+		}
+		return fc.setOutput(pipelineId, subKey, output, create)
+	}
+	valueMarsh, err := json.Marshal(value)
+	if err != nil {
+		logger.Errorf("File Cache: set value: error during marshal value: %s, err: %s\n", value, err.Error())
+		return err
+	}
+	return fc.updatePipeline(pipelineId, create, func(file *pipelineFile) error {
+		file.Values[subKey] = valueMarsh
+		return nil
+	})
+}
+
+// IncrementValue adds delta to the integer value in the cache, saves the file of the pipeline and returns the new value.
+// The whole read-modify-write is done under the lock of the pipeline, so concurrent increments aren't lost.
+// If the value doesn't exist in the cache, IncrementValue sets delta as the value.
+// If the value isn't an integer, IncrementValue returns an error.
+func (fc *Cache) IncrementValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, delta int) (int, error) {
+	if outputSubKeys[subKey] {
+		return 0, fmt.Errorf("value with pipelineId: %s and subKey: %s isn't an integer", pipelineId, subKey)
+	}
+	var result int
+	err := fc.updatePipeline(pipelineId, true, func(file *pipelineFile) error {
+		current := 0
+		if rawValue, found := file.Values[subKey]; found {
+			if err := json.Unmarshal(rawValue, &current); err != nil {
+				return fmt.Errorf("value with pipelineId: %s and subKey: %s isn't an integer", pipelineId, subKey)
+			}
+		}
+		result = current + delta
+		valueMarsh, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		file.Values[subKey] = valueMarsh
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// SetExpTime sets expiration time to particular pipelineId in cache and saves the file of the pipeline.
+// If pipelineId doesn't present in the cache (or is expired), SetExpTime returns an error.
+func (fc *Cache) SetExpTime(ctx context.Context, pipelineId uuid.UUID, expTime time.Duration) error {
+	return fc.updatePipeline(pipelineId, false, func(file *pipelineFile) error {
+		expiration := time.Now().Add(expTime)
+		file.Expiration = &expiration
+		return nil
+	})
+}
+
+// values returns the values of the pipeline by subKeys.
+// Returns no values if the pipeline doesn't exist or is expired. The expired pipeline is removed from the cache.
+// The values are unmarshalled after the lock of the pipeline is released.
+func (fc *Cache) values(pipelineId uuid.UUID, subKeys []cache.SubKey) (map[cache.SubKey]interface{}, error) {
+	p := fc.getPipeline(pipelineId, false)
+	if p == nil {
+		return nil, nil
+	}
+	p.Lock()
+	if p.file.isExpired() {
+		p.Unlock()
+		fc.deletePipeline(pipelineId)
+		return nil, nil
+	}
+	values := make(map[cache.SubKey]interface{}, len(subKeys))
+	rawValues := make(map[cache.SubKey]json.RawMessage, len(subKeys))
+	for _, subKey := range subKeys {
+		if output, found := p.outputs[subKey]; found {
+			values[subKey] = output
+		} else if rawValue, found := p.file.Values[subKey]; found {
+			rawValues[subKey] = rawValue
+		}
+	}
+	p.Unlock()
+	for subKey, rawValue := range rawValues {
+		value, err := unmarshalValue(subKey, rawValue)
+		if err != nil {
+			return nil, err
+		}
+		values[subKey] = value
+	}
+	return values, nil
+}
+
+// updatePipeline calls update with the values of the pipeline under the lock of the pipeline
+//	and saves the file of the pipeline if update returns no error.
+// If the pipeline doesn't exist or is expired, it is created if create is true, otherwise returns an error.
+func (fc *Cache) updatePipeline(pipelineId uuid.UUID, create bool, update func(file *pipelineFile) error) error {
+	p, err := fc.lockPipeline(pipelineId, create)
+	if err != nil {
+		return err
+	}
+	defer p.Unlock()
+	if err := update(&p.file); err != nil {
+		return err
+	}
+	return fc.save(pipelineId, p)
+}
+
+// setOutput sets the output of the pipeline by subKey under the lock of the pipeline and saves it to the output file.
+// If output is the previous output with the appended text, only the appended text is written to the end of the output file,
+//	otherwise the output file is replaced with the whole output. The file of the pipeline isn't saved again,
+//	so setting the output which grows doesn't rewrite the values of the pipeline each time.
+// If the pipeline doesn't exist or is expired, it is created if create is true, otherwise returns an error.
+func (fc *Cache) setOutput(pipelineId uuid.UUID, subKey cache.SubKey, output string, create bool) error {
+	p, err := fc.lockPipeline(pipelineId, create)
+	if err != nil {
+		return err
+	}
+	defer p.Unlock()
+	// the file of the pipeline is saved first, so the output file isn't removed as an orphan after the restart
+	if !p.saved {
+		if err := fc.save(pipelineId, p); err != nil {
+			return err
+		}
+	}
+	filePath := fc.outputFilePath(pipelineId, subKey)
+	previous, found := p.outputs[subKey]
+	if found && strings.HasPrefix(output, previous) {
+		err = appendFile(filePath, output[len(previous):])
+	} else {
+		err = replaceFile(filePath, []byte(output))
+	}
+	if err != nil {
+		// the output file could be written partially, so it is replaced with the whole output next time
+		delete(p.outputs, subKey)
+		logger.Errorf("File Cache: set output: error during writing output file of the pipeline: %s, err: %s\n", pipelineId, err.Error())
+		return err
+	}
+	p.outputs[subKey] = output
+	return nil
+}
+
+// lockPipeline returns the pipeline by pipelineId with its lock taken.
+// If the pipeline doesn't exist or is expired, it is created if create is true, otherwise returns an error.
+func (fc *Cache) lockPipeline(pipelineId uuid.UUID, create bool) (*pipeline, error) {
+	for {
+		p := fc.getPipeline(pipelineId, create)
+		if p == nil {
+			return nil, fmt.Errorf("%s pipeline id doesn't presented in cache: %w", pipelineId.String(), cache.ErrNotFound)
+		}
+		p.Lock()
+		// the pipeline is removed after it is got, so it is got again
+		if p.deleted {
+			p.Unlock()
+			continue
+		}
+		if p.file.isExpired() {
+			if !create {
+				p.Unlock()
+				return nil, fmt.Errorf("%s pipeline id doesn't presented in cache: %w", pipelineId.String(), cache.ErrNotFound)
+			}
+			fc.removeOutputFiles(pipelineId)
+			p.file = newPipelineFile()
+			p.outputs = make(map[cache.SubKey]string)
+		}
+		return p, nil
+	}
+}
+
+// getPipeline returns the pipeline by pipelineId.
+// If the pipeline doesn't exist, creates it if create is true, otherwise returns nil.
+func (fc *Cache) getPipeline(pipelineId uuid.UUID, create bool) *pipeline {
+	fc.mu.RLock()
+	p := fc.pipelines[pipelineId]
+	fc.mu.RUnlock()
+	if p != nil || !create {
+		return p
+	}
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if p = fc.pipelines[pipelineId]; p == nil {
+		p = &pipeline{file: newPipelineFile(), outputs: make(map[cache.SubKey]string)}
+		fc.pipelines[pipelineId] = p
+	}
+	return p
+}
+
+// deletePipeline removes the pipeline from the cache and removes its files.
+// The files are removed under the lock of the cache, so the files of the pipeline which is created again aren't removed.
+func (fc *Cache) deletePipeline(pipelineId uuid.UUID) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	p, found := fc.pipelines[pipelineId]
+	if !found {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.deleted = true
+	delete(fc.pipelines, pipelineId)
+	if err := os.Remove(fc.filePath(pipelineId)); err != nil && !os.IsNotExist(err) {
+		logger.Errorf("File Cache: delete pipeline: error during removing file of the pipeline: %s, err: %s\n", pipelineId, err.Error())
+	}
+	fc.removeOutputFiles(pipelineId)
+}
+
+// removeOutputFiles removes the output files of the pipeline.
+// Should be called under the lock of the pipeline.
+func (fc *Cache) removeOutputFiles(pipelineId uuid.UUID) {
+	for subKey := range outputSubKeys {
+		if err := os.Remove(fc.outputFilePath(pipelineId, subKey)); err != nil && !os.IsNotExist(err) {
+			logger.Errorf("File Cache: delete pipeline: error during removing output file of the pipeline: %s, err: %s\n", pipelineId, err.Error())
+		}
+	}
+}
+
+// save writes the file of the pipeline. The file is written to the temporary file which replaces the file of the pipeline,
+//	so the file of the pipeline isn't corrupted if the server is stopped while the file is written.
+// Should be called under the lock of the pipeline.
+func (fc *Cache) save(pipelineId uuid.UUID, p *pipeline) error {
+	data, err := json.Marshal(&p.file)
+	if err != nil {
+		logger.Errorf("File Cache: save pipeline: error during marshal file of the pipeline: %s, err: %s\n", pipelineId, err.Error())
+		return err
+	}
+	if err := replaceFile(fc.filePath(pipelineId), data); err != nil {
+		logger.Errorf("File Cache: save pipeline: error during writing file of the pipeline: %s, err: %s\n", pipelineId, err.Error())
+		return err
+	}
+	p.saved = true
+	return nil
+}
+
+// load loads the files of the pipelines and their output files from the directory of the cache.
+// Temporary files which are left if the server is stopped while the file is written are removed.
+// Output files of the pipelines which files don't exist are removed.
+// In case the directory couldn't be read returns an error.
+func (fc *Cache) load() error {
+	entries, err := os.ReadDir(fc.dir)
+	if err != nil {
+		logger.Errorf("File Cache: load: error during reading directory: %s, err: %s\n", fc.dir, err.Error())
+		return err
+	}
+	var outputFileNames []string
+	for _, entry := range entries {
+		name := entry.Name()
+		filePath := filepath.Join(fc.dir, name)
+		if entry.IsDir() {
+			continue
+		}
+		if strings.HasSuffix(name, tempFileExt) {
+			_ = os.Remove(filePath)
+			continue
+		}
+		if strings.HasSuffix(name, outputFileExt) {
+			// the output files are loaded after the files of their pipelines
+			outputFileNames = append(outputFileNames, name)
+			continue
+		}
+		if !strings.HasSuffix(name, fileExt) {
+			continue
+		}
+		pipelineId, err := uuid.Parse(strings.TrimSuffix(name, fileExt))
+		if err != nil {
+			logger.Errorf("File Cache: load: file isn't a file of the pipeline: %s\n", filePath)
+			continue
+		}
+		file, err := readPipelineFile(filePath)
+		if errors.Is(err, cache.ErrIncompatibleVersion) {
+			// the file is kept as is, so it could be loaded by the newer version of the server
+			logger.Errorf("File Cache: load: file of the pipeline is skipped: %s, err: %s\n", filePath, err.Error())
+			continue
+		}
+		if err != nil {
+			logger.Errorf("File Cache: load: corrupt file of the pipeline is skipped: %s, err: %s\n", filePath, err.Error())
+			_ = os.Rename(filePath, filePath+corruptFileExt)
+			continue
+		}
+		if file.isExpired() {
+			_ = os.Remove(filePath)
+			continue
+		}
+		fc.pipelines[pipelineId] = &pipeline{file: *file, outputs: make(map[cache.SubKey]string), saved: true}
+	}
+	for _, name := range outputFileNames {
+		fc.loadOutputFile(name)
+	}
+	for pipelineId, p := range fc.pipelines {
+		fc.migrateOutputs(pipelineId, p)
+		if fc.isInterrupted(&p.file) {
+			fc.recoverInterrupted(pipelineId, p)
+		}
+	}
+	return nil
+}
+
+// loadOutputFile loads the output file with name to the outputs of its pipeline.
+// The output file is removed if the file of its pipeline doesn't exist, i.e. it is expired or corrupt.
+func (fc *Cache) loadOutputFile(name string) {
+	filePath := filepath.Join(fc.dir, name)
+	parts := strings.SplitN(strings.TrimSuffix(name, outputFileExt), ".", 2)
+	pipelineId, err := uuid.Parse(parts[0])
+	if err != nil || len(parts) != 2 || !outputSubKeys[cache.SubKey(parts[1])] {
+		logger.Errorf("File Cache: load: file isn't an output file of the pipeline: %s\n", filePath)
+		return
+	}
+	p, found := fc.pipelines[pipelineId]
+	if !found {
+		// the file of the pipeline of the newer version is kept, so its output files are kept too
+		if _, err := os.Stat(fc.filePath(pipelineId)); os.IsNotExist(err) {
+			_ = os.Remove(filePath)
+		}
+		return
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		logger.Errorf("File Cache: load: output file of the pipeline is skipped: %s, err: %s\n", filePath, err.Error())
+		return
+	}
+	p.outputs[cache.SubKey(parts[1])] = string(data)
+}
+
+// migrateOutputs moves the outputs which are kept in the file of the pipeline of the previous version to the output files.
+func (fc *Cache) migrateOutputs(pipelineId uuid.UUID, p *pipeline) {
+	migrated := false
+	for subKey, rawValue := range p.file.Values {
+		if !outputSubKeys[subKey] {
+			continue
+		}
+		var output string
+		if err := json.Unmarshal(rawValue, &output); err != nil {
+			continue
+		}
+		if err := replaceFile(fc.outputFilePath(pipelineId, subKey), []byte(output)); err != nil {
+			logger.Errorf("File Cache: load: error during writing output file of the pipeline: %s, err: %s\n", pipelineId, err.Error())
+			continue
+		}
+		p.outputs[subKey] = output
+		delete(p.file.Values, subKey)
+		migrated = true
+	}
+	if migrated {
+		_ = fc.save(pipelineId, p)
+	}
+}
+
+// isInterrupted checks if the processing of the loaded pipeline is interrupted by the restart of the server:
+//	its status isn't completed. The expiration time doesn't matter, since it is set as soon as the pipeline is created.
+func (fc *Cache) isInterrupted(file *pipelineFile) bool {
+	if fc.isCompleted == nil {
+		return false
+	}
+	rawStatus, found := file.Values[cache.Status]
+	if !found {
+		return false
+	}
+	status, err := cache.UnmarshalValue(cache.Status, rawStatus)
+	return err == nil && !fc.isCompleted(status)
+}
+
+// recoverInterrupted sets playground.Status_STATUS_ERROR status with the internal error category
+//	and the expiration time to the pipeline which processing is interrupted by the restart of the server.
+func (fc *Cache) recoverInterrupted(pipelineId uuid.UUID, p *pipeline) {
+	status, _ := json.Marshal(pb.Status_STATUS_ERROR)
+	category, _ := json.Marshal(pb.ErrorCategory_ERROR_CATEGORY_INTERNAL)
+	p.file.Values[cache.Status] = status
+	p.file.Values[cache.ErrorCategory] = category
+	expiration := time.Now().Add(fc.interruptedExpiration)
+	p.file.Expiration = &expiration
+	logger.Errorf("File Cache: load: processing of the pipeline is interrupted by the restart of the server: %s\n", pipelineId)
+	_ = fc.save(pipelineId, p)
+}
+
+func (fc *Cache) startGC(ctx context.Context) {
+	ticker := time.NewTicker(fc.cleanupInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		case <-ticker.C:
+			for _, pipelineId := range fc.expiredPipelines() {
+				fc.deletePipeline(pipelineId)
+			}
+		}
+	}
+}
+
+func (fc *Cache) expiredPipelines() (pipelines []uuid.UUID) {
+	fc.mu.RLock()
+	defer fc.mu.RUnlock()
+	for pipelineId, p := range fc.pipelines {
+		p.Lock()
+		if p.file.isExpired() {
+			pipelines = append(pipelines, pipelineId)
+		}
+		p.Unlock()
+	}
+	return
+}
+
+// filePath returns the path of the file of the pipeline
+func (fc *Cache) filePath(pipelineId uuid.UUID) string {
+	return filepath.Join(fc.dir, pipelineId.String()+fileExt)
+}
+
+// outputFilePath returns the path of the output file of the pipeline by subKey
+func (fc *Cache) outputFilePath(pipelineId uuid.UUID, subKey cache.SubKey) string {
+	return filepath.Join(fc.dir, pipelineId.String()+"."+string(subKey)+outputFileExt)
+}
+
+// newPipelineFile returns the content of the file of the new pipeline
+func newPipelineFile() pipelineFile {
+	return pipelineFile{Version: fileVersion, Values: make(map[cache.SubKey]json.RawMessage)}
+}
+
+// replaceFile writes data to the temporary file which replaces the file by filePath,
+//	so the file isn't corrupted if the server is stopped while the file is written.
+func replaceFile(filePath string, data []byte) error {
+	if err := os.WriteFile(filePath+tempFileExt, data, filePermissions); err != nil {
+		return err
+	}
+	return os.Rename(filePath+tempFileExt, filePath)
+}
+
+// appendFile writes text to the end of the file by filePath. The file is created if it doesn't exist.
+func appendFile(filePath, text string) error {
+	if len(text) == 0 {
+		return nil
+	}
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePermissions)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(text); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// readPipelineFile reads and parses the file of the pipeline.
+// Each value of the file is checked to be unmarshalled by its subKey (see cache.UnmarshalValue).
+// In case the file is saved in the format of the newer version returns an error which wraps cache.ErrIncompatibleVersion.
+func readPipelineFile(filePath string) (*pipelineFile, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var file pipelineFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if file.Version > fileVersion {
+		return nil, fmt.Errorf("file has version %d, but the supported version is %d: %w", file.Version, fileVersion, cache.ErrIncompatibleVersion)
+	}
+	if file.Values == nil {
+		return nil, fmt.Errorf("file has no values")
+	}
+	for subKey, rawValue := range file.Values {
+		if _, err := cache.UnmarshalValue(subKey, rawValue); err != nil {
+			return nil, fmt.Errorf("value with subKey: %s is malformed: %s", subKey, err.Error())
+		}
+	}
+	file.Version = fileVersion
+	return &file, nil
+}
+
+// unmarshalValue unmarshals the value by subKey (see cache.UnmarshalValue)
+func unmarshalValue(subKey cache.SubKey, rawValue json.RawMessage) (interface{}, error) {
+	value, err := cache.UnmarshalValue(subKey, rawValue)
+	if err != nil {
+		logger.Errorf("File Cache: get value: error during unmarshal value, err: %s\n", err.Error())
+		return nil, err
+	}
+	return value, nil
+}
@@ -0,0 +1,586 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileCache_SetValue_GetValue(t *testing.T) {
+	pipelineId := uuid.New()
+	unknownId := uuid.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fc, err := New(ctx, t.TempDir())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	type args struct {
+		pipelineId uuid.UUID
+		subKey     cache.SubKey
+		value      interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetValue method after SetValue method with pb.Status value.
+			// As a result, want to receive the same pb.Status value.
+			name: "status value",
+			args: args{pipelineId: pipelineId, subKey: cache.Status, value: pb.Status_STATUS_FINISHED},
+			want: pb.Status_STATUS_FINISHED,
+		},
+		{
+			// Test case with calling GetValue method after SetValue method with string value.
+			// As a result, want to receive the same string value.
+			name: "string value",
+			args: args{pipelineId: pipelineId, subKey: cache.RunOutput, value: "MOCK_OUTPUT"},
+			want: "MOCK_OUTPUT",
+		},
+		{
+			// Test case with calling GetValue method after SetValue method with bool value.
+			// As a result, want to receive the same bool value.
+			name: "bool value",
+			args: args{pipelineId: pipelineId, subKey: cache.Canceled, value: true},
+			want: true,
+		},
+		{
+			// Test case with calling GetValue method for the pipeline which isn't in the cache.
+			// As a result, want to receive an error which wraps cache.ErrNotFound.
+			name:    "unknown pipeline",
+			args:    args{pipelineId: unknownId, subKey: cache.Status},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.args.value != nil {
+				if err := fc.SetValue(ctx, tt.args.pipelineId, tt.args.subKey, tt.args.value); err != nil {
+					t.Fatalf("SetValue() error = %v", err)
+				}
+			}
+			got, err := fc.GetValue(ctx, tt.args.pipelineId, tt.args.subKey)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, cache.ErrNotFound) {
+					t.Errorf("GetValue() error = %v, want %v", err, cache.ErrNotFound)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValue() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileCache_GetValues(t *testing.T) {
+	pipelineId := uuid.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fc, err := New(ctx, t.TempDir())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	_ = fc.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_RUN_ERROR)
+	_ = fc.SetValue(ctx, pipelineId, cache.RunError, "MOCK_ERROR")
+	tests := []struct {
+		name    string
+		subKeys []cache.SubKey
+		want    map[cache.SubKey]interface{}
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetValues method with subKeys which are in the cache and one which isn't.
+			// As a result, want to receive the values which are in the cache.
+			name:    "existing values",
+			subKeys: []cache.SubKey{cache.Status, cache.RunError, cache.RunOutput},
+			want:    map[cache.SubKey]interface{}{cache.Status: pb.Status_STATUS_RUN_ERROR, cache.RunError: "MOCK_ERROR"},
+		},
+		{
+			// Test case with calling GetValues method with subKeys which aren't in the cache.
+			// As a result, want to receive an error.
+			name:    "no values",
+			subKeys: []cache.SubKey{cache.RunOutput},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fc.GetValues(ctx, pipelineId, tt.subKeys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValues() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileCache_IncrementValue(t *testing.T) {
+	pipelineId := uuid.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fc, err := New(ctx, t.TempDir())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	_ = fc.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_OUTPUT")
+
+	// concurrent increments of the same value shouldn't be lost
+	const goroutines = 20
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := fc.IncrementValue(ctx, pipelineId, cache.RunOutputIndex, 1); err != nil {
+				t.Errorf("IncrementValue() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	got, err := fc.IncrementValue(ctx, pipelineId, cache.RunOutputIndex, 2)
+	if err != nil {
+		t.Fatalf("IncrementValue() error = %v", err)
+	}
+	if got != goroutines+2 {
+		t.Errorf("IncrementValue() got = %v, want %v", got, goroutines+2)
+	}
+
+	if _, err := fc.IncrementValue(ctx, pipelineId, cache.RunOutput, 1); err == nil {
+		t.Errorf("IncrementValue() for not an integer value, want error")
+	}
+}
+
+func TestFileCache_UpdateValue(t *testing.T) {
+	pipelineId := uuid.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+	fc, err := New(ctx, dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// the pipeline which isn't in the cache shouldn't be created
+	if err := fc.UpdateValue(ctx, pipelineId, cache.LastAccessed, time.Now()); !errors.Is(err, cache.ErrNotFound) {
+		t.Errorf("UpdateValue() for the pipeline which isn't in the cache error = %v, want %v", err, cache.ErrNotFound)
+	}
+	if err := fc.UpdateValue(ctx, pipelineId, cache.RunOutput, "MOCK_OUTPUT"); !errors.Is(err, cache.ErrNotFound) {
+		t.Errorf("UpdateValue() of the output for the pipeline which isn't in the cache error = %v, want %v", err, cache.ErrNotFound)
+	}
+	if _, err := os.Stat(filepath.Join(dir, pipelineId.String()+fileExt)); !os.IsNotExist(err) {
+		t.Errorf("file of the pipeline which isn't in the cache is created, err = %v", err)
+	}
+
+	_ = fc.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+	if err := fc.UpdateValue(ctx, pipelineId, cache.RunOutput, "MOCK_OUTPUT"); err != nil {
+		t.Fatalf("UpdateValue() error = %v", err)
+	}
+	if got, _ := fc.GetValue(ctx, pipelineId, cache.RunOutput); got != "MOCK_OUTPUT" {
+		t.Errorf("UpdateValue() set value = %v, want %v", got, "MOCK_OUTPUT")
+	}
+
+	// the expired pipeline shouldn't be created again
+	_ = fc.SetExpTime(ctx, pipelineId, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if err := fc.UpdateValue(ctx, pipelineId, cache.LastAccessed, time.Now()); !errors.Is(err, cache.ErrNotFound) {
+		t.Errorf("UpdateValue() for expired pipeline error = %v, want %v", err, cache.ErrNotFound)
+	}
+	if _, err := fc.GetValue(ctx, pipelineId, cache.Status); !errors.Is(err, cache.ErrNotFound) {
+		t.Errorf("GetValue() for expired pipeline error = %v, want %v", err, cache.ErrNotFound)
+	}
+}
+
+func TestFileCache_SetExpTime(t *testing.T) {
+	pipelineId := uuid.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+	fc, err := New(ctx, dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := fc.SetExpTime(ctx, pipelineId, time.Minute); err == nil {
+		t.Errorf("SetExpTime() for the pipeline which isn't in the cache, want error")
+	}
+
+	_ = fc.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+	if err := fc.SetExpTime(ctx, pipelineId, time.Millisecond); err != nil {
+		t.Fatalf("SetExpTime() error = %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err := fc.GetValue(ctx, pipelineId, cache.Status); !errors.Is(err, cache.ErrNotFound) {
+		t.Errorf("GetValue() for expired pipeline error = %v, want %v", err, cache.ErrNotFound)
+	}
+	if _, err := os.Stat(filepath.Join(dir, pipelineId.String()+fileExt)); !os.IsNotExist(err) {
+		t.Errorf("file of expired pipeline is not removed, err = %v", err)
+	}
+}
+
+func TestFileCache_Restart(t *testing.T) {
+	pipelineId := uuid.New()
+	expiredId := uuid.New()
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	fc, err := New(ctx, dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	_ = fc.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+	_ = fc.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_OUTPUT")
+	_, _ = fc.IncrementValue(ctx, pipelineId, cache.RunOutputIndex, 3)
+	_ = fc.SetExpTime(ctx, pipelineId, time.Hour)
+	_ = fc.SetValue(ctx, expiredId, cache.Status, pb.Status_STATUS_FINISHED)
+	_ = fc.SetExpTime(ctx, expiredId, time.Millisecond)
+	// simulate the restart of the server
+	cancel()
+	time.Sleep(5 * time.Millisecond)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	restarted, err := New(ctx, dir)
+	if err != nil {
+		t.Fatalf("New() after restart error = %v", err)
+	}
+	got, err := restarted.GetValues(ctx, pipelineId, []cache.SubKey{cache.Status, cache.RunOutput})
+	if err != nil {
+		t.Fatalf("GetValues() after restart error = %v", err)
+	}
+	want := map[cache.SubKey]interface{}{cache.Status: pb.Status_STATUS_FINISHED, cache.RunOutput: "MOCK_OUTPUT"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetValues() after restart got = %v, want %v", got, want)
+	}
+	index, err := restarted.IncrementValue(ctx, pipelineId, cache.RunOutputIndex, 1)
+	if err != nil || index != 4 {
+		t.Errorf("IncrementValue() after restart got = %v, %v, want 4", index, err)
+	}
+	if _, err := restarted.GetValue(ctx, expiredId, cache.Status); !errors.Is(err, cache.ErrNotFound) {
+		t.Errorf("GetValue() for expired pipeline after restart error = %v, want %v", err, cache.ErrNotFound)
+	}
+	if _, err := os.Stat(filepath.Join(dir, expiredId.String()+fileExt)); !os.IsNotExist(err) {
+		t.Errorf("file of expired pipeline is not removed on restart, err = %v", err)
+	}
+}
+
+func TestFileCache_CorruptFiles(t *testing.T) {
+	validId := uuid.New()
+	corruptId := uuid.New()
+	malformedId := uuid.New()
+	newerId := uuid.New()
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fc, err := New(ctx, dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	_ = fc.SetValue(ctx, validId, cache.Status, pb.Status_STATUS_FINISHED)
+
+	files := map[uuid.UUID]string{
+		corruptId:   `{"version":1,"values":{"STATUS":`,
+		malformedId: `{"version":1,"values":{"STATUS":"not a status"}}`,
+		newerId:     `{"version":3,"values":{"STATUS":8}}`,
+	}
+	for pipelineId, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, pipelineId.String()+fileExt), []byte(content), filePermissions); err != nil {
+			t.Fatalf("error during prepare file: %v", err)
+		}
+	}
+	_ = os.WriteFile(filepath.Join(dir, "not_a_pipeline"+fileExt), []byte("{}"), filePermissions)
+	_ = os.WriteFile(filepath.Join(dir, uuid.NewString()+fileExt+tempFileExt), []byte("{"), filePermissions)
+
+	restarted, err := New(ctx, dir)
+	if err != nil {
+		t.Fatalf("New() with corrupt files error = %v", err)
+	}
+	if got, err := restarted.GetValue(ctx, validId, cache.Status); err != nil || got != pb.Status_STATUS_FINISHED {
+		t.Errorf("GetValue() for valid pipeline got = %v, %v, want %v", got, err, pb.Status_STATUS_FINISHED)
+	}
+	for pipelineId := range files {
+		if _, err := restarted.GetValue(ctx, pipelineId, cache.Status); !errors.Is(err, cache.ErrNotFound) {
+			t.Errorf("GetValue() for not loaded pipeline error = %v, want %v", err, cache.ErrNotFound)
+		}
+	}
+	for _, pipelineId := range []uuid.UUID{corruptId, malformedId} {
+		if _, err := os.Stat(filepath.Join(dir, pipelineId.String()+fileExt+corruptFileExt)); err != nil {
+			t.Errorf("corrupt file is not renamed, err = %v", err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, newerId.String()+fileExt)); err != nil {
+		t.Errorf("file of the newer version is not kept, err = %v", err)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*"+tempFileExt)); len(matches) != 0 {
+		t.Errorf("temporary files are not removed: %v", matches)
+	}
+
+	// the pipeline of the corrupt file could be written again
+	if err := restarted.SetValue(ctx, corruptId, cache.Status, pb.Status_STATUS_FINISHED); err != nil {
+		t.Errorf("SetValue() for pipeline of corrupt file error = %v", err)
+	}
+}
+
+func TestFileCache_SetOutput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+	fc, err := New(ctx, dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	tests := []struct {
+		name       string
+		outputs    []interface{}
+		changeFile string
+		want       interface{}
+		wantFile   string
+		wantErr    bool
+	}{
+		{
+			// Test case with calling SetValue method with the output which grows.
+			// As a result, want to receive the last output and only the appended text written to the end of the output file,
+			//	so the text which is changed in the output file is kept.
+			name:       "appended output",
+			outputs:    []interface{}{"MOCK", "MOCK_OUTPUT"},
+			changeFile: "XXXX",
+			want:       "MOCK_OUTPUT",
+			wantFile:   "XXXX_OUTPUT",
+		},
+		{
+			// Test case with calling SetValue method with the output which doesn't start with the previous output.
+			// As a result, want to receive the last output and the output file replaced with it.
+			name:       "replaced output",
+			outputs:    []interface{}{"MOCK_OUTPUT", "MOCK"},
+			changeFile: "XXXX",
+			want:       "MOCK",
+			wantFile:   "MOCK",
+		},
+		{
+			// Test case with calling SetValue method with the output which isn't a string.
+			// As a result, want to receive an error.
+			name:    "output isn't a string",
+			outputs: []interface{}{1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			outputFilePath := filepath.Join(dir, pipelineId.String()+"."+string(cache.RunOutput)+outputFileExt)
+			var err error
+			for i, output := range tt.outputs {
+				if i > 0 && tt.changeFile != "" {
+					_ = os.WriteFile(outputFilePath, []byte(tt.changeFile), filePermissions)
+				}
+				if err = fc.SetValue(ctx, pipelineId, cache.RunOutput, output); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got, err := fc.GetValue(ctx, pipelineId, cache.RunOutput); err != nil || got != tt.want {
+				t.Errorf("GetValue() got = %v, %v, want %v", got, err, tt.want)
+			}
+			if data, err := os.ReadFile(outputFilePath); err != nil || string(data) != tt.wantFile {
+				t.Errorf("output file got = %s, %v, want %s", data, err, tt.wantFile)
+			}
+			if data, err := os.ReadFile(filepath.Join(dir, pipelineId.String()+fileExt)); err != nil || strings.Contains(string(data), string(cache.RunOutput)) {
+				t.Errorf("file of the pipeline got = %s, %v, want the file without the output", data, err)
+			}
+		})
+	}
+}
+
+func TestFileCache_RestartWithOutputFiles(t *testing.T) {
+	pipelineId := uuid.New()
+	previousVersionId := uuid.New()
+	orphanId := uuid.New()
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fc, err := New(ctx, dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	_ = fc.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_OUTPUT")
+	_ = fc.SetValue(ctx, pipelineId, cache.Logs, "MOCK_LOGS")
+	// the file of the previous version keeps the outputs with other values
+	previousVersionFile := `{"version":1,"values":{"STATUS":8,"RUN_OUTPUT":"MOCK_PREVIOUS_OUTPUT"}}`
+	if err := os.WriteFile(filepath.Join(dir, previousVersionId.String()+fileExt), []byte(previousVersionFile), filePermissions); err != nil {
+		t.Fatalf("error during prepare file: %v", err)
+	}
+	orphanFilePath := filepath.Join(dir, orphanId.String()+"."+string(cache.RunOutput)+outputFileExt)
+	_ = os.WriteFile(orphanFilePath, []byte("MOCK_ORPHAN_OUTPUT"), filePermissions)
+
+	restarted, err := New(ctx, dir)
+	if err != nil {
+		t.Fatalf("New() after restart error = %v", err)
+	}
+	got, err := restarted.GetValues(ctx, pipelineId, []cache.SubKey{cache.RunOutput, cache.Logs})
+	want := map[cache.SubKey]interface{}{cache.RunOutput: "MOCK_OUTPUT", cache.Logs: "MOCK_LOGS"}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("GetValues() after restart got = %v, %v, want %v", got, err, want)
+	}
+	got, err = restarted.GetValues(ctx, previousVersionId, []cache.SubKey{cache.Status, cache.RunOutput})
+	want = map[cache.SubKey]interface{}{cache.Status: pb.Status_STATUS_FINISHED, cache.RunOutput: "MOCK_PREVIOUS_OUTPUT"}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("GetValues() for pipeline of previous version got = %v, %v, want %v", got, err, want)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, previousVersionId.String()+"."+string(cache.RunOutput)+outputFileExt)); err != nil || string(data) != "MOCK_PREVIOUS_OUTPUT" {
+		t.Errorf("output of pipeline of previous version isn't moved to output file, got = %s, %v", data, err)
+	}
+	if _, err := os.Stat(orphanFilePath); !os.IsNotExist(err) {
+		t.Errorf("output file without file of the pipeline is not removed, err = %v", err)
+	}
+
+	// the output loaded after the restart is appended
+	if err := restarted.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_OUTPUT_NEXT"); err != nil {
+		t.Fatalf("SetValue() after restart error = %v", err)
+	}
+	if got, err := restarted.GetValue(ctx, pipelineId, cache.RunOutput); err != nil || got != "MOCK_OUTPUT_NEXT" {
+		t.Errorf("GetValue() after restart got = %v, %v, want %v", got, err, "MOCK_OUTPUT_NEXT")
+	}
+}
+
+func TestFileCache_RecoverInterrupted(t *testing.T) {
+	isCompleted := func(status interface{}) bool {
+		value, ok := status.(pb.Status)
+		return ok && value == pb.Status_STATUS_FINISHED
+	}
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fc, err := New(ctx, dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	tests := []struct {
+		name           string
+		status         pb.Status
+		expiration     time.Duration
+		prepare        func(pipelineId uuid.UUID)
+		wantStatus     pb.Status
+		wantCategory   bool
+		wantExpiration bool
+	}{
+		{
+			// Test case with loading the pipeline in progress without expiration time.
+			// As a result, want to receive the error status with the internal error category and the expiration time.
+			name:           "interrupted pipeline",
+			status:         pb.Status_STATUS_EXECUTING,
+			wantStatus:     pb.Status_STATUS_ERROR,
+			wantCategory:   true,
+			wantExpiration: true,
+		},
+		{
+			// Test case with loading the completed pipeline without expiration time.
+			// As a result, want to receive the pipeline as is.
+			name:       "completed pipeline",
+			status:     pb.Status_STATUS_FINISHED,
+			wantStatus: pb.Status_STATUS_FINISHED,
+		},
+		{
+			// Test case with loading the pipeline in progress with expiration time.
+			// As a result, want to receive the error status with the internal error category and the expiration time.
+			name:           "pipeline with expiration time",
+			status:         pb.Status_STATUS_EXECUTING,
+			expiration:     time.Hour,
+			wantStatus:     pb.Status_STATUS_ERROR,
+			wantCategory:   true,
+			wantExpiration: true,
+		},
+		{
+			// Test case with loading the pipeline in progress which values are saved in the same order as RunCode
+			// and the code processing do it: the expiration time is set right after the pipeline is created.
+			// As a result, want to receive the error status with the internal error category and the expiration time.
+			name: "pipeline created by RunCode",
+			prepare: func(pipelineId uuid.UUID) {
+				_ = fc.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_VALIDATING)
+				_ = fc.SetExpTime(ctx, pipelineId, time.Hour)
+				_ = fc.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+				_ = fc.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT")
+			},
+			wantStatus:     pb.Status_STATUS_ERROR,
+			wantCategory:   true,
+			wantExpiration: true,
+		},
+	}
+	pipelineIds := make(map[string]uuid.UUID, len(tests))
+	for _, tt := range tests {
+		pipelineId := uuid.New()
+		pipelineIds[tt.name] = pipelineId
+		if tt.prepare != nil {
+			tt.prepare(pipelineId)
+			continue
+		}
+		_ = fc.SetValue(ctx, pipelineId, cache.Status, tt.status)
+		if tt.expiration > 0 {
+			_ = fc.SetExpTime(ctx, pipelineId, tt.expiration)
+		}
+	}
+
+	restarted, err := NewWithRecovery(ctx, dir, isCompleted, time.Minute)
+	if err != nil {
+		t.Fatalf("NewWithRecovery() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := pipelineIds[tt.name]
+			if got, err := restarted.GetValue(ctx, pipelineId, cache.Status); err != nil || got != tt.wantStatus {
+				t.Errorf("GetValue() status got = %v, %v, want %v", got, err, tt.wantStatus)
+			}
+			category, err := restarted.GetValue(ctx, pipelineId, cache.ErrorCategory)
+			if tt.wantCategory && (err != nil || category != pb.ErrorCategory_ERROR_CATEGORY_INTERNAL) {
+				t.Errorf("GetValue() error category got = %v, %v, want %v", category, err, pb.ErrorCategory_ERROR_CATEGORY_INTERNAL)
+			}
+			if !tt.wantCategory && err == nil {
+				t.Errorf("GetValue() error category got = %v, want no error category", category)
+			}
+			p := restarted.getPipeline(pipelineId, false)
+			if (p.file.Expiration != nil) != tt.wantExpiration {
+				t.Errorf("expiration time got = %v, want expiration time %v", p.file.Expiration, tt.wantExpiration)
+			}
+			// the recovered pipeline is kept after the next restart
+			loaded, err := readPipelineFile(filepath.Join(dir, pipelineId.String()+fileExt))
+			if err != nil || !reflect.DeepEqual(loaded.Values[cache.Status], p.file.Values[cache.Status]) {
+				t.Errorf("file of the pipeline got = %v, %v, want it saved", loaded, err)
+			}
+		})
+	}
+}
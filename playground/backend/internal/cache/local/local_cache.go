@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -28,20 +30,77 @@ const (
 	cleanupInterval = 5 * time.Second
 )
 
+// outputSubKeys are the subKeys which values are counted to the total size of the outputs kept in the cache
+var outputSubKeys = map[cache.SubKey]bool{
+	cache.RunOutput:        true,
+	cache.RunError:         true,
+	cache.RunLogs:          true,
+	cache.CombinedLogs:     true,
+	cache.CompileOutput:    true,
+	cache.ValidationOutput: true,
+	cache.Logs:             true,
+	cache.Graph:            true,
+}
+
 type Cache struct {
 	sync.RWMutex
 	cleanupInterval     time.Duration
 	items               map[uuid.UUID]map[cache.SubKey]interface{}
 	pipelinesExpiration map[uuid.UUID]time.Time
+
+	// maxPipelines is a max number of pipelines kept in the cache. 0 means that the number of pipelines is not limited.
+	maxPipelines int
+	// accessClock is incremented on each access to the pipelines, so its values order the accesses
+	accessClock int64
+	// lastAccess keeps the value of accessClock at the last access by pipelineId of the pipelines which cache.Status is set.
+	// The map is changed under the write lock of the cache, but its values are changed atomically under the read lock,
+	//	so reads of the cache don't take the write lock.
+	lastAccess map[uuid.UUID]*int64
+	// statusChanged keeps channels by pipelineId which are closed when the status of the pipeline is set
+	statusChanged map[uuid.UUID]chan struct{}
+	// activePipelines keeps pipelineIds which status isn't completed according to isCompleted
+	activePipelines map[uuid.UUID]struct{}
+
+	// maxOutputBytes is a max total size (in bytes) of the outputs of all pipelines. 0 means that the size is not limited.
+	maxOutputBytes int
+	// isCompleted checks by the value of cache.Status if the pipeline is completed, so its outputs could be evicted
+	isCompleted func(status interface{}) bool
+	// outputBytes keeps the size of the outputs by pipelineId
+	outputBytes map[uuid.UUID]int
+	// totalOutputBytes is the total size of the outputs of all pipelines
+	totalOutputBytes int
 }
 
+// New returns local cache without limit of the number of pipelines
 func New(ctx context.Context) *Cache {
+	return NewWithLimits(ctx, 0, 0, nil)
+}
+
+// NewWithLimits returns local cache which keeps no more than maxPipelines pipelines
+//	and no more than maxOutputBytes bytes of the outputs (i.e. cache.RunOutput or cache.CompileOutput) of all pipelines.
+// Only the entries which cache.Status is set are counted as pipelines, so other entries are neither counted nor dropped.
+// If the number of pipelines is exceeded, all entries of the least recently accessed pipelines which are completed
+//	according to isCompleted are dropped. The pipelines in progress are never dropped, so the number of pipelines
+//	could exceed the limit until they are completed.
+// If the size of the outputs is exceeded, the outputs of the least recently accessed pipelines which are completed
+//	according to isCompleted are dropped. The outputs of the pipelines in progress and of the pipeline which is being written
+//	are never dropped, so the size of the outputs could exceed the limit until these pipelines are completed.
+// If isCompleted isn't set, no pipeline is considered completed, so the outputs aren't dropped.
+// If maxPipelines or maxOutputBytes isn't positive, the corresponding limit is not applied.
+func NewWithLimits(ctx context.Context, maxPipelines, maxOutputBytes int, isCompleted func(status interface{}) bool) *Cache {
 	items := make(map[uuid.UUID]map[cache.SubKey]interface{})
 	pipelinesExpiration := make(map[uuid.UUID]time.Time)
 	ls := &Cache{
 		cleanupInterval:     cleanupInterval,
 		items:               items,
 		pipelinesExpiration: pipelinesExpiration,
+		maxPipelines:        maxPipelines,
+		lastAccess:          make(map[uuid.UUID]*int64),
+		statusChanged:       make(map[uuid.UUID]chan struct{}),
+		activePipelines:     make(map[uuid.UUID]struct{}),
+		maxOutputBytes:      maxOutputBytes,
+		isCompleted:         isCompleted,
+		outputBytes:         make(map[uuid.UUID]int),
 	}
 
 	go ls.startGC(ctx)
@@ -55,22 +114,46 @@ func (lc *Cache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cach
 	value, found := lc.items[pipelineId][subKey]
 	if !found {
 		lc.RUnlock()
-		return nil, fmt.Errorf("value with pipelineId: %s and subKey: %s not found", pipelineId, subKey)
+		return nil, fmt.Errorf("value with pipelineId: %s and subKey: %s %w", pipelineId, subKey, cache.ErrNotFound)
 	}
 	expTime, found := lc.pipelinesExpiration[pipelineId]
+	lc.markAccessed(pipelineId)
 	lc.RUnlock()
 
 	if found && expTime.Before(time.Now()) {
-		lc.Lock()
-		delete(lc.items[pipelineId], subKey)
-		delete(lc.pipelinesExpiration, pipelineId)
-		lc.Unlock()
-		return nil, fmt.Errorf("value with pipelineId: %s and subKey: %s is expired", pipelineId, subKey)
+		lc.clearItems([]uuid.UUID{pipelineId})
+		return nil, fmt.Errorf("value with pipelineId: %s and subKey: %s is expired: %w", pipelineId, subKey, cache.ErrNotFound)
 	}
-
 	return value, nil
 }
 
+// GetValues returns values from cache by subKeys. All values are read under a single lock, so they are consistent
+//	with each other even if they are changed concurrently. Values which don't exist aren't added to the result.
+// If there are no values by subKeys or the pipeline is expired, GetValues returns an error.
+func (lc *Cache) GetValues(ctx context.Context, pipelineId uuid.UUID, subKeys []cache.SubKey) (map[cache.SubKey]interface{}, error) {
+	lc.RLock()
+	values := make(map[cache.SubKey]interface{}, len(subKeys))
+	for _, subKey := range subKeys {
+		if value, found := lc.items[pipelineId][subKey]; found {
+			values[subKey] = value
+		}
+	}
+	expTime, found := lc.pipelinesExpiration[pipelineId]
+	if len(values) != 0 {
+		lc.markAccessed(pipelineId)
+	}
+	lc.RUnlock()
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("values with pipelineId: %s and subKeys: %s %w", pipelineId, subKeys, cache.ErrNotFound)
+	}
+	if found && expTime.Before(time.Now()) {
+		lc.clearItems([]uuid.UUID{pipelineId})
+		return nil, fmt.Errorf("values with pipelineId: %s and subKeys: %s are expired: %w", pipelineId, subKeys, cache.ErrNotFound)
+	}
+	return values, nil
+}
+
 // SetValue puts element to cache.
 // If a particular pipelineId does not contain in the cache, SetValue creates a new element for this pipelineId without expiration time.
 // Use SetExpTime to set expiration time for cache elements.
@@ -83,10 +166,104 @@ func (lc *Cache) SetValue(ctx context.Context, pipelineId uuid.UUID, subKey cach
 	if !ok {
 		lc.items[pipelineId] = make(map[cache.SubKey]interface{})
 	}
-	lc.items[pipelineId][subKey] = value
+	lc.setValue(pipelineId, subKey, value)
+	return nil
+}
+
+// UpdateValue puts element to cache only if a particular pipelineId is contained in the cache and isn't expired.
+// If the pipelineId doesn't contain in the cache or is expired, UpdateValue returns an error.
+func (lc *Cache) UpdateValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) error {
+	lc.Lock()
+	defer lc.Unlock()
+
+	_, found := lc.items[pipelineId]
+	if expTime, hasExpTime := lc.pipelinesExpiration[pipelineId]; !found || hasExpTime && expTime.Before(time.Now()) {
+		return fmt.Errorf("pipelineId: %s %w", pipelineId, cache.ErrNotFound)
+	}
+	lc.setValue(pipelineId, subKey, value)
 	return nil
 }
 
+// setValue puts element to cache by the existing pipelineId.
+// Should be called under the lock of the cache.
+func (lc *Cache) setValue(pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) {
+	// the size of the outputs is counted only if it is limited
+	if lc.maxOutputBytes > 0 && outputSubKeys[subKey] {
+		lc.updateOutputBytes(pipelineId, outputSize(value)-outputSize(lc.items[pipelineId][subKey]))
+	}
+	lc.items[pipelineId][subKey] = value
+	if subKey == cache.Status {
+		lc.trackAccess(pipelineId)
+	}
+	lc.markAccessed(pipelineId)
+	if lc.maxOutputBytes > 0 && lc.totalOutputBytes > lc.maxOutputBytes {
+		lc.evictOutputs(pipelineId)
+	}
+	if subKey == cache.Status {
+		// the status is changed, so the pipelines which are completed now could be dropped
+		lc.evictPipelines(pipelineId)
+		lc.updateActivePipelines(pipelineId)
+		lc.notifyStatusChanged(pipelineId)
+	}
+}
+
+// ActivePipelines returns ids of the pipelines which cache.Status is set and isn't completed according to isCompleted.
+// If isCompleted isn't set, all pipelines with cache.Status are returned.
+// The index of the pipelines is updated when the status is set, so the cache isn't scanned.
+func (lc *Cache) ActivePipelines(ctx context.Context) ([]uuid.UUID, error) {
+	lc.RLock()
+	defer lc.RUnlock()
+	pipelines := make([]uuid.UUID, 0, len(lc.activePipelines))
+	for pipelineId := range lc.activePipelines {
+		pipelines = append(pipelines, pipelineId)
+	}
+	return pipelines, nil
+}
+
+// StatusChanged returns a channel which is closed when cache.Status of the pipeline is set next time
+//	or the pipeline is removed from the cache.
+// If the pipeline doesn't exist in the cache, the returned channel is already closed.
+func (lc *Cache) StatusChanged(pipelineId uuid.UUID) <-chan struct{} {
+	lc.Lock()
+	defer lc.Unlock()
+	if _, found := lc.items[pipelineId]; !found {
+		closed := make(chan struct{})
+		close(closed)
+		return closed
+	}
+	changed, found := lc.statusChanged[pipelineId]
+	if !found {
+		changed = make(chan struct{})
+		lc.statusChanged[pipelineId] = changed
+	}
+	return changed
+}
+
+// IncrementValue adds delta to the integer value in the cache and returns the new value.
+// The whole read-modify-write is done under the lock of the cache, so concurrent increments aren't lost.
+// If the value doesn't exist in the cache, IncrementValue sets delta as the value.
+// If the value isn't an integer, IncrementValue returns an error.
+func (lc *Cache) IncrementValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, delta int) (int, error) {
+	lc.Lock()
+	defer lc.Unlock()
+
+	_, ok := lc.items[pipelineId]
+	if !ok {
+		lc.items[pipelineId] = make(map[cache.SubKey]interface{})
+	}
+	lc.markAccessed(pipelineId)
+	current := 0
+	if value, found := lc.items[pipelineId][subKey]; found {
+		intValue, ok := value.(int)
+		if !ok {
+			return 0, fmt.Errorf("value with pipelineId: %s and subKey: %s isn't an integer", pipelineId, subKey)
+		}
+		current = intValue
+	}
+	lc.items[pipelineId][subKey] = current + delta
+	return current + delta, nil
+}
+
 // SetExpTime sets expiration time to particular pipelineId in cache.
 // If pipelineId doesn't present in the cache, SetExpTime returns an error.
 func (lc *Cache) SetExpTime(ctx context.Context, pipelineId uuid.UUID, expTime time.Duration) error {
@@ -133,7 +310,147 @@ func (lc *Cache) clearItems(pipelines []uuid.UUID) {
 	lc.Lock()
 	defer lc.Unlock()
 	for _, pipeline := range pipelines {
-		delete(lc.items, pipeline)
-		delete(lc.pipelinesExpiration, pipeline)
+		lc.deletePipeline(pipeline)
+	}
+}
+
+// tracksAccess checks if the order of the access to pipelines is needed for any limit of the cache
+func (lc *Cache) tracksAccess() bool {
+	return lc.maxPipelines > 0 || lc.maxOutputBytes > 0
+}
+
+// trackAccess starts tracking the access to the pipeline if it is needed for any limit of the cache.
+// Should be called under the lock of the cache.
+func (lc *Cache) trackAccess(pipelineId uuid.UUID) {
+	if !lc.tracksAccess() {
+		return
+	}
+	if _, found := lc.lastAccess[pipelineId]; found {
+		return
+	}
+	if lc.lastAccess == nil {
+		lc.lastAccess = make(map[uuid.UUID]*int64)
+	}
+	lc.lastAccess[pipelineId] = new(int64)
+}
+
+// markAccessed saves the access to the pipeline if the access to the pipeline is tracked.
+// Should be called under the lock of the cache, the read lock is enough since the access is saved atomically.
+func (lc *Cache) markAccessed(pipelineId uuid.UUID) {
+	if access, found := lc.lastAccess[pipelineId]; found {
+		atomic.StoreInt64(access, atomic.AddInt64(&lc.accessClock, 1))
+	}
+}
+
+// completedPipelines returns the tracked pipelines which are completed according to isCompleted except keptPipelineId
+//	ordered by the last access, the least recently accessed pipeline is the first one.
+// Should be called under the lock of the cache.
+func (lc *Cache) completedPipelines(keptPipelineId uuid.UUID) []uuid.UUID {
+	var pipelines []uuid.UUID
+	for pipelineId := range lc.lastAccess {
+		if pipelineId != keptPipelineId && lc.isPipelineCompleted(pipelineId) {
+			pipelines = append(pipelines, pipelineId)
+		}
+	}
+	sort.Slice(pipelines, func(i, j int) bool {
+		return atomic.LoadInt64(lc.lastAccess[pipelines[i]]) < atomic.LoadInt64(lc.lastAccess[pipelines[j]])
+	})
+	return pipelines
+}
+
+// evictPipelines drops the least recently accessed completed pipelines except keptPipelineId
+//	until the number of pipelines doesn't exceed maxPipelines.
+// Should be called under the lock of the cache.
+func (lc *Cache) evictPipelines(keptPipelineId uuid.UUID) {
+	if lc.maxPipelines <= 0 || len(lc.lastAccess) <= lc.maxPipelines {
+		return
+	}
+	for _, pipelineId := range lc.completedPipelines(keptPipelineId) {
+		if len(lc.lastAccess) <= lc.maxPipelines {
+			return
+		}
+		lc.deletePipeline(pipelineId)
 	}
 }
+
+// updateOutputBytes adds delta to the size of the outputs of the pipeline and to the total size of the outputs.
+// Should be called under the lock of the cache.
+func (lc *Cache) updateOutputBytes(pipelineId uuid.UUID, delta int) {
+	if delta == 0 {
+		return
+	}
+	lc.totalOutputBytes += delta
+	lc.outputBytes[pipelineId] += delta
+	if lc.outputBytes[pipelineId] == 0 {
+		delete(lc.outputBytes, pipelineId)
+	}
+}
+
+// evictOutputs drops the outputs of the least recently accessed completed pipelines except keptPipelineId
+//	until the total size of the outputs doesn't exceed maxOutputBytes.
+// The outputs of the pipelines in progress are never dropped, since they are still written and read by the clients.
+// Should be called under the lock of the cache.
+func (lc *Cache) evictOutputs(keptPipelineId uuid.UUID) {
+	for _, pipelineId := range lc.completedPipelines(keptPipelineId) {
+		if lc.totalOutputBytes <= lc.maxOutputBytes {
+			return
+		}
+		if lc.outputBytes[pipelineId] == 0 {
+			continue
+		}
+		for subKey := range lc.items[pipelineId] {
+			if outputSubKeys[subKey] {
+				delete(lc.items[pipelineId], subKey)
+			}
+		}
+		lc.updateOutputBytes(pipelineId, -lc.outputBytes[pipelineId])
+	}
+}
+
+// isPipelineCompleted checks if the pipeline is completed according to its cache.Status.
+// Should be called under the lock of the cache.
+func (lc *Cache) isPipelineCompleted(pipelineId uuid.UUID) bool {
+	return lc.isCompleted != nil && lc.isCompleted(lc.items[pipelineId][cache.Status])
+}
+
+// updateActivePipelines adds the pipeline to the index of the active pipelines or removes it from the index
+//	depending on its cache.Status.
+// Should be called under the lock of the cache.
+func (lc *Cache) updateActivePipelines(pipelineId uuid.UUID) {
+	if lc.isPipelineCompleted(pipelineId) {
+		delete(lc.activePipelines, pipelineId)
+		return
+	}
+	if lc.activePipelines == nil {
+		lc.activePipelines = make(map[uuid.UUID]struct{})
+	}
+	lc.activePipelines[pipelineId] = struct{}{}
+}
+
+// outputSize returns the size of the output value in bytes
+func outputSize(value interface{}) int {
+	if output, ok := value.(string); ok {
+		return len(output)
+	}
+	return 0
+}
+
+// notifyStatusChanged closes the channel returned by StatusChanged for the pipeline, so all waiters are notified.
+// Should be called under the lock of the cache.
+func (lc *Cache) notifyStatusChanged(pipelineId uuid.UUID) {
+	if changed, found := lc.statusChanged[pipelineId]; found {
+		close(changed)
+		delete(lc.statusChanged, pipelineId)
+	}
+}
+
+// deletePipeline deletes all entries of the pipeline from the cache.
+// Should be called under the lock of the cache.
+func (lc *Cache) deletePipeline(pipelineId uuid.UUID) {
+	lc.notifyStatusChanged(pipelineId)
+	lc.updateOutputBytes(pipelineId, -lc.outputBytes[pipelineId])
+	delete(lc.activePipelines, pipelineId)
+	delete(lc.items, pipelineId)
+	delete(lc.pipelinesExpiration, pipelineId)
+	delete(lc.lastAccess, pipelineId)
+}
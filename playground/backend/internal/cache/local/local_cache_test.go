@@ -18,9 +18,12 @@ package local
 import (
 	"beam.apache.org/playground/backend/internal/cache"
 	"context"
+	"errors"
 	"github.com/google/uuid"
 	"go.uber.org/goleak"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -57,6 +60,13 @@ func TestLocalCache_GetValue(t *testing.T) {
 	preparedItemsMap[preparedId][preparedSubKey] = value
 	preparedExpMap := make(map[uuid.UUID]time.Time)
 	preparedExpMap[preparedId] = time.Now().Add(time.Millisecond)
+	expiredId, _ := uuid.NewUUID()
+	expiredItemsMap := make(map[uuid.UUID]map[cache.SubKey]interface{})
+	expiredItemsMap[expiredId] = make(map[cache.SubKey]interface{})
+	expiredItemsMap[expiredId][preparedSubKey] = value
+	expiredItemsMap[expiredId][cache.RunOutput] = value
+	expiredExpMap := make(map[uuid.UUID]time.Time)
+	expiredExpMap[expiredId] = time.Now().Add(-time.Millisecond)
 	type fields struct {
 		cleanupInterval     time.Duration
 		items               map[uuid.UUID]map[cache.SubKey]interface{}
@@ -102,6 +112,21 @@ func TestLocalCache_GetValue(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Get expired value",
+			fields: fields{
+				cleanupInterval:     cleanupInterval,
+				items:               expiredItemsMap,
+				pipelinesExpiration: expiredExpMap,
+			},
+			args: args{
+				ctx:        context.Background(),
+				pipelineId: expiredId,
+				subKey:     preparedSubKey,
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -120,6 +145,121 @@ func TestLocalCache_GetValue(t *testing.T) {
 			}
 		})
 	}
+	if _, found := expiredItemsMap[expiredId]; found {
+		t.Errorf("GetValue() values of the expired pipeline: %s should be removed", expiredId)
+	}
+}
+
+func TestLocalCache_GetValues(t *testing.T) {
+	preparedId, _ := uuid.NewUUID()
+	preparedItemsMap := make(map[uuid.UUID]map[cache.SubKey]interface{})
+	preparedItemsMap[preparedId] = map[cache.SubKey]interface{}{cache.Status: "MOCK_STATUS", cache.RunOutput: "MOCK_OUTPUT"}
+	expiredId, _ := uuid.NewUUID()
+	expiredItemsMap := make(map[uuid.UUID]map[cache.SubKey]interface{})
+	expiredItemsMap[expiredId] = map[cache.SubKey]interface{}{cache.Status: "MOCK_STATUS"}
+	expiredExpMap := make(map[uuid.UUID]time.Time)
+	expiredExpMap[expiredId] = time.Now().Add(-time.Millisecond)
+	type fields struct {
+		items               map[uuid.UUID]map[cache.SubKey]interface{}
+		pipelinesExpiration map[uuid.UUID]time.Time
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		pipelineId uuid.UUID
+		subKeys    []cache.SubKey
+		want       map[cache.SubKey]interface{}
+		wantErr    bool
+	}{
+		{
+			name:       "Get exist values",
+			fields:     fields{items: preparedItemsMap, pipelinesExpiration: make(map[uuid.UUID]time.Time)},
+			pipelineId: preparedId,
+			subKeys:    []cache.SubKey{cache.Status, cache.RunOutput},
+			want:       map[cache.SubKey]interface{}{cache.Status: "MOCK_STATUS", cache.RunOutput: "MOCK_OUTPUT"},
+			wantErr:    false,
+		},
+		{
+			name:       "Get partially exist values",
+			fields:     fields{items: preparedItemsMap, pipelinesExpiration: make(map[uuid.UUID]time.Time)},
+			pipelineId: preparedId,
+			subKeys:    []cache.SubKey{cache.Status, cache.RunError},
+			want:       map[cache.SubKey]interface{}{cache.Status: "MOCK_STATUS"},
+			wantErr:    false,
+		},
+		{
+			name:       "Get not exist values",
+			fields:     fields{items: preparedItemsMap, pipelinesExpiration: make(map[uuid.UUID]time.Time)},
+			pipelineId: uuid.New(),
+			subKeys:    []cache.SubKey{cache.Status, cache.RunOutput},
+			want:       nil,
+			wantErr:    true,
+		},
+		{
+			name:       "Get expired values",
+			fields:     fields{items: expiredItemsMap, pipelinesExpiration: expiredExpMap},
+			pipelineId: expiredId,
+			subKeys:    []cache.SubKey{cache.Status},
+			want:       nil,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := &Cache{
+				items:               tt.fields.items,
+				pipelinesExpiration: tt.fields.pipelinesExpiration,
+			}
+			got, err := ls.GetValues(context.Background(), tt.pipelineId, tt.subKeys)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetValues() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, cache.ErrNotFound) {
+				t.Errorf("GetValues() error = %v, want %v", err, cache.ErrNotFound)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValues() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalCache_GetValuesConcurrentWrites(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lc := New(ctx)
+	pipelineId := uuid.New()
+	_ = lc.SetValue(ctx, pipelineId, cache.RunOutputIndex, 0)
+	_ = lc.SetValue(ctx, pipelineId, cache.LogsIndex, 0)
+
+	// the writer always sets cache.RunOutputIndex before cache.LogsIndex,
+	// so the consistent read sees cache.RunOutputIndex equal to cache.LogsIndex or greater by one
+	const iterations = 10000
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= iterations; i++ {
+			_ = lc.SetValue(ctx, pipelineId, cache.RunOutputIndex, i)
+			_ = lc.SetValue(ctx, pipelineId, cache.LogsIndex, i)
+		}
+	}()
+
+	for finished := false; !finished; {
+		select {
+		case <-done:
+			finished = true
+		default:
+		}
+		values, err := lc.GetValues(ctx, pipelineId, []cache.SubKey{cache.LogsIndex, cache.RunOutputIndex})
+		if err != nil {
+			t.Fatalf("GetValues() error = %v", err)
+		}
+		runOutputIndex, logsIndex := values[cache.RunOutputIndex].(int), values[cache.LogsIndex].(int)
+		if runOutputIndex != logsIndex && runOutputIndex != logsIndex+1 {
+			t.Fatalf("GetValues() torn read: %s = %d, %s = %d", cache.RunOutputIndex, runOutputIndex, cache.LogsIndex, logsIndex)
+		}
+	}
 }
 
 func TestLocalCache_SetValue(t *testing.T) {
@@ -177,6 +317,608 @@ func TestLocalCache_SetValue(t *testing.T) {
 	}
 }
 
+func TestLocalCache_UpdateValue(t *testing.T) {
+	preparedId := uuid.New()
+	expiredId := uuid.New()
+	missingId := uuid.New()
+	type args struct {
+		ctx        context.Context
+		pipelineId uuid.UUID
+		subKey     cache.SubKey
+		value      interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			// Test case with updating value of the pipeline which is kept in the cache.
+			// As a result, want to receive the updated value.
+			name: "Update value of existing pipeline",
+			args: args{
+				ctx:        context.Background(),
+				pipelineId: preparedId,
+				subKey:     cache.LastAccessed,
+				value:      time.Unix(1, 0),
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with updating value of the pipeline which is expired.
+			// As a result, want to receive an error and the pipeline shouldn't be kept in the cache.
+			name: "Update value of expired pipeline",
+			args: args{
+				ctx:        context.Background(),
+				pipelineId: expiredId,
+				subKey:     cache.LastAccessed,
+				value:      time.Unix(1, 0),
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with updating value of the pipeline which isn't kept in the cache.
+			// As a result, want to receive an error and the pipeline shouldn't be created.
+			name: "Update value of missing pipeline",
+			args: args{
+				ctx:        context.Background(),
+				pipelineId: missingId,
+				subKey:     cache.LastAccessed,
+				value:      time.Unix(1, 0),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := &Cache{
+				cleanupInterval: cleanupInterval,
+				items: map[uuid.UUID]map[cache.SubKey]interface{}{
+					preparedId: {cache.RunOutput: "MOCK_OUTPUT"},
+					expiredId:  {cache.RunOutput: "MOCK_OUTPUT"},
+				},
+				pipelinesExpiration: map[uuid.UUID]time.Time{
+					preparedId: time.Now().Add(time.Minute),
+					expiredId:  time.Now().Add(-time.Minute),
+				},
+			}
+			err := lc.UpdateValue(tt.args.ctx, tt.args.pipelineId, tt.args.subKey, tt.args.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpdateValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, cache.ErrNotFound) {
+					t.Errorf("UpdateValue() error = %v, want %v", err, cache.ErrNotFound)
+				}
+				if _, err := lc.GetValue(tt.args.ctx, tt.args.pipelineId, tt.args.subKey); err == nil {
+					t.Errorf("UpdateValue() created the pipeline with pipelineId: %s", tt.args.pipelineId)
+				}
+				return
+			}
+			got, err := lc.GetValue(tt.args.ctx, tt.args.pipelineId, tt.args.subKey)
+			if err != nil || got != tt.args.value {
+				t.Errorf("UpdateValue() set value = %v, want %v", got, tt.args.value)
+			}
+		})
+	}
+}
+
+func TestLocalCache_IncrementValue(t *testing.T) {
+	preparedId, _ := uuid.NewUUID()
+	preparedItemsMap := make(map[uuid.UUID]map[cache.SubKey]interface{})
+	preparedItemsMap[preparedId] = make(map[cache.SubKey]interface{})
+	preparedItemsMap[preparedId][cache.RunOutputIndex] = 5
+	preparedItemsMap[preparedId][cache.RunOutput] = "TEST_VALUE"
+	type args struct {
+		ctx        context.Context
+		pipelineId uuid.UUID
+		subKey     cache.SubKey
+		delta      int
+	}
+	tests := []struct {
+		name    string
+		items   map[uuid.UUID]map[cache.SubKey]interface{}
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			// Test case with calling IncrementValue method when the value doesn't exist.
+			// As a result, want to receive delta as the new value.
+			name:  "increment not existing value",
+			items: make(map[uuid.UUID]map[cache.SubKey]interface{}),
+			args: args{
+				ctx:        context.Background(),
+				pipelineId: preparedId,
+				subKey:     cache.RunOutputIndex,
+				delta:      3,
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			// Test case with calling IncrementValue method when the integer value exists.
+			// As a result, want to receive the sum of the value and delta.
+			name:  "increment existing value",
+			items: preparedItemsMap,
+			args: args{
+				ctx:        context.Background(),
+				pipelineId: preparedId,
+				subKey:     cache.RunOutputIndex,
+				delta:      3,
+			},
+			want:    8,
+			wantErr: false,
+		},
+		{
+			// Test case with calling IncrementValue method when the value isn't an integer.
+			// As a result, want to receive an error.
+			name:  "increment not integer value",
+			items: preparedItemsMap,
+			args: args{
+				ctx:        context.Background(),
+				pipelineId: preparedId,
+				subKey:     cache.RunOutput,
+				delta:      3,
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := &Cache{
+				cleanupInterval:     cleanupInterval,
+				items:               tt.items,
+				pipelinesExpiration: make(map[uuid.UUID]time.Time),
+			}
+			got, err := lc.IncrementValue(tt.args.ctx, tt.args.pipelineId, tt.args.subKey, tt.args.delta)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IncrementValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IncrementValue() got = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr {
+				value, _ := lc.GetValue(tt.args.ctx, tt.args.pipelineId, tt.args.subKey)
+				if !reflect.DeepEqual(value, tt.want) {
+					t.Errorf("IncrementValue() value in cache = %v, want %v", value, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLocalCache_IncrementValueConcurrently(t *testing.T) {
+	pipelineId := uuid.New()
+	goroutinesCount := 100
+	incrementsCount := 100
+	lc := &Cache{
+		cleanupInterval:     cleanupInterval,
+		items:               make(map[uuid.UUID]map[cache.SubKey]interface{}),
+		pipelinesExpiration: make(map[uuid.UUID]time.Time),
+	}
+	if err := lc.SetValue(context.Background(), pipelineId, cache.RunOutputIndex, 0); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutinesCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < incrementsCount; j++ {
+				if _, err := lc.IncrementValue(context.Background(), pipelineId, cache.RunOutputIndex, 1); err != nil {
+					t.Errorf("IncrementValue() error = %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	value, err := lc.GetValue(context.Background(), pipelineId, cache.RunOutputIndex)
+	if err != nil {
+		t.Fatalf("GetValue() error = %v", err)
+	}
+	if !reflect.DeepEqual(value, goroutinesCount*incrementsCount) {
+		t.Errorf("IncrementValue() lost updates: value = %v, want %v", value, goroutinesCount*incrementsCount)
+	}
+}
+
+func TestLocalCache_MaxPipelines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	inProgressStatus, completedStatus := 1, 2
+	isCompleted := func(status interface{}) bool {
+		return status == completedStatus
+	}
+	oldestPipelineId := uuid.New()
+	accessedPipelineId := uuid.New()
+	newestPipelineId := uuid.New()
+	counterId := uuid.New()
+
+	tests := []struct {
+		name            string
+		prepare         func(lc *Cache)
+		evictedIds      []uuid.UUID
+		survivedIds     []uuid.UUID
+		expectedTracked int
+		expectedItems   int
+	}{
+		{
+			// Test case with exceeding the max number of pipelines.
+			// As a result, the oldest pipeline should be dropped and the newest one should be kept.
+			name: "oldest pipeline is evicted",
+			prepare: func(lc *Cache) {
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.Status, completedStatus)
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.RunOutput, "MOCK_OUTPUT")
+				_ = lc.SetValue(ctx, accessedPipelineId, cache.Status, completedStatus)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.Status, completedStatus)
+			},
+			evictedIds:      []uuid.UUID{oldestPipelineId},
+			survivedIds:     []uuid.UUID{accessedPipelineId, newestPipelineId},
+			expectedTracked: 2,
+			expectedItems:   2,
+		},
+		{
+			// Test case with exceeding the max number of pipelines after reading the oldest pipeline.
+			// As a result, the least recently accessed pipeline should be dropped instead of the oldest one.
+			name: "recently accessed pipeline isn't evicted",
+			prepare: func(lc *Cache) {
+				_ = lc.SetValue(ctx, accessedPipelineId, cache.Status, completedStatus)
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.Status, completedStatus)
+				_, _ = lc.GetValue(ctx, accessedPipelineId, cache.Status)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.Status, completedStatus)
+			},
+			evictedIds:      []uuid.UUID{oldestPipelineId},
+			survivedIds:     []uuid.UUID{accessedPipelineId, newestPipelineId},
+			expectedTracked: 2,
+			expectedItems:   2,
+		},
+		{
+			// Test case with exceeding the max number of pipelines when the oldest pipeline is in progress.
+			// As a result, the least recently accessed completed pipeline should be dropped instead of the oldest one.
+			name: "pipeline in progress isn't evicted",
+			prepare: func(lc *Cache) {
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.Status, inProgressStatus)
+				_ = lc.SetValue(ctx, accessedPipelineId, cache.Status, completedStatus)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.Status, completedStatus)
+			},
+			evictedIds:      []uuid.UUID{accessedPipelineId},
+			survivedIds:     []uuid.UUID{oldestPipelineId, newestPipelineId},
+			expectedTracked: 2,
+			expectedItems:   2,
+		},
+		{
+			// Test case with exceeding the max number of pipelines when all pipelines are in progress.
+			// As a result, no pipeline should be dropped until the pipelines are completed.
+			name: "pipelines in progress exceed limit",
+			prepare: func(lc *Cache) {
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.Status, inProgressStatus)
+				_ = lc.SetValue(ctx, accessedPipelineId, cache.Status, inProgressStatus)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.Status, inProgressStatus)
+			},
+			evictedIds:      nil,
+			survivedIds:     []uuid.UUID{oldestPipelineId, accessedPipelineId, newestPipelineId},
+			expectedTracked: 3,
+			expectedItems:   3,
+		},
+		{
+			// Test case with the entry without status (i.e. the index of the output of the pipeline which status isn't set yet)
+			// which is accessed less recently than the pipelines.
+			// As a result, the entry shouldn't be counted as a pipeline and shouldn't be dropped.
+			name: "entry without status isn't evicted",
+			prepare: func(lc *Cache) {
+				_, _ = lc.IncrementValue(ctx, counterId, cache.RunOutputIndex, 1)
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.Status, completedStatus)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.Status, completedStatus)
+			},
+			evictedIds:      nil,
+			survivedIds:     []uuid.UUID{oldestPipelineId, newestPipelineId},
+			expectedTracked: 2,
+			expectedItems:   3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := NewWithLimits(ctx, 2, 0, isCompleted)
+			tt.prepare(lc)
+			for _, pipelineId := range tt.evictedIds {
+				if value, err := lc.GetValue(ctx, pipelineId, cache.Status); err == nil {
+					t.Errorf("GetValue() of evicted pipeline got = %v, want error", value)
+				}
+				if _, found := lc.pipelinesExpiration[pipelineId]; found {
+					t.Errorf("expiration time of evicted pipeline is kept")
+				}
+			}
+			for _, pipelineId := range tt.survivedIds {
+				if _, err := lc.GetValue(ctx, pipelineId, cache.Status); err != nil {
+					t.Errorf("GetValue() of survived pipeline error = %v", err)
+				}
+			}
+			if len(lc.lastAccess) != tt.expectedTracked {
+				t.Errorf("cache tracks %d pipelines, want %d", len(lc.lastAccess), tt.expectedTracked)
+			}
+			if len(lc.items) != tt.expectedItems {
+				t.Errorf("cache keeps %d entries, want %d", len(lc.items), tt.expectedItems)
+			}
+		})
+	}
+}
+
+func TestLocalCache_GetValueConcurrently(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	isCompleted := func(status interface{}) bool {
+		return status == 2
+	}
+	lc := NewWithLimits(ctx, 5, 0, isCompleted)
+	pipelineIds := make([]uuid.UUID, 10)
+	for i := range pipelineIds {
+		pipelineIds[i] = uuid.New()
+	}
+
+	// the access to the pipelines is saved by reads under the read lock while the pipelines are set and evicted
+	var wg sync.WaitGroup
+	for _, pipelineId := range pipelineIds {
+		wg.Add(2)
+		go func(pipelineId uuid.UUID) {
+			defer wg.Done()
+			_ = lc.SetValue(ctx, pipelineId, cache.Status, 2)
+		}(pipelineId)
+		go func(pipelineId uuid.UUID) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_, _ = lc.GetValue(ctx, pipelineId, cache.Status)
+				_, _ = lc.GetValues(ctx, pipelineId, []cache.SubKey{cache.Status})
+			}
+		}(pipelineId)
+	}
+	wg.Wait()
+
+	if len(lc.lastAccess) != 5 || len(lc.items) != 5 {
+		t.Errorf("cache tracks %d pipelines and keeps %d entries, want %d", len(lc.lastAccess), len(lc.items), 5)
+	}
+}
+
+func TestLocalCache_MaxOutputBytes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	inProgressStatus, completedStatus := 1, 2
+	isCompleted := func(status interface{}) bool {
+		return status == completedStatus
+	}
+	oldestPipelineId := uuid.New()
+	completedPipelineId := uuid.New()
+	newestPipelineId := uuid.New()
+	output := strings.Repeat("o", 40)
+
+	tests := []struct {
+		name             string
+		prepare          func(lc *Cache)
+		evictedIds       []uuid.UUID
+		survivedIds      []uuid.UUID
+		totalOutputBytes int
+	}{
+		{
+			// Test case with exceeding the max size of the outputs when there is a completed pipeline.
+			// As a result, the outputs of the completed pipeline should be dropped
+			// 	even though the pipeline in progress is accessed less recently.
+			name: "outputs of completed pipeline are evicted",
+			prepare: func(lc *Cache) {
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.Status, inProgressStatus)
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.RunOutput, output)
+				_ = lc.SetValue(ctx, completedPipelineId, cache.Status, completedStatus)
+				_ = lc.SetValue(ctx, completedPipelineId, cache.RunOutput, output)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.Status, inProgressStatus)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.RunOutput, output)
+			},
+			evictedIds:       []uuid.UUID{completedPipelineId},
+			survivedIds:      []uuid.UUID{oldestPipelineId, newestPipelineId},
+			totalOutputBytes: 80,
+		},
+		{
+			// Test case with exceeding the max size of the outputs when there are no completed pipelines.
+			// As a result, the outputs of the pipelines in progress shouldn't be dropped even though the limit is exceeded.
+			name: "outputs of pipelines in progress aren't evicted",
+			prepare: func(lc *Cache) {
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.Status, inProgressStatus)
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.RunOutput, output)
+				_ = lc.SetValue(ctx, completedPipelineId, cache.Status, inProgressStatus)
+				_ = lc.SetValue(ctx, completedPipelineId, cache.RunOutput, output)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.Status, inProgressStatus)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.RunOutput, output)
+			},
+			evictedIds:       nil,
+			survivedIds:      []uuid.UUID{oldestPipelineId, completedPipelineId, newestPipelineId},
+			totalOutputBytes: 120,
+		},
+		{
+			// Test case with updating the output of the pipeline several times.
+			// As a result, only the size of the last output should be counted.
+			name: "updated output is counted once",
+			prepare: func(lc *Cache) {
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.Status, completedStatus)
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.RunOutput, output)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.Status, inProgressStatus)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.RunOutput, output[:10])
+				_ = lc.SetValue(ctx, newestPipelineId, cache.RunOutput, output[:20])
+				_ = lc.SetValue(ctx, newestPipelineId, cache.RunOutput, output)
+			},
+			evictedIds:       nil,
+			survivedIds:      []uuid.UUID{oldestPipelineId, newestPipelineId},
+			totalOutputBytes: 80,
+		},
+		{
+			// Test case with the output of the single pipeline which exceeds the max size of the outputs.
+			// As a result, the output of the pipeline which is being written should be kept.
+			name: "outputs of written pipeline are kept",
+			prepare: func(lc *Cache) {
+				_ = lc.SetValue(ctx, newestPipelineId, cache.Status, inProgressStatus)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.RunOutput, output+output+output)
+			},
+			evictedIds:       nil,
+			survivedIds:      []uuid.UUID{newestPipelineId},
+			totalOutputBytes: 120,
+		},
+		{
+			// Test case with removing the pipeline from the cache.
+			// As a result, the size of its outputs shouldn't be counted.
+			name: "outputs of removed pipeline aren't counted",
+			prepare: func(lc *Cache) {
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.Status, completedStatus)
+				_ = lc.SetValue(ctx, oldestPipelineId, cache.RunOutput, output)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.Status, inProgressStatus)
+				_ = lc.SetValue(ctx, newestPipelineId, cache.RunOutput, output)
+				lc.clearItems([]uuid.UUID{oldestPipelineId})
+			},
+			evictedIds:       nil,
+			survivedIds:      []uuid.UUID{newestPipelineId},
+			totalOutputBytes: 40,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := NewWithLimits(ctx, 0, 100, isCompleted)
+			tt.prepare(lc)
+			for _, pipelineId := range tt.evictedIds {
+				if value, err := lc.GetValue(ctx, pipelineId, cache.RunOutput); err == nil {
+					t.Errorf("GetValue() of evicted output got = %v, want error", value)
+				}
+				if _, err := lc.GetValue(ctx, pipelineId, cache.Status); err != nil {
+					t.Errorf("GetValue() of status of pipeline with evicted output error = %v", err)
+				}
+			}
+			for _, pipelineId := range tt.survivedIds {
+				if _, err := lc.GetValue(ctx, pipelineId, cache.RunOutput); err != nil {
+					t.Errorf("GetValue() of survived output error = %v", err)
+				}
+			}
+			if lc.totalOutputBytes != tt.totalOutputBytes {
+				t.Errorf("cache counts %d bytes of outputs, want %d", lc.totalOutputBytes, tt.totalOutputBytes)
+			}
+		})
+	}
+}
+
+func TestLocalCache_StatusChanged(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pipelineId := uuid.New()
+
+	tests := []struct {
+		name        string
+		prepare     func(lc *Cache)
+		change      func(lc *Cache)
+		wantChanged bool
+	}{
+		{
+			// Test case with setting the status of the pipeline.
+			// As a result, the channel should be closed.
+			name: "status is set",
+			prepare: func(lc *Cache) {
+				_ = lc.SetValue(ctx, pipelineId, cache.Status, 1)
+			},
+			change: func(lc *Cache) {
+				_ = lc.SetValue(ctx, pipelineId, cache.Status, 2)
+			},
+			wantChanged: true,
+		},
+		{
+			// Test case with setting another value of the pipeline.
+			// As a result, the channel shouldn't be closed.
+			name: "another value is set",
+			prepare: func(lc *Cache) {
+				_ = lc.SetValue(ctx, pipelineId, cache.Status, 1)
+			},
+			change: func(lc *Cache) {
+				_ = lc.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_OUTPUT")
+			},
+			wantChanged: false,
+		},
+		{
+			// Test case with removing the pipeline from the cache.
+			// As a result, the channel should be closed.
+			name: "pipeline is removed",
+			prepare: func(lc *Cache) {
+				_ = lc.SetValue(ctx, pipelineId, cache.Status, 1)
+			},
+			change: func(lc *Cache) {
+				lc.clearItems([]uuid.UUID{pipelineId})
+			},
+			wantChanged: true,
+		},
+		{
+			// Test case with the pipeline which doesn't exist in the cache.
+			// As a result, the channel should be closed, so nobody waits for the status which won't be set.
+			name:        "pipeline doesn't exist",
+			prepare:     func(lc *Cache) {},
+			change:      func(lc *Cache) {},
+			wantChanged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := New(ctx)
+			tt.prepare(lc)
+			statusChanged := lc.StatusChanged(pipelineId)
+			tt.change(lc)
+			changed := false
+			select {
+			case <-statusChanged:
+				changed = true
+			default:
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("StatusChanged() is closed = %v, want %v", changed, tt.wantChanged)
+			}
+			if len(lc.statusChanged) != 0 && tt.wantChanged {
+				t.Errorf("cache keeps %d channels of notified pipelines", len(lc.statusChanged))
+			}
+		})
+	}
+}
+
+func TestLocalCache_ActivePipelines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	isCompleted := func(status interface{}) bool {
+		return status == "MOCK_COMPLETED"
+	}
+	running, completed, removed, withoutStatus := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	// Test case with calling ActivePipelines method when pipelines have different statuses.
+	// As a result, want to receive only the pipelines which have the status and aren't completed.
+	lc := NewWithLimits(ctx, 0, 0, isCompleted)
+	_ = lc.SetValue(ctx, running, cache.Status, "MOCK_RUNNING")
+	_ = lc.SetValue(ctx, completed, cache.Status, "MOCK_RUNNING")
+	_ = lc.SetValue(ctx, completed, cache.Status, "MOCK_COMPLETED")
+	_ = lc.SetValue(ctx, removed, cache.Status, "MOCK_RUNNING")
+	lc.clearItems([]uuid.UUID{removed})
+	_ = lc.SetValue(ctx, withoutStatus, cache.RunOutput, "MOCK_OUTPUT")
+	got, err := lc.ActivePipelines(ctx)
+	if err != nil {
+		t.Fatalf("ActivePipelines() error = %v", err)
+	}
+	if want := []uuid.UUID{running}; !samePipelineIdSlices(got, want) {
+		t.Errorf("ActivePipelines() got = %v, want %v", got, want)
+	}
+
+	// Test case with calling ActivePipelines method of the cache which doesn't know the completed statuses.
+	// As a result, want to receive all pipelines which have the status.
+	lc = New(ctx)
+	_ = lc.SetValue(ctx, running, cache.Status, "MOCK_RUNNING")
+	_ = lc.SetValue(ctx, completed, cache.Status, "MOCK_COMPLETED")
+	got, err = lc.ActivePipelines(ctx)
+	if err != nil {
+		t.Fatalf("ActivePipelines() error = %v", err)
+	}
+	if want := []uuid.UUID{running, completed}; !samePipelineIdSlices(got, want) {
+		t.Errorf("ActivePipelines() got = %v, want %v", got, want)
+	}
+}
+
 func TestLocalCache_SetExpTime(t *testing.T) {
 	preparedId, _ := uuid.NewUUID()
 	type fields struct {
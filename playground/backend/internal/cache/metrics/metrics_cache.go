@@ -0,0 +1,102 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	hitResult   = "hit"
+	missResult  = "miss"
+	errorResult = "error"
+)
+
+// getRequestsTotal is the number of the requests to get values from the cache by subKey and result (hit, miss or error)
+var getRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "playground",
+	Subsystem: "cache",
+	Name:      "get_requests_total",
+	Help:      "The total number of the requests to get values from the cache by subKey and result.",
+}, []string{"sub_key", "result"})
+
+// Cache is a decorator of cache.Cache which counts hits and misses of GetValue and GetValues by subKey.
+// All calls are passed to the wrapped cache as is, so the behaviour and errors of the wrapped cache are kept.
+type Cache struct {
+	cache.Cache
+}
+
+// New returns cache.Cache which wraps cacheService and counts hits and misses of its GetValue and GetValues
+func New(cacheService cache.Cache) *Cache {
+	return &Cache{Cache: cacheService}
+}
+
+// GetValue returns value from the wrapped cache by pipelineId and subKey.
+// The request is counted as a miss if the wrapped cache returns an error which wraps cache.ErrNotFound,
+//	as an error if it returns any other error and as a hit otherwise.
+func (c *Cache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey) (interface{}, error) {
+	value, err := c.Cache.GetValue(ctx, pipelineId, subKey)
+	result := hitResult
+	if errors.Is(err, cache.ErrNotFound) {
+		result = missResult
+	} else if err != nil {
+		result = errorResult
+	}
+	getRequestsTotal.WithLabelValues(string(subKey), result).Inc()
+	return value, err
+}
+
+// GetValues returns values from the wrapped cache by pipelineId and subKeys.
+// Each subKey is counted separately: as a hit if its value is returned, as a miss if it isn't
+//	and as an error if the wrapped cache returns an error which doesn't wrap cache.ErrNotFound.
+func (c *Cache) GetValues(ctx context.Context, pipelineId uuid.UUID, subKeys []cache.SubKey) (map[cache.SubKey]interface{}, error) {
+	values, err := c.Cache.GetValues(ctx, pipelineId, subKeys)
+	for _, subKey := range subKeys {
+		result := hitResult
+		if _, found := values[subKey]; !found {
+			result = missResult
+		}
+		if err != nil && !errors.Is(err, cache.ErrNotFound) {
+			result = errorResult
+		}
+		getRequestsTotal.WithLabelValues(string(subKey), result).Inc()
+	}
+	return values, err
+}
+
+// StatusChanged returns the channel of the wrapped cache which is closed when the status of the pipeline is set.
+// Returns nil if the wrapped cache doesn't notify about changes of the status.
+func (c *Cache) StatusChanged(pipelineId uuid.UUID) <-chan struct{} {
+	if notifier, ok := c.Cache.(cache.StatusNotifier); ok {
+		return notifier.StatusChanged(pipelineId)
+	}
+	return nil
+}
+
+// ActivePipelines returns ids of the pipelines in progress from the wrapped cache.
+// Returns an error which wraps cache.ErrNotSupported if the wrapped cache doesn't keep the index of the pipelines.
+func (c *Cache) ActivePipelines(ctx context.Context) ([]uuid.UUID, error) {
+	if lister, ok := c.Cache.(cache.ActivePipelinesLister); ok {
+		return lister.ActivePipelines(ctx)
+	}
+	return nil, fmt.Errorf("active pipelines: %w", cache.ErrNotSupported)
+}
@@ -0,0 +1,228 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/cache/local"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"testing"
+)
+
+// failingCache is cache.Cache which returns an error from all methods
+type failingCache struct {
+	cache.Cache
+}
+
+func (c failingCache) GetValue(_ context.Context, _ uuid.UUID, _ cache.SubKey) (interface{}, error) {
+	return nil, errors.New("MOCK_ERROR")
+}
+
+func counter(subKey cache.SubKey, result string) float64 {
+	return testutil.ToFloat64(getRequestsTotal.WithLabelValues(string(subKey), result))
+}
+
+func TestCache_GetValue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pipelineId := uuid.New()
+	localCache := local.New(ctx)
+	if err := localCache.SetValue(ctx, pipelineId, cache.Status, "MOCK_STATUS"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		cacheService cache.Cache
+		subKey       cache.SubKey
+		want         interface{}
+		wantErr      bool
+		wantResult   string
+	}{
+		{
+			// Test case with calling GetValue method with the subKey which value exists in the cache.
+			// As a result, want to receive the value and the increased number of hits.
+			name:         "hit",
+			cacheService: localCache,
+			subKey:       cache.Status,
+			want:         "MOCK_STATUS",
+			wantErr:      false,
+			wantResult:   hitResult,
+		},
+		{
+			// Test case with calling GetValue method with the subKey which value doesn't exist in the cache.
+			// As a result, want to receive cache.ErrNotFound and the increased number of misses.
+			name:         "miss",
+			cacheService: localCache,
+			subKey:       cache.RunOutput,
+			want:         nil,
+			wantErr:      true,
+			wantResult:   missResult,
+		},
+		{
+			// Test case with calling GetValue method when the wrapped cache returns an error.
+			// As a result, want to receive the error and the increased number of errors.
+			name:         "error",
+			cacheService: failingCache{},
+			subKey:       cache.RunError,
+			want:         nil,
+			wantErr:      true,
+			wantResult:   errorResult,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := map[string]float64{}
+			for _, result := range []string{hitResult, missResult, errorResult} {
+				before[result] = counter(tt.subKey, result)
+			}
+			// the result of the wrapped cache which should be passed through
+			_, wrappedErr := tt.cacheService.GetValue(ctx, pipelineId, tt.subKey)
+
+			got, err := New(tt.cacheService).GetValue(ctx, pipelineId, tt.subKey)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && err.Error() != wrappedErr.Error() {
+				t.Errorf("GetValue() error = %v, want the error of the wrapped cache %v", err, wrappedErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetValue() got = %v, want %v", got, tt.want)
+			}
+			for result, value := range before {
+				want := value
+				if result == tt.wantResult {
+					want++
+				}
+				if got := counter(tt.subKey, result); got != want {
+					t.Errorf("GetValue() %s requests = %v, want %v", result, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCache_GetValueSeveralCalls(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pipelineId := uuid.New()
+	metricsCache := New(local.New(ctx))
+	hitsBefore := counter(cache.Graph, hitResult)
+	missesBefore := counter(cache.Graph, missResult)
+
+	if _, err := metricsCache.GetValue(ctx, pipelineId, cache.Graph); !errors.Is(err, cache.ErrNotFound) {
+		t.Fatalf("GetValue() error = %v, want %v", err, cache.ErrNotFound)
+	}
+	if err := metricsCache.SetValue(ctx, pipelineId, cache.Graph, "MOCK_GRAPH"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := metricsCache.GetValue(ctx, pipelineId, cache.Graph); err != nil {
+			t.Fatalf("GetValue() error = %v", err)
+		}
+	}
+
+	if got := counter(cache.Graph, hitResult); got != hitsBefore+2 {
+		t.Errorf("GetValue() hits = %v, want %v", got, hitsBefore+2)
+	}
+	if got := counter(cache.Graph, missResult); got != missesBefore+1 {
+		t.Errorf("GetValue() misses = %v, want %v", got, missesBefore+1)
+	}
+}
+
+func TestCache_GetValues(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pipelineId := uuid.New()
+	metricsCache := New(local.New(ctx))
+	if err := metricsCache.SetValue(ctx, pipelineId, cache.CompileOutput, "MOCK_OUTPUT"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	hitsBefore := counter(cache.CompileOutput, hitResult)
+	missesBefore := counter(cache.CompileErrors, missResult)
+
+	values, err := metricsCache.GetValues(ctx, pipelineId, []cache.SubKey{cache.CompileOutput, cache.CompileErrors})
+	if err != nil {
+		t.Fatalf("GetValues() error = %v", err)
+	}
+	if values[cache.CompileOutput] != "MOCK_OUTPUT" {
+		t.Errorf("GetValues() got = %v, want %v", values[cache.CompileOutput], "MOCK_OUTPUT")
+	}
+	if got := counter(cache.CompileOutput, hitResult); got != hitsBefore+1 {
+		t.Errorf("GetValues() hits = %v, want %v", got, hitsBefore+1)
+	}
+	if got := counter(cache.CompileErrors, missResult); got != missesBefore+1 {
+		t.Errorf("GetValues() misses = %v, want %v", got, missesBefore+1)
+	}
+}
+
+func TestCache_StatusChanged(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pipelineId := uuid.New()
+	localCache := local.New(ctx)
+	if err := localCache.SetValue(ctx, pipelineId, cache.Status, "MOCK_STATUS"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+
+	// Test case with calling StatusChanged method of the cache which wraps the cache notifying about changes of the status.
+	// As a result, want to receive the channel of the wrapped cache which is closed when the status is set.
+	statusChanged := New(localCache).StatusChanged(pipelineId)
+	if err := localCache.SetValue(ctx, pipelineId, cache.Status, "MOCK_NEW_STATUS"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	select {
+	case <-statusChanged:
+	default:
+		t.Errorf("StatusChanged() isn't closed after the status is set")
+	}
+
+	// Test case with calling StatusChanged method of the cache which wraps the cache without notifications.
+	// As a result, want to receive nil.
+	if got := New(failingCache{}).StatusChanged(pipelineId); got != nil {
+		t.Errorf("StatusChanged() got = %v, want nil", got)
+	}
+}
+
+func TestCache_ActivePipelines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pipelineId := uuid.New()
+	localCache := local.New(ctx)
+	if err := localCache.SetValue(ctx, pipelineId, cache.Status, "MOCK_STATUS"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+
+	// Test case with calling ActivePipelines method of the cache which wraps the cache keeping the index of the pipelines.
+	// As a result, want to receive the pipelines of the wrapped cache.
+	got, err := New(localCache).ActivePipelines(ctx)
+	if err != nil {
+		t.Fatalf("ActivePipelines() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != pipelineId {
+		t.Errorf("ActivePipelines() got = %v, want [%v]", got, pipelineId)
+	}
+
+	// Test case with calling ActivePipelines method of the cache which wraps the cache without the index.
+	// As a result, want to receive cache.ErrNotSupported.
+	if _, err := New(failingCache{}).ActivePipelines(ctx); !errors.Is(err, cache.ErrNotSupported) {
+		t.Errorf("ActivePipelines() error = %v, want %v", err, cache.ErrNotSupported)
+	}
+}
@@ -16,7 +16,6 @@
 package redis
 
 import (
-	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/cache"
 	"beam.apache.org/playground/backend/internal/logger"
 	"context"
@@ -24,9 +23,29 @@ import (
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
+	"strconv"
+	"strings"
 	"time"
 )
 
+const (
+	// valueVersion is the version of the format of the values which are saved into Redis.
+	// It should be incremented each time the format of the values is changed incompatibly.
+	valueVersion = 1
+	// valueVersionPrefix and valueVersionSeparator wrap the version of the value: "v1:{json value}".
+	// JSON values never start with valueVersionPrefix, so the values without the version are also recognized.
+	valueVersionPrefix    = "v"
+	valueVersionSeparator = ":"
+)
+
+// updateValueScript sets the field of the hash only if the hash exists, so the expired hash isn't created again.
+// Returns 1 if the field is set and 0 if the hash doesn't exist.
+var updateValueScript = redis.NewScript(`if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+return 1`)
+
 type Cache struct {
 	*redis.Client
 }
@@ -52,12 +71,51 @@ func (rc *Cache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cach
 	value, err := rc.HGet(ctx, pipelineId.String(), string(subKeyMarsh)).Result()
 	if err != nil {
 		logger.Errorf("Redis Cache: get value: error during HGet operation for key: %s, subKey: %s, err: %s\n", pipelineId.String(), subKey, err.Error())
+		if err == redis.Nil {
+			return nil, fmt.Errorf("value with pipelineId: %s and subKey: %s %w", pipelineId, subKey, cache.ErrNotFound)
+		}
 		return nil, err
 	}
 
 	return unmarshalBySubKey(subKey, value)
 }
 
+// GetValues returns values by subKeys with a single HMGET operation, so all values are read atomically.
+// Values which don't exist aren't added to the result.
+func (rc *Cache) GetValues(ctx context.Context, pipelineId uuid.UUID, subKeys []cache.SubKey) (map[cache.SubKey]interface{}, error) {
+	fields := make([]string, 0, len(subKeys))
+	for _, subKey := range subKeys {
+		subKeyMarsh, err := json.Marshal(subKey)
+		if err != nil {
+			logger.Errorf("Redis Cache: get values: error during marshal subKey: %s, err: %s\n", subKey, err.Error())
+			return nil, err
+		}
+		fields = append(fields, string(subKeyMarsh))
+	}
+	result, err := rc.HMGet(ctx, pipelineId.String(), fields...).Result()
+	if err != nil {
+		logger.Errorf("Redis Cache: get values: error during HMGet operation for key: %s, subKeys: %s, err: %s\n", pipelineId.String(), subKeys, err.Error())
+		return nil, err
+	}
+
+	values := make(map[cache.SubKey]interface{}, len(subKeys))
+	for i, value := range result {
+		stringValue, ok := value.(string)
+		if !ok {
+			continue
+		}
+		unmarshalled, err := unmarshalBySubKey(subKeys[i], stringValue)
+		if err != nil {
+			return nil, err
+		}
+		values[subKeys[i]] = unmarshalled
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("values with pipelineId: %s and subKeys: %s %w", pipelineId, subKeys, cache.ErrNotFound)
+	}
+	return values, nil
+}
+
 func (rc *Cache) SetValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) error {
 	subKeyMarsh, err := json.Marshal(subKey)
 	if err != nil {
@@ -69,7 +127,7 @@ func (rc *Cache) SetValue(ctx context.Context, pipelineId uuid.UUID, subKey cach
 		logger.Errorf("Redis Cache: set value: error during marshal value: %s, err: %s\n", value, err.Error())
 		return err
 	}
-	_, err = rc.HSet(ctx, pipelineId.String(), subKeyMarsh, valueMarsh).Result()
+	_, err = rc.HSet(ctx, pipelineId.String(), subKeyMarsh, versionedValue(subKey, valueMarsh)).Result()
 	if err != nil {
 		logger.Errorf("Redis Cache: set value: error during HSet operation, err: %s\n", err.Error())
 		return err
@@ -77,6 +135,44 @@ func (rc *Cache) SetValue(ctx context.Context, pipelineId uuid.UUID, subKey cach
 	return nil
 }
 
+// UpdateValue sets the value by subKey with a single script, so the value is set only if the key exists at the same moment.
+// If the key doesn't exist (or is expired), UpdateValue returns an error.
+func (rc *Cache) UpdateValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) error {
+	subKeyMarsh, err := json.Marshal(subKey)
+	if err != nil {
+		logger.Errorf("Redis Cache: update value: error during marshal subKey: %s, err: %s\n", subKey, err.Error())
+		return err
+	}
+	valueMarsh, err := json.Marshal(value)
+	if err != nil {
+		logger.Errorf("Redis Cache: update value: error during marshal value: %s, err: %s\n", value, err.Error())
+		return err
+	}
+	updated, err := updateValueScript.Run(ctx, rc, []string{pipelineId.String()}, string(subKeyMarsh), string(versionedValue(subKey, valueMarsh))).Int()
+	if err != nil {
+		logger.Errorf("Redis Cache: update value: error during running script for key: %s, subKey: %s, err: %s\n", pipelineId.String(), subKey, err.Error())
+		return err
+	}
+	if updated == 0 {
+		return fmt.Errorf("key: %s %w", pipelineId, cache.ErrNotFound)
+	}
+	return nil
+}
+
+func (rc *Cache) IncrementValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, delta int) (int, error) {
+	subKeyMarsh, err := json.Marshal(subKey)
+	if err != nil {
+		logger.Errorf("Redis Cache: increment value: error during marshal subKey: %s, err: %s\n", subKey, err.Error())
+		return 0, err
+	}
+	value, err := rc.HIncrBy(ctx, pipelineId.String(), string(subKeyMarsh), int64(delta)).Result()
+	if err != nil {
+		logger.Errorf("Redis Cache: increment value: error during HIncrBy operation for key: %s, subKey: %s, err: %s\n", pipelineId.String(), subKey, err.Error())
+		return 0, err
+	}
+	return int(value), nil
+}
+
 func (rc *Cache) SetExpTime(ctx context.Context, pipelineId uuid.UUID, expTime time.Duration) error {
 	exists, err := rc.Exists(ctx, pipelineId.String()).Result()
 	if err != nil {
@@ -96,27 +192,50 @@ func (rc *Cache) SetExpTime(ctx context.Context, pipelineId uuid.UUID, expTime t
 	return nil
 }
 
-// unmarshalBySubKey unmarshal value by subKey
-func unmarshalBySubKey(subKey cache.SubKey, value string) (result interface{}, err error) {
-	switch subKey {
-	case cache.Status:
-		result = new(pb.Status)
-	case cache.RunOutput, cache.RunError, cache.CompileOutput, cache.Logs:
-		result = ""
-	case cache.Canceled:
-		result = false
-	case cache.RunOutputIndex, cache.LogsIndex:
-		result = 0
+// versionedValue returns the marshalled value with the prefix of the current version of the format.
+// Counters are kept without the version because Redis increments only integer values.
+func versionedValue(subKey cache.SubKey, valueMarsh []byte) []byte {
+	if isCounter(subKey) {
+		return valueMarsh
+	}
+	return []byte(fmt.Sprintf("%s%d%s%s", valueVersionPrefix, valueVersion, valueVersionSeparator, valueMarsh))
+}
+
+// unversionedValue returns the marshalled value without the prefix of the version.
+// Values without the version are saved before the format is versioned or are counters, so they are returned as is.
+// In case the version of the value is newer than valueVersion returns an error which wraps cache.ErrIncompatibleVersion.
+func unversionedValue(subKey cache.SubKey, value string) (string, error) {
+	if !strings.HasPrefix(value, valueVersionPrefix) {
+		return value, nil
 	}
-	err = json.Unmarshal([]byte(value), &result)
+	separatorIndex := strings.Index(value, valueVersionSeparator)
+	if separatorIndex < 0 {
+		return "", fmt.Errorf("value with subKey: %s has malformed version: %w", subKey, cache.ErrIncompatibleVersion)
+	}
+	version := value[len(valueVersionPrefix):separatorIndex]
+	if number, err := strconv.Atoi(version); err != nil || number > valueVersion {
+		return "", fmt.Errorf("value with subKey: %s has version %s, but the supported version is %d: %w", subKey, version, valueVersion, cache.ErrIncompatibleVersion)
+	}
+	return value[separatorIndex+len(valueVersionSeparator):], nil
+}
+
+// isCounter checks if the value by subKey is incremented by IncrementValue
+func isCounter(subKey cache.SubKey) bool {
+	return subKey == cache.RunOutputIndex || subKey == cache.CompileOutputStreamIndex || subKey == cache.LogsIndex || subKey == cache.IdempotencyClaims || subKey == cache.ClientActivePipelines
+}
+
+// unmarshalBySubKey unmarshal value by subKey (see cache.UnmarshalValue)
+// In case the version of the value isn't supported returns an error which wraps cache.ErrIncompatibleVersion.
+func unmarshalBySubKey(subKey cache.SubKey, value string) (interface{}, error) {
+	value, err := unversionedValue(subKey, value)
 	if err != nil {
 		logger.Errorf("Redis Cache: get value: error during unmarshal value, err: %s\n", err.Error())
+		return nil, err
 	}
-
-	switch subKey {
-	case cache.Status:
-		result = *result.(*pb.Status)
+	result, err := cache.UnmarshalValue(subKey, []byte(value))
+	if err != nil {
+		logger.Errorf("Redis Cache: get value: error during unmarshal value, err: %s\n", err.Error())
+		return nil, err
 	}
-
-	return
+	return result, nil
 }
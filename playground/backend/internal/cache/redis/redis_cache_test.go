@@ -20,6 +20,7 @@ import (
 	"beam.apache.org/playground/backend/internal/cache"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	"github.com/go-redis/redismock/v8"
@@ -197,6 +198,8 @@ func TestRedisCache_SetValue(t *testing.T) {
 	client, mock := redismock.NewClientMock()
 	marshSubKey, _ := json.Marshal(subKey)
 	marshValue, _ := json.Marshal(value)
+	versionedValue := []byte("v1:" + string(marshValue))
+	marshCounterSubKey, _ := json.Marshal(cache.RunOutputIndex)
 
 	type fields struct {
 		redisClient *redis.Client
@@ -217,7 +220,7 @@ func TestRedisCache_SetValue(t *testing.T) {
 		{
 			name: "error during HSet operation",
 			mocks: func() {
-				mock.ExpectHSet(pipelineId.String(), marshSubKey, marshValue).SetErr(fmt.Errorf("MOCK_ERROR"))
+				mock.ExpectHSet(pipelineId.String(), marshSubKey, versionedValue).SetErr(fmt.Errorf("MOCK_ERROR"))
 			},
 			fields: fields{client},
 			args: args{
@@ -231,7 +234,7 @@ func TestRedisCache_SetValue(t *testing.T) {
 		{
 			name: "all success",
 			mocks: func() {
-				mock.ExpectHSet(pipelineId.String(), marshSubKey, marshValue).SetVal(1)
+				mock.ExpectHSet(pipelineId.String(), marshSubKey, versionedValue).SetVal(1)
 				mock.ExpectExpire(pipelineId.String(), time.Minute*15).SetVal(true)
 			},
 			fields: fields{client},
@@ -243,6 +246,20 @@ func TestRedisCache_SetValue(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "counter is saved without version",
+			mocks: func() {
+				mock.ExpectHSet(pipelineId.String(), marshCounterSubKey, []byte("0")).SetVal(1)
+			},
+			fields: fields{client},
+			args: args{
+				ctx:        context.Background(),
+				pipelineId: pipelineId,
+				subKey:     cache.RunOutputIndex,
+				value:      0,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -258,6 +275,132 @@ func TestRedisCache_SetValue(t *testing.T) {
 	}
 }
 
+func TestRedisCache_UpdateValue(t *testing.T) {
+	pipelineId := uuid.New()
+	subKey := cache.Status
+	value := pb.Status_STATUS_FINISHED
+	client, mock := redismock.NewClientMock()
+	marshSubKey, _ := json.Marshal(subKey)
+	marshValue, _ := json.Marshal(value)
+	versionedValue := "v1:" + string(marshValue)
+	keys := []string{pipelineId.String()}
+
+	tests := []struct {
+		name         string
+		mocks        func()
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			// Test case with updating value of the existing key.
+			// As a result, want to receive no error.
+			name: "all success",
+			mocks: func() {
+				mock.ExpectEvalSha(updateValueScript.Hash(), keys, string(marshSubKey), versionedValue).SetVal(int64(1))
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with updating value of the key which doesn't exist.
+			// As a result, want to receive an error which wraps cache.ErrNotFound.
+			name: "key doesn't exist",
+			mocks: func() {
+				mock.ExpectEvalSha(updateValueScript.Hash(), keys, string(marshSubKey), versionedValue).SetVal(int64(0))
+			},
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			// Test case with updating value when the script is failed.
+			// As a result, want to receive an error.
+			name: "error during running script",
+			mocks: func() {
+				mock.ExpectEvalSha(updateValueScript.Hash(), keys, string(marshSubKey), versionedValue).SetErr(fmt.Errorf("MOCK_ERROR"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			rc := &Cache{client}
+			err := rc.UpdateValue(context.Background(), pipelineId, subKey, value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, cache.ErrNotFound) != tt.wantNotFound {
+				t.Errorf("UpdateValue() error = %v, want not found error %v", err, tt.wantNotFound)
+			}
+			mock.ClearExpect()
+		})
+	}
+}
+
+func TestRedisCache_IncrementValue(t *testing.T) {
+	pipelineId := uuid.New()
+	subKey := cache.RunOutputIndex
+	client, mock := redismock.NewClientMock()
+	marshSubKey, _ := json.Marshal(subKey)
+
+	type args struct {
+		ctx        context.Context
+		pipelineId uuid.UUID
+		subKey     cache.SubKey
+		delta      int
+	}
+	tests := []struct {
+		name    string
+		mocks   func()
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "error during HIncrBy operation",
+			mocks: func() {
+				mock.ExpectHIncrBy(pipelineId.String(), string(marshSubKey), 5).SetErr(fmt.Errorf("MOCK_ERROR"))
+			},
+			args: args{
+				ctx:        context.Background(),
+				pipelineId: pipelineId,
+				subKey:     subKey,
+				delta:      5,
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "all success",
+			mocks: func() {
+				mock.ExpectHIncrBy(pipelineId.String(), string(marshSubKey), 5).SetVal(10)
+			},
+			args: args{
+				ctx:        context.Background(),
+				pipelineId: pipelineId,
+				subKey:     subKey,
+				delta:      5,
+			},
+			want:    10,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			rc := &Cache{client}
+			got, err := rc.IncrementValue(tt.args.ctx, tt.args.pipelineId, tt.args.subKey, tt.args.delta)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IncrementValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IncrementValue() got = %v, want %v", got, tt.want)
+			}
+			mock.ClearExpect()
+		})
+	}
+}
+
 func Test_newRedisCache(t *testing.T) {
 	address := "host:port"
 	type args struct {
@@ -290,8 +433,24 @@ func Test_newRedisCache(t *testing.T) {
 func Test_unmarshalBySubKey(t *testing.T) {
 	status := pb.Status_STATUS_FINISHED
 	statusValue, _ := json.Marshal(status)
+	sdk := pb.Sdk_SDK_GO
+	sdkValue, _ := json.Marshal(sdk)
+	errorCategory := pb.ErrorCategory_ERROR_CATEGORY_OOM
+	errorCategoryValue, _ := json.Marshal(errorCategory)
+	canceledPhase := pb.CancelPhase_CANCEL_PHASE_RUN
+	canceledPhaseValue, _ := json.Marshal(canceledPhase)
 	output := "MOCK_OUTPUT"
 	outputValue, _ := json.Marshal(output)
+	index := 42
+	indexValue, _ := json.Marshal(index)
+	canceled := true
+	canceledValue, _ := json.Marshal(canceled)
+	duration := 1500 * time.Millisecond
+	durationValue, _ := json.Marshal(duration)
+	lastAccessed := time.Date(2021, time.November, 1, 10, 0, 0, 0, time.UTC)
+	lastAccessedValue, _ := json.Marshal(lastAccessed)
+	examples := []*pb.Example{{Name: "MOCK_NAME", Description: "MOCK_DESCRIPTION", Sdk: pb.Sdk_SDK_JAVA}}
+	examplesValue, _ := json.Marshal(examples)
 	type args struct {
 		ctx    context.Context
 		subKey cache.SubKey
@@ -313,6 +472,15 @@ func Test_unmarshalBySubKey(t *testing.T) {
 			want:    status,
 			wantErr: false,
 		},
+		{
+			name: "sdk subKey",
+			args: args{
+				subKey: cache.Sdk,
+				value:  string(sdkValue),
+			},
+			want:    sdk,
+			wantErr: false,
+		},
 		{
 			name: "runOutput subKey",
 			args: args{
@@ -331,6 +499,132 @@ func Test_unmarshalBySubKey(t *testing.T) {
 			want:    output,
 			wantErr: false,
 		},
+		{
+			name: "runOutputIndex subKey",
+			args: args{
+				subKey: cache.RunOutputIndex,
+				value:  string(indexValue),
+			},
+			want:    index,
+			wantErr: false,
+		},
+		{
+			name: "runExitCode subKey",
+			args: args{
+				subKey: cache.RunExitCode,
+				value:  string(indexValue),
+			},
+			want:    index,
+			wantErr: false,
+		},
+		{
+			name: "errorCategory subKey",
+			args: args{
+				subKey: cache.ErrorCategory,
+				value:  string(errorCategoryValue),
+			},
+			want:    errorCategory,
+			wantErr: false,
+		},
+		{
+			name: "canceledPhase subKey",
+			args: args{
+				subKey: cache.CanceledPhase,
+				value:  string(canceledPhaseValue),
+			},
+			want:    canceledPhase,
+			wantErr: false,
+		},
+		{
+			name: "canceled subKey",
+			args: args{
+				subKey: cache.Canceled,
+				value:  string(canceledValue),
+			},
+			want:    canceled,
+			wantErr: false,
+		},
+		{
+			name: "compileTime subKey",
+			args: args{
+				subKey: cache.CompileTime,
+				value:  string(durationValue),
+			},
+			want:    duration,
+			wantErr: false,
+		},
+		{
+			name: "runTime subKey",
+			args: args{
+				subKey: cache.RunTime,
+				value:  string(durationValue),
+			},
+			want:    duration,
+			wantErr: false,
+		},
+		{
+			name: "lastAccessed subKey",
+			args: args{
+				subKey: cache.LastAccessed,
+				value:  string(lastAccessedValue),
+			},
+			want:    lastAccessed,
+			wantErr: false,
+		},
+		{
+			name: "startTime subKey",
+			args: args{
+				subKey: cache.StartTime,
+				value:  string(lastAccessedValue),
+			},
+			want:    lastAccessed,
+			wantErr: false,
+		},
+		{
+			name: "examplesCatalog subKey",
+			args: args{
+				subKey: cache.ExamplesCatalog,
+				value:  string(examplesValue),
+			},
+			want:    examples,
+			wantErr: false,
+		},
+		{
+			name: "compileOutputStreamIndex subKey",
+			args: args{
+				subKey: cache.CompileOutputStreamIndex,
+				value:  string(indexValue),
+			},
+			want:    index,
+			wantErr: false,
+		},
+		{
+			name: "idempotencyClaims subKey",
+			args: args{
+				subKey: cache.IdempotencyClaims,
+				value:  string(indexValue),
+			},
+			want:    index,
+			wantErr: false,
+		},
+		{
+			name: "clientActivePipelines subKey",
+			args: args{
+				subKey: cache.ClientActivePipelines,
+				value:  string(indexValue),
+			},
+			want:    index,
+			wantErr: false,
+		},
+		{
+			name: "idempotentPipelineId subKey",
+			args: args{
+				subKey: cache.IdempotentPipelineId,
+				value:  string(outputValue),
+			},
+			want:    output,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -344,3 +638,151 @@ func Test_unmarshalBySubKey(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisCache_ValueVersion(t *testing.T) {
+	pipelineId := uuid.New()
+	subKey := cache.RunOutput
+	marshSubKey, _ := json.Marshal(subKey)
+	client, mock := redismock.NewClientMock()
+	rc := &Cache{client}
+
+	// the value saved by the current version is read back as is
+	saved := `v1:"MOCK_OUTPUT"`
+	mock.ExpectHSet(pipelineId.String(), marshSubKey, []byte(saved)).SetVal(1)
+	if err := rc.SetValue(context.TODO(), pipelineId, subKey, "MOCK_OUTPUT"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	mock.ClearExpect()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    interface{}
+		wantErr error
+	}{
+		{
+			// Test case with reading the value which is saved by the current version.
+			// As a result, want to receive the saved value.
+			name:    "current version",
+			value:   saved,
+			want:    "MOCK_OUTPUT",
+			wantErr: nil,
+		},
+		{
+			// Test case with reading the value which is saved before the format is versioned.
+			// As a result, want to receive the saved value.
+			name:    "value without version",
+			value:   `"MOCK_OUTPUT"`,
+			want:    "MOCK_OUTPUT",
+			wantErr: nil,
+		},
+		{
+			// Test case with reading the value which is saved by the newer version.
+			// As a result, want to receive an error which wraps cache.ErrIncompatibleVersion.
+			name:    "future version",
+			value:   `v2:{"output":"MOCK_OUTPUT"}`,
+			want:    nil,
+			wantErr: cache.ErrIncompatibleVersion,
+		},
+		{
+			// Test case with reading the value with the version which couldn't be parsed.
+			// As a result, want to receive an error which wraps cache.ErrIncompatibleVersion.
+			name:    "malformed version",
+			value:   `vX"MOCK_OUTPUT"`,
+			want:    nil,
+			wantErr: cache.ErrIncompatibleVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectHGet(pipelineId.String(), string(marshSubKey)).SetVal(tt.value)
+			got, err := rc.GetValue(context.TODO(), pipelineId, subKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValue() got = %v, want %v", got, tt.want)
+			}
+			mock.ClearExpect()
+		})
+	}
+}
+
+func TestRedisCache_GetValueNotFound(t *testing.T) {
+	pipelineId := uuid.New()
+	subKey := cache.RunOutput
+	client, mock := redismock.NewClientMock()
+	marshSubKey, _ := json.Marshal(subKey)
+	mock.ExpectHGet(pipelineId.String(), string(marshSubKey)).RedisNil()
+
+	rc := &Cache{client}
+	if _, err := rc.GetValue(context.TODO(), pipelineId, subKey); !errors.Is(err, cache.ErrNotFound) {
+		t.Errorf("GetValue() error = %v, want %v", err, cache.ErrNotFound)
+	}
+}
+
+func TestRedisCache_GetValues(t *testing.T) {
+	pipelineId := uuid.New()
+	status := pb.Status_STATUS_EXECUTING
+	output := "MOCK_OUTPUT"
+	marshStatusSubKey, _ := json.Marshal(cache.Status)
+	marshOutputSubKey, _ := json.Marshal(cache.RunOutput)
+	marshStatus, _ := json.Marshal(status)
+	marshOutput, _ := json.Marshal(output)
+	client, mock := redismock.NewClientMock()
+
+	tests := []struct {
+		name    string
+		mocks   func()
+		want    map[cache.SubKey]interface{}
+		wantErr bool
+	}{
+		{
+			name: "error during HMGet operation",
+			mocks: func() {
+				mock.ExpectHMGet(pipelineId.String(), string(marshStatusSubKey), string(marshOutputSubKey)).SetErr(fmt.Errorf("MOCK_ERROR"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "values don't exist",
+			mocks: func() {
+				mock.ExpectHMGet(pipelineId.String(), string(marshStatusSubKey), string(marshOutputSubKey)).SetVal([]interface{}{nil, nil})
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "part of values exists",
+			mocks: func() {
+				mock.ExpectHMGet(pipelineId.String(), string(marshStatusSubKey), string(marshOutputSubKey)).SetVal([]interface{}{string(marshStatus), nil})
+			},
+			want:    map[cache.SubKey]interface{}{cache.Status: status},
+			wantErr: false,
+		},
+		{
+			name: "all success",
+			mocks: func() {
+				mock.ExpectHMGet(pipelineId.String(), string(marshStatusSubKey), string(marshOutputSubKey)).SetVal([]interface{}{string(marshStatus), string(marshOutput)})
+			},
+			want:    map[cache.SubKey]interface{}{cache.Status: status, cache.RunOutput: output},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			rc := &Cache{client}
+			got, err := rc.GetValues(context.TODO(), pipelineId, []cache.SubKey{cache.Status, cache.RunOutput})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetValues() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValues() got = %v, want %v", got, tt.want)
+			}
+			mock.ClearExpect()
+		})
+	}
+}
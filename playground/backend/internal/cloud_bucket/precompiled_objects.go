@@ -40,6 +40,7 @@ const (
 	goExtension      = "go"
 	pyExtension      = "py"
 	scioExtension    = "scala"
+	kotlinExtension  = "kt"
 	separatorsNumber = 2
 )
 
@@ -248,6 +249,8 @@ func getFileExtensionBySdk(precompiledObjectPath string) (string, error) {
 		extension = goExtension
 	case pb.Sdk_SDK_SCIO.String():
 		extension = scioExtension
+	case pb.Sdk_SDK_KOTLIN.String():
+		extension = kotlinExtension
 	default:
 		return "", fmt.Errorf("")
 	}
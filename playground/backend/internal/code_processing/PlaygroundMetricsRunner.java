@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.nio.file.Paths;
+import java.util.ArrayList;
+import java.util.List;
+import org.apache.beam.runners.direct.DirectRunner;
+import org.apache.beam.sdk.Pipeline;
+import org.apache.beam.sdk.PipelineResult;
+import org.apache.beam.sdk.PipelineRunner;
+import org.apache.beam.sdk.metrics.DistributionResult;
+import org.apache.beam.sdk.metrics.GaugeResult;
+import org.apache.beam.sdk.metrics.MetricQueryResults;
+import org.apache.beam.sdk.metrics.MetricResult;
+import org.apache.beam.sdk.metrics.MetricsFilter;
+import org.apache.beam.sdk.options.PipelineOptions;
+
+/**
+ * Runner of the Playground backend which runs the pipeline by the DirectRunner and saves the
+ * metrics of the finished pipeline.
+ *
+ * <p>The metrics are saved as a JSON array to the pipeline_metrics.json file in the folder of the
+ * compiled class of the runner, so the backend reads them after the run. The pipeline isn't failed
+ * if the metrics couldn't be saved.
+ */
+public class PlaygroundMetricsRunner extends PipelineRunner<PipelineResult> {
+
+  private static final String METRICS_FILE_NAME = "pipeline_metrics.json";
+
+  private final DirectRunner directRunner;
+
+  private PlaygroundMetricsRunner(DirectRunner directRunner) {
+    this.directRunner = directRunner;
+  }
+
+  public static PlaygroundMetricsRunner fromOptions(PipelineOptions options) {
+    return new PlaygroundMetricsRunner(DirectRunner.fromOptions(options));
+  }
+
+  @Override
+  public PipelineResult run(Pipeline pipeline) {
+    PipelineResult result = directRunner.run(pipeline);
+    if (result.getState().isTerminal()) {
+      try {
+        saveMetrics(result.metrics().queryMetrics(MetricsFilter.builder().build()));
+      } catch (Exception e) {
+        System.err.println("Playground: metrics of the pipeline aren't saved: " + e);
+      }
+    }
+    return result;
+  }
+
+  private static void saveMetrics(MetricQueryResults results) throws Exception {
+    List<String> metrics = new ArrayList<>();
+    for (MetricResult<Long> counter : results.getCounters()) {
+      metrics.add(metric(counter, "counter") + ",\"value\":" + value(counter) + "}");
+    }
+    for (MetricResult<DistributionResult> distribution : results.getDistributions()) {
+      DistributionResult value = value(distribution);
+      metrics.add(
+          metric(distribution, "distribution")
+              + ",\"sum\":" + value.getSum()
+              + ",\"count\":" + value.getCount()
+              + ",\"min\":" + value.getMin()
+              + ",\"max\":" + value.getMax()
+              + "}");
+    }
+    for (MetricResult<GaugeResult> gauge : results.getGauges()) {
+      metrics.add(metric(gauge, "gauge") + ",\"value\":" + value(gauge).getValue() + "}");
+    }
+    Path metricsFile =
+        Paths.get(
+                PlaygroundMetricsRunner.class
+                    .getProtectionDomain()
+                    .getCodeSource()
+                    .getLocation()
+                    .toURI())
+            .resolve(METRICS_FILE_NAME);
+    Files.write(
+        metricsFile, ("[" + String.join(",", metrics) + "]").getBytes(StandardCharsets.UTF_8));
+  }
+
+  /** Returns the beginning of the JSON object of the metric with its namespace, name and type. */
+  private static String metric(MetricResult<?> result, String type) {
+    return "{\"namespace\":"
+        + quote(result.getName().getNamespace())
+        + ",\"name\":"
+        + quote(result.getName().getName())
+        + ",\"type\":"
+        + quote(type);
+  }
+
+  /** Returns the committed value of the metric or the attempted one if the committed isn't supported. */
+  private static <T> T value(MetricResult<T> result) {
+    try {
+      return result.getCommitted();
+    } catch (UnsupportedOperationException e) {
+      return result.getAttempted();
+    }
+  }
+
+  /** Returns the value as a JSON string. */
+  private static String quote(String value) {
+    StringBuilder quoted = new StringBuilder("\"");
+    for (char c : value.toCharArray()) {
+      if (c == '"' || c == '\\') {
+        quoted.append('\\').append(c);
+      } else if (c < 0x20) {
+        quoted.append(String.format("\\u%04x", (int) c));
+      } else {
+        quoted.append(c);
+      }
+    }
+    return quoted.append('"').toString();
+  }
+}
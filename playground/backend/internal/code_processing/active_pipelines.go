@@ -0,0 +1,70 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// ActivePipeline describes the pipeline which code processing isn't finished yet
+type ActivePipeline struct {
+	PipelineId uuid.UUID
+	Sdk        pb.Sdk
+	Status     pb.Status
+	StartTime  time.Time
+}
+
+// ActivePipelines returns the pipelines which code processing isn't finished yet ordered by the start time.
+// The pipelines are listed by the index of cacheService, so it should implement cache.ActivePipelinesLister,
+//	otherwise returns an error which wraps cache.ErrNotSupported.
+// Pipelines which are finished or removed from the cache after they are listed by the index aren't returned.
+func ActivePipelines(ctx context.Context, cacheService cache.Cache) ([]ActivePipeline, error) {
+	lister, ok := cacheService.(cache.ActivePipelinesLister)
+	if !ok {
+		return nil, fmt.Errorf("active pipelines: %w", cache.ErrNotSupported)
+	}
+	pipelineIds, err := lister.ActivePipelines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pipelines := make([]ActivePipeline, 0, len(pipelineIds))
+	for _, pipelineId := range pipelineIds {
+		values, err := cacheService.GetValues(ctx, pipelineId, []cache.SubKey{cache.Status, cache.Sdk, cache.StartTime})
+		if errors.Is(err, cache.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		status, ok := values[cache.Status].(pb.Status)
+		if !ok || IsFinalStatus(status) {
+			continue
+		}
+		sdk, _ := values[cache.Sdk].(pb.Sdk)
+		startTime, _ := values[cache.StartTime].(time.Time)
+		pipelines = append(pipelines, ActivePipeline{PipelineId: pipelineId, Sdk: sdk, Status: status, StartTime: startTime})
+	}
+	sort.Slice(pipelines, func(i, j int) bool {
+		return pipelines[i].StartTime.Before(pipelines[j].StartTime)
+	})
+	return pipelines, nil
+}
@@ -0,0 +1,103 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/cache/local"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// notListingCache is cache.Cache which doesn't keep the index of the active pipelines
+type notListingCache struct {
+	cache.Cache
+}
+
+func TestActivePipelines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	startTime := time.Date(2022, time.January, 1, 10, 0, 0, 0, time.UTC)
+	isCompleted := func(status interface{}) bool {
+		value, ok := status.(pb.Status)
+		return ok && IsFinalStatus(value)
+	}
+	pipelines := []struct {
+		sdk    pb.Sdk
+		status pb.Status
+		active bool
+	}{
+		{sdk: pb.Sdk_SDK_GO, status: pb.Status_STATUS_EXECUTING, active: true},
+		{sdk: pb.Sdk_SDK_JAVA, status: pb.Status_STATUS_COMPILING, active: true},
+		{sdk: pb.Sdk_SDK_PYTHON, status: pb.Status_STATUS_VALIDATING, active: true},
+		{sdk: pb.Sdk_SDK_GO, status: pb.Status_STATUS_FINISHED, active: false},
+		{sdk: pb.Sdk_SDK_JAVA, status: pb.Status_STATUS_COMPILE_ERROR, active: false},
+		{sdk: pb.Sdk_SDK_SCIO, status: pb.Status_STATUS_CANCELED, active: false},
+	}
+
+	tests := []struct {
+		name         string
+		cacheService cache.Cache
+	}{
+		{
+			// Test case with calling ActivePipelines method with the cache which keeps only active pipelines in the index.
+			// As a result, want to receive only the active pipelines ordered by the start time.
+			name:         "index of active pipelines",
+			cacheService: local.NewWithLimits(ctx, 0, 0, isCompleted),
+		},
+		{
+			// Test case with calling ActivePipelines method with the cache which keeps all pipelines in the index.
+			// As a result, want to receive only the active pipelines ordered by the start time.
+			name:         "index of all pipelines",
+			cacheService: local.New(ctx),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want []ActivePipeline
+			// pipelines are started in the reverse order, so the result should be sorted by the start time
+			for i := len(pipelines) - 1; i >= 0; i-- {
+				pipelineId := uuid.New()
+				pipelineStartTime := startTime.Add(time.Duration(i) * time.Minute)
+				_ = tt.cacheService.SetValue(ctx, pipelineId, cache.Sdk, pipelines[i].sdk)
+				_ = tt.cacheService.SetValue(ctx, pipelineId, cache.StartTime, pipelineStartTime)
+				_ = tt.cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_VALIDATING)
+				_ = tt.cacheService.SetValue(ctx, pipelineId, cache.Status, pipelines[i].status)
+				if pipelines[i].active {
+					want = append([]ActivePipeline{{PipelineId: pipelineId, Sdk: pipelines[i].sdk, Status: pipelines[i].status, StartTime: pipelineStartTime}}, want...)
+				}
+			}
+
+			got, err := ActivePipelines(ctx, tt.cacheService)
+			if err != nil {
+				t.Fatalf("ActivePipelines() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ActivePipelines() got = %v, want %v", got, want)
+			}
+		})
+	}
+
+	// Test case with calling ActivePipelines method with the cache which doesn't keep the index of the pipelines.
+	// As a result, want to receive cache.ErrNotSupported.
+	if _, err := ActivePipelines(ctx, notListingCache{}); !errors.Is(err, cache.ErrNotSupported) {
+		t.Errorf("ActivePipelines() error = %v, want %v", err, cache.ErrNotSupported)
+	}
+}
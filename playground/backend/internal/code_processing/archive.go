@@ -0,0 +1,136 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/archive"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/errors"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ArchiveResult saves the status, run output, compile output and graph of the code processing from cache
+//	into archiveStorage by pipelineId, so the result could be received after the pipeline is removed from the cache.
+// Only the code processing which is finished successfully (playground.Status_STATUS_FINISHED) is archived,
+//	the result of other pipelines isn't saved.
+func ArchiveResult(ctx context.Context, cacheService cache.Cache, archiveStorage archive.Storage, pipelineId uuid.UUID) error {
+	result, err := resultFromCache(ctx, cacheService, pipelineId)
+	if err != nil {
+		return err
+	}
+	if result.Status != pb.Status_STATUS_FINISHED {
+		return nil
+	}
+	result.ArchivedAt = time.Now()
+	return archive.Archive(ctx, archiveStorage, pipelineId.String(), result)
+}
+
+// resultSubKeys are the subKeys which are read from cache by GetResult.
+var resultSubKeys = []cache.SubKey{
+	cache.Status,
+	cache.RunOutput,
+	cache.RunError,
+	cache.CompileOutput,
+	cache.Graph,
+	cache.RunExitCode,
+	cache.CompileTime,
+	cache.RunTime,
+}
+
+// GetResult returns the result of the finished code processing by pipelineId from cache: the status, run output, run error,
+//	compile output, graph, exit code and durations of the compile and run steps in one call.
+// Values which haven't been saved for the code processing (i.e. the compile output of the Python code) are omitted.
+// In case the code processing isn't finished yet - returns an errors.InvalidArgumentError.
+// In case the pipeline doesn't exist in cache - returns an errors.NotFoundError.
+// In case the result couldn't be read from cache (i.e. it is saved in the incompatible format) - returns an errors.InternalError.
+func GetResult(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) (*archive.Result, error) {
+	return getResult(ctx, cacheService, nil, pipelineId, "GetResult")
+}
+
+// GetArchivedResult returns the result of the finished code processing by pipelineId as described for GetResult.
+// The result is read from cache while the pipeline is kept there and from archiveStorage after it is removed from the cache.
+// In case the pipeline is neither in cache nor in archiveStorage (or archiveStorage is nil) - returns an errors.NotFoundError.
+// In case the result couldn't be read from archiveStorage - returns an errors.InternalError.
+func GetArchivedResult(ctx context.Context, cacheService cache.Cache, archiveStorage archive.Storage, pipelineId uuid.UUID, errorTitle string) (*archive.Result, error) {
+	return getResult(ctx, cacheService, archiveStorage, pipelineId, errorTitle)
+}
+
+// getResult returns the result of the finished code processing by pipelineId from cache
+//	or from archiveStorage if it isn't nil and the pipeline isn't in cache (see GetArchivedResult).
+func getResult(ctx context.Context, cacheService cache.Cache, archiveStorage archive.Storage, pipelineId uuid.UUID, errorTitle string) (*archive.Result, error) {
+	result, err := resultFromCache(ctx, cacheService, pipelineId)
+	switch {
+	case err == nil:
+		if !IsFinalStatus(result.Status) {
+			return nil, errors.InvalidArgumentError(errorTitle, "Code processing of %s isn't finished yet", pipelineId)
+		}
+		return result, nil
+	case stderrors.Is(err, cache.ErrIncompatibleVersion):
+		logger.Errorf("%s: getResult(): cache.GetValues: error: %s", pipelineId, err.Error())
+		return nil, errors.InternalError(errorTitle, "Result of %s from cache has %s", pipelineId, cache.ErrIncompatibleVersion)
+	case !stderrors.Is(err, cache.ErrNotFound):
+		logger.Errorf("%s: getResult(): cache.GetValues: error: %s", pipelineId, err.Error())
+		return nil, errors.InternalError(errorTitle, "Error during reading the result from cache")
+	}
+	if archiveStorage == nil {
+		return nil, errors.NotFoundError(errorTitle, "Result of %s isn't found", pipelineId)
+	}
+	result, err = archive.GetArchivedResult(ctx, archiveStorage, pipelineId.String())
+	if err == archive.ErrNotFound {
+		return nil, errors.NotFoundError(errorTitle, "Result of %s isn't found", pipelineId)
+	}
+	if err != nil {
+		logger.Errorf("%s: getResult(): archive.GetArchivedResult: error: %s", pipelineId, err.Error())
+		return nil, errors.InternalError(errorTitle, "Error during reading the archived result")
+	}
+	return result, nil
+}
+
+// resultFromCache reads the status, outputs, exit code and durations of the code processing from cache in one read.
+// Values which haven't been saved into cache (i.e. the graph of the pipeline without the graph output) are empty.
+// In case the status of the pipeline isn't in cache - returns an error which wraps cache.ErrNotFound.
+// In case the values couldn't be read from cache or the status couldn't be converted - returns an error.
+func resultFromCache(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) (*archive.Result, error) {
+	values, err := cacheService.GetValues(ctx, pipelineId, resultSubKeys)
+	if err != nil {
+		return nil, err
+	}
+	value, found := values[cache.Status]
+	if !found {
+		return nil, fmt.Errorf("status of %s %w", pipelineId, cache.ErrNotFound)
+	}
+	status, ok := value.(pb.Status)
+	if !ok {
+		return nil, fmt.Errorf("status of %s couldn't be converted: %v", pipelineId, value)
+	}
+	result := &archive.Result{Status: status}
+	result.RunOutput, _ = values[cache.RunOutput].(string)
+	result.RunError, _ = values[cache.RunError].(string)
+	result.CompileOutput, _ = values[cache.CompileOutput].(string)
+	result.Graph, _ = values[cache.Graph].(string)
+	if exitCode, ok := values[cache.RunExitCode].(int); ok {
+		result.ExitCode = &exitCode
+	}
+	result.CompileTime, _ = values[cache.CompileTime].(time.Duration)
+	result.RunTime, _ = values[cache.RunTime].(time.Duration)
+	return result, nil
+}
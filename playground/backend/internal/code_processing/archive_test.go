@@ -0,0 +1,297 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/archive"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/cache/encrypted"
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestArchiveResult(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(os.TempDir(), "code_processing_archive_test")
+	defer os.RemoveAll(dir)
+	archiveStorage, err := archive.NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("NewFileStorage() error = %v", err)
+	}
+	finishedId := uuid.New()
+	failedId := uuid.New()
+	_ = cacheService.SetValue(ctx, finishedId, cache.Status, pb.Status_STATUS_FINISHED)
+	_ = cacheService.SetValue(ctx, finishedId, cache.RunOutput, "MOCK_RUN_OUTPUT")
+	_ = cacheService.SetValue(ctx, failedId, cache.Status, pb.Status_STATUS_RUN_ERROR)
+
+	tests := []struct {
+		name         string
+		pipelineId   uuid.UUID
+		wantErr      bool
+		wantArchived bool
+	}{
+		{
+			// Test case with archiving the result of the pipeline which isn't in the cache.
+			// As a result, want to receive an error.
+			name:         "pipeline doesn't exist",
+			pipelineId:   uuid.New(),
+			wantErr:      true,
+			wantArchived: false,
+		},
+		{
+			// Test case with archiving the result of the failed pipeline.
+			// As a result, want the result not to be archived.
+			name:         "pipeline is failed",
+			pipelineId:   failedId,
+			wantErr:      false,
+			wantArchived: false,
+		},
+		{
+			// Test case with archiving the result of the finished pipeline.
+			// As a result, want the result to be archived.
+			name:         "pipeline is finished",
+			pipelineId:   finishedId,
+			wantErr:      false,
+			wantArchived: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ArchiveResult(ctx, cacheService, archiveStorage, tt.pipelineId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ArchiveResult() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			result, err := archive.GetArchivedResult(ctx, archiveStorage, tt.pipelineId.String())
+			if (err == nil) != tt.wantArchived {
+				t.Errorf("ArchiveResult() archived = %v, want %v", err == nil, tt.wantArchived)
+				return
+			}
+			if tt.wantArchived && result.RunOutput != "MOCK_RUN_OUTPUT" {
+				t.Errorf("ArchiveResult() run output = %v, want %v", result.RunOutput, "MOCK_RUN_OUTPUT")
+			}
+		})
+	}
+}
+
+func TestGetResult(t *testing.T) {
+	ctx := context.Background()
+	executingId := uuid.New()
+	cachedId := uuid.New()
+	failedId := uuid.New()
+	compileFailedId := uuid.New()
+	_ = cacheService.SetValue(ctx, executingId, cache.Status, pb.Status_STATUS_EXECUTING)
+	_ = cacheService.SetValue(ctx, cachedId, cache.Status, pb.Status_STATUS_FINISHED)
+	_ = cacheService.SetValue(ctx, cachedId, cache.RunOutput, "MOCK_CACHED_OUTPUT")
+	_ = cacheService.SetValue(ctx, cachedId, cache.CompileOutput, "MOCK_COMPILE_OUTPUT")
+	_ = cacheService.SetValue(ctx, cachedId, cache.RunExitCode, 0)
+	_ = cacheService.SetValue(ctx, cachedId, cache.CompileTime, 2*time.Second)
+	_ = cacheService.SetValue(ctx, cachedId, cache.RunTime, 3*time.Second)
+	_ = cacheService.SetValue(ctx, failedId, cache.Status, pb.Status_STATUS_RUN_ERROR)
+	_ = cacheService.SetValue(ctx, failedId, cache.RunOutput, "MOCK_RUN_OUTPUT")
+	_ = cacheService.SetValue(ctx, failedId, cache.RunError, "MOCK_RUN_ERROR")
+	_ = cacheService.SetValue(ctx, failedId, cache.RunExitCode, 1)
+	_ = cacheService.SetValue(ctx, failedId, cache.RunTime, time.Second)
+	_ = cacheService.SetValue(ctx, compileFailedId, cache.Status, pb.Status_STATUS_COMPILE_ERROR)
+	_ = cacheService.SetValue(ctx, compileFailedId, cache.CompileOutput, "MOCK_COMPILE_ERROR")
+	_ = cacheService.SetValue(ctx, compileFailedId, cache.CompileTime, time.Second)
+	successExitCode := 0
+	failedExitCode := 1
+
+	tests := []struct {
+		name       string
+		pipelineId uuid.UUID
+		want       *archive.Result
+		wantCode   codes.Code
+	}{
+		{
+			// Test case with getting the result of the pipeline which is still executing.
+			// As a result, want to receive an error.
+			name:       "pipeline isn't finished",
+			pipelineId: executingId,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			// Test case with getting the result of the finished pipeline.
+			// As a result, want to receive the outputs, exit code and durations from the cache.
+			name:       "pipeline is finished",
+			pipelineId: cachedId,
+			want: &archive.Result{
+				Status:        pb.Status_STATUS_FINISHED,
+				RunOutput:     "MOCK_CACHED_OUTPUT",
+				CompileOutput: "MOCK_COMPILE_OUTPUT",
+				ExitCode:      &successExitCode,
+				CompileTime:   2 * time.Second,
+				RunTime:       3 * time.Second,
+			},
+			wantCode: codes.OK,
+		},
+		{
+			// Test case with getting the result of the pipeline which is failed during the run step without the compile step (i.e. Python code).
+			// As a result, want to receive the run error and exit code without the compile output and compile duration.
+			name:       "pipeline is failed",
+			pipelineId: failedId,
+			want: &archive.Result{
+				Status:    pb.Status_STATUS_RUN_ERROR,
+				RunOutput: "MOCK_RUN_OUTPUT",
+				RunError:  "MOCK_RUN_ERROR",
+				ExitCode:  &failedExitCode,
+				RunTime:   time.Second,
+			},
+			wantCode: codes.OK,
+		},
+		{
+			// Test case with getting the result of the pipeline which is failed during the compile step.
+			// As a result, want to receive the compile output without the exit code and run duration.
+			name:       "pipeline is failed to compile",
+			pipelineId: compileFailedId,
+			want: &archive.Result{
+				Status:        pb.Status_STATUS_COMPILE_ERROR,
+				CompileOutput: "MOCK_COMPILE_ERROR",
+				CompileTime:   time.Second,
+			},
+			wantCode: codes.OK,
+		},
+		{
+			// Test case with getting the result of the pipeline which doesn't exist.
+			// As a result, want to receive an error.
+			name:       "pipeline doesn't exist",
+			pipelineId: uuid.New(),
+			wantCode:   codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetResult(ctx, cacheService, tt.pipelineId)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("GetResult() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetResult() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetArchivedResult(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(os.TempDir(), "code_processing_result_test")
+	defer os.RemoveAll(dir)
+	archiveStorage, err := archive.NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("NewFileStorage() error = %v", err)
+	}
+	// the output of the pipeline is encrypted with another key, so it couldn't be read from the cache
+	anotherKeyCache, err := encrypted.New(cacheService, []byte("fedcba9876543210"))
+	if err != nil {
+		t.Fatalf("encrypted.New() error = %v", err)
+	}
+	encryptedCache, err := encrypted.New(cacheService, []byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("encrypted.New() error = %v", err)
+	}
+	cachedId := uuid.New()
+	archivedId := uuid.New()
+	undecryptableId := uuid.New()
+	_ = cacheService.SetValue(ctx, cachedId, cache.Status, pb.Status_STATUS_FINISHED)
+	_ = cacheService.SetValue(ctx, cachedId, cache.RunOutput, "MOCK_CACHED_OUTPUT")
+	_ = archive.Archive(ctx, archiveStorage, cachedId.String(), &archive.Result{Status: pb.Status_STATUS_FINISHED, RunOutput: "MOCK_ARCHIVED_OUTPUT"})
+	_ = archive.Archive(ctx, archiveStorage, archivedId.String(), &archive.Result{Status: pb.Status_STATUS_FINISHED, RunOutput: "MOCK_ARCHIVED_OUTPUT"})
+	_ = anotherKeyCache.SetValue(ctx, undecryptableId, cache.Status, pb.Status_STATUS_FINISHED)
+	_ = anotherKeyCache.SetValue(ctx, undecryptableId, cache.RunOutput, "MOCK_CACHED_OUTPUT")
+	_ = archive.Archive(ctx, archiveStorage, undecryptableId.String(), &archive.Result{Status: pb.Status_STATUS_FINISHED, RunOutput: "MOCK_ARCHIVED_OUTPUT"})
+
+	tests := []struct {
+		name           string
+		cacheService   cache.Cache
+		archiveStorage archive.Storage
+		pipelineId     uuid.UUID
+		want           *archive.Result
+		wantCode       codes.Code
+	}{
+		{
+			// Test case with getting the result of the finished pipeline which is both in the cache and in the archive.
+			// As a result, want to receive the result from the cache.
+			name:           "pipeline is in the cache",
+			cacheService:   cacheService,
+			archiveStorage: archiveStorage,
+			pipelineId:     cachedId,
+			want:           &archive.Result{Status: pb.Status_STATUS_FINISHED, RunOutput: "MOCK_CACHED_OUTPUT"},
+			wantCode:       codes.OK,
+		},
+		{
+			// Test case with getting the result of the pipeline which is only in the archive.
+			// As a result, want to receive the result from the archive.
+			name:           "pipeline is in the archive",
+			cacheService:   cacheService,
+			archiveStorage: archiveStorage,
+			pipelineId:     archivedId,
+			want:           &archive.Result{Status: pb.Status_STATUS_FINISHED, RunOutput: "MOCK_ARCHIVED_OUTPUT"},
+			wantCode:       codes.OK,
+		},
+		{
+			// Test case with getting the result of the pipeline which is only in the archive while the archive isn't configured.
+			// As a result, want to receive an error.
+			name:           "archive isn't configured",
+			cacheService:   cacheService,
+			archiveStorage: nil,
+			pipelineId:     archivedId,
+			wantCode:       codes.NotFound,
+		},
+		{
+			// Test case with getting the result of the pipeline which values in the cache couldn't be decrypted.
+			// As a result, want to receive an error instead of the result from the archive.
+			name:           "result in the cache couldn't be read",
+			cacheService:   encryptedCache,
+			archiveStorage: archiveStorage,
+			pipelineId:     undecryptableId,
+			wantCode:       codes.Internal,
+		},
+		{
+			// Test case with getting the result of the pipeline which doesn't exist.
+			// As a result, want to receive an error.
+			name:           "pipeline doesn't exist",
+			cacheService:   cacheService,
+			archiveStorage: archiveStorage,
+			pipelineId:     uuid.New(),
+			wantCode:       codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetArchivedResult(ctx, tt.cacheService, tt.archiveStorage, tt.pipelineId, "")
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("GetArchivedResult() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			if err == nil {
+				got.ArchivedAt = time.Time{}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GetArchivedResult() got = %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+}
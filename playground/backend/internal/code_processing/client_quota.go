@@ -0,0 +1,78 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// clientQuotasNamespace is the namespace of the ids which the counters of the active pipelines of the clients are kept in the cache by
+var clientQuotasNamespace = uuid.MustParse("2b1f5c0e-7d4a-4c8e-9a63-5e0d8f1b3c27")
+
+// AcquireClientSlot counts the code processing of the client as active if the client has less than maxPipelines
+//	active code processing, so the same client couldn't take all workers of the server.
+// Returns false if the client already has maxPipelines active code processing. The counter isn't changed in this case.
+// The code processing isn't limited if maxPipelines is 0 or the client couldn't be identified (clientId is empty).
+// The counter is kept in the cache for ttl after the last acquire, so it is reset even if the slots aren't released
+//	(i.e. the server is stopped during the code processing).
+// The acquired slot should be released by ReleaseClientSlot when the code processing reaches the final status
+//	or isn't started.
+func AcquireClientSlot(ctx context.Context, cacheService cache.Cache, clientId string, maxPipelines int, ttl time.Duration) (bool, error) {
+	if maxPipelines <= 0 || clientId == "" {
+		return true, nil
+	}
+	keyId := clientQuotaId(clientId)
+	active, err := cacheService.IncrementValue(ctx, keyId, cache.ClientActivePipelines, 1)
+	if err != nil {
+		return false, err
+	}
+	if active > maxPipelines {
+		if _, err = cacheService.IncrementValue(ctx, keyId, cache.ClientActivePipelines, -1); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+	if err = cacheService.SetExpTime(ctx, keyId, ttl); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ReleaseClientSlot releases the slot of the client which is acquired by AcquireClientSlot,
+//	so the next code processing of the client could be started.
+func ReleaseClientSlot(ctx context.Context, cacheService cache.Cache, clientId string, maxPipelines int) error {
+	if maxPipelines <= 0 || clientId == "" {
+		return nil
+	}
+	keyId := clientQuotaId(clientId)
+	active, err := cacheService.IncrementValue(ctx, keyId, cache.ClientActivePipelines, -1)
+	if err != nil {
+		return err
+	}
+	// the counter is negative if it has expired during the code processing
+	if active < 0 {
+		_, err = cacheService.IncrementValue(ctx, keyId, cache.ClientActivePipelines, -active)
+	}
+	return err
+}
+
+// clientQuotaId returns the id which the counter of the active pipelines of the client is kept in the cache by
+func clientQuotaId(clientId string) uuid.UUID {
+	return uuid.NewSHA1(clientQuotasNamespace, []byte(clientId))
+}
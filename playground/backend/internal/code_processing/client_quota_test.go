@@ -0,0 +1,79 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	"beam.apache.org/playground/backend/internal/cache/local"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAcquireClientSlot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cacheService := local.New(ctx)
+	ttl := time.Minute
+	maxPipelines := 2
+
+	acquire := func(clientId string, maxPipelines int) bool {
+		acquired, err := AcquireClientSlot(ctx, cacheService, clientId, maxPipelines, ttl)
+		if err != nil {
+			t.Fatalf("AcquireClientSlot() error = %v", err)
+		}
+		return acquired
+	}
+
+	// Test case with calling AcquireClientSlot method more times than the quota of the client allows.
+	// As a result, want to receive that only maxPipelines slots are acquired.
+	for i := 0; i < maxPipelines; i++ {
+		if !acquire("10.0.0.1", maxPipelines) {
+			t.Errorf("AcquireClientSlot() = false for slot %d, want true", i)
+		}
+	}
+	if acquire("10.0.0.1", maxPipelines) {
+		t.Errorf("AcquireClientSlot() = true over the quota, want false")
+	}
+
+	// Test case with calling AcquireClientSlot method by another client while the first client has used up its quota.
+	// As a result, want to receive that the slot is acquired.
+	if !acquire("10.0.0.2", maxPipelines) {
+		t.Errorf("AcquireClientSlot() = false for another client, want true")
+	}
+
+	// Test case with calling AcquireClientSlot method after the slot of the client is released.
+	// As a result, want to receive that the released slot is acquired again, but not more.
+	if err := ReleaseClientSlot(ctx, cacheService, "10.0.0.1", maxPipelines); err != nil {
+		t.Fatalf("ReleaseClientSlot() error = %v", err)
+	}
+	if !acquire("10.0.0.1", maxPipelines) {
+		t.Errorf("AcquireClientSlot() = false after the slot is released, want true")
+	}
+	if acquire("10.0.0.1", maxPipelines) {
+		t.Errorf("AcquireClientSlot() = true over the quota after the slot is released, want false")
+	}
+
+	// Test case with calling AcquireClientSlot method without the quota or for the client which couldn't be identified.
+	// As a result, want to receive that the slots are always acquired.
+	for i := 0; i < maxPipelines+1; i++ {
+		if !acquire("10.0.0.3", 0) {
+			t.Errorf("AcquireClientSlot() = false without the quota, want true")
+		}
+		if !acquire("", maxPipelines) {
+			t.Errorf("AcquireClientSlot() = false for the unidentified client, want true")
+		}
+	}
+}
@@ -23,283 +23,1900 @@ import (
 	"beam.apache.org/playground/backend/internal/executors"
 	"beam.apache.org/playground/backend/internal/fs_tool"
 	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/metrics"
 	"beam.apache.org/playground/backend/internal/setup_tools/builder"
 	"beam.apache.org/playground/backend/internal/streaming"
 	"beam.apache.org/playground/backend/internal/utils"
 	"beam.apache.org/playground/backend/internal/validators"
+	"bufio"
 	"bytes"
 	"context"
+	stderrors "errors"
 	"fmt"
 	"github.com/google/uuid"
 	"io"
+	"io/fs"
 	"os"
 	"os/exec"
-	"reflect"
+	"path/filepath"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 )
 
 const (
-	pauseDuration = 500 * time.Millisecond
+	pauseDuration              = 500 * time.Millisecond
+	outOfMemoryOutput          = "out of memory"
+	cpuTimeLimitExceededOutput = "cpu time limit exceeded"
+	diskQuotaExceededOutput    = "disk quota exceeded"
+	compileTimedOutOutput      = "compilation timed out after %s"
+	dotGraphKeyword            = "digraph"
+	combinedLogsTimeFormat     = "2006-01-02T15:04:05.000Z07:00"
+	// oomKilledExitCode is the exit code of the process which is killed by SIGKILL, i.e. by the OOM killer of the kernel
+	oomKilledExitCode = 128 + int(syscall.SIGKILL)
+	// cpuTimeAccuracy is an error of the CPU time reported for the process, which could be a bit less than the CPU time limit
+	cpuTimeAccuracy = 100 * time.Millisecond
 )
 
+// pipelineIdToken is replaced with the pipeline ID in the pipeline options of the code (i.e. "--output=/tmp/${PLAYGROUND_PIPELINE_ID}/out")
+const pipelineIdToken = "${" + executors.PipelineIdEnvKey + "}"
+
+// redactedValue replaces values of the sensitive environment variables in the command line of the run step
+const redactedValue = "<redacted>"
+
+// sensitiveEnvKeyParts are parts of the names of the environment variables which could contain secrets
+var sensitiveEnvKeyParts = []string{"SECRET", "TOKEN", "PASSWORD", "PASSWD", "CREDENTIAL", "KEY", "AUTH"}
+
+// Tags of the lines of the combined log which show the pipe the line was read from
+const (
+	stdOutTag = "[out]"
+	stdErrTag = "[err]"
+)
+
+// Phases of the code processing which are added to the log messages
+const (
+	fetchSourcePhase = "FetchSourceCode"
+	setupPhase       = "Setup"
+	validatePhase    = "Validate"
+	preparePhase     = "Prepare"
+	queuePhase       = "Queue"
+	compilePhase     = "Compile"
+	runPhase         = "Run"
+	graphPhase       = "Graph"
+	finishPhase      = "Finish"
+)
+
+var (
+	// errContextDone is returned if the code processing is interrupted because the context is done
+	errContextDone = fmt.Errorf("context was done")
+
+	// errCanceled is returned if the code processing is interrupted because it was canceled
+	errCanceled = fmt.Errorf("code processing was canceled")
+)
+
+// transientFailureMarkers are the beginnings of the error output of the JVM launcher which couldn't start the JVM
+//	(i.e. because threads couldn't be created). They are printed before the code is run, so the code can't cause them.
+var transientFailureMarkers = []string{
+	"Error occurred during initialization of VM",
+	"Error: Could not create the Java Virtual Machine.",
+}
+
+// outOfMemoryMarkers are the messages which are printed to the stderr by the executed code in case it runs out of memory
+var outOfMemoryMarkers = []string{
+	"java.lang.OutOfMemoryError",                                    // java
+	"There is insufficient memory for the Java Runtime Environment", // jvm couldn't start
+	"fatal error: out of memory",                                    // go
+	"fatal error: runtime: out of memory",                           // go, the memory isn't allocated because of the memory limit
+	"MemoryError",                                                   // python
+}
+
 // Process validates, compiles and runs code by pipelineId.
+// If the main source file contains a link to the code instead of the code itself, downloads the code before validation.
 // During each operation updates status of execution and saves it into cache:
+// - While the files of the code are prepared (the code is downloaded by the link, the size of the source files
+//	and the version of the SDK are checked) saves playground.Status_STATUS_PREPARING, while the code is validated
+//	and adapted for compilation saves playground.Status_STATUS_VALIDATING, while it is compiled saves playground.Status_STATUS_COMPILING
+//	and while it is run saves playground.Status_STATUS_EXECUTING as cache.Status into cache, so the status polled
+//	during the code processing shows the current step (i.e. for a stepper of the client). SDKs without compilation
+//	(i.e. Python) go from playground.Status_STATUS_VALIDATING to playground.Status_STATUS_EXECUTING.
+// - In case of the code couldn't be downloaded by the link saves playground.Status_STATUS_VALIDATION_ERROR as cache.Status
+//	and the reason of the failure as cache.ValidationOutput into cache.
+// - In case of the total size of the source files exceeds the max source size saves playground.Status_STATUS_VALIDATION_ERROR
+//	as cache.Status and "source too large" error with the size of the source files as cache.ValidationOutput into cache.
+//	The size is checked before other validators, so oversized code isn't read by them.
+// - In case of the path of the output set by the pipeline options (i.e. "--output") is outside the folder of the pipeline
+//	(i.e. "/etc/passwd" or "../../counts") saves playground.Status_STATUS_VALIDATION_ERROR as cache.Status
+//	and the reason as cache.ValidationOutput into cache. Relative paths of the output are resolved against the folder of the pipeline.
+// - While the code is prepared the source file is rewritten by the preparators of the SDK,
+//	i.e. the bare Go code without the package clause is wrapped in the main function and the used standard packages are imported.
+// - In case of the worker pool has no free slot to compile and run the code saves playground.Status_STATUS_QUEUED as cache.Status
+//	into cache and waits until a slot is released. Timeout and cancellation of the code processing are respected while waiting.
 // - In case of processing works more that timeout duration saves playground.Status_STATUS_RUN_TIMEOUT as cache.Status into cache.
+// - In case of compile step works more that compile timeout of the SDK (the timeout of processing if it isn't set for the SDK)
+//	saves playground.Status_STATUS_COMPILE_TIMEOUT as cache.Status and "compilation timed out" error with compile logs
+//	as cache.CompileOutput into cache.
+// - In case of run step works more that run timeout of the SDK (the timeout of processing if it isn't set for the SDK)
+//	saves playground.Status_STATUS_RUN_TIMEOUT as cache.Status into cache.
 // - In case of code processing has been canceled saves playground.Status_STATUS_CANCELED as cache.Status into cache.
-// - In case of validation step is failed saves playground.Status_STATUS_VALIDATION_ERROR as cache.Status into cache.
-// - In case of compile step is failed saves playground.Status_STATUS_COMPILE_ERROR as cache.Status and compile logs as cache.CompileOutput into cache.
-// - In case of compile step is completed with no errors saves compile output as cache.CompileOutput into cache.
+//	If the compile or run command is running, it is stopped by SIGTERM, so the executed code could finish gracefully
+//	(i.e. JVM runs shutdown hooks), and killed by SIGKILL if it is still alive after the cancel grace period.
+//	The signals are sent to all processes of the compiler, so it is stopped even if it is started by a script.
+//	The output of the canceled compile step isn't saved into cache, only the compile errors streamed before the cancel are kept.
+//	The output printed by the code until it finishes is kept in cache. It is saved before the status,
+//	so the run output isn't changed after playground.Status_STATUS_CANCELED is received.
+//	The compile phase (validation, preparation, queue and compile steps) and the run phase are canceled independently
+//	(see CancelPipeline). If only the run phase is canceled while the code is compiled, the compile step is finished
+//	and playground.Status_STATUS_CANCELED is saved instead of running the code, so the compiled code could be run again.
+// - In case of ctx is canceled (i.e. the server is shutting down) kills the running command of the step
+//	and saves playground.Status_STATUS_CANCELED as cache.Status into cache.
+// - In case of validation step is failed saves playground.Status_STATUS_VALIDATION_ERROR as cache.Status
+//	and the reason of the failure (i.e. the name of the forbidden import, the matched unbounded output loop or malformed pipeline options) as cache.ValidationOutput into cache.
+// - In case of validation step is completed with no errors saves the warnings about the code which don't fail the code processing
+//	(i.e. the code doesn't construct a Beam pipeline) as cache.Warnings into cache. The list is empty if there are no warnings.
+// - In case of compile step is failed saves playground.Status_STATUS_COMPILE_ERROR as cache.Status, compile logs as cache.CompileOutput
+//	and compile errors parsed from compile logs as cache.CompileErrors into cache.
+// - In case of compile step is completed with no errors saves compile output (both stdout and stderr of the compiler)
+//	as cache.CompileOutput into cache.
+// - Warnings of the compiler (i.e. deprecation or unchecked operations) are parsed from compile logs and saved
+//	as cache.CompileWarnings into cache whether the compile step is failed or not, so they could be shown for the finished code.
+// - While the code is compiling the errors printed by the compiler are appended to cache.CompileOutputStream as soon as
+//	they are printed, so the client could show them by WatchCompileOutput before the compile step is finished.
+// - In case of compile or run step is failed because of a transient failure (the process couldn't be started
+//	or the JVM launcher couldn't start the JVM) runs the step again up to the pipeline start retries times
+//	with the backoff which is doubled after each retry. Failures of the code itself are never retried.
 // - In case of run step is failed saves playground.Status_STATUS_RUN_ERROR as cache.Status and run logs as cache.RunError into cache.
+// - In case of run step is failed because the code runs out of memory (i.e. the JVM throws OutOfMemoryError or the code
+//	is killed by the OOM killer) saves playground.Status_STATUS_RUN_ERROR as cache.Status, playground.ErrorCategory_ERROR_CATEGORY_OOM
+//	as cache.ErrorCategory and "out of memory" error with run logs as cache.RunError into cache.
+// - In case of run step is failed because the code uses up the CPU time limit saves playground.Status_STATUS_RUN_TIMEOUT as cache.Status
+//	and "cpu time limit exceeded" error with run logs as cache.RunError into cache.
 // - In case of run step is completed with no errors saves playground.Status_STATUS_FINISHED as cache.Status and run output as cache.RunOutput into cache.
-// At the end of this method deletes all created folders.
-func Process(ctx context.Context, cacheService cache.Cache, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, appEnv *environment.ApplicationEnvs, sdkEnv *environment.BeamEnvs, pipelineOptions string) {
+//	If the output normalization is enabled for the SDK, ANSI escape sequences are stripped from the run output
+//	and line endings are normalized to "\n" before it is saved into cache.
+// - In case of compile step is completed (with or without errors) saves its duration as cache.CompileTime into cache.
+//	SDKs without compilation (i.e. Python) don't have cache.CompileTime.
+// - In case of run step is completed (with or without errors) saves its duration as cache.RunTime into cache.
+// - Right before the run step is started saves its command line with the values of sensitive environment variables redacted
+//	as cache.RunCommand into cache.
+// - In case of run step is completed (with or without errors) saves stderr output of the run step as cache.RunLogs
+//	and exit code of the executed code as cache.RunExitCode into cache.
+//	If the combined logs are enabled for the SDK, also saves stdout and stderr output of the run step merged
+//	in the order they were printed as cache.CombinedLogs into cache.
+//	Before that, if the graph output is set for the SDK, reads graph of the pipeline which the code has saved while it was run
+//	and saves it as cache.Graph into cache.
+//	Graph step is best-effort, so its errors don't change the status of the code processing.
+// - If the metrics are collected for the SDK, the code which isn't a unit test is run by the runner which saves
+//	the metrics of the pipeline (see setupMetricsRunner). In case of run step is completed successfully saves the metrics
+//	as cache.PipelineMetrics into cache before the graph. The list is empty if the pipeline doesn't define metrics.
+// - In case of the code is a unit test and run step is completed (with or without errors) saves the results of the tests
+//	parsed from run output as cache.TestResults into cache.
+// - In case of the code processing is failed (validation, compile or run error, timeout, cancellation or error of the server)
+//	saves playground.ErrorCategory of the failure as cache.ErrorCategory into cache right before the status,
+//	so clients could distinguish failures without parsing the error outputs.
+// - In case of compile or run output exceeds the max output size keeps only the beginning of the output in cache
+//	followed by a marker with the number of omitted bytes.
+//	If the run output should be spilled, the full run output is written to the file in the folder of the pipeline
+//	and the path of the file is saved as cache.RunOutputFile into cache (see GetFullOutputReader).
+// - In case of the run output flush policy is set (see environment.ApplicationEnvs.RunOutputFlushLines and RunOutputFlushInterval)
+//	the run output is appended to cache by batches of lines instead of line by line. The rest of the batch is saved when the run step finishes.
+// At the end of this method deletes the folder of the pipeline and sets expiration time for all cache values of the pipeline,
+// so they are kept in cache for the cache key expiration time after the code processing is finished.
+// The folder is deleted after the final status and outputs are saved into cache, so nothing needed from it is lost.
+// If the files of the pipeline should be kept for debugging, the folder isn't deleted.
+// Durations of compile and run steps, the number of executing pipelines and the final statuses are kept as metrics.
+// All log messages of the code processing contain pipelineId, SDK and phase of the code processing.
+// If the network isolation is enabled, the code is run without access to the network (in a new network namespace
+//	or wrapped with the sandbox command).
+// If the pipeline disk quota is set, the size of the folder of the pipeline is checked each pauseDuration during the run step.
+//	In case the quota is exceeded the executed code is killed, "disk quota exceeded" is saved as cache.RunError
+//	and playground.Status_STATUS_RUN_ERROR as cache.Status into cache.
+// If stdin isn't empty, it is passed to the standard input of the executed code.
+// Default pipeline options from the config of the SDK are merged with pipelineOptions, values of pipelineOptions override them.
+// The pipeline ID is passed to the executed code as PLAYGROUND_PIPELINE_ID environment variable
+//	and replaces ${PLAYGROUND_PIPELINE_ID} tokens in the pipeline options, so the code could namespace its outputs by the pipeline ID.
+//	The environment variable isn't set for the code which is dispatched to a pre-started JVM, the token should be used there.
+// If sdkVersion isn't empty, the code is processed with the toolchain of this version of the SDK, otherwise with the latest one.
+//	In case the version isn't configured for the SDK saves the error as cache.ValidationOutput
+//	and playground.Status_STATUS_VALIDATION_ERROR as cache.Status into cache.
+// If entryPoint isn't empty, the class with this fully qualified name is run instead of the inferred main class (only for Java, SCIO and Kotlin SDKs).
+//	In case the class isn't compiled from the code saves the error as cache.ValidationOutput
+//	and playground.Status_STATUS_VALIDATION_ERROR as cache.Status into cache.
+// stdin, sdkVersion and entryPoint which aren't empty are saved as cache.Stdin, cache.SdkVersion and cache.EntryPoint into cache,
+//	so the code is run with them again by RerunPipeline.
+// If jvmWarmPool isn't nil, the code which isn't a unit test is dispatched to a pre-started JVM of the pool
+//	(the cold start of the JVM is skipped). If the code couldn't be run by the pre-started JVM, a new JVM is started.
+// If the JSON logs are enabled for lc (see fs_tool.LifeCycle.EnableJsonLogs), the logs of the executed code
+//	are printed as JSON lines, so cache.RunLogs contains them as JSON lines. Such code isn't dispatched to a pre-started JVM.
+// If compileCache isn't nil, the compiled artifacts of the code are kept in it after the successful compilation.
+//	In case the artifacts of the same code (see CompileCache) are already kept, they are copied to the folder of the pipeline,
+//	the compile step is skipped and empty cache.CompileOutput is saved into cache.
+// The code is run by executionBackend (i.e. in a container or on a remote worker) which receives the command, the environment,
+//	the limits and the isolation of the code as executors.ExecutionSpec. If executionBackend is nil, the code is run
+//	in a throwaway container of the docker image of the SDK if it is set in the config of the SDK (see executors.DockerBackend),
+//	otherwise in a subprocess of the server (see executors.SubprocessBackend).
+//	In case the backend fails to execute the code (i.e. the container couldn't be created) saves playground.Status_STATUS_ERROR
+//	as cache.Status into cache. The code dispatched to a pre-started JVM isn't run by the backend.
+func Process(ctx context.Context, cacheService cache.Cache, workerPool *WorkerPool, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, appEnv *environment.ApplicationEnvs, sdkEnv *environment.BeamEnvs, pipelineOptions, stdin, sdkVersion, entryPoint string, jvmWarmPool *executors.JvmWarmPool, compileCache *CompileCache, executionBackend executors.ExecutionBackend) {
+	process(ctx, cacheService, workerPool, lc, pipelineId, appEnv, sdkEnv, pipelineOptions, stdin, sdkVersion, entryPoint, jvmWarmPool, compileCache, executionBackend, false, false)
+}
+
+// ValidateAndCompile validates and compiles code by pipelineId without running it.
+// It works the same way as Process, but doesn't wait for a slot of the worker pool and stops after the compile step:
+// - In case of compile step is failed saves playground.Status_STATUS_COMPILE_ERROR as cache.Status, compile logs as cache.CompileOutput
+//	and compile errors parsed from compile logs as cache.CompileErrors into cache.
+// - In case of compile step is completed with no errors (or the SDK doesn't need compilation)
+//	saves playground.Status_STATUS_COMPILE_FINISHED as cache.Status and compile output as cache.CompileOutput into cache.
+// Run output, run logs and logs aren't saved into cache.
+func ValidateAndCompile(ctx context.Context, cacheService cache.Cache, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, appEnv *environment.ApplicationEnvs, sdkEnv *environment.BeamEnvs, pipelineOptions string) {
+	process(ctx, cacheService, nil, lc, pipelineId, appEnv, sdkEnv, pipelineOptions, "", "", "", nil, nil, nil, true, false)
+}
+
+// RerunPipeline runs the code of the finished pipeline by sourceLc again with new pipelineOptions as a new pipeline by pipelineId,
+//	so the options could be changed without uploading the code again.
+// The source files and the compiled files of the finished pipeline are copied to the folders of lc (see fs_tool.LifeCycle.CopyCodeFiles),
+//	so the folders of lc should be created, but the files with the code shouldn't. The files of the finished pipeline should be kept
+//	(see environment.ApplicationEnvs.KeepPipelineFiles).
+// The id of the finished pipeline is saved as cache.SourcePipelineId of the new pipeline, then the code is processed as described for Process
+//	with stdin, sdkVersion and entryPoint of the finished pipeline, but the code isn't prepared and compiled again.
+// In case the finished pipeline doesn't exist in cache - returns an errors.NotFoundError.
+// The pipeline whose run step is canceled (see CancelPipeline) is considered as finished, since its code is compiled.
+// In case the code processing of the finished pipeline isn't finished or is failed before the run step
+//	or the files of the finished pipeline aren't kept - returns an errors.FailedPreconditionError.
+// In case of error during reading the parameters of the finished pipeline, copying the files or saving the id of the finished pipeline
+//	- returns an errors.InternalError.
+// The new pipeline isn't processed if an error is returned.
+func RerunPipeline(ctx context.Context, cacheService cache.Cache, workerPool *WorkerPool, sourceLc, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, appEnv *environment.ApplicationEnvs, sdkEnv *environment.BeamEnvs, pipelineOptions string, jvmWarmPool *executors.JvmWarmPool, executionBackend executors.ExecutionBackend) error {
+	sourcePipelineId := sourceLc.PipelineId()
+	status, err := GetProcessingStatus(ctx, cacheService, sourcePipelineId, "Rerun")
+	if err != nil {
+		return err
+	}
+	isRunFinished := false
+	switch status {
+	case pb.Status_STATUS_FINISHED, pb.Status_STATUS_RUN_ERROR, pb.Status_STATUS_RUN_TIMEOUT:
+		isRunFinished = true
+	case pb.Status_STATUS_CANCELED:
+		isRunFinished = isRunCanceled(ctx, cacheService, sourcePipelineId)
+	}
+	if !isRunFinished {
+		logger.Errorf("%s: RerunPipeline(): code processing isn't finished after the run step with status: %s", sourcePipelineId, status)
+		return errors.FailedPreconditionError("Rerun", "code processing isn't finished after the run step with status: %s", status.String())
+	}
+	if _, err := os.Stat(sourceLc.GetAbsoluteSourceFilePath()); err != nil {
+		logger.Errorf("%s: RerunPipeline(): files of the pipeline aren't kept: %s", sourcePipelineId, err.Error())
+		return errors.FailedPreconditionError("Rerun", "files of the pipeline aren't kept")
+	}
+	runParameters, err := cacheService.GetValues(ctx, sourcePipelineId, []cache.SubKey{cache.Stdin, cache.SdkVersion, cache.EntryPoint})
+	if err != nil && !stderrors.Is(err, cache.ErrNotFound) {
+		logger.Errorf("%s: RerunPipeline(): cache.GetValues: error: %s", sourcePipelineId, err.Error())
+		return errors.InternalError("Rerun", "Error during get parameters of the pipeline from cache")
+	}
+	// the empty parameters of the finished pipeline aren't kept in cache (see setRunParameters), so they are empty for the new pipeline too
+	stdin, _ := runParameters[cache.Stdin].(string)
+	sdkVersion, _ := runParameters[cache.SdkVersion].(string)
+	entryPoint, _ := runParameters[cache.EntryPoint].(string)
+	if err := lc.CopyCodeFiles(sourceLc); err != nil {
+		logger.Errorf("%s: RerunPipeline(): CopyCodeFiles(): error: %s", pipelineId, err.Error())
+		return errors.InternalError("Rerun", "Error during copying files of the pipeline")
+	}
+	if err := cacheService.SetValue(ctx, pipelineId, cache.SourcePipelineId, sourcePipelineId.String()); err != nil {
+		logger.Errorf("%s: RerunPipeline(): cache.SetValue: error: %s", pipelineId, err.Error())
+		return errors.InternalError("Rerun", "Error during set id of the source pipeline to cache")
+	}
+	process(ctx, cacheService, workerPool, lc, pipelineId, appEnv, sdkEnv, pipelineOptions, stdin, sdkVersion, entryPoint, jvmWarmPool, nil, executionBackend, false, true)
+	return nil
+}
+
+// isRunCanceled returns true if only the run step of the code processing by pipelineId is canceled (see CancelPipeline)
+func isRunCanceled(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) bool {
+	if canceled, err := cacheService.GetValue(ctx, pipelineId, cache.Canceled); err == nil {
+		if isCanceled, ok := canceled.(bool); ok && isCanceled {
+			return false
+		}
+	}
+	phase, err := cacheService.GetValue(ctx, pipelineId, cache.CanceledPhase)
+	return err == nil && phase == pb.CancelPhase_CANCEL_PHASE_RUN
+}
+
+// process processes the code by pipelineId as described for Process.
+// If compileOnly is true stops after the compile step as described for ValidateAndCompile.
+// If rerun is true skips the preparation and the compile steps as described for RerunPipeline.
+func process(ctx context.Context, cacheService cache.Cache, workerPool *WorkerPool, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, appEnv *environment.ApplicationEnvs, sdkEnv *environment.BeamEnvs, pipelineOptions, stdin, sdkVersion, entryPoint string, jvmWarmPool *executors.JvmWarmPool, compileCache *CompileCache, executionBackend executors.ExecutionBackend, compileOnly, rerun bool) {
+	ctx = logger.NewContext(ctx, logger.With(logger.PipelineIdField, pipelineId).With(logger.SdkField, sdkEnv.ApacheBeamSdk))
 	ctxWithTimeout, finishCtxFunc := context.WithTimeout(ctx, appEnv.PipelineExecuteTimeout())
+	metrics.PipelineStarted()
 	defer func(lc *fs_tool.LifeCycle) {
 		finishCtxFunc()
-		DeleteFolders(pipelineId, lc)
+		// ctx is canceled if the server is shutting down, but the results of the code processing should be kept anyway
+		cacheCtx := ctx
+		if ctx.Err() != nil {
+			cacheCtx = detachedContext(ctx)
+		}
+		finishMetrics(cacheCtx, cacheService, pipelineId)
+		if appEnv.KeepPipelineFiles() {
+			phaseLogger(cacheCtx, finishPhase).Infof("files of the pipeline are kept in %s", lc.Folder.BaseFolder)
+		} else {
+			deleteFolders(cacheCtx, lc)
+		}
+		setExpTime(cacheCtx, cacheService, pipelineId, appEnv.CacheEnvs().KeyExpirationTime())
 	}(lc)
 
 	errorChannel := make(chan error, 1)
 	successChannel := make(chan bool, 1)
-	cancelChannel := make(chan bool, 1)
+	compileCancelChannel := make(chan bool, 1)
+	runCancelChannel := make(chan bool, 1)
 	stopReadLogsChannel := make(chan bool, 1)
 	finishReadLogsChannel := make(chan bool, 1)
 	var validationResults sync.Map
 
-	go cancelCheck(ctxWithTimeout, pipelineId, cancelChannel, cacheService)
+	go cancelCheck(ctxWithTimeout, pipelineId, compileCancelChannel, runCancelChannel, cacheService)
 
-	executorBuilder, err := builder.SetupExecutorBuilder(lc.GetAbsoluteSourceFilePath(), lc.GetAbsoluteBaseFolderPath(), lc.GetAbsoluteExecutableFilePath(), utils.ReduceWhiteSpacesToSinge(pipelineOptions), sdkEnv)
+	// Preparation of the files
+	if err := utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.Status, pb.Status_STATUS_PREPARING); err != nil {
+		return
+	}
+	if !compileOnly {
+		if err := setRunParameters(ctxWithTimeout, cacheService, pipelineId, stdin, sdkVersion, entryPoint); err != nil {
+			return
+		}
+	}
+	if err := processSourceUrl(ctxWithTimeout, lc, pipelineId, appEnv.SourceUrlAllowedHosts(), cacheService); err != nil {
+		return
+	}
+	if err := processSourceSize(ctxWithTimeout, lc, pipelineId, appEnv.MaxSourceSize(), cacheService); err != nil {
+		return
+	}
+	sdkEnv, err := processSdkVersion(ctxWithTimeout, sdkEnv, sdkVersion, pipelineId, cacheService)
+	if err != nil {
+		return
+	}
+
+	pipelineOptions = utils.MergePipelineOptions(sdkEnv.ExecutorConfig.PipelineOptions, pipelineOptions)
+	pipelineOptions = strings.ReplaceAll(pipelineOptions, pipelineIdToken, pipelineId.String())
+	// the key of the compiled files is calculated by pipelineOptions, since runOptions contain the folder of the pipeline
+	runOptions, err := processOutputPaths(ctxWithTimeout, lc, pipelineOptions, appEnv, pipelineId, cacheService)
+	if err != nil {
+		return
+	}
+	executorBuilder, err := builder.SetupExecutorBuilder(lc, utils.ReduceWhiteSpacesToSinge(runOptions), sdkEnv)
 	if err != nil {
 		_ = processSetupError(err, pipelineId, cacheService, ctxWithTimeout)
 		return
 	}
+	executorBuilder = executorBuilder.
+		WithMemoryLimit(appEnv.PipelineMemoryLimit()).
+		WithCpuTimeLimit(appEnv.PipelineCpuTimeLimit()).
+		WithNetworkIsolation(appEnv.NetworkIsolation(), appEnv.SandboxCmd()).
+		WithEnv(pipelineEnv(sdkEnv.ExecutorConfig.Env, pipelineId, lc)).
+		WithStdin(stdin)
 	executor := executorBuilder.Build()
 	// Validate
-	logger.Infof("%s: Validate() ...\n", pipelineId)
-	validateFunc := executor.Validate()
+	phaseLogger(ctx, validatePhase).Infof("started")
+	if err := utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.Status, pb.Status_STATUS_VALIDATING); err != nil {
+		return
+	}
+	validateFunc := executor.Validate(ctxWithTimeout, lc)
 	go validateFunc(successChannel, errorChannel, &validationResults)
 
-	ok, err := processStep(ctxWithTimeout, pipelineId, cacheService, cancelChannel, successChannel)
+	ok, err := processStep(ctxWithTimeout, pipelineId, cacheService, compileCancelChannel, successChannel, nil)
 	if err != nil {
 		return
 	}
 	if !ok {
-		_ = processError(ctxWithTimeout, errorChannel, pipelineId, cacheService, "Validate", pb.Status_STATUS_VALIDATION_ERROR)
+		_ = processValidationError(ctxWithTimeout, errorChannel, pipelineId, cacheService)
+		return
+	}
+	if err := processValidationWarnings(ctxWithTimeout, &validationResults, sdkEnv.ApacheBeamSdk, pipelineId, cacheService); err != nil {
 		return
 	}
-	if err := processSuccess(ctxWithTimeout, pipelineId, cacheService, "Validate", pb.Status_STATUS_PREPARING); err != nil {
+	// the code is adapted for compilation while the status is still playground.Status_STATUS_VALIDATING
+	if err := processSuccess(ctxWithTimeout, pipelineId, cacheService, validatePhase, pb.Status_STATUS_VALIDATING); err != nil {
 		return
 	}
 
 	// Prepare
-	logger.Infof("%s: Prepare() ...\n", pipelineId)
-	prepareFunc := executor.Prepare()
-	go prepareFunc(successChannel, errorChannel)
+	// the code of the rerun pipeline is already prepared by the source pipeline
+	if !rerun {
+		phaseLogger(ctx, preparePhase).Infof("started")
+		prepareFunc := executor.Prepare()
+		go prepareFunc(successChannel, errorChannel)
 
-	ok, err = processStep(ctxWithTimeout, pipelineId, cacheService, cancelChannel, successChannel)
-	if err != nil {
-		return
+		ok, err = processStep(ctxWithTimeout, pipelineId, cacheService, compileCancelChannel, successChannel, nil)
+		if err != nil {
+			return
+		}
+		if !ok {
+			_ = processError(ctxWithTimeout, errorChannel, pipelineId, cacheService, preparePhase, pb.Status_STATUS_PREPARATION_ERROR, pb.ErrorCategory_ERROR_CATEGORY_INTERNAL)
+			return
+		}
 	}
-	if !ok {
-		_ = processError(ctxWithTimeout, errorChannel, pipelineId, cacheService, "Prepare", pb.Status_STATUS_PREPARATION_ERROR)
-		return
+	// SDKs without compilation (i.e. Python) go to the run step right after the preparation
+	nextStatus := pb.Status_STATUS_COMPILING
+	if sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_PYTHON && !compileOnly {
+		nextStatus = pb.Status_STATUS_EXECUTING
 	}
-	if err := processSuccess(ctxWithTimeout, pipelineId, cacheService, "Prepare", pb.Status_STATUS_COMPILING); err != nil {
+	if err := processSuccess(ctxWithTimeout, pipelineId, cacheService, preparePhase, nextStatus); err != nil {
 		return
 	}
 
+	metricsCollected := false
+	if sdkEnv.ExecutorConfig.CollectMetrics && !compileOnly && !isUnitTest(&validationResults) {
+		executorBuilder, metricsCollected = setupMetricsRunner(ctx, lc, sdkEnv.ApacheBeamSdk, runOptions, executorBuilder)
+		executor = executorBuilder.Build()
+	}
+
+	// Queue
+	if !compileOnly {
+		if err := waitForWorkerSlot(ctxWithTimeout, pipelineId, cacheService, workerPool, compileCancelChannel, nextStatus); err != nil {
+			return
+		}
+		defer workerPool.release()
+	}
+
 	switch sdkEnv.ApacheBeamSdk {
-	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_GO:
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_GO, pb.Sdk_SDK_SCIO, pb.Sdk_SDK_KOTLIN:
+		// the rerun pipeline runs the compiled files of the source pipeline
+		if rerun {
+			phaseLogger(ctx, compilePhase).Infof("the compiled files of the source pipeline are reused")
+			if err := processCompileSuccess(ctxWithTimeout, []byte(""), sdkEnv.ApacheBeamSdk, pipelineId, cacheService, appEnv.MaxCompileOutputSize()); err != nil {
+				return
+			}
+			break
+		}
+		compileCacheKey := compileCache.key(lc, pipelineId, sdkEnv, pipelineOptions)
+		if compileCache.restore(compileCacheKey, lc, pipelineId) {
+			phaseLogger(ctx, compilePhase).Infof("the compiled artifacts of the same code are reused")
+			if compileOnly {
+				_ = processCompileOnlySuccess(ctxWithTimeout, []byte(""), sdkEnv.ApacheBeamSdk, pipelineId, cacheService, appEnv.MaxCompileOutputSize())
+				return
+			}
+			if err := processCompileSuccess(ctxWithTimeout, []byte(""), sdkEnv.ApacheBeamSdk, pipelineId, cacheService, appEnv.MaxCompileOutputSize()); err != nil {
+				return
+			}
+			break
+		}
 		// Compile
-		logger.Infof("%s: Compile() ...\n", pipelineId)
-		compileCmd := executor.Compile(ctxWithTimeout)
+		phaseLogger(ctx, compilePhase).Infof("started")
+		var compileCmd *exec.Cmd
 		var compileError bytes.Buffer
 		var compileOutput bytes.Buffer
-		runCmdWithOutput(compileCmd, &compileOutput, &compileError, successChannel, errorChannel)
+		// the compile step is limited by the SDK-specific timeout in addition to the timeout of the whole code processing
+		compileTimeout := sdkEnv.CompileTimeout(appEnv.PipelineExecuteTimeout())
+		compileCtx, finishCompileCtxFunc := context.WithTimeout(ctxWithTimeout, compileTimeout)
+		defer finishCompileCtxFunc()
+		var compileOutputWriter streaming.RunOutputWriter
+		compileStartTime := time.Now()
+		for attempt := 0; ; attempt++ {
+			compileCmd = executor.Compile(compileCtx)
+			compileError.Reset()
+			compileOutput.Reset()
+			// the error output of the compiler is streamed, so compile errors could be received while the code is compiling
+			if err := utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.CompileOutputStream, ""); err != nil {
+				return
+			}
+			if err := utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.CompileOutputStreamIndex, 0); err != nil {
+				return
+			}
+			compileOutputWriter = streaming.RunOutputWriter{Ctx: ctxWithTimeout, CacheService: cacheService, PipelineId: pipelineId, SubKey: cache.CompileOutputStream, MaxSize: appEnv.MaxCompileOutputSize()}
+			runCmdWithOutput(compileCmd, &compileOutput, io.MultiWriter(&compileError, &compileOutputWriter), successChannel, errorChannel)
 
-		ok, err = processStep(ctxWithTimeout, pipelineId, cacheService, cancelChannel, successChannel)
-		if err != nil {
+			ok, err = processStep(ctxWithTimeout, pipelineId, cacheService, compileCancelChannel, successChannel, func() {
+				terminateCmd(ctxWithTimeout, compileCmd, successChannel, appEnv.PipelineCancelGracePeriod())
+				if err := compileOutputWriter.Close(); err != nil {
+					phaseLogger(ctx, compilePhase).Errorf("error during truncating output: %s", err.Error())
+				}
+			})
+			if err != nil {
+				return
+			}
+			if ok || compileCtx.Err() != nil {
+				break
+			}
+			retry, err := retryTransientFailure(ctxWithTimeout, compilePhase, pipelineId, cacheService, compileCancelChannel, errorChannel, compileError.Bytes(), attempt, appEnv)
+			if err != nil {
+				return
+			}
+			if !retry {
+				break
+			}
+		}
+		metrics.ObserveCompileDuration(compileStartTime)
+		_ = utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.CompileTime, time.Since(compileStartTime))
+		if !ok && compileCtx.Err() != nil {
+			_ = processCompileTimeout(ctxWithTimeout, errorChannel, compileError.Bytes(), compileTimeout, pipelineId, cacheService, appEnv.MaxCompileOutputSize())
 			return
 		}
 		if !ok {
-			_ = processCompileError(ctxWithTimeout, errorChannel, compileError.Bytes(), pipelineId, cacheService)
+			_ = processCompileError(ctxWithTimeout, errorChannel, compileError.Bytes(), sdkEnv.ApacheBeamSdk, pipelineId, cacheService, appEnv.MaxCompileOutputSize())
+			return
+		}
+		if err := compileCache.store(compileCacheKey, lc, pipelineId); err != nil {
+			phaseLogger(ctx, compilePhase).Warnf("compiled artifacts aren't kept: %s", err.Error())
+		}
+		// the warnings of the compiler are printed to stderr, so it is kept in the output of the successful compile step too
+		compileOutput.Write(compileError.Bytes())
+		if compileOnly {
+			_ = processCompileOnlySuccess(ctxWithTimeout, compileOutput.Bytes(), sdkEnv.ApacheBeamSdk, pipelineId, cacheService, appEnv.MaxCompileOutputSize())
 			return
 		}
-		if err := processCompileSuccess(ctxWithTimeout, compileOutput.Bytes(), pipelineId, cacheService); err != nil {
+		if err := processCompileSuccess(ctxWithTimeout, compileOutput.Bytes(), sdkEnv.ApacheBeamSdk, pipelineId, cacheService, appEnv.MaxCompileOutputSize()); err != nil {
 			return
 		}
 	case pb.Sdk_SDK_PYTHON:
-		if err := processCompileSuccess(ctxWithTimeout, []byte(""), pipelineId, cacheService); err != nil {
+		if compileOnly {
+			_ = processCompileOnlySuccess(ctxWithTimeout, []byte(""), sdkEnv.ApacheBeamSdk, pipelineId, cacheService, appEnv.MaxCompileOutputSize())
+			return
+		}
+		if err := processCompileSuccess(ctxWithTimeout, []byte(""), sdkEnv.ApacheBeamSdk, pipelineId, cacheService, appEnv.MaxCompileOutputSize()); err != nil {
+			return
+		}
+	}
+
+	// Run
+	if sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_JAVA || sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_SCIO || sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_KOTLIN {
+		executor, err = setJavaExecutableFile(lc, pipelineId, cacheService, ctxWithTimeout, executorBuilder, appEnv.ExecutionRoot(), entryPoint)
+		if err != nil {
+			return
+		}
+	}
+	// the run step canceled while the code is compiled isn't started, but the compiled code is kept
+	select {
+	case <-runCancelChannel:
+		_ = processCancel(ctxWithTimeout, cacheService, pipelineId)
+		return
+	default:
+	}
+	// the run step is limited by the SDK-specific timeout in addition to the timeout of the whole code processing
+	runCtx, finishRunCtxFunc := context.WithTimeout(ctxWithTimeout, sdkEnv.RunTimeout(appEnv.PipelineExecuteTimeout()))
+	defer finishRunCtxFunc()
+	phaseLogger(ctx, runPhase).Infof("started")
+	if executionBackend == nil {
+		executionBackend = defaultExecutionBackend(sdkEnv.ExecutorConfig)
+	}
+	// the pre-started JVMs are subprocesses of the server, so the code run by another backend isn't dispatched to them
+	if _, ok := executionBackend.(executors.SubprocessBackend); !ok {
+		jvmWarmPool = nil
+	}
+	var runExitCode int
+	var runError bytes.Buffer
+	var runOutput streaming.RunOutputWriter
+	var combinedLogs bytes.Buffer
+	go readLogFile(ctxWithTimeout, cacheService, lc.GetAbsoluteLogFilePath(), pipelineId, stopReadLogsChannel, finishReadLogsChannel)
+	runStartTime := time.Now()
+	diskQuotaExceeded := false
+	runOutputFilePath := ""
+	if appEnv.SpillRunOutput() {
+		runOutputFilePath = lc.GetAbsoluteRunOutputFilePath()
+	}
+	for attempt := 0; ; attempt++ {
+		runError.Reset()
+		combinedLogs.Reset()
+		runOutput = streaming.RunOutputWriter{Ctx: ctxWithTimeout, CacheService: cacheService, PipelineId: pipelineId, MaxSize: appEnv.MaxOutputSize(), Normalize: sdkEnv.ExecutorConfig.NormalizeOutput, SpillFilePath: runOutputFilePath, FlushLines: appEnv.RunOutputFlushLines(), FlushInterval: appEnv.RunOutputFlushInterval()}
+		var stopRun, killRun func()
+		if warmJvm := getWarmJvm(runCtx, &validationResults, &executor, jvmWarmPool); warmJvm != nil {
+			phaseLogger(ctx, runPhase).Infof("the code is dispatched to the pre-started JVM")
+			runCmd := warmJvm.Cmd()
+			_ = utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.RunCommand, commandLine(runCmd))
+			waitWarmJvm := func() error {
+				err := warmJvm.Wait()
+				runExitCode = executors.ExitCode(err)
+				return err
+			}
+			readCmdWithCombinedLogs(waitWarmJvm, warmJvm.Stdout(), warmJvm.Stderr(), &runOutput, &runError, &combinedLogs, successChannel, errorChannel)
+			stopRun = func() {
+				terminateCmd(runCtx, runCmd, successChannel, appEnv.PipelineCancelGracePeriod())
+			}
+			killRun = func() {
+				if runCmd.Process != nil {
+					_ = executors.SignalCmd(runCmd, syscall.SIGKILL)
+				}
+			}
+		} else {
+			_ = utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.RunCommand, commandLine(getExecuteCmd(&validationResults, &executor, runCtx)))
+			var runCombinedLogs *bytes.Buffer
+			if sdkEnv.ExecutorConfig.CombinedLogs {
+				runCombinedLogs = &combinedLogs
+			}
+			spec := getExecutionSpec(&validationResults, &executor)
+			spec.PipelineFolder = lc.GetAbsoluteBaseFolderPath()
+			spec.ReadOnlyPaths = readOnlyPaths(lc)
+			stopRun, killRun = runWithBackend(runCtx, executionBackend, spec, &runOutput, &runError, runCombinedLogs, &runExitCode, appEnv.PipelineCancelGracePeriod(), successChannel, errorChannel)
+		}
+		diskQuotaCtx, stopDiskQuotaCheck := context.WithCancel(runCtx)
+		diskQuotaChannel := make(chan bool, 1)
+		if appEnv.PipelineDiskQuota() > 0 {
+			go diskQuotaCheck(diskQuotaCtx, lc.GetAbsoluteBaseFolderPath(), appEnv.PipelineDiskQuota(), killRun, diskQuotaChannel)
+		}
+
+		// the output printed before the cancellation is kept
+		ok, err = processStep(runCtx, pipelineId, cacheService, runCancelChannel, successChannel, func() {
+			stopRun()
+			if err := runOutput.Close(); err != nil {
+				phaseLogger(ctx, runPhase).Errorf("error during truncating output: %s", err.Error())
+			}
+		})
+		stopDiskQuotaCheck()
+		if err != nil {
+			// the buffered output printed before the timeout is kept
+			if err := runOutput.Close(); err != nil {
+				phaseLogger(ctx, runPhase).Errorf("error during truncating output: %s", err.Error())
+			}
 			return
 		}
+		if ok {
+			break
+		}
+		// the code killed because of the disk quota would exceed it again, so it isn't retried
+		if len(diskQuotaChannel) > 0 {
+			diskQuotaExceeded = true
+			break
+		}
+		retry, err := retryTransientFailure(runCtx, runPhase, pipelineId, cacheService, runCancelChannel, errorChannel, runError.Bytes(), attempt, appEnv)
+		if err != nil {
+			return
+		}
+		if !retry {
+			break
+		}
+		// the output of the failed attempt isn't kept
+		if err := runOutput.Close(); err != nil {
+			phaseLogger(ctx, runPhase).Errorf("error during truncating output: %s", err.Error())
+		}
+		if err := utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.RunOutput, ""); err != nil {
+			return
+		}
+		if err := utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.RunOutputFile, ""); err != nil {
+			return
+		}
+	}
+	metrics.ObserveRunDuration(runStartTime)
+	_ = utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.RunTime, time.Since(runStartTime))
+	if err := runOutput.Close(); err != nil {
+		phaseLogger(ctx, runPhase).Errorf("error during truncating output: %s", err.Error())
+	}
+	if sdkEnv.ExecutorConfig.CombinedLogs {
+		_ = utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.CombinedLogs, utils.TruncateOutput(combinedLogs.String(), appEnv.MaxOutputSize()))
+	}
+	if isUnitTest(&validationResults) {
+		_ = processTestResults(ctxWithTimeout, lc, sdkEnv.ApacheBeamSdk, pipelineId, cacheService)
+	}
+	if !ok {
+		_ = processRunError(ctxWithTimeout, errorChannel, runError.Bytes(), runExitCode, pipelineId, cacheService, appEnv.PipelineCpuTimeLimit(), diskQuotaExceeded, stopReadLogsChannel, finishReadLogsChannel)
+		return
+	}
+	if metricsCollected {
+		processPipelineMetrics(ctxWithTimeout, lc, sdkEnv.ApacheBeamSdk, pipelineId, cacheService)
+	}
+	if len(sdkEnv.ExecutorConfig.GraphArgs) > 0 && !isUnitTest(&validationResults) {
+		processGraph(ctxWithTimeout, lc, pipelineId, cacheService)
+	}
+	_ = processRunSuccess(ctxWithTimeout, runError.Bytes(), pipelineId, cacheService, stopReadLogsChannel, finishReadLogsChannel)
+}
+
+// processSourceUrl downloads the code and saves it to the main source file if the file contains only a link to the code.
+// In case the code couldn't be downloaded, sets the reason as cache.ValidationOutput
+//	and playground.Status_STATUS_VALIDATION_ERROR as cache.Status into cache and returns an error.
+//	If a limit of downloading is exceeded, the reason starts with the code of the limit (i.e. SOURCE_URL_TOO_LARGE).
+func processSourceUrl(ctx context.Context, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, allowedHosts []string, cacheService cache.Cache) error {
+	sourceUrl, isSourceUrl, err := lc.GetSourceUrl()
+	if err != nil || !isSourceUrl {
+		// if the main source file couldn't be read, the error is processed on the validation step
+		return nil
+	}
+	phaseLogger(ctx, fetchSourcePhase).Infof("started")
+	if err := lc.CreateSourceCodeFileFromUrl(ctx, sourceUrl, allowedHosts); err != nil {
+		phaseLogger(ctx, fetchSourcePhase).Errorf("%s", err.Error())
+		if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.ValidationOutput, fmt.Sprintf("failed to fetch the code from %s: %s", sourceUrl, err.Error())); err != nil {
+			return err
+		}
+		if err := setErrorStatus(ctx, cacheService, pipelineId, pb.Status_STATUS_VALIDATION_ERROR, pb.ErrorCategory_ERROR_CATEGORY_VALIDATION); err != nil {
+			return err
+		}
+		return err
+	}
+	phaseLogger(ctx, fetchSourcePhase).Infof("finish")
+	return nil
+}
+
+// processSourceSize checks that the total size of the source files doesn't exceed maxSourceSize.
+// In case the size is exceeded, sets the error as cache.ValidationOutput and playground.Status_STATUS_VALIDATION_ERROR
+//	as cache.Status into cache and returns the error.
+func processSourceSize(ctx context.Context, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, maxSourceSize int, cacheService cache.Cache) error {
+	if _, err := validators.GetSourceSizeValidator(maxSourceSize).Validate(ctx, lc); err != nil {
+		phaseLogger(ctx, validatePhase).Errorf("%s", err.Error())
+		if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.ValidationOutput, err.Error()); err != nil {
+			return err
+		}
+		if err := setErrorStatus(ctx, cacheService, pipelineId, pb.Status_STATUS_VALIDATION_ERROR, pb.ErrorCategory_ERROR_CATEGORY_VALIDATION); err != nil {
+			return err
+		}
+		return err
+	}
+	return nil
+}
+
+// setRunParameters saves stdin as cache.Stdin, sdkVersion as cache.SdkVersion and entryPoint as cache.EntryPoint into cache,
+//	so the code could be run again with the same parameters (see RerunPipeline). The empty parameters aren't saved.
+func setRunParameters(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, stdin, sdkVersion, entryPoint string) error {
+	parameters := map[cache.SubKey]string{cache.Stdin: stdin, cache.SdkVersion: sdkVersion, cache.EntryPoint: entryPoint}
+	for subKey, value := range parameters {
+		if value == "" {
+			continue
+		}
+		if err := utils.SetToCache(ctx, cacheService, pipelineId, subKey, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processSdkVersion returns BeamEnvs with the toolchain of sdkVersion of the SDK.
+// In case the version isn't configured for the SDK, sets the error as cache.ValidationOutput
+//	and playground.Status_STATUS_VALIDATION_ERROR as cache.Status into cache and returns the error.
+func processSdkVersion(ctx context.Context, sdkEnv *environment.BeamEnvs, sdkVersion string, pipelineId uuid.UUID, cacheService cache.Cache) (*environment.BeamEnvs, error) {
+	versionEnv, err := sdkEnv.ForVersion(sdkVersion)
+	if err != nil {
+		phaseLogger(ctx, validatePhase).Errorf("%s", err.Error())
+		if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.ValidationOutput, err.Error()); err != nil {
+			return nil, err
+		}
+		if err := setErrorStatus(ctx, cacheService, pipelineId, pb.Status_STATUS_VALIDATION_ERROR, pb.ErrorCategory_ERROR_CATEGORY_VALIDATION); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
+	return versionEnv, nil
+}
+
+// processOutputPaths constrains the paths of the output which are set by pipelineOptions to the folder of the pipeline
+//	(see utils.ConstrainOutputPaths) and returns pipelineOptions with the absolute paths of the output.
+// In case some path is outside the folder of the pipeline and the allowed output paths, sets the error as cache.ValidationOutput
+//	and playground.Status_STATUS_VALIDATION_ERROR as cache.Status into cache and returns the error.
+func processOutputPaths(ctx context.Context, lc *fs_tool.LifeCycle, pipelineOptions string, appEnv *environment.ApplicationEnvs, pipelineId uuid.UUID, cacheService cache.Cache) (string, error) {
+	constrained, err := utils.ConstrainOutputPaths(pipelineOptions, appEnv.OutputPathOptions(), lc.GetAbsoluteBaseFolderPath(), appEnv.AllowedOutputPaths())
+	if err != nil {
+		phaseLogger(ctx, validatePhase).Errorf("%s", err.Error())
+		if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.ValidationOutput, err.Error()); err != nil {
+			return "", err
+		}
+		if err := setErrorStatus(ctx, cacheService, pipelineId, pb.Status_STATUS_VALIDATION_ERROR, pb.ErrorCategory_ERROR_CATEGORY_VALIDATION); err != nil {
+			return "", err
+		}
+		return "", err
+	}
+	return constrained, nil
+}
+
+// pipelineEnv returns a copy of env of the SDK with the pipeline ID of the code as executors.PipelineIdEnvKey variable.
+// If the logs of the code are printed as JSON lines, the variables which are needed for that are added too (see fs_tool.LifeCycle.JsonLogsEnv).
+func pipelineEnv(env map[string]string, pipelineId uuid.UUID, lc *fs_tool.LifeCycle) map[string]string {
+	jsonLogsEnv := lc.JsonLogsEnv(env)
+	result := make(map[string]string, len(env)+len(jsonLogsEnv)+1)
+	for key, value := range env {
+		result[key] = value
+	}
+	for key, value := range jsonLogsEnv {
+		result[key] = value
+	}
+	result[executors.PipelineIdEnvKey] = pipelineId.String()
+	return result
+}
+
+// getExecuteCmd return cmd instance based on the code type: unit test or example code
+func getExecuteCmd(valRes *sync.Map, executor *executors.Executor, ctxWithTimeout context.Context) *exec.Cmd {
+	return executors.SubprocessCmd(ctxWithTimeout, getExecutionSpec(valRes, executor))
+}
+
+// defaultExecutionBackend returns the backend which runs the code of the SDK with executorConfig:
+//	a throwaway container of the docker image of the SDK if it is set in the config, a subprocess of the server otherwise
+func defaultExecutionBackend(executorConfig *environment.ExecutorConfig) executors.ExecutionBackend {
+	if executorConfig.DockerImage != "" {
+		return executors.DockerBackend{Image: executorConfig.DockerImage, Network: executorConfig.DockerNetwork}
+	}
+	return executors.SubprocessBackend{}
+}
+
+// readOnlyPaths returns the paths of the source and compiled files of lc which the executed code shouldn't change.
+// The folders of the files are returned if they are separate from the folder of the pipeline,
+//	otherwise the source files themselves are returned since the code writes its files to the folder of the pipeline.
+func readOnlyPaths(lc *fs_tool.LifeCycle) []string {
+	baseFolder := lc.GetAbsoluteBaseFolderPath()
+	sourceFolder := filepath.Dir(lc.GetAbsoluteSourceFilePath())
+	if sourceFolder == baseFolder {
+		return lc.GetAbsoluteSourceFilePaths()
+	}
+	return []string{sourceFolder, lc.GetAbsoluteCompiledFolderPath()}
+}
+
+// getExecutionSpec returns the execution spec of the code for the execution backend based on the code type: unit test or example code
+func getExecutionSpec(valRes *sync.Map, executor *executors.Executor) executors.ExecutionSpec {
+	if isUnitTest(valRes) {
+		return executor.TestSpec()
+	}
+	return executor.RunSpec()
+}
+
+// getWarmJvm returns the pre-started JVM of jvmWarmPool which the code is dispatched to.
+// Returns nil if the code should be run by the command of getExecuteCmd: the pool isn't set, the code is a unit test
+//	or the code couldn't be run by the pre-started JVM.
+func getWarmJvm(ctx context.Context, valRes *sync.Map, executor *executors.Executor, jvmWarmPool *executors.JvmWarmPool) *executors.WarmJvm {
+	if jvmWarmPool == nil || isUnitTest(valRes) {
+		return nil
+	}
+	jvm, ok := executor.RunWarm(ctx, jvmWarmPool)
+	if !ok {
+		return nil
+	}
+	return jvm
+}
+
+// commandLine returns command line of cmd with its environment variables, i.e. "HOME=/root java -cp bin: HelloWorld".
+// Values of the environment variables which could contain secrets (i.e. "API_TOKEN") are redacted.
+// Arguments with spaces or special characters are quoted, so the command line could be copied to the shell.
+func commandLine(cmd *exec.Cmd) string {
+	parts := make([]string, 0, len(cmd.Env)+len(cmd.Args))
+	parts = append(parts, redactedEnv(cmd.Env)...)
+	for _, arg := range cmd.Args {
+		parts = append(parts, shellQuote(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// redactedEnv returns the environment variables ("KEY=value") with quoted values.
+// Values of the environment variables which could contain secrets are redacted.
+func redactedEnv(env []string) []string {
+	result := make([]string, 0, len(env))
+	for _, variable := range env {
+		key, value := variable, ""
+		if index := strings.Index(variable, "="); index >= 0 {
+			key, value = variable[:index], variable[index+1:]
+		}
+		if isSensitiveEnvKey(key) {
+			value = redactedValue
+		}
+		result = append(result, key+"="+shellQuote(value))
+	}
+	return result
+}
+
+// isSensitiveEnvKey checks if the environment variable with the key could contain secrets
+func isSensitiveEnvKey(key string) bool {
+	upperKey := strings.ToUpper(key)
+	for _, part := range sensitiveEnvKeyParts {
+		if strings.Contains(upperKey, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// shellQuote returns arg enclosed in single quotes if it contains spaces or characters which are special for the shell
+func shellQuote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`<>|&;()*?[]{}~#!") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// processValidationWarnings saves the warnings about the code according to the validation results as cache.Warnings into cache,
+//	i.e. the warning about the code which doesn't construct a Beam pipeline (see validators.GetBeamPipelineValidator)
+func processValidationWarnings(ctx context.Context, valRes *sync.Map, sdk pb.Sdk, pipelineId uuid.UUID, cacheService cache.Cache) error {
+	warnings := []string{}
+	if constructed, ok := valRes.Load(validators.BeamPipelineValidatorName); ok && !constructed.(bool) {
+		warnings = append(warnings, validators.MissingBeamPipelineWarning(sdk))
+	}
+	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Warnings, warnings)
+}
+
+// isUnitTest checks if the code is a unit test according to the validation results
+func isUnitTest(valRes *sync.Map) bool {
+	isUnitTest, ok := valRes.Load(validators.UnitTestValidatorName)
+	return ok && isUnitTest.(bool)
+}
+
+// setJavaExecutableFile sets executable file name to runner (JAVA and Kotlin class names and SCIO object name are known after compilation step)
+func setJavaExecutableFile(lc *fs_tool.LifeCycle, id uuid.UUID, service cache.Cache, ctx context.Context, executorBuilder *executors.ExecutorBuilder, dir, entryPoint string) (executors.Executor, error) {
+	if entryPoint != "" {
+		if err := processEntryPoint(ctx, lc, entryPoint, id, service); err != nil {
+			return executorBuilder.Build(), err
+		}
+		return executorBuilder.
+			WithExecutableFileName(entryPoint).
+			Build(), nil
+	}
+	className, err := lc.ExecutableName(id, dir)
+	if err != nil {
+		if err = processSetupError(err, id, service, ctx); err != nil {
+			return executorBuilder.Build(), err
+		}
+	}
+	return executorBuilder.
+		WithExecutableFileName(className).
+		Build(), nil
+}
+
+// processEntryPoint checks that the class specified as the entry point is compiled from the code.
+// In case it isn't saves the error as cache.ValidationOutput and playground.Status_STATUS_VALIDATION_ERROR as cache.Status into cache.
+func processEntryPoint(ctx context.Context, lc *fs_tool.LifeCycle, entryPoint string, pipelineId uuid.UUID, cacheService cache.Cache) error {
+	if lc.HasCompiledClass(entryPoint) {
+		return nil
+	}
+	err := fmt.Errorf("entry point %s isn't found in the compiled classes", entryPoint)
+	phaseLogger(ctx, validatePhase).Errorf("%s", err.Error())
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.ValidationOutput, err.Error()); err != nil {
+		return err
+	}
+	if err := setErrorStatus(ctx, cacheService, pipelineId, pb.Status_STATUS_VALIDATION_ERROR, pb.ErrorCategory_ERROR_CATEGORY_VALIDATION); err != nil {
+		return err
+	}
+	return err
+}
+
+// processSetupError processes errors during the setting up an executor builder
+func processSetupError(err error, pipelineId uuid.UUID, cacheService cache.Cache, ctxWithTimeout context.Context) error {
+	phaseLogger(ctxWithTimeout, setupPhase).Errorf("error during setup builder: %s", err.Error())
+	if err = setErrorStatus(ctxWithTimeout, cacheService, pipelineId, pb.Status_STATUS_ERROR, pb.ErrorCategory_ERROR_CATEGORY_INTERNAL); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetProcessingOutput gets processing output value from cache by key and subKey.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case subKey doesn't exist in cache for the key - returns an errors.NotFoundError.
+// In case value from cache by key and subKey is saved in the incompatible format - returns an errors.InternalError
+//	with "incompatible cache version" error.
+// In case value from cache by key and subKey couldn't be converted to string - returns an errors.InternalError.
+func GetProcessingOutput(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, errorTitle string) (string, error) {
+	value, err := cacheService.GetValue(ctx, key, subKey)
+	if err != nil {
+		logger.Errorf("%s: GetStringValueFromCache(): cache.GetValue: error: %s", key, err.Error())
+		if stderrors.Is(err, cache.ErrIncompatibleVersion) {
+			return "", incompatibleCacheVersionError(errorTitle, key, subKey)
+		}
+		return "", errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey))
+	}
+	stringValue, converted := value.(string)
+	if !converted {
+		logger.Errorf("%s: couldn't convert value to string: %s", key, value)
+		return "", errors.InternalError(errorTitle, "Value from cache couldn't be converted to string: %s", value)
+	}
+	return stringValue, nil
+}
+
+// GetFullOutputReader returns the reader of the full run output of the code by key. The reader should be closed by the caller.
+// In case the run output exceeds the max output size and is written to the file in the folder of the pipeline
+//	(see environment.ApplicationEnvs.SpillRunOutput), the reader reads the file. Otherwise, it reads the run output from cache.
+// The file is deleted together with the folder of the pipeline, so the full output could be read only while the files
+//	of the pipeline are kept (i.e. while the code is running or if environment.ApplicationEnvs.KeepPipelineFiles is set).
+// In case key doesn't exist in cache or the file with the run output is already deleted - returns an errors.NotFoundError.
+// In case the file with the run output couldn't be opened - returns an errors.InternalError.
+func GetFullOutputReader(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (io.ReadCloser, error) {
+	value, _ := cacheService.GetValue(ctx, key, cache.RunOutputFile)
+	filePath, _ := value.(string)
+	if filePath == "" {
+		output, err := GetProcessingOutput(ctx, cacheService, key, cache.RunOutput, errorTitle)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(strings.NewReader(output)), nil
+	}
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundError(errorTitle, "Full run output of %s is already deleted", key)
+	}
+	if err != nil {
+		logger.Errorf("%s: GetFullOutputReader(): os.Open: error: %s", key, err.Error())
+		return nil, errors.InternalError(errorTitle, "Error during opening the full run output")
+	}
+	return file, nil
+}
+
+// GetProcessingOutputSince gets the part of the processing output from cache by key and subKey which follows offset
+//	and the offset of the end of the output, so the client could pass it to the next call to receive only the new output.
+// Unlike GetNewOutput, the offset is kept by the client, so several clients could tail the same output independently.
+// In case key or subKey doesn't exist in cache - returns an errors.NotFoundError.
+// In case offset is negative or greater than the length of the output - returns an errors.InvalidArgumentError.
+// In case value from cache by key and subKey couldn't be converted to string - returns an errors.InternalError.
+func GetProcessingOutputSince(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, offset int, errorTitle string) (string, int, error) {
+	output, err := GetProcessingOutput(ctx, cacheService, key, subKey, errorTitle)
+	if err != nil {
+		return "", 0, err
+	}
+	if offset < 0 || offset > len(output) {
+		return "", 0, errors.InvalidArgumentError(errorTitle, "Offset %d is out of the output with length %d", offset, len(output))
+	}
+	return output[offset:], len(output), nil
+}
+
+// GetProcessingStatus gets processing status from cache by key.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case status from cache by key is saved in the incompatible format - returns an errors.InternalError
+//	with "incompatible cache version" error.
+// In case value from cache by key and subKey couldn't be converted to playground.Status - returns an errors.InternalError.
+func GetProcessingStatus(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (pb.Status, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.Status)
+	if err != nil {
+		logger.Errorf("%s: GetStringValueFromCache(): cache.GetValue: error: %s", key, err.Error())
+		if stderrors.Is(err, cache.ErrIncompatibleVersion) {
+			return pb.Status_STATUS_UNSPECIFIED, incompatibleCacheVersionError(errorTitle, key, cache.Status)
+		}
+		return pb.Status_STATUS_UNSPECIFIED, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.Status))
+	}
+	statusValue, converted := value.(pb.Status)
+	if !converted {
+		logger.Errorf("%s: couldn't convert value to correct status enum: %s", key, value)
+		return pb.Status_STATUS_UNSPECIFIED, errors.InternalError(errorTitle, "Value from cache couldn't be converted to correct status enum: %s", value)
+	}
+	return statusValue, nil
+}
+
+// CancelPipeline cancels the phase of the code processing by pipelineId, so the phase is stopped.
+// If phase is playground.CancelPhase_CANCEL_PHASE_UNSPECIFIED sets cache.Canceled flag, so the whole code processing is stopped.
+// Otherwise sets phase as cache.CanceledPhase:
+//	- playground.CancelPhase_CANCEL_PHASE_COMPILE stops the code processing before the run step.
+//	- playground.CancelPhase_CANCEL_PHASE_RUN stops only the run step. If the code is still compiled, the compilation is finished
+//	but the code isn't run. The compiled code is kept, so it could be run again by RerunPipeline.
+// In both cases playground.Status_STATUS_CANCELED is set as cache.Status when the code processing is stopped.
+// In case pipelineId doesn't exist in cache - returns an errors.NotFoundError.
+// In case the code processing is already finished or the compile phase is canceled after the code is compiled
+//	- returns an errors.FailedPreconditionError.
+// In case of error during setting the flag to cache - returns an errors.InternalError.
+func CancelPipeline(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, phase pb.CancelPhase) error {
+	status, err := GetProcessingStatus(ctx, cacheService, pipelineId, "Cancel")
+	if err != nil {
+		return err
+	}
+	if IsFinalStatus(status) {
+		logger.Errorf("%s: CancelPipeline(): code processing is already finished with status: %s", pipelineId, status)
+		return errors.FailedPreconditionError("Cancel", "code processing is already finished with status: %s", status.String())
+	}
+	if phase == pb.CancelPhase_CANCEL_PHASE_COMPILE && status == pb.Status_STATUS_EXECUTING {
+		logger.Errorf("%s: CancelPipeline(): code is already compiled", pipelineId)
+		return errors.FailedPreconditionError("Cancel", "code is already compiled, cancel the run phase or the whole code processing")
+	}
+	if phase == pb.CancelPhase_CANCEL_PHASE_UNSPECIFIED {
+		err = cacheService.SetValue(ctx, pipelineId, cache.Canceled, true)
+	} else {
+		err = cacheService.SetValue(ctx, pipelineId, cache.CanceledPhase, phase)
+	}
+	if err != nil {
+		logger.Errorf("%s: CancelPipeline(): cache.SetValue: error: %s", pipelineId, err.Error())
+		return errors.InternalError("Cancel", "Error during set cancel flag to cache")
+	}
+	return nil
+}
+
+// GetProcessingStatusWait gets processing status from cache by key as GetProcessingStatus,
+//	but blocks until the status differs from lastStatus, timeout elapses or ctx is done, so clients could long-poll the status.
+// Returns the status which is read last, so it is equal to lastStatus if the status isn't changed.
+// If cache implements cache.StatusNotifier the status is read again each time it is set,
+//	otherwise cache is checked each pauseDuration.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key couldn't be converted to playground.Status - returns an errors.InternalError.
+func GetProcessingStatusWait(ctx context.Context, cacheService cache.Cache, key uuid.UUID, lastStatus pb.Status, timeout time.Duration, errorTitle string) (pb.Status, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(pauseDuration)
+	defer ticker.Stop()
+	notifier, _ := cacheService.(cache.StatusNotifier)
+	for {
+		// the channel is received before the status, so the change between the read and the wait isn't missed
+		var statusChanged <-chan struct{}
+		if notifier != nil {
+			statusChanged = notifier.StatusChanged(key)
+		}
+		status, err := GetProcessingStatus(ctx, cacheService, key, errorTitle)
+		if err != nil || status != lastStatus {
+			return status, err
+		}
+		var pause <-chan time.Time
+		if statusChanged == nil {
+			pause = ticker.C
+		}
+		select {
+		case <-ctx.Done():
+			return status, nil
+		case <-timer.C:
+			return status, nil
+		case <-statusChanged:
+		case <-pause:
+		}
+	}
+}
+
+// incompatibleCacheVersionError returns an errors.InternalError for the value from cache by key and subKey
+//	which is saved in the format of the newer version of the cache, i.e. by the newer server during the rolling deploy.
+func incompatibleCacheVersionError(errorTitle string, key uuid.UUID, subKey cache.SubKey) error {
+	return errors.InternalError(errorTitle, "Value from cache by key: %s, subKey: %s has %s", key.String(), string(subKey), cache.ErrIncompatibleVersion)
+}
+
+// pipelineSnapshotSubKeys are the subKeys which are read from cache by GetPipelineSnapshot.
+var pipelineSnapshotSubKeys = []cache.SubKey{
+	cache.Status,
+	cache.RunOutput,
+	cache.RunError,
+	cache.CompileOutput,
+	cache.ValidationOutput,
+	cache.CompileErrors,
+	cache.RunOutputIndex,
+	cache.ErrorCategory,
+}
+
+// GetPipelineSnapshot gets status, outputs and errors of the code processing from cache by key in one read,
+//	so all of them correspond to the same moment of the code processing.
+// Values which haven't been saved into cache yet are left empty in the snapshot.
+// In case key or status doesn't exist in cache - returns an errors.NotFoundError.
+// In case any value from cache couldn't be converted to the corresponding type - returns an errors.InternalError.
+func GetPipelineSnapshot(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (*pb.GetPipelineSnapshotResponse, error) {
+	values, err := cacheService.GetValues(ctx, key, pipelineSnapshotSubKeys)
+	if err != nil {
+		logger.Errorf("%s: GetPipelineSnapshot(): cache.GetValues: error: %s", key, err.Error())
+		return nil, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.Status))
+	}
+	if _, ok := values[cache.Status]; !ok {
+		logger.Errorf("%s: GetPipelineSnapshot(): status doesn't exist in cache", key)
+		return nil, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.Status))
+	}
+	snapshot := &pb.GetPipelineSnapshotResponse{}
+	for subKey, value := range values {
+		converted := false
+		switch subKey {
+		case cache.Status:
+			snapshot.Status, converted = value.(pb.Status)
+		case cache.RunOutput:
+			snapshot.RunOutput, converted = value.(string)
+		case cache.RunError:
+			snapshot.RunError, converted = value.(string)
+		case cache.CompileOutput:
+			snapshot.CompileOutput, converted = value.(string)
+		case cache.ValidationOutput:
+			snapshot.ValidationOutput, converted = value.(string)
+		case cache.CompileErrors:
+			snapshot.CompileErrors, converted = value.([]*pb.CompileError)
+		case cache.RunOutputIndex:
+			var index int
+			index, converted = value.(int)
+			snapshot.RunOutputIndex = int32(index)
+		case cache.ErrorCategory:
+			snapshot.ErrorCategory, converted = value.(pb.ErrorCategory)
+		}
+		if !converted {
+			logger.Errorf("%s: couldn't convert value of %s: %s", key, subKey, value)
+			return nil, errors.InternalError(errorTitle, "Value from cache by subKey %s couldn't be converted: %s", string(subKey), value)
+		}
+	}
+	return snapshot, nil
+}
+
+// GetCompileErrors gets list of compile errors from cache by key.
+// In case key doesn't exist in cache or the code hasn't been compiled yet - returns an errors.NotFoundError.
+// In case value from cache couldn't be converted to list of playground.CompileError - returns an errors.InternalError.
+func GetCompileErrors(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) ([]*pb.CompileError, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.CompileErrors)
+	if err != nil {
+		logger.Errorf("%s: GetCompileErrors(): cache.GetValue: error: %s", key, err.Error())
+		return nil, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.CompileErrors))
+	}
+	compileErrors, converted := value.([]*pb.CompileError)
+	if !converted {
+		logger.Errorf("%s: couldn't convert value to list of compile errors: %s", key, value)
+		return nil, errors.InternalError(errorTitle, "Value from cache couldn't be converted to list of compile errors: %s", value)
+	}
+	return compileErrors, nil
+}
+
+// GetWarnings gets list of warnings about the code which don't fail the code processing from cache by key,
+//	i.e. the warning about the code which doesn't construct a Beam pipeline.
+// In case key doesn't exist in cache or the code hasn't been validated yet - returns an errors.NotFoundError.
+// In case value from cache couldn't be converted to list of strings - returns an errors.InternalError.
+func GetWarnings(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) ([]string, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.Warnings)
+	if err != nil {
+		logger.Errorf("%s: GetWarnings(): cache.GetValue: error: %s", key, err.Error())
+		return nil, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.Warnings))
+	}
+	warnings, converted := value.([]string)
+	if !converted {
+		logger.Errorf("%s: couldn't convert value to list of warnings: %s", key, value)
+		return nil, errors.InternalError(errorTitle, "Value from cache couldn't be converted to list of warnings: %s", value)
+	}
+	return warnings, nil
+}
+
+// GetCompileWarnings gets list of compile warnings from cache by key.
+// The warnings are kept for the successfully compiled code as well as for the code which isn't compiled.
+// In case key doesn't exist in cache or the code hasn't been compiled yet - returns an errors.NotFoundError.
+// In case value from cache couldn't be converted to list of playground.CompileError - returns an errors.InternalError.
+func GetCompileWarnings(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) ([]*pb.CompileError, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.CompileWarnings)
+	if err != nil {
+		logger.Errorf("%s: GetCompileWarnings(): cache.GetValue: error: %s", key, err.Error())
+		return nil, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.CompileWarnings))
+	}
+	compileWarnings, converted := value.([]*pb.CompileError)
+	if !converted {
+		logger.Errorf("%s: couldn't convert value to list of compile warnings: %s", key, value)
+		return nil, errors.InternalError(errorTitle, "Value from cache couldn't be converted to list of compile warnings: %s", value)
+	}
+	return compileWarnings, nil
+}
+
+// GetTestResults gets list of the results of the unit tests from cache by key.
+// In case key doesn't exist in cache or the code isn't a unit test or hasn't been run yet - returns an errors.NotFoundError.
+// In case value from cache couldn't be converted to list of playground.TestCase - returns an errors.InternalError.
+func GetTestResults(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) ([]*pb.TestCase, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.TestResults)
+	if err != nil {
+		logger.Errorf("%s: GetTestResults(): cache.GetValue: error: %s", key, err.Error())
+		return nil, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.TestResults))
+	}
+	testResults, converted := value.([]*pb.TestCase)
+	if !converted {
+		logger.Errorf("%s: couldn't convert value to list of test results: %s", key, value)
+		return nil, errors.InternalError(errorTitle, "Value from cache couldn't be converted to list of test results: %s", value)
+	}
+	return testResults, nil
+}
+
+// GetPipelineMetrics gets list of the metrics of the executed pipeline from cache by key.
+// In case key doesn't exist in cache or the metrics aren't collected for the pipeline - returns an errors.NotFoundError.
+// In case value from cache couldn't be converted to list of playground.PipelineMetric - returns an errors.InternalError.
+func GetPipelineMetrics(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) ([]*pb.PipelineMetric, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.PipelineMetrics)
+	if err != nil {
+		logger.Errorf("%s: GetPipelineMetrics(): cache.GetValue: error: %s", key, err.Error())
+		return nil, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.PipelineMetrics))
+	}
+	pipelineMetrics, converted := value.([]*pb.PipelineMetric)
+	if !converted {
+		logger.Errorf("%s: couldn't convert value to list of pipeline metrics: %s", key, value)
+		return nil, errors.InternalError(errorTitle, "Value from cache couldn't be converted to list of pipeline metrics: %s", value)
+	}
+	return pipelineMetrics, nil
+}
+
+// GetRunExitCode gets exit code of the executed code from cache by key.
+// In case key doesn't exist in cache or the code hasn't exited yet - returns an errors.NotFoundError.
+// In case value from cache couldn't be converted to int - returns an errors.InternalError.
+func GetRunExitCode(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (int, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.RunExitCode)
+	if err != nil {
+		logger.Errorf("%s: GetRunExitCode(): cache.GetValue: error: %s", key, err.Error())
+		return 0, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.RunExitCode))
+	}
+	exitCode, converted := value.(int)
+	if !converted {
+		logger.Errorf("%s: couldn't convert value to int: %s", key, value)
+		return 0, errors.InternalError(errorTitle, "Value from cache couldn't be converted to int: %s", value)
+	}
+	return exitCode, nil
+}
+
+// GetErrorCategory gets category of the error of the failed code processing from cache by key.
+// In case key doesn't exist in cache or the code processing isn't failed - returns an errors.NotFoundError.
+// In case value from cache couldn't be converted to playground.ErrorCategory - returns an errors.InternalError.
+func GetErrorCategory(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (pb.ErrorCategory, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.ErrorCategory)
+	if err != nil {
+		logger.Errorf("%s: GetErrorCategory(): cache.GetValue: error: %s", key, err.Error())
+		return pb.ErrorCategory_ERROR_CATEGORY_UNSPECIFIED, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.ErrorCategory))
+	}
+	category, converted := value.(pb.ErrorCategory)
+	if !converted {
+		logger.Errorf("%s: couldn't convert value to error category: %s", key, value)
+		return pb.ErrorCategory_ERROR_CATEGORY_UNSPECIFIED, errors.InternalError(errorTitle, "Value from cache couldn't be converted to error category: %s", value)
+	}
+	return category, nil
+}
+
+// GetCompileTime gets duration of the compile step from cache by key.
+// In case key doesn't exist in cache or the code hasn't been compiled - returns an errors.NotFoundError.
+// In case value from cache couldn't be converted to time.Duration - returns an errors.InternalError.
+func GetCompileTime(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (time.Duration, error) {
+	return getDuration(ctx, cacheService, key, cache.CompileTime, errorTitle)
+}
+
+// GetRunTime gets duration of the run step from cache by key.
+// In case key doesn't exist in cache or the code hasn't been run - returns an errors.NotFoundError.
+// In case value from cache couldn't be converted to time.Duration - returns an errors.InternalError.
+func GetRunTime(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (time.Duration, error) {
+	return getDuration(ctx, cacheService, key, cache.RunTime, errorTitle)
+}
+
+// getDuration gets duration value from cache by key and subKey
+func getDuration(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, errorTitle string) (time.Duration, error) {
+	value, err := cacheService.GetValue(ctx, key, subKey)
+	if err != nil {
+		logger.Errorf("%s: getDuration(): cache.GetValue: error: %s", key, err.Error())
+		return 0, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey))
+	}
+	duration, converted := value.(time.Duration)
+	if !converted {
+		logger.Errorf("%s: couldn't convert value to time.Duration: %s", key, value)
+		return 0, errors.InternalError(errorTitle, "Value from cache couldn't be converted to time.Duration: %s", value)
+	}
+	return duration, nil
+}
+
+// GetGraph gets graph of the pipeline in DOT format from cache by key.
+// In case key doesn't exist in cache or the graph hasn't been saved for the pipeline - returns an errors.NotFoundError.
+// In case value from cache couldn't be converted to string - returns an errors.InternalError.
+func GetGraph(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (string, error) {
+	return GetProcessingOutput(ctx, cacheService, key, cache.Graph, errorTitle)
+}
+
+// GetRunCommand gets command line of the run step from cache by key.
+// In case key doesn't exist in cache or the code hasn't been run yet - returns an errors.NotFoundError.
+// In case value from cache couldn't be converted to string - returns an errors.InternalError.
+func GetRunCommand(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (string, error) {
+	return GetProcessingOutput(ctx, cacheService, key, cache.RunCommand, errorTitle)
+}
+
+// GetSourcePipelineId gets id of the finished pipeline whose code is run again by the pipeline by key (see RerunPipeline) from cache.
+// In case key doesn't exist in cache or the pipeline isn't a rerun of another pipeline - returns an errors.NotFoundError.
+// In case value from cache couldn't be converted to uuid.UUID - returns an errors.InternalError.
+func GetSourcePipelineId(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (uuid.UUID, error) {
+	value, err := GetProcessingOutput(ctx, cacheService, key, cache.SourcePipelineId, errorTitle)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	sourcePipelineId, err := uuid.Parse(value)
+	if err != nil {
+		logger.Errorf("%s: couldn't convert value to uuid: %s", key, value)
+		return uuid.Nil, errors.InternalError(errorTitle, "Value from cache couldn't be converted to uuid: %s", value)
+	}
+	return sourcePipelineId, nil
+}
+
+// GetLastIndex gets last index for run output or logs from cache by key.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key and subKey couldn't be converted to int - returns an errors.InternalError.
+func GetLastIndex(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, errorTitle string) (int, error) {
+	value, err := cacheService.GetValue(ctx, key, subKey)
+	if err != nil {
+		logger.Errorf("%s: GetLastIndex(): cache.GetValue: error: %s", key, err.Error())
+		return 0, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey))
+	}
+	intValue, converted := value.(int)
+	if !converted {
+		logger.Errorf("%s: couldn't convert value to int: %s", key, value)
+		return 0, errors.InternalError(errorTitle, "Value from cache couldn't be converted to int: %s", value)
+	}
+	return intValue, nil
+}
+
+// outputIndexLocks serializes GetNewOutput for the same pipeline, so the index of the received output is read and moved
+//	by one reader at a time and the concurrent readers don't skip or repeat the output
+var outputIndexLocks = newPipelineLocks()
+
+// GetNewOutput gets the part of the output from cache by key and outputSubKey which hasn't been received yet.
+// indexSubKey is used to keep the index of the output which has been already received. This index is moved to the end
+//	of the output after each call, so the same part of the output isn't returned twice.
+// In case key, outputSubKey or indexSubKey doesn't exist in cache - returns an errors.NotFoundError.
+// In case the index couldn't be updated - returns an errors.InternalError.
+func GetNewOutput(ctx context.Context, cacheService cache.Cache, key uuid.UUID, outputSubKey, indexSubKey cache.SubKey, errorTitle string) (string, error) {
+	unlock := outputIndexLocks.lock(key)
+	defer unlock()
+	lastIndex, err := GetLastIndex(ctx, cacheService, key, indexSubKey, errorTitle)
+	if err != nil {
+		return "", err
+	}
+	output, err := GetProcessingOutput(ctx, cacheService, key, outputSubKey, errorTitle)
+	if err != nil {
+		return "", err
+	}
+	newOutput := ""
+	if len(output) > lastIndex {
+		newOutput = output[lastIndex:]
+		// the index is set to the end of the read output rather than moved by the length of the new output,
+		//	so it never passes the end of the output
+		if err := cacheService.SetValue(ctx, key, indexSubKey, len(output)); err != nil {
+			logger.Errorf("%s: GetNewOutput(): cache.SetValue: error: %s", key, err.Error())
+			return "", errors.InternalError(errorTitle, "Error during set value to cache: %s", err.Error())
+		}
+	}
+	return newOutput, nil
+}
+
+// pipelineLocks keeps a lock per pipeline. The lock of the pipeline is removed as soon as it isn't held or waited for.
+type pipelineLocks struct {
+	mu    sync.Mutex
+	locks map[uuid.UUID]*pipelineLock
+}
+
+// pipelineLock is the lock of the pipeline with the number of its holders and waiters
+type pipelineLock struct {
+	sync.Mutex
+	refs int
+}
+
+func newPipelineLocks() *pipelineLocks {
+	return &pipelineLocks{locks: make(map[uuid.UUID]*pipelineLock)}
+}
+
+// lock locks the pipeline by key and returns the function which unlocks it
+func (pl *pipelineLocks) lock(key uuid.UUID) func() {
+	pl.mu.Lock()
+	l, ok := pl.locks[key]
+	if !ok {
+		l = &pipelineLock{}
+		pl.locks[key] = l
+	}
+	l.refs++
+	pl.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		pl.mu.Lock()
+		defer pl.mu.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(pl.locks, key)
+		}
+	}
+}
+
+// WatchRunOutput watches the code processing by key and calls send with its status and the part of the run output
+//	which hasn't been received yet each time the status is changed or the new output is received.
+// Unlike GetNewOutput, the offset of the received output is kept by the call, so several clients could watch
+//	the same output independently and each of them receives the whole output.
+// Cache is checked each pauseDuration until the code processing reaches one of the final statuses
+//	(send is called for the final status with the rest of the output) or ctx is done.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case send returns an error - stops watching and returns the error.
+func WatchRunOutput(ctx context.Context, cacheService cache.Cache, key uuid.UUID, pauseDuration time.Duration, errorTitle string, send func(status pb.Status, newOutput string) error) error {
+	return watchOutput(ctx, cacheService, key, cache.RunOutput, IsFinalStatus, pauseDuration, errorTitle, send)
+}
+
+// WatchCompileOutput watches the code processing by key and calls send with its status and the part of the error output
+//	of the compiler which is streamed while the code is compiling and hasn't been received yet,
+//	each time the status is changed or the new output is received.
+// Cache is checked each pauseDuration until the compile step is finished (send is called for the status after the compile step,
+//	the final compile output could be received by GetProcessingOutput) or ctx is done.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case send returns an error - stops watching and returns the error.
+func WatchCompileOutput(ctx context.Context, cacheService cache.Cache, key uuid.UUID, pauseDuration time.Duration, errorTitle string, send func(status pb.Status, newOutput string) error) error {
+	return watchOutput(ctx, cacheService, key, cache.CompileOutputStream, isCompileFinishedStatus, pauseDuration, errorTitle, send)
+}
+
+// watchOutput watches the code processing by key and calls send with its status and the part of the output by outputSubKey
+//	which follows the offset of the received output each time the status is changed or the new output is received
+//	until isFinished returns true for the status or ctx is done.
+// The offset is local, so the output received by other clients doesn't affect the output which is sent.
+func watchOutput(ctx context.Context, cacheService cache.Cache, key uuid.UUID, outputSubKey cache.SubKey, isFinished func(status pb.Status) bool, pauseDuration time.Duration, errorTitle string, send func(status pb.Status, newOutput string) error) error {
+	ticker := time.NewTicker(pauseDuration)
+	defer ticker.Stop()
+	prevStatus := pb.Status_STATUS_UNSPECIFIED
+	offset := 0
+	for {
+		// status should be received before the output to send all output of the finished code processing
+		status, err := GetProcessingStatus(ctx, cacheService, key, errorTitle)
+		if err != nil {
+			return err
+		}
+		finished := isFinished(status)
+		// the output doesn't exist in cache until its step is started
+		newOutput := ""
+		if output, err := GetProcessingOutput(ctx, cacheService, key, outputSubKey, errorTitle); err == nil {
+			// the output is shorter than the received one if it is written again (e.g. the step is retried)
+			if offset > len(output) {
+				offset = 0
+			}
+			newOutput = output[offset:]
+			offset = len(output)
+		}
+		if status != prevStatus || newOutput != "" {
+			if err := send(status, newOutput); err != nil {
+				return err
+			}
+			prevStatus = status
+		}
+		if finished {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// isCompileFinishedStatus checks if the compile step of the code processing with the status is finished or skipped
+func isCompileFinishedStatus(status pb.Status) bool {
+	switch status {
+	case pb.Status_STATUS_UNSPECIFIED, pb.Status_STATUS_PREPARING, pb.Status_STATUS_VALIDATING, pb.Status_STATUS_QUEUED, pb.Status_STATUS_COMPILING:
+		return false
 	}
+	return true
+}
 
-	// Run
-	if sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_JAVA {
-		executor, err = setJavaExecutableFile(lc, pipelineId, cacheService, ctxWithTimeout, executorBuilder, appEnv.WorkingDir())
-		if err != nil {
-			return
-		}
+// IsFinalStatus checks if the code processing with the status is finished, so the status and output won't be changed
+func IsFinalStatus(status pb.Status) bool {
+	switch status {
+	case pb.Status_STATUS_VALIDATION_ERROR, pb.Status_STATUS_PREPARATION_ERROR, pb.Status_STATUS_COMPILE_ERROR,
+		pb.Status_STATUS_FINISHED, pb.Status_STATUS_COMPILE_FINISHED, pb.Status_STATUS_RUN_ERROR, pb.Status_STATUS_ERROR, pb.Status_STATUS_RUN_TIMEOUT,
+		pb.Status_STATUS_CANCELED, pb.Status_STATUS_COMPILE_TIMEOUT:
+		return true
 	}
-	runCmd := getExecuteCmd(&validationResults, &executor, ctxWithTimeout)
-	var runError bytes.Buffer
-	runOutput := streaming.RunOutputWriter{Ctx: ctxWithTimeout, CacheService: cacheService, PipelineId: pipelineId}
-	go readLogFile(ctxWithTimeout, cacheService, lc.GetAbsoluteLogFilePath(), pipelineId, stopReadLogsChannel, finishReadLogsChannel)
-	runCmdWithOutput(runCmd, &runOutput, &runError, successChannel, errorChannel)
+	return false
+}
 
-	ok, err = processStep(ctxWithTimeout, pipelineId, cacheService, cancelChannel, successChannel)
+// runCmdWithOutput runs command with keeping stdOut and writing stdErr line by line.
+// Each line of the error output is written to stdError as soon as it is printed by the command,
+//	so the errors of the command (i.e. compile errors) could be received before the command is finished.
+// The command is started before the method returns, so it could be terminated while it is running.
+func runCmdWithOutput(cmd *exec.Cmd, stdOutput io.Writer, stdError io.Writer, successChannel chan bool, errorChannel chan error) {
+	cmd.Stdout = stdOutput
+	stdErrPipe, err := cmd.StderrPipe()
 	if err != nil {
+		errorChannel <- err
+		successChannel <- false
 		return
 	}
-	if !ok {
-		_ = processRunError(ctxWithTimeout, errorChannel, runError.Bytes(), pipelineId, cacheService, stopReadLogsChannel, finishReadLogsChannel)
+	if err := cmd.Start(); err != nil {
+		errorChannel <- err
+		successChannel <- false
 		return
 	}
-	_ = processRunSuccess(ctxWithTimeout, pipelineId, cacheService, stopReadLogsChannel, finishReadLogsChannel)
+	readCmdWithStreamingOutput(cmd.Wait, stdErrPipe, stdError, successChannel, errorChannel)
 }
 
-// getExecuteCmd return cmd instance based on the code type: unit test or example code
-func getExecuteCmd(valRes *sync.Map, executor *executors.Executor, ctxWithTimeout context.Context) *exec.Cmd {
-	isUnitTest, ok := valRes.Load(validators.UnitTestValidatorName)
-	runType := executors.Run
-	if ok && isUnitTest.(bool) {
-		runType = executors.Test
+// runWithBackend runs the code of spec by backend with keeping stdErr and writing stdOut line by line.
+// Each line of the output is written to stdOutput as soon as it is printed by the code,
+//	so the output of the code could be received before the code is finished.
+// If combinedLogs isn't nil, stdOut and stdErr of the code are also written merged into combinedLogs
+//	as described for readCmdWithCombinedLogs.
+// The exit code of the executed code is set to exitCode before the result is sent to successChannel.
+// The code is started before the method returns, so it could be stopped while it is running.
+// Returns a function which stops the code gracefully (it waits for the result in successChannel during gracePeriod
+//	and kills the code if it is still running) and a function which kills the code immediately.
+func runWithBackend(ctx context.Context, backend executors.ExecutionBackend, spec executors.ExecutionSpec, stdOutput io.Writer, stdError *bytes.Buffer, combinedLogs *bytes.Buffer, exitCode *int, gracePeriod time.Duration, successChannel chan bool, errorChannel chan error) (func(), func()) {
+	executionCtx, killExecution := context.WithCancel(ctx)
+	stopChannel := make(chan struct{})
+	spec.Stop = stopChannel
+	spec.StopGracePeriod = gracePeriod
+	stdOutPipe, stdOutWriter := io.Pipe()
+	spec.Stdout = stdOutWriter
+	spec.Stderr = stdError
+	stdErrPipe, stdErrWriter := io.Pipe()
+	if combinedLogs != nil {
+		spec.Stderr = stdErrWriter
+	}
+	executionResult := make(chan error, 1)
+	go func() {
+		defer killExecution()
+		result, err := backend.Run(executionCtx, spec)
+		*exitCode = result.ExitCode
+		_ = stdOutWriter.Close()
+		_ = stdErrWriter.Close()
+		executionResult <- err
+	}()
+	wait := func() error {
+		return <-executionResult
 	}
-	cmdReflect := reflect.ValueOf(executor).MethodByName(string(runType)).Call([]reflect.Value{reflect.ValueOf(ctxWithTimeout)})
-	return cmdReflect[0].Interface().(*exec.Cmd)
+	if combinedLogs != nil {
+		readCmdWithCombinedLogs(wait, stdOutPipe, stdErrPipe, stdOutput, stdError, combinedLogs, successChannel, errorChannel)
+	} else {
+		readCmdWithStreamingOutput(wait, stdOutPipe, stdOutput, successChannel, errorChannel)
+	}
+	stop := func() {
+		close(stopChannel)
+		timer := time.NewTimer(gracePeriod)
+		defer timer.Stop()
+		select {
+		case <-successChannel:
+		case <-ctx.Done():
+		case <-timer.C:
+			logger.FromContext(ctx).Warnf("the code is still running after the cancel grace period %s, killing it", gracePeriod)
+			killExecution()
+		}
+	}
+	return stop, killExecution
 }
 
-// setJavaExecutableFile sets executable file name to runner (JAVA class name is known after compilation step)
-func setJavaExecutableFile(lc *fs_tool.LifeCycle, id uuid.UUID, service cache.Cache, ctx context.Context, executorBuilder *executors.ExecutorBuilder, dir string) (executors.Executor, error) {
-	className, err := lc.ExecutableName(id, dir)
-	if err != nil {
-		if err = processSetupError(err, id, service, ctx); err != nil {
-			return executorBuilder.Build(), err
+// readCmdWithStreamingOutput reads stdOut pipe of the started command line by line and writes each line to stdOutput.
+// wait is called to wait for the command after the pipe is read.
+func readCmdWithStreamingOutput(wait func() error, stdOutPipe io.Reader, stdOutput io.Writer, successChannel chan bool, errorChannel chan error) {
+	go func(successChannel chan bool, errChannel chan error) {
+		reader := bufio.NewReader(stdOutPipe)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				if _, err := stdOutput.Write(line); err != nil {
+					logger.Errorf("readCmdWithStreamingOutput(): error during write output: %s\n", err.Error())
+				}
+			}
+			if err != nil {
+				break
+			}
 		}
-	}
-	return executorBuilder.
-		WithExecutableFileName(className).
-		Build(), nil
+		if err := wait(); err != nil {
+			errChannel <- err
+			successChannel <- false
+		} else {
+			successChannel <- true
+		}
+	}(successChannel, errorChannel)
 }
 
-// processSetupError processes errors during the setting up an executor builder
-func processSetupError(err error, pipelineId uuid.UUID, cacheService cache.Cache, ctxWithTimeout context.Context) error {
-	logger.Errorf("%s: error during setup builder: %s\n", pipelineId, err.Error())
-	if err = utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.Status, pb.Status_STATUS_ERROR); err != nil {
-		return err
-	}
-	return nil
+// combinedLogLine is a line of the output of the command with the tag of the pipe it was read from
+type combinedLogLine struct {
+	tag      string
+	line     []byte
+	readTime time.Time
 }
 
-// GetProcessingOutput gets processing output value from cache by key and subKey.
-// In case key doesn't exist in cache - returns an errors.NotFoundError.
-// In case subKey doesn't exist in cache for the key - returns an errors.NotFoundError.
-// In case value from cache by key and subKey couldn't be converted to string - returns an errors.InternalError.
-func GetProcessingOutput(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, errorTitle string) (string, error) {
-	value, err := cacheService.GetValue(ctx, key, subKey)
-	if err != nil {
-		logger.Errorf("%s: GetStringValueFromCache(): cache.GetValue: error: %s", key, err.Error())
-		return "", errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey))
-	}
-	stringValue, converted := value.(string)
-	if !converted {
-		logger.Errorf("%s: couldn't convert value to string: %s", key, value)
-		return "", errors.InternalError(errorTitle, "Value from cache couldn't be converted to string: %s", value)
+// readCmdWithCombinedLogs reads stdOut and stdErr pipes of the started command with keeping stdErr,
+//	writing stdOut line by line to stdOutput and writing stdOut and stdErr merged into combinedLogs.
+// Both pipes are read concurrently and their lines are serialized through a channel,
+//	so lines of the combined log are kept in the order they were read from the pipes.
+// Each line of the combined log is prefixed with the time it was read and tagged with [out] or [err].
+// wait is called to wait for the command after both pipes are read.
+func readCmdWithCombinedLogs(wait func() error, stdOutPipe, stdErrPipe io.Reader, stdOutput io.Writer, stdError *bytes.Buffer, combinedLogs *bytes.Buffer, successChannel chan bool, errorChannel chan error) {
+	lines := make(chan combinedLogLine)
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go readPipeLines(stdOutPipe, stdOutTag, lines, &readers)
+	go readPipeLines(stdErrPipe, stdErrTag, lines, &readers)
+	go func() {
+		readers.Wait()
+		close(lines)
+	}()
+	go func(successChannel chan bool, errChannel chan error) {
+		for line := range lines {
+			if line.tag == stdOutTag {
+				if _, err := stdOutput.Write(line.line); err != nil {
+					logger.Errorf("readCmdWithCombinedLogs(): error during write output: %s\n", err.Error())
+				}
+			} else {
+				stdError.Write(line.line)
+			}
+			combinedLogs.WriteString(fmt.Sprintf("%s %s %s", line.readTime.Format(combinedLogsTimeFormat), line.tag, line.line))
+			if !bytes.HasSuffix(line.line, []byte("\n")) {
+				combinedLogs.WriteString("\n")
+			}
+		}
+		if err := wait(); err != nil {
+			errChannel <- err
+			successChannel <- false
+		} else {
+			successChannel <- true
+		}
+	}(successChannel, errorChannel)
+}
+
+// readPipeLines reads pipe line by line and sends each line with the tag to lines until the pipe is closed
+func readPipeLines(pipe io.Reader, tag string, lines chan combinedLogLine, readers *sync.WaitGroup) {
+	defer readers.Done()
+	reader := bufio.NewReader(pipe)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			lines <- combinedLogLine{tag: tag, line: line, readTime: time.Now()}
+		}
+		if err != nil {
+			return
+		}
 	}
-	return stringValue, nil
 }
 
-// GetProcessingStatus gets processing status from cache by key.
-// In case key doesn't exist in cache - returns an errors.NotFoundError.
-// In case value from cache by key and subKey couldn't be converted to playground.Status - returns an errors.InternalError.
-func GetProcessingStatus(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (pb.Status, error) {
-	value, err := cacheService.GetValue(ctx, key, cache.Status)
-	if err != nil {
-		logger.Errorf("%s: GetStringValueFromCache(): cache.GetValue: error: %s", key, err.Error())
-		return pb.Status_STATUS_UNSPECIFIED, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.Status))
+// terminateCmd stops cmd of the canceled step. At first sends SIGTERM, so the executed code could finish gracefully,
+//	and waits for the result of the command in successChannel during gracePeriod.
+// If the command is still alive after gracePeriod, kills it by SIGKILL.
+// If the command is started in its own process group, the signals are sent to all processes of the group.
+// If the command hasn't been started or has already finished, does nothing.
+func terminateCmd(ctx context.Context, cmd *exec.Cmd, successChannel chan bool, gracePeriod time.Duration) {
+	if cmd.Process == nil {
+		return
 	}
-	statusValue, converted := value.(pb.Status)
-	if !converted {
-		logger.Errorf("%s: couldn't convert value to correct status enum: %s", key, value)
-		return pb.Status_STATUS_UNSPECIFIED, errors.InternalError(errorTitle, "Value from cache couldn't be converted to correct status enum: %s", value)
+	if err := executors.SignalCmd(cmd, syscall.SIGTERM); err != nil {
+		return
+	}
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+	select {
+	case <-successChannel:
+	case <-ctx.Done():
+	case <-timer.C:
+		logger.FromContext(ctx).Warnf("command is still alive after the cancel grace period %s, killing it", gracePeriod)
+		_ = executors.SignalCmd(cmd, syscall.SIGKILL)
 	}
-	return statusValue, nil
 }
 
-// GetLastIndex gets last index for run output or logs from cache by key.
-// In case key doesn't exist in cache - returns an errors.NotFoundError.
-// In case value from cache by key and subKey couldn't be converted to int - returns an errors.InternalError.
-func GetLastIndex(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, errorTitle string) (int, error) {
-	value, err := cacheService.GetValue(ctx, key, subKey)
-	if err != nil {
-		logger.Errorf("%s: GetLastIndex(): cache.GetValue: error: %s", key, err.Error())
-		return 0, errors.NotFoundError(errorTitle, "Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey))
+// retryTransientFailure checks if the failed step should be run again and waits for the backoff before the retry.
+// The step is retried only if it is failed because of a transient failure (see isTransientFailure)
+//	and it has been retried less than the pipeline start retries times (attempt is the number of done retries).
+// The backoff is doubled after each retry. Timeout and cancellation of the code processing are respected while waiting:
+//	sets corresponding status to the cache and returns error.
+// If the step isn't retried, the error of the step is kept in errorChannel to be processed as usual.
+func retryTransientFailure(ctx context.Context, phase string, pipelineId uuid.UUID, cacheService cache.Cache, cancelChannel chan bool, errorChannel chan error, errorOutput []byte, attempt int, appEnv *environment.ApplicationEnvs) (bool, error) {
+	if attempt >= appEnv.PipelineStartRetries() {
+		return false, nil
 	}
-	intValue, converted := value.(int)
-	if !converted {
-		logger.Errorf("%s: couldn't convert value to int: %s", key, value)
-		return 0, errors.InternalError(errorTitle, "Value from cache couldn't be converted to int: %s", value)
+	err := <-errorChannel
+	if !isTransientFailure(err, errorOutput) {
+		errorChannel <- err
+		return false, nil
 	}
-	return intValue, nil
-}
+	backoff := appEnv.PipelineRetryBackoff() << attempt
+	phaseLogger(ctx, phase).Warnf("transient failure: %s, output: %s, retry in %s", err.Error(), errorOutput, backoff)
 
-// runCmdWithOutput runs command with keeping stdOut and stdErr
-func runCmdWithOutput(cmd *exec.Cmd, stdOutput io.Writer, stdError *bytes.Buffer, successChannel chan bool, errorChannel chan error) {
-	cmd.Stdout = stdOutput
-	cmd.Stderr = stdError
-	go func(cmd *exec.Cmd, successChannel chan bool, errChannel chan error) {
-		err := cmd.Run()
-		if err != nil {
-			errChannel <- err
-			successChannel <- false
-		} else {
-			successChannel <- true
-		}
-	}(cmd, successChannel, errorChannel)
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		_ = finishByContext(ctx, pipelineId, cacheService)
+		return false, errContextDone
+	case <-cancelChannel:
+		_ = processCancel(ctx, cacheService, pipelineId)
+		return false, errCanceled
+	case <-timer.C:
+		return true, nil
+	}
 }
 
 // processStep processes each executor's step with cancel and timeout checks.
 // If finishes by canceling, timeout or error - returns error.
 // If finishes successfully with no error during step processing - returns true.
 // If finishes successfully but with some error during step processing - returns false.
-func processStep(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, cancelChannel, successChannel chan bool) (bool, error) {
+// If the step is canceled and stopStep isn't nil, stopStep is called before the status is set,
+//	so the step is stopped and its output is saved before the client receives playground.Status_STATUS_CANCELED.
+func processStep(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, cancelChannel, successChannel chan bool, stopStep func()) (bool, error) {
 	select {
 	case <-ctx.Done():
-		_ = finishByTimeout(ctx, pipelineId, cacheService)
-		return false, fmt.Errorf("%s: context was done", pipelineId)
+		_ = finishByContext(ctx, pipelineId, cacheService)
+		return false, errContextDone
 	case <-cancelChannel:
+		if stopStep != nil {
+			stopStep()
+		}
 		_ = processCancel(ctx, cacheService, pipelineId)
-		return false, fmt.Errorf("%s: code processing was canceled", pipelineId)
+		return false, errCanceled
 	case ok := <-successChannel:
+		// the command of the step could be killed because the context is done before the context is checked
+		if !ok && ctx.Err() != nil {
+			_ = finishByContext(ctx, pipelineId, cacheService)
+			return false, errContextDone
+		}
 		return ok, nil
 	}
 }
 
-// cancelCheck checks cancel flag for code processing.
-// If cancel flag doesn't exist in cache continue working.
+// waitForWorkerSlot takes a slot of the worker pool to compile and run the code.
+// If there is no free slot sets playground.Status_STATUS_QUEUED as cache.Status and waits until:
+//	- a slot is released. Sets nextStatus (the status of the step after the queue) as cache.Status and returns.
+//	- the context is done. Sets playground.Status_STATUS_RUN_TIMEOUT (or playground.Status_STATUS_CANCELED
+//	if the context is canceled) as cache.Status and returns error.
+//	- the code processing is canceled. Sets playground.Status_STATUS_CANCELED as cache.Status and returns error.
+// In case of error the slot isn't taken.
+func waitForWorkerSlot(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, workerPool *WorkerPool, cancelChannel chan bool, nextStatus pb.Status) error {
+	if workerPool.tryAcquire() {
+		return nil
+	}
+	phaseLogger(ctx, queuePhase).Infof("waits for a free slot to compile and run the code")
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, pb.Status_STATUS_QUEUED); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		_ = finishByContext(ctx, pipelineId, cacheService)
+		return errContextDone
+	case <-cancelChannel:
+		_ = processCancel(ctx, cacheService, pipelineId)
+		return errCanceled
+	case workerPool.slots <- struct{}{}:
+	}
+
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, nextStatus); err != nil {
+		workerPool.release()
+		return err
+	}
+	return nil
+}
+
+// cancelCheck checks cancel flags for code processing.
+// If cancel flags don't exist in cache or have unexpected types continue working.
 // If context is done it means that the code processing was finished (successfully/with error/timeout). Return.
-// If cancel flag exists, and it is true it means that the code processing was canceled. Set true to cancelChannel and return.
-func cancelCheck(ctx context.Context, pipelineId uuid.UUID, cancelChannel chan bool, cacheService cache.Cache) {
+// If cache.Canceled flag is true it means that the whole code processing was canceled.
+//	Set true to compileCancelChannel and runCancelChannel and return.
+// If cache.CanceledPhase is playground.CancelPhase_CANCEL_PHASE_COMPILE it means that the code processing was canceled
+//	before the run step. Set true to compileCancelChannel and return.
+// If cache.CanceledPhase is playground.CancelPhase_CANCEL_PHASE_RUN it means that only the run step was canceled.
+//	Set true to runCancelChannel and continue working, so the whole code processing still could be canceled.
+func cancelCheck(ctx context.Context, pipelineId uuid.UUID, compileCancelChannel, runCancelChannel chan bool, cacheService cache.Cache) {
 	ticker := time.NewTicker(pauseDuration)
+	defer ticker.Stop()
+	runCanceled := false
 	for {
 		select {
 		case <-ctx.Done():
-			ticker.Stop()
 			return
 		case <-ticker.C:
-			cancel, err := cacheService.GetValue(ctx, pipelineId, cache.Canceled)
+			if cancel, err := cacheService.GetValue(ctx, pipelineId, cache.Canceled); err == nil {
+				if isCanceled, ok := cancel.(bool); ok && isCanceled {
+					compileCancelChannel <- true
+					if !runCanceled {
+						runCancelChannel <- true
+					}
+					return
+				}
+			}
+			value, err := cacheService.GetValue(ctx, pipelineId, cache.CanceledPhase)
 			if err != nil {
 				continue
 			}
-			if cancel.(bool) {
-				cancelChannel <- true
+			phase, ok := value.(pb.CancelPhase)
+			if !ok {
+				continue
+			}
+			switch phase {
+			case pb.CancelPhase_CANCEL_PHASE_COMPILE:
+				compileCancelChannel <- true
+				return
+			case pb.CancelPhase_CANCEL_PHASE_RUN:
+				if !runCanceled {
+					runCancelChannel <- true
+					runCanceled = true
+				}
+			}
+		}
+	}
+}
+
+// diskQuotaCheck checks the size of the folder of the pipeline each pauseDuration while the code is running.
+// If context is done it means that the run step was finished. Return.
+// If the size of the folder exceeds diskQuota megabytes, sets true to diskQuotaChannel, kills the code by kill and returns.
+func diskQuotaCheck(ctx context.Context, folderPath string, diskQuota int, kill func(), diskQuotaChannel chan bool) {
+	ticker := time.NewTicker(pauseDuration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			size, err := folderSize(folderPath)
+			if err != nil || size <= int64(diskQuota)*1024*1024 {
+				continue
 			}
+			phaseLogger(ctx, runPhase).Warnf("size of the folder of the pipeline %d bytes exceeds the disk quota %d MB, killing the code", size, diskQuota)
+			diskQuotaChannel <- true
+			kill()
 			return
 		}
 	}
 }
 
+// folderSize returns the total size of the files in the folder including its subfolders.
+// Files which are deleted while the folder is walked are skipped.
+func folderSize(folderPath string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(folderPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
 // readLogFile reads logs from the log file and keeps it to the cache.
 // If context is done it means that the code processing was finished (successfully/with error/timeout). Write last logs to the cache.
 // If <-stopReadLogsChannel it means that the code processing was finished (canceled/timeout)
@@ -343,7 +1960,7 @@ func writeLogsToCache(ctx context.Context, cacheService cache.Cache, logFilePath
 	}
 	logs, err := os.ReadFile(logFilePath)
 	if err != nil {
-		logger.Errorf("%s: writeLogsToCache(): error during read from logs file: %s", pipelineId, err.Error())
+		phaseLogger(ctx, runPhase).Errorf("error during read from logs file: %s", err.Error())
 		return err
 	}
 	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Logs, string(logs))
@@ -351,93 +1968,345 @@ func writeLogsToCache(ctx context.Context, cacheService cache.Cache, logFilePath
 
 // DeleteFolders removes all prepared folders for received LifeCycle
 func DeleteFolders(pipelineId uuid.UUID, lc *fs_tool.LifeCycle) {
-	logger.Infof("%s: DeleteFolders() ...\n", pipelineId)
-	if err := lc.DeleteFolders(); err != nil {
-		logger.Error("%s: DeleteFolders(): %s\n", pipelineId, err.Error())
+	deleteFolders(logger.NewContext(context.Background(), logger.With(logger.PipelineIdField, pipelineId)), lc)
+}
+
+// deleteFolders removes the folder of the pipeline for received LifeCycle and logs with the log entry of ctx
+func deleteFolders(ctx context.Context, lc *fs_tool.LifeCycle) {
+	log := phaseLogger(ctx, finishPhase)
+	log.Infof("DeleteFolders() ...")
+	if err := lc.Cleanup(); err != nil {
+		log.Errorf("DeleteFolders(): %s", err.Error())
+	}
+	log.Infof("DeleteFolders() complete")
+	log.Infof("complete")
+}
+
+// phaseLogger returns the log entry of ctx with the phase of the code processing
+func phaseLogger(ctx context.Context, phase string) *logger.Entry {
+	return logger.FromContext(ctx).With(logger.PhaseField, phase)
+}
+
+// detachedContext returns a background context which carries the log entry of ctx.
+// It is used to keep the results of the code processing when ctx is canceled.
+func detachedContext(ctx context.Context) context.Context {
+	return logger.NewContext(context.Background(), logger.FromContext(ctx))
+}
+
+// finishMetrics keeps the final status of the code processing as a metric.
+// If the status couldn't be received from the cache, it is kept as playground.Status_STATUS_UNSPECIFIED.
+func finishMetrics(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) {
+	status, _ := GetProcessingStatus(ctx, cacheService, pipelineId, "Process")
+	metrics.PipelineFinished(status)
+}
+
+// setExpTime sets expiration time for all cache values of the pipeline.
+// It is used when the code processing reaches one of the final statuses.
+func setExpTime(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, expTime time.Duration) {
+	if err := cacheService.SetExpTime(ctx, pipelineId, expTime); err != nil {
+		phaseLogger(ctx, finishPhase).Errorf("cache.SetExpTime(): %s", err.Error())
 	}
-	logger.Infof("%s: DeleteFolders() complete\n", pipelineId)
-	logger.Infof("%s: complete\n", pipelineId)
 }
 
 // finishByTimeout is used in case of runCode method finished by timeout
 func finishByTimeout(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache) error {
-	logger.Errorf("%s: code processing finishes because of timeout\n", pipelineId)
+	logger.FromContext(ctx).Errorf("code processing finishes because of timeout")
 
 	// set to cache pipelineId: cache.SubKey_Status: Status_STATUS_RUN_TIMEOUT
-	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, pb.Status_STATUS_RUN_TIMEOUT)
+	return setErrorStatus(ctx, cacheService, pipelineId, pb.Status_STATUS_RUN_TIMEOUT, pb.ErrorCategory_ERROR_CATEGORY_TIMEOUT)
+}
+
+// finishByContext saves the status of the code processing which is finished because the context is done:
+//	- playground.Status_STATUS_CANCELED if the context is canceled (i.e. the server is shutting down).
+//	- playground.Status_STATUS_RUN_TIMEOUT if the deadline of the context is exceeded.
+func finishByContext(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache) error {
+	if ctx.Err() == context.Canceled {
+		// the context is canceled, so the status is saved with the background context
+		return processCancel(detachedContext(ctx), cacheService, pipelineId)
+	}
+	return finishByTimeout(ctx, pipelineId, cacheService)
 }
 
 // processError processes error received during processing validation or preparation steps.
-// This method sets corresponding status to the cache.
-func processError(ctx context.Context, errorChannel chan error, pipelineId uuid.UUID, cacheService cache.Cache, errorTitle string, newStatus pb.Status) error {
+// This method sets corresponding status and category of the error to the cache.
+func processError(ctx context.Context, errorChannel chan error, pipelineId uuid.UUID, cacheService cache.Cache, errorTitle string, newStatus pb.Status, category pb.ErrorCategory) error {
 	err := <-errorChannel
-	logger.Errorf("%s: %s(): %s\n", pipelineId, errorTitle, err.Error())
+	phaseLogger(ctx, errorTitle).Errorf("%s", err.Error())
 
-	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, newStatus)
+	return setErrorStatus(ctx, cacheService, pipelineId, newStatus, category)
+}
+
+// processValidationError processes error received during processing validation step.
+// This method sets the error as validation output and corresponding status to the cache.
+func processValidationError(ctx context.Context, errorChannel chan error, pipelineId uuid.UUID, cacheService cache.Cache) error {
+	err := <-errorChannel
+	phaseLogger(ctx, validatePhase).Errorf("%s", err.Error())
+
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.ValidationOutput, err.Error()); err != nil {
+		return err
+	}
+	return setErrorStatus(ctx, cacheService, pipelineId, pb.Status_STATUS_VALIDATION_ERROR, pb.ErrorCategory_ERROR_CATEGORY_VALIDATION)
 }
 
 // processCompileError processes error received during processing compile step.
-// This method sets error output and corresponding status to the cache.
-func processCompileError(ctx context.Context, errorChannel chan error, errorOutput []byte, pipelineId uuid.UUID, cacheService cache.Cache) error {
+// This method sets error output, compile errors and warnings parsed from the error output and corresponding status to the cache.
+//	The error output is truncated to maxCompileOutputSize bytes.
+func processCompileError(ctx context.Context, errorChannel chan error, errorOutput []byte, sdk pb.Sdk, pipelineId uuid.UUID, cacheService cache.Cache, maxCompileOutputSize int) error {
+	err := <-errorChannel
+	phaseLogger(ctx, compilePhase).Errorf("err: %s, output: %s", err.Error(), errorOutput)
+
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileOutput, "error: "+err.Error()+", output: "+utils.TruncateCompileOutput(string(errorOutput), maxCompileOutputSize)); err != nil {
+		return err
+	}
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileErrors, parseCompileErrors(sdk, string(errorOutput))); err != nil {
+		return err
+	}
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileWarnings, parseCompileWarnings(sdk, string(errorOutput))); err != nil {
+		return err
+	}
+	return setErrorStatus(ctx, cacheService, pipelineId, pb.Status_STATUS_COMPILE_ERROR, pb.ErrorCategory_ERROR_CATEGORY_COMPILE)
+}
+
+// processCompileTimeout processes error received when the compile step is stopped because it exceeds the compile timeout.
+// This method sets the timeout error with the error output and corresponding status to the cache.
+func processCompileTimeout(ctx context.Context, errorChannel chan error, errorOutput []byte, compileTimeout time.Duration, pipelineId uuid.UUID, cacheService cache.Cache, maxCompileOutputSize int) error {
 	err := <-errorChannel
-	logger.Errorf("%s: Compile(): err: %s, output: %s\n", pipelineId, err.Error(), errorOutput)
+	errorMessage := fmt.Sprintf(compileTimedOutOutput, compileTimeout)
+	phaseLogger(ctx, compilePhase).Errorf("%s, err: %s", errorMessage, err.Error())
 
-	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileOutput, "error: "+err.Error()+", output: "+string(errorOutput)); err != nil {
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileOutput, "error: "+errorMessage+", output: "+utils.TruncateCompileOutput(string(errorOutput), maxCompileOutputSize)); err != nil {
 		return err
 	}
-	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, pb.Status_STATUS_COMPILE_ERROR)
+	return setErrorStatus(ctx, cacheService, pipelineId, pb.Status_STATUS_COMPILE_TIMEOUT, pb.ErrorCategory_ERROR_CATEGORY_TIMEOUT)
 }
 
 // processRunError processes error received during processing run step.
-// This method sets error output to the cache and after that sets value to channel to stop goroutine which writes logs.
+// This method sets error output, stderr output and exit code of the run step to the cache and after that sets value
+//	to channel to stop goroutine which writes logs.
+//	If the code has run out of memory, "out of memory" is set as an error instead of the process exit status.
+//	The code is considered as run out of memory if it prints the message about it (i.e. java.lang.OutOfMemoryError)
+//	or it is killed by SIGKILL (exit code 137) which is sent by the OOM killer of the kernel, since the server kills the code
+//	only if it is canceled, exceeds the disk quota or the timeout, which are processed separately.
+//	If the code has used up the CPU time limit, "cpu time limit exceeded" is set as an error
+//	and playground.Status_STATUS_RUN_TIMEOUT is set as a status instead of playground.Status_STATUS_RUN_ERROR.
+//	If the code is killed because of the disk quota (diskQuotaExceeded is true), "disk quota exceeded" is set as an error.
+//	If the code couldn't be executed because of a failure of the execution backend (i.e. the container couldn't be created),
+//	playground.Status_STATUS_ERROR is set as a status instead of playground.Status_STATUS_RUN_ERROR.
 //	After receiving a signal that goroutine was finished (read value from finishReadLogsChannel) this method
 //	sets corresponding status to the cache.
-func processRunError(ctx context.Context, errorChannel chan error, errorOutput []byte, pipelineId uuid.UUID, cacheService cache.Cache, stopReadLogsChannel, finishReadLogsChannel chan bool) error {
+func processRunError(ctx context.Context, errorChannel chan error, errorOutput []byte, exitCode int, pipelineId uuid.UUID, cacheService cache.Cache, cpuTimeLimit int, diskQuotaExceeded bool, stopReadLogsChannel, finishReadLogsChannel chan bool) error {
 	err := <-errorChannel
-	logger.Errorf("%s: Run(): err: %s, output: %s\n", pipelineId, err.Error(), errorOutput)
+	phaseLogger(ctx, runPhase).Errorf("err: %s, output: %s", err.Error(), errorOutput)
 
-	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.RunError, "error: "+err.Error()+", output: "+string(errorOutput)); err != nil {
+	status := pb.Status_STATUS_RUN_ERROR
+	category := pb.ErrorCategory_ERROR_CATEGORY_RUNTIME_EXCEPTION
+	errorMessage := err.Error()
+	if diskQuotaExceeded {
+		errorMessage = diskQuotaExceededOutput
+	} else if isOutOfMemory(errorOutput) || stderrors.Is(err, executors.ErrOutOfMemory) {
+		errorMessage = outOfMemoryOutput
+		category = pb.ErrorCategory_ERROR_CATEGORY_OOM
+	} else if stderrors.Is(err, executors.ErrBackendFailure) {
+		status = pb.Status_STATUS_ERROR
+		category = pb.ErrorCategory_ERROR_CATEGORY_INTERNAL
+	} else if isCpuTimeLimitExceeded(err, cpuTimeLimit) {
+		errorMessage = cpuTimeLimitExceededOutput
+		status = pb.Status_STATUS_RUN_TIMEOUT
+		category = pb.ErrorCategory_ERROR_CATEGORY_TIMEOUT
+	} else if exitCode == oomKilledExitCode {
+		// the hard CPU time limit is enforced by SIGKILL too, so it is checked before
+		errorMessage = outOfMemoryOutput
+		category = pb.ErrorCategory_ERROR_CATEGORY_OOM
+	}
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.RunError, "error: "+errorMessage+", output: "+string(errorOutput)); err != nil {
+		return err
+	}
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.RunLogs, string(errorOutput)); err != nil {
 		return err
 	}
+	if exitCode >= 0 {
+		if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.RunExitCode, exitCode); err != nil {
+			return err
+		}
+	}
 
 	stopReadLogsChannel <- true
 	<-finishReadLogsChannel
 
-	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, pb.Status_STATUS_RUN_ERROR)
+	return setErrorStatus(ctx, cacheService, pipelineId, status, category)
+}
+
+// isTransientFailure checks if the step is failed because of a transient failure instead of the code itself:
+//	the process of the step couldn't be started (i.e. fork is failed because of the lack of resources),
+//	the execution backend has failed to execute the code (i.e. the container couldn't be created)
+//	or the JVM launcher couldn't start the JVM. Failures because of the memory limit aren't transient.
+func isTransientFailure(err error, errorOutput []byte) bool {
+	if stderrors.Is(err, executors.ErrBackendFailure) {
+		return true
+	}
+	if execErr, ok := err.(*exec.Error); ok {
+		return execErr.Err != exec.ErrNotFound
+	}
+	if _, ok := err.(*exec.ExitError); !ok || isOutOfMemory(errorOutput) {
+		return false
+	}
+	output := bytes.TrimSpace(errorOutput)
+	for _, marker := range transientFailureMarkers {
+		if bytes.HasPrefix(output, []byte(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCpuTimeLimitExceeded checks if the executed code is killed because it has used up cpuTimeLimit seconds of CPU time.
+// The kernel sends SIGXCPU when the limit is reached and SIGKILL if the code keeps running
+//	(i.e. Go and Java runtimes don't stop on SIGXCPU), so the used CPU time is checked for both signals.
+func isCpuTimeLimitExceeded(err error, cpuTimeLimit int) bool {
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || cpuTimeLimit <= 0 {
+		return false
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() || (status.Signal() != syscall.SIGXCPU && status.Signal() != syscall.SIGKILL) {
+		return false
+	}
+	cpuTime := exitErr.UserTime() + exitErr.SystemTime()
+	return cpuTime >= time.Duration(cpuTimeLimit)*time.Second-cpuTimeAccuracy
+}
+
+// isOutOfMemory checks if the error output of the executed code contains a message that the code has run out of memory
+func isOutOfMemory(errorOutput []byte) bool {
+	for _, marker := range outOfMemoryMarkers {
+		if bytes.Contains(errorOutput, []byte(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+// processGraph reads graph of the pipeline which the code has saved to the graph file while it was run
+//	(see environment.ExecutorConfig.GraphArgs) and saves the graph as cache.Graph into cache.
+// The graph is derived from the run of the code, so the code isn't run again to print the graph.
+// This step is best-effort: in case of some error it is logged and the code processing is continued.
+func processGraph(ctx context.Context, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, cacheService cache.Cache) {
+	log := phaseLogger(ctx, graphPhase)
+	log.Infof("started")
+	data, err := os.ReadFile(lc.GetAbsoluteGraphFilePath())
+	switch {
+	case os.IsNotExist(err):
+		log.Errorf("graph of the pipeline isn't saved by the code")
+		return
+	case err != nil:
+		log.Errorf("error during reading the graph of the pipeline: %s", err.Error())
+		return
+	}
+	graph, found := extractGraph(string(data))
+	if !found {
+		log.Errorf("saved file doesn't contain graph of the pipeline")
+		return
+	}
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.Graph, graph); err != nil {
+		return
+	}
+	log.Infof("finish")
+}
+
+// extractGraph extracts graph in DOT format from the file saved by the code, since the file could contain
+//	not only the graph (i.e. a header of the file).
+func extractGraph(output string) (string, bool) {
+	start := strings.Index(output, dotGraphKeyword)
+	end := strings.LastIndex(output, "}")
+	if start == -1 || end < start {
+		return "", false
+	}
+	return output[start:end+1] + "\n", true
+}
+
+// processTestResults parses the results of the unit tests from the run output and the source code
+// and sets them as cache.TestResults to the cache.
+func processTestResults(ctx context.Context, lc *fs_tool.LifeCycle, sdk pb.Sdk, pipelineId uuid.UUID, cacheService cache.Cache) error {
+	source, err := os.ReadFile(lc.GetAbsoluteSourceFilePath())
+	if err != nil {
+		phaseLogger(ctx, runPhase).Errorf("error during reading the source code of the tests: %s", err.Error())
+		return err
+	}
+	output := ""
+	if value, err := cacheService.GetValue(ctx, pipelineId, cache.RunOutput); err == nil {
+		output, _ = value.(string)
+	}
+	return utils.SetToCache(ctx, cacheService, pipelineId, cache.TestResults, parseTestResults(sdk, string(source), output))
 }
 
 // processSuccess processes case after successful process validation or preparation steps.
 // This method sets corresponding status to the cache.
 func processSuccess(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, successTitle string, newStatus pb.Status) error {
-	logger.Infof("%s: %s(): finish\n", pipelineId, successTitle)
+	phaseLogger(ctx, successTitle).Infof("finish")
 
 	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, newStatus)
 }
 
 // processCompileSuccess processes case after successful compile step.
-// This method sets output of the compile step, sets empty string as output of the run step and
+// This method sets output, empty list of errors and warnings parsed from the output of the compile step,
+//	sets empty string as output and stderr output of the run step and
 //	sets corresponding status to the cache.
-func processCompileSuccess(ctx context.Context, output []byte, pipelineId uuid.UUID, cacheService cache.Cache) error {
-	logger.Infof("%s: Compile() finish\n", pipelineId)
+//	The output of the compile step is truncated to maxCompileOutputSize bytes.
+func processCompileSuccess(ctx context.Context, output []byte, sdk pb.Sdk, pipelineId uuid.UUID, cacheService cache.Cache, maxCompileOutputSize int) error {
+	phaseLogger(ctx, compilePhase).Infof("finish")
 
-	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileOutput, string(output)); err != nil {
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileOutput, utils.TruncateCompileOutput(string(output), maxCompileOutputSize)); err != nil {
+		return err
+	}
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileErrors, []*pb.CompileError{}); err != nil {
+		return err
+	}
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileWarnings, parseCompileWarnings(sdk, string(output))); err != nil {
 		return err
 	}
 	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.RunOutput, ""); err != nil {
 		return err
 	}
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.RunLogs, ""); err != nil {
+		return err
+	}
 	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.Logs, ""); err != nil {
 		return err
 	}
 	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
 }
 
+// processCompileOnlySuccess processes case after successful compile step if the code shouldn't be run.
+// This method sets output, empty list of errors and warnings parsed from the output of the compile step
+//	and sets corresponding status to the cache.
+//	The output of the compile step is truncated to maxCompileOutputSize bytes.
+func processCompileOnlySuccess(ctx context.Context, output []byte, sdk pb.Sdk, pipelineId uuid.UUID, cacheService cache.Cache, maxCompileOutputSize int) error {
+	phaseLogger(ctx, compilePhase).Infof("finish")
+
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileOutput, utils.TruncateCompileOutput(string(output), maxCompileOutputSize)); err != nil {
+		return err
+	}
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileErrors, []*pb.CompileError{}); err != nil {
+		return err
+	}
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileWarnings, parseCompileWarnings(sdk, string(output))); err != nil {
+		return err
+	}
+	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, pb.Status_STATUS_COMPILE_FINISHED)
+}
+
 // processRunSuccess processes case after successful run step.
-// This method sets value to channel to stop goroutine which writes logs.
-//	After receiving a signal that goroutine was finished (read value from finishReadLogsChannel) this method
-//	sets corresponding status to the cache.
-func processRunSuccess(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, stopReadLogsChannel, finishReadLogsChannel chan bool) error {
-	logger.Infof("%s: Run() finish\n", pipelineId)
+// This method sets stderr output and zero exit code of the run step to the cache and after that sets value to channel
+//	to stop goroutine which writes logs. After receiving a signal that goroutine was finished (read value from finishReadLogsChannel)
+//	this method sets corresponding status to the cache.
+func processRunSuccess(ctx context.Context, errorOutput []byte, pipelineId uuid.UUID, cacheService cache.Cache, stopReadLogsChannel, finishReadLogsChannel chan bool) error {
+	phaseLogger(ctx, runPhase).Infof("finish")
+
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.RunLogs, string(errorOutput)); err != nil {
+		return err
+	}
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.RunExitCode, 0); err != nil {
+		return err
+	}
 
 	stopReadLogsChannel <- true
 	<-finishReadLogsChannel
@@ -447,8 +2316,17 @@ func processRunSuccess(ctx context.Context, pipelineId uuid.UUID, cacheService c
 
 // processCancel process case when code processing was canceled
 func processCancel(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) error {
-	logger.Infof("%s: was canceled\n", pipelineId)
+	logger.FromContext(ctx).Infof("was canceled")
 
 	// set to cache pipelineId: cache.SubKey_Status: pb.Status_STATUS_CANCELED
-	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, pb.Status_STATUS_CANCELED)
+	return setErrorStatus(ctx, cacheService, pipelineId, pb.Status_STATUS_CANCELED, pb.ErrorCategory_ERROR_CATEGORY_CANCELLED)
+}
+
+// setErrorStatus saves category of the error as cache.ErrorCategory and status as cache.Status into cache.
+// The category is saved before the status, so it is available as soon as the final status is received.
+func setErrorStatus(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, status pb.Status, category pb.ErrorCategory) error {
+	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.ErrorCategory, category); err != nil {
+		return err
+	}
+	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, status)
 }
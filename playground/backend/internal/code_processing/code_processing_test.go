@@ -22,24 +22,42 @@ import (
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/executors"
 	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/setup_tools/builder"
+	"beam.apache.org/playground/backend/internal/streaming"
+	"beam.apache.org/playground/backend/internal/utils"
 	"beam.apache.org/playground/backend/internal/validators"
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
 	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
 	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"runtime"
+	"sort"
 	"strings"
 	"sync"
+	"syscall"
 	"testing"
 	"time"
 )
 
 const (
 	javaConfig     = "{\n  \"compile_cmd\": \"javac\",\n  \"run_cmd\": \"java\",\n  \"test_cmd\": \"java\",\n  \"compile_args\": [\n    \"-d\",\n    \"bin\",\n    \"-classpath\"\n  ],\n  \"run_args\": [\n    \"-cp\",\n    \"bin:\"\n  ],\n  \"test_args\": [\n    \"-cp\",\n    \"bin:\",\n    \"JUnit\"\n  ]\n}"
+	scioConfig     = "{\n  \"compile_cmd\": \"scalac\",\n  \"run_cmd\": \"scala\",\n  \"test_cmd\": \"scala\",\n  \"compile_args\": [\n    \"-d\",\n    \"bin\",\n    \"-classpath\"\n  ],\n  \"run_args\": [\n    \"-cp\",\n    \"bin:\"\n  ],\n  \"test_args\": [\n    \"-cp\",\n    \"bin:\",\n    \"org.scalatest.tools.Runner\",\n    \"-o\",\n    \"-s\"\n  ],\n  \"forbidden_imports\": [\n    \"scala.sys.process\"\n  ]\n}"
+	kotlinConfig   = "{\n  \"compile_cmd\": \"kotlinc\",\n  \"run_cmd\": \"java\",\n  \"test_cmd\": \"java\",\n  \"compile_args\": [\n    \"-d\",\n    \"bin\",\n    \"-classpath\"\n  ],\n  \"run_args\": [\n    \"-cp\",\n    \"bin:\"\n  ],\n  \"test_args\": [\n    \"-cp\",\n    \"bin:\",\n    \"JUnit\"\n  ],\n  \"forbidden_imports\": [\n    \"java.lang.ProcessBuilder\"\n  ]\n}"
 	fileName       = "fakeFileName"
 	baseFileFolder = "executable_files"
 	configFolder   = "configs"
@@ -67,6 +85,16 @@ func setup() {
 	if err != nil {
 		panic(err)
 	}
+	// create configs for scio
+	err = os.WriteFile(filepath.Join("configs", pb.Sdk_SDK_SCIO.String()+".json"), []byte(scioConfig), 0600)
+	if err != nil {
+		panic(err)
+	}
+	// create configs for kotlin
+	err = os.WriteFile(filepath.Join("configs", pb.Sdk_SDK_KOTLIN.String()+".json"), []byte(kotlinConfig), 0600)
+	if err != nil {
+		panic(err)
+	}
 
 	path, err := os.Getwd()
 	if err != nil {
@@ -94,16 +122,34 @@ func fakeExecutableName(uuid.UUID, string) (string, error) {
 	return fileName, nil
 }
 
+// withOptions returns the application environment with the options of appEnvs changed by update.
+// The output paths aren't constrained, so the tests could pass any pipeline options.
+func withOptions(appEnvs *environment.ApplicationEnvs, update func(options *environment.ApplicationOptions)) *environment.ApplicationEnvs {
+	options := appEnvs.Options()
+	options.OutputPathOptions = nil
+	update(&options)
+	return environment.NewApplicationEnvs(appEnvs.WorkingDir(), appEnvs.CacheEnvs(), options)
+}
+
 func Test_Process(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
 	if err != nil {
 		panic(err)
 	}
-	sdkEnv, err := environment.ConfigureBeamEnvs(appEnvs.WorkingDir())
+	sdkEnvs, err := environment.ConfigureBeamEnvs(appEnvs.WorkingDir())
 	if err != nil {
 		panic(err)
 	}
+	sdkEnv := sdkEnvs[pb.Sdk_SDK_JAVA]
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	goCompileErrorPipelineId := uuid.New()
+	goGraphSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	goGraphSdkEnv.ExecutorConfig.GraphArgs = []string{"--graph={graphFile}"}
+	goRunTimeoutSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	goRunTimeoutSdkEnv.ExecutorConfig.RunTimeout = "1s"
+	goForbiddenImportsSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	goForbiddenImportsSdkEnv.ExecutorConfig.ForbiddenImports = []string{"\"os/exec\""}
 
 	type args struct {
 		ctx             context.Context
@@ -113,15 +159,20 @@ func Test_Process(t *testing.T) {
 		pipelineOptions string
 	}
 	tests := []struct {
-		name                  string
-		createExecFile        bool
-		code                  string
-		cancelFunc            bool
-		expectedStatus        pb.Status
-		expectedRunOutput     interface{}
-		expectedRunError      interface{}
-		expectedCompileOutput interface{}
-		args                  args
+		name                     string
+		createExecFile           bool
+		code                     string
+		additionalFiles          []fs_tool.CodeFile
+		cancelFunc               bool
+		expectedStatus           pb.Status
+		expectedCategory         interface{}
+		expectedRunOutput        interface{}
+		expectedRunError         interface{}
+		expectedCompileOutput    interface{}
+		expectedGraph            interface{}
+		expectedValidationOutput interface{}
+		expectedTimes            bool
+		args                     args
 	}{
 		{
 			// Test case with calling processCode method with small timeout.
@@ -131,12 +182,13 @@ func Test_Process(t *testing.T) {
 			code:                  "",
 			cancelFunc:            false,
 			expectedStatus:        pb.Status_STATUS_RUN_TIMEOUT,
+			expectedCategory:      pb.ErrorCategory_ERROR_CATEGORY_TIMEOUT,
 			expectedCompileOutput: nil,
 			expectedRunOutput:     nil,
 			expectedRunError:      nil,
 			args: args{
 				ctx:             context.Background(),
-				appEnv:          &environment.ApplicationEnvs{},
+				appEnv:          withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.PipelineExecuteTimeout = 0 }),
 				sdkEnv:          sdkEnv,
 				pipelineId:      uuid.New(),
 				pipelineOptions: "",
@@ -145,14 +197,16 @@ func Test_Process(t *testing.T) {
 		{
 			// Test case with calling processCode method without preparing files with code.
 			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR.
-			name:                  "validation failed",
-			createExecFile:        false,
-			code:                  "",
-			cancelFunc:            false,
-			expectedStatus:        pb.Status_STATUS_VALIDATION_ERROR,
-			expectedCompileOutput: nil,
-			expectedRunOutput:     nil,
-			expectedRunError:      nil,
+			name:                     "validation failed",
+			createExecFile:           false,
+			code:                     "",
+			cancelFunc:               false,
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedCategory:         pb.ErrorCategory_ERROR_CATEGORY_VALIDATION,
+			expectedCompileOutput:    nil,
+			expectedRunOutput:        nil,
+			expectedRunError:         nil,
+			expectedValidationOutput: "file does not exist",
 			args: args{
 				ctx:             context.Background(),
 				appEnv:          appEnvs,
@@ -169,6 +223,7 @@ func Test_Process(t *testing.T) {
 			code:                  "MOCK_CODE",
 			cancelFunc:            false,
 			expectedStatus:        pb.Status_STATUS_COMPILE_ERROR,
+			expectedCategory:      pb.ErrorCategory_ERROR_CATEGORY_COMPILE,
 			expectedCompileOutput: "error: exit status 1, output: %s:1: error: reached end of file while parsing\nMOCK_CODE\n^\n1 error\n",
 			expectedRunOutput:     nil,
 			expectedRunError:      nil,
@@ -188,6 +243,7 @@ func Test_Process(t *testing.T) {
 			code:                  "class HelloWorld {\n    public static void main(String[] args) {\n        System.out.println(1/0);\n    }\n}",
 			cancelFunc:            false,
 			expectedStatus:        pb.Status_STATUS_RUN_ERROR,
+			expectedCategory:      pb.ErrorCategory_ERROR_CATEGORY_RUNTIME_EXCEPTION,
 			expectedCompileOutput: "",
 			expectedRunOutput:     "",
 			expectedRunError:      "error: exit status 1, output: Exception in thread \"main\" java.lang.ArithmeticException: / by zero\n\tat HelloWorld.main(%s.java:3)\n",
@@ -207,6 +263,7 @@ func Test_Process(t *testing.T) {
 			code:                  "class HelloWorld {\n    public static void main(String[] args) {\n        while(true){}\n    }\n}",
 			cancelFunc:            true,
 			expectedStatus:        pb.Status_STATUS_CANCELED,
+			expectedCategory:      pb.ErrorCategory_ERROR_CATEGORY_CANCELLED,
 			expectedCompileOutput: "",
 			expectedRunOutput:     "",
 			args: args{
@@ -225,9 +282,11 @@ func Test_Process(t *testing.T) {
 			cancelFunc:            false,
 			code:                  "class HelloWorld {\n    public static void main(String[] args) {\n        System.out.println(\"Hello world!\");\n    }\n}",
 			expectedStatus:        pb.Status_STATUS_FINISHED,
+			expectedCategory:      nil,
 			expectedCompileOutput: "",
 			expectedRunOutput:     "Hello world!\n",
 			expectedRunError:      nil,
+			expectedTimes:         true,
 			args: args{
 				ctx:             context.Background(),
 				appEnv:          appEnvs,
@@ -236,32 +295,227 @@ func Test_Process(t *testing.T) {
 				pipelineOptions: "",
 			},
 		},
+		{
+			// Test case with calling processCode method with incorrect go code.
+			// As a result status into cache should be set as Status_STATUS_COMPILE_ERROR.
+			name:                  "go compilation failed",
+			createExecFile:        true,
+			code:                  "package main\n\nfunc main() {\n\tMOCK_CODE\n}\n",
+			cancelFunc:            false,
+			expectedStatus:        pb.Status_STATUS_COMPILE_ERROR,
+			expectedCategory:      pb.ErrorCategory_ERROR_CATEGORY_COMPILE,
+			expectedCompileOutput: fmt.Sprintf("error: exit status 1, output: # command-line-arguments\n./%s.go:4:2: undefined: MOCK_CODE\n", goCompileErrorPipelineId),
+			expectedRunOutput:     nil,
+			expectedRunError:      nil,
+			args: args{
+				ctx:             context.Background(),
+				appEnv:          appEnvs,
+				sdkEnv:          goSdkEnv,
+				pipelineId:      goCompileErrorPipelineId,
+				pipelineOptions: "",
+			},
+		},
+		{
+			// Test case with calling processCode method with correct go code.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:                  "go processing complete successfully",
+			createExecFile:        true,
+			code:                  "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n}\n",
+			cancelFunc:            false,
+			expectedStatus:        pb.Status_STATUS_FINISHED,
+			expectedCategory:      nil,
+			expectedCompileOutput: "",
+			expectedRunOutput:     "Hello world!\n",
+			expectedRunError:      nil,
+			expectedTimes:         true,
+			args: args{
+				ctx:             context.Background(),
+				appEnv:          appEnvs,
+				sdkEnv:          goSdkEnv,
+				pipelineId:      uuid.New(),
+				pipelineOptions: "",
+			},
+		},
+		{
+			// Test case with calling processCode method with go code split across several files with incorrect code in one of them.
+			// As a result status into cache should be set as Status_STATUS_COMPILE_ERROR
+			// 	and compile output should reference the file with incorrect code.
+			name:                  "go compilation of several files failed",
+			createExecFile:        true,
+			code:                  "package main\n\nfunc main() {\n\thello()\n}\n",
+			additionalFiles:       []fs_tool.CodeFile{{Name: "helper.go", Content: "package main\n\nfunc hello() {\n\tMOCK_CODE\n}\n"}},
+			cancelFunc:            false,
+			expectedStatus:        pb.Status_STATUS_COMPILE_ERROR,
+			expectedCategory:      pb.ErrorCategory_ERROR_CATEGORY_COMPILE,
+			expectedCompileOutput: "error: exit status 1, output: # command-line-arguments\n./helper.go:4:2: undefined: MOCK_CODE\n",
+			expectedRunOutput:     nil,
+			expectedRunError:      nil,
+			args: args{
+				ctx:             context.Background(),
+				appEnv:          appEnvs,
+				sdkEnv:          goSdkEnv,
+				pipelineId:      uuid.New(),
+				pipelineOptions: "",
+			},
+		},
+		{
+			// Test case with calling processCode method with correct go code split across several files.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:                  "go processing of several files complete successfully",
+			createExecFile:        true,
+			code:                  "package main\n\nfunc main() {\n\thello()\n}\n",
+			additionalFiles:       []fs_tool.CodeFile{{Name: "helper.go", Content: "package main\n\nimport \"fmt\"\n\nfunc hello() {\n\tfmt.Println(\"Hello world!\")\n}\n"}},
+			cancelFunc:            false,
+			expectedStatus:        pb.Status_STATUS_FINISHED,
+			expectedCategory:      nil,
+			expectedCompileOutput: "",
+			expectedRunOutput:     "Hello world!\n",
+			expectedRunError:      nil,
+			args: args{
+				ctx:             context.Background(),
+				appEnv:          appEnvs,
+				sdkEnv:          goSdkEnv,
+				pipelineId:      uuid.New(),
+				pipelineOptions: "",
+			},
+		},
+		{
+			// Test case with calling processCode method with go code which saves graph of the pipeline to the file by the graph args
+			// while it is run.
+			// As a result status into cache should be set as Status_STATUS_FINISHED and graph should be saved into cache.
+			name:                  "go processing with graph complete successfully",
+			createExecFile:        true,
+			code:                  "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n\t\"strings\"\n)\n\nfunc main() {\n\tif len(os.Args) > 1 && strings.HasPrefix(os.Args[1], \"--graph=\") {\n\t\t_ = os.WriteFile(strings.TrimPrefix(os.Args[1], \"--graph=\"), []byte(\"digraph {\\n}\"), 0600)\n\t}\n\tfmt.Println(\"Hello world!\")\n}\n",
+			cancelFunc:            false,
+			expectedStatus:        pb.Status_STATUS_FINISHED,
+			expectedCategory:      nil,
+			expectedCompileOutput: "",
+			expectedRunOutput:     "Hello world!\n",
+			expectedRunError:      nil,
+			expectedGraph:         "digraph {\n}\n",
+			args: args{
+				ctx:             context.Background(),
+				appEnv:          appEnvs,
+				sdkEnv:          goGraphSdkEnv,
+				pipelineId:      uuid.New(),
+				pipelineOptions: "",
+			},
+		},
+		{
+			// Test case with calling processCode method with go code which doesn't save graph of the pipeline.
+			// As a result status into cache should be set as Status_STATUS_FINISHED and graph shouldn't be saved into cache.
+			name:                  "go processing without saved graph complete successfully",
+			createExecFile:        true,
+			code:                  "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n}\n",
+			cancelFunc:            false,
+			expectedStatus:        pb.Status_STATUS_FINISHED,
+			expectedCategory:      nil,
+			expectedCompileOutput: "",
+			expectedRunOutput:     "Hello world!\n",
+			expectedRunError:      nil,
+			expectedGraph:         nil,
+			args: args{
+				ctx:             context.Background(),
+				appEnv:          appEnvs,
+				sdkEnv:          goGraphSdkEnv,
+				pipelineId:      uuid.New(),
+				pipelineOptions: "",
+			},
+		},
+		{
+			// Test case with calling processCode method with go code which works more than run timeout of the SDK.
+			// As a result status into cache should be set as Status_STATUS_RUN_TIMEOUT.
+			name:                  "go run timeout of the sdk",
+			createExecFile:        true,
+			code:                  "package main\n\nimport \"time\"\n\nfunc main() {\n\ttime.Sleep(time.Minute)\n}\n",
+			cancelFunc:            false,
+			expectedStatus:        pb.Status_STATUS_RUN_TIMEOUT,
+			expectedCategory:      pb.ErrorCategory_ERROR_CATEGORY_TIMEOUT,
+			expectedCompileOutput: "",
+			expectedRunOutput:     "",
+			expectedRunError:      nil,
+			args: args{
+				ctx:             context.Background(),
+				appEnv:          appEnvs,
+				sdkEnv:          goRunTimeoutSdkEnv,
+				pipelineId:      uuid.New(),
+				pipelineOptions: "",
+			},
+		},
+		{
+			// Test case with calling processCode method with go code which works less than run timeout of the SDK.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:                  "go processing within run timeout of the sdk complete successfully",
+			createExecFile:        true,
+			code:                  "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n}\n",
+			cancelFunc:            false,
+			expectedStatus:        pb.Status_STATUS_FINISHED,
+			expectedCategory:      nil,
+			expectedCompileOutput: "",
+			expectedRunOutput:     "Hello world!\n",
+			expectedRunError:      nil,
+			args: args{
+				ctx:             context.Background(),
+				appEnv:          appEnvs,
+				sdkEnv:          goRunTimeoutSdkEnv,
+				pipelineId:      uuid.New(),
+				pipelineOptions: "",
+			},
+		},
+		{
+			// Test case with calling processCode method with go code which uses forbidden import.
+			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR
+			// 	and validation output should contain the name of the forbidden import.
+			name:                     "go validation of forbidden imports failed",
+			createExecFile:           true,
+			code:                     "package main\n\nimport \"os/exec\"\n\nfunc main() {\n\t_ = exec.Command(\"ls\").Run()\n}\n",
+			cancelFunc:               false,
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedCategory:         pb.ErrorCategory_ERROR_CATEGORY_VALIDATION,
+			expectedCompileOutput:    nil,
+			expectedRunOutput:        nil,
+			expectedRunError:         nil,
+			expectedValidationOutput: "forbidden import \"os/exec\" is used in %[2]s.go",
+			args: args{
+				ctx:             context.Background(),
+				appEnv:          appEnvs,
+				sdkEnv:          goForbiddenImportsSdkEnv,
+				pipelineId:      uuid.New(),
+				pipelineOptions: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, tt.args.pipelineId, os.Getenv("APP_WORK_DIR"))
+			lc, _ := fs_tool.NewLifeCycle(tt.args.sdkEnv.ApacheBeamSdk, tt.args.pipelineId, os.Getenv("APP_WORK_DIR"))
 			err := lc.CreateFolders()
 			if err != nil {
 				t.Fatalf("error during prepare folders: %s", err.Error())
 			}
 			if tt.createExecFile {
-				_, _ = lc.CreateSourceCodeFile(tt.code)
+				files := append([]fs_tool.CodeFile{{Content: tt.code, IsMain: true}}, tt.additionalFiles...)
+				_ = lc.CreateSourceCodeFiles(files)
 			}
 
 			if tt.cancelFunc {
 				go func(ctx context.Context, pipelineId uuid.UUID) {
 					// to imitate behavior of cancellation
 					time.Sleep(5 * time.Second)
-					cacheService.SetValue(ctx, pipelineId, cache.Canceled, true)
+					_ = CancelPipeline(ctx, cacheService, pipelineId, pb.CancelPhase_CANCEL_PHASE_UNSPECIFIED)
 				}(tt.args.ctx, tt.args.pipelineId)
 			}
-			Process(tt.args.ctx, cacheService, lc, tt.args.pipelineId, tt.args.appEnv, tt.args.sdkEnv, tt.args.pipelineOptions)
+			Process(tt.args.ctx, cacheService, NewWorkerPool(tt.args.appEnv.MaxConcurrentPipelines()), lc, tt.args.pipelineId, tt.args.appEnv, tt.args.sdkEnv, tt.args.pipelineOptions, "", "", "", nil, nil, nil)
 
 			status, _ := cacheService.GetValue(tt.args.ctx, tt.args.pipelineId, cache.Status)
 			if !reflect.DeepEqual(status, tt.expectedStatus) {
 				t.Errorf("processCode() set status: %s, but expectes: %s", status, tt.expectedStatus)
 			}
 
+			category, _ := cacheService.GetValue(tt.args.ctx, tt.args.pipelineId, cache.ErrorCategory)
+			if !reflect.DeepEqual(category, tt.expectedCategory) {
+				t.Errorf("processCode() set errorCategory: %s, but expectes: %s", category, tt.expectedCategory)
+			}
+
 			compileOutput, _ := cacheService.GetValue(tt.args.ctx, tt.args.pipelineId, cache.CompileOutput)
 			if tt.expectedCompileOutput != nil && strings.Contains(tt.expectedCompileOutput.(string), "%s") {
 				tt.expectedCompileOutput = fmt.Sprintf(tt.expectedCompileOutput.(string), lc.GetAbsoluteSourceFilePath())
@@ -282,181 +536,4597 @@ func Test_Process(t *testing.T) {
 			if !reflect.DeepEqual(runError, tt.expectedRunError) {
 				t.Errorf("processCode() set runError: %s, but expectes: %s", runError, tt.expectedRunError)
 			}
+
+			graph, _ := cacheService.GetValue(tt.args.ctx, tt.args.pipelineId, cache.Graph)
+			if !reflect.DeepEqual(graph, tt.expectedGraph) {
+				t.Errorf("processCode() set graph: %s, but expectes: %s", graph, tt.expectedGraph)
+			}
+
+			validationOutput, _ := cacheService.GetValue(tt.args.ctx, tt.args.pipelineId, cache.ValidationOutput)
+			if tt.expectedValidationOutput != nil && strings.Contains(tt.expectedValidationOutput.(string), "%") {
+				tt.expectedValidationOutput = fmt.Sprintf(tt.expectedValidationOutput.(string), lc.GetAbsoluteSourceFilePath(), tt.args.pipelineId)
+			}
+			if !reflect.DeepEqual(validationOutput, tt.expectedValidationOutput) {
+				t.Errorf("processCode() set validationOutput: %s, but expectes: %s", validationOutput, tt.expectedValidationOutput)
+			}
+
+			if tt.expectedTimes {
+				if compileTime, err := GetCompileTime(tt.args.ctx, cacheService, tt.args.pipelineId, ""); err != nil || compileTime <= 0 {
+					t.Errorf("processCode() set compileTime: %s, but expectes positive duration, err: %v", compileTime, err)
+				}
+				if runTime, err := GetRunTime(tt.args.ctx, cacheService, tt.args.pipelineId, ""); err != nil || runTime <= 0 {
+					t.Errorf("processCode() set runTime: %s, but expectes positive duration, err: %v", runTime, err)
+				}
+			}
 		})
 	}
 }
 
-func TestGetProcessingOutput(t *testing.T) {
+func TestProcessWithMemoryLimit(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
-	pipelineId := uuid.New()
-	incorrectConvertPipelineId := uuid.New()
-	err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT")
-	if err != nil {
-		panic(err)
-	}
-	err = cacheService.SetValue(context.Background(), incorrectConvertPipelineId, cache.RunOutput, cache.RunOutput)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
 	if err != nil {
 		panic(err)
 	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	memoryHungryCode := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tdata := make([]byte, 4<<30)\n\tfor i := range data {\n\t\tdata[i] = 1\n\t}\n\tfmt.Println(len(data))\n}\n"
+	ctx := context.Background()
 
 	type args struct {
-		ctx          context.Context
-		cacheService cache.Cache
-		key          uuid.UUID
-		subKey       cache.SubKey
-		errorTitle   string
+		appEnv *environment.ApplicationEnvs
+		sdkEnv *environment.BeamEnvs
+		code   string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    string
-		wantErr bool
+		name                   string
+		args                   args
+		expectedStatus         pb.Status
+		expectedCategory       interface{}
+		expectedRunErrorPrefix string
 	}{
 		{
-			// Test case with calling GetProcessingOutput with pipelineId which doesn't contain run output.
-			// As a result, want to receive an error.
-			name: "get run output with incorrect pipelineId",
+			// Test case with calling Process method with code which allocates more memory than the memory limit.
+			// As a result status into cache should be set as Status_STATUS_RUN_ERROR
+			// 	and run error should contain "out of memory" message.
+			name: "memory limit exceeded",
 			args: args{
-				ctx:          context.Background(),
-				cacheService: cacheService,
-				key:          uuid.New(),
-				subKey:       cache.RunOutput,
-				errorTitle:   "",
+				appEnv: withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.PipelineMemoryLimit = 1024 }),
+				sdkEnv: goSdkEnv,
+				code:   memoryHungryCode,
 			},
-			want:    "",
-			wantErr: true,
+			expectedStatus:         pb.Status_STATUS_RUN_ERROR,
+			expectedCategory:       pb.ErrorCategory_ERROR_CATEGORY_OOM,
+			expectedRunErrorPrefix: "error: out of memory, output: ",
 		},
 		{
-			// Test case with calling GetProcessingOutput with pipelineId which contains incorrect run output.
-			// As a result, want to receive an error.
-			name: "get run output with incorrect run output",
+			// Test case with calling Process method with code which allocates memory within the memory limit.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name: "memory limit isn't exceeded",
 			args: args{
-				ctx:          context.Background(),
-				cacheService: cacheService,
-				key:          incorrectConvertPipelineId,
-				subKey:       cache.RunOutput,
-				errorTitle:   "",
+				appEnv: withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.PipelineMemoryLimit = 1024 }),
+				sdkEnv: goSdkEnv,
+				code:   "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(len(make([]byte, 1<<20)))\n}\n",
 			},
-			want:    "",
-			wantErr: true,
+			expectedStatus: pb.Status_STATUS_FINISHED,
 		},
 		{
-			// Test case with calling GetProcessingOutput with pipelineId which contains run output.
-			// As a result, want to receive an expected string.
-			name: "get run output with correct pipelineId",
+			// Test case with calling Process method with Python code which allocates memory until it runs out of memory.
+			// As a result status into cache should be set as Status_STATUS_RUN_ERROR with the OOM error category
+			// 	and run error should contain "out of memory" message.
+			name: "python code runs out of memory",
 			args: args{
-				ctx:          context.Background(),
-				cacheService: cacheService,
-				key:          pipelineId,
-				subKey:       cache.RunOutput,
-				errorTitle:   "",
+				appEnv: withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.PipelineMemoryLimit = 1024 }),
+				sdkEnv: pythonSdkEnv,
+				code:   "chunks = []\nwhile True:\n    chunks.append(bytearray(64 << 20))\n",
 			},
-			want:    "MOCK_RUN_OUTPUT",
-			wantErr: false,
+			expectedStatus:         pb.Status_STATUS_RUN_ERROR,
+			expectedCategory:       pb.ErrorCategory_ERROR_CATEGORY_OOM,
+			expectedRunErrorPrefix: "error: out of memory, output: ",
+		},
+		{
+			// Test case with calling Process method with code which is killed by SIGKILL as the OOM killer of the kernel does.
+			// As a result status into cache should be set as Status_STATUS_RUN_ERROR with the OOM error category
+			// 	and run error should contain "out of memory" message.
+			name: "code is killed by OOM killer",
+			args: args{
+				appEnv: appEnvs,
+				sdkEnv: pythonSdkEnv,
+				code:   "import os\nimport signal\nos.kill(os.getpid(), signal.SIGKILL)\n",
+			},
+			expectedStatus:         pb.Status_STATUS_RUN_ERROR,
+			expectedCategory:       pb.ErrorCategory_ERROR_CATEGORY_OOM,
+			expectedRunErrorPrefix: "error: out of memory, output: ",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := GetProcessingOutput(tt.args.ctx, tt.args.cacheService, tt.args.key, tt.args.subKey, tt.args.errorTitle)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("GetProcessingOutput() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(tt.args.sdkEnv.ApacheBeamSdk, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
 			}
-			if got != tt.want {
-				t.Errorf("GetProcessingOutput() got = %v, want %v", got, tt.want)
+			_, _ = lc.CreateSourceCodeFile(tt.args.code)
+
+			Process(ctx, cacheService, NewWorkerPool(tt.args.appEnv.MaxConcurrentPipelines()), lc, pipelineId, tt.args.appEnv, tt.args.sdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			category, _ := cacheService.GetValue(ctx, pipelineId, cache.ErrorCategory)
+			if !reflect.DeepEqual(category, tt.expectedCategory) {
+				t.Errorf("Process() set errorCategory: %s, but expectes: %s", category, tt.expectedCategory)
+			}
+			if tt.expectedRunErrorPrefix != "" {
+				runError, _ := cacheService.GetValue(ctx, pipelineId, cache.RunError)
+				if runErrorString, ok := runError.(string); !ok || !strings.HasPrefix(runErrorString, tt.expectedRunErrorPrefix) {
+					t.Errorf("Process() set runError: %s, but expectes prefix: %s", runError, tt.expectedRunErrorPrefix)
+				}
 			}
 		})
 	}
 }
 
-func TestGetProcessingStatus(t *testing.T) {
+func TestProcessWithCpuTimeLimit(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
-	pipelineId := uuid.New()
-	incorrectConvertPipelineId := uuid.New()
-	err := cacheService.SetValue(context.Background(), pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
-	if err != nil {
-		panic(err)
-	}
-	err = cacheService.SetValue(context.Background(), incorrectConvertPipelineId, cache.Status, "MOCK_STATUS")
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
 	if err != nil {
 		panic(err)
 	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	busyLoopCode := "package main\n\nfunc main() {\n\tfor {\n\t}\n}\n"
+	ctx := context.Background()
 
-	type args struct {
-		ctx          context.Context
-		cacheService cache.Cache
-		key          uuid.UUID
-		errorTitle   string
-	}
 	tests := []struct {
-		name    string
-		args    args
-		want    pb.Status
-		wantErr bool
+		name                   string
+		code                   string
+		expectedStatus         pb.Status
+		expectedCategory       interface{}
+		expectedRunErrorPrefix string
 	}{
 		{
-			// Test case with calling GetProcessingStatus with pipelineId which doesn't contain status.
-			// As a result, want to receive an error.
-			name: "get status with incorrect pipelineId",
-			args: args{
-				ctx:          context.Background(),
-				cacheService: cacheService,
-				key:          uuid.New(),
-				errorTitle:   "",
-			},
-			want:    pb.Status_STATUS_UNSPECIFIED,
-			wantErr: true,
+			// Test case with calling Process method with code which is busy with computations longer than the cpu time limit.
+			// As a result status into cache should be set as Status_STATUS_RUN_TIMEOUT
+			// 	and run error should contain message about exceeded cpu time limit.
+			name:                   "cpu time limit exceeded",
+			code:                   busyLoopCode,
+			expectedStatus:         pb.Status_STATUS_RUN_TIMEOUT,
+			expectedCategory:       pb.ErrorCategory_ERROR_CATEGORY_TIMEOUT,
+			expectedRunErrorPrefix: "error: cpu time limit exceeded, output: ",
 		},
 		{
-			// Test case with calling GetProcessingStatus with pipelineId which contains incorrect status value in cache.
-			// As a result, want to receive an error.
-			name: "get status with incorrect cache value",
-			args: args{
-				ctx:          context.Background(),
-				cacheService: cacheService,
-				key:          incorrectConvertPipelineId,
-				errorTitle:   "",
-			},
-			want:    pb.Status_STATUS_UNSPECIFIED,
-			wantErr: true,
+			// Test case with calling Process method with code which uses less cpu time than the cpu time limit.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:           "cpu time limit isn't exceeded",
+			code:           "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n}\n",
+			expectedStatus: pb.Status_STATUS_FINISHED,
 		},
-		{
-			// Test case with calling GetProcessingStatus with pipelineId which contains status.
-			// As a result, want to receive an expected status.
-			name: "get status with correct pipelineId",
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.PipelineCpuTimeLimit = 1 })
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), lc, pipelineId, appEnv, goSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			category, _ := cacheService.GetValue(ctx, pipelineId, cache.ErrorCategory)
+			if !reflect.DeepEqual(category, tt.expectedCategory) {
+				t.Errorf("Process() set errorCategory: %s, but expectes: %s", category, tt.expectedCategory)
+			}
+			if tt.expectedRunErrorPrefix != "" {
+				runError, _ := cacheService.GetValue(ctx, pipelineId, cache.RunError)
+				if runErrorString, ok := runError.(string); !ok || !strings.HasPrefix(runErrorString, tt.expectedRunErrorPrefix) {
+					t.Errorf("Process() set runError: %s, but expectes prefix: %s", runError, tt.expectedRunErrorPrefix)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessWithDiskQuota(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	// writes 1 MB into a file next to the code each 100 ms until the code is killed (at most 100 MB)
+	largeFileCode := "import os, time\nwith open(os.path.join(os.path.dirname(os.path.abspath(__file__)), 'large_file'), 'wb') as f:\n    for _ in range(100):\n        f.write(b'0' * 1024 * 1024)\n        f.flush()\n        time.sleep(0.1)\n"
+	ctx := context.Background()
+
+	tests := []struct {
+		name                   string
+		code                   string
+		expectedStatus         pb.Status
+		expectedCategory       interface{}
+		expectedRunErrorPrefix string
+	}{
+		{
+			// Test case with calling Process method with code which writes a large file to its folder.
+			// As a result status into cache should be set as Status_STATUS_RUN_ERROR
+			// 	and run error should contain message about exceeded disk quota.
+			name:                   "disk quota exceeded",
+			code:                   largeFileCode,
+			expectedStatus:         pb.Status_STATUS_RUN_ERROR,
+			expectedCategory:       pb.ErrorCategory_ERROR_CATEGORY_RUNTIME_EXCEPTION,
+			expectedRunErrorPrefix: "error: disk quota exceeded, output: ",
+		},
+		{
+			// Test case with calling Process method with code which doesn't write files.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:           "disk quota isn't exceeded",
+			code:           "print('Hello world!')\n",
+			expectedStatus: pb.Status_STATUS_FINISHED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := exec.LookPath(pythonSdkEnv.ExecutorConfig.RunCmd); err != nil {
+				t.Skipf("%s isn't installed", pythonSdkEnv.ExecutorConfig.RunCmd)
+			}
+			appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+				options.PipelineCpuTimeLimit = 1
+				options.PipelineDiskQuota = 1
+			})
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), lc, pipelineId, appEnv, pythonSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			category, _ := cacheService.GetValue(ctx, pipelineId, cache.ErrorCategory)
+			if !reflect.DeepEqual(category, tt.expectedCategory) {
+				t.Errorf("Process() set errorCategory: %s, but expectes: %s", category, tt.expectedCategory)
+			}
+			if tt.expectedRunErrorPrefix != "" {
+				runError, _ := cacheService.GetValue(ctx, pipelineId, cache.RunError)
+				if runErrorString, ok := runError.(string); !ok || !strings.HasPrefix(runErrorString, tt.expectedRunErrorPrefix) {
+					t.Errorf("Process() set runError: %s, but expectes prefix: %s", runError, tt.expectedRunErrorPrefix)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessWithCompileCache(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goPath, err := exec.LookPath("go")
+	if err != nil {
+		t.Skipf("%s isn't installed", "go")
+	}
+	ctx := context.Background()
+	tempDir, err := os.MkdirTemp("", "compile_cache")
+	if err != nil {
+		t.Fatalf("error during prepare the temp dir: %s", err.Error())
+	}
+	defer os.RemoveAll(tempDir)
+	// the compile command counts the compilations in the file before the code is compiled
+	compilesFile := filepath.Join(tempDir, "compiles")
+	compileCmd := filepath.Join(tempDir, "compile.sh")
+	wrapper := fmt.Sprintf("#!/bin/sh\necho compiled >> %s\nexec %s \"$@\"\n", compilesFile, goPath)
+	if err := os.WriteFile(compileCmd, []byte(wrapper), 0700); err != nil {
+		t.Fatalf("error during prepare the compile command: %s", err.Error())
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig(compileCmd, "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	compileCache, err := NewCompileCache(filepath.Join(tempDir, "artifacts"), 10)
+	if err != nil {
+		t.Fatalf("error during prepare the compile cache: %s", err.Error())
+	}
+	helloWorld := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n}\n"
+	// the same dependency jar of two versions
+	firstDependency := map[string]string{"org.apache.beam:beam-sdks-java-io-kafka:2.35.0": filepath.Join(tempDir, "kafka-2.35.0.jar")}
+	secondDependency := map[string]string{"org.apache.beam:beam-sdks-java-io-kafka:2.36.0": filepath.Join(tempDir, "kafka-2.36.0.jar")}
+	for _, dependency := range []map[string]string{firstDependency, secondDependency} {
+		for _, jarPath := range dependency {
+			if err := os.WriteFile(jarPath, []byte(jarPath), 0600); err != nil {
+				t.Fatalf("error during prepare the dependency: %s", err.Error())
+			}
+		}
+	}
+
+	tests := []struct {
+		name              string
+		code              string
+		dependencies      map[string]string
+		updateToolchain   bool
+		expectedCompiles  int
+		expectedRunOutput string
+	}{
+		{
+			// Test case with calling Process method with the code which isn't compiled before.
+			// As a result, the code should be compiled and run.
+			name:              "code is compiled",
+			code:              helloWorld,
+			expectedCompiles:  1,
+			expectedRunOutput: "Hello world!\n",
+		},
+		{
+			// Test case with calling Process method with the same code (differs by line endings only).
+			// As a result, the compile step should be skipped and the kept artifacts should be run.
+			name:              "same code isn't compiled",
+			code:              strings.ReplaceAll(helloWorld, "\n", "\r\n"),
+			expectedCompiles:  1,
+			expectedRunOutput: "Hello world!\n",
+		},
+		{
+			// Test case with calling Process method with another code.
+			// As a result, the code should be compiled.
+			name:              "another code is compiled",
+			code:              strings.ReplaceAll(helloWorld, "Hello world!", "Hello Beam!"),
+			expectedCompiles:  2,
+			expectedRunOutput: "Hello Beam!\n",
+		},
+		{
+			// Test case with calling Process method with the same code after the toolchain is updated.
+			// As a result, the kept artifacts shouldn't be reused and the code should be compiled.
+			name:              "same code is compiled by the updated toolchain",
+			code:              helloWorld,
+			updateToolchain:   true,
+			expectedCompiles:  3,
+			expectedRunOutput: "Hello world!\n",
+		},
+		{
+			// Test case with calling Process method with the same code and the dependency in the classpath.
+			// As a result, the kept artifacts compiled without the dependency shouldn't be reused and the code should be compiled.
+			name:              "same code is compiled with the dependency",
+			code:              helloWorld,
+			dependencies:      firstDependency,
+			expectedCompiles:  4,
+			expectedRunOutput: "Hello world!\n",
+		},
+		{
+			// Test case with calling Process method with the same code and another version of the dependency in the classpath.
+			// As a result, the kept artifacts compiled with the first version shouldn't be reused and the code should be compiled.
+			name:              "same code is compiled with another version of the dependency",
+			code:              helloWorld,
+			dependencies:      secondDependency,
+			expectedCompiles:  5,
+			expectedRunOutput: "Hello world!\n",
+		},
+		{
+			// Test case with calling Process method with the same code and the first version of the dependency again.
+			// As a result, the compile step should be skipped and the artifacts compiled with the first version should be run.
+			name:              "same code with the same dependency isn't compiled",
+			code:              helloWorld,
+			dependencies:      firstDependency,
+			expectedCompiles:  5,
+			expectedRunOutput: "Hello world!\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goSdkEnv.ExecutorConfig.ClasspathDependencies = tt.dependencies
+			if tt.updateToolchain {
+				updateTime := time.Now().Add(time.Hour)
+				if err := os.Chtimes(compileCmd, updateTime, updateTime); err != nil {
+					t.Fatalf("error during update the compile command: %s", err.Error())
+				}
+			}
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, goSdkEnv, "", "", "", "", nil, compileCache, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_FINISHED)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, tt.expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %s, but expectes: %s", runOutput, tt.expectedRunOutput)
+			}
+			compileOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.CompileOutput)
+			if !reflect.DeepEqual(compileOutput, "") {
+				t.Errorf("Process() set compileOutput: %s, but expectes empty output", compileOutput)
+			}
+			compiles, _ := os.ReadFile(compilesFile)
+			if got := strings.Count(string(compiles), "compiled"); got != tt.expectedCompiles {
+				t.Errorf("Process() compiled the code %d times, but expectes: %d", got, tt.expectedCompiles)
+			}
+		})
+	}
+}
+
+func TestRerunPipeline(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goPath, err := exec.LookPath("go")
+	if err != nil {
+		t.Skipf("%s isn't installed", "go")
+	}
+	ctx := context.Background()
+	tempDir, err := os.MkdirTemp("", "rerun")
+	if err != nil {
+		t.Fatalf("error during prepare the temp dir: %s", err.Error())
+	}
+	defer os.RemoveAll(tempDir)
+	// the compile command counts the compilations in the file before the code is compiled
+	compilesFile := filepath.Join(tempDir, "compiles")
+	compileCmd := filepath.Join(tempDir, "compile.sh")
+	wrapper := fmt.Sprintf("#!/bin/sh\necho compiled >> %s\nexec %s \"$@\"\n", compilesFile, goPath)
+	if err := os.WriteFile(compileCmd, []byte(wrapper), 0700); err != nil {
+		t.Fatalf("error during prepare the compile command: %s", err.Error())
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig(compileCmd, "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	// the files of the pipelines are kept, so the finished pipelines could be run again
+	appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+		options.NetworkIsolation = false
+		options.SandboxCmd = nil
+		options.KeepPipelineFiles = true
+	})
+
+	tests := []struct {
+		name             string
+		sdkEnv           *environment.BeamEnvs
+		code             string
+		expectedCompiles int
+	}{
+		{
+			// Test case with calling RerunPipeline method with the finished Go pipeline and new pipeline options.
+			// As a result, the compiled code of the finished pipeline should be run with new options without compilation.
+			name:             "go pipeline",
+			sdkEnv:           goSdkEnv,
+			code:             "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n\t\"strings\"\n)\n\nfunc main() {\n\tfmt.Println(strings.Join(os.Args[1:], \" \"))\n}\n",
+			expectedCompiles: 1,
+		},
+		{
+			// Test case with calling RerunPipeline method with the finished Python pipeline and new pipeline options.
+			// As a result, the code of the finished pipeline should be run with new options.
+			name:   "python pipeline",
+			sdkEnv: pythonSdkEnv,
+			code:   "import sys\nprint(\" \".join(sys.argv[1:]))\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Remove(compilesFile)
+			sourcePipelineId := uuid.New()
+			sourceLc, _ := fs_tool.NewLifeCycle(tt.sdkEnv.ApacheBeamSdk, sourcePipelineId, appEnvs.WorkingDir())
+			if err := sourceLc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			defer sourceLc.Cleanup()
+			_, _ = sourceLc.CreateSourceCodeFile(tt.code)
+			Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), sourceLc, sourcePipelineId, appEnv, tt.sdkEnv, "--name=first", "", "", "", nil, nil, nil)
+
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(tt.sdkEnv.ApacheBeamSdk, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			defer lc.Cleanup()
+			if err := RerunPipeline(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), sourceLc, lc, pipelineId, appEnv, tt.sdkEnv, "--name=second", nil, nil); err != nil {
+				t.Fatalf("RerunPipeline() error = %v", err)
+			}
+
+			processingStatus, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(processingStatus, pb.Status_STATUS_FINISHED) {
+				t.Fatalf("RerunPipeline() set status: %s, but expectes: %s", processingStatus, pb.Status_STATUS_FINISHED)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, "--name=second\n") {
+				t.Errorf("RerunPipeline() set runOutput: %q, but expectes: %q", runOutput, "--name=second\n")
+			}
+			sourceRunOutput, _ := cacheService.GetValue(ctx, sourcePipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(sourceRunOutput, "--name=first\n") {
+				t.Errorf("RerunPipeline() changed runOutput of the source pipeline: %q, but expectes: %q", sourceRunOutput, "--name=first\n")
+			}
+			gotSourcePipelineId, err := GetSourcePipelineId(ctx, cacheService, pipelineId, "")
+			if err != nil {
+				t.Fatalf("GetSourcePipelineId() error = %v", err)
+			}
+			if gotSourcePipelineId != sourcePipelineId {
+				t.Errorf("RerunPipeline() set source pipeline id: %s, but expectes: %s", gotSourcePipelineId, sourcePipelineId)
+			}
+			compiles, _ := os.ReadFile(compilesFile)
+			if got := strings.Count(string(compiles), "compiled"); got != tt.expectedCompiles {
+				t.Errorf("RerunPipeline() compiled the code %d times together with the source pipeline, but expectes: %d", got, tt.expectedCompiles)
+			}
+		})
+	}
+}
+
+func TestRerunPipelineWithEntryPoint(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	ctx := context.Background()
+	tempDir, err := os.MkdirTemp("", "rerun_entry_point")
+	if err != nil {
+		t.Fatalf("error during prepare the temp dir: %s", err.Error())
+	}
+	defer os.RemoveAll(tempDir)
+	// the compile command creates the compiled classes of the code and the run command of each version of the SDK
+	//	prints the version, its arguments and the standard input, so the parameters of the run could be checked by the run output
+	compileCmd := filepath.Join(tempDir, "compile.sh")
+	compileScript := "#!/bin/sh\nmkdir -p bin/org/example\ntouch bin/org/example/Main.class bin/org/example/Helper.class\n"
+	if err := os.WriteFile(compileCmd, []byte(compileScript), 0700); err != nil {
+		t.Fatalf("error during prepare the compile command: %s", err.Error())
+	}
+	versions := map[string]*environment.ExecutorConfig{}
+	for _, version := range []string{"2.40.0", "2.41.0"} {
+		runCmd := filepath.Join(tempDir, "run_"+version+".sh")
+		runScript := fmt.Sprintf("#!/bin/sh\necho \"%s $*\"\ncat\n", version)
+		if err := os.WriteFile(runCmd, []byte(runScript), 0700); err != nil {
+			t.Fatalf("error during prepare the run command: %s", err.Error())
+		}
+		versions[version] = environment.NewExecutorConfig(compileCmd, runCmd, "", []string{}, []string{}, []string{})
+	}
+	javaSdkEnv := environment.NewVersionedBeamEnvs(pb.Sdk_SDK_JAVA, versions, "")
+	// the files of the pipelines are kept, so the finished pipelines could be run again
+	appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+		options.NetworkIsolation = false
+		options.SandboxCmd = nil
+		options.KeepPipelineFiles = true
+	})
+	code := "package org.example;\n\npublic class Main {\n\tpublic static void main(String[] args) {}\n}\n\nclass Helper {\n\tpublic static void main(String[] args) {}\n}\n"
+
+	// Test case with calling RerunPipeline method with the finished Java pipeline which is run with the entry point,
+	//	the version of the SDK which isn't the latest one and stdin.
+	// As a result, the compiled code should be run again with the same entry point, version of the SDK and stdin.
+	sourcePipelineId := uuid.New()
+	sourceLc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, sourcePipelineId, appEnvs.WorkingDir())
+	if err := sourceLc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	defer sourceLc.Cleanup()
+	_, _ = sourceLc.CreateSourceCodeFile(code)
+	Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), sourceLc, sourcePipelineId, appEnv, javaSdkEnv, "--name=first", "input\n", "2.40.0", "org.example.Helper", nil, nil, nil)
+	sourceRunOutput, _ := cacheService.GetValue(ctx, sourcePipelineId, cache.RunOutput)
+	if !reflect.DeepEqual(sourceRunOutput, "2.40.0 org.example.Helper --name=first\ninput\n") {
+		t.Fatalf("Process() set runOutput: %q, but expectes: %q", sourceRunOutput, "2.40.0 org.example.Helper --name=first\ninput\n")
+	}
+
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, pipelineId, appEnvs.WorkingDir())
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	defer lc.Cleanup()
+	if err := RerunPipeline(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), sourceLc, lc, pipelineId, appEnv, javaSdkEnv, "--name=second", nil, nil); err != nil {
+		t.Fatalf("RerunPipeline() error = %v", err)
+	}
+	processingStatus, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if !reflect.DeepEqual(processingStatus, pb.Status_STATUS_FINISHED) {
+		t.Fatalf("RerunPipeline() set status: %s, but expectes: %s", processingStatus, pb.Status_STATUS_FINISHED)
+	}
+	runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+	if !reflect.DeepEqual(runOutput, "2.40.0 org.example.Helper --name=second\ninput\n") {
+		t.Errorf("RerunPipeline() set runOutput: %q, but expectes: %q", runOutput, "2.40.0 org.example.Helper --name=second\ninput\n")
+	}
+}
+
+func TestProcessWithRunPhaseCancel(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goPath, err := exec.LookPath("go")
+	if err != nil {
+		t.Skipf("%s isn't installed", "go")
+	}
+	ctx := context.Background()
+	tempDir, err := os.MkdirTemp("", "cancel_run")
+	if err != nil {
+		t.Fatalf("error during prepare the temp dir: %s", err.Error())
+	}
+	defer os.RemoveAll(tempDir)
+	// the compile command counts the compilations in the file and compiles the code slowly, so the run phase could be canceled while it is compiled
+	compilesFile := filepath.Join(tempDir, "compiles")
+	compileCmd := filepath.Join(tempDir, "compile.sh")
+	wrapper := fmt.Sprintf("#!/bin/sh\necho compiled >> %s\nsleep 1\nexec %s \"$@\"\n", compilesFile, goPath)
+	if err := os.WriteFile(compileCmd, []byte(wrapper), 0700); err != nil {
+		t.Fatalf("error during prepare the compile command: %s", err.Error())
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig(compileCmd, "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	// the files of the pipelines are kept, so the canceled pipelines could be run again
+	appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+		options.NetworkIsolation = false
+		options.SandboxCmd = nil
+		options.KeepPipelineFiles = true
+	})
+	// the code waits after printing its pipeline options only if it is run with "--wait" option
+	code := "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n\t\"strings\"\n\t\"time\"\n)\n\nfunc main() {\n\tfmt.Println(strings.Join(os.Args[1:], \" \"))\n\tif len(os.Args) > 1 && os.Args[1] == \"--wait\" {\n\t\ttime.Sleep(time.Minute)\n\t}\n}\n"
+
+	tests := []struct {
+		name              string
+		isReadyToCancel   func(sourcePipelineId uuid.UUID) bool
+		expectedRunOutput string
+		expectedRunStart  bool
+	}{
+		{
+			// Test case with calling CancelPipeline method with the run phase while the code is running.
+			// As a result, the run step should be stopped with Status_STATUS_CANCELED, the output printed before the cancel should be kept
+			//	and the compiled code should be run again with new options without compilation.
+			name: "cancel while the code is running",
+			isReadyToCancel: func(sourcePipelineId uuid.UUID) bool {
+				runOutput, _ := cacheService.GetValue(ctx, sourcePipelineId, cache.RunOutput)
+				return runOutput == "--wait\n"
+			},
+			expectedRunOutput: "--wait\n",
+			expectedRunStart:  true,
+		},
+		{
+			// Test case with calling CancelPipeline method with the run phase while the code is compiling.
+			// As a result, the compile step should be finished, the code shouldn't be run, Status_STATUS_CANCELED should be set
+			//	and the compiled code should be run again with new options without compilation.
+			name: "cancel while the code is compiling",
+			isReadyToCancel: func(sourcePipelineId uuid.UUID) bool {
+				processingStatus, _ := cacheService.GetValue(ctx, sourcePipelineId, cache.Status)
+				return processingStatus == pb.Status_STATUS_COMPILING
+			},
+			expectedRunOutput: "",
+			expectedRunStart:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Remove(compilesFile)
+			sourcePipelineId := uuid.New()
+			sourceLc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, sourcePipelineId, appEnvs.WorkingDir())
+			if err := sourceLc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			defer sourceLc.Cleanup()
+			_, _ = sourceLc.CreateSourceCodeFile(code)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), sourceLc, sourcePipelineId, appEnv, goSdkEnv, "--wait", "", "", "", nil, nil, nil)
+			}()
+			deadline := time.After(30 * time.Second)
+			for !tt.isReadyToCancel(sourcePipelineId) {
+				select {
+				case <-deadline:
+					t.Fatalf("the code processing isn't ready to cancel")
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+			if err := CancelPipeline(ctx, cacheService, sourcePipelineId, pb.CancelPhase_CANCEL_PHASE_RUN); err != nil {
+				t.Fatalf("CancelPipeline() error = %v", err)
+			}
+			select {
+			case <-done:
+			case <-time.After(30 * time.Second):
+				t.Fatalf("Process() isn't finished after cancel")
+			}
+
+			processingStatus, _ := cacheService.GetValue(ctx, sourcePipelineId, cache.Status)
+			if !reflect.DeepEqual(processingStatus, pb.Status_STATUS_CANCELED) {
+				t.Fatalf("Process() set status: %s, but expectes: %s", processingStatus, pb.Status_STATUS_CANCELED)
+			}
+			sourceRunOutput, _ := cacheService.GetValue(ctx, sourcePipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(sourceRunOutput, tt.expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %q, but expectes: %q", sourceRunOutput, tt.expectedRunOutput)
+			}
+			_, err := cacheService.GetValue(ctx, sourcePipelineId, cache.RunCommand)
+			if isRunStarted := err == nil; isRunStarted != tt.expectedRunStart {
+				t.Errorf("Process() started the run step: %t, but expectes: %t", isRunStarted, tt.expectedRunStart)
+			}
+
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			defer lc.Cleanup()
+			if err := RerunPipeline(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), sourceLc, lc, pipelineId, appEnv, goSdkEnv, "--name=second", nil, nil); err != nil {
+				t.Fatalf("RerunPipeline() error = %v", err)
+			}
+			processingStatus, _ = cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(processingStatus, pb.Status_STATUS_FINISHED) {
+				t.Fatalf("RerunPipeline() set status: %s, but expectes: %s", processingStatus, pb.Status_STATUS_FINISHED)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, "--name=second\n") {
+				t.Errorf("RerunPipeline() set runOutput: %q, but expectes: %q", runOutput, "--name=second\n")
+			}
+			compiles, _ := os.ReadFile(compilesFile)
+			if got := strings.Count(string(compiles), "compiled"); got != 1 {
+				t.Errorf("RerunPipeline() compiled the code %d times together with the canceled pipeline, but expectes: %d", got, 1)
+			}
+		})
+	}
+}
+
+func TestRerunPipelineErrors(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		sourceStatus pb.Status
+		keepFiles    bool
+		expectedCode codes.Code
+	}{
+		{
+			// Test case with calling RerunPipeline method with the pipeline which doesn't exist in cache.
+			// As a result, want to receive NotFound error.
+			name:         "unknown pipeline",
+			expectedCode: codes.NotFound,
+		},
+		{
+			// Test case with calling RerunPipeline method with the pipeline which is still running.
+			// As a result, want to receive FailedPrecondition error.
+			name:         "pipeline isn't finished",
+			sourceStatus: pb.Status_STATUS_EXECUTING,
+			keepFiles:    true,
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			// Test case with calling RerunPipeline method with the pipeline which is failed before the run step.
+			// As a result, want to receive FailedPrecondition error.
+			name:         "pipeline isn't compiled",
+			sourceStatus: pb.Status_STATUS_COMPILE_ERROR,
+			keepFiles:    true,
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			// Test case with calling RerunPipeline method with the finished pipeline whose files aren't kept.
+			// As a result, want to receive FailedPrecondition error.
+			name:         "files of pipeline aren't kept",
+			sourceStatus: pb.Status_STATUS_FINISHED,
+			expectedCode: codes.FailedPrecondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourcePipelineId := uuid.New()
+			sourceLc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, sourcePipelineId, appEnvs.WorkingDir())
+			if tt.sourceStatus != pb.Status_STATUS_UNSPECIFIED {
+				_ = cacheService.SetValue(ctx, sourcePipelineId, cache.Status, tt.sourceStatus)
+			}
+			if tt.keepFiles {
+				if err := sourceLc.CreateFolders(); err != nil {
+					t.Fatalf("error during prepare folders: %s", err.Error())
+				}
+				defer sourceLc.Cleanup()
+				_, _ = sourceLc.CreateSourceCodeFile("print(\"Hello world!\")\n")
+			}
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			defer lc.Cleanup()
+
+			err := RerunPipeline(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), sourceLc, lc, pipelineId, appEnvs, pythonSdkEnv, "", nil, nil)
+			if status.Code(err) != tt.expectedCode {
+				t.Errorf("RerunPipeline() error = %v, but expectes code: %s", err, tt.expectedCode)
+			}
+			if _, err := cacheService.GetValue(ctx, pipelineId, cache.Status); err == nil {
+				t.Errorf("RerunPipeline() processed the pipeline, but expectes it isn't processed")
+			}
+		})
+	}
+}
+
+func TestProcessWithJsonLogs(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	ctx := context.Background()
+	code := "import logging\nlogging.basicConfig(level=logging.INFO)\nlogging.info(\"started\")\nlogging.warning(\"line with \\\"quotes\\\"\\nand new line\")\nprint(\"done\")\n"
+
+	tests := []struct {
+		name             string
+		jsonLogs         bool
+		expectedMessages []string
+	}{
+		{
+			// Test case with calling Process method with the code which logs records when the JSON logs are enabled.
+			// As a result, each line of run logs should be a JSON object with the message of the record.
+			name:             "json logs",
+			jsonLogs:         true,
+			expectedMessages: []string{"started", "line with \"quotes\"\nand new line"},
+		},
+		{
+			// Test case with calling Process method with the code which logs records when the JSON logs aren't enabled.
+			// As a result, run logs should be plain text.
+			name:     "plain text logs",
+			jsonLogs: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := exec.LookPath(pythonSdkEnv.ExecutorConfig.RunCmd); err != nil {
+				t.Skipf("%s isn't installed", pythonSdkEnv.ExecutorConfig.RunCmd)
+			}
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(code)
+			if tt.jsonLogs {
+				if err := lc.EnableJsonLogs(); err != nil {
+					t.Fatalf("error during enabling json logs: %s", err.Error())
+				}
+			}
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, pythonSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+				t.Fatalf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_FINISHED)
+			}
+			runLogs, _ := cacheService.GetValue(ctx, pipelineId, cache.RunLogs)
+			lines := strings.Split(strings.TrimSpace(runLogs.(string)), "\n")
+			if !tt.jsonLogs {
+				if !reflect.DeepEqual(lines[0], "INFO:root:started") {
+					t.Errorf("Process() set runLogs: %s, but expectes plain text", runLogs)
+				}
+				return
+			}
+			var messages []string
+			for _, line := range lines {
+				var record map[string]interface{}
+				if err := json.Unmarshal([]byte(line), &record); err != nil {
+					t.Fatalf("Process() set runLogs line %q, but expectes a JSON object: %s", line, err.Error())
+				}
+				if record["level"] == nil || record["timestamp"] == nil {
+					t.Errorf("Process() set runLogs record %v, but expectes level and timestamp", record)
+				}
+				messages = append(messages, record["message"].(string))
+			}
+			if !reflect.DeepEqual(messages, tt.expectedMessages) {
+				t.Errorf("Process() set runLogs messages %q, but expectes: %q", messages, tt.expectedMessages)
+			}
+		})
+	}
+}
+
+func TestProcessWithBareCode(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	// the code without the package clause, the main function and imports is wrapped during the preparation step
+	_, _ = lc.CreateSourceCodeFile("fmt.Println(strings.ToUpper(\"hello\"))")
+
+	Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, goSdkEnv, "", "", "", "", nil, nil, nil)
+
+	status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+		t.Errorf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_FINISHED)
+	}
+	runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+	if !reflect.DeepEqual(runOutput, "HELLO\n") {
+		t.Errorf("Process() set runOutput: %s, but expectes: %s", runOutput, "HELLO\n")
+	}
+}
+
+func TestProcessWithCompileOutputSize(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	// -e flag makes the compiler report all errors instead of the first 10 ones
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-gcflags=-e", "-o"}, []string{}, []string{}), "")
+	var code strings.Builder
+	code.WriteString("package main\n\nfunc main() {\n")
+	for i := 0; i < 300; i++ {
+		code.WriteString(fmt.Sprintf("\t_ = undefinedVariable%d\n", i))
+	}
+	code.WriteString("}\n")
+	ctx := context.Background()
+
+	tests := []struct {
+		name                 string
+		maxCompileOutputSize int
+		wantTruncated        bool
+	}{
+		{
+			// Test case with calling Process method with the code which produces hundreds of compile errors and the small compile output size.
+			// As a result the compile output should be truncated to the max compile output size with the marker.
+			name:                 "compile output exceeds limit",
+			maxCompileOutputSize: 1024,
+			wantTruncated:        true,
+		},
+		{
+			// Test case with calling Process method with the code which produces hundreds of compile errors and the unlimited compile output size.
+			// As a result the compile output should contain all compile errors.
+			name:                 "compile output isn't limited",
+			maxCompileOutputSize: 0,
+			wantTruncated:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.MaxCompileOutputSize = tt.maxCompileOutputSize })
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnv.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(code.String())
+
+			Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), lc, pipelineId, appEnv, goSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, pb.Status_STATUS_COMPILE_ERROR) {
+				t.Fatalf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_COMPILE_ERROR)
+			}
+			value, _ := cacheService.GetValue(ctx, pipelineId, cache.CompileOutput)
+			compileOutput, _ := value.(string)
+			truncated := strings.Contains(compileOutput, "compiler output truncated")
+			if truncated != tt.wantTruncated {
+				t.Errorf("Process() set truncated compile output: %t, but expectes: %t", truncated, tt.wantTruncated)
+			}
+			if tt.wantTruncated && len(compileOutput) > tt.maxCompileOutputSize+200 {
+				t.Errorf("Process() set compile output with length %d, but expectes at most: %d", len(compileOutput), tt.maxCompileOutputSize+200)
+			}
+			if !tt.wantTruncated && !strings.Contains(compileOutput, "undefinedVariable299") {
+				t.Errorf("Process() set compile output without the last compile error: %s", compileOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithCancelKeepsPartialOutput(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	code := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"partial\")\n\tfor {\n\t}\n}\n"
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_, _ = lc.CreateSourceCodeFile(code)
+
+	go func() {
+		// the code is canceled as soon as it prints the partial output
+		for {
+			output, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if value, _ := output.(string); strings.Contains(value, "partial") {
+				_ = CancelPipeline(ctx, cacheService, pipelineId, pb.CancelPhase_CANCEL_PHASE_UNSPECIFIED)
+				return
+			}
+			if status, err := cacheService.GetValue(ctx, pipelineId, cache.Status); err == nil && IsFinalStatus(status.(pb.Status)) {
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}()
+	Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, goSdkEnv, "", "", "", "", nil, nil, nil)
+
+	status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if !reflect.DeepEqual(status, pb.Status_STATUS_CANCELED) {
+		t.Fatalf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_CANCELED)
+	}
+	output, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+	if output != "partial\n" {
+		t.Errorf("Process() set run output: %q, but expectes: %q", output, "partial\n")
+	}
+}
+
+func TestProcessWithRunCommand(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	executorConfig := environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{})
+	executorConfig.Env = map[string]string{"API_TOKEN": "s3cr3t", "GREETING": "hello world"}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, executorConfig, "")
+	pipelineOptions := "--name=beam"
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_, _ = lc.CreateSourceCodeFile("package main\n\nfunc main() {\n}\n")
+
+	Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, goSdkEnv, pipelineOptions, "", "", "", nil, nil, nil)
+
+	status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+		t.Fatalf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_FINISHED)
+	}
+	executorBuilder, err := builder.SetupExecutorBuilder(lc, pipelineOptions, goSdkEnv)
+	if err != nil {
+		t.Fatalf("error during setup executor builder: %s", err.Error())
+	}
+	executor := executorBuilder.
+		WithMemoryLimit(appEnvs.PipelineMemoryLimit()).
+		WithCpuTimeLimit(appEnvs.PipelineCpuTimeLimit()).
+		WithNetworkIsolation(appEnvs.NetworkIsolation(), appEnvs.SandboxCmd()).
+		WithEnv(pipelineEnv(executorConfig.Env, pipelineId, lc)).
+		Build()
+	runCmd := executor.Run(ctx)
+	wantCommand := commandLine(runCmd)
+	runCommand, err := GetRunCommand(ctx, cacheService, pipelineId, "")
+	if err != nil {
+		t.Fatalf("GetRunCommand() error: %s", err.Error())
+	}
+	if runCommand != wantCommand {
+		t.Errorf("Process() set run command: %s, but expectes: %s", runCommand, wantCommand)
+	}
+	if !strings.HasSuffix(runCommand, strings.Join(runCmd.Args, " ")) {
+		t.Errorf("Process() set run command: %s, but expectes it to end with the command and args: %s", runCommand, runCmd.Args)
+	}
+	if strings.Contains(runCommand, "s3cr3t") || !strings.Contains(runCommand, "API_TOKEN='<redacted>'") {
+		t.Errorf("Process() set run command with the secret which isn't redacted: %s", runCommand)
+	}
+	if !strings.Contains(runCommand, "GREETING='hello world'") {
+		t.Errorf("Process() set run command without the environment variable of the config: %s", runCommand)
+	}
+}
+
+func TestProcessWithConcurrentCompiledDirs(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	// the fake compiler copies the source file into the output folder as the compiled class after a pause,
+	// so the pipelines are compiled at the same time, and the fake JVM prints the class from the classpath
+	toolsDir := t.TempDir()
+	compiler := filepath.Join(toolsDir, "javac")
+	jvm := filepath.Join(toolsDir, "java")
+	compilerScript := "#!/bin/sh\nwhile [ $# -gt 1 ]; do if [ \"$1\" = \"-d\" ]; then out=\"$2\"; shift; fi; shift; done\nsleep 1\ncat \"$1\" > \"$out/HelloWorld.class\"\n"
+	jvmScript := "#!/bin/sh\nwhile [ $# -gt 1 ]; do if [ \"$1\" = \"-cp\" ]; then cp=\"$2\"; shift; fi; shift; done\ncat \"${cp%%:*}/$1.class\"\n"
+	if err := os.WriteFile(compiler, []byte(compilerScript), 0755); err != nil {
+		t.Fatalf("error during create compiler: %s", err.Error())
+	}
+	if err := os.WriteFile(jvm, []byte(jvmScript), 0755); err != nil {
+		t.Fatalf("error during create jvm: %s", err.Error())
+	}
+	javaSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_JAVA, environment.NewExecutorConfig(compiler, jvm, jvm, []string{"-d", "{compiledDir}"}, []string{"-cp", "{compiledDir}:"}, []string{"-cp", "{compiledDir}:", "JUnit"}), "")
+	ctx := context.Background()
+
+	pipelineIds := []uuid.UUID{uuid.New(), uuid.New()}
+	var wg sync.WaitGroup
+	for i, pipelineId := range pipelineIds {
+		lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, pipelineId, appEnvs.WorkingDir())
+		if err := lc.CreateFolders(); err != nil {
+			t.Fatalf("error during prepare folders: %s", err.Error())
+		}
+		code := fmt.Sprintf("class HelloWorld {\n    public static void main(String[] args) {\n        System.out.println(\"pipeline %d\");\n    }\n}\n", i)
+		if _, err := lc.CreateSourceCodeFile(code); err != nil {
+			t.Fatalf("error during create source file: %s", err.Error())
+		}
+		wg.Add(1)
+		go func(lc *fs_tool.LifeCycle, pipelineId uuid.UUID) {
+			defer wg.Done()
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, javaSdkEnv, "", "", "", "", nil, nil, nil)
+		}(lc, pipelineId)
+	}
+	wg.Wait()
+
+	for i, pipelineId := range pipelineIds {
+		status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+		if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+			t.Errorf("Process() set status of pipeline %d: %s, but expectes: %s", i, status, pb.Status_STATUS_FINISHED)
+			continue
+		}
+		output, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+		if !strings.Contains(output.(string), fmt.Sprintf("pipeline %d", i)) {
+			t.Errorf("Process() set run output of pipeline %d with the class of another pipeline: %s", i, output)
+		}
+	}
+}
+
+func TestProcessWithSdkVersion(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	// the fake toolchains of the versions print their versions instead of running the code
+	versions := map[string]*environment.ExecutorConfig{
+		"2.50": environment.NewExecutorConfig("", "sh", "", []string{}, []string{"-c", "echo 2.50"}, []string{}),
+		"2.55": environment.NewExecutorConfig("", "sh", "", []string{}, []string{"-c", "echo 2.55"}, []string{}),
+	}
+	sdkEnv := environment.NewVersionedBeamEnvs(pb.Sdk_SDK_PYTHON, versions, "")
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		sdkVersion        string
+		expectedStatus    pb.Status
+		expectedRunOutput string
+	}{
+		{
+			// Test case with calling Process method with the configured version of the SDK.
+			// As a result the code should be run with the toolchain of this version.
+			name:              "configured version",
+			sdkVersion:        "2.50",
+			expectedStatus:    pb.Status_STATUS_FINISHED,
+			expectedRunOutput: "2.50\n",
+		},
+		{
+			// Test case with calling Process method without the version of the SDK.
+			// As a result the code should be run with the toolchain of the latest version.
+			name:              "default version",
+			sdkVersion:        "",
+			expectedStatus:    pb.Status_STATUS_FINISHED,
+			expectedRunOutput: "2.55\n",
+		},
+		{
+			// Test case with calling Process method with the version which isn't configured for the SDK.
+			// As a result status should be set as Status_STATUS_VALIDATION_ERROR and the code shouldn't be run.
+			name:           "unknown version",
+			sdkVersion:     "2.0",
+			expectedStatus: pb.Status_STATUS_VALIDATION_ERROR,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile("print('MOCK')")
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, sdkEnv, "", "", tt.sdkVersion, "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Fatalf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			if tt.expectedStatus == pb.Status_STATUS_VALIDATION_ERROR {
+				validationOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.ValidationOutput)
+				if !strings.Contains(validationOutput.(string), tt.sdkVersion) {
+					t.Errorf("Process() set validationOutput: %s, but expectes the unknown version: %s", validationOutput, tt.sdkVersion)
+				}
+				return
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, tt.expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %s, but expectes: %s", runOutput, tt.expectedRunOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithCompileTimeout(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name             string
+		compileScript    string
+		compileTimeout   string
+		expectedStatus   pb.Status
+		expectedCategory pb.ErrorCategory
+		expectedOutput   string
+	}{
+		{
+			// Test case with calling Process method with the compiler which works longer than the compile timeout.
+			// As a result the compiler should be stopped and status should be set as Status_STATUS_COMPILE_TIMEOUT.
+			name:             "slow compiler",
+			compileScript:    "echo compiling >&2; exec sleep 10",
+			compileTimeout:   "200ms",
+			expectedStatus:   pb.Status_STATUS_COMPILE_TIMEOUT,
+			expectedCategory: pb.ErrorCategory_ERROR_CATEGORY_TIMEOUT,
+			expectedOutput:   "error: compilation timed out after 200ms, output: compiling\n",
+		},
+		{
+			// Test case with calling Process method with the compiler which fails before the compile timeout.
+			// As a result status should be set as Status_STATUS_COMPILE_ERROR.
+			name:             "compile error before timeout",
+			compileScript:    "echo MOCK_COMPILE_ERROR >&2; exit 1",
+			compileTimeout:   "10s",
+			expectedStatus:   pb.Status_STATUS_COMPILE_ERROR,
+			expectedCategory: pb.ErrorCategory_ERROR_CATEGORY_COMPILE,
+			expectedOutput:   "error: exit status 1, output: MOCK_COMPILE_ERROR\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the fake compiler ignores the names of the output and source files which are passed after the script
+			executorConfig := environment.NewExecutorConfig("sh", "", "", []string{"-c", tt.compileScript}, []string{}, []string{})
+			executorConfig.CompileTimeout = tt.compileTimeout
+			goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, executorConfig, "")
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile("package main\n\nfunc main() {}\n")
+
+			startTime := time.Now()
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, goSdkEnv, "", "", "", "", nil, nil, nil)
+
+			if elapsed := time.Since(startTime); elapsed > 5*time.Second {
+				t.Errorf("Process() works %s, but the compile step should be stopped by the compile timeout", elapsed)
+			}
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			category, _ := cacheService.GetValue(ctx, pipelineId, cache.ErrorCategory)
+			if !reflect.DeepEqual(category, tt.expectedCategory) {
+				t.Errorf("Process() set errorCategory: %s, but expectes: %s", category, tt.expectedCategory)
+			}
+			compileOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.CompileOutput)
+			if !reflect.DeepEqual(compileOutput, tt.expectedOutput) {
+				t.Errorf("Process() set compileOutput: %s, but expectes: %s", compileOutput, tt.expectedOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithRetries(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	ctx := context.Background()
+	transientFailure := "echo 'Error occurred during initialization of VM' >&2; exit 1"
+	codeFailure := "echo 'Exception in thread \"main\" java.lang.RuntimeException' >&2; exit 1"
+
+	tests := []struct {
+		name string
+		// failures is the number of the first attempts which fail with failureScript
+		failures         int
+		failureScript    string
+		retries          int
+		expectedStatus   pb.Status
+		expectedAttempts int
+	}{
+		{
+			// Test case with calling Process method with the executor which fails once because of the transient failure.
+			// As a result the run step should be retried exactly once and status should be set as Status_STATUS_FINISHED.
+			name:             "transient failure is retried",
+			failures:         1,
+			failureScript:    transientFailure,
+			retries:          2,
+			expectedStatus:   pb.Status_STATUS_FINISHED,
+			expectedAttempts: 2,
+		},
+		{
+			// Test case with calling Process method with the executor which always fails because of the transient failure.
+			// As a result the run step should be retried the number of retries times and status should be set as Status_STATUS_RUN_ERROR.
+			name:             "transient failure after all retries",
+			failures:         3,
+			failureScript:    transientFailure,
+			retries:          1,
+			expectedStatus:   pb.Status_STATUS_RUN_ERROR,
+			expectedAttempts: 2,
+		},
+		{
+			// Test case with calling Process method with the code which fails because of the exception.
+			// As a result the run step shouldn't be retried and status should be set as Status_STATUS_RUN_ERROR.
+			name:             "code failure isn't retried",
+			failures:         1,
+			failureScript:    codeFailure,
+			retries:          2,
+			expectedStatus:   pb.Status_STATUS_RUN_ERROR,
+			expectedAttempts: 1,
+		},
+		{
+			// Test case with calling Process method with the executor which fails because of the transient failure when retries are disabled.
+			// As a result the run step shouldn't be retried and status should be set as Status_STATUS_RUN_ERROR.
+			name:             "retries are disabled",
+			failures:         1,
+			failureScript:    transientFailure,
+			retries:          0,
+			expectedStatus:   pb.Status_STATUS_RUN_ERROR,
+			expectedAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the fake executor counts its attempts in the file and fails during the first attempts
+			attemptsFile := filepath.Join(t.TempDir(), "attempts")
+			script := fmt.Sprintf("echo attempt >> %s; if [ $(wc -l < %s) -le %d ]; then %s; fi; echo done", attemptsFile, attemptsFile, tt.failures, tt.failureScript)
+			sdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "sh", "", []string{}, []string{"-c", script}, []string{}), "")
+			appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+				options.PipelineStartRetries = tt.retries
+				options.PipelineRetryBackoff = 10 * time.Millisecond
+			})
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, appEnv.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile("print('MOCK')")
+
+			Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), lc, pipelineId, appEnv, sdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			attempts, err := os.ReadFile(attemptsFile)
+			if err != nil {
+				t.Fatalf("error during read attempts: %s", err.Error())
+			}
+			if got := strings.Count(string(attempts), "attempt"); got != tt.expectedAttempts {
+				t.Errorf("Process() runs the code %d times, but expectes: %d", got, tt.expectedAttempts)
+			}
+			if tt.expectedStatus == pb.Status_STATUS_FINISHED {
+				runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+				if !reflect.DeepEqual(runOutput, "done\n") {
+					t.Errorf("Process() set runOutput: %s, but expectes: %s", runOutput, "done\n")
+				}
+			}
+		})
+	}
+}
+
+func TestProcessWithNetworkIsolation(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	// network namespace could be created only with CAP_SYS_ADMIN
+	checkCmd := exec.Command("true")
+	checkCmd.SysProcAttr = &syscall.SysProcAttr{Cloneflags: syscall.CLONE_NEWNET}
+	if err := checkCmd.Run(); err != nil {
+		t.Skipf("network namespace couldn't be created: %s", err.Error())
+	}
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error during listen: %s", err.Error())
+	}
+	defer listener.Close()
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	dialCode := fmt.Sprintf("package main\n\nimport (\n\t\"fmt\"\n\t\"net\"\n\t\"os\"\n)\n\nfunc main() {\n\tconn, err := net.Dial(\"tcp\", %q)\n\tif err != nil {\n\t\tfmt.Println(err)\n\t\tos.Exit(1)\n\t}\n\tconn.Close()\n\tfmt.Println(\"connected\")\n}\n", listener.Addr().String())
+	ctx := context.Background()
+
+	tests := []struct {
+		name             string
+		networkIsolation bool
+		expectedStatus   pb.Status
+	}{
+		{
+			// Test case with calling Process method with code which opens a socket without network isolation.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:             "network isn't isolated",
+			networkIsolation: false,
+			expectedStatus:   pb.Status_STATUS_FINISHED,
+		},
+		{
+			// Test case with calling Process method with code which opens a socket with network isolation.
+			// As a result status into cache should be set as Status_STATUS_RUN_ERROR since the socket couldn't be opened.
+			name:             "network is isolated",
+			networkIsolation: true,
+			expectedStatus:   pb.Status_STATUS_RUN_ERROR,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+				options.NetworkIsolation = tt.networkIsolation
+				options.SandboxCmd = nil
+			})
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(dialCode)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), lc, pipelineId, appEnv, goSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				runError, _ := cacheService.GetValue(ctx, pipelineId, cache.RunError)
+				t.Errorf("Process() set status: %s, but expectes: %s, run error: %v", status, tt.expectedStatus, runError)
+			}
+		})
+	}
+}
+
+func TestProcessDeletesFolders(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	code := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello World\")\n}\n"
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		keepPipelineFiles bool
+		wantFolderExists  bool
+	}{
+		{
+			// Test case with calling Process method with the code which is finished successfully.
+			// As a result the folder of the pipeline should be deleted.
+			name:              "files aren't kept",
+			keepPipelineFiles: false,
+			wantFolderExists:  false,
+		},
+		{
+			// Test case with calling Process method with the code which is finished successfully
+			//	when the files of the pipeline should be kept for debugging.
+			// As a result the folder of the pipeline should be kept.
+			name:              "files are kept",
+			keepPipelineFiles: true,
+			wantFolderExists:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.KeepPipelineFiles = tt.keepPipelineFiles })
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			defer lc.Cleanup()
+			_, _ = lc.CreateSourceCodeFile(code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), lc, pipelineId, appEnv, goSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_FINISHED)
+			}
+			_, err := os.Stat(lc.Folder.BaseFolder)
+			if folderExists := err == nil; folderExists != tt.wantFolderExists {
+				t.Errorf("Process() folder of the pipeline exists: %v, but expectes: %v", folderExists, tt.wantFolderExists)
+			}
+		})
+	}
+}
+
+func TestProcessWithRunOutputFlushPolicy(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	code := "for i in range(20000):\n    print(\"line %d\" % i, flush=True)\n"
+	var fullOutput strings.Builder
+	for i := 0; i < 20000; i++ {
+		fullOutput.WriteString(fmt.Sprintf("line %d\n", i))
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		flushLines    int
+		flushInterval time.Duration
+	}{
+		{
+			// Test case with calling Process method with the code which prints a lot of lines
+			//	when the run output is written to the cache line by line.
+			// As a result, the full output should be kept in the cache.
+			name: "line by line",
+		},
+		{
+			// Test case with calling Process method with the code which prints a lot of lines
+			//	when the run output is written to the cache by batches of lines.
+			// As a result, the full output should be kept in the cache.
+			name:       "flush lines",
+			flushLines: 500,
+		},
+		{
+			// Test case with calling Process method with the code which prints a lot of lines
+			//	when the run output is written to the cache by batches of lines or by the interval.
+			// As a result, the full output should be kept in the cache.
+			name:          "flush lines and interval",
+			flushLines:    500,
+			flushInterval: time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+				options.NetworkIsolation = false
+				options.SandboxCmd = nil
+				options.KeepPipelineFiles = false
+				options.RunOutputFlushLines = tt.flushLines
+				options.RunOutputFlushInterval = tt.flushInterval
+			})
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			defer lc.Cleanup()
+			_, _ = lc.CreateSourceCodeFile(code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), lc, pipelineId, appEnv, pythonSdkEnv, "", "", "", "", nil, nil, nil)
+
+			processingStatus, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(processingStatus, pb.Status_STATUS_FINISHED) {
+				t.Fatalf("Process() set status: %s, but expectes: %s", processingStatus, pb.Status_STATUS_FINISHED)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if output, _ := runOutput.(string); output != fullOutput.String() {
+				t.Errorf("Process() set runOutput of %d bytes, but expectes the same output of %d bytes", len(output), fullOutput.Len())
+			}
+		})
+	}
+}
+
+func TestProcessWithSpillRunOutput(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	code := "for i in range(100):\n    print(\"line %d\" % i)\n"
+	var fullOutput strings.Builder
+	for i := 0; i < 100; i++ {
+		fullOutput.WriteString(fmt.Sprintf("line %d\n", i))
+	}
+	maxOutputSize := 64
+	truncatedOutput := fullOutput.String()[:maxOutputSize] + utils.TruncatedOutputMarker(fullOutput.Len()-maxOutputSize)
+	ctx := context.Background()
+
+	tests := []struct {
+		name               string
+		spillRunOutput     bool
+		expectedFullOutput string
+	}{
+		{
+			// Test case with calling Process method with the code which prints more than the max output size.
+			// As a result, the truncated output should be kept in the cache and the full output shouldn't be kept.
+			name:               "run output isn't spilled",
+			spillRunOutput:     false,
+			expectedFullOutput: truncatedOutput,
+		},
+		{
+			// Test case with calling Process method with the code which prints more than the max output size
+			//	when the run output should be spilled to the file.
+			// As a result, the truncated output should be kept in the cache and the full output should be kept in the file.
+			name:               "run output is spilled",
+			spillRunOutput:     true,
+			expectedFullOutput: fullOutput.String(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+				options.MaxOutputSize = maxOutputSize
+				options.NetworkIsolation = false
+				options.SandboxCmd = nil
+				options.KeepPipelineFiles = true
+				options.SpillRunOutput = tt.spillRunOutput
+			})
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			defer lc.Cleanup()
+			_, _ = lc.CreateSourceCodeFile(code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), lc, pipelineId, appEnv, pythonSdkEnv, "", "", "", "", nil, nil, nil)
+
+			processingStatus, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(processingStatus, pb.Status_STATUS_FINISHED) {
+				t.Fatalf("Process() set status: %s, but expectes: %s", processingStatus, pb.Status_STATUS_FINISHED)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, truncatedOutput) {
+				t.Errorf("Process() set runOutput: %q, but expectes: %q", runOutput, truncatedOutput)
+			}
+			reader, err := GetFullOutputReader(ctx, cacheService, pipelineId, "")
+			if err != nil {
+				t.Fatalf("GetFullOutputReader() error = %v", err)
+			}
+			full, err := io.ReadAll(reader)
+			_ = reader.Close()
+			if err != nil {
+				t.Fatalf("error during read the full output: %s", err.Error())
+			}
+			if string(full) != tt.expectedFullOutput {
+				t.Errorf("GetFullOutputReader() read: %q, but expectes: %q", full, tt.expectedFullOutput)
+			}
+
+			// the file with the full output is deleted together with the folder of the pipeline
+			if err := lc.Cleanup(); err != nil {
+				t.Fatalf("error during delete folders: %s", err.Error())
+			}
+			_, err = GetFullOutputReader(ctx, cacheService, pipelineId, "")
+			if tt.spillRunOutput && status.Code(err) != codes.NotFound {
+				t.Errorf("GetFullOutputReader() error = %v, but expectes NotFound after the folder of the pipeline is deleted", err)
+			}
+		})
+	}
+}
+
+func TestProcessWithBeamPipelineWarning(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	helloWorld := "print('Hello World!')\n"
+	pipeline := "import apache_beam as beam\n\nwith beam.Pipeline() as p:\n    p | beam.Create(['Hello', 'Beam']) | beam.Map(print)\n"
+	ctx := context.Background()
+
+	tests := []struct {
+		name                string
+		code                string
+		warnMissingPipeline bool
+		expectedWarnings    []string
+	}{
+		{
+			// Test case with calling Process method with the code which doesn't construct a Beam pipeline
+			//	when the missing pipeline isn't checked.
+			// As a result, the list of warnings should be empty.
+			name:                "missing pipeline isn't checked",
+			code:                helloWorld,
+			warnMissingPipeline: false,
+			expectedWarnings:    []string{},
+		},
+		{
+			// Test case with calling Process method with the code which doesn't construct a Beam pipeline.
+			// As a result, the warning about the missing pipeline should be saved into cache.
+			name:                "pipeline is missing",
+			code:                helloWorld,
+			warnMissingPipeline: true,
+			expectedWarnings:    []string{validators.MissingBeamPipelineWarning(pb.Sdk_SDK_PYTHON)},
+		},
+		{
+			// Test case with calling Process method with the code which constructs a Beam pipeline.
+			// As a result, the list of warnings should be empty.
+			name:                "pipeline is constructed",
+			code:                pipeline,
+			warnMissingPipeline: true,
+			expectedWarnings:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executorConfig := environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{})
+			executorConfig.WarnMissingPipeline = tt.warnMissingPipeline
+			pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, executorConfig, "")
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, pythonSdkEnv, "", "", "", "", nil, nil, nil)
+
+			// the warnings are saved after the validation whether the code is run successfully or not
+			warnings, err := GetWarnings(ctx, cacheService, pipelineId, "")
+			if err != nil {
+				t.Fatalf("GetWarnings() error = %v", err)
+			}
+			if !reflect.DeepEqual(warnings, tt.expectedWarnings) {
+				t.Errorf("Process() set warnings: %v, but expectes: %v", warnings, tt.expectedWarnings)
+			}
+		})
+	}
+}
+
+func TestProcessWithDefaultPipelineOptions(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	executorConfig := environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{})
+	executorConfig.PipelineOptions = "--temp_location=/tmp/temp --name base"
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, executorConfig, "")
+	code := "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n\t\"strings\"\n)\n\nfunc main() {\n\tfmt.Println(strings.Join(os.Args[1:], \" \"))\n}\n"
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		pipelineOptions   string
+		expectedRunOutput string
+	}{
+		{
+			// Test case with calling Process method without pipeline options.
+			// As a result the code should receive default pipeline options from the config of the SDK.
+			name:              "default pipeline options",
+			pipelineOptions:   "",
+			expectedRunOutput: "--temp_location=/tmp/temp --name base\n",
+		},
+		{
+			// Test case with calling Process method with pipeline options which override and add options.
+			// As a result the code should receive merged pipeline options where values of the user override default values.
+			name:              "overridden pipeline options",
+			pipelineOptions:   "--name=user --input=/tmp/in",
+			expectedRunOutput: "--temp_location=/tmp/temp --name=user --input=/tmp/in\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, goSdkEnv, tt.pipelineOptions, "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_FINISHED)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, tt.expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %q, but expectes: %q", runOutput, tt.expectedRunOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithStdin(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	code := "package main\n\nimport (\n\t\"bufio\"\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc main() {\n\tline, _ := bufio.NewReader(os.Stdin).ReadString('\\n')\n\tfmt.Printf(\"input: %s\", line)\n}\n"
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		stdin             string
+		expectedRunOutput string
+	}{
+		{
+			// Test case with calling Process method with code which reads a line from stdin and input for the code.
+			// As a result run output into cache should contain the input.
+			name:              "with stdin",
+			stdin:             "hello playground\n",
+			expectedRunOutput: "input: hello playground\n",
+		},
+		{
+			// Test case with calling Process method with code which reads a line from stdin without input for the code.
+			// As a result run output into cache should contain empty input.
+			name:              "without stdin",
+			stdin:             "",
+			expectedRunOutput: "input: ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, goSdkEnv, "", tt.stdin, "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_FINISHED)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, tt.expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %q, but expectes: %q", runOutput, tt.expectedRunOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithPipelineId(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	ctx := context.Background()
+
+	tests := []struct {
+		name                    string
+		code                    string
+		pipelineOptions         string
+		expectedRunOutputFormat string
+	}{
+		{
+			// Test case with calling Process method with code which prints the environment variable with the pipeline ID.
+			// As a result run output into cache should contain the pipeline ID.
+			name:                    "pipeline id in env",
+			code:                    "import os\nprint(os.environ['PLAYGROUND_PIPELINE_ID'])\n",
+			expectedRunOutputFormat: "%s\n",
+		},
+		{
+			// Test case with calling Process method with code which prints its pipeline options with the pipeline ID token.
+			// As a result run output into cache should contain the pipeline options with the pipeline ID instead of the token.
+			name:                    "pipeline id token in pipeline options",
+			code:                    "import sys\nprint(' '.join(sys.argv[1:]))\n",
+			pipelineOptions:         "--temp_location=/tmp/${PLAYGROUND_PIPELINE_ID}/temp",
+			expectedRunOutputFormat: "--temp_location=/tmp/%s/temp\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := exec.LookPath(pythonSdkEnv.ExecutorConfig.RunCmd); err != nil {
+				t.Skipf("%s isn't installed", pythonSdkEnv.ExecutorConfig.RunCmd)
+			}
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, pythonSdkEnv, tt.pipelineOptions, "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_FINISHED)
+			}
+			expectedRunOutput := fmt.Sprintf(tt.expectedRunOutputFormat, pipelineId)
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %q, but expectes: %q", runOutput, expectedRunOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithOutputLimit(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	code := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfor i := 0; i < 10; i++ {\n\t\tfmt.Println(\"0123456789\")\n\t}\n}\n"
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		maxOutputSize     int
+		expectedRunOutput string
+	}{
+		{
+			// Test case with calling Process method with code which prints more than the max output size.
+			// As a result run output should be truncated and contain the number of omitted bytes.
+			name:              "output exceeds limit",
+			maxOutputSize:     25,
+			expectedRunOutput: "0123456789\n0123456789\n012\n... output truncated (85 bytes omitted)\n",
+		},
+		{
+			// Test case with calling Process method with code which prints less than the max output size.
+			// As a result run output should be kept as is.
+			name:              "output doesn't exceed limit",
+			maxOutputSize:     110,
+			expectedRunOutput: strings.Repeat("0123456789\n", 10),
+		},
+		{
+			// Test case with calling Process method without max output size.
+			// As a result run output should be kept as is.
+			name:              "output isn't limited",
+			maxOutputSize:     0,
+			expectedRunOutput: strings.Repeat("0123456789\n", 10),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.MaxOutputSize = tt.maxOutputSize })
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), lc, pipelineId, appEnv, goSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_FINISHED)
+			}
+			runOutput, err := GetProcessingOutput(ctx, cacheService, pipelineId, cache.RunOutput, "")
+			if err != nil {
+				t.Fatalf("GetProcessingOutput() error = %v", err)
+			}
+			if runOutput != tt.expectedRunOutput {
+				t.Errorf("Process() set runOutput: %q, but expectes: %q", runOutput, tt.expectedRunOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithPipelineOptions(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	code := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n}\n"
+	ctx := context.Background()
+
+	tests := []struct {
+		name                     string
+		pipelineOptions          string
+		allowedRunners           []string
+		expectedStatus           pb.Status
+		expectedValidationOutput interface{}
+	}{
+		{
+			// Test case with calling Process method with valid pipeline options.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:                     "valid pipeline options",
+			pipelineOptions:          "--runner=direct --output out.txt",
+			expectedStatus:           pb.Status_STATUS_FINISHED,
+			expectedValidationOutput: nil,
+		},
+		{
+			// Test case with calling Process method with runner which couldn't be used in the sandbox.
+			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR with the reason.
+			name:                     "unknown runner",
+			pipelineOptions:          "--runner=DataflowRunner",
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedValidationOutput: "runner DataflowRunner isn't allowed, only DirectRunner could be used",
+		},
+		{
+			// Test case with calling Process method with malformed pipeline options.
+			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR with the reason.
+			name:                     "malformed pipeline options",
+			pipelineOptions:          "runner=direct",
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedValidationOutput: "malformed pipeline option: runner=direct",
+		},
+		{
+			// Test case with calling Process method with runner which is allowed by the config of the SDK.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:                     "configured allowed runner",
+			pipelineOptions:          "--runner=direct",
+			allowedRunners:           []string{"direct"},
+			expectedStatus:           pb.Status_STATUS_FINISHED,
+			expectedValidationOutput: nil,
+		},
+		{
+			// Test case with calling Process method with runner which isn't allowed by the config of the SDK.
+			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR with the allowed runners.
+			name:                     "configured disallowed runner",
+			pipelineOptions:          "--runner=FlinkRunner",
+			allowedRunners:           []string{"direct"},
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedValidationOutput: "runner FlinkRunner isn't allowed, allowed runners: direct",
+		},
+		{
+			// Test case with calling Process method without runner and with the allowed runners in the config of the SDK.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:                     "no runner with configured allowed runners",
+			pipelineOptions:          "--output out.txt",
+			allowedRunners:           []string{"direct"},
+			expectedStatus:           pb.Status_STATUS_FINISHED,
+			expectedValidationOutput: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(code)
+			executorConfig := *goSdkEnv.ExecutorConfig
+			executorConfig.AllowedRunners = tt.allowedRunners
+			sdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, &executorConfig, "")
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, sdkEnv, tt.pipelineOptions, "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			validationOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.ValidationOutput)
+			if !reflect.DeepEqual(validationOutput, tt.expectedValidationOutput) {
+				t.Errorf("Process() set validationOutput: %s, but expectes: %s", validationOutput, tt.expectedValidationOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithSourceUrl(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/loop.go" {
+			http.Redirect(w, r, "/loop.go", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte("package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n}\n"))
+	}))
+	defer server.Close()
+	serverUrl, _ := url.Parse(server.URL)
+	sourceUrl := server.URL + "/main.go"
+	loopUrl := server.URL + "/loop.go"
+
+	tests := []struct {
+		name                     string
+		sourceUrl                string
+		appEnv                   *environment.ApplicationEnvs
+		expectedStatus           pb.Status
+		expectedRunOutput        interface{}
+		expectedValidationOutput interface{}
+	}{
+		{
+			// Test case with calling Process method with a link to the code from the allowed host.
+			// As a result the code should be downloaded and status into cache should be set as Status_STATUS_FINISHED.
+			name:      "code from allowed host",
+			sourceUrl: sourceUrl,
+			appEnv: withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+				options.SourceUrlAllowedHosts = []string{serverUrl.Hostname()}
+			}),
+			expectedStatus:           pb.Status_STATUS_FINISHED,
+			expectedRunOutput:        "Hello world!\n",
+			expectedValidationOutput: nil,
+		},
+		{
+			// Test case with calling Process method with a link to the code from the host which isn't allowed.
+			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR
+			// 	and validation output should contain the reason code why the code couldn't be downloaded.
+			name:                     "code from not allowed host",
+			sourceUrl:                sourceUrl,
+			appEnv:                   withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.SourceUrlAllowedHosts = nil }),
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedRunOutput:        nil,
+			expectedValidationOutput: fmt.Sprintf("failed to fetch the code from %s: %s: host %s isn't allowed", sourceUrl, fs_tool.SourceUrlHostNotAllowedReason, serverUrl.Hostname()),
+		},
+		{
+			// Test case with calling Process method with a link which is redirected to itself.
+			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR
+			// 	and validation output should contain the reason code of the exceeded limit.
+			name:      "redirect loop",
+			sourceUrl: loopUrl,
+			appEnv: withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+				options.SourceUrlAllowedHosts = []string{serverUrl.Hostname()}
+			}),
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedRunOutput:        nil,
+			expectedValidationOutput: fmt.Sprintf("failed to fetch the code from %s: %s: stopped after 5 redirects", loopUrl, fs_tool.SourceUrlTooManyRedirectsReason),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.sourceUrl)
+
+			Process(ctx, cacheService, NewWorkerPool(tt.appEnv.MaxConcurrentPipelines()), lc, pipelineId, tt.appEnv, goSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, tt.expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %s, but expectes: %s", runOutput, tt.expectedRunOutput)
+			}
+			validationOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.ValidationOutput)
+			if !reflect.DeepEqual(validationOutput, tt.expectedValidationOutput) {
+				t.Errorf("Process() set validationOutput: %s, but expectes: %s", validationOutput, tt.expectedValidationOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithMaxSourceSize(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	code := "print(\"ok\")\n"
+	ctx := context.Background()
+
+	tests := []struct {
+		name                     string
+		maxSourceSize            int
+		expectedStatus           pb.Status
+		expectedValidationOutput interface{}
+	}{
+		{
+			// Test case with calling Process method with code which is smaller than the max source size.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:                     "just under the limit",
+			maxSourceSize:            len(code) + 1,
+			expectedStatus:           pb.Status_STATUS_FINISHED,
+			expectedValidationOutput: nil,
+		},
+		{
+			// Test case with calling Process method with code which has the max source size.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:                     "at the limit",
+			maxSourceSize:            len(code),
+			expectedStatus:           pb.Status_STATUS_FINISHED,
+			expectedValidationOutput: nil,
+		},
+		{
+			// Test case with calling Process method with code which is larger than the max source size.
+			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR
+			//	and validation output should contain the size of the code.
+			name:                     "just over the limit",
+			maxSourceSize:            len(code) - 1,
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedValidationOutput: fmt.Sprintf("source too large: %d bytes, the max size is %d bytes", len(code), len(code)-1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.MaxSourceSize = tt.maxSourceSize })
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, env.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(code)
+
+			Process(ctx, cacheService, NewWorkerPool(env.MaxConcurrentPipelines()), lc, pipelineId, env, pythonSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			validationOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.ValidationOutput)
+			if !reflect.DeepEqual(validationOutput, tt.expectedValidationOutput) {
+				t.Errorf("Process() set validationOutput: %s, but expectes: %s", validationOutput, tt.expectedValidationOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithOutputPaths(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	code := "import sys\nprint(' '.join(sys.argv[1:]))\n"
+	env := withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+		options.OutputPathOptions = []string{"output"}
+		options.AllowedOutputPaths = []string{"/tmp/playground_output"}
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name                     string
+		pipelineOptions          string
+		expectedStatus           pb.Status
+		expectedRunOutput        string
+		expectedValidationOutput interface{}
+	}{
+		{
+			// Test case with calling Process method with the relative path of the output inside the folder of the pipeline.
+			// As a result status into cache should be set as Status_STATUS_FINISHED
+			//	and the code should receive the absolute path of the output in the folder of the pipeline.
+			name:              "path inside the folder of the pipeline",
+			pipelineOptions:   "--output counts/out.txt",
+			expectedStatus:    pb.Status_STATUS_FINISHED,
+			expectedRunOutput: "--output {baseFolder}/counts/out.txt\n",
+		},
+		{
+			// Test case with calling Process method with the absolute path of the output in the allowed output path.
+			// As a result status into cache should be set as Status_STATUS_FINISHED and the path should be kept.
+			name:              "path inside the allowed output path",
+			pipelineOptions:   "--output=/tmp/playground_output/counts",
+			expectedStatus:    pb.Status_STATUS_FINISHED,
+			expectedRunOutput: "--output=/tmp/playground_output/counts\n",
+		},
+		{
+			// Test case with calling Process method with the absolute path of the output outside the folder of the pipeline.
+			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR
+			//	and validation output should contain the rejected path.
+			name:                     "absolute path outside the folder of the pipeline",
+			pipelineOptions:          "--output=/etc/passwd",
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedValidationOutput: "output path is outside the folder of the pipeline: /etc/passwd",
+		},
+		{
+			// Test case with calling Process method with the relative path of the output which goes outside the folder of the pipeline.
+			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR
+			//	and validation output should contain the rejected path.
+			name:                     "relative traversal outside the folder of the pipeline",
+			pipelineOptions:          "--output=../../counts",
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedValidationOutput: "output path is outside the folder of the pipeline: ../../counts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := exec.LookPath(pythonSdkEnv.ExecutorConfig.RunCmd); err != nil {
+				t.Skipf("%s isn't installed", pythonSdkEnv.ExecutorConfig.RunCmd)
+			}
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, env.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(code)
+			baseFolder := lc.GetAbsoluteBaseFolderPath()
+
+			Process(ctx, cacheService, NewWorkerPool(env.MaxConcurrentPipelines()), lc, pipelineId, env, pythonSdkEnv, tt.pipelineOptions, "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			validationOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.ValidationOutput)
+			if !reflect.DeepEqual(validationOutput, tt.expectedValidationOutput) {
+				t.Errorf("Process() set validationOutput: %v, but expectes: %v", validationOutput, tt.expectedValidationOutput)
+			}
+			if tt.expectedStatus != pb.Status_STATUS_FINISHED {
+				return
+			}
+			expectedRunOutput := strings.ReplaceAll(tt.expectedRunOutput, "{baseFolder}", baseFolder)
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %q, but expectes: %q", runOutput, expectedRunOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithRunLogs(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		code              string
+		expectedStatus    pb.Status
+		expectedRunOutput interface{}
+		expectedRunLogs   interface{}
+		expectedRunError  interface{}
+		expectedExitCode  interface{}
+	}{
+		{
+			// Test case with calling Process method with code which writes to stdout and stderr and finishes successfully.
+			// As a result stdout should be set as run output, stderr should be set as run logs and run error shouldn't be set.
+			name:              "run logs of successful run",
+			code:              "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n\tfmt.Fprintln(os.Stderr, \"INFO: pipeline started\")\n}\n",
+			expectedStatus:    pb.Status_STATUS_FINISHED,
+			expectedRunOutput: "Hello world!\n",
+			expectedRunLogs:   "INFO: pipeline started\n",
+			expectedRunError:  nil,
+			expectedExitCode:  0,
+		},
+		{
+			// Test case with calling Process method with code which writes to stdout and stderr and exits with non-zero code.
+			// As a result stderr should be set both as run logs and as a part of run error.
+			name:              "run logs of failed run",
+			code:              "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n\tfmt.Fprintln(os.Stderr, \"ERROR: pipeline failed\")\n\tos.Exit(1)\n}\n",
+			expectedStatus:    pb.Status_STATUS_RUN_ERROR,
+			expectedRunOutput: "Hello world!\n",
+			expectedRunLogs:   "ERROR: pipeline failed\n",
+			expectedRunError:  "error: exit status 1, output: ERROR: pipeline failed\n",
+			expectedExitCode:  1,
+		},
+		{
+			// Test case with calling Process method with code which is killed by SIGKILL.
+			// As a result exit code should be set as 128 + the number of the signal
+			//	and run error should contain "out of memory" message since SIGKILL is sent by the OOM killer.
+			name:              "exit code of killed run",
+			code:              "package main\n\nimport (\n\t\"os\"\n\t\"syscall\"\n)\n\nfunc main() {\n\t_ = syscall.Kill(os.Getpid(), syscall.SIGKILL)\n\tselect {}\n}\n",
+			expectedStatus:    pb.Status_STATUS_RUN_ERROR,
+			expectedRunOutput: "",
+			expectedRunLogs:   "",
+			expectedRunError:  "error: out of memory, output: ",
+			expectedExitCode:  137,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, goSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, tt.expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %s, but expectes: %s", runOutput, tt.expectedRunOutput)
+			}
+			runLogs, _ := cacheService.GetValue(ctx, pipelineId, cache.RunLogs)
+			if !reflect.DeepEqual(runLogs, tt.expectedRunLogs) {
+				t.Errorf("Process() set runLogs: %s, but expectes: %s", runLogs, tt.expectedRunLogs)
+			}
+			runError, _ := cacheService.GetValue(ctx, pipelineId, cache.RunError)
+			if !reflect.DeepEqual(runError, tt.expectedRunError) {
+				t.Errorf("Process() set runError: %s, but expectes: %s", runError, tt.expectedRunError)
+			}
+			exitCode, _ := cacheService.GetValue(ctx, pipelineId, cache.RunExitCode)
+			if !reflect.DeepEqual(exitCode, tt.expectedExitCode) {
+				t.Errorf("Process() set exitCode: %v, but expectes: %v", exitCode, tt.expectedExitCode)
+			}
+		})
+	}
+}
+
+func TestProcessScio(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("BEAM_SDK", pb.Sdk_SDK_SCIO.String())
+	defer os.Setenv("BEAM_SDK", pb.Sdk_SDK_JAVA.String())
+	scioSdkEnvs, err := environment.ConfigureBeamEnvs(appEnvs.WorkingDir())
+	if err != nil {
+		panic(err)
+	}
+	scioSdkEnv := scioSdkEnvs[pb.Sdk_SDK_SCIO]
+	ctx := context.Background()
+
+	tests := []struct {
+		name                     string
+		code                     string
+		requiredCmds             []string
+		expectedStatus           pb.Status
+		expectedRunOutput        interface{}
+		expectedValidationOutput interface{}
+	}{
+		{
+			// Test case with calling Process method with SCIO code which uses a forbidden import.
+			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR.
+			name:                     "scio code with forbidden import",
+			code:                     "import scala.sys.process._\n\nobject HelloWorld {\n  def main(args: Array[String]): Unit = {\n    \"ls\".!\n  }\n}\n",
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedRunOutput:        nil,
+			expectedValidationOutput: "forbidden import scala.sys.process is used in %s.scala",
+		},
+		{
+			// Test case with calling Process method with SCIO code which is compiled with scalac and run on the JVM.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:                     "scio processing complete successfully",
+			code:                     "package com.spotify.scio.examples\n\nobject HelloWorld {\n  def main(cmdlineArgs: Array[String]): Unit = {\n    println(\"Hello world!\")\n  }\n}\n",
+			requiredCmds:             []string{scioSdkEnv.ExecutorConfig.CompileCmd, scioSdkEnv.ExecutorConfig.RunCmd},
+			expectedStatus:           pb.Status_STATUS_FINISHED,
+			expectedRunOutput:        "Hello world!\n",
+			expectedValidationOutput: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, cmd := range tt.requiredCmds {
+				if _, err := exec.LookPath(cmd); err != nil {
+					t.Skipf("%s isn't installed", cmd)
+				}
+			}
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_SCIO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, scioSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, tt.expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %s, but expectes: %s", runOutput, tt.expectedRunOutput)
+			}
+			expectedValidationOutput := tt.expectedValidationOutput
+			if expectedValidationOutput != nil {
+				expectedValidationOutput = fmt.Sprintf(expectedValidationOutput.(string), pipelineId)
+			}
+			validationOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.ValidationOutput)
+			if !reflect.DeepEqual(validationOutput, expectedValidationOutput) {
+				t.Errorf("Process() set validationOutput: %s, but expectes: %s", validationOutput, expectedValidationOutput)
+			}
+		})
+	}
+}
+
+func TestProcessKotlin(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("BEAM_SDK", pb.Sdk_SDK_KOTLIN.String())
+	defer os.Setenv("BEAM_SDK", pb.Sdk_SDK_JAVA.String())
+	kotlinSdkEnvs, err := environment.ConfigureBeamEnvs(appEnvs.WorkingDir())
+	if err != nil {
+		panic(err)
+	}
+	kotlinSdkEnv := kotlinSdkEnvs[pb.Sdk_SDK_KOTLIN]
+	ctx := context.Background()
+
+	tests := []struct {
+		name                     string
+		code                     string
+		requiredCmds             []string
+		expectedStatus           pb.Status
+		expectedRunOutput        interface{}
+		expectedValidationOutput interface{}
+	}{
+		{
+			// Test case with calling Process method with Kotlin code which uses a forbidden import.
+			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR.
+			name:                     "kotlin code with forbidden import",
+			code:                     "import java.lang.ProcessBuilder\n\nfun main(args: Array<String>) {\n    ProcessBuilder(\"ls\").start()\n}\n",
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedRunOutput:        nil,
+			expectedValidationOutput: "forbidden import java.lang.ProcessBuilder is used in %s.kt",
+		},
+		{
+			// Test case with calling Process method with Kotlin code which is compiled with kotlinc and run on the JVM.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:                     "kotlin processing complete successfully",
+			code:                     "fun main(args: Array<String>) {\n    println(\"Hello world!\")\n}\n",
+			requiredCmds:             []string{kotlinSdkEnv.ExecutorConfig.CompileCmd, kotlinSdkEnv.ExecutorConfig.RunCmd},
+			expectedStatus:           pb.Status_STATUS_FINISHED,
+			expectedRunOutput:        "Hello world!\n",
+			expectedValidationOutput: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, cmd := range tt.requiredCmds {
+				if _, err := exec.LookPath(cmd); err != nil {
+					t.Skipf("%s isn't installed", cmd)
+				}
+			}
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_KOTLIN, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, kotlinSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, tt.expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %s, but expectes: %s", runOutput, tt.expectedRunOutput)
+			}
+			expectedValidationOutput := tt.expectedValidationOutput
+			if expectedValidationOutput != nil {
+				expectedValidationOutput = fmt.Sprintf(expectedValidationOutput.(string), pipelineId)
+			}
+			validationOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.ValidationOutput)
+			if !reflect.DeepEqual(validationOutput, expectedValidationOutput) {
+				t.Errorf("Process() set validationOutput: %s, but expectes: %s", validationOutput, expectedValidationOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithUnboundedOutput(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	goSdkEnv.ExecutorConfig.ForbidUnboundedOutput = true
+	ctx := context.Background()
+
+	tests := []struct {
+		name                     string
+		code                     string
+		expectedStatus           pb.Status
+		expectedRunOutput        interface{}
+		expectedValidationOutput interface{}
+	}{
+		{
+			// Test case with calling Process method with code which prints the output in the infinite loop.
+			// As a result status into cache should be set as Status_STATUS_VALIDATION_ERROR
+			// 	and validation output should contain the matched loop.
+			name:                     "infinite loop with output",
+			code:                     "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfor {\n\t\tfmt.Println(\"Hello World!\")\n\t}\n}\n",
+			expectedStatus:           pb.Status_STATUS_VALIDATION_ERROR,
+			expectedRunOutput:        nil,
+			expectedValidationOutput: "unbounded output loop for { with fmt.Println is used in %s.go",
+		},
+		{
+			// Test case with calling Process method with code which prints the output in the bounded loop.
+			// As a result status into cache should be set as Status_STATUS_FINISHED.
+			name:                     "bounded loop with output",
+			code:                     "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfor i := 0; i < 3; i++ {\n\t\tfmt.Println(i)\n\t}\n}\n",
+			expectedStatus:           pb.Status_STATUS_FINISHED,
+			expectedRunOutput:        "0\n1\n2\n",
+			expectedValidationOutput: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, goSdkEnv, "", "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, tt.expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %s, but expectes: %s", runOutput, tt.expectedRunOutput)
+			}
+			expectedValidationOutput := tt.expectedValidationOutput
+			if expectedValidationOutput != nil {
+				expectedValidationOutput = fmt.Sprintf(expectedValidationOutput.(string), pipelineId)
+			}
+			validationOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.ValidationOutput)
+			if !reflect.DeepEqual(validationOutput, expectedValidationOutput) {
+				t.Errorf("Process() set validationOutput: %s, but expectes: %s", validationOutput, expectedValidationOutput)
+			}
+		})
+	}
+}
+
+func TestProcessWithWorkerPool(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	ctx := context.Background()
+	// prints the time of the start and the end of the run step
+	code := "package main\n\nimport (\n\t\"fmt\"\n\t\"time\"\n)\n\nfunc main() {\n\tfmt.Println(time.Now().UnixNano())\n\ttime.Sleep(500 * time.Millisecond)\n\tfmt.Println(time.Now().UnixNano())\n}\n"
+	pipelinesCount := 3
+	workerPool := NewWorkerPool(1)
+
+	var wg sync.WaitGroup
+	pipelineIds := make([]uuid.UUID, pipelinesCount)
+	for i := range pipelineIds {
+		pipelineId := uuid.New()
+		pipelineIds[i] = pipelineId
+		lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+		if err := lc.CreateFolders(); err != nil {
+			t.Fatalf("error during prepare folders: %s", err.Error())
+		}
+		_, _ = lc.CreateSourceCodeFile(code)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Process(ctx, cacheService, workerPool, lc, pipelineId, appEnvs, goSdkEnv, "", "", "", "", nil, nil, nil)
+		}()
+	}
+	wg.Wait()
+
+	type interval struct {
+		start, end int64
+	}
+	intervals := make([]interval, 0, pipelinesCount)
+	for _, pipelineId := range pipelineIds {
+		status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+		if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+			t.Fatalf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_FINISHED)
+		}
+		runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+		var runInterval interval
+		if _, err := fmt.Sscan(runOutput.(string), &runInterval.start, &runInterval.end); err != nil {
+			t.Fatalf("Process() set unexpected runOutput: %s", runOutput)
+		}
+		intervals = append(intervals, runInterval)
+	}
+	for i := range intervals {
+		for j := i + 1; j < len(intervals); j++ {
+			if intervals[i].start < intervals[j].end && intervals[j].start < intervals[i].end {
+				t.Errorf("Process() runs pipelines %s and %s at the same time, but the worker pool size is 1", pipelineIds[i], pipelineIds[j])
+			}
+		}
+	}
+}
+
+func TestProcessCanceledWhileQueued(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_, _ = lc.CreateSourceCodeFile("package main\n\nfunc main() {}\n")
+
+	// the only slot of the pool is taken, so the pipeline should be queued
+	workerPool := NewWorkerPool(1)
+	workerPool.tryAcquire()
+	defer workerPool.release()
+
+	processFinished := make(chan bool, 1)
+	go func() {
+		Process(ctx, cacheService, workerPool, lc, pipelineId, appEnvs, goSdkEnv, "", "", "", "", nil, nil, nil)
+		processFinished <- true
+	}()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+		if status == pb.Status_STATUS_QUEUED {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_QUEUED)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if err := CancelPipeline(ctx, cacheService, pipelineId, pb.CancelPhase_CANCEL_PHASE_UNSPECIFIED); err != nil {
+		t.Fatalf("error during set cancel flag: %s", err.Error())
+	}
+	<-processFinished
+
+	status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if !reflect.DeepEqual(status, pb.Status_STATUS_CANCELED) {
+		t.Errorf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_CANCELED)
+	}
+}
+
+func TestValidateAndCompile(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		code           string
+		expectedStatus pb.Status
+	}{
+		{
+			// Test case with calling ValidateAndCompile method with code which compiles successfully.
+			// As a result status into cache should be set as Status_STATUS_COMPILE_FINISHED and the code shouldn't be run.
+			name:           "code compiles",
+			code:           "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n}\n",
+			expectedStatus: pb.Status_STATUS_COMPILE_FINISHED,
+		},
+		{
+			// Test case with calling ValidateAndCompile method with code which doesn't compile.
+			// As a result status into cache should be set as Status_STATUS_COMPILE_ERROR and the code shouldn't be run.
+			name:           "code doesn't compile",
+			code:           "package main\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n}\n",
+			expectedStatus: pb.Status_STATUS_COMPILE_ERROR,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+
+			ValidateAndCompile(ctx, cacheService, lc, pipelineId, appEnvs, goSdkEnv, "")
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("ValidateAndCompile() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			if _, err := cacheService.GetValue(ctx, pipelineId, cache.CompileOutput); err != nil {
+				t.Errorf("ValidateAndCompile() didn't set compile output: %s", err.Error())
+			}
+			if runOutput, err := cacheService.GetValue(ctx, pipelineId, cache.RunOutput); err == nil {
+				t.Errorf("ValidateAndCompile() set run output: %s, but it shouldn't be set", runOutput)
+			}
+		})
+	}
+}
+
+func TestProcessLogsContainPipelineId(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_, _ = lc.CreateSourceCodeFile("package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n}\n")
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	ValidateAndCompile(ctx, cacheService, lc, pipelineId, appEnvs, goSdkEnv, "")
+	log.SetOutput(os.Stderr)
+
+	for _, phase := range []string{validatePhase, preparePhase, compilePhase, finishPhase} {
+		if !strings.Contains(logs.String(), fmt.Sprintf("phase=%s", phase)) {
+			t.Errorf("ValidateAndCompile() didn't log phase %s, logs: %s", phase, logs.String())
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		if !strings.Contains(line, fmt.Sprintf("pipelineId=%s sdk=%s", pipelineId, pb.Sdk_SDK_GO)) {
+			t.Errorf("ValidateAndCompile() logged line without pipelineId and sdk: %s", line)
+		}
+	}
+}
+
+func TestProcessWithoutCompileStep(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, appEnvs.WorkingDir())
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_, _ = lc.CreateSourceCodeFile("print(\"Hello world!\")\n")
+
+	Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, pythonSdkEnv, "", "", "", "", nil, nil, nil)
+
+	status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+		t.Fatalf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_FINISHED)
+	}
+	if compileTime, err := GetCompileTime(ctx, cacheService, pipelineId, ""); err == nil {
+		t.Errorf("Process() set compileTime: %s, but it shouldn't be set for SDK without compile step", compileTime)
+	}
+	if runTime, err := GetRunTime(ctx, cacheService, pipelineId, ""); err != nil || runTime <= 0 {
+		t.Errorf("Process() set runTime: %s, but expectes positive duration, err: %v", runTime, err)
+	}
+}
+
+func TestProcessStatusDuringSteps(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	// runs go commands (formatting of the code during preparation and compilation) after a delay,
+	//	so the statuses of the validate and compile steps could be polled
+	goPath, err := exec.LookPath("go")
+	if err != nil {
+		t.Skipf("%s isn't installed", "go")
+	}
+	binDir, err := os.MkdirTemp("", "slow_go")
+	if err != nil {
+		t.Fatalf("error during prepare the go wrapper: %s", err.Error())
+	}
+	defer os.RemoveAll(binDir)
+	wrapper := fmt.Sprintf("#!/bin/sh\nsleep 0.5\nexec %s \"$@\"\n", goPath)
+	if err := os.WriteFile(filepath.Join(binDir, "go"), []byte(wrapper), 0700); err != nil {
+		t.Fatalf("error during prepare the go wrapper: %s", err.Error())
+	}
+	path := os.Getenv("PATH")
+	_ = os.Setenv("PATH", binDir+string(os.PathListSeparator)+path)
+	defer os.Setenv("PATH", path)
+
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	ctx := context.Background()
+	// serves the code after a delay, so the status of the preparation of the files could be polled
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		switch r.URL.Path {
+		case "/main.go":
+			_, _ = w.Write([]byte("package main\n\nimport (\n\t\"fmt\"\n\t\"time\"\n)\n\nfunc main() {\n\ttime.Sleep(500 * time.Millisecond)\n\tfmt.Println(\"done\")\n}\n"))
+		case "/main.py":
+			_, _ = w.Write([]byte("import time\ntime.sleep(0.5)\nprint(\"done\")\n"))
+		}
+	}))
+	defer server.Close()
+	serverUrl, _ := url.Parse(server.URL)
+	appEnv := withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+		options.SourceUrlAllowedHosts = []string{serverUrl.Hostname()}
+	})
+
+	tests := []struct {
+		name            string
+		sdkEnv          *environment.BeamEnvs
+		sourceUrl       string
+		wantStatuses    []pb.Status
+		notWantStatuses []pb.Status
+	}{
+		{
+			// Test case with calling Process method with Go code which is downloaded, formatted, compiled and run with delays.
+			// As a result, want to poll playground.Status_STATUS_PREPARING while the code is downloaded,
+			//	playground.Status_STATUS_VALIDATING while it is validated and formatted, playground.Status_STATUS_COMPILING
+			//	during compilation, playground.Status_STATUS_EXECUTING during the run and playground.Status_STATUS_FINISHED at the end.
+			name:         "sdk with compile step",
+			sdkEnv:       goSdkEnv,
+			sourceUrl:    server.URL + "/main.go",
+			wantStatuses: []pb.Status{pb.Status_STATUS_PREPARING, pb.Status_STATUS_VALIDATING, pb.Status_STATUS_COMPILING, pb.Status_STATUS_EXECUTING, pb.Status_STATUS_FINISHED},
+		},
+		{
+			// Test case with calling Process method with Python code which is downloaded and run with delays.
+			// As a result, want to poll playground.Status_STATUS_PREPARING while the code is downloaded,
+			//	playground.Status_STATUS_EXECUTING during the run and playground.Status_STATUS_FINISHED at the end
+			//	without playground.Status_STATUS_COMPILING.
+			name:            "sdk without compile step",
+			sdkEnv:          pythonSdkEnv,
+			sourceUrl:       server.URL + "/main.py",
+			wantStatuses:    []pb.Status{pb.Status_STATUS_PREPARING, pb.Status_STATUS_EXECUTING, pb.Status_STATUS_FINISHED},
+			notWantStatuses: []pb.Status{pb.Status_STATUS_COMPILING},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(tt.sdkEnv.ApacheBeamSdk, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.sourceUrl)
+			_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_PREPARING)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				Process(ctx, cacheService, NewWorkerPool(appEnv.MaxConcurrentPipelines()), lc, pipelineId, appEnv, tt.sdkEnv, "", "", "", "", nil, nil, nil)
+			}()
+
+			// keeps the distinct statuses in the order they are polled
+			var polled []pb.Status
+			poll := func() {
+				status, err := GetProcessingStatus(ctx, cacheService, pipelineId, "")
+				if err == nil && (len(polled) == 0 || polled[len(polled)-1] != status) {
+					polled = append(polled, status)
+				}
+			}
+			ticker := time.NewTicker(10 * time.Millisecond)
+			defer ticker.Stop()
+			for finished := false; !finished; {
+				select {
+				case <-done:
+					finished = true
+				case <-ticker.C:
+				}
+				poll()
+			}
+
+			next := 0
+			for _, status := range polled {
+				if next < len(tt.wantStatuses) && status == tt.wantStatuses[next] {
+					next++
+				}
+				for _, notWant := range tt.notWantStatuses {
+					if status == notWant {
+						t.Errorf("Process() set status %s, but it isn't expected", status)
+					}
+				}
+			}
+			if next != len(tt.wantStatuses) {
+				t.Errorf("Process() set statuses %v, want them to contain %v in order", polled, tt.wantStatuses)
+			}
+			if last := polled[len(polled)-1]; last != pb.Status_STATUS_FINISHED {
+				t.Errorf("Process() set the final status %s, want %s", last, pb.Status_STATUS_FINISHED)
+			}
+		})
+	}
+}
+
+func TestProcessCancelWithGracePeriod(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{}), "")
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		code              string
+		gracePeriod       time.Duration
+		expectedRunOutput string
+		minDuration       time.Duration
+	}{
+		{
+			// Test case with canceling code which traps SIGTERM and prints a message before exiting.
+			// As a result, the message should be kept as run output and status should be playground.Status_STATUS_CANCELED.
+			name:              "code finishes after SIGTERM",
+			code:              "import signal\nimport sys\nimport time\n\ndef handler(signum, frame):\n    print(\"terminated gracefully\", flush=True)\n    sys.exit(0)\n\nsignal.signal(signal.SIGTERM, handler)\nprint(\"started\", flush=True)\ntime.sleep(60)\n",
+			gracePeriod:       5 * time.Second,
+			expectedRunOutput: "started\nterminated gracefully\n",
+			minDuration:       0,
+		},
+		{
+			// Test case with canceling code which ignores SIGTERM.
+			// As a result, the code should be killed after the grace period and status should be playground.Status_STATUS_CANCELED.
+			name:              "code is killed after grace period",
+			code:              "import signal\nimport time\n\nsignal.signal(signal.SIGTERM, signal.SIG_IGN)\nprint(\"started\", flush=True)\ntime.sleep(60)\n",
+			gracePeriod:       200 * time.Millisecond,
+			expectedRunOutput: "started\n",
+			minDuration:       200 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.PipelineCancelGracePeriod = tt.gracePeriod })
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, env.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+			_ = cacheService.SetValue(ctx, pipelineId, cache.Canceled, false)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				Process(ctx, cacheService, NewWorkerPool(env.MaxConcurrentPipelines()), lc, pipelineId, env, pythonSdkEnv, "", "", "", "", nil, nil, nil)
+			}()
+
+			// cancels the code processing as soon as the code is started
+			for {
+				runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+				if runOutput == "started\n" {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			canceledAt := time.Now()
+			_ = cacheService.SetValue(ctx, pipelineId, cache.Canceled, true)
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("Process() isn't finished after cancel")
+			}
+			if duration := time.Since(canceledAt); duration < tt.minDuration {
+				t.Errorf("Process() is finished in %s after cancel, but expected at least %s", duration, tt.minDuration)
+			}
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, pb.Status_STATUS_CANCELED) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_CANCELED)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, tt.expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %q, but expectes: %q", runOutput, tt.expectedRunOutput)
+			}
+		})
+	}
+}
+
+func TestProcessCancelDuringCompile(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	// the compiler is imitated by a script which starts a long process and waits for it as the scripts of the compilers do
+	compileCmd := filepath.Join(t.TempDir(), "slow_compiler")
+	if err := os.WriteFile(compileCmd, []byte("#!/bin/sh\necho compiling\nsleep 60 &\nwait\n"), 0700); err != nil {
+		t.Fatalf("error during write compile script: %s", err.Error())
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig(compileCmd, "", "", []string{}, []string{}, []string{}), "")
+	code := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n}\n"
+	// the grace period is longer than the expected duration of the cancel, so the compiler should stop on SIGTERM
+	gracePeriod := 10 * time.Second
+	env := withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.PipelineCancelGracePeriod = gracePeriod })
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, env.WorkingDir())
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_, _ = lc.CreateSourceCodeFile(code)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Process(ctx, cacheService, NewWorkerPool(env.MaxConcurrentPipelines()), lc, pipelineId, env, goSdkEnv, "", "", "", "", nil, nil, nil)
+	}()
+
+	// cancels the code processing as soon as the code is compiling
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+		if status == pb.Status_STATUS_COMPILING {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_COMPILING)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := CancelPipeline(ctx, cacheService, pipelineId, pb.CancelPhase_CANCEL_PHASE_UNSPECIFIED); err != nil {
+		t.Fatalf("CancelPipeline() error = %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Process() isn't finished after cancel during compile")
+	}
+	status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if !reflect.DeepEqual(status, pb.Status_STATUS_CANCELED) {
+		t.Errorf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_CANCELED)
+	}
+	if compileOutput, err := cacheService.GetValue(ctx, pipelineId, cache.CompileOutput); err == nil {
+		t.Errorf("Process() set compileOutput: %q, but expectes no compile output", compileOutput)
+	}
+}
+
+func TestProcessStreamCompileOutput(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	// the compiler is imitated by a script which prints the compile errors one by one and fails
+	compileCmd := filepath.Join(t.TempDir(), "failing_compiler")
+	script := "#!/bin/sh\necho 'main.go:4:2: first error' >&2\nsleep 1\necho 'main.go:5:2: second error' >&2\nexit 1\n"
+	if err := os.WriteFile(compileCmd, []byte(script), 0700); err != nil {
+		t.Fatalf("error during write compile script: %s", err.Error())
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig(compileCmd, "", "", []string{}, []string{}, []string{}), "")
+	code := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello world!\")\n}\n"
+	env := withOptions(appEnvs, func(options *environment.ApplicationOptions) { options.PipelineStartRetries = 0 })
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, env.WorkingDir())
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_, _ = lc.CreateSourceCodeFile(code)
+	// the status is saved before the code processing is started as the controller does
+	if err := cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_VALIDATING); err != nil {
+		t.Fatalf("error during set status: %s", err.Error())
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Process(ctx, cacheService, NewWorkerPool(env.MaxConcurrentPipelines()), lc, pipelineId, env, goSdkEnv, "", "", "", "", nil, nil, nil)
+	}()
+
+	// the first error should be received while the code is still compiling
+	var streamedOutput string
+	firstErrorWhileCompiling := false
+	err = WatchCompileOutput(ctx, cacheService, pipelineId, 10*time.Millisecond, "", func(status pb.Status, newOutput string) error {
+		streamedOutput += newOutput
+		if status == pb.Status_STATUS_COMPILING && strings.Contains(streamedOutput, "first error") && !strings.Contains(streamedOutput, "second error") {
+			firstErrorWhileCompiling = true
+		}
+		return nil
+	})
+	<-done
+	if err != nil {
+		t.Fatalf("WatchCompileOutput() error = %v", err)
+	}
+	if !firstErrorWhileCompiling {
+		t.Errorf("WatchCompileOutput() didn't receive the first error while the code is compiling, streamed output: %q", streamedOutput)
+	}
+	expectedStreamedOutput := "main.go:4:2: first error\nmain.go:5:2: second error\n"
+	if streamedOutput != expectedStreamedOutput {
+		t.Errorf("WatchCompileOutput() streamed output: %q, but expectes: %q", streamedOutput, expectedStreamedOutput)
+	}
+	status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if !reflect.DeepEqual(status, pb.Status_STATUS_COMPILE_ERROR) {
+		t.Errorf("Process() set status: %s, but expectes: %s", status, pb.Status_STATUS_COMPILE_ERROR)
+	}
+	compileOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.CompileOutput)
+	if !strings.HasPrefix(compileOutput.(string), "error: exit status 1, output: ") || !strings.Contains(compileOutput.(string), expectedStreamedOutput) {
+		t.Errorf("Process() set compileOutput: %q, but expectes the compile error with: %q", compileOutput, expectedStreamedOutput)
+	}
+}
+
+func TestProcessCompileWarnings(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	// the compiler is imitated by a script which prints the deprecation note of javac and compiles the code successfully
+	compileCmd := filepath.Join(t.TempDir(), "javac")
+	script := "#!/bin/sh\necho 'Note: HelloWorld.java uses or overrides a deprecated API.' >&2\necho 'Note: Recompile with -Xlint:deprecation for details.' >&2\n"
+	if err := os.WriteFile(compileCmd, []byte(script), 0700); err != nil {
+		t.Fatalf("error during write compile script: %s", err.Error())
+	}
+	javaSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_JAVA, environment.NewExecutorConfig(compileCmd, "java", "java", []string{"-d", "bin"}, []string{}, []string{}), "")
+	code := "class HelloWorld {\n    public static void main(String[] args) {\n        System.out.println(new Integer(1));\n    }\n}\n"
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, pipelineId, appEnvs.WorkingDir())
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_, _ = lc.CreateSourceCodeFile(code)
+
+	ValidateAndCompile(ctx, cacheService, lc, pipelineId, appEnvs, javaSdkEnv, "")
+
+	status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if !reflect.DeepEqual(status, pb.Status_STATUS_COMPILE_FINISHED) {
+		t.Fatalf("ValidateAndCompile() set status: %s, but expectes: %s", status, pb.Status_STATUS_COMPILE_FINISHED)
+	}
+	expectedWarnings := []*pb.CompileError{
+		{File: "HelloWorld.java", Severity: "warning", Message: "HelloWorld.java uses or overrides a deprecated API.\nRecompile with -Xlint:deprecation for details."},
+	}
+	compileWarnings, err := GetCompileWarnings(ctx, cacheService, pipelineId, "")
+	if err != nil {
+		t.Fatalf("GetCompileWarnings() error = %v", err)
+	}
+	if !reflect.DeepEqual(compileWarnings, expectedWarnings) {
+		t.Errorf("ValidateAndCompile() set compile warnings: %v, but expectes: %v", compileWarnings, expectedWarnings)
+	}
+	compileOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.CompileOutput)
+	if !strings.Contains(compileOutput.(string), "Note: HelloWorld.java uses or overrides a deprecated API.") {
+		t.Errorf("ValidateAndCompile() set compileOutput: %q, but expectes the full output of the compiler", compileOutput)
+	}
+	compileErrors, _ := cacheService.GetValue(ctx, pipelineId, cache.CompileErrors)
+	if !reflect.DeepEqual(compileErrors, []*pb.CompileError{}) {
+		t.Errorf("ValidateAndCompile() set compile errors: %v, but expectes no errors", compileErrors)
+	}
+}
+
+func TestProcessSourceFileName(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	// the compiler is imitated by a script which checks that the public class is declared in the file with the same name as javac does
+	compileCmd := filepath.Join(t.TempDir(), "javac")
+	script := "#!/bin/sh\nstatus=0\nfor file in \"$@\"; do\n" +
+		"  case \"$file\" in *.java) ;; *) continue ;; esac\n" +
+		"  class=$(sed -n 's/^public class \\([A-Za-z_][A-Za-z0-9_]*\\).*/\\1/p' \"$file\" | head -n 1)\n" +
+		"  if [ -n \"$class\" ] && [ \"$(basename \"$file\")\" != \"$class.java\" ]; then\n" +
+		"    echo \"$file:1: error: class $class is public, should be declared in a file named $class.java\" >&2\n" +
+		"    status=1\n" +
+		"  fi\n" +
+		"done\nexit $status\n"
+	if err := os.WriteFile(compileCmd, []byte(script), 0700); err != nil {
+		t.Fatalf("error during write compile script: %s", err.Error())
+	}
+	javaSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_JAVA, environment.NewExecutorConfig(compileCmd, "java", "java", []string{"-d", "bin"}, []string{}, []string{}), "")
+	code := "public class WordCount {\n    public static void main(String[] args) {\n        System.out.println(\"Hello world!\");\n    }\n}\n"
+	ctx := context.Background()
+
+	tests := []struct {
+		name               string
+		sourceFileName     string
+		expectedStatus     pb.Status
+		expectedErrorFile  string
+		expectedErrorCount int
+	}{
+		{
+			// Test case with calling ValidateAndCompile method with the code which is named after the pipeline id.
+			// As a result, want the code to be compiled since the public modifier of the class is removed.
+			name:           "file named after the pipeline id",
+			sourceFileName: "",
+			expectedStatus: pb.Status_STATUS_COMPILE_FINISHED,
+		},
+		{
+			// Test case with calling ValidateAndCompile method with the code which file is named after its public class.
+			// As a result, want the code to be compiled.
+			name:           "file named after the public class",
+			sourceFileName: "WordCount",
+			expectedStatus: pb.Status_STATUS_COMPILE_FINISHED,
+		},
+		{
+			// Test case with calling ValidateAndCompile method with the code which file isn't named after its public class.
+			// As a result, want to receive the compile error which references the file with the name set by the user.
+			name:               "file named differently from the public class",
+			sourceFileName:     "Main.java",
+			expectedStatus:     pb.Status_STATUS_COMPILE_ERROR,
+			expectedErrorFile:  "Main.java",
+			expectedErrorCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, pipelineId, appEnvs.WorkingDir())
+			defer lc.Cleanup()
+			if tt.sourceFileName != "" {
+				if err := lc.SetSourceFileName(tt.sourceFileName); err != nil {
+					t.Fatalf("SetSourceFileName() error = %v", err)
+				}
+			}
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(code)
+
+			ValidateAndCompile(ctx, cacheService, lc, pipelineId, appEnvs, javaSdkEnv, "")
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				compileOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.CompileOutput)
+				t.Fatalf("ValidateAndCompile() set status: %s, but expectes: %s, compile output: %s", status, tt.expectedStatus, compileOutput)
+			}
+			compileErrors, _ := cacheService.GetValue(ctx, pipelineId, cache.CompileErrors)
+			if len(compileErrors.([]*pb.CompileError)) != tt.expectedErrorCount {
+				t.Fatalf("ValidateAndCompile() set compile errors: %v, but expectes %d errors", compileErrors, tt.expectedErrorCount)
+			}
+			if tt.expectedErrorCount > 0 && filepath.Base(compileErrors.([]*pb.CompileError)[0].File) != tt.expectedErrorFile {
+				t.Errorf("ValidateAndCompile() set compile error of the file: %s, but expectes: %s", compileErrors.([]*pb.CompileError)[0].File, tt.expectedErrorFile)
+			}
+		})
+	}
+}
+
+// fakeExecutionBackend records the spec of the executed code and prints fake output instead of executing the code
+type fakeExecutionBackend struct {
+	spec     executors.ExecutionSpec
+	exitCode int
+	err      error
+	// waitStop makes the code run until it is stopped
+	waitStop bool
+	started  chan struct{}
+}
+
+func (b *fakeExecutionBackend) Run(ctx context.Context, spec executors.ExecutionSpec) (executors.ExecutionResult, error) {
+	b.spec = spec
+	_, _ = io.WriteString(spec.Stdout, "fake output\n")
+	_, _ = io.WriteString(spec.Stderr, "fake error\n")
+	close(b.started)
+	if b.waitStop {
+		select {
+		case <-spec.Stop:
+			return executors.ExecutionResult{ExitCode: 143}, fmt.Errorf("signal: terminated")
+		case <-ctx.Done():
+			return executors.ExecutionResult{ExitCode: 137}, ctx.Err()
+		}
+	}
+	return executors.ExecutionResult{ExitCode: b.exitCode}, b.err
+}
+
+func TestProcessWithExecutionBackend(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{"-u"}, []string{}), "")
+	env := withOptions(appEnvs, func(options *environment.ApplicationOptions) {
+		options.PipelineMemoryLimit = 256
+		options.PipelineCpuTimeLimit = 10
+		options.PipelineCancelGracePeriod = time.Second
+		options.PipelineStartRetries = 0
+	})
+	code := "print('Hello world!')\n"
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		backend           *fakeExecutionBackend
+		cancel            bool
+		expectedStatus    pb.Status
+		expectedCategory  interface{}
+		expectedRunOutput string
+		expectedExitCode  interface{}
+	}{
+		{
+			// Test case with calling Process method with the backend which executes the code successfully.
+			// As a result, the backend should receive the spec of the code and the status should be finished with the output of the backend.
+			name:              "successful run",
+			backend:           &fakeExecutionBackend{},
+			expectedStatus:    pb.Status_STATUS_FINISHED,
+			expectedRunOutput: "fake output\n",
+			expectedExitCode:  0,
+		},
+		{
+			// Test case with calling Process method with the backend which finishes the code with a non-zero exit code.
+			// As a result, the status should be run error with the exit code of the backend.
+			name:              "failed run",
+			backend:           &fakeExecutionBackend{exitCode: 3, err: fmt.Errorf("exit status 3")},
+			expectedStatus:    pb.Status_STATUS_RUN_ERROR,
+			expectedCategory:  pb.ErrorCategory_ERROR_CATEGORY_RUNTIME_EXCEPTION,
+			expectedRunOutput: "fake output\n",
+			expectedExitCode:  3,
+		},
+		{
+			// Test case with calling Process method with the backend which kills the code because it has run out of memory.
+			// As a result, the status should be run error with the OOM error category.
+			name:              "out of memory",
+			backend:           &fakeExecutionBackend{exitCode: 137, err: fmt.Errorf("%w: exit status 137", executors.ErrOutOfMemory)},
+			expectedStatus:    pb.Status_STATUS_RUN_ERROR,
+			expectedCategory:  pb.ErrorCategory_ERROR_CATEGORY_OOM,
+			expectedRunOutput: "fake output\n",
+			expectedExitCode:  137,
+		},
+		{
+			// Test case with calling Process method with the backend which fails to execute the code, i.e. the container couldn't be created.
+			// As a result, the status should be the error of the server instead of the run error.
+			name:              "backend failure",
+			backend:           &fakeExecutionBackend{exitCode: -1, err: fmt.Errorf("%w: the container isn't run", executors.ErrBackendFailure)},
+			expectedStatus:    pb.Status_STATUS_ERROR,
+			expectedCategory:  pb.ErrorCategory_ERROR_CATEGORY_INTERNAL,
+			expectedRunOutput: "fake output\n",
+			expectedExitCode:  nil,
+		},
+		{
+			// Test case with calling Process method with the backend which runs the code until it is stopped and canceling the code processing.
+			// As a result, the backend should be asked to stop the code and the status should be canceled with the output kept.
+			name:              "canceled run",
+			backend:           &fakeExecutionBackend{waitStop: true},
+			cancel:            true,
+			expectedStatus:    pb.Status_STATUS_CANCELED,
+			expectedCategory:  pb.ErrorCategory_ERROR_CATEGORY_CANCELLED,
+			expectedRunOutput: "fake output\n",
+			expectedExitCode:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, env.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(code)
+			tt.backend.started = make(chan struct{})
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				Process(ctx, cacheService, NewWorkerPool(env.MaxConcurrentPipelines()), lc, pipelineId, env, pythonSdkEnv, "--opt=value", "input\n", "", "", nil, nil, tt.backend)
+			}()
+			select {
+			case <-tt.backend.started:
+			case <-done:
+				t.Fatalf("Process() is finished without running the code by the backend")
+			}
+			if tt.cancel {
+				if err := CancelPipeline(ctx, cacheService, pipelineId, pb.CancelPhase_CANCEL_PHASE_UNSPECIFIED); err != nil {
+					t.Fatalf("CancelPipeline() error = %v", err)
+				}
+			}
+			<-done
+
+			spec := tt.backend.spec
+			if spec.Command != "python3" {
+				t.Errorf("Process() run the command: %s, but expectes: %s", spec.Command, "python3")
+			}
+			expectedArgs := []string{"-u", lc.GetAbsoluteExecutableFilePath(), "--opt=value"}
+			if !reflect.DeepEqual(spec.Args, expectedArgs) {
+				t.Errorf("Process() run the command with args: %v, but expectes: %v", spec.Args, expectedArgs)
+			}
+			pipelineIdEnv := executors.PipelineIdEnvKey + "=" + pipelineId.String()
+			if !containsEnv(spec.Env, pipelineIdEnv) {
+				t.Errorf("Process() run the command with env: %v, but expectes it to contain: %s", spec.Env, pipelineIdEnv)
+			}
+			if spec.WorkingDir != lc.GetAbsoluteBaseFolderPath() {
+				t.Errorf("Process() run the command in: %s, but expectes: %s", spec.WorkingDir, lc.GetAbsoluteBaseFolderPath())
+			}
+			if spec.Stdin != "input\n" {
+				t.Errorf("Process() run the command with stdin: %q, but expectes: %q", spec.Stdin, "input\n")
+			}
+			if spec.MemoryLimit != 256 || spec.CpuTimeLimit != 10 {
+				t.Errorf("Process() run the command with limits: %d MB, %d s, but expectes: %d MB, %d s", spec.MemoryLimit, spec.CpuTimeLimit, 256, 10)
+			}
+			if spec.StopGracePeriod != time.Second {
+				t.Errorf("Process() run the command with stop grace period: %s, but expectes: %s", spec.StopGracePeriod, time.Second)
+			}
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Process() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+			if !reflect.DeepEqual(runOutput, tt.expectedRunOutput) {
+				t.Errorf("Process() set runOutput: %q, but expectes: %q", runOutput, tt.expectedRunOutput)
+			}
+			category, _ := cacheService.GetValue(ctx, pipelineId, cache.ErrorCategory)
+			if !reflect.DeepEqual(category, tt.expectedCategory) {
+				t.Errorf("Process() set error category: %v, but expectes: %v", category, tt.expectedCategory)
+			}
+			exitCode, _ := cacheService.GetValue(ctx, pipelineId, cache.RunExitCode)
+			if !reflect.DeepEqual(exitCode, tt.expectedExitCode) {
+				t.Errorf("Process() set exitCode: %v, but expectes: %v", exitCode, tt.expectedExitCode)
+			}
+		})
+	}
+}
+
+func TestProcessWithDockerBackend(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	if err := exec.Command("docker", "info").Run(); err != nil {
+		t.Skipf("docker isn't available: %s", err.Error())
+	}
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	executorConfig := environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{})
+	executorConfig.DockerImage = "python:3.8-slim"
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, executorConfig, "")
+	code := "print('Hello world!')\n"
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, appEnvs.WorkingDir())
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_, _ = lc.CreateSourceCodeFile(code)
+
+	// Test case with calling Process method with the SDK which is run in a container of the docker image.
+	// As a result, the code should be run in the container and its output should be saved into cache.
+	Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, pythonSdkEnv, "", "", "", "", nil, nil, nil)
+
+	status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+		runError, _ := cacheService.GetValue(ctx, pipelineId, cache.RunError)
+		t.Fatalf("Process() set status: %s, but expectes: %s, run error: %v", status, pb.Status_STATUS_FINISHED, runError)
+	}
+	runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+	if !reflect.DeepEqual(runOutput, "Hello world!\n") {
+		t.Errorf("Process() set runOutput: %q, but expectes: %q", runOutput, "Hello world!\n")
+	}
+}
+
+func TestCancelPipeline(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	runningPipelineId := uuid.New()
+	compilingPipelineId := uuid.New()
+	finishedPipelineId := uuid.New()
+	_ = cacheService.SetValue(ctx, runningPipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+	_ = cacheService.SetValue(ctx, compilingPipelineId, cache.Status, pb.Status_STATUS_COMPILING)
+	_ = cacheService.SetValue(ctx, finishedPipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+
+	tests := []struct {
+		name              string
+		pipelineId        uuid.UUID
+		phase             pb.CancelPhase
+		wantCode          codes.Code
+		wantCanceled      bool
+		wantCanceledPhase interface{}
+	}{
+		{
+			// Test case with calling CancelPipeline method with pipelineId of the running code processing.
+			// As a result, want to receive cache.Canceled flag set to true.
+			name:         "running pipeline",
+			pipelineId:   runningPipelineId,
+			phase:        pb.CancelPhase_CANCEL_PHASE_UNSPECIFIED,
+			wantCode:     codes.OK,
+			wantCanceled: true,
+		},
+		{
+			// Test case with calling CancelPipeline method with pipelineId which doesn't exist in cache.
+			// As a result, want to receive NotFound error.
+			name:         "unknown pipeline",
+			pipelineId:   uuid.New(),
+			phase:        pb.CancelPhase_CANCEL_PHASE_UNSPECIFIED,
+			wantCode:     codes.NotFound,
+			wantCanceled: false,
+		},
+		{
+			// Test case with calling CancelPipeline method with pipelineId of the finished code processing.
+			// As a result, want to receive FailedPrecondition error and cache.Canceled flag isn't set.
+			name:         "finished pipeline",
+			pipelineId:   finishedPipelineId,
+			phase:        pb.CancelPhase_CANCEL_PHASE_UNSPECIFIED,
+			wantCode:     codes.FailedPrecondition,
+			wantCanceled: false,
+		},
+		{
+			// Test case with calling CancelPipeline method with the run phase of the compiled code.
+			// As a result, want to receive the run phase as cache.CanceledPhase and cache.Canceled flag isn't set.
+			name:              "run phase of the compiling pipeline",
+			pipelineId:        compilingPipelineId,
+			phase:             pb.CancelPhase_CANCEL_PHASE_RUN,
+			wantCode:          codes.OK,
+			wantCanceled:      false,
+			wantCanceledPhase: pb.CancelPhase_CANCEL_PHASE_RUN,
+		},
+		{
+			// Test case with calling CancelPipeline method with the compile phase of the running code.
+			// As a result, want to receive FailedPrecondition error and cache.CanceledPhase isn't set.
+			name:         "compile phase of the running pipeline",
+			pipelineId:   runningPipelineId,
+			phase:        pb.CancelPhase_CANCEL_PHASE_COMPILE,
+			wantCode:     codes.FailedPrecondition,
+			wantCanceled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CancelPipeline(ctx, cacheService, tt.pipelineId, tt.phase)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("CancelPipeline() error = %v, want code %s", err, tt.wantCode)
+			}
+			canceled, _ := cacheService.GetValue(ctx, tt.pipelineId, cache.Canceled)
+			if (canceled == true) != tt.wantCanceled {
+				t.Errorf("CancelPipeline() set canceled flag: %v, but expectes: %v", canceled, tt.wantCanceled)
+			}
+			canceledPhase, _ := cacheService.GetValue(ctx, tt.pipelineId, cache.CanceledPhase)
+			if !reflect.DeepEqual(canceledPhase, tt.wantCanceledPhase) {
+				t.Errorf("CancelPipeline() set canceled phase: %v, but expectes: %v", canceledPhase, tt.wantCanceledPhase)
+			}
+		})
+	}
+}
+
+func TestGetProcessingOutput(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	pipelineId := uuid.New()
+	incorrectConvertPipelineId := uuid.New()
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT")
+	if err != nil {
+		panic(err)
+	}
+	err = cacheService.SetValue(context.Background(), incorrectConvertPipelineId, cache.RunOutput, cache.RunOutput)
+	if err != nil {
+		panic(err)
+	}
+
+	type args struct {
+		ctx          context.Context
+		cacheService cache.Cache
+		key          uuid.UUID
+		subKey       cache.SubKey
+		errorTitle   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetProcessingOutput with pipelineId which doesn't contain run output.
+			// As a result, want to receive an error.
+			name: "get run output with incorrect pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          uuid.New(),
+				subKey:       cache.RunOutput,
+				errorTitle:   "",
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetProcessingOutput with pipelineId which contains incorrect run output.
+			// As a result, want to receive an error.
+			name: "get run output with incorrect run output",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          incorrectConvertPipelineId,
+				subKey:       cache.RunOutput,
+				errorTitle:   "",
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetProcessingOutput with pipelineId which contains run output.
+			// As a result, want to receive an expected string.
+			name: "get run output with correct pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          pipelineId,
+				subKey:       cache.RunOutput,
+				errorTitle:   "",
+			},
+			want:    "MOCK_RUN_OUTPUT",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetProcessingOutput(tt.args.ctx, tt.args.cacheService, tt.args.key, tt.args.subKey, tt.args.errorTitle)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetProcessingOutput() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetProcessingOutput() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetProcessingOutputSince(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	if err := cacheService.SetValue(ctx, pipelineId, cache.RunOutput, ""); err != nil {
+		panic(err)
+	}
+
+	// the output grows between the calls as it does while the code is run
+	steps := []struct {
+		appended   string
+		want       string
+		wantOffset int
+	}{
+		{appended: "", want: "", wantOffset: 0},
+		{appended: "first line\n", want: "first line\n", wantOffset: 11},
+		{appended: "", want: "", wantOffset: 11},
+		{appended: "second line\nthird", want: "second line\nthird", wantOffset: 28},
+		{appended: " line\n", want: " line\n", wantOffset: 34},
+	}
+	output := ""
+	offset := 0
+	for i, step := range steps {
+		output += step.appended
+		if err := cacheService.SetValue(ctx, pipelineId, cache.RunOutput, output); err != nil {
+			panic(err)
+		}
+		got, gotOffset, err := GetProcessingOutputSince(ctx, cacheService, pipelineId, cache.RunOutput, offset, "")
+		if err != nil {
+			t.Fatalf("GetProcessingOutputSince() step %d error = %v", i, err)
+		}
+		if got != step.want || gotOffset != step.wantOffset {
+			t.Errorf("GetProcessingOutputSince() step %d got = %q, %d, want %q, %d", i, got, gotOffset, step.want, step.wantOffset)
+		}
+		offset = gotOffset
+	}
+
+	// the offset is kept by the client, so the whole output could be received again from the beginning
+	if got, _, err := GetProcessingOutputSince(ctx, cacheService, pipelineId, cache.RunOutput, 0, ""); err != nil || got != output {
+		t.Errorf("GetProcessingOutputSince() from the beginning got = %q, %v, want %q", got, err, output)
+	}
+
+	tests := []struct {
+		name     string
+		key      uuid.UUID
+		offset   int
+		wantCode codes.Code
+	}{
+		{
+			// Test case with calling GetProcessingOutputSince with pipelineId which doesn't contain run output.
+			// As a result, want to receive NotFound error.
+			name:     "incorrect pipelineId",
+			key:      uuid.New(),
+			offset:   0,
+			wantCode: codes.NotFound,
+		},
+		{
+			// Test case with calling GetProcessingOutputSince with negative offset.
+			// As a result, want to receive InvalidArgument error.
+			name:     "negative offset",
+			key:      pipelineId,
+			offset:   -1,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			// Test case with calling GetProcessingOutputSince with offset after the end of the output.
+			// As a result, want to receive InvalidArgument error.
+			name:     "offset after the end of the output",
+			key:      pipelineId,
+			offset:   len(output) + 1,
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := GetProcessingOutputSince(ctx, cacheService, tt.key, cache.RunOutput, tt.offset, "")
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("GetProcessingOutputSince() error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestGetProcessingStatus(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	pipelineId := uuid.New()
+	incorrectConvertPipelineId := uuid.New()
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+	if err != nil {
+		panic(err)
+	}
+	err = cacheService.SetValue(context.Background(), incorrectConvertPipelineId, cache.Status, "MOCK_STATUS")
+	if err != nil {
+		panic(err)
+	}
+
+	type args struct {
+		ctx          context.Context
+		cacheService cache.Cache
+		key          uuid.UUID
+		errorTitle   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    pb.Status
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetProcessingStatus with pipelineId which doesn't contain status.
+			// As a result, want to receive an error.
+			name: "get status with incorrect pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          uuid.New(),
+				errorTitle:   "",
+			},
+			want:    pb.Status_STATUS_UNSPECIFIED,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetProcessingStatus with pipelineId which contains incorrect status value in cache.
+			// As a result, want to receive an error.
+			name: "get status with incorrect cache value",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          incorrectConvertPipelineId,
+				errorTitle:   "",
+			},
+			want:    pb.Status_STATUS_UNSPECIFIED,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetProcessingStatus with pipelineId which contains status.
+			// As a result, want to receive an expected status.
+			name: "get status with correct pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          pipelineId,
+				errorTitle:   "",
+			},
+			want:    pb.Status_STATUS_FINISHED,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetProcessingStatus(tt.args.ctx, tt.args.cacheService, tt.args.key, tt.args.errorTitle)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetProcessingStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetProcessingStatus() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// pollingCache is a cache which doesn't notify about changes of the status
+type pollingCache struct {
+	cache.Cache
+}
+
+func TestGetProcessingStatusWait(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		cacheService cache.Cache
+		lastStatus   pb.Status
+		timeout      time.Duration
+		// newStatus is set into cache after delay if it isn't unspecified
+		newStatus   pb.Status
+		delay       time.Duration
+		want        pb.Status
+		maxDuration time.Duration
+	}{
+		{
+			// Test case with calling GetProcessingStatusWait method when the status is changed after the delay.
+			// As a result, want to receive the new status as soon as it is set.
+			name:         "status is changed after delay",
+			ctx:          context.Background(),
+			cacheService: cacheService,
+			lastStatus:   pb.Status_STATUS_EXECUTING,
+			timeout:      5 * time.Second,
+			newStatus:    pb.Status_STATUS_FINISHED,
+			delay:        100 * time.Millisecond,
+			want:         pb.Status_STATUS_FINISHED,
+			maxDuration:  time.Second,
+		},
+		{
+			// Test case with calling GetProcessingStatusWait method when the status already differs from the last status.
+			// As a result, want to receive the status without waiting.
+			name:         "status already differs",
+			ctx:          context.Background(),
+			cacheService: cacheService,
+			lastStatus:   pb.Status_STATUS_COMPILING,
+			timeout:      5 * time.Second,
+			want:         pb.Status_STATUS_EXECUTING,
+			maxDuration:  time.Second,
+		},
+		{
+			// Test case with calling GetProcessingStatusWait method when the status isn't changed before the timeout.
+			// As a result, want to receive the last status after the timeout.
+			name:         "timeout elapses",
+			ctx:          context.Background(),
+			cacheService: cacheService,
+			lastStatus:   pb.Status_STATUS_EXECUTING,
+			timeout:      100 * time.Millisecond,
+			want:         pb.Status_STATUS_EXECUTING,
+			maxDuration:  time.Second,
+		},
+		{
+			// Test case with calling GetProcessingStatusWait method with the canceled context.
+			// As a result, want to receive the last status without waiting for the timeout.
+			name:         "context is canceled",
+			ctx:          canceledCtx,
+			cacheService: cacheService,
+			lastStatus:   pb.Status_STATUS_EXECUTING,
+			timeout:      5 * time.Second,
+			want:         pb.Status_STATUS_EXECUTING,
+			maxDuration:  time.Second,
+		},
+		{
+			// Test case with calling GetProcessingStatusWait method with the cache which doesn't notify about changes of the status.
+			// As a result, want to receive the new status which is found by polling the cache.
+			name:         "cache without notifications",
+			ctx:          context.Background(),
+			cacheService: pollingCache{cacheService},
+			lastStatus:   pb.Status_STATUS_EXECUTING,
+			timeout:      5 * time.Second,
+			newStatus:    pb.Status_STATUS_FINISHED,
+			delay:        100 * time.Millisecond,
+			want:         pb.Status_STATUS_FINISHED,
+			maxDuration:  time.Second + pauseDuration,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			if err := cacheService.SetValue(context.Background(), pipelineId, cache.Status, pb.Status_STATUS_EXECUTING); err != nil {
+				t.Fatalf("error during set status: %s", err.Error())
+			}
+			var wg sync.WaitGroup
+			if tt.newStatus != pb.Status_STATUS_UNSPECIFIED {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					time.Sleep(tt.delay)
+					_ = cacheService.SetValue(context.Background(), pipelineId, cache.Status, tt.newStatus)
+				}()
+			}
+
+			start := time.Now()
+			got, err := GetProcessingStatusWait(tt.ctx, tt.cacheService, pipelineId, tt.lastStatus, tt.timeout, "")
+			elapsed := time.Since(start)
+			wg.Wait()
+			if err != nil {
+				t.Fatalf("GetProcessingStatusWait() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetProcessingStatusWait() got = %v, want %v", got, tt.want)
+			}
+			if elapsed > tt.maxDuration {
+				t.Errorf("GetProcessingStatusWait() returned after %s, but expectes no later than %s", elapsed, tt.maxDuration)
+			}
+		})
+	}
+
+	// Test case with calling GetProcessingStatusWait method with pipelineId which doesn't exist in cache.
+	// As a result, want to receive an error without waiting.
+	if _, err := GetProcessingStatusWait(context.Background(), cacheService, uuid.New(), pb.Status_STATUS_UNSPECIFIED, 5*time.Second, ""); status.Code(err) != codes.NotFound {
+		t.Errorf("GetProcessingStatusWait() error = %v, but expectes not found error", err)
+	}
+}
+
+// incompatibleVersionCache is a cache which values are saved by the newer version of the cache
+type incompatibleVersionCache struct {
+	cache.Cache
+}
+
+func (c incompatibleVersionCache) GetValue(_ context.Context, _ uuid.UUID, subKey cache.SubKey) (interface{}, error) {
+	return nil, fmt.Errorf("value with subKey: %s has version 2, but the supported version is 1: %w", subKey, cache.ErrIncompatibleVersion)
+}
+
+func TestGetProcessingValuesWithIncompatibleCacheVersion(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	pipelineId := uuid.New()
+	incompatibleCache := incompatibleVersionCache{cacheService}
+
+	// Test case with calling GetProcessingOutput with the value saved by the newer version of the cache.
+	// As a result, want to receive an internal error with "incompatible cache version".
+	runOutput, err := GetProcessingOutput(context.Background(), incompatibleCache, pipelineId, cache.RunOutput, "")
+	if status.Code(err) != codes.Internal || !strings.Contains(err.Error(), cache.ErrIncompatibleVersion.Error()) {
+		t.Errorf("GetProcessingOutput() error = %v, but expectes internal error with %q", err, cache.ErrIncompatibleVersion)
+	}
+	if runOutput != "" {
+		t.Errorf("GetProcessingOutput() got = %v, want empty output", runOutput)
+	}
+
+	// Test case with calling GetProcessingStatus with the status saved by the newer version of the cache.
+	// As a result, want to receive an internal error with "incompatible cache version".
+	gotStatus, err := GetProcessingStatus(context.Background(), incompatibleCache, pipelineId, "")
+	if status.Code(err) != codes.Internal || !strings.Contains(err.Error(), cache.ErrIncompatibleVersion.Error()) {
+		t.Errorf("GetProcessingStatus() error = %v, but expectes internal error with %q", err, cache.ErrIncompatibleVersion)
+	}
+	if gotStatus != pb.Status_STATUS_UNSPECIFIED {
+		t.Errorf("GetProcessingStatus() got = %v, want %v", gotStatus, pb.Status_STATUS_UNSPECIFIED)
+	}
+}
+
+func TestGetProcessingStatusOfEvictedPipeline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	isCompleted := func(status interface{}) bool {
+		value, ok := status.(pb.Status)
+		return ok && IsFinalStatus(value)
+	}
+	limitedCache := local.NewWithLimits(ctx, 1, 0, isCompleted)
+	oldestPipelineId := uuid.New()
+	newestPipelineId := uuid.New()
+	if err := limitedCache.SetValue(ctx, oldestPipelineId, cache.Status, pb.Status_STATUS_FINISHED); err != nil {
+		panic(err)
+	}
+	if err := limitedCache.SetValue(ctx, newestPipelineId, cache.Status, pb.Status_STATUS_EXECUTING); err != nil {
+		panic(err)
+	}
+
+	_, err := GetProcessingStatus(ctx, limitedCache, oldestPipelineId, "")
+	if err == nil {
+		t.Fatalf("GetProcessingStatus() of evicted pipeline should return an error")
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetProcessingStatus() error = %v, want not found error", err)
+	}
+	newestStatus, err := GetProcessingStatus(ctx, limitedCache, newestPipelineId, "")
+	if err != nil || newestStatus != pb.Status_STATUS_EXECUTING {
+		t.Errorf("GetProcessingStatus() of the newest pipeline got = %v, %v, want %v", newestStatus, err, pb.Status_STATUS_EXECUTING)
+	}
+}
+
+func TestGetProcessingOutputOfEvictedOutput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	isCompleted := func(status interface{}) bool {
+		value, ok := status.(pb.Status)
+		return ok && IsFinalStatus(value)
+	}
+	limitedCache := local.NewWithLimits(ctx, 0, 10, isCompleted)
+	inProgressPipelineId := uuid.New()
+	completedPipelineId := uuid.New()
+	newestPipelineId := uuid.New()
+	for pipelineId, pipelineStatus := range map[uuid.UUID]pb.Status{
+		inProgressPipelineId: pb.Status_STATUS_EXECUTING,
+		completedPipelineId:  pb.Status_STATUS_FINISHED,
+		newestPipelineId:     pb.Status_STATUS_EXECUTING,
+	} {
+		if err := limitedCache.SetValue(ctx, pipelineId, cache.Status, pipelineStatus); err != nil {
+			panic(err)
+		}
+	}
+	// the outputs of all pipelines exceed the global budget only when the output of the newest pipeline is written
+	for _, pipelineId := range []uuid.UUID{inProgressPipelineId, completedPipelineId, newestPipelineId} {
+		if err := limitedCache.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK"); err != nil {
+			panic(err)
+		}
+	}
+
+	_, err := GetProcessingOutput(ctx, limitedCache, completedPipelineId, cache.RunOutput, "")
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetProcessingOutput() of evicted output error = %v, want not found error", err)
+	}
+	for _, pipelineId := range []uuid.UUID{inProgressPipelineId, newestPipelineId} {
+		if output, err := GetProcessingOutput(ctx, limitedCache, pipelineId, cache.RunOutput, ""); err != nil || output != "MOCK" {
+			t.Errorf("GetProcessingOutput() of pipeline in progress got = %v, %v, want %v", output, err, "MOCK")
+		}
+	}
+}
+
+func TestGetCompileErrors(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	pipelineId := uuid.New()
+	incorrectConvertPipelineId := uuid.New()
+	compileErrors := []*pb.CompileError{{File: "MOCK_FILE", Line: 1, Column: 1, Severity: "error", Message: "MOCK_MESSAGE"}}
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.CompileErrors, compileErrors)
+	if err != nil {
+		panic(err)
+	}
+	err = cacheService.SetValue(context.Background(), incorrectConvertPipelineId, cache.CompileErrors, "MOCK_COMPILE_ERRORS")
+	if err != nil {
+		panic(err)
+	}
+
+	type args struct {
+		ctx          context.Context
+		cacheService cache.Cache
+		key          uuid.UUID
+		errorTitle   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*pb.CompileError
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetCompileErrors with pipelineId which doesn't contain compile errors.
+			// As a result, want to receive an error.
+			name: "get compile errors with incorrect pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          uuid.New(),
+				errorTitle:   "",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetCompileErrors with pipelineId which contains incorrect compile errors value in cache.
+			// As a result, want to receive an error.
+			name: "get compile errors with incorrect cache value",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          incorrectConvertPipelineId,
+				errorTitle:   "",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetCompileErrors with pipelineId which contains compile errors.
+			// As a result, want to receive an expected list of compile errors.
+			name: "get compile errors with correct pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          pipelineId,
+				errorTitle:   "",
+			},
+			want:    compileErrors,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetCompileErrors(tt.args.ctx, tt.args.cacheService, tt.args.key, tt.args.errorTitle)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetCompileErrors() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCompileErrors() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPipelineSnapshot(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	partialPipelineId := uuid.New()
+	withoutStatusPipelineId := uuid.New()
+	incorrectConvertPipelineId := uuid.New()
+	compileErrors := []*pb.CompileError{{File: "MOCK_FILE", Line: 1, Column: 1, Severity: "error", Message: "MOCK_MESSAGE"}}
+	values := map[uuid.UUID]map[cache.SubKey]interface{}{
+		pipelineId: {
+			cache.Status:           pb.Status_STATUS_RUN_ERROR,
+			cache.RunOutput:        "MOCK_RUN_OUTPUT",
+			cache.RunError:         "MOCK_RUN_ERROR",
+			cache.CompileOutput:    "MOCK_COMPILE_OUTPUT",
+			cache.ValidationOutput: "MOCK_VALIDATION_OUTPUT",
+			cache.CompileErrors:    compileErrors,
+			cache.RunOutputIndex:   5,
+			cache.ErrorCategory:    pb.ErrorCategory_ERROR_CATEGORY_RUNTIME_EXCEPTION,
+		},
+		partialPipelineId: {
+			cache.Status:    pb.Status_STATUS_EXECUTING,
+			cache.RunOutput: "MOCK_RUN_OUTPUT",
+		},
+		withoutStatusPipelineId: {
+			cache.RunOutput: "MOCK_RUN_OUTPUT",
+		},
+		incorrectConvertPipelineId: {
+			cache.Status:    pb.Status_STATUS_EXECUTING,
+			cache.RunOutput: 1,
+		},
+	}
+	for key, subKeys := range values {
+		for subKey, value := range subKeys {
+			if err := cacheService.SetValue(ctx, key, subKey, value); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name    string
+		key     uuid.UUID
+		want    *pb.GetPipelineSnapshotResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetPipelineSnapshot with pipelineId which doesn't exist in cache.
+			// As a result, want to receive an error.
+			name:    "get snapshot with incorrect pipelineId",
+			key:     uuid.New(),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetPipelineSnapshot with pipelineId which doesn't contain status.
+			// As a result, want to receive an error.
+			name:    "get snapshot without status",
+			key:     withoutStatusPipelineId,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetPipelineSnapshot with pipelineId which contains incorrect run output value in cache.
+			// As a result, want to receive an error.
+			name:    "get snapshot with incorrect cache value",
+			key:     incorrectConvertPipelineId,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetPipelineSnapshot with pipelineId which contains only status and run output.
+			// As a result, want to receive a snapshot with other values left empty.
+			name: "get snapshot of executing pipeline",
+			key:  partialPipelineId,
+			want: &pb.GetPipelineSnapshotResponse{
+				Status:    pb.Status_STATUS_EXECUTING,
+				RunOutput: "MOCK_RUN_OUTPUT",
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetPipelineSnapshot with pipelineId which contains all values.
+			// As a result, want to receive a snapshot with all values.
+			name: "get snapshot of failed pipeline",
+			key:  pipelineId,
+			want: &pb.GetPipelineSnapshotResponse{
+				Status:           pb.Status_STATUS_RUN_ERROR,
+				RunOutput:        "MOCK_RUN_OUTPUT",
+				RunError:         "MOCK_RUN_ERROR",
+				CompileOutput:    "MOCK_COMPILE_OUTPUT",
+				ValidationOutput: "MOCK_VALIDATION_OUTPUT",
+				CompileErrors:    compileErrors,
+				RunOutputIndex:   5,
+				ErrorCategory:    pb.ErrorCategory_ERROR_CATEGORY_RUNTIME_EXCEPTION,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetPipelineSnapshot(ctx, cacheService, tt.key, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetPipelineSnapshot() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetPipelineSnapshot() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTestResults(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	pipelineId := uuid.New()
+	incorrectConvertPipelineId := uuid.New()
+	testResults := []*pb.TestCase{{Name: "MOCK_NAME", Passed: false, FailureMessage: "MOCK_MESSAGE"}}
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.TestResults, testResults)
+	if err != nil {
+		panic(err)
+	}
+	err = cacheService.SetValue(context.Background(), incorrectConvertPipelineId, cache.TestResults, "MOCK_TEST_RESULTS")
+	if err != nil {
+		panic(err)
+	}
+
+	type args struct {
+		ctx          context.Context
+		cacheService cache.Cache
+		key          uuid.UUID
+		errorTitle   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*pb.TestCase
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetTestResults with pipelineId which doesn't contain test results.
+			// As a result, want to receive an error.
+			name: "get test results with incorrect pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          uuid.New(),
+				errorTitle:   "",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetTestResults with pipelineId which contains incorrect test results value in cache.
+			// As a result, want to receive an error.
+			name: "get test results with incorrect cache value",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          incorrectConvertPipelineId,
+				errorTitle:   "",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetTestResults with pipelineId which contains test results.
+			// As a result, want to receive an expected list of test results.
+			name: "get test results with correct pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          pipelineId,
+				errorTitle:   "",
+			},
+			want:    testResults,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTestResults(tt.args.ctx, tt.args.cacheService, tt.args.key, tt.args.errorTitle)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTestResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTestResults() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetLastIndex(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	pipelineId := uuid.New()
+	incorrectConvertPipelineId := uuid.New()
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutputIndex, 2)
+	if err != nil {
+		panic(err)
+	}
+	err = cacheService.SetValue(context.Background(), incorrectConvertPipelineId, cache.RunOutputIndex, "MOCK_LAST_INDEX")
+	if err != nil {
+		panic(err)
+	}
+
+	type args struct {
+		ctx          context.Context
+		cacheService cache.Cache
+		key          uuid.UUID
+		subKey       cache.SubKey
+		errorTitle   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetLastIndex with pipelineId which doesn't contain status.
+			// As a result, want to receive an error.
+			name: "get last index with incorrect pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          uuid.New(),
+				subKey:       cache.RunOutputIndex,
+				errorTitle:   "",
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetLastIndex with pipelineId which contains incorrect status value in cache.
+			// As a result, want to receive an error.
+			name: "get last index with incorrect cache value",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          incorrectConvertPipelineId,
+				subKey:       cache.RunOutputIndex,
+				errorTitle:   "",
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetLastIndex with pipelineId which contains status.
+			// As a result, want to receive an expected last index.
+			name: "get last index with correct pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          pipelineId,
+				subKey:       cache.RunOutputIndex,
+				errorTitle:   "",
+			},
+			want:    2,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetLastIndex(tt.args.ctx, tt.args.cacheService, tt.args.key, tt.args.subKey, tt.args.errorTitle)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLastIndex() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetLastIndex() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRunExitCode(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	pipelineId := uuid.New()
+	incorrectConvertPipelineId := uuid.New()
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.RunExitCode, 137)
+	if err != nil {
+		panic(err)
+	}
+	err = cacheService.SetValue(context.Background(), incorrectConvertPipelineId, cache.RunExitCode, "MOCK_EXIT_CODE")
+	if err != nil {
+		panic(err)
+	}
+
+	type args struct {
+		ctx          context.Context
+		cacheService cache.Cache
+		key          uuid.UUID
+		errorTitle   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetRunExitCode with pipelineId which doesn't contain exit code.
+			// As a result, want to receive an error.
+			name: "get exit code with incorrect pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          uuid.New(),
+				errorTitle:   "",
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRunExitCode with pipelineId which contains incorrect exit code value in cache.
+			// As a result, want to receive an error.
+			name: "get exit code with incorrect cache value",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          incorrectConvertPipelineId,
+				errorTitle:   "",
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRunExitCode with pipelineId which contains exit code.
+			// As a result, want to receive an expected exit code.
+			name: "get exit code with correct pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          pipelineId,
+				errorTitle:   "",
+			},
+			want:    137,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetRunExitCode(tt.args.ctx, tt.args.cacheService, tt.args.key, tt.args.errorTitle)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetRunExitCode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetRunExitCode() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetErrorCategory(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	pipelineId := uuid.New()
+	incorrectConvertPipelineId := uuid.New()
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.ErrorCategory, pb.ErrorCategory_ERROR_CATEGORY_RUNTIME_EXCEPTION)
+	if err != nil {
+		panic(err)
+	}
+	err = cacheService.SetValue(context.Background(), incorrectConvertPipelineId, cache.ErrorCategory, "MOCK_CATEGORY")
+	if err != nil {
+		panic(err)
+	}
+
+	type args struct {
+		ctx          context.Context
+		cacheService cache.Cache
+		key          uuid.UUID
+		errorTitle   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    pb.ErrorCategory
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetErrorCategory with pipelineId which doesn't contain error category.
+			// As a result, want to receive an error.
+			name: "get error category with incorrect pipelineId",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          uuid.New(),
+				errorTitle:   "",
+			},
+			want:    pb.ErrorCategory_ERROR_CATEGORY_UNSPECIFIED,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetErrorCategory with pipelineId which contains incorrect error category value in cache.
+			// As a result, want to receive an error.
+			name: "get error category with incorrect cache value",
+			args: args{
+				ctx:          context.Background(),
+				cacheService: cacheService,
+				key:          incorrectConvertPipelineId,
+				errorTitle:   "",
+			},
+			want:    pb.ErrorCategory_ERROR_CATEGORY_UNSPECIFIED,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetErrorCategory with pipelineId which contains error category.
+			// As a result, want to receive an expected error category.
+			name: "get error category with correct pipelineId",
 			args: args{
 				ctx:          context.Background(),
 				cacheService: cacheService,
 				key:          pipelineId,
 				errorTitle:   "",
 			},
-			want:    pb.Status_STATUS_FINISHED,
+			want:    pb.ErrorCategory_ERROR_CATEGORY_RUNTIME_EXCEPTION,
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := GetProcessingStatus(tt.args.ctx, tt.args.cacheService, tt.args.key, tt.args.errorTitle)
+			got, err := GetErrorCategory(tt.args.ctx, tt.args.cacheService, tt.args.key, tt.args.errorTitle)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("GetProcessingStatus() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetErrorCategory() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if got != tt.want {
-				t.Errorf("GetProcessingStatus() got = %v, want %v", got, tt.want)
+				t.Errorf("GetErrorCategory() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestGetLastIndex(t *testing.T) {
+func TestGetNewOutput(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	pipelineId := uuid.New()
-	incorrectConvertPipelineId := uuid.New()
-	err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutputIndex, 2)
+	withoutIndexPipelineId := uuid.New()
+	runOutput := "MOCK_RUN_OUTPUT"
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, runOutput)
 	if err != nil {
 		panic(err)
 	}
-	err = cacheService.SetValue(context.Background(), incorrectConvertPipelineId, cache.RunOutputIndex, "MOCK_LAST_INDEX")
+	err = cacheService.SetValue(context.Background(), pipelineId, cache.RunOutputIndex, 5)
+	if err != nil {
+		panic(err)
+	}
+	err = cacheService.SetValue(context.Background(), withoutIndexPipelineId, cache.RunOutput, runOutput)
 	if err != nil {
 		panic(err)
 	}
@@ -465,77 +5135,288 @@ func TestGetLastIndex(t *testing.T) {
 		ctx          context.Context
 		cacheService cache.Cache
 		key          uuid.UUID
-		subKey       cache.SubKey
+		outputSubKey cache.SubKey
+		indexSubKey  cache.SubKey
 		errorTitle   string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    int
-		wantErr bool
+		name      string
+		args      args
+		want      string
+		wantIndex interface{}
+		wantErr   bool
 	}{
 		{
-			// Test case with calling GetLastIndex with pipelineId which doesn't contain status.
+			// Test case with calling GetNewOutput with pipelineId which doesn't contain index of the output.
 			// As a result, want to receive an error.
-			name: "get last index with incorrect pipelineId",
+			name: "get new output without index",
 			args: args{
 				ctx:          context.Background(),
 				cacheService: cacheService,
-				key:          uuid.New(),
-				subKey:       cache.RunOutputIndex,
+				key:          withoutIndexPipelineId,
+				outputSubKey: cache.RunOutput,
+				indexSubKey:  cache.RunOutputIndex,
 				errorTitle:   "",
 			},
-			want:    0,
-			wantErr: true,
+			want:      "",
+			wantIndex: nil,
+			wantErr:   true,
 		},
 		{
-			// Test case with calling GetLastIndex with pipelineId which contains incorrect status value in cache.
-			// As a result, want to receive an error.
-			name: "get last index with incorrect cache value",
+			// Test case with calling GetNewOutput with pipelineId which contains output and index of the output.
+			// As a result, want to receive the part of the output after the index and index moved to the end of the output.
+			name: "get new output",
 			args: args{
 				ctx:          context.Background(),
 				cacheService: cacheService,
-				key:          incorrectConvertPipelineId,
-				subKey:       cache.RunOutputIndex,
+				key:          pipelineId,
+				outputSubKey: cache.RunOutput,
+				indexSubKey:  cache.RunOutputIndex,
 				errorTitle:   "",
 			},
-			want:    0,
-			wantErr: true,
+			want:      runOutput[5:],
+			wantIndex: len(runOutput),
+			wantErr:   false,
 		},
 		{
-			// Test case with calling GetLastIndex with pipelineId which contains status.
-			// As a result, want to receive an expected last index.
-			name: "get last index with correct pipelineId",
+			// Test case with calling GetNewOutput with pipelineId which output has been already received.
+			// As a result, want to receive an empty output.
+			name: "get new output twice",
 			args: args{
 				ctx:          context.Background(),
 				cacheService: cacheService,
 				key:          pipelineId,
-				subKey:       cache.RunOutputIndex,
+				outputSubKey: cache.RunOutput,
+				indexSubKey:  cache.RunOutputIndex,
 				errorTitle:   "",
 			},
-			want:    2,
-			wantErr: false,
+			want:      "",
+			wantIndex: len(runOutput),
+			wantErr:   false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := GetLastIndex(tt.args.ctx, tt.args.cacheService, tt.args.key, tt.args.subKey, tt.args.errorTitle)
+			got, err := GetNewOutput(tt.args.ctx, tt.args.cacheService, tt.args.key, tt.args.outputSubKey, tt.args.indexSubKey, tt.args.errorTitle)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("GetLastIndex() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetNewOutput() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if got != tt.want {
-				t.Errorf("GetLastIndex() got = %v, want %v", got, tt.want)
+				t.Errorf("GetNewOutput() got = %v, want %v", got, tt.want)
+			}
+			index, _ := tt.args.cacheService.GetValue(tt.args.ctx, tt.args.key, tt.args.indexSubKey)
+			if !reflect.DeepEqual(index, tt.wantIndex) {
+				t.Errorf("GetNewOutput() set index = %v, want %v", index, tt.wantIndex)
+			}
+		})
+	}
+}
+
+// yieldingCache is the cache which yields the processor after each read, so the concurrent readers interleave
+type yieldingCache struct {
+	cache.Cache
+}
+
+func (c *yieldingCache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey) (interface{}, error) {
+	value, err := c.Cache.GetValue(ctx, pipelineId, subKey)
+	runtime.Gosched()
+	return value, err
+}
+
+func TestGetNewOutput_ConcurrentReaders(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	cacheService := &yieldingCache{Cache: cacheService}
+	pipelineId := uuid.New()
+	_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 0)
+	_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, "")
+
+	// Test case with calling GetNewOutput by several concurrent readers while the output is written.
+	// As a result, want to receive each part of the output exactly once by one of the readers.
+	readers := 10
+	lines := 200
+	var mu sync.Mutex
+	received := make([]string, 0)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				newOutput, err := GetNewOutput(ctx, cacheService, pipelineId, cache.RunOutput, cache.RunOutputIndex, "")
+				if err != nil {
+					t.Errorf("GetNewOutput() error = %v", err)
+					return
+				}
+				if newOutput != "" {
+					mu.Lock()
+					received = append(received, newOutput)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	output := ""
+	for i := 0; i < lines; i++ {
+		output += fmt.Sprintf("MOCK_LINE_%d\n", i)
+		_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, output)
+		runtime.Gosched()
+	}
+	close(done)
+	wg.Wait()
+	rest, err := GetNewOutput(ctx, cacheService, pipelineId, cache.RunOutput, cache.RunOutputIndex, "")
+	if err != nil {
+		t.Fatalf("GetNewOutput() error = %v", err)
+	}
+	received = append(received, rest)
+	// the parts could be appended by the readers in another order than they are received, so the lines are compared
+	gotLines := strings.Split(strings.Join(received, ""), "\n")
+	wantLines := strings.Split(output, "\n")
+	sort.Strings(gotLines)
+	sort.Strings(wantLines)
+	if !reflect.DeepEqual(gotLines, wantLines) {
+		t.Errorf("GetNewOutput() received %d lines, want each of %d lines once", len(gotLines), len(wantLines))
+	}
+	if index, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutputIndex); index != len(output) {
+		t.Errorf("GetNewOutput() set index = %v, want %v", index, len(output))
+	}
+}
+
+func TestWatchRunOutput(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pauseDuration := 50 * time.Millisecond
+	pipelineId := uuid.New()
+	_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+	type update struct {
+		status pb.Status
+		output string
+	}
+
+	tests := []struct {
+		name    string
+		key     uuid.UUID
+		sendErr error
+		want    []update
+		wantErr bool
+	}{
+		{
+			// Test case with calling WatchRunOutput method with pipelineId which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "pipelineId doesn't exist",
+			key:     uuid.New(),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling WatchRunOutput method with send which fails.
+			// As a result, want to receive the error of send after the first update.
+			name:    "send fails",
+			key:     pipelineId,
+			sendErr: fmt.Errorf("MOCK_ERROR"),
+			want:    []update{{pb.Status_STATUS_EXECUTING, ""}},
+			wantErr: true,
+		},
+		{
+			// Test case with calling WatchRunOutput method with pipelineId which run output is written during the watching.
+			// As a result, want to receive updates only when status is changed or the new output is received.
+			name: "run output is written during watching",
+			key:  pipelineId,
+			want: []update{
+				{pb.Status_STATUS_EXECUTING, ""},
+				{pb.Status_STATUS_EXECUTING, "MOCK_RUN_OUTPUT\n"},
+				{pb.Status_STATUS_FINISHED, ""},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []update
+			err := WatchRunOutput(ctx, cacheService, tt.key, pauseDuration, "", func(status pb.Status, newOutput string) error {
+				got = append(got, update{status, newOutput})
+				if tt.sendErr == nil && status == pb.Status_STATUS_EXECUTING {
+					// the output and the final status are written after the update is received, so they are received by the next updates
+					if newOutput == "" {
+						_ = cacheService.SetValue(ctx, tt.key, cache.RunOutput, "MOCK_RUN_OUTPUT\n")
+					} else {
+						_ = cacheService.SetValue(ctx, tt.key, cache.Status, pb.Status_STATUS_FINISHED)
+					}
+				}
+				return tt.sendErr
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WatchRunOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WatchRunOutput() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestWatchRunOutput_IndependentOffsets(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	output := "MOCK_RUN_OUTPUT\n"
+	_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+	_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, output)
+	// the output is already received by GetNewOutput
+	_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, len(output))
+
+	// each watcher should receive the whole output regardless of the output received by GetNewOutput and other watchers
+	for i := 0; i < 2; i++ {
+		var got string
+		err := WatchRunOutput(ctx, cacheService, pipelineId, 50*time.Millisecond, "", func(status pb.Status, newOutput string) error {
+			got += newOutput
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WatchRunOutput() error = %v", err)
+		}
+		if got != output {
+			t.Errorf("WatchRunOutput() watcher %d got = %q, want %q", i, got, output)
+		}
+	}
+	if index, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutputIndex); index != len(output) {
+		t.Errorf("WatchRunOutput() changed index = %v, want %v", index, len(output))
+	}
+}
+
 func Test_setJavaExecutableFile(t *testing.T) {
 	pipelineId := uuid.New()
-	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, pipelineId, os.Getenv("APP_WORK_DIR"))
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, pipelineId, t.TempDir())
 	lc.ExecutableName = fakeExecutableName
-	executorBuilder := executors.NewExecutorBuilder().WithRunner().WithCommand("fake cmd").ExecutorBuilder
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("CreateFolders() error = %v", err)
+	}
+	entryPointFolder := filepath.Join(lc.GetAbsoluteCompiledFolderPath(), "org", "example")
+	if err := os.MkdirAll(entryPointFolder, fs.ModePerm); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(entryPointFolder, "WordCount.class"), []byte("TEMP_DATA"), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	newExecutorBuilder := func() *executors.ExecutorBuilder {
+		executorBuilder := executors.NewExecutorBuilder().WithRunner().WithCommand("fake cmd").ExecutorBuilder
+		return &executorBuilder
+	}
+	wantExecutor := func(executableName string) executors.Executor {
+		return executors.NewExecutorBuilder().
+			WithExecutableFileName(executableName).
+			WithRunner().
+			WithCommand("fake cmd").
+			WithTestRunner().
+			Build()
+	}
 	type args struct {
 		lc              *fs_tool.LifeCycle
 		id              uuid.UUID
@@ -543,41 +5424,84 @@ func Test_setJavaExecutableFile(t *testing.T) {
 		ctx             context.Context
 		executorBuilder *executors.ExecutorBuilder
 		dir             string
+		entryPoint      string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    executors.Executor
-		wantErr bool
+		name       string
+		args       args
+		want       executors.Executor
+		wantErr    bool
+		wantStatus interface{}
 	}{
 		{
+			// Test case with calling setJavaExecutableFile method without the entry point.
+			// As a result, want to receive the executor which runs the inferred class.
 			name: "set executable name to runner",
 			args: args{
 				lc:              lc,
 				id:              pipelineId,
 				service:         cacheService,
 				ctx:             context.Background(),
-				executorBuilder: &executorBuilder,
+				executorBuilder: newExecutorBuilder(),
+				dir:             "",
+				entryPoint:      "",
+			},
+			want:    wantExecutor(fileName),
+			wantErr: false,
+		},
+		{
+			// Test case with calling setJavaExecutableFile method with the entry point which is compiled from the code.
+			// As a result, want to receive the executor which runs the entry point instead of the inferred class.
+			name: "set entry point to runner",
+			args: args{
+				lc:              lc,
+				id:              pipelineId,
+				service:         cacheService,
+				ctx:             context.Background(),
+				executorBuilder: newExecutorBuilder(),
 				dir:             "",
+				entryPoint:      "org.example.WordCount",
 			},
-			want: executors.NewExecutorBuilder().
-				WithExecutableFileName(fileName).
-				WithRunner().
-				WithCommand("fake cmd").
-				WithTestRunner().
-				Build(),
+			want:    wantExecutor("org.example.WordCount"),
 			wantErr: false,
 		},
+		{
+			// Test case with calling setJavaExecutableFile method with the entry point which isn't compiled from the code.
+			// As a result, want to receive an error and playground.Status_STATUS_VALIDATION_ERROR as the status in cache.
+			name: "nonexistent entry point",
+			args: args{
+				lc:              lc,
+				id:              pipelineId,
+				service:         cacheService,
+				ctx:             context.Background(),
+				executorBuilder: newExecutorBuilder(),
+				dir:             "",
+				entryPoint:      "org.example.Helper",
+			},
+			want:       newExecutorBuilder().Build(),
+			wantErr:    true,
+			wantStatus: pb.Status_STATUS_VALIDATION_ERROR,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := setJavaExecutableFile(tt.args.lc, tt.args.id, tt.args.service, tt.args.ctx, tt.args.executorBuilder, tt.args.dir)
+			got, err := setJavaExecutableFile(tt.args.lc, tt.args.id, tt.args.service, tt.args.ctx, tt.args.executorBuilder, tt.args.dir, tt.args.entryPoint)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("setJavaExecutableFile() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("setJavaExecutableFile() = %v, want %v", got, tt.want)
 			}
+			if tt.wantStatus != nil {
+				status, _ := tt.args.service.GetValue(tt.args.ctx, tt.args.id, cache.Status)
+				if status != tt.wantStatus {
+					t.Errorf("setJavaExecutableFile() set status = %v, want %v", status, tt.wantStatus)
+				}
+				validationOutput, _ := tt.args.service.GetValue(tt.args.ctx, tt.args.id, cache.ValidationOutput)
+				if !strings.Contains(fmt.Sprint(validationOutput), tt.args.entryPoint) {
+					t.Errorf("setJavaExecutableFile() set validation output = %v, want the error about %s", validationOutput, tt.args.entryPoint)
+				}
+			}
 		})
 	}
 }
@@ -645,3 +5569,333 @@ func Test_getRunOrTestCmd(t *testing.T) {
 		})
 	}
 }
+
+func Test_runWithBackend(t *testing.T) {
+	pipelineId := uuid.New()
+	ctx := context.Background()
+	if err := cacheService.SetValue(ctx, pipelineId, cache.RunOutput, ""); err != nil {
+		panic(err)
+	}
+	successChannel := make(chan bool, 1)
+	errorChannel := make(chan error, 1)
+	runOutput := streaming.RunOutputWriter{Ctx: ctx, CacheService: cacheService, PipelineId: pipelineId}
+	var runError bytes.Buffer
+	exitCode := -1
+	spec := executors.ExecutionSpec{Command: "sh", Args: []string{"-c", "echo first; sleep 2; echo second"}}
+
+	runWithBackend(ctx, executors.SubprocessBackend{}, spec, &runOutput, &runError, nil, &exitCode, time.Second, successChannel, errorChannel)
+
+	// the first line should be available before the command is finished
+	time.Sleep(time.Second)
+	output, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+	if output != "first\n" {
+		t.Errorf("runWithBackend() output before the command is finished: %s, but expects: %s", output, "first\n")
+	}
+
+	if ok := <-successChannel; !ok {
+		t.Errorf("runWithBackend() finished with error: %s", <-errorChannel)
+	}
+	output, _ = cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+	if output != "first\nsecond\n" {
+		t.Errorf("runWithBackend() output after the command is finished: %s, but expects: %s", output, "first\nsecond\n")
+	}
+	if exitCode != 0 {
+		t.Errorf("runWithBackend() exit code: %d, but expects: 0", exitCode)
+	}
+}
+
+func Test_runWithBackendCombinedLogs(t *testing.T) {
+	pipelineId := uuid.New()
+	ctx := context.Background()
+	if err := cacheService.SetValue(ctx, pipelineId, cache.RunOutput, ""); err != nil {
+		panic(err)
+	}
+	successChannel := make(chan bool, 1)
+	errorChannel := make(chan error, 1)
+	runOutput := streaming.RunOutputWriter{Ctx: ctx, CacheService: cacheService, PipelineId: pipelineId}
+	var runError bytes.Buffer
+	var combinedLogs bytes.Buffer
+	exitCode := -1
+	spec := executors.ExecutionSpec{Command: "sh", Args: []string{"-c", "echo out1; sleep 0.1; echo err1 >&2; sleep 0.1; echo out2; sleep 0.1; echo err2 >&2; sleep 0.1; printf out3"}}
+
+	runWithBackend(ctx, executors.SubprocessBackend{}, spec, &runOutput, &runError, &combinedLogs, &exitCode, time.Second, successChannel, errorChannel)
+
+	if ok := <-successChannel; !ok {
+		t.Fatalf("runWithBackend() finished with error: %s", <-errorChannel)
+	}
+	output, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+	if output != "out1\nout2\nout3" {
+		t.Errorf("runWithBackend() output: %q, but expects: %q", output, "out1\nout2\nout3")
+	}
+	if runError.String() != "err1\nerr2\n" {
+		t.Errorf("runWithBackend() error output: %q, but expects: %q", runError.String(), "err1\nerr2\n")
+	}
+
+	wantLines := []string{"[out] out1", "[err] err1", "[out] out2", "[err] err2", "[out] out3"}
+	gotLines := strings.Split(strings.TrimSuffix(combinedLogs.String(), "\n"), "\n")
+	if len(gotLines) != len(wantLines) {
+		t.Fatalf("runWithBackend() combined logs: %q, but expects lines: %q", combinedLogs.String(), wantLines)
+	}
+	for i, line := range gotLines {
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) != 2 || parts[1] != wantLines[i] {
+			t.Errorf("runWithBackend() combined log line %d: %q, but expects: %q", i, line, wantLines[i])
+			continue
+		}
+		if _, err := time.Parse(combinedLogsTimeFormat, parts[0]); err != nil {
+			t.Errorf("runWithBackend() combined log line %d has invalid time: %s", i, err.Error())
+		}
+	}
+}
+
+func Test_commandLine(t *testing.T) {
+	tests := []struct {
+		name string
+		env  []string
+		args []string
+		want string
+	}{
+		{
+			// Test case with calling commandLine method with plain environment variables and arguments.
+			// As a result, want to receive them joined with spaces.
+			name: "plain command",
+			env:  []string{"HOME=/root"},
+			args: []string{"java", "-cp", "bin:", "HelloWorld"},
+			want: "HOME=/root java -cp bin: HelloWorld",
+		},
+		{
+			// Test case with calling commandLine method with environment variables which could contain secrets.
+			// As a result, want to receive the command line with their values redacted.
+			name: "sensitive environment variables",
+			env:  []string{"API_TOKEN=s3cr3t", "DB_PASSWORD=pa55", "aws_secret_access_key=key", "PATH=/usr/bin"},
+			args: []string{"python3", "main.py"},
+			want: "API_TOKEN='<redacted>' DB_PASSWORD='<redacted>' aws_secret_access_key='<redacted>' PATH=/usr/bin python3 main.py",
+		},
+		{
+			// Test case with calling commandLine method with arguments which contain spaces and quotes.
+			// As a result, want to receive these arguments quoted for the shell.
+			name: "arguments with special characters",
+			env:  []string{"EMPTY="},
+			args: []string{"go", "run", "--message=hello world", "it's"},
+			want: "EMPTY='' go run '--message=hello world' 'it'\\''s'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(tt.args[0], tt.args[1:]...)
+			cmd.Env = tt.env
+			if got := commandLine(cmd); got != tt.want {
+				t.Errorf("commandLine() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readOnlyPaths(t *testing.T) {
+	pipelineId := uuid.New()
+	tests := []struct {
+		name string
+		sdk  pb.Sdk
+		want func(lc *fs_tool.LifeCycle) []string
+	}{
+		{
+			// Test case with calling readOnlyPaths method with the pipeline of the compiled SDK.
+			// As a result, want to receive the folders of the source and compiled files.
+			name: "compiled sdk",
+			sdk:  pb.Sdk_SDK_GO,
+			want: func(lc *fs_tool.LifeCycle) []string {
+				return []string{filepath.Join(lc.GetAbsoluteBaseFolderPath(), "src"), lc.GetAbsoluteCompiledFolderPath()}
+			},
+		},
+		{
+			// Test case with calling readOnlyPaths method with the pipeline of the interpreted SDK.
+			// As a result, want to receive the source files since they are in the folder of the pipeline.
+			name: "interpreted sdk",
+			sdk:  pb.Sdk_SDK_PYTHON,
+			want: func(lc *fs_tool.LifeCycle) []string {
+				return []string{lc.GetAbsoluteSourceFilePath()}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc, err := fs_tool.NewLifeCycle(tt.sdk, pipelineId, t.TempDir())
+			if err != nil {
+				t.Fatalf("error during prepare lifeCycle: %s", err.Error())
+			}
+			if got, want := readOnlyPaths(lc), tt.want(lc); !reflect.DeepEqual(got, want) {
+				t.Errorf("readOnlyPaths() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_isOutOfMemory(t *testing.T) {
+	tests := []struct {
+		name        string
+		errorOutput string
+		want        bool
+	}{
+		{
+			// Test case with calling isOutOfMemory method with the stack trace of the JVM which runs out of the heap.
+			// As a result, want to receive true.
+			name: "jvm heap space",
+			errorOutput: "Exception in thread \"main\" java.lang.OutOfMemoryError: Java heap space\n" +
+				"\tat java.base/java.util.Arrays.copyOf(Arrays.java:3512)\n" +
+				"\tat java.base/java.util.ArrayList.grow(ArrayList.java:237)\n" +
+				"\tat org.apache.beam.examples.MinimalWordCount.main(MinimalWordCount.java:42)\n",
+			want: true,
+		},
+		{
+			// Test case with calling isOutOfMemory method with the stack trace of the pipeline which is failed
+			//	because the JVM runs out of memory in a worker thread.
+			// As a result, want to receive true.
+			name: "jvm out of memory in pipeline",
+			errorOutput: "Exception in thread \"main\" org.apache.beam.sdk.Pipeline$PipelineExecutionException: java.lang.OutOfMemoryError: GC overhead limit exceeded\n" +
+				"\tat org.apache.beam.runners.direct.DirectRunner$DirectPipelineResult.waitUntilFinish(DirectRunner.java:373)\n" +
+				"Caused by: java.lang.OutOfMemoryError: GC overhead limit exceeded\n",
+			want: true,
+		},
+		{
+			// Test case with calling isOutOfMemory method with the output of the JVM which couldn't start because of lack of memory.
+			// As a result, want to receive true.
+			name:        "jvm couldn't start",
+			errorOutput: "# There is insufficient memory for the Java Runtime Environment to continue.\n# Native memory allocation (mmap) failed to map 262144 bytes\n",
+			want:        true,
+		},
+		{
+			// Test case with calling isOutOfMemory method with the traceback of Python code which runs out of memory.
+			// As a result, want to receive true.
+			name:        "python memory error",
+			errorOutput: "Traceback (most recent call last):\n  File \"main.py\", line 3, in <module>\n    chunks.append(bytearray(64 << 20))\nMemoryError\n",
+			want:        true,
+		},
+		{
+			// Test case with calling isOutOfMemory method with the stack trace of another exception of the JVM.
+			// As a result, want to receive false.
+			name:        "jvm runtime exception",
+			errorOutput: "Exception in thread \"main\" java.lang.IllegalStateException: no input\n\tat Main.main(Main.java:5)\n",
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isOutOfMemory([]byte(tt.errorOutput)); got != tt.want {
+				t.Errorf("isOutOfMemory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isRunCanceled(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[cache.SubKey]interface{}
+		want   bool
+	}{
+		{
+			// Test case with calling isRunCanceled method when only the run step is canceled.
+			// As a result, want to receive true.
+			name:   "run step is canceled",
+			values: map[cache.SubKey]interface{}{cache.Canceled: false, cache.CanceledPhase: pb.CancelPhase_CANCEL_PHASE_RUN},
+			want:   true,
+		},
+		{
+			// Test case with calling isRunCanceled method when the whole code processing is canceled.
+			// As a result, want to receive false.
+			name:   "code processing is canceled",
+			values: map[cache.SubKey]interface{}{cache.Canceled: true, cache.CanceledPhase: pb.CancelPhase_CANCEL_PHASE_RUN},
+			want:   false,
+		},
+		{
+			// Test case with calling isRunCanceled method when the cancel flag has an unexpected type.
+			// As a result, want to receive true because the flag is considered as not canceled.
+			name:   "cancel flag with unexpected type",
+			values: map[cache.SubKey]interface{}{cache.Canceled: "true", cache.CanceledPhase: pb.CancelPhase_CANCEL_PHASE_RUN},
+			want:   true,
+		},
+		{
+			// Test case with calling isRunCanceled method when the canceled phase has an unexpected type.
+			// As a result, want to receive false.
+			name:   "canceled phase with unexpected type",
+			values: map[cache.SubKey]interface{}{cache.Canceled: false, cache.CanceledPhase: "CANCEL_PHASE_RUN"},
+			want:   false,
+		},
+		{
+			// Test case with calling isRunCanceled method when cancel flags don't exist in cache.
+			// As a result, want to receive false.
+			name:   "cancel flags don't exist",
+			values: map[cache.SubKey]interface{}{},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			pipelineId := uuid.New()
+			for subKey, value := range tt.values {
+				if err := cacheService.SetValue(ctx, pipelineId, subKey, value); err != nil {
+					t.Fatalf("cache.SetValue() error = %v", err)
+				}
+			}
+			if got := isRunCanceled(ctx, cacheService, pipelineId); got != tt.want {
+				t.Errorf("isRunCanceled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getExecuteCmdEnv(t *testing.T) {
+	unitTests := sync.Map{}
+	unitTests.Store(validators.UnitTestValidatorName, true)
+
+	notUnitTests := sync.Map{}
+	notUnitTests.Store(validators.UnitTestValidatorName, false)
+
+	env := map[string]string{"PYTHONHASHSEED": "0", "GOOGLE_APPLICATION_CREDENTIALS": "/path/to/credentials.json"}
+	executor := executors.NewExecutorBuilder().
+		WithEnv(env).
+		WithRunner().
+		WithCommand("runCommand").
+		WithPipelineOptions([]string{""}).
+		WithTestRunner().
+		WithCommand("testCommand").
+		Build()
+
+	tests := []struct {
+		name      string
+		valResult *sync.Map
+	}{
+		{
+			// Test case with calling getExecuteCmd method with executor which has environment variables for the code.
+			// As a result, want to receive run cmd with these environment variables.
+			name:      "run cmd with env",
+			valResult: &notUnitTests,
+		},
+		{
+			// Test case with calling getExecuteCmd method with executor which has environment variables for the code.
+			// As a result, want to receive test cmd with these environment variables.
+			name:      "test cmd with env",
+			valResult: &unitTests,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getExecuteCmd(tt.valResult, &executor, context.Background())
+			for key, value := range env {
+				if !containsEnv(got.Env, key+"="+value) {
+					t.Errorf("getExecuteCmd() env = %v, want to contain %s=%s", got.Env, key, value)
+				}
+			}
+		})
+	}
+}
+
+func containsEnv(env []string, variable string) bool {
+	for _, value := range env {
+		if value == variable {
+			return true
+		}
+	}
+	return false
+}
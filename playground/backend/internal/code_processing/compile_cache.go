@@ -0,0 +1,210 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// pipelineIdPlaceholder replaces the id of the pipeline in the names of the kept artifacts and in the code,
+//	so the artifacts compiled for one pipeline are reused by another one
+const pipelineIdPlaceholder = "{pipelineId}"
+
+// CompileCache keeps the compiled artifacts of the code, so the same code (i.e. a shared example) which is submitted again
+//	is run without compilation.
+// The artifacts are found by the hash of the normalized source files, the SDK, the pipeline options, the toolchain
+//	which compiles the code and the dependencies of the classpath (their coordinates and jars), so the artifacts
+//	aren't reused after the toolchain or some dependency is changed.
+// The least recently used artifacts are deleted if there are more artifacts than the size of the cache.
+type CompileCache struct {
+	dir  string
+	size int
+
+	mu sync.Mutex
+	// keys are the keys of the kept artifacts from the least recently used to the most recently used
+	keys     *list.List
+	elements map[string]*list.Element
+}
+
+// NewCompileCache constructor for CompileCache.
+// dir is a directory where the artifacts are kept, the artifacts which are kept there by the previous run of the server are deleted.
+// size is a number of the kept artifacts. 0 means that the code is always compiled, nil is returned in this case.
+func NewCompileCache(dir string, size int) (*CompileCache, error) {
+	if size <= 0 {
+		return nil, nil
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, fs.ModePerm); err != nil {
+		return nil, err
+	}
+	return &CompileCache{dir: dir, size: size, keys: list.New(), elements: make(map[string]*list.Element)}, nil
+}
+
+// key returns the key of the compiled artifacts of the code of the pipeline.
+// Returns an empty key if the source files, the toolchain or the dependencies couldn't be read, so the code is compiled as usual.
+func (cc *CompileCache) key(lc *fs_tool.LifeCycle, pipelineId uuid.UUID, sdkEnv *environment.BeamEnvs, pipelineOptions string) string {
+	if cc == nil {
+		return ""
+	}
+	toolchain, err := toolchainVersion(sdkEnv.ExecutorConfig.CompileCmd)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.New()
+	write := func(value string) {
+		_, _ = io.WriteString(hash, value)
+		_, _ = hash.Write([]byte{0})
+	}
+	write(sdkEnv.ApacheBeamSdk.String())
+	write(sdkEnv.Version)
+	write(toolchain)
+	write(strings.Join(sdkEnv.ExecutorConfig.CompileArgs, " "))
+	write(pipelineOptions)
+	dependencies := sdkEnv.ExecutorConfig.ClasspathDependencies
+	coordinates := make([]string, 0, len(dependencies))
+	for coordinate := range dependencies {
+		coordinates = append(coordinates, coordinate)
+	}
+	sort.Strings(coordinates)
+	for _, coordinate := range coordinates {
+		version, err := dependencyVersion(dependencies[coordinate])
+		if err != nil {
+			return ""
+		}
+		write(coordinate)
+		write(version)
+	}
+	for _, filePath := range lc.GetAbsoluteSourceFilePaths() {
+		code, err := os.ReadFile(filePath)
+		if err != nil {
+			return ""
+		}
+		write(strings.ReplaceAll(filepath.Base(filePath), pipelineId.String(), pipelineIdPlaceholder))
+		write(normalizeSource(string(code), pipelineId))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// restore copies the artifacts kept by the key to the folder with compiled files of the pipeline.
+// Returns false if there are no artifacts by the key or they couldn't be copied.
+func (cc *CompileCache) restore(key string, lc *fs_tool.LifeCycle, pipelineId uuid.UUID) bool {
+	if cc == nil || key == "" {
+		return false
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	element, ok := cc.elements[key]
+	if !ok {
+		return false
+	}
+	if err := fs_tool.CopyFolder(filepath.Join(cc.dir, key), lc.GetAbsoluteCompiledFolderPath(), pipelineIdPlaceholder, pipelineId.String()); err != nil {
+		return false
+	}
+	cc.keys.MoveToBack(element)
+	return true
+}
+
+// store keeps the artifacts from the folder with compiled files of the pipeline by the key.
+// The least recently used artifacts are deleted if there are more artifacts than the size of the cache.
+func (cc *CompileCache) store(key string, lc *fs_tool.LifeCycle, pipelineId uuid.UUID) error {
+	if cc == nil || key == "" {
+		return nil
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if _, ok := cc.elements[key]; ok {
+		return nil
+	}
+	artifactsDir := filepath.Join(cc.dir, key)
+	if err := fs_tool.CopyFolder(lc.GetAbsoluteCompiledFolderPath(), artifactsDir, pipelineId.String(), pipelineIdPlaceholder); err != nil {
+		_ = os.RemoveAll(artifactsDir)
+		return err
+	}
+	cc.elements[key] = cc.keys.PushBack(key)
+	for cc.keys.Len() > cc.size {
+		oldest := cc.keys.Remove(cc.keys.Front()).(string)
+		delete(cc.elements, oldest)
+		_ = os.RemoveAll(filepath.Join(cc.dir, oldest))
+	}
+	return nil
+}
+
+// toolchainVersion returns the version of the toolchain which compiles the code: the resolved path, the size and
+//	the modification time of the compile command, so the version is changed when the toolchain is updated.
+func toolchainVersion(compileCmd string) (string, error) {
+	cmdPath, err := exec.LookPath(compileCmd)
+	if err != nil {
+		return "", err
+	}
+	return fileVersion(cmdPath)
+}
+
+// dependencyVersion returns the version of the dependency of the classpath: the versions (see fileVersion) of all jars
+//	which match the path of the dependency (i.e. "/opt/deps/kafka/*"), so the version is changed when some jar is updated.
+func dependencyVersion(dependencyPath string) (string, error) {
+	jarPaths, err := filepath.Glob(dependencyPath)
+	if err != nil {
+		return "", err
+	}
+	versions := make([]string, 0, len(jarPaths))
+	for _, jarPath := range jarPaths {
+		version, err := fileVersion(jarPath)
+		if err != nil {
+			return "", err
+		}
+		versions = append(versions, version)
+	}
+	return strings.Join(versions, ","), nil
+}
+
+// fileVersion returns the resolved path, the size and the modification time of the file
+func fileVersion(path string) (string, error) {
+	path, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%d:%d", path, info.Size(), info.ModTime().UnixNano()), nil
+}
+
+// normalizeSource normalizes line endings and trailing spaces of the code and replaces the id of the pipeline,
+//	so the code which differs by them only has the same compiled artifacts.
+func normalizeSource(code string, pipelineId uuid.UUID) string {
+	lines := strings.Split(strings.ReplaceAll(code, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.ReplaceAll(strings.TrimSpace(strings.Join(lines, "\n")), pipelineId.String(), pipelineIdPlaceholder)
+}
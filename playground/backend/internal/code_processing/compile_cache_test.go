@@ -0,0 +1,169 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewCompileCache(t *testing.T) {
+	compileCache, err := NewCompileCache(filepath.Join(t.TempDir(), "artifacts"), 0)
+	if err != nil {
+		t.Fatalf("NewCompileCache() error = %v", err)
+	}
+	if compileCache != nil {
+		t.Errorf("NewCompileCache() = %v, but expectes nil for the size 0", compileCache)
+	}
+	if compileCache.restore("key", nil, uuid.New()) {
+		t.Errorf("restore() = true, but expectes false for nil cache")
+	}
+}
+
+func TestCompileCache_storeAndRestore(t *testing.T) {
+	workingDir := t.TempDir()
+	compileCache, err := NewCompileCache(filepath.Join(workingDir, "artifacts"), 1)
+	if err != nil {
+		t.Fatalf("NewCompileCache() error = %v", err)
+	}
+	compile := func(content string) (*fs_tool.LifeCycle, uuid.UUID) {
+		pipelineId := uuid.New()
+		lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, workingDir)
+		if err := lc.CreateFolders(); err != nil {
+			t.Fatalf("error during prepare folders: %s", err.Error())
+		}
+		if err := os.WriteFile(lc.GetAbsoluteExecutableFilePath(), []byte(content), 0700); err != nil {
+			t.Fatalf("error during prepare the executable file: %s", err.Error())
+		}
+		return lc, pipelineId
+	}
+	firstLc, firstPipelineId := compile("first")
+	if err := compileCache.store("first", firstLc, firstPipelineId); err != nil {
+		t.Fatalf("store() error = %v", err)
+	}
+
+	// Test case with restoring the artifacts into the folder of another pipeline.
+	// As a result, the executable file should be copied with the name of the pipeline and its mode.
+	lc, pipelineId := compile("")
+	_ = os.Remove(lc.GetAbsoluteExecutableFilePath())
+	if !compileCache.restore("first", lc, pipelineId) {
+		t.Fatalf("restore() = false, but expectes true")
+	}
+	content, err := os.ReadFile(lc.GetAbsoluteExecutableFilePath())
+	if err != nil || string(content) != "first" {
+		t.Errorf("restore() copied %s, but expectes: %s", content, "first")
+	}
+	if info, err := os.Stat(lc.GetAbsoluteExecutableFilePath()); err != nil || info.Mode().Perm() != 0700 {
+		t.Errorf("restore() copied the file with mode %v, but expectes: %v", info.Mode().Perm(), os.FileMode(0700))
+	}
+
+	// Test case with storing more artifacts than the size of the cache.
+	// As a result, the least recently used artifacts should be deleted.
+	secondLc, secondPipelineId := compile("second")
+	if err := compileCache.store("second", secondLc, secondPipelineId); err != nil {
+		t.Fatalf("store() error = %v", err)
+	}
+	if compileCache.restore("first", lc, pipelineId) {
+		t.Errorf("restore() = true, but expectes false for the evicted artifacts")
+	}
+	if _, err := os.Stat(filepath.Join(compileCache.dir, "first")); !os.IsNotExist(err) {
+		t.Errorf("the evicted artifacts aren't deleted: %v", err)
+	}
+	if !compileCache.restore("second", lc, pipelineId) {
+		t.Errorf("restore() = false, but expectes true")
+	}
+}
+
+func TestCompileCache_keyWithDependencies(t *testing.T) {
+	workingDir := t.TempDir()
+	compileCache, err := NewCompileCache(filepath.Join(workingDir, "artifacts"), 1)
+	if err != nil {
+		t.Fatalf("NewCompileCache() error = %v", err)
+	}
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, pipelineId, workingDir)
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	if _, err := lc.CreateSourceCodeFile("class Main {}"); err != nil {
+		t.Fatalf("error during prepare the source file: %s", err.Error())
+	}
+	jarsDir := filepath.Join(workingDir, "jars")
+	if err := os.MkdirAll(jarsDir, 0700); err != nil {
+		t.Fatalf("error during prepare the jars folder: %s", err.Error())
+	}
+	for _, jar := range []string{"kafka-2.35.0.jar", "kafka-2.36.0.jar", "guava.jar"} {
+		if err := os.WriteFile(filepath.Join(jarsDir, jar), []byte(jar), 0600); err != nil {
+			t.Fatalf("error during prepare the jar: %s", err.Error())
+		}
+	}
+	key := func(dependencies map[string]string) string {
+		executorConfig := environment.NewExecutorConfig("sh", "java", "java", []string{"-d", "bin"}, []string{}, []string{})
+		executorConfig.ClasspathDependencies = dependencies
+		return compileCache.key(lc, pipelineId, environment.NewBeamEnvs(pb.Sdk_SDK_JAVA, executorConfig, ""), "")
+	}
+	firstVersion := map[string]string{"org.apache.beam:beam-sdks-java-io-kafka:2.35.0": filepath.Join(jarsDir, "kafka-2.35.0.jar")}
+	secondVersion := map[string]string{"org.apache.beam:beam-sdks-java-io-kafka:2.36.0": filepath.Join(jarsDir, "kafka-2.36.0.jar")}
+	withoutDependencies := key(nil)
+	withFirstVersion := key(firstVersion)
+	withSecondVersion := key(secondVersion)
+
+	// Test case with getting keys of the same code with different dependencies.
+	// As a result, the keys should be distinct.
+	if withoutDependencies == "" || withFirstVersion == "" || withSecondVersion == "" {
+		t.Fatalf("key() = \"\", but expectes the key for the readable source files and dependencies")
+	}
+	if withoutDependencies == withFirstVersion || withFirstVersion == withSecondVersion || withoutDependencies == withSecondVersion {
+		t.Errorf("key() = %s, %s, %s, but expectes distinct keys for different dependencies", withoutDependencies, withFirstVersion, withSecondVersion)
+	}
+
+	// Test case with getting keys of the same code with the same several dependencies.
+	// As a result, the keys should be equal regardless of the order of the dependencies.
+	both := map[string]string{"com.google.guava:guava:31.0.1-jre": filepath.Join(jarsDir, "guava.jar")}
+	for coordinate, path := range firstVersion {
+		both[coordinate] = path
+	}
+	if got, want := key(both), key(both); got != want {
+		t.Errorf("key() = %s, but expectes: %s for the same dependencies", got, want)
+	}
+
+	// Test case with getting the key after the jar of the dependency is updated by the same coordinate.
+	// As a result, the key should be changed.
+	updateTime := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(jarsDir, "kafka-2.35.0.jar"), updateTime, updateTime); err != nil {
+		t.Fatalf("error during update the jar: %s", err.Error())
+	}
+	if got := key(firstVersion); got == withFirstVersion {
+		t.Errorf("key() = %s, but expectes another key after the jar of the dependency is updated", got)
+	}
+
+	// Test case with getting the key of the dependency whose path matches several jars.
+	// As a result, the key should be changed when some of the jars is updated.
+	wildcard := map[string]string{"org.apache.beam:beam-sdks-java-io-kafka:2.35.0": filepath.Join(jarsDir, "kafka-*")}
+	withWildcard := key(wildcard)
+	if err := os.Chtimes(filepath.Join(jarsDir, "kafka-2.36.0.jar"), updateTime, updateTime); err != nil {
+		t.Fatalf("error during update the jar: %s", err.Error())
+	}
+	if got := key(wildcard); got == withWildcard {
+		t.Errorf("key() = %s, but expectes another key after the jar matched by the dependency path is updated", got)
+	}
+}
@@ -0,0 +1,192 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// javacErrorRegexp matches the first line of the javac error, i.e. "/path/to/File.java:1: error: message"
+	javacErrorRegexp = regexp.MustCompile(`^(.+):(\d+): (error|warning): (.*)$`)
+	// javacCaretRegexp matches the line of the javac error which points to the column of the error
+	javacCaretRegexp = regexp.MustCompile(`^\s*\^\s*$`)
+	// javacSummaryRegexp matches the last line of the javac output, i.e. "1 error"
+	javacSummaryRegexp = regexp.MustCompile(`^\d+ (error|warning)s?$`)
+	// kotlincErrorRegexp matches the first line of the kotlinc error, i.e. "/path/to/File.kt:1:5: error: message"
+	kotlincErrorRegexp = regexp.MustCompile(`^(.+):(\d+):(\d+): (error|warning|info): (.*)$`)
+	// kotlincMessageRegexp matches the line of the kotlinc message which isn't related to a source file,
+	//	i.e. "warning: classpath entry points to a non-existent location"
+	kotlincMessageRegexp = regexp.MustCompile(`^(error|warning|info|exception): `)
+	// javacNoteRegexp matches the mandatory note of javac which is printed instead of the warnings
+	//	if they aren't enabled, i.e. "Note: /path/to/File.java uses or overrides a deprecated API."
+	javacNoteRegexp = regexp.MustCompile(`^Note: (.*)$`)
+	// javacNoteFileRegexp matches the source file at the start of the javac note
+	javacNoteFileRegexp = regexp.MustCompile(`^(\S+\.java) `)
+)
+
+// warningSeverity is the severity of the compile errors which don't fail the compilation
+const warningSeverity = "warning"
+
+// parseCompileErrors parses the output of the compiler of the sdk into the list of compile errors.
+// In case the format of the compiler output isn't supported for the sdk, returns an empty list.
+func parseCompileErrors(sdk pb.Sdk, output string) []*pb.CompileError {
+	switch sdk {
+	case pb.Sdk_SDK_JAVA:
+		return parseJavacErrors(output)
+	case pb.Sdk_SDK_KOTLIN:
+		return parseKotlincErrors(output)
+	}
+	return []*pb.CompileError{}
+}
+
+// parseCompileWarnings parses the output of the compiler of the sdk into the list of compile warnings,
+//	i.e. the messages of the compiler which don't fail the compilation (deprecation, unchecked operations, etc.).
+// For javac, the notes about the deprecated API and the unchecked operations are also returned as warnings
+//	since javac prints them instead of the warnings if the warnings aren't enabled by -Xlint.
+// In case the format of the compiler output isn't supported for the sdk, returns an empty list.
+func parseCompileWarnings(sdk pb.Sdk, output string) []*pb.CompileError {
+	warnings := make([]*pb.CompileError, 0)
+	for _, compileError := range parseCompileErrors(sdk, output) {
+		if compileError.Severity == warningSeverity {
+			warnings = append(warnings, compileError)
+		}
+	}
+	if sdk == pb.Sdk_SDK_JAVA {
+		warnings = append(warnings, parseJavacNotes(output)...)
+	}
+	return warnings
+}
+
+// parseJavacErrors parses the output of javac into the list of compile errors.
+// Each error of javac has the following format:
+//	/path/to/File.java:line: error: message
+//	source code line
+//	    ^
+//	    additional information about the error (optional)
+// The column of the error is defined by the position of "^" in the line after the source code line.
+func parseJavacErrors(output string) []*pb.CompileError {
+	compileErrors := make([]*pb.CompileError, 0)
+	var current *pb.CompileError
+	lineAfterHeader := 0
+	for _, line := range strings.Split(output, "\n") {
+		if match := javacErrorRegexp.FindStringSubmatch(line); match != nil {
+			lineNumber, _ := strconv.Atoi(match[2])
+			current = &pb.CompileError{
+				File:     match[1],
+				Line:     int32(lineNumber),
+				Severity: match[3],
+				Message:  match[4],
+			}
+			compileErrors = append(compileErrors, current)
+			lineAfterHeader = 0
+			continue
+		}
+		if javacNoteRegexp.MatchString(line) {
+			current = nil
+			continue
+		}
+		if current == nil || javacSummaryRegexp.MatchString(line) || strings.TrimSpace(line) == "" {
+			continue
+		}
+		lineAfterHeader++
+		switch {
+		case lineAfterHeader == 1:
+			// source code line
+		case lineAfterHeader == 2 && javacCaretRegexp.MatchString(line):
+			current.Column = int32(strings.Index(line, "^") + 1)
+		default:
+			current.Message += "\n" + strings.TrimSpace(line)
+		}
+	}
+	return compileErrors
+}
+
+// parseJavacNotes parses the notes of javac into the list of compile warnings.
+// The notes of javac are printed after the errors and the warnings in the following format:
+//	Note: /path/to/File.java uses or overrides a deprecated API.
+//	Note: Recompile with -Xlint:deprecation for details.
+// The consecutive notes are joined into one warning, the source file is taken from its first note if it is there.
+func parseJavacNotes(output string) []*pb.CompileError {
+	warnings := make([]*pb.CompileError, 0)
+	var current *pb.CompileError
+	for _, line := range strings.Split(output, "\n") {
+		match := javacNoteRegexp.FindStringSubmatch(line)
+		if match == nil {
+			current = nil
+			continue
+		}
+		if current != nil {
+			current.Message += "\n" + match[1]
+			continue
+		}
+		current = &pb.CompileError{Severity: warningSeverity, Message: match[1]}
+		if fileMatch := javacNoteFileRegexp.FindStringSubmatch(match[1]); fileMatch != nil {
+			current.File = fileMatch[1]
+		}
+		warnings = append(warnings, current)
+	}
+	return warnings
+}
+
+// parseKotlincErrors parses the output of kotlinc into the list of compile errors.
+// Each error of kotlinc has the following format:
+//	/path/to/File.kt:line:column: error: message
+//	source code line
+//	    ^
+// Unlike javac, kotlinc reports the column in the first line, so the source code line and the line with "^" are skipped.
+// The messages of kotlinc which aren't related to a source file are skipped as well.
+func parseKotlincErrors(output string) []*pb.CompileError {
+	compileErrors := make([]*pb.CompileError, 0)
+	var current *pb.CompileError
+	lineAfterHeader := 0
+	for _, line := range strings.Split(output, "\n") {
+		if match := kotlincErrorRegexp.FindStringSubmatch(line); match != nil {
+			lineNumber, _ := strconv.Atoi(match[2])
+			column, _ := strconv.Atoi(match[3])
+			current = &pb.CompileError{
+				File:     match[1],
+				Line:     int32(lineNumber),
+				Column:   int32(column),
+				Severity: match[4],
+				Message:  match[5],
+			}
+			compileErrors = append(compileErrors, current)
+			lineAfterHeader = 0
+			continue
+		}
+		if kotlincMessageRegexp.MatchString(line) {
+			current = nil
+			continue
+		}
+		if current == nil || strings.TrimSpace(line) == "" {
+			continue
+		}
+		lineAfterHeader++
+		switch {
+		case lineAfterHeader == 1:
+			// source code line
+		case lineAfterHeader == 2 && javacCaretRegexp.MatchString(line):
+			// the column is already known from the first line
+		default:
+			current.Message += "\n" + strings.TrimSpace(line)
+		}
+	}
+	return compileErrors
+}
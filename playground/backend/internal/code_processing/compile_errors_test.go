@@ -0,0 +1,197 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"reflect"
+	"testing"
+)
+
+func Test_parseCompileErrors(t *testing.T) {
+	type args struct {
+		sdk    pb.Sdk
+		output string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*pb.CompileError
+	}{
+		{
+			// Test case with calling parseCompileErrors method with javac output of the incorrect code.
+			// As a result, want to receive a list with one error.
+			name: "javac error",
+			args: args{
+				sdk:    pb.Sdk_SDK_JAVA,
+				output: "/path/to/src/HelloWorld.java:1: error: reached end of file while parsing\nMOCK_CODE\n^\n1 error\n",
+			},
+			want: []*pb.CompileError{
+				{File: "/path/to/src/HelloWorld.java", Line: 1, Column: 1, Severity: "error", Message: "reached end of file while parsing"},
+			},
+		},
+		{
+			// Test case with calling parseCompileErrors method with javac output which contains several errors and warnings.
+			// As a result, want to receive a list with all errors and warnings with additional information in messages.
+			name: "javac several errors",
+			args: args{
+				sdk: pb.Sdk_SDK_JAVA,
+				output: "/path/to/src/HelloWorld.java:3: error: cannot find symbol\n" +
+					"        System.out.println(x);\n" +
+					"                           ^\n" +
+					"  symbol:   variable x\n" +
+					"  location: class HelloWorld\n" +
+					"/path/to/src/Helper.java:5: warning: [deprecation] Integer(int) in Integer has been deprecated\n" +
+					"    Integer i = new Integer(1);\n" +
+					"                ^\n" +
+					"1 error\n" +
+					"1 warning\n",
+			},
+			want: []*pb.CompileError{
+				{File: "/path/to/src/HelloWorld.java", Line: 3, Column: 28, Severity: "error", Message: "cannot find symbol\nsymbol:   variable x\nlocation: class HelloWorld"},
+				{File: "/path/to/src/Helper.java", Line: 5, Column: 17, Severity: "warning", Message: "[deprecation] Integer(int) in Integer has been deprecated"},
+			},
+		},
+		{
+			// Test case with calling parseCompileErrors method with output which doesn't contain javac errors.
+			// As a result, want to receive an empty list.
+			name: "javac unknown output",
+			args: args{
+				sdk:    pb.Sdk_SDK_JAVA,
+				output: "error: exec: \"javac\": executable file not found in $PATH",
+			},
+			want: []*pb.CompileError{},
+		},
+		{
+			// Test case with calling parseCompileErrors method with kotlinc output which contains an error, a warning
+			//	and a message which isn't related to a source file.
+			// As a result, want to receive a list with the error and the warning with columns from their first lines.
+			name: "kotlinc errors",
+			args: args{
+				sdk: pb.Sdk_SDK_KOTLIN,
+				output: "warning: classpath entry points to a non-existent location: /opt/apache/beam/jars/missing.jar\n" +
+					"/path/to/src/HelloWorld.kt:3:13: error: unresolved reference: x\n" +
+					"    println(x)\n" +
+					"            ^\n" +
+					"/path/to/src/HelloWorld.kt:2:9: warning: variable 'y' is never used\n" +
+					"    val y = 1\n" +
+					"        ^\n",
+			},
+			want: []*pb.CompileError{
+				{File: "/path/to/src/HelloWorld.kt", Line: 3, Column: 13, Severity: "error", Message: "unresolved reference: x"},
+				{File: "/path/to/src/HelloWorld.kt", Line: 2, Column: 9, Severity: "warning", Message: "variable 'y' is never used"},
+			},
+		},
+		{
+			// Test case with calling parseCompileErrors method with sdk which compiler output isn't supported.
+			// As a result, want to receive an empty list.
+			name: "unsupported sdk",
+			args: args{
+				sdk:    pb.Sdk_SDK_GO,
+				output: "./main.go:4:2: undefined: fmt.Printl\n",
+			},
+			want: []*pb.CompileError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCompileErrors(tt.args.sdk, tt.args.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCompileErrors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseCompileWarnings(t *testing.T) {
+	type args struct {
+		sdk    pb.Sdk
+		output string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*pb.CompileError
+	}{
+		{
+			// Test case with calling parseCompileWarnings method with javac output which contains an error, a warning and notes.
+			// As a result, want to receive a list with the warning and the notes joined into one warning.
+			name: "javac warnings and notes",
+			args: args{
+				sdk: pb.Sdk_SDK_JAVA,
+				output: "/path/to/src/HelloWorld.java:3: error: cannot find symbol\n" +
+					"        System.out.println(x);\n" +
+					"                           ^\n" +
+					"/path/to/src/Helper.java:5: warning: [removal] Integer(int) in Integer has been deprecated and marked for removal\n" +
+					"    Integer i = new Integer(1);\n" +
+					"                ^\n" +
+					"Note: /path/to/src/Helper.java uses unchecked or unsafe operations.\n" +
+					"Note: Recompile with -Xlint:unchecked for details.\n" +
+					"1 error\n" +
+					"1 warning\n",
+			},
+			want: []*pb.CompileError{
+				{File: "/path/to/src/Helper.java", Line: 5, Column: 17, Severity: "warning", Message: "[removal] Integer(int) in Integer has been deprecated and marked for removal"},
+				{File: "/path/to/src/Helper.java", Severity: "warning", Message: "/path/to/src/Helper.java uses unchecked or unsafe operations.\nRecompile with -Xlint:unchecked for details."},
+			},
+		},
+		{
+			// Test case with calling parseCompileWarnings method with javac output which contains notes about several files.
+			// As a result, want to receive a warning without the file.
+			name: "javac notes about several files",
+			args: args{
+				sdk:    pb.Sdk_SDK_JAVA,
+				output: "Note: Some input files use or override a deprecated API.\nNote: Recompile with -Xlint:deprecation for details.\n",
+			},
+			want: []*pb.CompileError{
+				{Severity: "warning", Message: "Some input files use or override a deprecated API.\nRecompile with -Xlint:deprecation for details."},
+			},
+		},
+		{
+			// Test case with calling parseCompileWarnings method with kotlinc output which contains an error and a warning.
+			// As a result, want to receive a list with the warning.
+			name: "kotlinc warnings",
+			args: args{
+				sdk: pb.Sdk_SDK_KOTLIN,
+				output: "/path/to/src/HelloWorld.kt:3:13: error: unresolved reference: x\n" +
+					"    println(x)\n" +
+					"            ^\n" +
+					"/path/to/src/HelloWorld.kt:2:9: warning: variable 'y' is never used\n" +
+					"    val y = 1\n" +
+					"        ^\n",
+			},
+			want: []*pb.CompileError{
+				{File: "/path/to/src/HelloWorld.kt", Line: 2, Column: 9, Severity: "warning", Message: "variable 'y' is never used"},
+			},
+		},
+		{
+			// Test case with calling parseCompileWarnings method with empty output of the successful compilation.
+			// As a result, want to receive an empty list.
+			name: "no warnings",
+			args: args{
+				sdk:    pb.Sdk_SDK_JAVA,
+				output: "",
+			},
+			want: []*pb.CompileError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCompileWarnings(tt.args.sdk, tt.args.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCompileWarnings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/setup_tools/builder"
+	"beam.apache.org/playground/backend/internal/utils"
+	"context"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// uncompiledClassName is used in the planned run command of the JVM-based SDKs instead of the name of the class
+//
+//	which is run if the code isn't compiled yet, since the name is known after the compile step
+const uncompiledClassName = "MAIN_CLASS"
+
+// ExecutionPlan contains the commands which are used by Process to compile and run the code
+type ExecutionPlan struct {
+	// CompileCommand is the command line of the compile step. It is empty if the SDK doesn't need compilation (i.e. Python).
+	CompileCommand string
+
+	// RunCommand is the command line of the run step without its environment variables
+	RunCommand string
+
+	// Env is the environment variables ("KEY=value") of the run step. Values which could contain secrets are redacted.
+	Env []string
+}
+
+// PlanExecution returns the compile and run commands which Process would use for the code of lc with pipelineOptions,
+//
+//	i.e. for debugging the configuration of the SDK. Nothing is run: the code isn't validated, prepared or compiled,
+//	the files of lc aren't changed and nothing is saved into cache.
+//
+// The commands are planned for the code which isn't a unit test. The limits and the isolation of the code
+//
+//	which are set by the server (i.e. the memory limit) aren't included.
+//
+// The name of the class which is run by the JVM-based SDKs is known after the compile step,
+//
+//	so it is uncompiledClassName if the code of lc isn't compiled yet.
+//
+// In case the executor couldn't be set up for the SDK (i.e. the pipeline options are malformed) returns an error.
+func PlanExecution(ctx context.Context, lc *fs_tool.LifeCycle, sdkEnv *environment.BeamEnvs, pipelineOptions string) (*ExecutionPlan, error) {
+	pipelineId := lc.PipelineId()
+	pipelineOptions = utils.MergePipelineOptions(sdkEnv.ExecutorConfig.PipelineOptions, pipelineOptions)
+	pipelineOptions = strings.ReplaceAll(pipelineOptions, pipelineIdToken, pipelineId.String())
+	executorBuilder, err := builder.SetupExecutorBuilder(lc, utils.ReduceWhiteSpacesToSinge(pipelineOptions), sdkEnv)
+	if err != nil {
+		return nil, err
+	}
+	executorBuilder = executorBuilder.WithEnv(pipelineEnv(sdkEnv.ExecutorConfig.Env, pipelineId, lc))
+	executor := executorBuilder.Build()
+
+	plan := &ExecutionPlan{}
+	switch sdkEnv.ApacheBeamSdk {
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_GO, pb.Sdk_SDK_SCIO, pb.Sdk_SDK_KOTLIN:
+		plan.CompileCommand = commandLine(&exec.Cmd{Args: executor.Compile(ctx).Args})
+	}
+	if sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_JAVA || sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_SCIO || sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_KOTLIN {
+		// the class is looked for in the compiled files as setJavaExecutableFile does, but the errors aren't saved into cache
+		className, err := lc.ExecutableName(pipelineId, lc.WorkingDir())
+		if err != nil {
+			className = uncompiledClassName
+		}
+		executor = executorBuilder.WithExecutableFileName(className).Build()
+	}
+	var validationResults sync.Map
+	runCmd := getExecuteCmd(&validationResults, &executor, ctx)
+	plan.RunCommand = commandLine(&exec.Cmd{Args: runCmd.Args})
+	plan.Env = redactedEnv(runCmd.Env)
+	return plan, nil
+}
@@ -0,0 +1,134 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/executors"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"context"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPlanExecution(t *testing.T) {
+	javaSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_JAVA, environment.NewExecutorConfig("javac", "java", "java", []string{"-d", "bin", "-classpath"}, []string{"-cp", "bin:"}, []string{"-cp", "bin:", "JUnit"}), "")
+	javaSdkEnv.ExecutorConfig.Env = map[string]string{"API_TOKEN": "MOCK_TOKEN"}
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "", []string{}, []string{"-u"}, []string{}), "")
+	javaCode := "public class HelloWorld {\n    public static void main(String[] args) {\n        System.out.println(\"Hello world!\");\n    }\n}\n"
+	pythonCode := "print('Hello world!')\n"
+	ctx := context.Background()
+
+	tests := []struct {
+		name                   string
+		sdkEnv                 *environment.BeamEnvs
+		code                   string
+		compiledClass          string
+		pipelineOptions        string
+		expectedCompileCommand []string
+		expectedRunCommand     string
+		expectedEnv            []string
+		wantErr                bool
+	}{
+		{
+			// Test case with calling PlanExecution method with Java code which isn't compiled yet.
+			// As a result, want to receive the compile command with the args of the config of the SDK
+			//	and the run command with the placeholder of the class which is known after compilation.
+			name:                   "java code",
+			sdkEnv:                 javaSdkEnv,
+			code:                   javaCode,
+			expectedCompileCommand: []string{"javac -d bin -classpath ", ".java"},
+			expectedRunCommand:     "java -cp bin: " + uncompiledClassName,
+			expectedEnv:            []string{"API_TOKEN=" + shellQuote(redactedValue)},
+		},
+		{
+			// Test case with calling PlanExecution method with Java code which is already compiled and pipeline options.
+			// As a result, want to receive the run command with the compiled class and the pipeline options.
+			name:                   "compiled java code",
+			sdkEnv:                 javaSdkEnv,
+			code:                   javaCode,
+			compiledClass:          "HelloWorld",
+			pipelineOptions:        "--output out.txt",
+			expectedCompileCommand: []string{"javac -d bin -classpath "},
+			expectedRunCommand:     "java -cp bin: HelloWorld --output=out.txt",
+		},
+		{
+			// Test case with calling PlanExecution method with Python code.
+			// As a result, want to receive no compile command and the run command of the source file.
+			name:               "python code",
+			sdkEnv:             pythonSdkEnv,
+			code:               pythonCode,
+			expectedRunCommand: "python3 -u ",
+		},
+		{
+			// Test case with calling PlanExecution method with malformed pipeline options.
+			// As a result, want to receive an error.
+			name:            "malformed pipeline options",
+			sdkEnv:          javaSdkEnv,
+			code:            javaCode,
+			pipelineOptions: "-Xmx",
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(tt.sdkEnv.ApacheBeamSdk, pipelineId, t.TempDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+			if tt.compiledClass != "" {
+				if err := os.WriteFile(filepath.Join(lc.GetAbsoluteCompiledFolderPath(), tt.compiledClass+".class"), []byte{}, 0600); err != nil {
+					t.Fatalf("error during write compiled class: %s", err.Error())
+				}
+			}
+
+			got, err := PlanExecution(ctx, lc, tt.sdkEnv, tt.pipelineOptions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlanExecution() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for _, part := range tt.expectedCompileCommand {
+				if !strings.Contains(got.CompileCommand, part) {
+					t.Errorf("PlanExecution() compile command = %q, expectes it to contain %q", got.CompileCommand, part)
+				}
+			}
+			if len(tt.expectedCompileCommand) == 0 && got.CompileCommand != "" {
+				t.Errorf("PlanExecution() compile command = %q, expectes no compile command", got.CompileCommand)
+			}
+			if !strings.HasPrefix(got.RunCommand, tt.expectedRunCommand) {
+				t.Errorf("PlanExecution() run command = %q, expectes it to start with %q", got.RunCommand, tt.expectedRunCommand)
+			}
+			expectedEnv := append([]string{executors.PipelineIdEnvKey + "=" + pipelineId.String()}, tt.expectedEnv...)
+			for _, variable := range expectedEnv {
+				if !containsEnv(got.Env, variable) {
+					t.Errorf("PlanExecution() env = %v, expectes it to contain %q", got.Env, variable)
+				}
+			}
+			// nothing is run, so the source file isn't prepared
+			if code, _ := os.ReadFile(lc.GetAbsoluteSourceFilePath()); string(code) != tt.code {
+				t.Errorf("PlanExecution() changed the source file: %q, expectes: %q", code, tt.code)
+			}
+		})
+	}
+}
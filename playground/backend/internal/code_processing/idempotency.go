@@ -0,0 +1,166 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+const (
+	// idempotencyKeyWaitTimeout is the max time to wait until the code processing with the claimed idempotency key is started
+	idempotencyKeyWaitTimeout = 10 * time.Second
+	// idempotencyKeyPollInterval is the interval of checking if the code processing with the claimed idempotency key is started
+	idempotencyKeyPollInterval = 50 * time.Millisecond
+)
+
+// idempotencyKeysNamespace is the namespace of the ids which the idempotency keys are kept in the cache by
+var idempotencyKeysNamespace = uuid.MustParse("857df4a3-94ba-438e-8f7e-3cd8ab33cc3e")
+
+// errIdempotencyKeyReleased is returned by waitIdempotentPipelineId in case the claim of the idempotency key is released
+var errIdempotencyKeyReleased = errors.New("idempotency key is released")
+
+// ErrIdempotencyKeyReused is returned by ClaimIdempotencyKey in case the idempotency key is already claimed by the request
+//	with another payload, so the key is reused by the client instead of being retried with the same request.
+var ErrIdempotencyKeyReused = errors.New("idempotency key is already used for another request")
+
+// IdempotencyKey is the idempotency key of the request which is scoped by the client, so the clients which send
+//	the same key (i.e. by guessing it) don't receive the pipelines of each other.
+type IdempotencyKey struct {
+	// ClientId is the identifier of the client which sent the request
+	ClientId string
+	// Key is the idempotency key which is sent by the client
+	Key string
+}
+
+// id returns the id which the idempotency key is kept in the cache by
+func (k IdempotencyKey) id() uuid.UUID {
+	// the client identifier never contains the zero byte, so the different pairs of the client and the key aren't mixed
+	return uuid.NewSHA1(idempotencyKeysNamespace, []byte(k.ClientId+"\x00"+k.Key))
+}
+
+// IdempotencyPayloadHash returns the hash of the request to run the code which is compared for the requests
+//	with the same idempotency key. The idempotency key itself isn't hashed.
+func IdempotencyPayloadHash(request *pb.RunCodeRequest) (string, error) {
+	payload := proto.Clone(request).(*pb.RunCodeRequest)
+	payload.IdempotencyKey = ""
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// ClaimIdempotencyKey claims the idempotency key, so the code sent several times with the same key
+//	(i.e. by the client which retries the request) is processed only once.
+// Returns true if the key is claimed by this call. The caller should start the code processing and save its id
+//	by SetIdempotentPipelineId or release the key by ReleaseIdempotencyKey if the code processing isn't started.
+// Returns false and the id of the pipeline which is started with the key if the key is already claimed.
+//	If the code processing isn't started yet, waits until it is started or the key is released by the concurrent request.
+// Returns ErrIdempotencyKeyReused if the key is already claimed by the request with another payloadHash.
+// The key is kept in the cache for ttl.
+func ClaimIdempotencyKey(ctx context.Context, cacheService cache.Cache, key IdempotencyKey, payloadHash string, ttl time.Duration) (uuid.UUID, bool, error) {
+	keyId := key.id()
+	waitCtx, cancel := context.WithTimeout(ctx, idempotencyKeyWaitTimeout)
+	defer cancel()
+	for {
+		claims, err := cacheService.IncrementValue(ctx, keyId, cache.IdempotencyClaims, 1)
+		if err != nil {
+			return uuid.Nil, false, err
+		}
+		if claims == 1 {
+			if err = cacheService.SetExpTime(ctx, keyId, ttl); err != nil {
+				return uuid.Nil, false, err
+			}
+			if err = cacheService.SetValue(ctx, keyId, cache.IdempotencyPayloadHash, payloadHash); err != nil {
+				return uuid.Nil, false, err
+			}
+			return uuid.Nil, true, nil
+		}
+		pipelineId, err := waitIdempotentPipelineId(waitCtx, cacheService, keyId, payloadHash)
+		if errors.Is(err, errIdempotencyKeyReleased) {
+			continue
+		}
+		if err != nil {
+			return uuid.Nil, false, err
+		}
+		return pipelineId, false, nil
+	}
+}
+
+// SetIdempotentPipelineId saves the id of the pipeline which is started with the claimed idempotency key,
+//	so the requests with the same key receive it. The key is kept in the cache for ttl.
+func SetIdempotentPipelineId(ctx context.Context, cacheService cache.Cache, key IdempotencyKey, pipelineId uuid.UUID, ttl time.Duration) error {
+	keyId := key.id()
+	if err := cacheService.SetValue(ctx, keyId, cache.IdempotentPipelineId, pipelineId.String()); err != nil {
+		return err
+	}
+	// the key could be expired and removed during the start of the code processing, so the value is saved with the new key
+	return cacheService.SetExpTime(ctx, keyId, ttl)
+}
+
+// ReleaseIdempotencyKey releases the claimed idempotency key in case the code processing isn't started,
+//	so the key could be claimed again by the next request with it.
+func ReleaseIdempotencyKey(ctx context.Context, cacheService cache.Cache, key IdempotencyKey) error {
+	keyId := key.id()
+	// the empty id marks the released key for the local cache which increments the counter of the expired key as is
+	if err := cacheService.SetValue(ctx, keyId, cache.IdempotentPipelineId, ""); err != nil {
+		return err
+	}
+	return cacheService.SetExpTime(ctx, keyId, 0)
+}
+
+// waitIdempotentPipelineId waits until the id of the pipeline which is started with the idempotency key is saved.
+// The values of the key are only read, so the released key isn't created again without the expiration time.
+// Returns errIdempotencyKeyReleased in case the key is released, so it should be claimed again.
+// Returns ErrIdempotencyKeyReused in case the key is claimed by the request with another payloadHash.
+func waitIdempotentPipelineId(ctx context.Context, cacheService cache.Cache, keyId uuid.UUID, payloadHash string) (uuid.UUID, error) {
+	ticker := time.NewTicker(idempotencyKeyPollInterval)
+	defer ticker.Stop()
+	for {
+		values, err := cacheService.GetValues(ctx, keyId, []cache.SubKey{cache.IdempotencyClaims, cache.IdempotencyPayloadHash, cache.IdempotentPipelineId})
+		switch {
+		case err == nil:
+			// the counter is removed together with the key, so it doesn't exist only if the key is released
+			if _, found := values[cache.IdempotencyClaims]; !found {
+				return uuid.Nil, errIdempotencyKeyReleased
+			}
+			if claimedHash, ok := values[cache.IdempotencyPayloadHash].(string); ok && claimedHash != payloadHash {
+				return uuid.Nil, ErrIdempotencyKeyReused
+			}
+			if pipelineId, ok := values[cache.IdempotentPipelineId].(string); ok && pipelineId != "" {
+				return uuid.Parse(pipelineId)
+			}
+		case errors.Is(err, cache.ErrNotFound):
+			return uuid.Nil, errIdempotencyKeyReleased
+		default:
+			return uuid.Nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return uuid.Nil, fmt.Errorf("code processing with the idempotency key isn't started: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/cache/local"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClaimIdempotencyKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cacheService := local.New(ctx)
+	ttl := time.Minute
+	payloadHash := "MOCK_PAYLOAD_HASH"
+
+	// Test case with calling ClaimIdempotencyKey method by several concurrent requests with the same key.
+	// As a result, want to receive that the key is claimed only by one request
+	//	and all other requests receive the id of the pipeline which is started by it.
+	key := IdempotencyKey{ClientId: "MOCK_CLIENT", Key: uuid.New().String()}
+	pipelineId := uuid.New()
+	requests := 10
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	claims := 0
+	receivedIds := make([]uuid.UUID, 0, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			existingId, claimed, err := ClaimIdempotencyKey(ctx, cacheService, key, payloadHash, ttl)
+			if err != nil {
+				t.Errorf("ClaimIdempotencyKey() error = %v", err)
+				return
+			}
+			if claimed {
+				// the code processing is started a bit later than the key is claimed
+				time.Sleep(100 * time.Millisecond)
+				if err := SetIdempotentPipelineId(ctx, cacheService, key, pipelineId, ttl); err != nil {
+					t.Errorf("SetIdempotentPipelineId() error = %v", err)
+				}
+				existingId = pipelineId
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if claimed {
+				claims++
+			}
+			receivedIds = append(receivedIds, existingId)
+		}()
+	}
+	wg.Wait()
+	if claims != 1 {
+		t.Errorf("ClaimIdempotencyKey() claimed the key %d times, want 1", claims)
+	}
+	if len(receivedIds) != requests {
+		t.Fatalf("ClaimIdempotencyKey() returned %d ids, want %d", len(receivedIds), requests)
+	}
+	for _, receivedId := range receivedIds {
+		if receivedId != pipelineId {
+			t.Errorf("ClaimIdempotencyKey() got = %v, want %v", receivedId, pipelineId)
+		}
+	}
+
+	// Test case with calling ClaimIdempotencyKey method with the key which is released by the previous request.
+	// As a result, want to receive that the key is claimed again.
+	releasedKey := IdempotencyKey{ClientId: "MOCK_CLIENT", Key: uuid.New().String()}
+	if _, claimed, err := ClaimIdempotencyKey(ctx, cacheService, releasedKey, payloadHash, ttl); err != nil || !claimed {
+		t.Fatalf("ClaimIdempotencyKey() claimed = %v, error = %v, want the key to be claimed", claimed, err)
+	}
+	if err := ReleaseIdempotencyKey(ctx, cacheService, releasedKey); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey() error = %v", err)
+	}
+	if _, claimed, err := ClaimIdempotencyKey(ctx, cacheService, releasedKey, payloadHash, ttl); err != nil || !claimed {
+		t.Errorf("ClaimIdempotencyKey() claimed = %v, error = %v, want the released key to be claimed again", claimed, err)
+	}
+
+	// Test case with calling ClaimIdempotencyKey method with the key which is released while the request waits for it.
+	// As a result, want to receive that the key is claimed by the waiting request.
+	waitedKey := IdempotencyKey{ClientId: "MOCK_CLIENT", Key: uuid.New().String()}
+	if _, claimed, err := ClaimIdempotencyKey(ctx, cacheService, waitedKey, payloadHash, ttl); err != nil || !claimed {
+		t.Fatalf("ClaimIdempotencyKey() claimed = %v, error = %v, want the key to be claimed", claimed, err)
+	}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = ReleaseIdempotencyKey(ctx, cacheService, waitedKey)
+	}()
+	if _, claimed, err := ClaimIdempotencyKey(ctx, cacheService, waitedKey, payloadHash, ttl); err != nil || !claimed {
+		t.Errorf("ClaimIdempotencyKey() claimed = %v, error = %v, want the released key to be claimed by the waiting request", claimed, err)
+	}
+
+	// Test case with calling ClaimIdempotencyKey method with the key which is claimed by another client.
+	// As a result, want to receive that the key is claimed by the client since the keys of the clients are separate.
+	otherClientKey := IdempotencyKey{ClientId: "MOCK_OTHER_CLIENT", Key: key.Key}
+	if _, claimed, err := ClaimIdempotencyKey(ctx, cacheService, otherClientKey, payloadHash, ttl); err != nil || !claimed {
+		t.Errorf("ClaimIdempotencyKey() claimed = %v, error = %v, want the key of another client to be claimed", claimed, err)
+	}
+
+	// Test case with calling ClaimIdempotencyKey method with the key which is claimed by the request with another payload.
+	// As a result, want to receive ErrIdempotencyKeyReused.
+	if _, _, err := ClaimIdempotencyKey(ctx, cacheService, key, "MOCK_OTHER_PAYLOAD_HASH", ttl); !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Errorf("ClaimIdempotencyKey() error = %v, want %v", err, ErrIdempotencyKeyReused)
+	}
+
+	// Test case with calling waitIdempotentPipelineId method with the key which is released.
+	// As a result, want to receive errIdempotencyKeyReleased and the key which isn't created again without the expiration time.
+	notRecreatedKey := IdempotencyKey{ClientId: "MOCK_CLIENT", Key: uuid.New().String()}
+	if _, claimed, err := ClaimIdempotencyKey(ctx, cacheService, notRecreatedKey, payloadHash, ttl); err != nil || !claimed {
+		t.Fatalf("ClaimIdempotencyKey() claimed = %v, error = %v, want the key to be claimed", claimed, err)
+	}
+	if err := ReleaseIdempotencyKey(ctx, cacheService, notRecreatedKey); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey() error = %v", err)
+	}
+	if _, err := waitIdempotentPipelineId(ctx, cacheService, notRecreatedKey.id(), payloadHash); !errors.Is(err, errIdempotencyKeyReleased) {
+		t.Errorf("waitIdempotentPipelineId() error = %v, want %v", err, errIdempotencyKeyReleased)
+	}
+	if value, err := cacheService.GetValue(ctx, notRecreatedKey.id(), cache.IdempotencyClaims); !errors.Is(err, cache.ErrNotFound) {
+		t.Errorf("GetValue() got = %v, error = %v, want the released key not to be created again", value, err)
+	}
+}
+
+func TestIdempotencyPayloadHash(t *testing.T) {
+	request := &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, PipelineOptions: "--name=first", IdempotencyKey: "MOCK_KEY"}
+	want, err := IdempotencyPayloadHash(request)
+	if err != nil {
+		t.Fatalf("IdempotencyPayloadHash() error = %v", err)
+	}
+	tests := []struct {
+		name     string
+		request  *pb.RunCodeRequest
+		wantSame bool
+	}{
+		{
+			// Test case with calling IdempotencyPayloadHash method with the same request with another idempotency key.
+			// As a result, want to receive the same hash.
+			name:     "another idempotency key",
+			request:  &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, PipelineOptions: "--name=first", IdempotencyKey: "MOCK_OTHER_KEY"},
+			wantSame: true,
+		},
+		{
+			// Test case with calling IdempotencyPayloadHash method with the request with another code.
+			// As a result, want to receive another hash.
+			name:     "another code",
+			request:  &pb.RunCodeRequest{Code: "MOCK_OTHER_CODE", Sdk: pb.Sdk_SDK_JAVA, PipelineOptions: "--name=first", IdempotencyKey: "MOCK_KEY"},
+			wantSame: false,
+		},
+		{
+			// Test case with calling IdempotencyPayloadHash method with the request with other pipeline options.
+			// As a result, want to receive another hash.
+			name:     "other pipeline options",
+			request:  &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, PipelineOptions: "--name=second", IdempotencyKey: "MOCK_KEY"},
+			wantSame: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IdempotencyPayloadHash(tt.request)
+			if err != nil {
+				t.Fatalf("IdempotencyPayloadHash() error = %v", err)
+			}
+			if (got == want) != tt.wantSame {
+				t.Errorf("IdempotencyPayloadHash() got = %v, want the same hash as %v: %v", got, want, tt.wantSame)
+			}
+		})
+	}
+	if request.IdempotencyKey != "MOCK_KEY" {
+		t.Errorf("IdempotencyPayloadHash() changed the idempotency key of the request")
+	}
+}
@@ -0,0 +1,151 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	stderrors "errors"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// maxSweepInterval is a max interval between checks of the idle pipelines
+const maxSweepInterval = time.Minute
+
+// IdleSweeper removes from the cache finished pipelines which status and output haven't been read for the idle timeout.
+// The time of the last read of the client is kept as cache.LastAccessed by MarkAccessed.
+type IdleSweeper struct {
+	cacheService  cache.Cache
+	idleTimeout   time.Duration
+	sweepInterval time.Duration
+	// now returns the current time, it is replaced in tests to move the clock forward
+	now func() time.Time
+
+	mu        sync.Mutex
+	pipelines map[uuid.UUID]struct{}
+}
+
+// NewIdleSweeper constructor for IdleSweeper.
+// If idleTimeout isn't positive, pipelines aren't tracked and idle pipelines aren't removed.
+func NewIdleSweeper(cacheService cache.Cache, idleTimeout time.Duration) *IdleSweeper {
+	sweepInterval := idleTimeout / 2
+	if sweepInterval > maxSweepInterval {
+		sweepInterval = maxSweepInterval
+	}
+	return &IdleSweeper{
+		cacheService:  cacheService,
+		idleTimeout:   idleTimeout,
+		sweepInterval: sweepInterval,
+		now:           time.Now,
+		pipelines:     make(map[uuid.UUID]struct{}),
+	}
+}
+
+// Track starts tracking of the pipeline, so it is removed from the cache when it becomes idle.
+// The pipeline is considered as accessed at the moment it is tracked.
+func (s *IdleSweeper) Track(ctx context.Context, pipelineId uuid.UUID) error {
+	if s.idleTimeout <= 0 {
+		return nil
+	}
+	if err := s.cacheService.SetValue(ctx, pipelineId, cache.LastAccessed, s.now()); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pipelines[pipelineId] = struct{}{}
+	return nil
+}
+
+// MarkAccessed saves the current time as cache.LastAccessed into cache, so the pipeline isn't considered as idle.
+// It should be called when the client reads the status or the output of the pipeline, the reads of the server itself
+//	aren't counted. The pipeline which doesn't exist in cache (i.e. it is expired) isn't created again.
+func MarkAccessed(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) {
+	err := cacheService.UpdateValue(ctx, pipelineId, cache.LastAccessed, time.Now())
+	if err != nil && !stderrors.Is(err, cache.ErrNotFound) {
+		logger.Errorf("%s: MarkAccessed(): cache.UpdateValue: error: %s", pipelineId, err.Error())
+	}
+}
+
+// Run removes idle pipelines from the cache every sweep interval until ctx is done.
+// If the idle timeout isn't positive, Run returns immediately.
+func (s *IdleSweeper) Run(ctx context.Context) {
+	if s.idleTimeout <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep(ctx)
+		}
+	}
+}
+
+// sweep removes from the cache tracked pipelines which are finished and haven't been accessed for the idle timeout.
+// The pipeline is removed by setting zero expiration time, so it is expired immediately.
+// Pipelines which are removed from the cache aren't tracked anymore.
+func (s *IdleSweeper) sweep(ctx context.Context) {
+	for _, pipelineId := range s.trackedPipelines() {
+		status, err := s.cacheService.GetValue(ctx, pipelineId, cache.Status)
+		if err != nil {
+			// the pipeline is already removed from the cache
+			s.untrack(pipelineId)
+			continue
+		}
+		if status, ok := status.(pb.Status); !ok || !IsFinalStatus(status) {
+			continue
+		}
+		value, err := s.cacheService.GetValue(ctx, pipelineId, cache.LastAccessed)
+		if err != nil {
+			continue
+		}
+		lastAccessed, ok := value.(time.Time)
+		if !ok || s.now().Sub(lastAccessed) < s.idleTimeout {
+			continue
+		}
+		if err := s.cacheService.SetExpTime(ctx, pipelineId, 0); err != nil {
+			logger.Errorf("%s: IdleSweeper: cache.SetExpTime(): %s", pipelineId, err.Error())
+			continue
+		}
+		logger.Infof("%s: IdleSweeper: pipeline is removed since it isn't accessed from %s", pipelineId, lastAccessed)
+		s.untrack(pipelineId)
+	}
+}
+
+// trackedPipelines returns ids of the tracked pipelines
+func (s *IdleSweeper) trackedPipelines() []uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pipelines := make([]uuid.UUID, 0, len(s.pipelines))
+	for pipelineId := range s.pipelines {
+		pipelines = append(pipelines, pipelineId)
+	}
+	return pipelines
+}
+
+// untrack stops tracking of the pipeline
+func (s *IdleSweeper) untrack(pipelineId uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pipelines, pipelineId)
+}
@@ -0,0 +1,214 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/goleak"
+	"testing"
+	"time"
+)
+
+func TestIdleSweeper_sweep(t *testing.T) {
+	idleTimeout := time.Minute
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		status      pb.Status
+		readStatus  bool
+		accessed    bool
+		elapsed     time.Duration
+		wantRemoved bool
+	}{
+		{
+			// Test case with sweeping finished pipeline which isn't accessed for longer than the idle timeout.
+			// As a result the pipeline should be removed from the cache.
+			name:        "finished pipeline is idle",
+			status:      pb.Status_STATUS_FINISHED,
+			elapsed:     idleTimeout + time.Second,
+			wantRemoved: true,
+		},
+		{
+			// Test case with sweeping finished pipeline which is accessed recently.
+			// As a result the pipeline should be kept in the cache.
+			name:        "finished pipeline isn't idle",
+			status:      pb.Status_STATUS_FINISHED,
+			elapsed:     idleTimeout - time.Second,
+			wantRemoved: false,
+		},
+		{
+			// Test case with sweeping executing pipeline which isn't accessed for longer than the idle timeout.
+			// As a result the pipeline should be kept in the cache since the code is still processing.
+			name:        "executing pipeline is idle",
+			status:      pb.Status_STATUS_EXECUTING,
+			elapsed:     idleTimeout + time.Second,
+			wantRemoved: false,
+		},
+		{
+			// Test case with sweeping finished pipeline which status is read by the client after the pipeline is tracked.
+			// As a result the pipeline should be kept in the cache since the idle timeout is counted from the last read.
+			name:        "status of finished pipeline is read",
+			status:      pb.Status_STATUS_FINISHED,
+			accessed:    true,
+			elapsed:     idleTimeout + time.Second,
+			wantRemoved: false,
+		},
+		{
+			// Test case with sweeping finished pipeline which status is read by the server itself after the pipeline is tracked.
+			// As a result the pipeline should be removed from the cache since only the reads of the client are counted.
+			name:        "status of finished pipeline is read by the server",
+			status:      pb.Status_STATUS_FINISHED,
+			readStatus:  true,
+			elapsed:     idleTimeout + time.Second,
+			wantRemoved: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the pipeline is tracked an hour ago, so the reads of its status which are saved by MarkAccessed are later than that
+			trackedAt := time.Now().Add(-time.Hour)
+			sweeper := NewIdleSweeper(cacheService, idleTimeout)
+			sweeper.now = func() time.Time { return trackedAt }
+			pipelineId := uuid.New()
+			_ = cacheService.SetValue(ctx, pipelineId, cache.Status, tt.status)
+			if err := sweeper.Track(ctx, pipelineId); err != nil {
+				t.Fatalf("Track() error: %s", err.Error())
+			}
+			if tt.readStatus {
+				if _, err := GetProcessingStatus(ctx, cacheService, pipelineId, "error"); err != nil {
+					t.Fatalf("GetProcessingStatus() error: %s", err.Error())
+				}
+			}
+			if tt.accessed {
+				MarkAccessed(ctx, cacheService, pipelineId)
+			}
+			// move the clock forward from the moment the pipeline was tracked
+			sweeper.now = func() time.Time { return trackedAt.Add(tt.elapsed) }
+
+			sweeper.sweep(ctx)
+
+			_, err := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if removed := err != nil; removed != tt.wantRemoved {
+				t.Errorf("sweep() removed = %v, want %v", removed, tt.wantRemoved)
+			}
+			if _, tracked := sweeper.pipelines[pipelineId]; tracked == tt.wantRemoved {
+				t.Errorf("sweep() tracked = %v, want %v", tracked, !tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestMarkAccessed(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		prepare      func(pipelineId uuid.UUID)
+		wantAccessed bool
+	}{
+		{
+			// Test case with marking the pipeline which exists in the cache.
+			// As a result the time of the access should be saved.
+			name: "pipeline exists",
+			prepare: func(pipelineId uuid.UUID) {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+			},
+			wantAccessed: true,
+		},
+		{
+			// Test case with marking the pipeline which doesn't exist in the cache.
+			// As a result the pipeline shouldn't be created.
+			name:         "pipeline doesn't exist",
+			prepare:      func(pipelineId uuid.UUID) {},
+			wantAccessed: false,
+		},
+		{
+			// Test case with marking the pipeline which is expired.
+			// As a result the pipeline shouldn't be created again.
+			name: "pipeline is expired",
+			prepare: func(pipelineId uuid.UUID) {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+				_ = cacheService.SetExpTime(ctx, pipelineId, 0)
+			},
+			wantAccessed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			tt.prepare(pipelineId)
+
+			MarkAccessed(ctx, cacheService, pipelineId)
+
+			_, err := cacheService.GetValue(ctx, pipelineId, cache.LastAccessed)
+			if accessed := err == nil; accessed != tt.wantAccessed {
+				t.Errorf("MarkAccessed() saved the access = %v, want %v", accessed, tt.wantAccessed)
+			}
+		})
+	}
+}
+
+func TestIdleSweeper_Run(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx, cancel := context.WithCancel(context.Background())
+	sweeper := NewIdleSweeper(cacheService, time.Minute)
+	sweeper.sweepInterval = 10 * time.Millisecond
+	pipelineId := uuid.New()
+	_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+	if err := sweeper.Track(ctx, pipelineId); err != nil {
+		t.Fatalf("Track() error: %s", err.Error())
+	}
+	sweeper.now = func() time.Time { return time.Now().Add(time.Hour) }
+
+	finished := make(chan struct{})
+	go func() {
+		sweeper.Run(ctx)
+		close(finished)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(sweeper.trackedPipelines()) != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := cacheService.GetValue(ctx, pipelineId, cache.Status); err == nil {
+		t.Errorf("Run() didn't remove idle pipeline")
+	}
+
+	cancel()
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Errorf("Run() isn't finished after the context is canceled")
+	}
+}
+
+func TestIdleSweeper_Disabled(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	sweeper := NewIdleSweeper(cacheService, 0)
+	pipelineId := uuid.New()
+	if err := sweeper.Track(ctx, pipelineId); err != nil {
+		t.Fatalf("Track() error: %s", err.Error())
+	}
+	if len(sweeper.trackedPipelines()) != 0 {
+		t.Errorf("Track() tracks pipeline when the idle timeout isn't set")
+	}
+	// Run returns immediately when the idle timeout isn't set
+	sweeper.Run(ctx)
+}
@@ -0,0 +1,174 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/executors"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/utils"
+	"context"
+	_ "embed"
+	"encoding/json"
+	stderrors "errors"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// metricsFileName is the name of the file which the metrics runner saves the metrics of the pipeline to
+const metricsFileName = "pipeline_metrics.json"
+
+var (
+	// javaMetricsRunnerSource is the source code of the metrics runner of Java SDK
+	//go:embed PlaygroundMetricsRunner.java
+	javaMetricsRunnerSource []byte
+	// pythonMetricsRunnerSource is the source code of the metrics runner of Python SDK
+	//go:embed playground_metrics.py
+	pythonMetricsRunnerSource []byte
+)
+
+// metricsRunner is the runner which runs the pipeline by the DirectRunner and saves the metrics of the finished pipeline
+//	to metricsFileName next to the runner. The source of the runner is added to the code of the user.
+type metricsRunner struct {
+	// fileName is the name of the source file of the runner
+	fileName string
+	source   []byte
+	// option is the pipeline option which makes the code run the pipeline by the runner
+	option string
+}
+
+// pipelineMetric is the metric of the pipeline in the format it is saved by the metrics runner
+type pipelineMetric struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Value     int64  `json:"value"`
+	Sum       int64  `json:"sum"`
+	Count     int64  `json:"count"`
+	Min       int64  `json:"min"`
+	Max       int64  `json:"max"`
+}
+
+// pipelineMetricTypes are the types of the metrics by the names which are used by the metrics runner
+var pipelineMetricTypes = map[string]pb.PipelineMetricType{
+	"counter":      pb.PipelineMetricType_PIPELINE_METRIC_TYPE_COUNTER,
+	"distribution": pb.PipelineMetricType_PIPELINE_METRIC_TYPE_DISTRIBUTION,
+	"gauge":        pb.PipelineMetricType_PIPELINE_METRIC_TYPE_GAUGE,
+}
+
+// getMetricsRunner returns the metrics runner of the sdk.
+// Returns false if the metrics runner isn't supported for the sdk.
+func getMetricsRunner(sdk pb.Sdk) (metricsRunner, bool) {
+	switch sdk {
+	case pb.Sdk_SDK_JAVA:
+		return metricsRunner{fileName: "PlaygroundMetricsRunner.java", source: javaMetricsRunnerSource, option: "--runner=PlaygroundMetricsRunner"}, true
+	case pb.Sdk_SDK_PYTHON:
+		return metricsRunner{fileName: "playground_metrics.py", source: pythonMetricsRunnerSource, option: "--runner=playground_metrics.PlaygroundMetricsRunner"}, true
+	default:
+		return metricsRunner{}, false
+	}
+}
+
+// getMetricsFilePath returns the path to the file with the metrics of the pipeline which is saved by the metrics runner of the sdk.
+// The compiled runner of Java SDK saves the file to the folder with compiled files, the runner of Python SDK - to the folder with the code.
+func getMetricsFilePath(lc *fs_tool.LifeCycle, sdk pb.Sdk) string {
+	if sdk == pb.Sdk_SDK_JAVA {
+		return filepath.Join(lc.GetAbsoluteCompiledFolderPath(), metricsFileName)
+	}
+	return filepath.Join(filepath.Dir(lc.GetAbsoluteSourceFilePath()), metricsFileName)
+}
+
+// setupMetricsRunner adds the source of the metrics runner of the sdk to the code and returns executorBuilder
+//	which compiles the runner with the code and runs the pipeline by the runner.
+// The runner isn't set if the runner is set by pipelineOptions of the user, so the metrics aren't saved in this case.
+// Returns false if the metrics runner isn't supported for the sdk or couldn't be added to the code,
+//	so the code is run without collecting of the metrics.
+func setupMetricsRunner(ctx context.Context, lc *fs_tool.LifeCycle, sdk pb.Sdk, pipelineOptions string, executorBuilder *executors.ExecutorBuilder) (*executors.ExecutorBuilder, bool) {
+	runner, ok := getMetricsRunner(sdk)
+	if !ok {
+		return executorBuilder, false
+	}
+	// the runner is already added if the code is rerun with the files of the pipeline which collected the metrics
+	if !hasSourceFile(lc, runner.fileName) {
+		if err := lc.AddSourceCodeFile(runner.fileName, runner.source); err != nil {
+			phaseLogger(ctx, preparePhase).Errorf("error during adding the metrics runner: %s", err.Error())
+			return executorBuilder, false
+		}
+	}
+	pipelineOptions = utils.ReduceWhiteSpacesToSinge(utils.MergePipelineOptions(runner.option, pipelineOptions))
+	return &executorBuilder.
+		WithCompiler().
+		WithFileNames(lc.GetAbsoluteSourceFilePaths()).
+		WithRunner().
+		WithPipelineOptions(strings.Split(pipelineOptions, " ")).
+		ExecutorBuilder, true
+}
+
+// hasSourceFile returns true if the code has the source file with fileName
+func hasSourceFile(lc *fs_tool.LifeCycle, fileName string) bool {
+	for _, filePath := range lc.GetAbsoluteSourceFilePaths() {
+		if filepath.Base(filePath) == fileName {
+			return true
+		}
+	}
+	return false
+}
+
+// processPipelineMetrics reads the metrics of the pipeline saved by the metrics runner and saves them
+//	as cache.PipelineMetrics into cache.
+// Not all pipelines define metrics and the runner could be overridden by the user, so in case the metrics
+//	aren't saved by the runner or couldn't be read the empty list is saved. Errors don't change the status of the code processing.
+func processPipelineMetrics(ctx context.Context, lc *fs_tool.LifeCycle, sdk pb.Sdk, pipelineId uuid.UUID, cacheService cache.Cache) {
+	metrics := make([]*pb.PipelineMetric, 0)
+	data, err := os.ReadFile(getMetricsFilePath(lc, sdk))
+	switch {
+	case stderrors.Is(err, os.ErrNotExist):
+		phaseLogger(ctx, runPhase).Infof("metrics of the pipeline aren't saved by the runner")
+	case err != nil:
+		phaseLogger(ctx, runPhase).Errorf("error during reading the metrics of the pipeline: %s", err.Error())
+	default:
+		if metrics, err = parsePipelineMetrics(data); err != nil {
+			phaseLogger(ctx, runPhase).Errorf("error during parsing the metrics of the pipeline: %s", err.Error())
+			metrics = make([]*pb.PipelineMetric, 0)
+		}
+	}
+	_ = utils.SetToCache(ctx, cacheService, pipelineId, cache.PipelineMetrics, metrics)
+}
+
+// parsePipelineMetrics parses the metrics of the pipeline from the JSON array saved by the metrics runner
+func parsePipelineMetrics(data []byte) ([]*pb.PipelineMetric, error) {
+	var saved []pipelineMetric
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+	metrics := make([]*pb.PipelineMetric, 0, len(saved))
+	for _, metric := range saved {
+		metrics = append(metrics, &pb.PipelineMetric{
+			Namespace: metric.Namespace,
+			Name:      metric.Name,
+			Type:      pipelineMetricTypes[metric.Type],
+			Value:     metric.Value,
+			Sum:       metric.Sum,
+			Count:     metric.Count,
+			Min:       metric.Min,
+			Max:       metric.Max,
+		})
+	}
+	return metrics, nil
+}
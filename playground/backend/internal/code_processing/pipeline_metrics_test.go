@@ -0,0 +1,345 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/executors"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/goleak"
+	"google.golang.org/protobuf/proto"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	javaCounterCode = "import org.apache.beam.sdk.Pipeline;\n" +
+		"import org.apache.beam.sdk.metrics.Counter;\n" +
+		"import org.apache.beam.sdk.metrics.Metrics;\n" +
+		"import org.apache.beam.sdk.options.PipelineOptionsFactory;\n" +
+		"import org.apache.beam.sdk.transforms.Create;\n" +
+		"import org.apache.beam.sdk.transforms.DoFn;\n" +
+		"import org.apache.beam.sdk.transforms.ParDo;\n\n" +
+		"public class CounterExample {\n" +
+		"  static class CountFn extends DoFn<String, String> {\n" +
+		"    private final Counter elements = Metrics.counter(\"playground\", \"elements\");\n\n" +
+		"    @ProcessElement\n" +
+		"    public void processElement(@Element String element, OutputReceiver<String> out) {\n" +
+		"      elements.inc();\n" +
+		"      out.output(element);\n" +
+		"    }\n" +
+		"  }\n\n" +
+		"  public static void main(String[] args) {\n" +
+		"    Pipeline p = Pipeline.create(PipelineOptionsFactory.fromArgs(args).create());\n" +
+		"    p.apply(Create.of(\"a\", \"b\", \"c\")).apply(ParDo.of(new CountFn()));\n" +
+		"    p.run().waitUntilFinish();\n" +
+		"  }\n" +
+		"}\n"
+	pythonCounterCode = "import apache_beam as beam\n" +
+		"from apache_beam.metrics import Metrics\n" +
+		"from apache_beam.options.pipeline_options import PipelineOptions\n\n\n" +
+		"class CountFn(beam.DoFn):\n" +
+		"  def __init__(self):\n" +
+		"    self.elements = Metrics.counter(\"playground\", \"elements\")\n\n" +
+		"  def process(self, element):\n" +
+		"    self.elements.inc()\n" +
+		"    yield element\n\n\n" +
+		"with beam.Pipeline(options=PipelineOptions()) as p:\n" +
+		"  p | beam.Create([\"a\", \"b\", \"c\"]) | beam.ParDo(CountFn())\n"
+)
+
+func TestProcessWithPipelineMetrics(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	javaSdkEnvs, err := environment.ConfigureBeamEnvs(appEnvs.WorkingDir())
+	if err != nil {
+		panic(err)
+	}
+	javaSdkEnv := javaSdkEnvs[pb.Sdk_SDK_JAVA]
+	javaExecutorConfig := *javaSdkEnv.ExecutorConfig
+	javaExecutorConfig.CollectMetrics = true
+	javaSdkEnv.ExecutorConfig = &javaExecutorConfig
+	pythonExecutorConfig := environment.NewExecutorConfig("", "python3", "", []string{}, []string{}, []string{})
+	pythonExecutorConfig.CollectMetrics = true
+	pythonSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, pythonExecutorConfig, "")
+	counter := &pb.PipelineMetric{Namespace: "playground", Name: "elements", Type: pb.PipelineMetricType_PIPELINE_METRIC_TYPE_COUNTER, Value: 3}
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		sdkEnv            *environment.BeamEnvs
+		code              string
+		pipelineOptions   string
+		requiredCmds      []string
+		requiredBeam      bool
+		expectedRunOutput string
+		expectedMetric    *pb.PipelineMetric
+	}{
+		{
+			// Test case with calling Process method with Python code which doesn't run a pipeline.
+			// As a result status into cache should be set as Status_STATUS_FINISHED and metrics should be empty.
+			name:              "code without pipeline",
+			sdkEnv:            pythonSdkEnv,
+			code:              "print(\"Hello world!\")\n",
+			requiredCmds:      []string{"python3"},
+			expectedRunOutput: "Hello world!\n",
+			expectedMetric:    nil,
+		},
+		{
+			// Test case with calling Process method with Python code which sets the runner by the pipeline options.
+			// As a result status into cache should be set as Status_STATUS_FINISHED and metrics should be empty.
+			name:              "runner is set by the user",
+			sdkEnv:            pythonSdkEnv,
+			code:              "import sys\nprint(sys.argv[1:])\n",
+			pipelineOptions:   "--runner=DirectRunner",
+			requiredCmds:      []string{"python3"},
+			expectedRunOutput: "['--runner=DirectRunner']\n",
+			expectedMetric:    nil,
+		},
+		{
+			// Test case with calling Process method with Python code which defines a counter.
+			// As a result metrics into cache should contain the counter.
+			name:           "python code with counter",
+			sdkEnv:         pythonSdkEnv,
+			code:           pythonCounterCode,
+			requiredCmds:   []string{"python3"},
+			requiredBeam:   true,
+			expectedMetric: counter,
+		},
+		{
+			// Test case with calling Process method with Java code which defines a counter.
+			// As a result metrics into cache should contain the counter.
+			name:           "java code with counter",
+			sdkEnv:         javaSdkEnv,
+			code:           javaCounterCode,
+			requiredCmds:   []string{javaSdkEnv.ExecutorConfig.CompileCmd, javaSdkEnv.ExecutorConfig.RunCmd},
+			requiredBeam:   true,
+			expectedMetric: counter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, cmd := range tt.requiredCmds {
+				if _, err := exec.LookPath(cmd); err != nil {
+					t.Skipf("%s isn't installed", cmd)
+				}
+			}
+			if tt.requiredBeam && !isBeamInstalled(tt.sdkEnv) {
+				t.Skipf("Apache Beam isn't installed")
+			}
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(tt.sdkEnv.ApacheBeamSdk, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile(tt.code)
+
+			Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, tt.sdkEnv, tt.pipelineOptions, "", "", "", nil, nil, nil)
+
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+				runError, _ := cacheService.GetValue(ctx, pipelineId, cache.RunError)
+				t.Fatalf("Process() set status: %s, but expectes: %s, run error: %s", status, pb.Status_STATUS_FINISHED, runError)
+			}
+			if tt.expectedRunOutput != "" {
+				runOutput, _ := cacheService.GetValue(ctx, pipelineId, cache.RunOutput)
+				if !reflect.DeepEqual(runOutput, tt.expectedRunOutput) {
+					t.Errorf("Process() set runOutput: %s, but expectes: %s", runOutput, tt.expectedRunOutput)
+				}
+			}
+			pipelineMetrics, err := GetPipelineMetrics(ctx, cacheService, pipelineId, "")
+			if err != nil {
+				t.Fatalf("Process() didn't set pipeline metrics: %s", err.Error())
+			}
+			if tt.expectedMetric == nil {
+				if len(pipelineMetrics) != 0 {
+					t.Errorf("Process() set pipeline metrics: %v, but expectes empty list", pipelineMetrics)
+				}
+				return
+			}
+			for _, metric := range pipelineMetrics {
+				if proto.Equal(metric, tt.expectedMetric) {
+					return
+				}
+			}
+			t.Errorf("Process() set pipeline metrics: %v, but expectes them to contain: %v", pipelineMetrics, tt.expectedMetric)
+		})
+	}
+}
+
+// isBeamInstalled checks if Apache Beam with the DirectRunner could be used by the code of the SDK
+func isBeamInstalled(sdkEnv *environment.BeamEnvs) bool {
+	if sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_PYTHON {
+		return exec.Command("python3", "-c", "import apache_beam").Run() == nil
+	}
+	for _, arg := range sdkEnv.ExecutorConfig.RunArgs {
+		for _, entry := range strings.Split(arg, string(os.PathListSeparator)) {
+			if jars, _ := filepath.Glob(filepath.Join(filepath.Dir(entry), "beam-runners-direct*.jar")); len(jars) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func Test_parsePipelineMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []*pb.PipelineMetric
+		wantErr bool
+	}{
+		{
+			// Test case with calling parsePipelineMetrics method with metrics of all types.
+			// As a result, want to receive the list of the metrics.
+			name: "metrics of all types",
+			data: `[{"namespace":"ns","name":"elements","type":"counter","value":3},` +
+				`{"namespace":"ns","name":"sizes","type":"distribution","sum":10,"count":4,"min":1,"max":4},` +
+				`{"namespace":"ns","name":"last","type":"gauge","value":7}]`,
+			want: []*pb.PipelineMetric{
+				{Namespace: "ns", Name: "elements", Type: pb.PipelineMetricType_PIPELINE_METRIC_TYPE_COUNTER, Value: 3},
+				{Namespace: "ns", Name: "sizes", Type: pb.PipelineMetricType_PIPELINE_METRIC_TYPE_DISTRIBUTION, Sum: 10, Count: 4, Min: 1, Max: 4},
+				{Namespace: "ns", Name: "last", Type: pb.PipelineMetricType_PIPELINE_METRIC_TYPE_GAUGE, Value: 7},
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with calling parsePipelineMetrics method with the distribution without elements.
+			// As a result, want to receive the distribution with zero values.
+			name:    "empty distribution",
+			data:    `[{"namespace":"ns","name":"sizes","type":"distribution","sum":0,"count":0,"min":null,"max":null}]`,
+			want:    []*pb.PipelineMetric{{Namespace: "ns", Name: "sizes", Type: pb.PipelineMetricType_PIPELINE_METRIC_TYPE_DISTRIBUTION}},
+			wantErr: false,
+		},
+		{
+			// Test case with calling parsePipelineMetrics method with the empty list.
+			// As a result, want to receive the empty list.
+			name:    "no metrics",
+			data:    `[]`,
+			want:    []*pb.PipelineMetric{},
+			wantErr: false,
+		},
+		{
+			// Test case with calling parsePipelineMetrics method with incorrect data.
+			// As a result, want to receive an error.
+			name:    "incorrect data",
+			data:    `{"namespace":`,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePipelineMetrics([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePipelineMetrics() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parsePipelineMetrics() got = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !proto.Equal(got[i], tt.want[i]) {
+					t.Errorf("parsePipelineMetrics() got = %v, want %v", got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_setupMetricsRunner(t *testing.T) {
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	tests := []struct {
+		name            string
+		sdk             pb.Sdk
+		pipelineOptions string
+		want            bool
+		wantFileName    string
+		wantOptions     []string
+	}{
+		{
+			// Test case with calling setupMetricsRunner method for Java SDK.
+			// As a result, want to receive the runner added to the code and set by the pipeline options.
+			name:            "java",
+			sdk:             pb.Sdk_SDK_JAVA,
+			pipelineOptions: "--output=out",
+			want:            true,
+			wantFileName:    "PlaygroundMetricsRunner.java",
+			wantOptions:     []string{"--runner=PlaygroundMetricsRunner", "--output=out"},
+		},
+		{
+			// Test case with calling setupMetricsRunner method for Python SDK with the runner set by the user.
+			// As a result, want to receive the runner added to the code, but the runner of the user is kept.
+			name:            "runner of the user",
+			sdk:             pb.Sdk_SDK_PYTHON,
+			pipelineOptions: "--runner DirectRunner",
+			want:            true,
+			wantFileName:    "playground_metrics.py",
+			wantOptions:     []string{"--runner", "DirectRunner"},
+		},
+		{
+			// Test case with calling setupMetricsRunner method for Go SDK.
+			// As a result, want to receive false since the metrics runner isn't supported.
+			name: "unsupported sdk",
+			sdk:  pb.Sdk_SDK_GO,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(tt.sdk, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			defer lc.DeleteFolders()
+			_, _ = lc.CreateSourceCodeFile("MOCK_CODE")
+			executorBuilder := executors.NewExecutorBuilder().
+				WithRunner().
+				WithPipelineOptions(strings.Split(tt.pipelineOptions, " ")).
+				ExecutorBuilder
+			got, ok := setupMetricsRunner(context.Background(), lc, tt.sdk, tt.pipelineOptions, &executorBuilder)
+			if ok != tt.want {
+				t.Fatalf("setupMetricsRunner() got = %v, want %v", ok, tt.want)
+			}
+			if !ok {
+				return
+			}
+			filePaths := lc.GetAbsoluteSourceFilePaths()
+			if len(filePaths) != 2 || filepath.Base(filePaths[1]) != tt.wantFileName {
+				t.Errorf("setupMetricsRunner() added source files: %v, want: %s", filePaths, tt.wantFileName)
+			}
+			executor := got.Build()
+			cmd := executor.Run(context.Background())
+			if gotOptions := cmd.Args[len(cmd.Args)-len(tt.wantOptions):]; !reflect.DeepEqual(gotOptions, tt.wantOptions) {
+				t.Errorf("setupMetricsRunner() set run args: %v, want them to end with: %v", cmd.Args, tt.wantOptions)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""
+Runner of the Playground backend which runs the pipeline by the DirectRunner
+and saves the metrics of the finished pipeline.
+
+The metrics are saved as a JSON array to the pipeline_metrics.json file next to
+this module, so the backend reads them after the run. The pipeline isn't failed
+if the metrics couldn't be saved.
+"""
+
+import json
+import os
+import sys
+
+from apache_beam.runners.direct.direct_runner import DirectRunner
+from apache_beam.runners.runner import PipelineState
+
+METRICS_FILE_NAME = "pipeline_metrics.json"
+
+
+class PlaygroundMetricsRunner(DirectRunner):
+  """
+  DirectRunner which saves the metrics of the pipeline after the pipeline is finished
+  """
+  def run_pipeline(self, pipeline, options):
+    result = super().run_pipeline(pipeline, options)
+    if PipelineState.is_terminal(result.state):
+      try:
+        _save_metrics(result.metrics().query())
+      except Exception as e:  # pylint: disable=broad-except
+        print("Playground: metrics of the pipeline aren't saved: %s" % e,
+              file=sys.stderr)
+    return result
+
+
+def _save_metrics(results):
+  metrics = []
+  for counter in results["counters"]:
+    metric = _metric(counter, "counter")
+    metric["value"] = _value(counter)
+    metrics.append(metric)
+  for distribution in results["distributions"]:
+    value = _value(distribution)
+    metric = _metric(distribution, "distribution")
+    metric.update(
+        sum=value.sum, count=value.count, min=value.min, max=value.max)
+    metrics.append(metric)
+  for gauge in results["gauges"]:
+    metric = _metric(gauge, "gauge")
+    metric["value"] = _value(gauge).value
+    metrics.append(metric)
+  metrics_file = os.path.join(
+      os.path.dirname(os.path.abspath(__file__)), METRICS_FILE_NAME)
+  with open(metrics_file, "w") as f:
+    json.dump(metrics, f)
+
+
+def _metric(result, metric_type):
+  """
+  Returns the metric with its namespace, name and type
+  """
+  return {
+      "namespace": result.key.metric.namespace,
+      "name": result.key.metric.name,
+      "type": metric_type
+  }
+
+
+def _value(result):
+  """
+  Returns the committed value of the metric or the attempted one
+  if the committed isn't supported
+  """
+  if result.committed is not None:
+    return result.committed
+  return result.attempted
@@ -0,0 +1,112 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// ProcessingTracker keeps track of the running code processing, so it could be stopped when the server is shutting down
+type ProcessingTracker struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	cacheService cache.Cache
+
+	mu        sync.Mutex
+	stopped   bool
+	pipelines map[uuid.UUID]*fs_tool.LifeCycle
+	wg        sync.WaitGroup
+}
+
+// NewProcessingTracker constructor for ProcessingTracker.
+// The context of the code processing started by the tracker is derived from ctx.
+func NewProcessingTracker(ctx context.Context, cacheService cache.Cache) *ProcessingTracker {
+	trackerCtx, cancel := context.WithCancel(ctx)
+	return &ProcessingTracker{
+		ctx:          trackerCtx,
+		cancel:       cancel,
+		cacheService: cacheService,
+		pipelines:    make(map[uuid.UUID]*fs_tool.LifeCycle),
+	}
+}
+
+// Go runs the code processing of the pipeline in a new goroutine.
+// process receives the context which is canceled when the tracker is shut down.
+// Returns false if the tracker is already shut down, so the code processing isn't started.
+func (pt *ProcessingTracker) Go(pipelineId uuid.UUID, lc *fs_tool.LifeCycle, process func(ctx context.Context)) bool {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	if pt.stopped {
+		return false
+	}
+	pt.pipelines[pipelineId] = lc
+	pt.wg.Add(1)
+	go func() {
+		defer pt.finish(pipelineId)
+		process(pt.ctx)
+	}()
+	return true
+}
+
+// Shutdown cancels all running code processing and waits until they are finished, but not longer than gracePeriod.
+// Subprocesses of the code processing are killed when its context is canceled.
+// Code processing which isn't finished in gracePeriod is considered as canceled: its folders are deleted
+//	and playground.Status_STATUS_CANCELED is saved as cache.Status and playground.ErrorCategory_ERROR_CATEGORY_CANCELLED
+//	as cache.ErrorCategory into cache.
+// Returns false if some code processing isn't finished in gracePeriod.
+func (pt *ProcessingTracker) Shutdown(gracePeriod time.Duration) bool {
+	pt.mu.Lock()
+	pt.stopped = true
+	pt.mu.Unlock()
+	pt.cancel()
+
+	finished := make(chan struct{})
+	go func() {
+		pt.wg.Wait()
+		close(finished)
+	}()
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+	select {
+	case <-finished:
+		return true
+	case <-timer.C:
+	}
+
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	for pipelineId, lc := range pt.pipelines {
+		logger.Errorf("%s: code processing isn't finished in %s after shutdown\n", pipelineId, gracePeriod)
+		// the context of the tracker is canceled, so the status is saved with the background context
+		_ = setErrorStatus(context.Background(), pt.cacheService, pipelineId, pb.Status_STATUS_CANCELED, pb.ErrorCategory_ERROR_CATEGORY_CANCELLED)
+		DeleteFolders(pipelineId, lc)
+	}
+	return false
+}
+
+// finish removes the pipeline from the running code processing
+func (pt *ProcessingTracker) finish(pipelineId uuid.UUID) {
+	pt.mu.Lock()
+	delete(pt.pipelines, pipelineId)
+	pt.mu.Unlock()
+	pt.wg.Done()
+}
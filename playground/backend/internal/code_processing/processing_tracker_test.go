@@ -0,0 +1,123 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/goleak"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProcessingTracker_Shutdown(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, environment.NewExecutorConfig("go", "", "", []string{"build", "-o"}, []string{}, []string{}), "")
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		gracePeriod    time.Duration
+		process        func(lc *fs_tool.LifeCycle, pipelineId uuid.UUID, unblock chan struct{}) func(ctx context.Context)
+		waitForStatus  pb.Status
+		want           bool
+		expectedStatus pb.Status
+	}{
+		{
+			// Test case with shutting down the tracker when the code which runs forever is executing.
+			// As a result the running command should be killed, the code processing should be finished in the grace period
+			// and status into cache should be set as Status_STATUS_CANCELED.
+			name:        "code processing is finished in the grace period",
+			gracePeriod: 10 * time.Second,
+			process: func(lc *fs_tool.LifeCycle, pipelineId uuid.UUID, _ chan struct{}) func(ctx context.Context) {
+				return func(ctx context.Context) {
+					Process(ctx, cacheService, NewWorkerPool(appEnvs.MaxConcurrentPipelines()), lc, pipelineId, appEnvs, goSdkEnv, "", "", "", "", nil, nil, nil)
+				}
+			},
+			waitForStatus:  pb.Status_STATUS_EXECUTING,
+			want:           true,
+			expectedStatus: pb.Status_STATUS_CANCELED,
+		},
+		{
+			// Test case with shutting down the tracker when the code processing ignores the cancellation.
+			// As a result the tracker should stop waiting after the grace period, delete folders of the code processing
+			// and set status into cache as Status_STATUS_CANCELED.
+			name:        "code processing isn't finished in the grace period",
+			gracePeriod: 100 * time.Millisecond,
+			process: func(lc *fs_tool.LifeCycle, pipelineId uuid.UUID, unblock chan struct{}) func(ctx context.Context) {
+				return func(ctx context.Context) {
+					_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+					<-unblock
+				}
+			},
+			waitForStatus:  pb.Status_STATUS_EXECUTING,
+			want:           false,
+			expectedStatus: pb.Status_STATUS_CANCELED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, appEnvs.WorkingDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			_, _ = lc.CreateSourceCodeFile("package main\n\nfunc main() {\n\tfor {\n\t}\n}\n")
+
+			tracker := NewProcessingTracker(ctx, cacheService)
+			unblock := make(chan struct{})
+			defer close(unblock)
+			if !tracker.Go(pipelineId, lc, tt.process(lc, pipelineId, unblock)) {
+				t.Fatalf("Go() doesn't start the code processing")
+			}
+
+			deadline := time.Now().Add(30 * time.Second)
+			for {
+				status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+				if status == tt.waitForStatus {
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("code processing set status: %s, but expectes: %s", status, tt.waitForStatus)
+				}
+				time.Sleep(50 * time.Millisecond)
+			}
+
+			if got := tracker.Shutdown(tt.gracePeriod); got != tt.want {
+				t.Errorf("Shutdown() = %v, want %v", got, tt.want)
+			}
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.expectedStatus) {
+				t.Errorf("Shutdown() set status: %s, but expectes: %s", status, tt.expectedStatus)
+			}
+			if _, err := os.Stat(lc.Folder.BaseFolder); !os.IsNotExist(err) {
+				t.Errorf("Shutdown() doesn't delete folder %s of the code processing", lc.Folder.BaseFolder)
+			}
+			if tracker.Go(uuid.New(), lc, func(ctx context.Context) {}) {
+				t.Errorf("Go() starts the code processing after shutdown")
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"regexp"
+	"strings"
+)
+
+var (
+	// javaTestMethodRegexp matches the name of the method annotated with @Test in the source code
+	javaTestMethodRegexp = regexp.MustCompile(`(?s)@Test\b.*?\bvoid\s+(\w+)\s*\(`)
+	// junitFailureRegexp matches the first line of the JUnit failure, i.e. "1) testMethod(TestClass)"
+	junitFailureRegexp = regexp.MustCompile(`^\d+\) (\w+)\((.+)\)$`)
+	// junitStackTraceRegexp matches the line of the stack trace of the JUnit failure
+	junitStackTraceRegexp = regexp.MustCompile(`^\s+(at |\.\.\. \d+ more)`)
+	// junitSummaryRegexp matches the lines which end the list of the JUnit failures, i.e. "FAILURES!!!" or "OK (2 tests)"
+	junitSummaryRegexp = regexp.MustCompile(`^(FAILURES!!!|OK \(\d+ tests?\)|Tests run: .*)$`)
+)
+
+// parseTestResults parses the output of the test runner of the sdk into the list of test results.
+// The source code is used to find the tests which are absent in the output of the test runner.
+// In case the format of the test runner output isn't supported for the sdk, returns an empty list.
+func parseTestResults(sdk pb.Sdk, source, output string) []*pb.TestCase {
+	switch sdk {
+	case pb.Sdk_SDK_JAVA:
+		return parseJUnitResults(source, output)
+	}
+	return []*pb.TestCase{}
+}
+
+// parseJUnitResults parses the output of JUnitCore into the list of test results.
+// JUnitCore reports only failed tests, each failure has the following format:
+//	1) testMethod(TestClass)
+//	failure message
+//		at stack trace line
+// All other methods annotated with @Test in the source code are considered as passed.
+// JUnitCore doesn't report the duration of each test, so the duration of the test results is 0.
+func parseJUnitResults(source, output string) []*pb.TestCase {
+	failures := make([]*pb.TestCase, 0)
+	var current *pb.TestCase
+	for _, line := range strings.Split(output, "\n") {
+		if match := junitFailureRegexp.FindStringSubmatch(line); match != nil {
+			current = &pb.TestCase{Name: match[1], Passed: false}
+			failures = append(failures, current)
+			continue
+		}
+		if current == nil || strings.TrimSpace(line) == "" {
+			continue
+		}
+		if junitStackTraceRegexp.MatchString(line) || junitSummaryRegexp.MatchString(line) {
+			// the failure message is followed by the stack trace
+			current = nil
+			continue
+		}
+		if current.FailureMessage != "" {
+			current.FailureMessage += "\n"
+		}
+		current.FailureMessage += strings.TrimSpace(line)
+	}
+
+	testResults := make([]*pb.TestCase, 0)
+	for _, match := range javaTestMethodRegexp.FindAllStringSubmatch(source, -1) {
+		testCase := &pb.TestCase{Name: match[1], Passed: true}
+		for i, failure := range failures {
+			if failure.Name == testCase.Name {
+				testCase = failure
+				failures = append(failures[:i], failures[i+1:]...)
+				break
+			}
+		}
+		testResults = append(testResults, testCase)
+	}
+	// failures of the methods which aren't found in the source code, i.e. initializationError
+	return append(testResults, failures...)
+}
@@ -0,0 +1,130 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"reflect"
+	"testing"
+)
+
+const junitTestCode = "import static org.junit.Assert.assertEquals;\n\n" +
+	"import org.junit.Test;\n" +
+	"import org.junit.runner.RunWith;\n" +
+	"import org.junit.runners.JUnit4;\n\n" +
+	"@RunWith(JUnit4.class)\n" +
+	"public class MathTest {\n\n" +
+	"  @Test\n" +
+	"  public void testAdd() {\n" +
+	"    assertEquals(4, 2 + 2);\n" +
+	"  }\n\n" +
+	"  @Test(timeout = 1000)\n" +
+	"  public void testSubtract() {\n" +
+	"    assertEquals(1, 2 - 2);\n" +
+	"  }\n" +
+	"}\n"
+
+func Test_parseTestResults(t *testing.T) {
+	type args struct {
+		sdk    pb.Sdk
+		source string
+		output string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*pb.TestCase
+	}{
+		{
+			// Test case with calling parseTestResults method with JUnit output of the code with one passing and one failing test.
+			// As a result, want to receive a list with the passed test and the failed test with the failure message.
+			name: "junit one passed and one failed",
+			args: args{
+				sdk:    pb.Sdk_SDK_JAVA,
+				source: junitTestCode,
+				output: "JUnit version 4.13\n" +
+					"..E\n" +
+					"Time: 0.012\n" +
+					"There was 1 failure:\n" +
+					"1) testSubtract(MathTest)\n" +
+					"java.lang.AssertionError: expected:<1> but was:<0>\n" +
+					"\tat org.junit.Assert.fail(Assert.java:89)\n" +
+					"\tat MathTest.testSubtract(MathTest.java:17)\n" +
+					"\n" +
+					"FAILURES!!!\n" +
+					"Tests run: 2,  Failures: 1\n\n",
+			},
+			want: []*pb.TestCase{
+				{Name: "testAdd", Passed: true},
+				{Name: "testSubtract", Passed: false, FailureMessage: "java.lang.AssertionError: expected:<1> but was:<0>"},
+			},
+		},
+		{
+			// Test case with calling parseTestResults method with JUnit output of the code with all passing tests.
+			// As a result, want to receive a list with all tests passed.
+			name: "junit all passed",
+			args: args{
+				sdk:    pb.Sdk_SDK_JAVA,
+				source: junitTestCode,
+				output: "JUnit version 4.13\n..\nTime: 0.005\n\nOK (2 tests)\n\n",
+			},
+			want: []*pb.TestCase{
+				{Name: "testAdd", Passed: true},
+				{Name: "testSubtract", Passed: true},
+			},
+		},
+		{
+			// Test case with calling parseTestResults method with JUnit output which contains the failure of the method absent in the source code.
+			// As a result, want to receive a list with the failure after the tests from the source code.
+			name: "junit initialization error",
+			args: args{
+				sdk:    pb.Sdk_SDK_JAVA,
+				source: "public class EmptyTest {}",
+				output: "JUnit version 4.13\n" +
+					".E\n" +
+					"Time: 0.003\n" +
+					"There was 1 failure:\n" +
+					"1) initializationError(EmptyTest)\n" +
+					"java.lang.Exception: No runnable methods\n" +
+					"\tat org.junit.runners.BlockJUnit4ClassRunner.validateInstanceMethods(BlockJUnit4ClassRunner.java:191)\n" +
+					"\n" +
+					"FAILURES!!!\n" +
+					"Tests run: 1,  Failures: 1\n\n",
+			},
+			want: []*pb.TestCase{
+				{Name: "initializationError", Passed: false, FailureMessage: "java.lang.Exception: No runnable methods"},
+			},
+		},
+		{
+			// Test case with calling parseTestResults method with sdk which test runner output isn't supported.
+			// As a result, want to receive an empty list.
+			name: "unsupported sdk",
+			args: args{
+				sdk:    pb.Sdk_SDK_GO,
+				source: "MOCK_SOURCE",
+				output: "--- FAIL: TestMock (0.00s)\n",
+			},
+			want: []*pb.TestCase{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseTestResults(tt.args.sdk, tt.args.source, tt.args.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTestResults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
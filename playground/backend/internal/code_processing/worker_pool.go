@@ -0,0 +1,52 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+// WorkerPool bounds the number of pipelines which are compiled and run at the same time
+type WorkerPool struct {
+	slots chan struct{}
+}
+
+// NewWorkerPool constructor for WorkerPool.
+// size is a number of pipelines which could be compiled and run at the same time. 0 means that the number is not limited.
+func NewWorkerPool(size int) *WorkerPool {
+	if size <= 0 {
+		return &WorkerPool{}
+	}
+	return &WorkerPool{slots: make(chan struct{}, size)}
+}
+
+// tryAcquire takes a free slot of the pool without waiting.
+// Returns false if there is no free slot.
+func (wp *WorkerPool) tryAcquire() bool {
+	if wp == nil || wp.slots == nil {
+		return true
+	}
+	select {
+	case wp.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release frees the slot taken by tryAcquire or by sending to the slots channel.
+func (wp *WorkerPool) release() {
+	if wp == nil || wp.slots == nil {
+		return
+	}
+	<-wp.slots
+}
@@ -17,6 +17,7 @@ package environment
 
 import (
 	"fmt"
+	"net"
 	"time"
 )
 
@@ -44,14 +45,31 @@ func (serverEnvs *NetworkEnvs) Protocol() string {
 
 //CacheEnvs contains all environment variables that needed to use cache
 type CacheEnvs struct {
-	// cacheType is type of cache (local/redis)
+	// cacheType is type of cache (local/remote/file)
 	cacheType string
 
 	// this is a string with hostname:port of the cache server for redis caches
+	// or the directory to keep the files of the pipelines for file caches
 	address string
 
 	// keyExpirationTime is expiration time for cache keys
 	keyExpirationTime time.Duration
+
+	// maxPipelines is a max number of pipelines kept in the local cache.
+	// 0 means that the number of pipelines is not limited.
+	maxPipelines int
+
+	// idleTimeout is a duration after which the finished pipeline is removed from the cache if its results aren't read.
+	// 0 means that idle pipelines are not removed.
+	idleTimeout time.Duration
+
+	// maxOutputBytes is a max total size (in bytes) of the outputs of all pipelines kept in the local cache.
+	// 0 means that the total size is not limited.
+	maxOutputBytes int
+
+	// encryptionKey is an AES key which is used to encrypt the values kept in the cache.
+	// Empty key means that the values are kept unencrypted.
+	encryptionKey []byte
 }
 
 // CacheType returns cache type
@@ -59,7 +77,7 @@ func (ce *CacheEnvs) CacheType() string {
 	return ce.cacheType
 }
 
-// Address returns address to connect to remote cache service
+// Address returns address to connect to remote cache service or the directory of file cache
 func (ce *CacheEnvs) Address() string {
 	return ce.address
 }
@@ -69,12 +87,36 @@ func (ce *CacheEnvs) KeyExpirationTime() time.Duration {
 	return ce.keyExpirationTime
 }
 
+// MaxPipelines returns max number of pipelines kept in the local cache
+func (ce *CacheEnvs) MaxPipelines() int {
+	return ce.maxPipelines
+}
+
+// IdleTimeout returns duration after which the finished pipeline is removed from the cache if its results aren't read
+func (ce *CacheEnvs) IdleTimeout() time.Duration {
+	return ce.idleTimeout
+}
+
+// MaxOutputBytes returns max total size of the outputs of all pipelines kept in the local cache
+func (ce *CacheEnvs) MaxOutputBytes() int {
+	return ce.maxOutputBytes
+}
+
+// EncryptionKey returns AES key to encrypt the values kept in the cache
+func (ce *CacheEnvs) EncryptionKey() []byte {
+	return ce.encryptionKey
+}
+
 // NewCacheEnvs constructor for CacheEnvs
-func NewCacheEnvs(cacheType, cacheAddress string, cacheExpirationTime time.Duration) *CacheEnvs {
+func NewCacheEnvs(cacheType, cacheAddress string, cacheExpirationTime time.Duration, maxPipelines int, idleTimeout time.Duration, maxOutputBytes int, encryptionKey []byte) *CacheEnvs {
 	return &CacheEnvs{
 		cacheType:         cacheType,
 		address:           cacheAddress,
 		keyExpirationTime: cacheExpirationTime,
+		maxPipelines:      maxPipelines,
+		idleTimeout:       idleTimeout,
+		maxOutputBytes:    maxOutputBytes,
+		encryptionKey:     encryptionKey,
 	}
 }
 
@@ -88,16 +130,151 @@ type ApplicationEnvs struct {
 	// cacheEnvs contains environment variables for cache
 	cacheEnvs *CacheEnvs
 
-	// pipelineExecuteTimeout is timeout for code processing
-	pipelineExecuteTimeout time.Duration
+	// options contains the rest of environment variables of the application
+	options ApplicationOptions
+}
+
+// ApplicationOptions contains environment variables of the application which configure the code processing
+type ApplicationOptions struct {
+	// PipelineExecuteTimeout is timeout for code processing
+	PipelineExecuteTimeout time.Duration
+
+	// PipelineMemoryLimit is a limit of the memory (in megabytes) which could be used by the executed code.
+	// 0 means that the memory is not limited.
+	PipelineMemoryLimit int
+
+	// SourceUrlAllowedHosts is a list of hosts from which the code could be downloaded if a link to the code is received
+	//	instead of the code itself
+	SourceUrlAllowedHosts []string
+
+	// MaxConcurrentPipelines is a number of pipelines which could be compiled and run at the same time.
+	// 0 means that the number of pipelines is not limited.
+	MaxConcurrentPipelines int
+
+	// MaxOutputSize is a max size (in bytes) of the run output which is kept in the cache.
+	// 0 means that the size of the output is not limited.
+	MaxOutputSize int
+
+	// MaxCompileOutputSize is a max size (in bytes) of the compile output which is kept in the cache.
+	// It is separate from MaxOutputSize because the compiler could report a huge number of errors for the generated code.
+	// 0 means that the size of the output is not limited.
+	MaxCompileOutputSize int
+
+	// ExamplesDir is a directory with examples which are listed by the server.
+	// Empty string means that there are no examples.
+	ExamplesDir string
+
+	// ExamplesRefreshInterval is an interval after which the scanned list of examples is invalidated
+	ExamplesRefreshInterval time.Duration
+
+	// NetworkIsolation is true if the executed code should be run in a new network namespace without access to the network.
+	// It requires CAP_SYS_ADMIN (i.e. root), so it could be disabled for the local development.
+	NetworkIsolation bool
+
+	// SandboxCmd is a command (with its arguments) which wraps the executed code to isolate it (i.e. "firejail --net=none").
+	// If it is set, it is used instead of the network namespace.
+	SandboxCmd []string
+
+	// KeepPipelineFiles is true if the files of the code processing should be kept after the code processing is finished.
+	// It is used for debugging only since the files aren't removed at all.
+	KeepPipelineFiles bool
+
+	// PipelineCpuTimeLimit is a limit of the CPU time (in seconds) which could be used by the executed code.
+	// Unlike the timeout, it stops the code which is busy with computations regardless of the wall-clock time.
+	// 0 means that the CPU time is not limited.
+	PipelineCpuTimeLimit int
+
+	// ArchiveLocation is a location where results of the finished code processing are kept after they are removed from the cache:
+	//	"gs://bucket/prefix" for the Google Cloud Storage bucket or a path to the local directory.
+	// Empty string means that results aren't archived.
+	ArchiveLocation string
+
+	// PipelineCancelGracePeriod is a time which is given to the executed code to finish after SIGTERM
+	// when the code processing is canceled. The code which is still alive after it is killed by SIGKILL.
+	PipelineCancelGracePeriod time.Duration
+
+	// MaxSourceSize is a max total size (in bytes) of the source files of the code.
+	// The code with larger source files isn't validated, compiled and run. 0 means that the size is not limited.
+	MaxSourceSize int
+
+	// PipelineStartRetries is a number of retries of the compile or run step which is failed because of a transient failure,
+	//	i.e. the process of the step couldn't be started or the JVM couldn't be initialized.
+	// Failures of the code itself are never retried. 0 means that steps aren't retried.
+	PipelineStartRetries int
+
+	// PipelineRetryBackoff is a time to wait before the first retry of the step, it is doubled before each next retry
+	PipelineRetryBackoff time.Duration
+
+	// RateLimitPerMinute is a number of the code processing requests which could be received from the same client per minute.
+	// Requests over the limit are rejected before the code is saved. 0 means that requests are not limited.
+	RateLimitPerMinute int
+
+	// RateLimitBurst is a max number of the code processing requests which could be received from the same client at once.
+	// 0 means that it is equal to RateLimitPerMinute.
+	RateLimitBurst int
+
+	// DebugMode is true if the debugging information of the code processing (i.e. the command line of the run step)
+	//	could be received by the clients. It is used for debugging only.
+	DebugMode bool
+
+	// JvmWarmPoolSize is a number of the pre-started JVMs which the Java code is dispatched to.
+	// 0 means that the Java code is always run by a new JVM.
+	JvmWarmPoolSize int
+
+	// PipelineDiskQuota is a limit of the disk space (in megabytes) which could be used by the files in the folder of the pipeline
+	//	during the run of the code. 0 means that the disk space is not limited.
+	PipelineDiskQuota int
+
+	// CompileCacheSize is a number of the compiled artifacts which are kept to be reused for the same code.
+	// 0 means that the code is always compiled.
+	CompileCacheSize int
+
+	// MaxPipelinesPerClient is a number of the code processing which could be in progress for the same client at the same time.
+	// 0 means that the number is not limited for the client (only by MaxConcurrentPipelines for all clients).
+	MaxPipelinesPerClient int
+
+	// ExecutionRoot is a directory where the files of the code processing are created, i.e. a tmpfs mount to avoid the disk IO
+	//	during the compilation and the run of the code. The configs are kept in workingDir anyway.
+	// Empty string means that the files are created in workingDir.
+	ExecutionRoot string
+
+	// SpillRunOutput is true if the full run output which exceeds MaxOutputSize is written to a file in the folder
+	//	of the pipeline instead of being truncated. Only the first MaxOutputSize bytes are kept in the cache anyway.
+	SpillRunOutput bool
+
+	// RunOutputFlushLines is a number of lines of the run output which are buffered before they are written to the cache.
+	// RunOutputFlushInterval is a max time the buffered run output waits before it is written to the cache.
+	// 0 for both means that the run output is written to the cache line by line.
+	RunOutputFlushLines    int
+	RunOutputFlushInterval time.Duration
+
+	// OutputPathOptions are names of the pipeline options (without leading dashes, i.e. "output") which values are paths
+	//	of the files written by the code. Such paths are constrained to the folder of the pipeline.
+	// Empty list means that the paths of the output aren't checked.
+	OutputPathOptions []string
+
+	// AllowedOutputPaths is a list of absolute folders outside the folder of the pipeline where the code could write the output too
+	AllowedOutputPaths []string
+
+	// WebSocketOriginPatterns is a list of host patterns (i.e. "*.example.com") of the origins of the browser clients
+	//	which could connect to the WebSocket endpoint. The clients from the same host as the server are always allowed.
+	WebSocketOriginPatterns []string
+
+	// TrustedProxies are the networks of the proxies (i.e. load balancers) which the X-Forwarded-For header is accepted from
+	//	to identify the client. The header is ignored if the request is received from other peers.
+	TrustedProxies []*net.IPNet
+
+	// AdminToken is a token of the operators which should be sent as "Authorization: Bearer <token>" to access
+	//	the admin endpoints (i.e. the list of the pipelines in progress). Empty string means that the admin endpoints are disabled.
+	AdminToken string
 }
 
 // NewApplicationEnvs constructor for ApplicationEnvs
-func NewApplicationEnvs(workingDir string, cacheEnvs *CacheEnvs, pipelineExecuteTimeout time.Duration) *ApplicationEnvs {
+func NewApplicationEnvs(workingDir string, cacheEnvs *CacheEnvs, options ApplicationOptions) *ApplicationEnvs {
 	return &ApplicationEnvs{
-		workingDir:             workingDir,
-		cacheEnvs:              cacheEnvs,
-		pipelineExecuteTimeout: pipelineExecuteTimeout,
+		workingDir: workingDir,
+		cacheEnvs:  cacheEnvs,
+		options:    options,
 	}
 }
 
@@ -111,7 +288,175 @@ func (ae *ApplicationEnvs) CacheEnvs() *CacheEnvs {
 	return ae.cacheEnvs
 }
 
+// Options returns a copy of the options of the application, i.e. to construct the environment with some options changed
+func (ae *ApplicationEnvs) Options() ApplicationOptions {
+	return ae.options
+}
+
 // PipelineExecuteTimeout returns timeout for code processing
 func (ae *ApplicationEnvs) PipelineExecuteTimeout() time.Duration {
-	return ae.pipelineExecuteTimeout
+	return ae.options.PipelineExecuteTimeout
+}
+
+// PipelineMemoryLimit returns limit of the memory (in megabytes) for the executed code
+func (ae *ApplicationEnvs) PipelineMemoryLimit() int {
+	return ae.options.PipelineMemoryLimit
+}
+
+// PipelineCpuTimeLimit returns limit of the CPU time (in seconds) for the executed code
+func (ae *ApplicationEnvs) PipelineCpuTimeLimit() int {
+	return ae.options.PipelineCpuTimeLimit
+}
+
+// ArchiveLocation returns location where results of the finished code processing are archived
+func (ae *ApplicationEnvs) ArchiveLocation() string {
+	return ae.options.ArchiveLocation
+}
+
+// PipelineCancelGracePeriod returns time which is given to the executed code to finish after SIGTERM when the code processing is canceled
+func (ae *ApplicationEnvs) PipelineCancelGracePeriod() time.Duration {
+	return ae.options.PipelineCancelGracePeriod
+}
+
+// MaxSourceSize returns max total size (in bytes) of the source files of the code
+func (ae *ApplicationEnvs) MaxSourceSize() int {
+	return ae.options.MaxSourceSize
+}
+
+// PipelineStartRetries returns number of retries of the compile or run step which is failed because of a transient failure
+func (ae *ApplicationEnvs) PipelineStartRetries() int {
+	return ae.options.PipelineStartRetries
+}
+
+// PipelineRetryBackoff returns time to wait before the first retry of the step which is failed because of a transient failure
+func (ae *ApplicationEnvs) PipelineRetryBackoff() time.Duration {
+	return ae.options.PipelineRetryBackoff
+}
+
+// RateLimitPerMinute returns number of the code processing requests which could be received from the same client per minute
+func (ae *ApplicationEnvs) RateLimitPerMinute() int {
+	return ae.options.RateLimitPerMinute
+}
+
+// RateLimitBurst returns max number of the code processing requests which could be received from the same client at once
+func (ae *ApplicationEnvs) RateLimitBurst() int {
+	return ae.options.RateLimitBurst
+}
+
+// SourceUrlAllowedHosts returns list of hosts from which the code could be downloaded
+func (ae *ApplicationEnvs) SourceUrlAllowedHosts() []string {
+	return ae.options.SourceUrlAllowedHosts
+}
+
+// MaxConcurrentPipelines returns number of pipelines which could be compiled and run at the same time
+func (ae *ApplicationEnvs) MaxConcurrentPipelines() int {
+	return ae.options.MaxConcurrentPipelines
+}
+
+// MaxOutputSize returns max size (in bytes) of the run output which is kept in the cache
+func (ae *ApplicationEnvs) MaxOutputSize() int {
+	return ae.options.MaxOutputSize
+}
+
+// MaxCompileOutputSize returns max size (in bytes) of the compile output which is kept in the cache
+func (ae *ApplicationEnvs) MaxCompileOutputSize() int {
+	return ae.options.MaxCompileOutputSize
+}
+
+// ExamplesDir returns directory with examples
+func (ae *ApplicationEnvs) ExamplesDir() string {
+	return ae.options.ExamplesDir
+}
+
+// ExamplesRefreshInterval returns interval after which the scanned list of examples is invalidated
+func (ae *ApplicationEnvs) ExamplesRefreshInterval() time.Duration {
+	return ae.options.ExamplesRefreshInterval
+}
+
+// NetworkIsolation returns true if the executed code should be run without access to the network
+func (ae *ApplicationEnvs) NetworkIsolation() bool {
+	return ae.options.NetworkIsolation
+}
+
+// SandboxCmd returns command which wraps the executed code to isolate it
+func (ae *ApplicationEnvs) SandboxCmd() []string {
+	return ae.options.SandboxCmd
+}
+
+// KeepPipelineFiles returns true if the files of the code processing should be kept after the code processing is finished
+func (ae *ApplicationEnvs) KeepPipelineFiles() bool {
+	return ae.options.KeepPipelineFiles
+}
+
+// DebugMode returns true if the debugging information of the code processing could be received by the clients
+func (ae *ApplicationEnvs) DebugMode() bool {
+	return ae.options.DebugMode
+}
+
+// JvmWarmPoolSize returns number of the pre-started JVMs which the Java code is dispatched to
+func (ae *ApplicationEnvs) JvmWarmPoolSize() int {
+	return ae.options.JvmWarmPoolSize
+}
+
+// PipelineDiskQuota returns limit of the disk space (in megabytes) for the files of the pipeline
+func (ae *ApplicationEnvs) PipelineDiskQuota() int {
+	return ae.options.PipelineDiskQuota
+}
+
+// CompileCacheSize returns number of the compiled artifacts which are kept to be reused for the same code
+func (ae *ApplicationEnvs) CompileCacheSize() int {
+	return ae.options.CompileCacheSize
+}
+
+// MaxPipelinesPerClient returns number of the code processing which could be in progress for the same client at the same time
+func (ae *ApplicationEnvs) MaxPipelinesPerClient() int {
+	return ae.options.MaxPipelinesPerClient
+}
+
+// ExecutionRoot returns directory where the files of the code processing are created (working dir if it isn't set)
+func (ae *ApplicationEnvs) ExecutionRoot() string {
+	if ae.options.ExecutionRoot == "" {
+		return ae.workingDir
+	}
+	return ae.options.ExecutionRoot
+}
+
+// SpillRunOutput returns true if the full run output which exceeds the max output size is written to a file in the folder of the pipeline
+func (ae *ApplicationEnvs) SpillRunOutput() bool {
+	return ae.options.SpillRunOutput
+}
+
+// RunOutputFlushLines returns number of lines of the run output which are buffered before they are written to the cache
+func (ae *ApplicationEnvs) RunOutputFlushLines() int {
+	return ae.options.RunOutputFlushLines
+}
+
+// RunOutputFlushInterval returns max time the buffered run output waits before it is written to the cache
+func (ae *ApplicationEnvs) RunOutputFlushInterval() time.Duration {
+	return ae.options.RunOutputFlushInterval
+}
+
+// OutputPathOptions returns names of the pipeline options which values are paths of the output of the code
+func (ae *ApplicationEnvs) OutputPathOptions() []string {
+	return ae.options.OutputPathOptions
+}
+
+// AllowedOutputPaths returns list of folders outside the folder of the pipeline where the code could write the output
+func (ae *ApplicationEnvs) AllowedOutputPaths() []string {
+	return ae.options.AllowedOutputPaths
+}
+
+// WebSocketOriginPatterns returns host patterns of the origins which could connect to the WebSocket endpoint
+func (ae *ApplicationEnvs) WebSocketOriginPatterns() []string {
+	return ae.options.WebSocketOriginPatterns
+}
+
+// TrustedProxies returns networks of the proxies which the X-Forwarded-For header is accepted from
+func (ae *ApplicationEnvs) TrustedProxies() []*net.IPNet {
+	return ae.options.TrustedProxies
+}
+
+// AdminToken returns token of the operators to access the admin endpoints (empty if the admin endpoints are disabled)
+func (ae *ApplicationEnvs) AdminToken() string {
+	return ae.options.AdminToken
 }
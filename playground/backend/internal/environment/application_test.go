@@ -156,6 +156,90 @@ func TestCacheEnvs_KeyExpirationTime(t *testing.T) {
 	}
 }
 
+func TestCacheEnvs_MaxPipelines(t *testing.T) {
+	tests := []struct {
+		name string
+		ce   *CacheEnvs
+		want int
+	}{
+		{
+			name: "all success",
+			ce:   NewCacheEnvs("MOCK_CACHE_TYPE", "MOCK_ADDRESS", 0, 100, 0, 0, nil),
+			want: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ce.MaxPipelines(); got != tt.want {
+				t.Errorf("MaxPipelines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheEnvs_IdleTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		ce   *CacheEnvs
+		want time.Duration
+	}{
+		{
+			name: "all success",
+			ce:   NewCacheEnvs("MOCK_CACHE_TYPE", "MOCK_ADDRESS", 0, 0, time.Hour, 0, nil),
+			want: time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ce.IdleTimeout(); got != tt.want {
+				t.Errorf("IdleTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheEnvs_MaxOutputBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		ce   *CacheEnvs
+		want int
+	}{
+		{
+			name: "all success",
+			ce:   NewCacheEnvs("MOCK_CACHE_TYPE", "MOCK_ADDRESS", 0, 0, 0, 1024, nil),
+			want: 1024,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ce.MaxOutputBytes(); got != tt.want {
+				t.Errorf("MaxOutputBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheEnvs_EncryptionKey(t *testing.T) {
+	tests := []struct {
+		name string
+		ce   *CacheEnvs
+		want []byte
+	}{
+		{
+			name: "all success",
+			ce:   NewCacheEnvs("MOCK_CACHE_TYPE", "MOCK_ADDRESS", 0, 0, 0, 0, []byte("MOCK_KEY")),
+			want: []byte("MOCK_KEY"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ce.EncryptionKey(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EncryptionKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestApplicationEnvs_WorkingDir(t *testing.T) {
 	type fields struct {
 		workingDir             string
@@ -180,9 +264,9 @@ func TestApplicationEnvs_WorkingDir(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ae := &ApplicationEnvs{
-				workingDir:             tt.fields.workingDir,
-				cacheEnvs:              tt.fields.cacheEnvs,
-				pipelineExecuteTimeout: tt.fields.pipelineExecuteTimeout,
+				workingDir: tt.fields.workingDir,
+				cacheEnvs:  tt.fields.cacheEnvs,
+				options:    ApplicationOptions{PipelineExecuteTimeout: tt.fields.pipelineExecuteTimeout},
 			}
 			if got := ae.WorkingDir(); got != tt.want {
 				t.Errorf("WorkingDir() = %v, want %v", got, tt.want)
@@ -215,9 +299,9 @@ func TestApplicationEnvs_CacheEnvs(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ae := &ApplicationEnvs{
-				workingDir:             tt.fields.workingDir,
-				cacheEnvs:              tt.fields.cacheEnvs,
-				pipelineExecuteTimeout: tt.fields.pipelineExecuteTimeout,
+				workingDir: tt.fields.workingDir,
+				cacheEnvs:  tt.fields.cacheEnvs,
+				options:    ApplicationOptions{PipelineExecuteTimeout: tt.fields.pipelineExecuteTimeout},
 			}
 			if got := ae.CacheEnvs(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CacheEnvs() = %v, want %v", got, tt.want)
@@ -250,9 +334,9 @@ func TestApplicationEnvs_PipelineExecuteTimeout(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ae := &ApplicationEnvs{
-				workingDir:             tt.fields.workingDir,
-				cacheEnvs:              tt.fields.cacheEnvs,
-				pipelineExecuteTimeout: tt.fields.pipelineExecuteTimeout,
+				workingDir: tt.fields.workingDir,
+				cacheEnvs:  tt.fields.cacheEnvs,
+				options:    ApplicationOptions{PipelineExecuteTimeout: tt.fields.pipelineExecuteTimeout},
 			}
 			if got := ae.PipelineExecuteTimeout(); got != tt.want {
 				t.Errorf("PipelineExecuteTimeout() = %v, want %v", got, tt.want)
@@ -17,6 +17,11 @@ package environment
 
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // ExecutorConfig contains all environment variables needed for compiling and execution of the code commands:
@@ -26,6 +31,30 @@ import (
 // - CompileArgs: arguments which are needed to compile files with code
 // - RunArgs: arguments which are needed to run compiled code
 // - TestArgs: arguments which are needed to run unit test code
+// - GraphArgs: pipeline options which make the code save graph of the pipeline in DOT format to "{graphFile}" while it is run.
+//	They are added to the pipeline options of the run, so the graph is derived from the run of the code instead of a separate one (optional)
+// - RunTimeout: timeout of the run step in time.Duration format, i.e. "30s" (optional)
+// - CompileTimeout: timeout of the compile step in time.Duration format, i.e. "30s" (optional)
+// - ForbiddenImports: imports which are not allowed to be used in the code, i.e. "java.lang.Runtime" (optional)
+// - AllowedRunners: runners which could be set by the pipeline options of the user, i.e. "DirectRunner",
+//	only the direct runner is allowed if it isn't set (optional)
+// - ForbidUnboundedOutput: whether the code with trivially-infinite loops which print the output, i.e. while(true) with println inside,
+//	is rejected by the validation step (optional)
+// - WarnMissingPipeline: whether the user is warned if the code doesn't construct a Beam pipeline, i.e. a plain Hello World,
+//	the code is processed as usual in this case (optional)
+// - Env: environment variables which are set for the executed code, i.e. {"PYTHONHASHSEED": "0"} (optional)
+// - PipelineOptions: default pipeline options which are overridden by pipeline options of the user, i.e. "--output=/tmp/out" (optional)
+// - NormalizeOutput: whether ANSI escape sequences are stripped from the run output and line endings are normalized to "\n" (optional)
+// - CombinedLogs: whether stdout and stderr output of the run step are also kept merged into a single log in the order they were printed (optional)
+// - BeamPath: path to the Apache Beam jars of the JVM-based SDKs, BEAM_PATH is used if it isn't set (optional)
+// - CollectMetrics: whether the pipeline is run by the runner which saves the metrics of the pipeline after the run,
+//	unless the runner is set by the pipeline options of the user. It is supported by Java and Python SDKs (optional)
+// - DockerImage: image with the toolchain of the SDK, i.e. "python:3.8-slim". If it is set, the code is run
+//	in a throwaway container of this image instead of a subprocess of the server (optional)
+// - DockerNetwork: Docker network which the container of DockerImage is connected to, i.e. "bridge",
+//	the container has no network if it isn't set or the code is isolated from the network by PIPELINE_NETWORK_ISOLATION (optional)
+// For the JVM-based SDKs "{compiledDir}" in CompileArgs, RunArgs and TestArgs is replaced with the folder of the pipeline
+//	with compiled files, so pipelines which are processed at the same time don't share the compiled classes.
 type ExecutorConfig struct {
 	CompileCmd  string   `json:"compile_cmd"`
 	RunCmd      string   `json:"run_cmd"`
@@ -33,6 +62,35 @@ type ExecutorConfig struct {
 	CompileArgs []string `json:"compile_args"`
 	RunArgs     []string `json:"run_args"`
 	TestArgs    []string `json:"test_args"`
+	GraphArgs   []string `json:"graph_args"`
+	RunTimeout  string   `json:"run_timeout"`
+
+	CompileTimeout   string            `json:"compile_timeout"`
+	ForbiddenImports []string          `json:"forbidden_imports"`
+	AllowedRunners   []string          `json:"allowed_runners"`
+	Env              map[string]string `json:"env"`
+	PipelineOptions  string            `json:"pipeline_options"`
+	NormalizeOutput  bool              `json:"normalize_output"`
+	CombinedLogs     bool              `json:"combined_logs"`
+	BeamPath         string            `json:"beam_path"`
+	CollectMetrics   bool              `json:"collect_metrics"`
+	DockerImage      string            `json:"docker_image"`
+	DockerNetwork    string            `json:"docker_network"`
+
+	// ForbidUnboundedOutput is checked by a static heuristic, so it is disabled by default not to reject legitimate code.
+	ForbidUnboundedOutput bool `json:"forbid_unbounded_output"`
+
+	// WarnMissingPipeline is checked by a static heuristic, so the code without the pipeline isn't rejected, only warned about.
+	WarnMissingPipeline bool `json:"warn_missing_pipeline"`
+
+	// Dependencies are the allowlisted dependency jars which could be added to the classpath of the JVM-based SDKs.
+	// Keys are coordinates of the dependencies (i.e. "org.apache.beam:beam-sdks-java-io-kafka:2.35.0"),
+	// values are paths to the pre-provisioned jars of the dependencies.
+	Dependencies map[string]string `json:"dependencies"`
+
+	// ClasspathDependencies are the dependencies from Dependencies which are added to the classpath (see CLASSPATH_DEPENDENCIES).
+	// Keys are coordinates of the dependencies, values are their paths.
+	ClasspathDependencies map[string]string `json:"-"`
 }
 
 // NewExecutorConfig creates and returns ExecutorConfig
@@ -40,11 +98,23 @@ func NewExecutorConfig(compileCmd, runCmd, testCmd string, compileArgs, runArgs,
 	return &ExecutorConfig{CompileCmd: compileCmd, RunCmd: runCmd, TestCmd: testCmd, CompileArgs: compileArgs, RunArgs: runArgs, TestArgs: testArgs}
 }
 
+// beamPath returns the path to the Apache Beam jars from the config or from the environment variables if it isn't set in the config
+func (e *ExecutorConfig) beamPath() string {
+	if e.BeamPath != "" {
+		return e.BeamPath
+	}
+	return getEnv(beamPathKey, defaultBeamJarsPath)
+}
+
 // BeamEnvs contains all environments related of ApacheBeam. These will use to run pipelines
 type BeamEnvs struct {
 	ApacheBeamSdk  pb.Sdk
 	ExecutorConfig *ExecutorConfig
+	// Version is the version of the SDK which ExecutorConfig corresponds to, empty if the SDK isn't versioned
+	Version        string
 	preparedModDir string
+	// versions are the executor configs of all configured versions of the SDK by their versions
+	versions map[string]*ExecutorConfig
 }
 
 // NewBeamEnvs is a BeamEnvs constructor
@@ -52,7 +122,98 @@ func NewBeamEnvs(apacheBeamSdk pb.Sdk, executorConfig *ExecutorConfig, preparedM
 	return &BeamEnvs{ApacheBeamSdk: apacheBeamSdk, ExecutorConfig: executorConfig, preparedModDir: preparedModDir}
 }
 
+// NewVersionedBeamEnvs is a BeamEnvs constructor for the SDK with several configured versions.
+// versions are the executor configs by the versions of the SDK, the latest version is used by default.
+func NewVersionedBeamEnvs(apacheBeamSdk pb.Sdk, versions map[string]*ExecutorConfig, preparedModDir string) *BeamEnvs {
+	beamEnvs := &BeamEnvs{ApacheBeamSdk: apacheBeamSdk, preparedModDir: preparedModDir, versions: versions}
+	if available := beamEnvs.Versions(); len(available) > 0 {
+		beamEnvs.Version = available[len(available)-1]
+		beamEnvs.ExecutorConfig = versions[beamEnvs.Version]
+	}
+	return beamEnvs
+}
+
+// Versions returns all configured versions of the SDK from the oldest to the latest one
+func (b *BeamEnvs) Versions() []string {
+	versions := make([]string, 0, len(b.versions))
+	for version := range b.versions {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) < 0
+	})
+	return versions
+}
+
+// ForVersion returns BeamEnvs which use the executor config of the version of the SDK.
+// If version is empty, returns the BeamEnvs as is, so the default (latest) version is used.
+// If version isn't configured for the SDK - returns error.
+func (b *BeamEnvs) ForVersion(version string) (*BeamEnvs, error) {
+	if version == "" || version == b.Version {
+		return b, nil
+	}
+	executorConfig, ok := b.versions[version]
+	if !ok {
+		return nil, fmt.Errorf("version %s of %s isn't supported, supported versions: [%s]", version, b.ApacheBeamSdk, strings.Join(b.Versions(), ", "))
+	}
+	versionEnvs := *b
+	versionEnvs.ExecutorConfig = executorConfig
+	versionEnvs.Version = version
+	return &versionEnvs, nil
+}
+
+// compareVersions compares versions separated by dots part by part, i.e. "2.9" is older than "2.10".
+// Numeric parts are compared as numbers, other parts are compared as strings.
+// Returns a negative number if a is older than b, a positive number if a is newer than b and 0 if they are equal.
+func compareVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNumber, aErr := strconv.Atoi(aParts[i])
+		bNumber, bErr := strconv.Atoi(bParts[i])
+		if aErr == nil && bErr == nil {
+			if aNumber != bNumber {
+				return aNumber - bNumber
+			}
+			continue
+		}
+		if cmp := strings.Compare(aParts[i], bParts[i]); cmp != 0 {
+			return cmp
+		}
+	}
+	return len(aParts) - len(bParts)
+}
+
 // PreparedModDir returns the path to the directory where prepared go.mod and go.sum are located
 func (b *BeamEnvs) PreparedModDir() string {
 	return b.preparedModDir
 }
+
+// RunTimeout returns timeout of the run step for the SDK.
+// If the timeout isn't set in the config of the SDK or it is incorrect, returns defaultTimeout.
+func (b *BeamEnvs) RunTimeout(defaultTimeout time.Duration) time.Duration {
+	if b.ExecutorConfig == nil {
+		return defaultTimeout
+	}
+	return parseTimeout(b.ExecutorConfig.RunTimeout, defaultTimeout)
+}
+
+// CompileTimeout returns timeout of the compile step for the SDK.
+// If the timeout isn't set in the config of the SDK or it is incorrect, returns defaultTimeout.
+func (b *BeamEnvs) CompileTimeout(defaultTimeout time.Duration) time.Duration {
+	if b.ExecutorConfig == nil {
+		return defaultTimeout
+	}
+	return parseTimeout(b.ExecutorConfig.CompileTimeout, defaultTimeout)
+}
+
+// parseTimeout parses timeout in time.Duration format or returns defaultTimeout if it's empty or incorrect
+func parseTimeout(timeout string, defaultTimeout time.Duration) time.Duration {
+	if timeout == "" {
+		return defaultTimeout
+	}
+	parsed, err := time.ParseDuration(timeout)
+	if err != nil {
+		return defaultTimeout
+	}
+	return parsed
+}
@@ -17,7 +17,9 @@ package environment
 
 import (
 	playground "beam.apache.org/playground/backend/internal/api/v1"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestBeamEnvs_PreparedModDir(t *testing.T) {
@@ -55,3 +57,204 @@ func TestBeamEnvs_PreparedModDir(t *testing.T) {
 		})
 	}
 }
+
+func TestBeamEnvs_RunTimeout(t *testing.T) {
+	defaultTimeout := time.Minute
+	type fields struct {
+		ExecutorConfig *ExecutorConfig
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   time.Duration
+	}{
+		{
+			// Test case with calling RunTimeout method when run timeout is set in the config.
+			// As a result, want to receive the run timeout from the config.
+			name:   "run timeout is configured",
+			fields: fields{ExecutorConfig: &ExecutorConfig{RunTimeout: "30s"}},
+			want:   30 * time.Second,
+		},
+		{
+			// Test case with calling RunTimeout method when run timeout isn't set in the config.
+			// As a result, want to receive the default timeout.
+			name:   "run timeout isn't configured",
+			fields: fields{ExecutorConfig: &ExecutorConfig{}},
+			want:   defaultTimeout,
+		},
+		{
+			// Test case with calling RunTimeout method when run timeout in the config is incorrect.
+			// As a result, want to receive the default timeout.
+			name:   "incorrect run timeout",
+			fields: fields{ExecutorConfig: &ExecutorConfig{RunTimeout: "30"}},
+			want:   defaultTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BeamEnvs{ExecutorConfig: tt.fields.ExecutorConfig}
+			if got := b.RunTimeout(defaultTimeout); got != tt.want {
+				t.Errorf("RunTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewVersionedBeamEnvs(t *testing.T) {
+	oldConfig := &ExecutorConfig{CompileCmd: "javac-2.9"}
+	latestConfig := &ExecutorConfig{CompileCmd: "javac-2.10"}
+	beamEnvs := NewVersionedBeamEnvs(playground.Sdk_SDK_JAVA, map[string]*ExecutorConfig{"2.9": oldConfig, "2.10": latestConfig}, "")
+	if beamEnvs.Version != "2.10" {
+		t.Errorf("NewVersionedBeamEnvs() Version = %v, want %v", beamEnvs.Version, "2.10")
+	}
+	if beamEnvs.ExecutorConfig != latestConfig {
+		t.Errorf("NewVersionedBeamEnvs() ExecutorConfig = %v, want %v", beamEnvs.ExecutorConfig, latestConfig)
+	}
+	if got := beamEnvs.Versions(); !reflect.DeepEqual(got, []string{"2.9", "2.10"}) {
+		t.Errorf("Versions() = %v, want %v", got, []string{"2.9", "2.10"})
+	}
+}
+
+func TestBeamEnvs_ForVersion(t *testing.T) {
+	oldConfig := &ExecutorConfig{CompileCmd: "javac-2.50"}
+	latestConfig := &ExecutorConfig{CompileCmd: "javac-2.55"}
+	versionedEnvs := NewVersionedBeamEnvs(playground.Sdk_SDK_JAVA, map[string]*ExecutorConfig{"2.50": oldConfig, "2.55": latestConfig}, "")
+	notVersionedEnvs := NewBeamEnvs(playground.Sdk_SDK_JAVA, latestConfig, "")
+	tests := []struct {
+		name               string
+		beamEnvs           *BeamEnvs
+		version            string
+		wantExecutorConfig *ExecutorConfig
+		wantVersion        string
+		wantErr            bool
+	}{
+		{
+			// Test case with calling ForVersion method with the configured version.
+			// As a result, want to receive BeamEnvs with the executor config of this version.
+			name:               "configured version",
+			beamEnvs:           versionedEnvs,
+			version:            "2.50",
+			wantExecutorConfig: oldConfig,
+			wantVersion:        "2.50",
+			wantErr:            false,
+		},
+		{
+			// Test case with calling ForVersion method without the version.
+			// As a result, want to receive BeamEnvs with the executor config of the latest version.
+			name:               "default version",
+			beamEnvs:           versionedEnvs,
+			version:            "",
+			wantExecutorConfig: latestConfig,
+			wantVersion:        "2.55",
+			wantErr:            false,
+		},
+		{
+			// Test case with calling ForVersion method with the version which isn't configured.
+			// As a result, want to receive an error.
+			name:     "unknown version",
+			beamEnvs: versionedEnvs,
+			version:  "2.0",
+			wantErr:  true,
+		},
+		{
+			// Test case with calling ForVersion method without the version for the SDK without versions.
+			// As a result, want to receive BeamEnvs as is.
+			name:               "default version of not versioned sdk",
+			beamEnvs:           notVersionedEnvs,
+			version:            "",
+			wantExecutorConfig: latestConfig,
+			wantVersion:        "",
+			wantErr:            false,
+		},
+		{
+			// Test case with calling ForVersion method with the version for the SDK without versions.
+			// As a result, want to receive an error.
+			name:     "version of not versioned sdk",
+			beamEnvs: notVersionedEnvs,
+			version:  "2.55",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.beamEnvs.ForVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ForVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.ExecutorConfig != tt.wantExecutorConfig {
+				t.Errorf("ForVersion() ExecutorConfig = %v, want %v", got.ExecutorConfig, tt.wantExecutorConfig)
+			}
+			if got.Version != tt.wantVersion {
+				t.Errorf("ForVersion() Version = %v, want %v", got.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func Test_compareVersions(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{name: "equal versions", a: "2.55", b: "2.55", want: 0},
+		{name: "numeric parts are compared as numbers", a: "2.9", b: "2.10", want: -1},
+		{name: "longer version is newer", a: "2.55.1", b: "2.55", want: 1},
+		{name: "not numeric parts are compared as strings", a: "2.55-rc2", b: "2.55-rc1", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := compareVersions(tt.a, tt.b)
+			if (got < 0 && tt.want >= 0) || (got > 0 && tt.want <= 0) || (got == 0 && tt.want != 0) {
+				t.Errorf("compareVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBeamEnvs_CompileTimeout(t *testing.T) {
+	defaultTimeout := time.Minute
+	type fields struct {
+		ExecutorConfig *ExecutorConfig
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   time.Duration
+	}{
+		{
+			// Test case with calling CompileTimeout method when compile timeout is set in the config.
+			// As a result, want to receive the compile timeout from the config.
+			name:   "compile timeout is configured",
+			fields: fields{ExecutorConfig: &ExecutorConfig{CompileTimeout: "30s"}},
+			want:   30 * time.Second,
+		},
+		{
+			// Test case with calling CompileTimeout method when compile timeout isn't set in the config.
+			// As a result, want to receive the default timeout.
+			name:   "compile timeout isn't configured",
+			fields: fields{ExecutorConfig: &ExecutorConfig{RunTimeout: "30s"}},
+			want:   defaultTimeout,
+		},
+		{
+			// Test case with calling CompileTimeout method when compile timeout in the config is incorrect.
+			// As a result, want to receive the default timeout.
+			name:   "incorrect compile timeout",
+			fields: fields{ExecutorConfig: &ExecutorConfig{CompileTimeout: "30"}},
+			want:   defaultTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BeamEnvs{ExecutorConfig: tt.fields.ExecutorConfig}
+			if got := b.CompileTimeout(defaultTimeout); got != tt.want {
+				t.Errorf("CompileTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,47 +17,118 @@ package environment
 
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
 const (
-	serverIpKey                   = "SERVER_IP"
-	serverPortKey                 = "SERVER_PORT"
-	beamSdkKey                    = "BEAM_SDK"
-	workingDirKey                 = "APP_WORK_DIR"
-	preparedModDirKey             = "PREPARED_MOD_DIR"
-	cacheTypeKey                  = "CACHE_TYPE"
-	cacheAddressKey               = "CACHE_ADDRESS"
-	beamPathKey                   = "BEAM_PATH"
-	cacheKeyExpirationTimeKey     = "KEY_EXPIRATION_TIME"
-	pipelineExecuteTimeoutKey     = "PIPELINE_EXPIRATION_TIMEOUT"
-	protocolTypeKey               = "PROTOCOL_TYPE"
-	defaultProtocol               = "HTTP"
-	defaultIp                     = "localhost"
-	defaultPort                   = 8080
-	defaultSdk                    = pb.Sdk_SDK_JAVA
-	defaultBeamJarsPath           = "/opt/apache/beam/jars/*"
-	defaultCacheType              = "local"
-	defaultCacheAddress           = "localhost:6379"
-	defaultCacheKeyExpirationTime = time.Minute * 15
-	defaultPipelineExecuteTimeout = time.Minute * 10
-	jsonExt                       = ".json"
-	configFolderName              = "configs"
+	serverIpKey                    = "SERVER_IP"
+	serverPortKey                  = "SERVER_PORT"
+	beamSdkKey                     = "BEAM_SDK"
+	workingDirKey                  = "APP_WORK_DIR"
+	preparedModDirKey              = "PREPARED_MOD_DIR"
+	cacheTypeKey                   = "CACHE_TYPE"
+	cacheAddressKey                = "CACHE_ADDRESS"
+	beamPathKey                    = "BEAM_PATH"
+	scioPathKey                    = "SCIO_PATH"
+	kotlinStdlibPathKey            = "KOTLIN_STDLIB_PATH"
+	classpathDependenciesKey       = "CLASSPATH_DEPENDENCIES"
+	cacheKeyExpirationTimeKey      = "KEY_EXPIRATION_TIME"
+	cacheMaxPipelinesKey           = "CACHE_MAX_PIPELINES"
+	cacheIdleTimeoutKey            = "CACHE_IDLE_TIMEOUT"
+	cacheMaxOutputBytesKey         = "CACHE_MAX_OUTPUT_BYTES"
+	cacheEncryptionKeyKey          = "CACHE_ENCRYPTION_KEY"
+	pipelineExecuteTimeoutKey      = "PIPELINE_EXPIRATION_TIMEOUT"
+	pipelineMemoryLimitKey         = "PIPELINE_MEMORY_LIMIT"
+	pipelineCpuTimeLimitKey        = "PIPELINE_CPU_TIME_LIMIT"
+	sourceUrlAllowedHostsKey       = "SOURCE_URL_ALLOWED_HOSTS"
+	maxConcurrentPipelinesKey      = "MAX_CONCURRENT_PIPELINES"
+	maxOutputSizeKey               = "MAX_OUTPUT_SIZE"
+	maxCompileOutputSizeKey        = "MAX_COMPILE_OUTPUT_SIZE"
+	examplesDirKey                 = "EXAMPLES_DIR"
+	examplesRefreshIntervalKey     = "EXAMPLES_REFRESH_INTERVAL"
+	pipelineNetworkIsolationKey    = "PIPELINE_NETWORK_ISOLATION"
+	pipelineSandboxCmdKey          = "PIPELINE_SANDBOX_CMD"
+	keepPipelineFilesKey           = "KEEP_PIPELINE_FILES"
+	archiveLocationKey             = "ARCHIVE_LOCATION"
+	pipelineCancelGracePeriodKey   = "PIPELINE_CANCEL_GRACE_PERIOD"
+	maxSourceSizeKey               = "MAX_SOURCE_SIZE"
+	pipelineStartRetriesKey        = "PIPELINE_START_RETRIES"
+	pipelineRetryBackoffKey        = "PIPELINE_START_RETRY_BACKOFF"
+	rateLimitPerMinuteKey          = "RATE_LIMIT_PER_MINUTE"
+	rateLimitBurstKey              = "RATE_LIMIT_BURST"
+	debugModeKey                   = "DEBUG_MODE"
+	jvmWarmPoolSizeKey             = "JVM_WARM_POOL_SIZE"
+	pipelineDiskQuotaKey           = "PIPELINE_DISK_QUOTA"
+	compileCacheSizeKey            = "COMPILE_CACHE_SIZE"
+	maxPipelinesPerClientKey       = "MAX_PIPELINES_PER_CLIENT"
+	executionRootKey               = "EXECUTION_ROOT"
+	spillRunOutputKey              = "SPILL_RUN_OUTPUT"
+	runOutputFlushLinesKey         = "RUN_OUTPUT_FLUSH_LINES"
+	runOutputFlushIntervalKey      = "RUN_OUTPUT_FLUSH_INTERVAL"
+	outputPathOptionsKey           = "OUTPUT_PATH_OPTIONS"
+	allowedOutputPathsKey          = "ALLOWED_OUTPUT_PATHS"
+	webSocketOriginPatternsKey     = "WEBSOCKET_ORIGIN_PATTERNS"
+	trustedProxiesKey              = "TRUSTED_PROXIES"
+	adminTokenKey                  = "ADMIN_TOKEN"
+	protocolTypeKey                = "PROTOCOL_TYPE"
+	defaultProtocol                = "HTTP"
+	defaultIp                      = "localhost"
+	defaultPort                    = 8080
+	defaultSdk                     = pb.Sdk_SDK_JAVA
+	defaultBeamJarsPath            = "/opt/apache/beam/jars/*"
+	defaultScioJarsPath            = "/opt/scio/jars/*"
+	defaultKotlinStdlibPath        = "/opt/kotlinc/lib/kotlin-stdlib.jar"
+	defaultCacheType               = "local"
+	defaultCacheAddress            = "localhost:6379"
+	defaultCacheKeyExpirationTime  = time.Minute * 15
+	defaultCacheMaxPipelines       = 0
+	defaultCacheIdleTimeout        = time.Duration(0)
+	defaultCacheMaxOutputBytes     = 0
+	defaultPipelineExecuteTimeout  = time.Minute * 10
+	defaultPipelineMemoryLimit     = 0
+	defaultPipelineCpuTimeLimit    = 0
+	defaultMaxConcurrentPipelines  = 0
+	defaultMaxOutputSize           = 0
+	defaultMaxCompileOutputSize    = 0
+	defaultMaxSourceSize           = 0
+	defaultExamplesRefreshInterval = time.Minute * 10
+	defaultCancelGracePeriod       = time.Second * 5
+	defaultStartRetries            = 0
+	defaultRetryBackoff            = time.Second
+	defaultRateLimitPerMinute      = 0
+	defaultRateLimitBurst          = 0
+	defaultJvmWarmPoolSize         = 0
+	defaultPipelineDiskQuota       = 0
+	defaultCompileCacheSize        = 0
+	defaultMaxPipelinesPerClient   = 0
+	defaultRunOutputFlushLines     = 0
+	defaultRunOutputFlushInterval  = time.Duration(0)
+	jsonExt                        = ".json"
+	versionSeparator               = "-"
+	configFolderName               = "configs"
 )
 
+// defaultOutputPathOptions are names of the pipeline options which values are paths of the output of the code by default
+var defaultOutputPathOptions = []string{"output"}
+
 // Environment operates with environment structures: NetworkEnvs, BeamEnvs, ApplicationEnvs
 // Environment contains all environment variables which are used by the application
 type Environment struct {
-	NetworkEnvs     NetworkEnvs
-	BeamSdkEnvs     BeamEnvs
+	NetworkEnvs NetworkEnvs
+	// BeamSdkEnvs contains BeamEnvs of each SDK which is served by the application, keyed by the SDK
+	BeamSdkEnvs     map[pb.Sdk]*BeamEnvs
 	ApplicationEnvs ApplicationEnvs
 }
 
@@ -65,9 +136,9 @@ type Environment struct {
 // Default values:
 // LogWriters: by default using os.Stdout
 // NetworkEnvs: by default using defaultIp, defaultPort and defaultProtocol from constants
-// BeamEnvs: by default using pb.Sdk_SDK_JAVA
+// BeamEnvs: BeamEnvs of each SDK which is served by the application
 // ApplicationEnvs: required field not providing by default value
-func NewEnvironment(networkEnvs NetworkEnvs, beamEnvs BeamEnvs, appEnvs ApplicationEnvs) *Environment {
+func NewEnvironment(networkEnvs NetworkEnvs, beamEnvs map[pb.Sdk]*BeamEnvs, appEnvs ApplicationEnvs) *Environment {
 	svc := Environment{}
 	svc.NetworkEnvs = networkEnvs
 	svc.BeamSdkEnvs = beamEnvs
@@ -76,6 +147,25 @@ func NewEnvironment(networkEnvs NetworkEnvs, beamEnvs BeamEnvs, appEnvs Applicat
 	return &svc
 }
 
+// GetBeamSdkEnvs returns BeamEnvs of the sdk.
+// Returns false if the sdk isn't served by the application.
+func (e *Environment) GetBeamSdkEnvs(sdk pb.Sdk) (*BeamEnvs, bool) {
+	beamEnvs, ok := e.BeamSdkEnvs[sdk]
+	return beamEnvs, ok
+}
+
+// SortedBeamSdkEnvs returns BeamEnvs of all SDKs which are served by the application ordered by the SDK
+func (e *Environment) SortedBeamSdkEnvs() []*BeamEnvs {
+	beamEnvs := make([]*BeamEnvs, 0, len(e.BeamSdkEnvs))
+	for _, sdkEnvs := range e.BeamSdkEnvs {
+		beamEnvs = append(beamEnvs, sdkEnvs)
+	}
+	sort.Slice(beamEnvs, func(i, j int) bool {
+		return beamEnvs[i].ApacheBeamSdk < beamEnvs[j].ApacheBeamSdk
+	})
+	return beamEnvs
+}
+
 // GetApplicationEnvsFromOsEnvs returns ApplicationEnvs.
 // Lookups in os environment variables and tries to take values for all (exclude working dir) ApplicationEnvs parameters.
 // In case some value doesn't exist sets default values:
@@ -83,10 +173,69 @@ func NewEnvironment(networkEnvs NetworkEnvs, beamEnvs BeamEnvs, appEnvs Applicat
 //	- cache expiration time: 15 minutes
 //	- type of cache: local
 //	- cache address: localhost:6379
+//	- max number of pipelines in the local cache: 0 (the number of pipelines is not limited)
+//	- cache idle timeout: 0 (idle pipelines are not removed)
+//	- cache encryption key: empty (values are kept in the cache unencrypted)
+//	- pipeline memory limit: 0 (memory is not limited)
+//	- pipeline cpu time limit: 0 (cpu time is not limited)
+//	- source url allowed hosts: empty (the code couldn't be downloaded by a link)
+//	- max concurrent pipelines: 0 (the number of pipelines is not limited)
+//	- max output size: 0 (the size of the run output is not limited)
+//	- max compile output size: 0 (the size of the compile output is not limited)
+//	- max source size: 0 (the size of the source files is not limited)
+//	- examples dir: empty (there are no examples)
+//	- examples refresh interval: 10 minutes
+//	- archive location: empty (results of the code processing aren't archived)
+//	- pipeline cancel grace period: 5 seconds
+//	- rate limit per minute: 0 (requests are not limited)
+//	- rate limit burst: 0 (it is equal to the rate limit per minute)
+//	- debug mode: false (debugging information of the code processing isn't exposed)
+//	- jvm warm pool size: 0 (the Java code is always run by a new JVM)
+//	- pipeline disk quota: 0 (disk space is not limited)
+//	- compile cache size: 0 (the code is always compiled)
+//	- run output flush lines: 0 and run output flush interval: 0 (the run output is written to the cache line by line)
 // If os environment variables don't contain a value for app working dir - returns error.
+// If the cache encryption key isn't a base64 encoded key of 16, 24 or 32 bytes - returns error,
+//	so the values aren't kept unencrypted by mistake.
 func GetApplicationEnvsFromOsEnvs() (*ApplicationEnvs, error) {
 	pipelineExecuteTimeout := defaultPipelineExecuteTimeout
+	pipelineMemoryLimit := defaultPipelineMemoryLimit
+	pipelineCpuTimeLimit := defaultPipelineCpuTimeLimit
+	var sourceUrlAllowedHosts []string
+	maxConcurrentPipelines := defaultMaxConcurrentPipelines
+	maxOutputSize := defaultMaxOutputSize
+	maxCompileOutputSize := defaultMaxCompileOutputSize
+	maxSourceSize := defaultMaxSourceSize
+	examplesDir := getEnv(examplesDirKey, "")
+	archiveLocation := getEnv(archiveLocationKey, "")
+	adminToken := getEnv(adminTokenKey, "")
+	examplesRefreshInterval := defaultExamplesRefreshInterval
+	networkIsolation := false
+	var sandboxCmd []string
+	keepPipelineFiles := false
+	debugMode := false
+	pipelineCancelGracePeriod := defaultCancelGracePeriod
+	pipelineStartRetries := defaultStartRetries
+	pipelineRetryBackoff := defaultRetryBackoff
+	rateLimitPerMinute := defaultRateLimitPerMinute
+	rateLimitBurst := defaultRateLimitBurst
+	jvmWarmPoolSize := defaultJvmWarmPoolSize
+	pipelineDiskQuota := defaultPipelineDiskQuota
+	compileCacheSize := defaultCompileCacheSize
+	maxPipelinesPerClient := defaultMaxPipelinesPerClient
+	executionRoot := ""
+	spillRunOutput := false
+	runOutputFlushLines := defaultRunOutputFlushLines
+	runOutputFlushInterval := defaultRunOutputFlushInterval
+	outputPathOptions := defaultOutputPathOptions
+	var allowedOutputPaths []string
+	var webSocketOriginPatterns []string
+	var trustedProxies []*net.IPNet
 	cacheExpirationTime := defaultCacheKeyExpirationTime
+	cacheMaxPipelines := defaultCacheMaxPipelines
+	cacheIdleTimeout := defaultCacheIdleTimeout
+	cacheMaxOutputBytes := defaultCacheMaxOutputBytes
+	var cacheEncryptionKey []byte
 	cacheType := getEnv(cacheTypeKey, defaultCacheType)
 	cacheAddress := getEnv(cacheAddressKey, defaultCacheAddress)
 
@@ -97,6 +246,37 @@ func GetApplicationEnvsFromOsEnvs() (*ApplicationEnvs, error) {
 			log.Printf("couldn't convert provided cache expiration time. Using default %s\n", defaultCacheKeyExpirationTime)
 		}
 	}
+	if value, present := os.LookupEnv(cacheMaxPipelinesKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			cacheMaxPipelines = converted
+		} else {
+			log.Printf("couldn't convert provided max number of pipelines in the cache. Using default %d\n", defaultCacheMaxPipelines)
+		}
+	}
+	if value, present := os.LookupEnv(cacheIdleTimeoutKey); present {
+		if converted, err := time.ParseDuration(value); err == nil && converted >= 0 {
+			cacheIdleTimeout = converted
+		} else {
+			log.Printf("couldn't convert provided cache idle timeout. Idle pipelines are not removed\n")
+		}
+	}
+	if value, present := os.LookupEnv(cacheMaxOutputBytesKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			cacheMaxOutputBytes = converted
+		} else {
+			log.Printf("couldn't convert provided max size of the output in the cache. Using default %d\n", defaultCacheMaxOutputBytes)
+		}
+	}
+	if value, present := os.LookupEnv(cacheEncryptionKeyKey); present && value != "" {
+		converted, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode provided cache encryption key: %w", err)
+		}
+		if keyLength := len(converted); keyLength != 16 && keyLength != 24 && keyLength != 32 {
+			return nil, fmt.Errorf("cache encryption key should be 16, 24 or 32 bytes long, but it is %d bytes long", keyLength)
+		}
+		cacheEncryptionKey = converted
+	}
 	if value, present := os.LookupEnv(pipelineExecuteTimeoutKey); present {
 		if converted, err := time.ParseDuration(value); err == nil {
 			pipelineExecuteTimeout = converted
@@ -104,9 +284,252 @@ func GetApplicationEnvsFromOsEnvs() (*ApplicationEnvs, error) {
 			log.Printf("couldn't convert provided pipeline execute timeout. Using default %s\n", defaultPipelineExecuteTimeout)
 		}
 	}
+	if value, present := os.LookupEnv(pipelineMemoryLimitKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			pipelineMemoryLimit = converted
+		} else {
+			log.Printf("couldn't convert provided pipeline memory limit. Using default %d\n", defaultPipelineMemoryLimit)
+		}
+	}
+	if value, present := os.LookupEnv(pipelineCpuTimeLimitKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			pipelineCpuTimeLimit = converted
+		} else {
+			log.Printf("couldn't convert provided pipeline cpu time limit. Using default %d\n", defaultPipelineCpuTimeLimit)
+		}
+	}
+	if value, present := os.LookupEnv(sourceUrlAllowedHostsKey); present {
+		for _, host := range strings.Split(value, ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				sourceUrlAllowedHosts = append(sourceUrlAllowedHosts, host)
+			}
+		}
+	}
+	if value, present := os.LookupEnv(maxConcurrentPipelinesKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			maxConcurrentPipelines = converted
+		} else {
+			log.Printf("couldn't convert provided max concurrent pipelines. Using default %d\n", defaultMaxConcurrentPipelines)
+		}
+	}
+	if value, present := os.LookupEnv(maxOutputSizeKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			maxOutputSize = converted
+		} else {
+			log.Printf("couldn't convert provided max output size. Using default %d\n", defaultMaxOutputSize)
+		}
+	}
+	if value, present := os.LookupEnv(maxCompileOutputSizeKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			maxCompileOutputSize = converted
+		} else {
+			log.Printf("couldn't convert provided max compile output size. Using default %d\n", defaultMaxCompileOutputSize)
+		}
+	}
+	if value, present := os.LookupEnv(maxSourceSizeKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			maxSourceSize = converted
+		} else {
+			log.Printf("couldn't convert provided max source size. Using default %d\n", defaultMaxSourceSize)
+		}
+	}
+	if value, present := os.LookupEnv(examplesRefreshIntervalKey); present {
+		if converted, err := time.ParseDuration(value); err == nil && converted > 0 {
+			examplesRefreshInterval = converted
+		} else {
+			log.Printf("couldn't convert provided examples refresh interval. Using default %s\n", defaultExamplesRefreshInterval)
+		}
+	}
+	if value, present := os.LookupEnv(pipelineNetworkIsolationKey); present {
+		if converted, err := strconv.ParseBool(value); err == nil {
+			networkIsolation = converted
+		} else {
+			log.Printf("couldn't convert provided pipeline network isolation. Network isolation is disabled\n")
+		}
+	}
+	if value, present := os.LookupEnv(pipelineSandboxCmdKey); present && strings.TrimSpace(value) != "" {
+		sandboxCmd = strings.Fields(value)
+	}
+	if value, present := os.LookupEnv(keepPipelineFilesKey); present {
+		if converted, err := strconv.ParseBool(value); err == nil {
+			keepPipelineFiles = converted
+		} else {
+			log.Printf("couldn't convert provided keep pipeline files. Files of pipelines are removed\n")
+		}
+	}
+	if value, present := os.LookupEnv(debugModeKey); present {
+		if converted, err := strconv.ParseBool(value); err == nil {
+			debugMode = converted
+		} else {
+			log.Printf("couldn't convert provided debug mode. Debug mode is disabled\n")
+		}
+	}
+	if value, present := os.LookupEnv(pipelineCancelGracePeriodKey); present {
+		if converted, err := time.ParseDuration(value); err == nil && converted >= 0 {
+			pipelineCancelGracePeriod = converted
+		} else {
+			log.Printf("couldn't convert provided pipeline cancel grace period. Using default %s\n", defaultCancelGracePeriod)
+		}
+	}
+	if value, present := os.LookupEnv(pipelineStartRetriesKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			pipelineStartRetries = converted
+		} else {
+			log.Printf("couldn't convert provided pipeline start retries. Using default %d\n", defaultStartRetries)
+		}
+	}
+	if value, present := os.LookupEnv(pipelineRetryBackoffKey); present {
+		if converted, err := time.ParseDuration(value); err == nil && converted >= 0 {
+			pipelineRetryBackoff = converted
+		} else {
+			log.Printf("couldn't convert provided pipeline start retry backoff. Using default %s\n", defaultRetryBackoff)
+		}
+	}
+	if value, present := os.LookupEnv(rateLimitPerMinuteKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			rateLimitPerMinute = converted
+		} else {
+			log.Printf("couldn't convert provided rate limit per minute. Using default %d\n", defaultRateLimitPerMinute)
+		}
+	}
+	if value, present := os.LookupEnv(rateLimitBurstKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			rateLimitBurst = converted
+		} else {
+			log.Printf("couldn't convert provided rate limit burst. Using default %d\n", defaultRateLimitBurst)
+		}
+	}
+	if value, present := os.LookupEnv(jvmWarmPoolSizeKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			jvmWarmPoolSize = converted
+		} else {
+			log.Printf("couldn't convert provided jvm warm pool size. Using default %d\n", defaultJvmWarmPoolSize)
+		}
+	}
+	if value, present := os.LookupEnv(pipelineDiskQuotaKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			pipelineDiskQuota = converted
+		} else {
+			log.Printf("couldn't convert provided pipeline disk quota. Using default %d\n", defaultPipelineDiskQuota)
+		}
+	}
+	if value, present := os.LookupEnv(compileCacheSizeKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			compileCacheSize = converted
+		} else {
+			log.Printf("couldn't convert provided compile cache size. Using default %d\n", defaultCompileCacheSize)
+		}
+	}
+	if value, present := os.LookupEnv(maxPipelinesPerClientKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			maxPipelinesPerClient = converted
+		} else {
+			log.Printf("couldn't convert provided max pipelines per client. Using default %d\n", defaultMaxPipelinesPerClient)
+		}
+	}
+	if value, present := os.LookupEnv(executionRootKey); present && value != "" {
+		if err := checkWritableDir(value); err != nil {
+			return nil, fmt.Errorf("execution root %s isn't writable: %w", value, err)
+		}
+		executionRoot = value
+	}
+	if value, present := os.LookupEnv(spillRunOutputKey); present {
+		if converted, err := strconv.ParseBool(value); err == nil {
+			spillRunOutput = converted
+		} else {
+			log.Printf("couldn't convert provided spill run output. Run output is truncated\n")
+		}
+	}
+	if value, present := os.LookupEnv(runOutputFlushLinesKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			runOutputFlushLines = converted
+		} else {
+			log.Printf("couldn't convert provided run output flush lines. Using default %d\n", defaultRunOutputFlushLines)
+		}
+	}
+	if value, present := os.LookupEnv(runOutputFlushIntervalKey); present {
+		if converted, err := time.ParseDuration(value); err == nil && converted >= 0 {
+			runOutputFlushInterval = converted
+		} else {
+			log.Printf("couldn't convert provided run output flush interval. Using default %s\n", defaultRunOutputFlushInterval)
+		}
+	}
+	if value, present := os.LookupEnv(outputPathOptionsKey); present {
+		outputPathOptions = nil
+		for _, option := range strings.Split(value, ",") {
+			if option = strings.TrimLeft(strings.TrimSpace(option), "-"); option != "" {
+				outputPathOptions = append(outputPathOptions, option)
+			}
+		}
+	}
+	if value, present := os.LookupEnv(allowedOutputPathsKey); present {
+		for _, path := range strings.Split(value, ",") {
+			if path = strings.TrimSpace(path); path == "" {
+				continue
+			}
+			if !filepath.IsAbs(path) {
+				log.Printf("allowed output path should be absolute. Skipping %s\n", path)
+				continue
+			}
+			allowedOutputPaths = append(allowedOutputPaths, filepath.Clean(path))
+		}
+	}
+	if value, present := os.LookupEnv(webSocketOriginPatternsKey); present {
+		for _, pattern := range strings.Split(value, ",") {
+			if pattern = strings.TrimSpace(pattern); pattern != "" {
+				webSocketOriginPatterns = append(webSocketOriginPatterns, pattern)
+			}
+		}
+	}
+	if value, present := os.LookupEnv(trustedProxiesKey); present {
+		for _, proxy := range strings.Split(value, ",") {
+			if proxy = strings.TrimSpace(proxy); proxy == "" {
+				continue
+			}
+			if trustedProxy, err := parseTrustedProxy(proxy); err == nil {
+				trustedProxies = append(trustedProxies, trustedProxy)
+			} else {
+				log.Printf("trusted proxy should be an IP address or a CIDR. Skipping %s\n", proxy)
+			}
+		}
+	}
 
 	if value, present := os.LookupEnv(workingDirKey); present {
-		return NewApplicationEnvs(value, NewCacheEnvs(cacheType, cacheAddress, cacheExpirationTime), pipelineExecuteTimeout), nil
+		return NewApplicationEnvs(value, NewCacheEnvs(cacheType, cacheAddress, cacheExpirationTime, cacheMaxPipelines, cacheIdleTimeout, cacheMaxOutputBytes, cacheEncryptionKey), ApplicationOptions{
+			PipelineExecuteTimeout:    pipelineExecuteTimeout,
+			PipelineMemoryLimit:       pipelineMemoryLimit,
+			SourceUrlAllowedHosts:     sourceUrlAllowedHosts,
+			MaxConcurrentPipelines:    maxConcurrentPipelines,
+			MaxOutputSize:             maxOutputSize,
+			ExamplesDir:               examplesDir,
+			ExamplesRefreshInterval:   examplesRefreshInterval,
+			NetworkIsolation:          networkIsolation,
+			SandboxCmd:                sandboxCmd,
+			KeepPipelineFiles:         keepPipelineFiles,
+			PipelineCpuTimeLimit:      pipelineCpuTimeLimit,
+			ArchiveLocation:           archiveLocation,
+			PipelineCancelGracePeriod: pipelineCancelGracePeriod,
+			MaxSourceSize:             maxSourceSize,
+			PipelineStartRetries:      pipelineStartRetries,
+			PipelineRetryBackoff:      pipelineRetryBackoff,
+			MaxCompileOutputSize:      maxCompileOutputSize,
+			RateLimitPerMinute:        rateLimitPerMinute,
+			RateLimitBurst:            rateLimitBurst,
+			DebugMode:                 debugMode,
+			JvmWarmPoolSize:           jvmWarmPoolSize,
+			PipelineDiskQuota:         pipelineDiskQuota,
+			CompileCacheSize:          compileCacheSize,
+			MaxPipelinesPerClient:     maxPipelinesPerClient,
+			ExecutionRoot:             executionRoot,
+			SpillRunOutput:            spillRunOutput,
+			RunOutputFlushLines:       runOutputFlushLines,
+			RunOutputFlushInterval:    runOutputFlushInterval,
+			OutputPathOptions:         outputPathOptions,
+			AllowedOutputPaths:        allowedOutputPaths,
+			WebSocketOriginPatterns:   webSocketOriginPatterns,
+			TrustedProxies:            trustedProxies,
+			AdminToken:                adminToken,
+		}), nil
 	}
 	return nil, errors.New("APP_WORK_DIR env should be provided with os.env")
 }
@@ -130,31 +553,92 @@ func GetNetworkEnvsFromOsEnvs() (*NetworkEnvs, error) {
 	return NewNetworkEnvs(ip, port, protocol), nil
 }
 
-// ConfigureBeamEnvs returns BeamEnvs.
-// Lookups in os environment variables and takes value for Apache Beam SDK.
-// If os environment variables don't contain a value for Apache Beam SDK - returns error.
-// Configures ExecutorConfig with config file.
-func ConfigureBeamEnvs(workDir string) (*BeamEnvs, error) {
-	sdk := pb.Sdk_SDK_UNSPECIFIED
-	preparedModDir, modDirExist := os.LookupEnv(preparedModDirKey)
+// ConfigureBeamEnvs returns BeamEnvs of each SDK which is served by the application, keyed by the SDK.
+// Lookups in os environment variables and takes values for Apache Beam SDKs: BEAM_SDK contains one SDK
+//	or several comma-separated SDKs (i.e. "SDK_JAVA,SDK_PYTHON,SDK_GO").
+// If os environment variables don't contain a value for Apache Beam SDK - all SDKs which have config files
+//	in the configs folder are configured. If there is no such SDK or BEAM_SDK contains an unknown SDK - returns error.
+// Configures ExecutorConfig of each SDK with its config file.
+// If there are config files of several versions of the SDK (i.e. "SDK_JAVA-2.50.json" and "SDK_JAVA-2.55.json"),
+//	all of them are configured and the latest version is used by default, the config file without the version is ignored.
+// For Java, SCIO and Kotlin SDKs the dependencies listed in CLASSPATH_DEPENDENCIES (comma-separated coordinates or paths
+//	of the allowlisted dependencies from the config file) are added to the classpath of compile, run and test commands.
+// If some dependency isn't allowlisted - returns error.
+// If the config file is missing, contains incorrect json or doesn't contain required keys (see validateExecutorConfig)
+//	returns error which contains the name of the SDK and the reason.
+func ConfigureBeamEnvs(workDir string) (map[pb.Sdk]*BeamEnvs, error) {
+	var sdks []pb.Sdk
 	if value, present := os.LookupEnv(beamSdkKey); present {
-
-		switch value {
-		case pb.Sdk_SDK_JAVA.String():
-			sdk = pb.Sdk_SDK_JAVA
-		case pb.Sdk_SDK_GO.String():
-			sdk = pb.Sdk_SDK_GO
-			if !modDirExist {
-				return nil, errors.New("env PREPARED_MOD_DIR must be specified in the environment variables for GO sdk")
+		for _, name := range strings.Split(value, ",") {
+			sdk := pb.Sdk(pb.Sdk_value[strings.TrimSpace(name)])
+			if sdk == pb.Sdk_SDK_UNSPECIFIED {
+				return nil, errors.New("env BEAM_SDK must contain only supported sdks")
 			}
-		case pb.Sdk_SDK_PYTHON.String():
-			sdk = pb.Sdk_SDK_PYTHON
-		case pb.Sdk_SDK_SCIO.String():
-			sdk = pb.Sdk_SDK_SCIO
+			sdks = append(sdks, sdk)
 		}
+	} else {
+		configuredSdks, err := getConfiguredSdks(workDir)
+		if err != nil {
+			return nil, err
+		}
+		sdks = configuredSdks
 	}
-	if sdk == pb.Sdk_SDK_UNSPECIFIED {
-		return nil, errors.New("env BEAM_SDK must be specified in the environment variables")
+	if len(sdks) == 0 {
+		return nil, errors.New("env BEAM_SDK must be specified in the environment variables or config files of sdks must be provided")
+	}
+	beamEnvs := make(map[pb.Sdk]*BeamEnvs, len(sdks))
+	for _, sdk := range sdks {
+		sdkEnvs, err := configureSdkEnvs(workDir, sdk)
+		if err != nil {
+			return nil, err
+		}
+		beamEnvs[sdk] = sdkEnvs
+	}
+	return beamEnvs, nil
+}
+
+// getConfiguredSdks returns SDKs which have config files (with or without the version) in the configs folder
+func getConfiguredSdks(workDir string) ([]pb.Sdk, error) {
+	configPaths, err := filepath.Glob(filepath.Join(workDir, configFolderName, "*"+jsonExt))
+	if err != nil {
+		return nil, err
+	}
+	var sdks []pb.Sdk
+	found := make(map[pb.Sdk]bool)
+	for _, configPath := range configPaths {
+		name := strings.SplitN(strings.TrimSuffix(filepath.Base(configPath), jsonExt), versionSeparator, 2)[0]
+		sdk := pb.Sdk(pb.Sdk_value[name])
+		if sdk == pb.Sdk_SDK_UNSPECIFIED || found[sdk] {
+			continue
+		}
+		found[sdk] = true
+		sdks = append(sdks, sdk)
+	}
+	return sdks, nil
+}
+
+// configureSdkEnvs returns BeamEnvs of the sdk configured with its config files.
+// Go SDK requires PREPARED_MOD_DIR in os environment variables.
+func configureSdkEnvs(workDir string, sdk pb.Sdk) (*BeamEnvs, error) {
+	preparedModDir, modDirExist := os.LookupEnv(preparedModDirKey)
+	if sdk == pb.Sdk_SDK_GO && !modDirExist {
+		return nil, errors.New("env PREPARED_MOD_DIR must be specified in the environment variables for GO sdk")
+	}
+	versionConfigPaths, err := filepath.Glob(filepath.Join(workDir, configFolderName, sdk.String()+versionSeparator+"*"+jsonExt))
+	if err != nil {
+		return nil, err
+	}
+	if len(versionConfigPaths) > 0 {
+		versions := make(map[string]*ExecutorConfig, len(versionConfigPaths))
+		for _, configPath := range versionConfigPaths {
+			version := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(configPath), sdk.String()+versionSeparator), jsonExt)
+			executorConfig, err := createExecutorConfig(sdk, configPath)
+			if err != nil {
+				return nil, err
+			}
+			versions[version] = executorConfig
+		}
+		return NewVersionedBeamEnvs(sdk, versions, preparedModDir), nil
 	}
 	configPath := filepath.Join(workDir, configFolderName, sdk.String()+jsonExt)
 	executorConfig, err := createExecutorConfig(sdk, configPath)
@@ -169,33 +653,137 @@ func ConfigureBeamEnvs(workDir string) (*BeamEnvs, error) {
 func createExecutorConfig(apacheBeamSdk pb.Sdk, configPath string) (*ExecutorConfig, error) {
 	executorConfig, err := getConfigFromJson(configPath)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("couldn't configure %s: %s", apacheBeamSdk, err.Error())
+	}
+	if err := validateExecutorConfig(apacheBeamSdk, configPath, executorConfig); err != nil {
+		return nil, fmt.Errorf("couldn't configure %s: %s", apacheBeamSdk, err.Error())
 	}
 	switch apacheBeamSdk {
 	case pb.Sdk_SDK_JAVA:
-		executorConfig.CompileArgs = append(executorConfig.CompileArgs, getEnv(beamPathKey, defaultBeamJarsPath))
-		executorConfig.RunArgs[1] = fmt.Sprintf("%s%s", executorConfig.RunArgs[1], getEnv(beamPathKey, defaultBeamJarsPath))
-		executorConfig.TestArgs[1] = fmt.Sprintf("%s%s", executorConfig.TestArgs[1], getEnv(beamPathKey, defaultBeamJarsPath))
+		classpath, dependencies, err := withDependencies(executorConfig.beamPath(), executorConfig.Dependencies, getEnv(classpathDependenciesKey, ""))
+		if err != nil {
+			return nil, err
+		}
+		executorConfig.ClasspathDependencies = dependencies
+		executorConfig.CompileArgs = append(executorConfig.CompileArgs, classpath)
+		executorConfig.RunArgs[1] = fmt.Sprintf("%s%s", executorConfig.RunArgs[1], classpath)
+		executorConfig.TestArgs[1] = fmt.Sprintf("%s%s", executorConfig.TestArgs[1], classpath)
 	case pb.Sdk_SDK_GO:
 		// Go sdk doesn't need any additional arguments from the config file
 	case pb.Sdk_SDK_PYTHON:
 		// Python sdk doesn't need any additional arguments from the config file
 	case pb.Sdk_SDK_SCIO:
-		return nil, errors.New("not yet supported")
+		// SCIO code is compiled and run with both Apache Beam jars and SCIO jars
+		classpath := fmt.Sprintf("%s:%s", executorConfig.beamPath(), getEnv(scioPathKey, defaultScioJarsPath))
+		classpath, dependencies, err := withDependencies(classpath, executorConfig.Dependencies, getEnv(classpathDependenciesKey, ""))
+		if err != nil {
+			return nil, err
+		}
+		executorConfig.ClasspathDependencies = dependencies
+		executorConfig.CompileArgs = append(executorConfig.CompileArgs, classpath)
+		executorConfig.RunArgs[1] = fmt.Sprintf("%s%s", executorConfig.RunArgs[1], classpath)
+		executorConfig.TestArgs[1] = fmt.Sprintf("%s%s", executorConfig.TestArgs[1], classpath)
+	case pb.Sdk_SDK_KOTLIN:
+		// Kotlin code is compiled into JVM classes and run with java, so the Kotlin standard library should be in the classpath
+		classpath := fmt.Sprintf("%s:%s", executorConfig.beamPath(), getEnv(kotlinStdlibPathKey, defaultKotlinStdlibPath))
+		classpath, dependencies, err := withDependencies(classpath, executorConfig.Dependencies, getEnv(classpathDependenciesKey, ""))
+		if err != nil {
+			return nil, err
+		}
+		executorConfig.ClasspathDependencies = dependencies
+		executorConfig.CompileArgs = append(executorConfig.CompileArgs, classpath)
+		executorConfig.RunArgs[1] = fmt.Sprintf("%s%s", executorConfig.RunArgs[1], classpath)
+		executorConfig.TestArgs[1] = fmt.Sprintf("%s%s", executorConfig.TestArgs[1], classpath)
 	}
 	return executorConfig, nil
 }
 
+// validateExecutorConfig checks that the config of the SDK contains keys which are required to process the code:
+//	compile_cmd for SDKs which compile the code, run_cmd for SDKs which don't run the compiled file directly
+//	and run_args and test_args with the classpath flag and the classpath for Java, SCIO and Kotlin SDKs.
+func validateExecutorConfig(apacheBeamSdk pb.Sdk, configPath string, executorConfig *ExecutorConfig) error {
+	if apacheBeamSdk != pb.Sdk_SDK_PYTHON && executorConfig.CompileCmd == "" {
+		return fmt.Errorf("compile_cmd is missing in the config file %s", configPath)
+	}
+	if apacheBeamSdk != pb.Sdk_SDK_GO && executorConfig.RunCmd == "" {
+		return fmt.Errorf("run_cmd is missing in the config file %s", configPath)
+	}
+	switch apacheBeamSdk {
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_SCIO, pb.Sdk_SDK_KOTLIN:
+		if len(executorConfig.RunArgs) < 2 {
+			return fmt.Errorf("run_args should contain the classpath flag and the classpath in the config file %s", configPath)
+		}
+		if len(executorConfig.TestArgs) < 2 {
+			return fmt.Errorf("test_args should contain the classpath flag and the classpath in the config file %s", configPath)
+		}
+	}
+	return nil
+}
+
+// withDependencies appends paths of the requested dependencies to the classpath.
+// requested is a comma-separated list of dependencies, each of them is a coordinate or a path of one of allowed dependencies.
+// Returns the classpath and the requested dependencies by their coordinates (nil if no dependency is requested).
+// If some requested dependency isn't allowed - returns error.
+func withDependencies(classpath string, allowed map[string]string, requested string) (string, map[string]string, error) {
+	paths := []string{classpath}
+	var dependencies map[string]string
+	for _, dependency := range strings.Split(requested, ",") {
+		dependency = strings.TrimSpace(dependency)
+		if dependency == "" {
+			continue
+		}
+		coordinate := dependency
+		path, ok := allowed[dependency]
+		if !ok {
+			coordinate, ok = allowedDependencyCoordinate(allowed, dependency)
+			if !ok {
+				return "", nil, fmt.Errorf("dependency %s isn't allowed", dependency)
+			}
+			path = dependency
+		}
+		paths = append(paths, path)
+		if dependencies == nil {
+			dependencies = make(map[string]string)
+		}
+		dependencies[coordinate] = path
+	}
+	return strings.Join(paths, ":"), dependencies, nil
+}
+
+// allowedDependencyCoordinate returns the coordinate of the allowed dependency by its path.
+// Returns false if path isn't a path of one of allowed dependencies.
+func allowedDependencyCoordinate(allowed map[string]string, path string) (string, bool) {
+	for coordinate, allowedPath := range allowed {
+		if allowedPath == path {
+			return coordinate, true
+		}
+	}
+	return "", false
+}
+
 // getConfigFromJson reads a json file to ExecutorConfig
 func getConfigFromJson(configPath string) (*ExecutorConfig, error) {
 	file, err := ioutil.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("the config file %s isn't found", configPath)
+	}
 	if err != nil {
 		return nil, err
 	}
 	executorConfig := ExecutorConfig{}
 	err = json.Unmarshal(file, &executorConfig)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("incorrect json in the config file %s: %s", configPath, err.Error())
+	}
+	if executorConfig.RunTimeout != "" {
+		if _, err = time.ParseDuration(executorConfig.RunTimeout); err != nil {
+			return nil, fmt.Errorf("incorrect run_timeout in the config file %s: %s", configPath, err.Error())
+		}
+	}
+	if executorConfig.CompileTimeout != "" {
+		if _, err = time.ParseDuration(executorConfig.CompileTimeout); err != nil {
+			return nil, fmt.Errorf("incorrect compile_timeout in the config file %s: %s", configPath, err.Error())
+		}
 	}
 	return &executorConfig, err
 }
@@ -207,3 +795,37 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// checkWritableDir checks that the files could be created in dir by creating and removing a temporary file.
+// dir is created if it doesn't exist.
+func checkWritableDir(dir string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	file, err := ioutil.TempFile(dir, ".write_check")
+	if err != nil {
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Remove(file.Name())
+}
+
+// parseTrustedProxy parses the trusted proxy which is an IP address (i.e. "10.0.0.1") or a CIDR (i.e. "10.0.0.0/8")
+func parseTrustedProxy(proxy string) (*net.IPNet, error) {
+	if strings.Contains(proxy, "/") {
+		_, ipNet, err := net.ParseCIDR(proxy)
+		return ipNet, err
+	}
+	ip := net.ParseIP(proxy)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address: %s", proxy)
+	}
+	bits := 8 * net.IPv6len
+	if ip.To4() != nil {
+		ip = ip.To4()
+		bits = 8 * net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
@@ -19,18 +19,41 @@ import (
 	playground "beam.apache.org/playground/backend/internal/api/v1"
 	"fmt"
 	"io/fs"
+	"net"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 const (
-	javaConfig = "{\n  \"compile_cmd\": \"javac\",\n  \"run_cmd\": \"java\",\n  \"test_cmd\": \"java\",\n  \"compile_args\": [\n    \"-d\",\n    \"bin\",\n    \"-classpath\"\n  ],\n  \"run_args\": [\n    \"-cp\",\n    \"bin:\"\n  ],\n  \"test_args\": [\n    \"-cp\",\n    \"bin:\",\n    \"JUnit\"\n  ]\n}"
-	jarsPath   = "/opt/apache/beam/jars/*"
+	javaConfig   = "{\n  \"compile_cmd\": \"javac\",\n  \"run_cmd\": \"java\",\n  \"test_cmd\": \"java\",\n  \"compile_args\": [\n    \"-d\",\n    \"bin\",\n    \"-classpath\"\n  ],\n  \"run_args\": [\n    \"-cp\",\n    \"bin:\"\n  ],\n  \"test_args\": [\n    \"-cp\",\n    \"bin:\",\n    \"JUnit\"\n  ]\n}"
+	jarsPath     = "/opt/apache/beam/jars/*"
+	scioConfig   = "{\n  \"compile_cmd\": \"scalac\",\n  \"run_cmd\": \"scala\",\n  \"test_cmd\": \"scala\",\n  \"compile_args\": [\n    \"-d\",\n    \"bin\",\n    \"-classpath\"\n  ],\n  \"run_args\": [\n    \"-cp\",\n    \"bin:\"\n  ],\n  \"test_args\": [\n    \"-cp\",\n    \"bin:\",\n    \"org.scalatest.tools.Runner\",\n    \"-o\",\n    \"-s\"\n  ]\n}"
+	scioJarsPath = "/opt/scio/jars/*"
+	kotlinConfig = "{\n  \"compile_cmd\": \"kotlinc\",\n  \"run_cmd\": \"java\",\n  \"test_cmd\": \"java\",\n  \"compile_args\": [\n    \"-d\",\n    \"bin\",\n    \"-classpath\"\n  ],\n  \"run_args\": [\n    \"-cp\",\n    \"bin:\"\n  ],\n  \"test_args\": [\n    \"-cp\",\n    \"bin:\",\n    \"JUnit\"\n  ]\n}"
+	kotlinStdlib = "/opt/kotlinc/lib/kotlin-stdlib.jar"
+
+	runTimeoutConfigName          = "run_timeout.json"
+	runTimeoutConfig              = "{\n  \"compile_cmd\": \"javac\",\n  \"run_cmd\": \"java\",\n  \"run_timeout\": \"30s\"\n}"
+	incorrectRunTimeoutConfigName = "incorrect_run_timeout.json"
+	incorrectRunTimeoutConfig     = "{\n  \"compile_cmd\": \"javac\",\n  \"run_cmd\": \"java\",\n  \"run_timeout\": \"30\"\n}"
+	dependenciesConfigName        = "dependencies.json"
+	dependenciesConfig            = "{\n  \"compile_cmd\": \"javac\",\n  \"run_cmd\": \"java\",\n  \"test_cmd\": \"java\",\n  \"compile_args\": [\n    \"-d\",\n    \"bin\",\n    \"-classpath\"\n  ],\n  \"run_args\": [\n    \"-cp\",\n    \"bin:\"\n  ],\n  \"test_args\": [\n    \"-cp\",\n    \"bin:\",\n    \"JUnit\"\n  ],\n  \"dependencies\": {\n    \"org.apache.beam:beam-sdks-java-io-kafka:2.35.0\": \"/opt/deps/kafka/*\",\n    \"com.google.guava:guava:31.0.1-jre\": \"/opt/deps/guava.jar\"\n  }\n}"
+)
+
+const (
+	compileTimeoutConfigName          = "compile_timeout.json"
+	compileTimeoutConfig              = "{\n  \"compile_cmd\": \"javac\",\n  \"run_cmd\": \"java\",\n  \"compile_timeout\": \"1m\"\n}"
+	incorrectCompileTimeoutConfigName = "incorrect_compile_timeout.json"
+	incorrectCompileTimeoutConfig     = "{\n  \"compile_cmd\": \"javac\",\n  \"run_cmd\": \"java\",\n  \"compile_timeout\": \"1\"\n}"
 )
 
 var executorConfig *ExecutorConfig
+var scioExecutorConfig *ExecutorConfig
+var kotlinExecutorConfig *ExecutorConfig
 
 func TestMain(m *testing.M) {
 	err := setup()
@@ -51,6 +74,34 @@ func setup() error {
 	if err != nil {
 		return err
 	}
+	err = os.WriteFile(filepath.Join(configFolderName, playground.Sdk_SDK_SCIO.String()+jsonExt), []byte(scioConfig), 0600)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(configFolderName, playground.Sdk_SDK_KOTLIN.String()+jsonExt), []byte(kotlinConfig), 0600)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(configFolderName, runTimeoutConfigName), []byte(runTimeoutConfig), 0600)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(configFolderName, incorrectRunTimeoutConfigName), []byte(incorrectRunTimeoutConfig), 0600)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(configFolderName, dependenciesConfigName), []byte(dependenciesConfig), 0600)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(configFolderName, compileTimeoutConfigName), []byte(compileTimeoutConfig), 0600)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(configFolderName, incorrectCompileTimeoutConfigName), []byte(incorrectCompileTimeoutConfig), 0600)
+	if err != nil {
+		return err
+	}
 	os.Clearenv()
 
 	executorConfig = NewExecutorConfig(
@@ -59,6 +110,18 @@ func setup() error {
 		[]string{"-cp", "bin:" + jarsPath},
 		[]string{"-cp", "bin:" + jarsPath, "JUnit"},
 	)
+	scioExecutorConfig = NewExecutorConfig(
+		"scalac", "scala", "scala",
+		[]string{"-d", "bin", "-classpath", jarsPath + ":" + scioJarsPath},
+		[]string{"-cp", "bin:" + jarsPath + ":" + scioJarsPath},
+		[]string{"-cp", "bin:" + jarsPath + ":" + scioJarsPath, "org.scalatest.tools.Runner", "-o", "-s"},
+	)
+	kotlinExecutorConfig = NewExecutorConfig(
+		"kotlinc", "java", "java",
+		[]string{"-d", "bin", "-classpath", jarsPath + ":" + kotlinStdlib},
+		[]string{"-cp", "bin:" + jarsPath + ":" + kotlinStdlib},
+		[]string{"-cp", "bin:" + jarsPath + ":" + kotlinStdlib, "JUnit"},
+	)
 	return nil
 }
 
@@ -79,6 +142,34 @@ func setOsEnvs(envsToSet map[string]string) error {
 	return nil
 }
 
+// applicationOptions returns the default options of the application changed by update
+func applicationOptions(update func(options *ApplicationOptions)) ApplicationOptions {
+	options := ApplicationOptions{
+		PipelineExecuteTimeout:    defaultPipelineExecuteTimeout,
+		PipelineMemoryLimit:       defaultPipelineMemoryLimit,
+		MaxConcurrentPipelines:    defaultMaxConcurrentPipelines,
+		MaxOutputSize:             defaultMaxOutputSize,
+		ExamplesRefreshInterval:   defaultExamplesRefreshInterval,
+		PipelineCpuTimeLimit:      defaultPipelineCpuTimeLimit,
+		PipelineCancelGracePeriod: defaultCancelGracePeriod,
+		MaxSourceSize:             defaultMaxSourceSize,
+		PipelineStartRetries:      defaultStartRetries,
+		PipelineRetryBackoff:      defaultRetryBackoff,
+		MaxCompileOutputSize:      defaultMaxCompileOutputSize,
+		RateLimitPerMinute:        defaultRateLimitPerMinute,
+		RateLimitBurst:            defaultRateLimitBurst,
+		JvmWarmPoolSize:           defaultJvmWarmPoolSize,
+		PipelineDiskQuota:         defaultPipelineDiskQuota,
+		CompileCacheSize:          defaultCompileCacheSize,
+		MaxPipelinesPerClient:     defaultMaxPipelinesPerClient,
+		OutputPathOptions:         defaultOutputPathOptions,
+	}
+	if update != nil {
+		update(&options)
+	}
+	return options
+}
+
 func TestNewEnvironment(t *testing.T) {
 	executorConfig := NewExecutorConfig("javac", "java", "java", []string{""}, []string{""}, []string{""})
 	preparedModDir := ""
@@ -88,16 +179,16 @@ func TestNewEnvironment(t *testing.T) {
 	}{
 		{name: "create env service with default envs", want: &Environment{
 			NetworkEnvs:     *NewNetworkEnvs(defaultIp, defaultPort, defaultProtocol),
-			BeamSdkEnvs:     *NewBeamEnvs(defaultSdk, executorConfig, preparedModDir),
-			ApplicationEnvs: *NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, defaultPipelineExecuteTimeout),
+			BeamSdkEnvs:     map[playground.Sdk]*BeamEnvs{defaultSdk: NewBeamEnvs(defaultSdk, executorConfig, preparedModDir)},
+			ApplicationEnvs: *NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)),
 		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if got := NewEnvironment(
 				*NewNetworkEnvs(defaultIp, defaultPort, defaultProtocol),
-				*NewBeamEnvs(defaultSdk, executorConfig, preparedModDir),
-				*NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, defaultPipelineExecuteTimeout)); !reflect.DeepEqual(got, tt.want) {
+				map[playground.Sdk]*BeamEnvs{defaultSdk: NewBeamEnvs(defaultSdk, executorConfig, preparedModDir)},
+				*NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil))); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewEnvironment() = %v, want %v", got, tt.want)
 			}
 		})
@@ -109,25 +200,29 @@ func Test_getSdkEnvsFromOsEnvs(t *testing.T) {
 	preparedModDir := ""
 	tests := []struct {
 		name      string
-		want      *BeamEnvs
+		want      map[playground.Sdk]*BeamEnvs
 		envsToSet map[string]string
 		wantErr   bool
 	}{
 		{
-			name:      "not specified beam sdk key in os envs",
-			want:      nil,
+			name: "not specified beam sdk key in os envs, all sdks with config files are configured",
+			want: map[playground.Sdk]*BeamEnvs{
+				defaultSdk:                NewBeamEnvs(defaultSdk, executorConfig, preparedModDir),
+				playground.Sdk_SDK_SCIO:   NewBeamEnvs(playground.Sdk_SDK_SCIO, scioExecutorConfig, preparedModDir),
+				playground.Sdk_SDK_KOTLIN: NewBeamEnvs(playground.Sdk_SDK_KOTLIN, kotlinExecutorConfig, preparedModDir),
+			},
 			envsToSet: map[string]string{},
-			wantErr:   true,
+			wantErr:   false,
 		},
 		{
 			name:      "default beam envs",
-			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir),
+			want:      map[playground.Sdk]*BeamEnvs{defaultSdk: NewBeamEnvs(defaultSdk, executorConfig, preparedModDir)},
 			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA"},
 			wantErr:   false,
 		},
 		{
 			name:      "specific sdk key in os envs",
-			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir),
+			want:      map[playground.Sdk]*BeamEnvs{defaultSdk: NewBeamEnvs(defaultSdk, executorConfig, preparedModDir)},
 			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA"},
 			wantErr:   false,
 		},
@@ -137,9 +232,37 @@ func Test_getSdkEnvsFromOsEnvs(t *testing.T) {
 			envsToSet: map[string]string{beamSdkKey: "SDK_J"},
 			wantErr:   true,
 		},
+		{
+			name:      "scio sdk key in os envs",
+			want:      map[playground.Sdk]*BeamEnvs{playground.Sdk_SDK_SCIO: NewBeamEnvs(playground.Sdk_SDK_SCIO, scioExecutorConfig, preparedModDir)},
+			envsToSet: map[string]string{beamSdkKey: "SDK_SCIO"},
+			wantErr:   false,
+		},
+		{
+			name:      "kotlin sdk key in os envs",
+			want:      map[playground.Sdk]*BeamEnvs{playground.Sdk_SDK_KOTLIN: NewBeamEnvs(playground.Sdk_SDK_KOTLIN, kotlinExecutorConfig, preparedModDir)},
+			envsToSet: map[string]string{beamSdkKey: "SDK_KOTLIN"},
+			wantErr:   false,
+		},
+		{
+			name: "several sdk keys in os envs",
+			want: map[playground.Sdk]*BeamEnvs{
+				defaultSdk:                NewBeamEnvs(defaultSdk, executorConfig, preparedModDir),
+				playground.Sdk_SDK_KOTLIN: NewBeamEnvs(playground.Sdk_SDK_KOTLIN, kotlinExecutorConfig, preparedModDir),
+			},
+			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA, SDK_KOTLIN"},
+			wantErr:   false,
+		},
+		{
+			name:      "several sdk keys with wrong one in os envs",
+			want:      nil,
+			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA,SDK_J"},
+			wantErr:   true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
 			if err := setOsEnvs(tt.envsToSet); err != nil {
 				t.Fatalf("couldn't setup os env")
 			}
@@ -156,6 +279,144 @@ func Test_getSdkEnvsFromOsEnvs(t *testing.T) {
 	os.Clearenv()
 }
 
+func Test_getSdkEnvsFromOsEnvsWithoutConfigs(t *testing.T) {
+	os.Clearenv()
+	got, err := ConfigureBeamEnvs(t.TempDir())
+	if err == nil {
+		t.Errorf("getSdkEnvsFromOsEnvs() got = %v, want error", got)
+	}
+}
+
+func TestEnvironment_SortedBeamSdkEnvs(t *testing.T) {
+	javaEnvs := NewBeamEnvs(playground.Sdk_SDK_JAVA, executorConfig, "")
+	pythonEnvs := NewBeamEnvs(playground.Sdk_SDK_PYTHON, executorConfig, "")
+	goEnvs := NewBeamEnvs(playground.Sdk_SDK_GO, executorConfig, "")
+	env := NewEnvironment(NetworkEnvs{}, map[playground.Sdk]*BeamEnvs{
+		playground.Sdk_SDK_PYTHON: pythonEnvs,
+		playground.Sdk_SDK_JAVA:   javaEnvs,
+		playground.Sdk_SDK_GO:     goEnvs,
+	}, ApplicationEnvs{})
+	if got, want := env.SortedBeamSdkEnvs(), []*BeamEnvs{javaEnvs, goEnvs, pythonEnvs}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedBeamSdkEnvs() got = %v, want %v", got, want)
+	}
+	if got, ok := env.GetBeamSdkEnvs(playground.Sdk_SDK_GO); !ok || got != goEnvs {
+		t.Errorf("GetBeamSdkEnvs() got = %v, %v, want %v, true", got, ok, goEnvs)
+	}
+	if got, ok := env.GetBeamSdkEnvs(playground.Sdk_SDK_SCIO); ok {
+		t.Errorf("GetBeamSdkEnvs() got = %v, %v, want nil, false", got, ok)
+	}
+}
+
+func Test_getSdkEnvsFromOsEnvsWithIncorrectConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		sdk         playground.Sdk
+		config      string
+		wantErrPart string
+	}{
+		{
+			// Test case with calling ConfigureBeamEnvs method without the config file of the SDK.
+			// As a result, want to receive an error which contains the SDK and the missing file.
+			name:        "missing config file",
+			sdk:         playground.Sdk_SDK_PYTHON,
+			wantErrPart: "isn't found",
+		},
+		{
+			// Test case with calling ConfigureBeamEnvs method with the config file which contains incorrect json.
+			// As a result, want to receive an error which contains the SDK and the parse problem.
+			name:        "invalid json",
+			sdk:         playground.Sdk_SDK_PYTHON,
+			config:      "{\n  \"run_cmd\": \"python3\",\n",
+			wantErrPart: "incorrect json",
+		},
+		{
+			// Test case with calling ConfigureBeamEnvs method with the config file without run_cmd.
+			// As a result, want to receive an error which contains the SDK and the missing key.
+			name:        "missing run_cmd",
+			sdk:         playground.Sdk_SDK_PYTHON,
+			config:      "{\n  \"compile_cmd\": \"\"\n}",
+			wantErrPart: "run_cmd is missing",
+		},
+		{
+			// Test case with calling ConfigureBeamEnvs method with the config file without compile_cmd.
+			// As a result, want to receive an error which contains the SDK and the missing key.
+			name:        "missing compile_cmd",
+			sdk:         playground.Sdk_SDK_JAVA,
+			config:      "{\n  \"run_cmd\": \"java\",\n  \"run_args\": [\"-cp\", \"bin:\"],\n  \"test_args\": [\"-cp\", \"bin:\"]\n}",
+			wantErrPart: "compile_cmd is missing",
+		},
+		{
+			// Test case with calling ConfigureBeamEnvs method with the config file of Java SDK without the classpath in run_args.
+			// As a result, want to receive an error which contains the SDK and the incorrect key.
+			name:        "missing classpath in run_args",
+			sdk:         playground.Sdk_SDK_JAVA,
+			config:      "{\n  \"compile_cmd\": \"javac\",\n  \"run_cmd\": \"java\",\n  \"test_args\": [\"-cp\", \"bin:\"]\n}",
+			wantErrPart: "run_args should contain the classpath",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(workingDir, configFolderName), fs.ModePerm); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			if tt.config != "" {
+				if err := os.WriteFile(filepath.Join(workingDir, configFolderName, tt.sdk.String()+jsonExt), []byte(tt.config), 0600); err != nil {
+					t.Fatalf("error during write config file: %s", err.Error())
+				}
+			}
+			if err := setOsEnvs(map[string]string{beamSdkKey: tt.sdk.String()}); err != nil {
+				t.Fatalf("couldn't setup os env")
+			}
+			got, err := ConfigureBeamEnvs(workingDir)
+			if err == nil {
+				t.Fatalf("ConfigureBeamEnvs() got = %v, want error", got)
+			}
+			if !strings.Contains(err.Error(), tt.sdk.String()) || !strings.Contains(err.Error(), tt.wantErrPart) {
+				t.Errorf("ConfigureBeamEnvs() error = %v, want error with %s and %s", err, tt.sdk, tt.wantErrPart)
+			}
+		})
+	}
+	os.Clearenv()
+}
+
+func Test_getSdkEnvsFromOsEnvsWithVersions(t *testing.T) {
+	workingDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(workingDir, configFolderName), fs.ModePerm); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	oldJarsPath := "/opt/apache/beam-2.50/jars/*"
+	oldConfig := "{\n  \"compile_cmd\": \"javac\",\n  \"run_cmd\": \"java\",\n  \"test_cmd\": \"java\",\n  \"compile_args\": [\"-classpath\"],\n  \"run_args\": [\"-cp\", \"bin:\"],\n  \"test_args\": [\"-cp\", \"bin:\"],\n  \"beam_path\": \"" + oldJarsPath + "\"\n}"
+	for name, config := range map[string]string{"SDK_JAVA-2.50.json": oldConfig, "SDK_JAVA-2.55.json": javaConfig} {
+		if err := os.WriteFile(filepath.Join(workingDir, configFolderName, name), []byte(config), 0600); err != nil {
+			t.Fatalf("error during write config: %s", err.Error())
+		}
+	}
+	if err := setOsEnvs(map[string]string{beamSdkKey: "SDK_JAVA"}); err != nil {
+		t.Fatalf("couldn't setup os env")
+	}
+	defer os.Clearenv()
+
+	sdkEnvs, err := ConfigureBeamEnvs(workingDir)
+	if err != nil {
+		t.Fatalf("getSdkEnvsFromOsEnvs() error = %v", err)
+	}
+	got := sdkEnvs[playground.Sdk_SDK_JAVA]
+	if !reflect.DeepEqual(got.Versions(), []string{"2.50", "2.55"}) {
+		t.Errorf("getSdkEnvsFromOsEnvs() versions = %v, want %v", got.Versions(), []string{"2.50", "2.55"})
+	}
+	if got.Version != "2.55" || !reflect.DeepEqual(got.ExecutorConfig, executorConfig) {
+		t.Errorf("getSdkEnvsFromOsEnvs() default version = %v with config %v, want %v with config %v", got.Version, got.ExecutorConfig, "2.55", executorConfig)
+	}
+	oldEnvs, err := got.ForVersion("2.50")
+	if err != nil {
+		t.Fatalf("ForVersion() error = %v", err)
+	}
+	if wantArgs := []string{"-cp", "bin:" + oldJarsPath}; !reflect.DeepEqual(oldEnvs.ExecutorConfig.RunArgs, wantArgs) {
+		t.Errorf("ForVersion() run args = %v, want %v", oldEnvs.ExecutorConfig.RunArgs, wantArgs)
+	}
+}
+
 func Test_getNetworkEnvsFromOsEnvs(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -198,14 +459,95 @@ func Test_getNetworkEnvsFromOsEnvs(t *testing.T) {
 }
 
 func Test_getApplicationEnvsFromOsEnvs(t *testing.T) {
+	executionRoot := filepath.Join(t.TempDir(), "execution_root")
 	tests := []struct {
 		name      string
 		want      *ApplicationEnvs
 		wantErr   bool
 		envsToSet map[string]string
 	}{
-		{name: "working dir is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, defaultPipelineExecuteTimeout), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app"}},
+		{name: "working dir is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app"}},
 		{name: "working dir isn't provided", want: nil, wantErr: true},
+		{name: "pipeline memory limit is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.PipelineMemoryLimit = 512 })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineMemoryLimitKey: "512"}},
+		{name: "incorrect pipeline memory limit, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineMemoryLimitKey: "512MB"}},
+		{name: "pipeline cpu time limit is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.PipelineCpuTimeLimit = 30 })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineCpuTimeLimitKey: "30"}},
+		{name: "incorrect pipeline cpu time limit, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineCpuTimeLimitKey: "-30"}},
+		{name: "source url allowed hosts are provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) {
+			options.SourceUrlAllowedHosts = []string{"github.com", "raw.githubusercontent.com"}
+		})), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", sourceUrlAllowedHostsKey: "github.com, raw.githubusercontent.com,"}},
+		{name: "max concurrent pipelines is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.MaxConcurrentPipelines = 4 })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", maxConcurrentPipelinesKey: "4"}},
+		{name: "incorrect max concurrent pipelines, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", maxConcurrentPipelinesKey: "-1"}},
+		{name: "max output size is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.MaxOutputSize = 1048576 })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", maxOutputSizeKey: "1048576"}},
+		{name: "incorrect max output size, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", maxOutputSizeKey: "1MB"}},
+		{name: "max compile output size is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.MaxCompileOutputSize = 1048576 })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", maxCompileOutputSizeKey: "1048576"}},
+		{name: "incorrect max compile output size, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", maxCompileOutputSizeKey: "1MB"}},
+		{name: "rate limit is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.RateLimitPerMinute = 60; options.RateLimitBurst = 10 })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", rateLimitPerMinuteKey: "60", rateLimitBurstKey: "10"}},
+		{name: "incorrect rate limit, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", rateLimitPerMinuteKey: "-1", rateLimitBurstKey: "ten"}},
+		{name: "jvm warm pool size is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.JvmWarmPoolSize = 2 })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", jvmWarmPoolSizeKey: "2"}},
+		{name: "incorrect jvm warm pool size, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", jvmWarmPoolSizeKey: "-2"}},
+		{name: "pipeline disk quota is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.PipelineDiskQuota = 100 })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineDiskQuotaKey: "100"}},
+		{name: "incorrect pipeline disk quota, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineDiskQuotaKey: "-100"}},
+		{name: "compile cache size is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.CompileCacheSize = 50 })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", compileCacheSizeKey: "50"}},
+		{name: "incorrect compile cache size, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", compileCacheSizeKey: "-50"}},
+		{name: "max pipelines per client is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.MaxPipelinesPerClient = 2 })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", maxPipelinesPerClientKey: "2"}},
+		{name: "incorrect max pipelines per client, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", maxPipelinesPerClientKey: "-2"}},
+		{name: "execution root is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.ExecutionRoot = executionRoot })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", executionRootKey: executionRoot}},
+		{name: "execution root isn't writable", want: nil, wantErr: true, envsToSet: map[string]string{workingDirKey: "/app", executionRootKey: "/dev/null/execution_root"}},
+		{name: "cache max pipelines is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, 1000, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", cacheMaxPipelinesKey: "1000"}},
+		{name: "cache idle timeout is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, time.Hour, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", cacheIdleTimeoutKey: "1h"}},
+		{name: "incorrect cache idle timeout, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", cacheIdleTimeoutKey: "-1h"}},
+		{name: "cache max output bytes is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, 1048576, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", cacheMaxOutputBytesKey: "1048576"}},
+		{name: "incorrect cache max output bytes, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", cacheMaxOutputBytesKey: "-1"}},
+		{name: "cache encryption key is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, []byte("0123456789abcdef")}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", cacheEncryptionKeyKey: "MDEyMzQ1Njc4OWFiY2RlZg=="}},
+		{name: "incorrect cache encryption key", want: nil, wantErr: true, envsToSet: map[string]string{workingDirKey: "/app", cacheEncryptionKeyKey: "not a base64 key"}},
+		{name: "cache encryption key of incorrect length", want: nil, wantErr: true, envsToSet: map[string]string{workingDirKey: "/app", cacheEncryptionKeyKey: "MDEyMzQ1Njc4OQ=="}},
+		{name: "incorrect cache max pipelines, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", cacheMaxPipelinesKey: "-5"}},
+		{name: "examples dir and refresh interval are provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) {
+			options.ExamplesDir = "/examples"
+			options.ExamplesRefreshInterval = time.Minute
+		})), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", examplesDirKey: "/examples", examplesRefreshIntervalKey: "1m"}},
+		{name: "incorrect examples refresh interval, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", examplesRefreshIntervalKey: "0s"}},
+		{name: "network isolation is enabled", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.NetworkIsolation = true })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineNetworkIsolationKey: "true"}},
+		{name: "keep pipeline files is enabled", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.KeepPipelineFiles = true })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", keepPipelineFilesKey: "true"}},
+		{name: "debug mode is enabled", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.DebugMode = true })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", debugModeKey: "true"}},
+		{name: "incorrect debug mode, should be disabled", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", debugModeKey: "yes please"}},
+		{name: "spill run output is enabled", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.SpillRunOutput = true })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", spillRunOutputKey: "true"}},
+		{name: "incorrect spill run output, should be disabled", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", spillRunOutputKey: "maybe"}},
+		{name: "run output flush policy is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) {
+			options.RunOutputFlushLines = 100
+			options.RunOutputFlushInterval = 50 * time.Millisecond
+		})), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", runOutputFlushLinesKey: "100", runOutputFlushIntervalKey: "50ms"}},
+		{name: "incorrect run output flush policy, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) {
+			options.RunOutputFlushLines = defaultRunOutputFlushLines
+			options.RunOutputFlushInterval = defaultRunOutputFlushInterval
+		})), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", runOutputFlushLinesKey: "-1", runOutputFlushIntervalKey: "soon"}},
+		{name: "output path options and allowed output paths are provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) {
+			options.OutputPathOptions = []string{"output", "temp_location"}
+			options.AllowedOutputPaths = []string{"/tmp/out"}
+		})), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", outputPathOptionsKey: "--output, temp_location,", allowedOutputPathsKey: "/tmp/out/, relative/out"}},
+		{name: "empty output path options, the paths of the output aren't checked", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.OutputPathOptions = nil })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", outputPathOptionsKey: ""}},
+		{name: "websocket origin patterns are provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) {
+			options.WebSocketOriginPatterns = []string{"playground.example.com", "*.example.org"}
+		})), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", webSocketOriginPatternsKey: "playground.example.com, *.example.org,"}},
+		{name: "trusted proxies are provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) {
+			options.TrustedProxies = []*net.IPNet{
+				{IP: net.IP{10, 0, 0, 0}, Mask: net.CIDRMask(8, 32)},
+				{IP: net.IP{192, 168, 0, 1}, Mask: net.CIDRMask(32, 32)},
+				{IP: net.ParseIP("2001:db8::1"), Mask: net.CIDRMask(128, 128)},
+			}
+		})), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", trustedProxiesKey: "10.0.0.0/8, 192.168.0.1, 2001:db8::1, not-an-ip,"}},
+		{name: "admin token is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.AdminToken = "MOCK_ADMIN_TOKEN" })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", adminTokenKey: "MOCK_ADMIN_TOKEN"}},
+		{name: "archive location is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.ArchiveLocation = "gs://playground-archive/results" })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", archiveLocationKey: "gs://playground-archive/results"}},
+		{name: "pipeline cancel grace period is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.PipelineCancelGracePeriod = time.Second })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineCancelGracePeriodKey: "1s"}},
+		{name: "incorrect pipeline cancel grace period, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineCancelGracePeriodKey: "-1s"}},
+		{name: "max source size is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.MaxSourceSize = 1048576 })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", maxSourceSizeKey: "1048576"}},
+		{name: "incorrect max source size, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", maxSourceSizeKey: "1MB"}},
+		{name: "pipeline start retries are provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.PipelineStartRetries = 2 })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineStartRetriesKey: "2"}},
+		{name: "incorrect pipeline start retries, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineStartRetriesKey: "-2"}},
+		{name: "pipeline start retry backoff is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.PipelineRetryBackoff = 500 * time.Millisecond })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineRetryBackoffKey: "500ms"}},
+		{name: "incorrect pipeline start retry backoff, should be default", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineRetryBackoffKey: "fast"}},
+		{name: "incorrect network isolation, should be disabled", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(nil)), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineNetworkIsolationKey: "enabled"}},
+		{name: "sandbox command is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime, defaultCacheMaxPipelines, defaultCacheIdleTimeout, defaultCacheMaxOutputBytes, nil}, applicationOptions(func(options *ApplicationOptions) { options.SandboxCmd = []string{"firejail", "--net=none"} })), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", pipelineSandboxCmdKey: "firejail  --net=none"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -244,6 +586,18 @@ func Test_createExecutorConfig(t *testing.T) {
 			want:    executorConfig,
 			wantErr: false,
 		},
+		{
+			name:    "create executor configuration for scio from json file",
+			args:    args{apacheBeamSdk: playground.Sdk_SDK_SCIO, configPath: filepath.Join(configFolderName, playground.Sdk_SDK_SCIO.String()+jsonExt)},
+			want:    scioExecutorConfig,
+			wantErr: false,
+		},
+		{
+			name:    "create executor configuration for kotlin from json file",
+			args:    args{apacheBeamSdk: playground.Sdk_SDK_KOTLIN, configPath: filepath.Join(configFolderName, playground.Sdk_SDK_KOTLIN.String()+jsonExt)},
+			want:    kotlinExecutorConfig,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -259,6 +613,72 @@ func Test_createExecutorConfig(t *testing.T) {
 	}
 }
 
+func Test_createExecutorConfigWithDependencies(t *testing.T) {
+	configPath := filepath.Join(configFolderName, dependenciesConfigName)
+	tests := []struct {
+		name             string
+		dependencies     string
+		wantClasspath    string
+		wantDependencies map[string]string
+		wantErr          bool
+	}{
+		{
+			// Test case with creating executor configuration without requested dependencies.
+			// As a result, want to receive the classpath with Apache Beam jars only.
+			name:             "without dependencies",
+			dependencies:     "",
+			wantClasspath:    jarsPath,
+			wantDependencies: nil,
+			wantErr:          false,
+		},
+		{
+			// Test case with creating executor configuration with dependencies requested by coordinate and by path.
+			// As a result, want to receive the classpath with paths of the dependencies after Apache Beam jars
+			//	and the dependencies by their coordinates.
+			name:          "allowlisted dependencies",
+			dependencies:  "org.apache.beam:beam-sdks-java-io-kafka:2.35.0, /opt/deps/guava.jar",
+			wantClasspath: jarsPath + ":/opt/deps/kafka/*:/opt/deps/guava.jar",
+			wantDependencies: map[string]string{
+				"org.apache.beam:beam-sdks-java-io-kafka:2.35.0": "/opt/deps/kafka/*",
+				"com.google.guava:guava:31.0.1-jre":              "/opt/deps/guava.jar",
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with creating executor configuration with dependency which isn't allowlisted.
+			// As a result, want to receive an error.
+			name:         "dependency isn't allowlisted",
+			dependencies: "/tmp/unknown.jar",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.Setenv(classpathDependenciesKey, tt.dependencies); err != nil {
+				t.Fatalf("error during set env: %s", err.Error())
+			}
+			defer os.Unsetenv(classpathDependenciesKey)
+			got, err := createExecutorConfig(playground.Sdk_SDK_JAVA, configPath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("createExecutorConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if wantArgs := []string{"-d", "bin", "-classpath", tt.wantClasspath}; !reflect.DeepEqual(got.CompileArgs, wantArgs) {
+				t.Errorf("createExecutorConfig() compile args = %v, want %v", got.CompileArgs, wantArgs)
+			}
+			if wantArg := "bin:" + tt.wantClasspath; got.RunArgs[1] != wantArg {
+				t.Errorf("createExecutorConfig() run classpath = %v, want %v", got.RunArgs[1], wantArg)
+			}
+			if !reflect.DeepEqual(got.ClasspathDependencies, tt.wantDependencies) {
+				t.Errorf("createExecutorConfig() classpath dependencies = %v, want %v", got.ClasspathDependencies, tt.wantDependencies)
+			}
+		})
+	}
+}
+
 func Test_getConfigFromJson(t *testing.T) {
 	type args struct {
 		configPath string
@@ -275,6 +695,30 @@ func Test_getConfigFromJson(t *testing.T) {
 			want:    NewExecutorConfig("javac", "java", "java", []string{"-d", "bin", "-classpath"}, []string{"-cp", "bin:"}, []string{"-cp", "bin:", "JUnit"}),
 			wantErr: false,
 		},
+		{
+			name:    "get object with run timeout from json",
+			args:    args{filepath.Join(configFolderName, runTimeoutConfigName)},
+			want:    &ExecutorConfig{CompileCmd: "javac", RunCmd: "java", RunTimeout: "30s"},
+			wantErr: false,
+		},
+		{
+			name:    "error if incorrect run timeout",
+			args:    args{filepath.Join(configFolderName, incorrectRunTimeoutConfigName)},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "get object with compile timeout from json",
+			args:    args{filepath.Join(configFolderName, compileTimeoutConfigName)},
+			want:    &ExecutorConfig{CompileCmd: "javac", RunCmd: "java", CompileTimeout: "1m"},
+			wantErr: false,
+		},
+		{
+			name:    "error if incorrect compile timeout",
+			args:    args{filepath.Join(configFolderName, incorrectCompileTimeoutConfigName)},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "error if wrong json path",
 			args:    args{filepath.Join("wrong_folder", defaultSdk.String()+jsonExt)},
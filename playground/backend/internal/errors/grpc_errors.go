@@ -38,3 +38,21 @@ func InternalError(title string, formatMessage string, args ...interface{}) erro
 	message := fmt.Sprintf(formatMessage, args...)
 	return status.Errorf(codes.Internal, "%s: %s", title, message)
 }
+
+// ResourceExhaustedError returns error with ResourceExhausted code error and message like "title: message"
+func ResourceExhaustedError(title string, formatMessage string, args ...interface{}) error {
+	message := fmt.Sprintf(formatMessage, args...)
+	return status.Errorf(codes.ResourceExhausted, "%s: %s", title, message)
+}
+
+// PermissionDeniedError returns error with PermissionDenied code error and message like "title: message"
+func PermissionDeniedError(title string, formatMessage string, args ...interface{}) error {
+	message := fmt.Sprintf(formatMessage, args...)
+	return status.Errorf(codes.PermissionDenied, "%s: %s", title, message)
+}
+
+// FailedPreconditionError returns error with FailedPrecondition code error and message like "title: message"
+func FailedPreconditionError(title string, formatMessage string, args ...interface{}) error {
+	message := fmt.Sprintf(formatMessage, args...)
+	return status.Errorf(codes.FailedPrecondition, "%s: %s", title, message)
+}
@@ -0,0 +1,249 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package examples
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	metaInfoName    = "meta.info"
+	javaExtension   = "java"
+	goExtension     = "go"
+	pyExtension     = "py"
+	scioExtension   = "scala"
+	kotlinExtension = "kt"
+)
+
+// catalogId is a key of the scanned examples in the cache
+var catalogId = uuid.NewSHA1(uuid.NameSpaceURL, []byte("beam-playground-examples-catalog"))
+
+// ErrExampleNotFound is returned when the requested example isn't presented in the examples directory
+var ErrExampleNotFound = errors.New("example not found")
+
+// metaInfo is a content of the meta.info file of the example
+type metaInfo struct {
+	Description     string `json:"description,omitempty"`
+	PipelineOptions string `json:"pipeline_options,omitempty"`
+}
+
+// Catalog represents examples which are stored at the examples directory.
+// The examples directory has the same structure as the precompiled objects bucket, namely:
+// SDK_JAVA/
+// --------MinimalWordCount/
+// ----------- MinimalWordCount.java
+// ----------- meta.info
+// ----  ...
+// SDK_GO/
+// --------MinimalWordCount/
+// ----------- MinimalWordCount.go
+// ----------- meta.info
+// ...
+// meta.info is a json file that has the following fields:
+// {
+//	"description": "Description of an example",
+//	"pipeline_options": "--option value"
+// }
+// meta.info is optional, examples without it have empty description and pipeline options.
+// The scanned list of examples is kept in the cache and is invalidated after refreshInterval.
+type Catalog struct {
+	examplesDir     string
+	cacheService    cache.Cache
+	refreshInterval time.Duration
+}
+
+// New constructor for Catalog
+func New(examplesDir string, cacheService cache.Cache, refreshInterval time.Duration) *Catalog {
+	return &Catalog{
+		examplesDir:     examplesDir,
+		cacheService:    cacheService,
+		refreshInterval: refreshInterval,
+	}
+}
+
+// ListExamples returns examples for the sdk. All examples are returned if sdk is unspecified.
+func (c *Catalog) ListExamples(ctx context.Context, sdk pb.Sdk) ([]*pb.Example, error) {
+	catalog, err := c.getCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sdk == pb.Sdk_SDK_UNSPECIFIED {
+		return catalog, nil
+	}
+	examples := make([]*pb.Example, 0)
+	for _, example := range catalog {
+		if example.Sdk == sdk {
+			examples = append(examples, example)
+		}
+	}
+	return examples, nil
+}
+
+// GetExample returns the source code of the example.
+// In case the example isn't presented in the examples directory returns ErrExampleNotFound.
+func (c *Catalog) GetExample(ctx context.Context, sdk pb.Sdk, name string) (string, error) {
+	if _, err := c.findExample(ctx, sdk, name); err != nil {
+		return "", err
+	}
+	data, err := ioutil.ReadFile(c.sourcePath(sdk, name))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetPipelineOptions returns default pipeline options of the example.
+// In case the example isn't presented in the examples directory returns ErrExampleNotFound.
+func (c *Catalog) GetPipelineOptions(ctx context.Context, sdk pb.Sdk, name string) (string, error) {
+	example, err := c.findExample(ctx, sdk, name)
+	if err != nil {
+		return "", err
+	}
+	return example.PipelineOptions, nil
+}
+
+// findExample returns the example from the catalog by sdk and name.
+// In case the example isn't presented in the catalog returns ErrExampleNotFound.
+func (c *Catalog) findExample(ctx context.Context, sdk pb.Sdk, name string) (*pb.Example, error) {
+	catalog, err := c.getCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, example := range catalog {
+		if example.Sdk == sdk && example.Name == name {
+			return example, nil
+		}
+	}
+	return nil, ErrExampleNotFound
+}
+
+// getCatalog returns examples from the cache or scans the examples directory if they aren't presented in the cache
+func (c *Catalog) getCatalog(ctx context.Context) ([]*pb.Example, error) {
+	value, err := c.cacheService.GetValue(ctx, catalogId, cache.ExamplesCatalog)
+	if err == nil {
+		if catalog, ok := value.([]*pb.Example); ok {
+			return catalog, nil
+		}
+		logger.Errorf("Examples: value from cache has incorrect type: %T", value)
+	}
+
+	catalog, err := c.scan()
+	if err != nil {
+		return nil, err
+	}
+	if err = c.cacheService.SetValue(ctx, catalogId, cache.ExamplesCatalog, catalog); err != nil {
+		logger.Errorf("Examples: can't keep examples in the cache: %s", err.Error())
+		return catalog, nil
+	}
+	if err = c.cacheService.SetExpTime(ctx, catalogId, c.refreshInterval); err != nil {
+		logger.Errorf("Examples: can't set expiration time of the examples: %s", err.Error())
+	}
+	return catalog, nil
+}
+
+// scan reads all examples from the examples directory
+func (c *Catalog) scan() ([]*pb.Example, error) {
+	catalog := make([]*pb.Example, 0)
+	if c.examplesDir == "" {
+		return catalog, nil
+	}
+	for _, sdk := range []pb.Sdk{pb.Sdk_SDK_JAVA, pb.Sdk_SDK_GO, pb.Sdk_SDK_PYTHON, pb.Sdk_SDK_SCIO, pb.Sdk_SDK_KOTLIN} {
+		entries, err := ioutil.ReadDir(filepath.Join(c.examplesDir, sdk.String()))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			name := entry.Name()
+			if _, err := os.Stat(c.sourcePath(sdk, name)); err != nil {
+				logger.Warnf("Examples: %s/%s doesn't contain a source file, skipped", sdk.String(), name)
+				continue
+			}
+			info, err := readMetaInfo(filepath.Join(c.examplesDir, sdk.String(), name, metaInfoName))
+			if err != nil {
+				return nil, fmt.Errorf("can't read meta info of the %s/%s example: %s", sdk.String(), name, err.Error())
+			}
+			catalog = append(catalog, &pb.Example{
+				Name:            name,
+				Description:     info.Description,
+				Sdk:             sdk,
+				PipelineOptions: info.PipelineOptions,
+			})
+		}
+	}
+	sort.SliceStable(catalog, func(i, j int) bool {
+		if catalog[i].Sdk != catalog[j].Sdk {
+			return catalog[i].Sdk < catalog[j].Sdk
+		}
+		return catalog[i].Name < catalog[j].Name
+	})
+	return catalog, nil
+}
+
+// sourcePath returns the path to the source file of the example
+func (c *Catalog) sourcePath(sdk pb.Sdk, name string) string {
+	return filepath.Join(c.examplesDir, sdk.String(), name, name+"."+getFileExtensionBySdk(sdk))
+}
+
+// readMetaInfo reads meta.info file of the example. Missing meta.info file means empty meta info.
+func readMetaInfo(path string) (*metaInfo, error) {
+	info := &metaInfo{}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return info, nil
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(data, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// getFileExtensionBySdk returns extension of the source file for the sdk
+func getFileExtensionBySdk(sdk pb.Sdk) string {
+	switch sdk {
+	case pb.Sdk_SDK_JAVA:
+		return javaExtension
+	case pb.Sdk_SDK_GO:
+		return goExtension
+	case pb.Sdk_SDK_PYTHON:
+		return pyExtension
+	case pb.Sdk_SDK_SCIO:
+		return scioExtension
+	case pb.Sdk_SDK_KOTLIN:
+		return kotlinExtension
+	}
+	return ""
+}
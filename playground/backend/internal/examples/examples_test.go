@@ -0,0 +1,280 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package examples
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache/local"
+	"context"
+	"google.golang.org/protobuf/proto"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeExample(t *testing.T, examplesDir string, sdk pb.Sdk, name, code, meta string) {
+	exampleDir := filepath.Join(examplesDir, sdk.String(), name)
+	if err := os.MkdirAll(exampleDir, fs.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(exampleDir, name+"."+getFileExtensionBySdk(sdk)), []byte(code), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if meta != "" {
+		if err := os.WriteFile(filepath.Join(exampleDir, metaInfoName), []byte(meta), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCatalog_ListExamples(t *testing.T) {
+	examplesDir := t.TempDir()
+	writeExample(t, examplesDir, pb.Sdk_SDK_JAVA, "WordCount", "class WordCount {}", `{"description": "Word count", "pipeline_options": "--output out.txt"}`)
+	writeExample(t, examplesDir, pb.Sdk_SDK_JAVA, "JoinExamples", "class JoinExamples {}", "")
+	writeExample(t, examplesDir, pb.Sdk_SDK_GO, "PingPong", "package main", `{"description": "Ping pong"}`)
+	// directory without source file is skipped
+	if err := os.MkdirAll(filepath.Join(examplesDir, pb.Sdk_SDK_GO.String(), "Empty"), fs.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	joinExamples := &pb.Example{Name: "JoinExamples", Sdk: pb.Sdk_SDK_JAVA}
+	wordCount := &pb.Example{Name: "WordCount", Description: "Word count", Sdk: pb.Sdk_SDK_JAVA, PipelineOptions: "--output out.txt"}
+	pingPong := &pb.Example{Name: "PingPong", Description: "Ping pong", Sdk: pb.Sdk_SDK_GO}
+
+	tests := []struct {
+		name        string
+		examplesDir string
+		sdk         pb.Sdk
+		want        []*pb.Example
+		wantErr     bool
+	}{
+		{
+			// Test case with calling ListExamples without sdk.
+			// As a result, want to receive all examples sorted by sdk and name.
+			name:        "all examples",
+			examplesDir: examplesDir,
+			sdk:         pb.Sdk_SDK_UNSPECIFIED,
+			want:        []*pb.Example{joinExamples, wordCount, pingPong},
+			wantErr:     false,
+		},
+		{
+			// Test case with calling ListExamples with sdk.
+			// As a result, want to receive only examples of this sdk.
+			name:        "java examples",
+			examplesDir: examplesDir,
+			sdk:         pb.Sdk_SDK_JAVA,
+			want:        []*pb.Example{joinExamples, wordCount},
+			wantErr:     false,
+		},
+		{
+			// Test case with calling ListExamples with sdk which doesn't have examples directory.
+			// As a result, want to receive an empty list.
+			name:        "python examples",
+			examplesDir: examplesDir,
+			sdk:         pb.Sdk_SDK_PYTHON,
+			want:        []*pb.Example{},
+			wantErr:     false,
+		},
+		{
+			// Test case with calling ListExamples without configured examples directory.
+			// As a result, want to receive an empty list.
+			name:        "examples directory isn't configured",
+			examplesDir: "",
+			sdk:         pb.Sdk_SDK_UNSPECIFIED,
+			want:        []*pb.Example{},
+			wantErr:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			c := New(tt.examplesDir, local.New(ctx), time.Minute)
+			got, err := c.ListExamples(ctx, tt.sdk)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListExamples() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("ListExamples() got = %v, want %v", got, tt.want)
+				return
+			}
+			for i := range tt.want {
+				if !proto.Equal(got[i], tt.want[i]) {
+					t.Errorf("ListExamples() got = %v, want %v", got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCatalog_ListExamplesWithIncorrectMetaInfo(t *testing.T) {
+	examplesDir := t.TempDir()
+	writeExample(t, examplesDir, pb.Sdk_SDK_JAVA, "WordCount", "class WordCount {}", "not a json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := New(examplesDir, local.New(ctx), time.Minute)
+	if _, err := c.ListExamples(ctx, pb.Sdk_SDK_JAVA); err == nil {
+		t.Errorf("ListExamples() expected error for incorrect meta.info")
+	}
+}
+
+func TestCatalog_GetExample(t *testing.T) {
+	examplesDir := t.TempDir()
+	writeExample(t, examplesDir, pb.Sdk_SDK_JAVA, "WordCount", "class WordCount {}", "")
+	writeExample(t, examplesDir, pb.Sdk_SDK_PYTHON, "WordCount", "print('word count')", "")
+
+	tests := []struct {
+		name    string
+		sdk     pb.Sdk
+		exName  string
+		want    string
+		wantErr error
+	}{
+		{
+			// Test case with calling GetExample with existing example.
+			// As a result, want to receive the source code of the example.
+			name:   "java example",
+			sdk:    pb.Sdk_SDK_JAVA,
+			exName: "WordCount",
+			want:   "class WordCount {}",
+		},
+		{
+			// Test case with calling GetExample with the same example name for another sdk.
+			// As a result, want to receive the source code of the example of this sdk.
+			name:   "python example",
+			sdk:    pb.Sdk_SDK_PYTHON,
+			exName: "WordCount",
+			want:   "print('word count')",
+		},
+		{
+			// Test case with calling GetExample with example which doesn't exist.
+			// As a result, want to receive ErrExampleNotFound.
+			name:    "unknown example",
+			sdk:     pb.Sdk_SDK_GO,
+			exName:  "WordCount",
+			wantErr: ErrExampleNotFound,
+		},
+		{
+			// Test case with calling GetExample with path instead of the example name.
+			// As a result, want to receive ErrExampleNotFound.
+			name:    "path as example name",
+			sdk:     pb.Sdk_SDK_JAVA,
+			exName:  "../SDK_JAVA/WordCount",
+			wantErr: ErrExampleNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			c := New(examplesDir, local.New(ctx), time.Minute)
+			got, err := c.GetExample(ctx, tt.sdk, tt.exName)
+			if err != tt.wantErr {
+				t.Errorf("GetExample() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetExample() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalog_GetPipelineOptions(t *testing.T) {
+	examplesDir := t.TempDir()
+	writeExample(t, examplesDir, pb.Sdk_SDK_JAVA, "WordCount", "class WordCount {}", "{\"pipeline_options\": \"--output=/tmp/out\"}")
+	writeExample(t, examplesDir, pb.Sdk_SDK_JAVA, "MinimalWordCount", "class MinimalWordCount {}", "")
+
+	tests := []struct {
+		name    string
+		sdk     pb.Sdk
+		exName  string
+		want    string
+		wantErr error
+	}{
+		{
+			// Test case with calling GetPipelineOptions with example which has pipeline options in meta.info.
+			// As a result, want to receive pipeline options of the example.
+			name:   "example with pipeline options",
+			sdk:    pb.Sdk_SDK_JAVA,
+			exName: "WordCount",
+			want:   "--output=/tmp/out",
+		},
+		{
+			// Test case with calling GetPipelineOptions with example without meta.info.
+			// As a result, want to receive empty pipeline options.
+			name:   "example without pipeline options",
+			sdk:    pb.Sdk_SDK_JAVA,
+			exName: "MinimalWordCount",
+			want:   "",
+		},
+		{
+			// Test case with calling GetPipelineOptions with example which doesn't exist.
+			// As a result, want to receive ErrExampleNotFound.
+			name:    "unknown example",
+			sdk:     pb.Sdk_SDK_GO,
+			exName:  "WordCount",
+			wantErr: ErrExampleNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			c := New(examplesDir, local.New(ctx), time.Minute)
+			got, err := c.GetPipelineOptions(ctx, tt.sdk, tt.exName)
+			if err != tt.wantErr {
+				t.Errorf("GetPipelineOptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetPipelineOptions() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalog_RefreshInterval(t *testing.T) {
+	examplesDir := t.TempDir()
+	writeExample(t, examplesDir, pb.Sdk_SDK_JAVA, "WordCount", "class WordCount {}", "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	refreshInterval := time.Millisecond * 100
+	c := New(examplesDir, local.New(ctx), refreshInterval)
+
+	got, err := c.ListExamples(ctx, pb.Sdk_SDK_JAVA)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("ListExamples() got = %v, err = %v, want 1 example", got, err)
+	}
+
+	// new example isn't visible until the catalog is invalidated
+	writeExample(t, examplesDir, pb.Sdk_SDK_JAVA, "JoinExamples", "class JoinExamples {}", "")
+	got, err = c.ListExamples(ctx, pb.Sdk_SDK_JAVA)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("ListExamples() got = %v, err = %v, want cached 1 example", got, err)
+	}
+
+	time.Sleep(refreshInterval * 2)
+	got, err = c.ListExamples(ctx, pb.Sdk_SDK_JAVA)
+	if err != nil || len(got) != 2 {
+		t.Fatalf("ListExamples() got = %v, err = %v, want 2 examples after refresh", got, err)
+	}
+}
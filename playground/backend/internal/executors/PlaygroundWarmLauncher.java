@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import java.io.ByteArrayOutputStream;
+import java.io.File;
+import java.io.IOException;
+import java.io.InputStream;
+import java.lang.reflect.InvocationTargetException;
+import java.lang.reflect.Method;
+import java.net.URL;
+import java.net.URLClassLoader;
+import java.nio.charset.StandardCharsets;
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.Base64;
+import java.util.List;
+import java.util.logging.LogManager;
+
+/**
+ * Launcher of the pre-started JVMs of the Playground backend.
+ *
+ * <p>The JVM is started before the code is compiled and waits for the request in the standard
+ * input, so the startup of the JVM isn't a part of the run of the code. The request is the first
+ * line of the standard input which consists of the base64 encoded fields separated by spaces: the
+ * classpath of the code, the number N of the system properties, N system properties in key=value
+ * format, the main class and the arguments of the main method. The rest of the standard input is
+ * read by the code.
+ *
+ * <p>The main method of the class is run the same way as by the java command, so the JVM exits
+ * when the code is finished. Each JVM runs the code only once.
+ */
+public class PlaygroundWarmLauncher {
+
+  public static void main(String[] launcherArgs) throws Throwable {
+    String request = readRequest(System.in);
+    if (request == null) {
+      // the pool is closed before the code is dispatched
+      return;
+    }
+    List<String> fields = new ArrayList<>();
+    for (String field : request.split(" ", -1)) {
+      fields.add(new String(Base64.getDecoder().decode(field), StandardCharsets.UTF_8));
+    }
+    int index = 0;
+    String classpath = fields.get(index++);
+    int propertiesNumber = Integer.parseInt(fields.get(index++));
+    for (int i = 0; i < propertiesNumber; i++) {
+      String property = fields.get(index++);
+      int separator = property.indexOf('=');
+      if (separator < 0) {
+        System.setProperty(property, "");
+      } else {
+        System.setProperty(property.substring(0, separator), property.substring(separator + 1));
+      }
+    }
+    if (System.getProperty("java.util.logging.config.file") != null) {
+      LogManager.getLogManager().readConfiguration();
+    }
+    String mainClassName = fields.get(index++);
+    String[] args = fields.subList(index, fields.size()).toArray(new String[0]);
+
+    URLClassLoader loader =
+        new URLClassLoader(classpathUrls(classpath), ClassLoader.getSystemClassLoader());
+    Thread.currentThread().setContextClassLoader(loader);
+    Class<?> mainClass = Class.forName(mainClassName, true, loader);
+    Method main = mainClass.getMethod("main", String[].class);
+    main.setAccessible(true);
+    try {
+      main.invoke(null, (Object) args);
+    } catch (InvocationTargetException e) {
+      throw e.getCause();
+    }
+  }
+
+  /**
+   * Reads the first line of the input byte by byte, so the rest of the input is left for the code.
+   * Returns null if the input is closed before the line is read.
+   */
+  private static String readRequest(InputStream in) throws IOException {
+    ByteArrayOutputStream line = new ByteArrayOutputStream();
+    int b;
+    while ((b = in.read()) != '\n') {
+      if (b == -1) {
+        return null;
+      }
+      line.write(b);
+    }
+    return new String(line.toByteArray(), StandardCharsets.UTF_8);
+  }
+
+  /**
+   * Returns URLs of the entries of the classpath. Entries which end with "*" are expanded to the
+   * jar files of the directory as the java command does. Empty entries are skipped.
+   */
+  private static URL[] classpathUrls(String classpath) throws IOException {
+    List<URL> urls = new ArrayList<>();
+    for (String entry : classpath.split(File.pathSeparator)) {
+      if (entry.isEmpty()) {
+        continue;
+      }
+      if (entry.equals("*") || entry.endsWith(File.separator + "*")) {
+        String dir = entry.substring(0, entry.length() - 1);
+        File[] jars = new File(dir.isEmpty() ? "." : dir).listFiles();
+        if (jars == null) {
+          continue;
+        }
+        Arrays.sort(jars);
+        for (File jar : jars) {
+          if (jar.getName().toLowerCase().endsWith(".jar")) {
+            urls.add(jar.toURI().toURL());
+          }
+        }
+        continue;
+      }
+      urls.add(new File(entry).toURI().toURL());
+    }
+    return urls.toArray(new URL[0]);
+  }
+}
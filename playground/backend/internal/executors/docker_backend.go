@@ -0,0 +1,167 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dockerCmd is the command of the Docker client which manages the containers of the executed code
+const dockerCmd = "docker"
+
+// dockerFailureExitCode is the exit code of "docker run" if the container couldn't be created or started by Docker itself
+const dockerFailureExitCode = 125
+
+// dockerCmdTimeout is the timeout of the commands of the Docker client which manage the container (i.e. "docker kill")
+const dockerCmdTimeout = 30 * time.Second
+
+// dockerScratchDir is the folder of the container where the executed code creates its temporary files
+const dockerScratchDir = "/tmp/playground"
+
+// DockerBackend executes the code in a throwaway Docker container of Image.
+// The folder of the pipeline is mounted at the same path, so the paths of the files in the command of the code
+//	are the same as on the server and the files which the code writes to the folder (i.e. the logs, the metrics,
+//	the graph and the output files of the pipeline) are read by the server after the run.
+//	The source and compiled files are mounted read-only over it, so the code couldn't change them.
+//	Temporary files of the code are written to the scratch folder (TMPDIR of the code) which is created for each container and deleted with it.
+// The memory and CPU time limits of the code are enforced by the options of the container.
+//	The container has no network unless Network is set and the code isn't isolated from the network by ExecutionSpec.
+// Environment variables of the server from the base environment (i.e. PATH) aren't passed to the container,
+//	so the environment of the image is kept.
+type DockerBackend struct {
+	// Image is the image of the container with the toolchain of the SDK
+	Image string
+	// Network is the Docker network which the container is connected to, i.e. "bridge".
+	// The container isn't connected to any network if it isn't set.
+	Network string
+}
+
+// Run runs the code in a new container and waits for it. The container is removed after the code is finished.
+// If spec.Stop is closed, the container is stopped by "docker stop" with spec.StopGracePeriod (SIGTERM and then SIGKILL).
+// If ctx is done before the code is finished, the container is killed.
+// Returns ErrOutOfMemory if the code is killed because of the memory limit
+//	and ErrBackendFailure if the container couldn't be run by Docker.
+func (b DockerBackend) Run(ctx context.Context, spec ExecutionSpec) (ExecutionResult, error) {
+	scratchDir, err := os.MkdirTemp("", "playground-scratch-")
+	if err != nil {
+		return ExecutionResult{ExitCode: -1}, fmt.Errorf("%w: couldn't create the scratch folder: %s", ErrBackendFailure, err.Error())
+	}
+	defer os.RemoveAll(scratchDir)
+	name := "playground-" + uuid.NewString()
+	cmd := exec.Command(dockerCmd, b.runArgs(name, scratchDir, spec)...)
+	if spec.Stdin != "" {
+		cmd.Stdin = strings.NewReader(spec.Stdin)
+	}
+	cmd.Stdout = spec.Stdout
+	cmd.Stderr = spec.Stderr
+	if err := cmd.Start(); err != nil {
+		return ExecutionResult{ExitCode: -1}, fmt.Errorf("%w: couldn't start %s: %s", ErrBackendFailure, dockerCmd, err.Error())
+	}
+	defer runDockerCmd(dockerCmdTimeout, "rm", "--force", name)
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err = <-done:
+	case <-spec.Stop:
+		stopTimeout := int(math.Ceil(spec.StopGracePeriod.Seconds()))
+		_, _ = runDockerCmd(dockerCmdTimeout+spec.StopGracePeriod, "stop", "--time", strconv.Itoa(stopTimeout), name)
+		err = <-done
+	case <-ctx.Done():
+		_, _ = runDockerCmd(dockerCmdTimeout, "kill", name)
+		err = <-done
+	}
+	exitCode := ExitCode(err)
+	if exitCode == dockerFailureExitCode {
+		return ExecutionResult{ExitCode: -1}, fmt.Errorf("%w: the container of %s isn't run: %s", ErrBackendFailure, b.Image, err.Error())
+	}
+	if err != nil && isOomKilled(name) {
+		return ExecutionResult{ExitCode: exitCode}, fmt.Errorf("%w: %s", ErrOutOfMemory, err.Error())
+	}
+	return ExecutionResult{ExitCode: exitCode}, err
+}
+
+// runArgs returns arguments of "docker run" which runs the code of spec in the container with name.
+// The sandbox command of spec isn't used since the code is isolated by the container.
+func (b DockerBackend) runArgs(name, scratchDir string, spec ExecutionSpec) []string {
+	args := []string{"run", "--name", name, "--init"}
+	if spec.Stdin != "" {
+		args = append(args, "--interactive")
+	}
+	if spec.PipelineFolder != "" {
+		args = append(args, "--volume", spec.PipelineFolder+":"+spec.PipelineFolder)
+	}
+	for _, path := range spec.ReadOnlyPaths {
+		args = append(args, "--volume", path+":"+path+":ro")
+	}
+	args = append(args, "--volume", scratchDir+":"+dockerScratchDir, "--env", "TMPDIR="+dockerScratchDir)
+	if spec.WorkingDir != "" {
+		args = append(args, "--workdir", spec.WorkingDir)
+	}
+	if spec.MemoryLimit > 0 {
+		// swap isn't used, so the memory of the code is limited exactly
+		memoryLimit := strconv.Itoa(spec.MemoryLimit) + "m"
+		args = append(args, "--memory", memoryLimit, "--memory-swap", memoryLimit)
+	}
+	if spec.CpuTimeLimit > 0 {
+		args = append(args, "--ulimit", fmt.Sprintf("cpu=%d:%d", spec.CpuTimeLimit, spec.CpuTimeLimit))
+	}
+	network := "none"
+	if b.Network != "" && !spec.NetworkIsolation {
+		network = b.Network
+	}
+	args = append(args, "--network", network)
+	for _, variable := range spec.Env {
+		if !isBaseEnv(variable) {
+			args = append(args, "--env", variable)
+		}
+	}
+	return append(append(args, b.Image, spec.Command), spec.Args...)
+}
+
+// isBaseEnv checks if the environment variable in "key=value" format is from the base environment of the server
+func isBaseEnv(variable string) bool {
+	key := strings.SplitN(variable, "=", 2)[0]
+	for _, baseKey := range baseEnvKeys {
+		if key == baseKey {
+			return true
+		}
+	}
+	return false
+}
+
+// isOomKilled checks if the container with name is killed because it has run out of memory
+func isOomKilled(name string) bool {
+	output, err := runDockerCmd(dockerCmdTimeout, "inspect", "--format", "{{.State.OOMKilled}}", name)
+	return err == nil && strings.TrimSpace(string(output)) == "true"
+}
+
+// runDockerCmd runs the command of the Docker client with args and returns its output.
+// The command is killed if it isn't finished during timeout.
+func runDockerCmd(timeout time.Duration, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return exec.CommandContext(ctx, dockerCmd, args...).Output()
+}
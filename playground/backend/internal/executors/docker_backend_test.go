@@ -0,0 +1,162 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDockerBackend_runArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		network string
+		spec    ExecutionSpec
+		want    []string
+	}{
+		{
+			// Test case with calling runArgs method with the spec without limits and isolation.
+			// As a result, want to receive the command of the code run in the image with the scratch folder and without the network.
+			name: "without limits",
+			spec: ExecutionSpec{Command: "python3", Args: []string{"/pipeline/src/main.py"}},
+			want: []string{"run", "--name", "test", "--init", "--volume", "/scratch:/tmp/playground", "--env", "TMPDIR=/tmp/playground",
+				"--network", "none", "python:3.8", "python3", "/pipeline/src/main.py"},
+		},
+		{
+			// Test case with calling runArgs method with the spec with the folder of the pipeline, limits, isolation, stdin and environment.
+			// As a result, want to receive the folder mounted writable with the source and compiled files mounted read-only over it,
+			//	the limits and the network isolation as options of the container
+			//	and only environment variables which aren't from the base environment of the server.
+			name:    "with limits and isolation",
+			network: "bridge",
+			spec: ExecutionSpec{
+				Command:          "python3",
+				Args:             []string{"/pipeline/src/main.py", "--opt=value"},
+				Env:              []string{"PATH=/usr/bin", PipelineIdEnvKey + "=id"},
+				WorkingDir:       "/pipeline",
+				PipelineFolder:   "/pipeline",
+				ReadOnlyPaths:    []string{"/pipeline/src", "/pipeline/bin"},
+				Stdin:            "input",
+				MemoryLimit:      512,
+				CpuTimeLimit:     10,
+				NetworkIsolation: true,
+				SandboxCmd:       []string{"firejail", "--net=none"},
+			},
+			want: []string{"run", "--name", "test", "--init", "--interactive", "--volume", "/pipeline:/pipeline",
+				"--volume", "/pipeline/src:/pipeline/src:ro", "--volume", "/pipeline/bin:/pipeline/bin:ro",
+				"--volume", "/scratch:/tmp/playground", "--env", "TMPDIR=/tmp/playground", "--workdir", "/pipeline",
+				"--memory", "512m", "--memory-swap", "512m", "--ulimit", "cpu=10:10", "--network", "none", "--env", PipelineIdEnvKey + "=id",
+				"python:3.8", "python3", "/pipeline/src/main.py", "--opt=value"},
+		},
+		{
+			// Test case with calling runArgs method with the network of the backend and the spec without isolation.
+			// As a result, want to receive the container connected to the network of the backend.
+			name:    "with network",
+			network: "bridge",
+			spec:    ExecutionSpec{Command: "python3", Args: []string{"/pipeline/src/main.py"}},
+			want: []string{"run", "--name", "test", "--init", "--volume", "/scratch:/tmp/playground", "--env", "TMPDIR=/tmp/playground",
+				"--network", "bridge", "python:3.8", "python3", "/pipeline/src/main.py"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := DockerBackend{Image: "python:3.8", Network: tt.network}
+			if got := backend.runArgs("test", "/scratch", tt.spec); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("runArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDockerBackend_Run(t *testing.T) {
+	// the Docker client is imitated by a script which prints the output of the container and exits with the exit code
+	//	from FAKE_DOCKER_EXIT_CODE, so the results of the container are checked without Docker
+	binDir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$1\" in\n" +
+		"run) echo \"Hello world!\"; exit $FAKE_DOCKER_EXIT_CODE ;;\n" +
+		"inspect) echo $FAKE_DOCKER_OOM_KILLED ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(binDir, dockerCmd), []byte(script), 0700); err != nil {
+		t.Fatalf("error during write docker script: %s", err.Error())
+	}
+	path := os.Getenv("PATH")
+	defer os.Setenv("PATH", path)
+	_ = os.Setenv("PATH", binDir+string(os.PathListSeparator)+path)
+	defer os.Unsetenv("FAKE_DOCKER_EXIT_CODE")
+	defer os.Unsetenv("FAKE_DOCKER_OOM_KILLED")
+
+	tests := []struct {
+		name             string
+		exitCode         string
+		oomKilled        string
+		wantErr          error
+		expectedExitCode int
+	}{
+		{
+			// Test case with calling Run method with the container which finishes successfully.
+			// As a result, want to receive the output of the container and zero exit code.
+			name:             "successful run",
+			exitCode:         "0",
+			oomKilled:        "false",
+			expectedExitCode: 0,
+		},
+		{
+			// Test case with calling Run method with the container which is killed because it has run out of memory.
+			// As a result, want to receive ErrOutOfMemory and the exit code of the container.
+			name:             "out of memory",
+			exitCode:         "137",
+			oomKilled:        "true",
+			wantErr:          ErrOutOfMemory,
+			expectedExitCode: 137,
+		},
+		{
+			// Test case with calling Run method with the container which couldn't be run by Docker.
+			// As a result, want to receive ErrBackendFailure and -1 as the exit code since the code isn't run.
+			name:             "docker failure",
+			exitCode:         "125",
+			oomKilled:        "false",
+			wantErr:          ErrBackendFailure,
+			expectedExitCode: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Setenv("FAKE_DOCKER_EXIT_CODE", tt.exitCode)
+			_ = os.Setenv("FAKE_DOCKER_OOM_KILLED", tt.oomKilled)
+			var stdout, stderr bytes.Buffer
+			spec := ExecutionSpec{Command: "python3", Stdout: &stdout, Stderr: &stderr}
+			result, err := DockerBackend{Image: "python:3.8"}.Run(context.Background(), spec)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Run() error = %v, but expects: %v", err, tt.wantErr)
+			}
+			if result.ExitCode != tt.expectedExitCode {
+				t.Errorf("Run() exit code = %d, but expects: %d", result.ExitCode, tt.expectedExitCode)
+			}
+			if stdout.String() != "Hello world!\n" {
+				t.Errorf("Run() stdout = %q, but expects: %q", stdout.String(), "Hello world!\n")
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrOutOfMemory is returned by ExecutionBackend if the executed code is killed because it has run out of memory
+var ErrOutOfMemory = errors.New("out of memory")
+
+// ErrBackendFailure is returned (wrapped) by ExecutionBackend if the code couldn't be executed because of a failure
+//	of the backend itself instead of the code, i.e. the container couldn't be created
+var ErrBackendFailure = errors.New("execution backend failure")
+
+// ExecutionSpec describes the execution of the code which is independent of the way the code is executed
+type ExecutionSpec struct {
+	// Command is the name of the command which runs the code
+	Command string
+	// Args are arguments of the command
+	Args []string
+	// Env are environment variables of the executed code in "key=value" format
+	Env []string
+	// WorkingDir is the working directory of the executed code
+	WorkingDir string
+	// PipelineFolder is the folder of the pipeline with the source and compiled files of the code.
+	// The code could write its files to it, i.e. the logs, the metrics and the graph of the pipeline.
+	PipelineFolder string
+	// ReadOnlyPaths are the files and folders in PipelineFolder which the code shouldn't change, i.e. the source and compiled files
+	ReadOnlyPaths []string
+	// Stdin is passed to the standard input of the executed code
+	Stdin string
+	// MemoryLimit is the limit of memory (in megabytes) of the executed code. The memory isn't limited if it isn't positive.
+	MemoryLimit int
+	// CpuTimeLimit is the limit of CPU time (in seconds) of the executed code. CPU time isn't limited if it isn't positive.
+	CpuTimeLimit int
+	// NetworkIsolation is true if the executed code shouldn't have access to the network
+	NetworkIsolation bool
+	// SandboxCmd is the command which wraps the executed code, i.e. ["nsjail", "--quiet", "--"]
+	SandboxCmd []string
+	// Stdout and Stderr receive the output of the executed code while it is running
+	Stdout io.Writer
+	Stderr io.Writer
+	// Stop is closed when the executed code should be stopped gracefully.
+	// The code which isn't stopped after StopGracePeriod is killed.
+	Stop <-chan struct{}
+	// StopGracePeriod is the time which the executed code has to stop after Stop is closed
+	StopGracePeriod time.Duration
+}
+
+// ExecutionResult is the result of the finished execution of the code
+type ExecutionResult struct {
+	// ExitCode is the exit code of the executed code.
+	// If the code is killed by a signal, it is 128 + the number of the signal as shells do (i.e. 137 for SIGKILL).
+	// It is -1 if the code hasn't exited by itself, i.e. it couldn't be started.
+	ExitCode int
+}
+
+// ExecutionBackend executes the code described by ExecutionSpec, i.e. in a subprocess, in a container or on a remote worker.
+type ExecutionBackend interface {
+	// Run executes the code and blocks until it is finished.
+	// The code is killed if ctx is done before the code is finished.
+	// Returns an error if the code couldn't be executed or is finished unsuccessfully.
+	Run(ctx context.Context, spec ExecutionSpec) (ExecutionResult, error)
+}
+
+// SubprocessBackend executes the code in a subprocess of the server
+type SubprocessBackend struct{}
+
+// Run starts the command of SubprocessCmd and waits for it.
+// If spec.Stop is closed, the command is terminated by SIGTERM and killed if it isn't stopped after spec.StopGracePeriod.
+// If ctx is done, the command is killed. The signals are sent to the whole process group of the command,
+//	so the processes forked by the executed code don't survive it.
+// The error of the finished command is returned as is, i.e. *exec.ExitError if the command exits with a non-zero code.
+func (b SubprocessBackend) Run(ctx context.Context, spec ExecutionSpec) (ExecutionResult, error) {
+	cmd := SubprocessCmd(ctx, spec)
+	cmd.Stdout = spec.Stdout
+	cmd.Stderr = spec.Stderr
+	if err := cmd.Start(); err != nil {
+		return ExecutionResult{ExitCode: ExitCode(err)}, err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		_ = SignalCmd(cmd, syscall.SIGKILL)
+		err = <-done
+	case <-spec.Stop:
+		_ = SignalCmd(cmd, syscall.SIGTERM)
+		timer := time.NewTimer(spec.StopGracePeriod)
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			_ = SignalCmd(cmd, syscall.SIGKILL)
+			err = <-done
+		case <-timer.C:
+			_ = SignalCmd(cmd, syscall.SIGKILL)
+			err = <-done
+		}
+		timer.Stop()
+	}
+	if ctx.Err() != nil {
+		// the command could be killed by ctx before the select, the forked processes which don't keep its output are killed here
+		_ = SignalCmd(cmd, syscall.SIGKILL)
+	}
+	return ExecutionResult{ExitCode: ExitCode(err)}, err
+}
+
+// SubprocessCmd prepares the Cmd of the executed code with the memory and CPU time limits and the isolation of spec.
+// If the sandbox command is set, the executed code is wrapped with it.
+// Otherwise, if the network isolation is enabled, the executed code is run in a new network namespace,
+//	so it has no access to the network.
+// The executed code is started in its own process group, so it could be stopped together with the processes it forks
+//	(see SignalCmd).
+// The input of spec is passed to the standard input of the executed code.
+func SubprocessCmd(ctx context.Context, spec ExecutionSpec) *exec.Cmd {
+	name := spec.Command
+	args := spec.Args
+	if len(spec.SandboxCmd) > 0 {
+		args = append(append(append([]string{}, spec.SandboxCmd[1:]...), name), args...)
+		name = spec.SandboxCmd[0]
+	}
+	cmd := commandWithLimits(ctx, spec.MemoryLimit, spec.CpuTimeLimit, name, args...)
+	if len(spec.SandboxCmd) == 0 && spec.NetworkIsolation {
+		cmd.SysProcAttr = networkNamespaceAttr()
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	if spec.Stdin != "" {
+		cmd.Stdin = strings.NewReader(spec.Stdin)
+	}
+	cmd.Dir = spec.WorkingDir
+	cmd.Env = spec.Env
+	return cmd
+}
+
+// ExitCode returns exit code of the executed code from the error of its Cmd.
+// If the code is killed by a signal, returns 128 + the number of the signal as shells do (i.e. 137 for SIGKILL).
+// Returns -1 if the error isn't caused by the exit of the executed code (i.e. the command isn't found).
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return -1
+	}
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal())
+	}
+	return exitErr.ExitCode()
+}
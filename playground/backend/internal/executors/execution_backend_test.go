@@ -0,0 +1,186 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSubprocessBackend_Run(t *testing.T) {
+	tests := []struct {
+		name             string
+		spec             ExecutionSpec
+		stop             bool
+		wantErr          bool
+		expectedExitCode int
+		expectedStdout   string
+		expectedStderr   string
+	}{
+		{
+			// Test case with calling Run method with the code which reads stdin and prints to stdout and stderr.
+			// As a result, want to receive the output of the code and zero exit code.
+			name:             "successful run",
+			spec:             ExecutionSpec{Command: "sh", Args: []string{"-c", "read line; echo out $line; echo err >&2"}, Stdin: "input\n"},
+			expectedExitCode: 0,
+			expectedStdout:   "out input\n",
+			expectedStderr:   "err\n",
+		},
+		{
+			// Test case with calling Run method with the code which exits with a non-zero code.
+			// As a result, want to receive an error and the exit code of the code.
+			name:             "failed run",
+			spec:             ExecutionSpec{Command: "sh", Args: []string{"-c", "exit 3"}},
+			wantErr:          true,
+			expectedExitCode: 3,
+		},
+		{
+			// Test case with calling Run method with the command which isn't found.
+			// As a result, want to receive an error and -1 as the exit code since the code isn't started.
+			name:             "command not found",
+			spec:             ExecutionSpec{Command: "unknown_command_of_playground"},
+			wantErr:          true,
+			expectedExitCode: -1,
+		},
+		{
+			// Test case with calling Run method with the code which ignores SIGTERM and stopping it.
+			// As a result, want to receive the code killed by SIGKILL after the grace period.
+			name:             "stopped run",
+			spec:             ExecutionSpec{Command: "sh", Args: []string{"-c", "trap '' TERM; exec sleep 60"}, StopGracePeriod: 100 * time.Millisecond},
+			stop:             true,
+			wantErr:          true,
+			expectedExitCode: 137,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			stop := make(chan struct{})
+			tt.spec.Stdout = &stdout
+			tt.spec.Stderr = &stderr
+			tt.spec.Stop = stop
+			if tt.stop {
+				time.AfterFunc(100*time.Millisecond, func() { close(stop) })
+			}
+			result, err := SubprocessBackend{}.Run(context.Background(), tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result.ExitCode != tt.expectedExitCode {
+				t.Errorf("Run() exit code = %d, but expects: %d", result.ExitCode, tt.expectedExitCode)
+			}
+			if stdout.String() != tt.expectedStdout {
+				t.Errorf("Run() stdout = %q, but expects: %q", stdout.String(), tt.expectedStdout)
+			}
+			if stderr.String() != tt.expectedStderr {
+				t.Errorf("Run() stderr = %q, but expects: %q", stderr.String(), tt.expectedStderr)
+			}
+		})
+	}
+}
+
+func TestSubprocessBackend_RunForkingCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		stop    bool
+	}{
+		{
+			// Test case with calling Run method with the code which forks a sleeping child and is finished by the timeout.
+			// As a result, want the child to be killed together with the code.
+			name:    "timeout",
+			timeout: 200 * time.Millisecond,
+		},
+		{
+			// Test case with calling Run method with the code which forks a sleeping child and is canceled.
+			// As a result, want the child to be killed together with the code.
+			name:    "cancel",
+			timeout: time.Minute,
+			stop:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pidFile := filepath.Join(t.TempDir(), "child.pid")
+			// the child keeps stdout of the code open, so Run isn't finished while the child is alive
+			var stdout bytes.Buffer
+			stop := make(chan struct{})
+			spec := ExecutionSpec{
+				Command:         "sh",
+				Args:            []string{"-c", "trap '' TERM; sleep 60 & echo $! > " + pidFile + "; wait"},
+				Stdout:          &stdout,
+				Stop:            stop,
+				StopGracePeriod: 100 * time.Millisecond,
+			}
+			if tt.stop {
+				time.AfterFunc(200*time.Millisecond, func() { close(stop) })
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			finished := make(chan error, 1)
+			go func() {
+				_, err := SubprocessBackend{}.Run(ctx, spec)
+				finished <- err
+			}()
+			select {
+			case err := <-finished:
+				if err == nil {
+					t.Errorf("Run() should return an error for the killed code")
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatalf("Run() isn't finished while the forked child is alive")
+			}
+			pidData, err := ioutil.ReadFile(pidFile)
+			if err != nil {
+				t.Fatalf("error during read pid of the child: %s", err.Error())
+			}
+			pid, err := strconv.Atoi(strings.TrimSpace(string(pidData)))
+			if err != nil {
+				t.Fatalf("error during parse pid of the child: %s", err.Error())
+			}
+			deadline := time.Now().Add(5 * time.Second)
+			for processAlive(pid) {
+				if time.Now().After(deadline) {
+					_ = syscall.Kill(pid, syscall.SIGKILL)
+					t.Fatalf("the child %d forked by the code is still alive", pid)
+				}
+				time.Sleep(50 * time.Millisecond)
+			}
+		})
+	}
+}
+
+// processAlive checks if the process with pid is running.
+// A zombie process isn't alive since the process which should reap it could be missing in the container.
+func processAlive(pid int) bool {
+	if err := syscall.Kill(pid, 0); err != nil {
+		return false
+	}
+	stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return true
+	}
+	fields := strings.Fields(string(stat[strings.LastIndex(string(stat), ")")+1:]))
+	return len(fields) == 0 || fields[0] != "Z"
+}
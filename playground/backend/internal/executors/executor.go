@@ -16,13 +16,41 @@
 package executors
 
 import (
+	"beam.apache.org/playground/backend/internal/fs_tool"
 	"beam.apache.org/playground/backend/internal/preparators"
 	"beam.apache.org/playground/backend/internal/validators"
 	"context"
+	"fmt"
+	"golang.org/x/sync/errgroup"
+	"os"
 	"os/exec"
+	"sort"
+	"strings"
 	"sync"
+	"syscall"
 )
 
+// memoryLimitCmd is a shell command which limits the data segment (in kilobytes) of the process.
+// Since Linux 4.7 the data segment includes the private writable mappings, so the memory allocated by the process is limited,
+//	but the address space which is only reserved (i.e. by the JVM for the heap and the code cache) isn't counted
+//	unlike the limit of the virtual memory which doesn't allow the JVM to start.
+const memoryLimitCmd = "ulimit -d %d"
+
+// cpuTimeLimitCmd is a shell command which limits CPU time (in seconds) of the process.
+// The process is killed by SIGXCPU when it uses up the limit.
+const cpuTimeLimitCmd = "ulimit -t %d"
+
+// execCmd is a shell command which replaces the shell with the executed command,
+// so the limits are applied only to the executed command
+const execCmd = "exec \"$0\" \"$@\""
+
+// baseEnvKeys are names of the environment variables of the server which are passed to the executed code.
+// Other environment variables of the server aren't passed to the executed code to isolate it from the server.
+var baseEnvKeys = []string{"PATH", "HOME", "LANG", "TMPDIR", "JAVA_HOME", "PYTHONPATH"}
+
+// PipelineIdEnvKey is the name of the environment variable which contains the pipeline ID of the executed code
+const PipelineIdEnvKey = "PLAYGROUND_PIPELINE_ID"
+
 type ExecutionType string
 
 const (
@@ -33,10 +61,24 @@ const (
 //CmdConfiguration for base cmd code execution
 type CmdConfiguration struct {
 	fileName        string
+	fileNames       []string
+	outputFilePath  string
 	workingDir      string
 	commandName     string
 	commandArgs     []string
+	jvmArgs         []string
 	pipelineOptions []string
+	graphArgs       []string
+	memoryLimit     int
+	// cpuTimeLimit is a limit of the CPU time (in seconds) of the command
+	cpuTimeLimit int
+	env          map[string]string
+	// networkIsolation is true if the command should be run in a new network namespace
+	networkIsolation bool
+	// sandboxCmd wraps the command to isolate it, it is used instead of the network namespace if it is set
+	sandboxCmd []string
+	// stdin is passed to the standard input of the command if it isn't empty
+	stdin string
 }
 
 // Executor struct for all sdks (Java/Python/Go/SCIO)
@@ -48,30 +90,34 @@ type Executor struct {
 	preparators []preparators.Preparator
 }
 
-// Validate returns the function that applies all validators of executor
-func (ex *Executor) Validate() func(chan bool, chan error, *sync.Map) {
+// Validate returns the function that applies all validators of executor to the code of lc.
+// Validators are run concurrently and their results are stored into valRes by names of the validators.
+// If several validators fail, the error of the first of them (in the order of validators of executor) is sent to errCh,
+//	so the reported error doesn't depend on the order in which the validators finish.
+func (ex *Executor) Validate(ctx context.Context, lc *fs_tool.LifeCycle) func(chan bool, chan error, *sync.Map) {
 	return func(doneCh chan bool, errCh chan error, valRes *sync.Map) {
-		validationErrors := make(chan error, len(ex.validators))
-		var wg sync.WaitGroup
-		for _, validator := range ex.validators {
-			wg.Add(1)
-			go func(validationErrors chan error, valRes *sync.Map, validator validators.Validator) {
-				defer wg.Done()
-				res, err := validator.Validator(validator.Args...)
+		validationErrors := make([]error, len(ex.validators))
+		var group errgroup.Group
+		for i, validator := range ex.validators {
+			i, validator := i, validator
+			group.Go(func() error {
+				res, err := validator.Validate(ctx, lc)
+				validationErrors[i] = err
+				valRes.Store(validator.Name(), res)
+				return err
+			})
+		}
+		if group.Wait() != nil {
+			for _, err := range validationErrors {
 				if err != nil {
-					validationErrors <- err
+					errCh <- err
+					break
 				}
-				valRes.Store(validator.Name, res)
-			}(validationErrors, valRes, validator)
-		}
-		wg.Wait()
-		select {
-		case err := <-validationErrors:
-			errCh <- err
+			}
 			doneCh <- false
-		default:
-			doneCh <- true
+			return
 		}
+		doneCh <- true
 	}
 }
 
@@ -91,34 +137,168 @@ func (ex *Executor) Prepare() func(chan bool, chan error) {
 }
 
 // Compile prepares the Cmd for code compilation
+// The compiler is started in its own process group, so it could be stopped by SignalCmd
+//	together with the processes which are started by it (i.e. the JVM started by the script of the compiler).
 // Returns Cmd instance
 func (ex *Executor) Compile(ctx context.Context) *exec.Cmd {
-	args := append(ex.compileArgs.commandArgs, ex.compileArgs.fileName)
+	args := ex.compileArgs.commandArgs
+	if ex.compileArgs.outputFilePath != "" {
+		args = append(args, ex.compileArgs.outputFilePath)
+	}
+	if len(ex.compileArgs.fileNames) > 0 {
+		args = append(args, ex.compileArgs.fileNames...)
+	} else {
+		args = append(args, ex.compileArgs.fileName)
+	}
 	cmd := exec.CommandContext(ctx, ex.compileArgs.commandName, args...)
 	cmd.Dir = ex.compileArgs.workingDir
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 	return cmd
 }
 
+// SignalCmd sends sig to the started cmd.
+// If cmd is started in its own process group, sig is sent to all processes of the group,
+//	so the processes which are started by cmd don't keep running after it is stopped.
+func SignalCmd(cmd *exec.Cmd, sig syscall.Signal) error {
+	if cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid {
+		return syscall.Kill(-cmd.Process.Pid, sig)
+	}
+	return cmd.Process.Signal(sig)
+}
+
 // Run prepares the Cmd for execution of the code
 // Returns Cmd instance
 func (ex *Executor) Run(ctx context.Context) *exec.Cmd {
-	args := ex.runArgs.commandArgs
+	return SubprocessCmd(ctx, ex.RunSpec())
+}
+
+// RunSpec prepares the ExecutionSpec for execution of the code by an ExecutionBackend
+func (ex *Executor) RunSpec() ExecutionSpec {
+	return ex.runArgs.executionSpec(ex.runCmdArgs()...)
+}
+
+// runCmdArgs returns arguments of the command to run the code.
+// JVM flags are placed before other arguments, so they are applied to the JVM instead of the executed code.
+func (ex *Executor) runCmdArgs() []string {
+	args := append(append([]string{}, ex.runArgs.jvmArgs...), ex.runArgs.commandArgs...)
 	if ex.runArgs.fileName != "" {
 		args = append(args, ex.runArgs.fileName)
 	}
-	if ex.runArgs.pipelineOptions[0] != "" {
+	return append(args, ex.pipelineArgs()...)
+}
+
+// pipelineArgs returns the pipeline options of the code followed by the graph args,
+//	so the graph of the pipeline is saved by the same run of the code.
+func (ex *Executor) pipelineArgs() []string {
+	var args []string
+	if len(ex.runArgs.pipelineOptions) > 0 && ex.runArgs.pipelineOptions[0] != "" {
 		args = append(args, ex.runArgs.pipelineOptions...)
 	}
-	cmd := exec.CommandContext(ctx, ex.runArgs.commandName, args...)
-	cmd.Dir = ex.runArgs.workingDir
-	return cmd
+	return append(args, ex.runArgs.graphArgs...)
+}
+
+// RunWarm dispatches the execution of the code to a pre-started JVM of pool instead of starting the run command.
+// Returns false if the code should be run by the command of Run: the pool is nil or has no pre-started JVM,
+//	JVM flags are set for the code or the run command of the code couldn't be run by the JVMs of the pool
+//	(see JvmWarmPool). The JVM is killed if ctx is done before the code is finished.
+func (ex *Executor) RunWarm(ctx context.Context, pool *JvmWarmPool) (*WarmJvm, bool) {
+	if pool == nil || len(ex.runArgs.jvmArgs) > 0 {
+		return nil, false
+	}
+	var classpath string
+	var properties []string
+	for i := 0; i < len(ex.runArgs.commandArgs); i++ {
+		arg := ex.runArgs.commandArgs[i]
+		switch {
+		case isClasspathFlag(arg) && i+1 < len(ex.runArgs.commandArgs):
+			i++
+			classpath = ex.runArgs.commandArgs[i]
+		case strings.HasPrefix(arg, "-D"):
+			properties = append(properties, strings.TrimPrefix(arg, "-D"))
+		default:
+			// other flags of the java command couldn't be applied to the pre-started JVM
+			return nil, false
+		}
+	}
+	if classpath == "" || ex.runArgs.fileName == "" || !pool.accepts(&ex.runArgs, strings.Split(classpath, string(os.PathListSeparator))) {
+		return nil, false
+	}
+	jvm, ok := pool.take()
+	if !ok {
+		return nil, false
+	}
+	if err := jvm.dispatch(ctx, classpath, properties, ex.runArgs.fileName, ex.pipelineArgs(), ex.runArgs.stdin); err != nil {
+		jvm.kill()
+		return nil, false
+	}
+	return jvm, true
 }
 
 // RunTest prepares the Cmd for execution of the unit test
 // Returns Cmd instance
 func (ex *Executor) RunTest(ctx context.Context) *exec.Cmd {
-	args := append(ex.testArgs.commandArgs, ex.testArgs.fileName)
-	cmd := exec.CommandContext(ctx, ex.testArgs.commandName, args...)
-	cmd.Dir = ex.testArgs.workingDir
-	return cmd
+	return SubprocessCmd(ctx, ex.TestSpec())
+}
+
+// TestSpec prepares the ExecutionSpec for execution of the unit test by an ExecutionBackend
+func (ex *Executor) TestSpec() ExecutionSpec {
+	args := append(append(append([]string{}, ex.testArgs.jvmArgs...), ex.testArgs.commandArgs...), ex.testArgs.fileName)
+	return ex.testArgs.executionSpec(args...)
+}
+
+// cmdEnv returns environment variables of the executed code in "key=value" format.
+// The minimal base environment of the server (variables from baseEnvKeys) is merged with env,
+//	so the executed code doesn't inherit all environment variables of the server.
+// Values from env override values of the base environment.
+func cmdEnv(env map[string]string) []string {
+	mergedEnv := make(map[string]string)
+	for _, key := range baseEnvKeys {
+		if value, ok := os.LookupEnv(key); ok {
+			mergedEnv[key] = value
+		}
+	}
+	for key, value := range env {
+		mergedEnv[key] = value
+	}
+	result := make([]string, 0, len(mergedEnv))
+	for key, value := range mergedEnv {
+		result = append(result, key+"="+value)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// executionSpec returns the ExecutionSpec of the command of cmdConfig with args,
+//	so the executed code has the environment, the limits and the isolation of cmdConfig.
+func (cmdConfig *CmdConfiguration) executionSpec(args ...string) ExecutionSpec {
+	return ExecutionSpec{
+		Command:          cmdConfig.commandName,
+		Args:             args,
+		Env:              cmdEnv(cmdConfig.env),
+		WorkingDir:       cmdConfig.workingDir,
+		Stdin:            cmdConfig.stdin,
+		MemoryLimit:      cmdConfig.memoryLimit,
+		CpuTimeLimit:     cmdConfig.cpuTimeLimit,
+		NetworkIsolation: cmdConfig.networkIsolation,
+		SandboxCmd:       cmdConfig.sandboxCmd,
+	}
+}
+
+// commandWithLimits prepares the Cmd which can use no more than memoryLimit megabytes of memory
+// and no more than cpuTimeLimit seconds of CPU time.
+// If memoryLimit or cpuTimeLimit isn't positive, the memory or the CPU time of the command isn't limited.
+func commandWithLimits(ctx context.Context, memoryLimit, cpuTimeLimit int, name string, args ...string) *exec.Cmd {
+	var limits []string
+	if memoryLimit > 0 {
+		limits = append(limits, fmt.Sprintf(memoryLimitCmd, memoryLimit*1024))
+	}
+	if cpuTimeLimit > 0 {
+		limits = append(limits, fmt.Sprintf(cpuTimeLimitCmd, cpuTimeLimit))
+	}
+	if len(limits) == 0 {
+		return exec.CommandContext(ctx, name, args...)
+	}
+	shellCmd := strings.Join(append(limits, execCmd), " && ")
+	shellArgs := append([]string{"-c", shellCmd, name}, args...)
+	return exec.CommandContext(ctx, "sh", shellArgs...)
 }
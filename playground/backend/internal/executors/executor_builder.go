@@ -76,6 +76,64 @@ func (b *ExecutorBuilder) WithWorkingDir(dir string) *ExecutorBuilder {
 	return b
 }
 
+//WithMemoryLimit adds limit of the memory (in megabytes) for the executed code to executor
+func (b *ExecutorBuilder) WithMemoryLimit(memoryLimit int) *ExecutorBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.runArgs.memoryLimit = memoryLimit
+		e.testArgs.memoryLimit = memoryLimit
+	})
+	return b
+}
+
+//WithCpuTimeLimit adds limit of the CPU time (in seconds) for the executed code to executor
+func (b *ExecutorBuilder) WithCpuTimeLimit(cpuTimeLimit int) *ExecutorBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.runArgs.cpuTimeLimit = cpuTimeLimit
+		e.testArgs.cpuTimeLimit = cpuTimeLimit
+	})
+	return b
+}
+
+//WithNetworkIsolation adds isolation of the executed code from the network to executor.
+//If sandboxCmd is set, the executed code is wrapped with it instead of running in a new network namespace
+func (b *ExecutorBuilder) WithNetworkIsolation(networkIsolation bool, sandboxCmd []string) *ExecutorBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.runArgs.networkIsolation = networkIsolation
+		e.runArgs.sandboxCmd = sandboxCmd
+		e.testArgs.networkIsolation = networkIsolation
+		e.testArgs.sandboxCmd = sandboxCmd
+	})
+	return b
+}
+
+//WithStdin adds the input which is passed to the standard input of the executed code to executor
+func (b *ExecutorBuilder) WithStdin(stdin string) *ExecutorBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.runArgs.stdin = stdin
+		e.testArgs.stdin = stdin
+	})
+	return b
+}
+
+//WithEnv adds environment variables for the executed code to executor
+func (b *ExecutorBuilder) WithEnv(env map[string]string) *ExecutorBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.runArgs.env = env
+		e.testArgs.env = env
+	})
+	return b
+}
+
+//WithJvmArgs adds JVM flags (i.e. -Xmx512m) for the executed code to executor.
+//They are passed to the run command before the classpath args
+func (b *ExecutorBuilder) WithJvmArgs(jvmArgs []string) *ExecutorBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.runArgs.jvmArgs = jvmArgs
+		e.testArgs.jvmArgs = jvmArgs
+	})
+	return b
+}
+
 // WithCompiler - Lives chains to type *ExecutorBuilder and returns a *CompileBuilder
 func (b *ExecutorBuilder) WithCompiler() *CompileBuilder {
 	return &CompileBuilder{*b}
@@ -125,6 +183,32 @@ func (b *CompileBuilder) WithFileName(fileName string) *CompileBuilder {
 	return b
 }
 
+//WithFileNames adds names of all files which should be compiled together to executor.
+//If they are set, they are used instead of the file name which is set by WithFileName
+func (b *CompileBuilder) WithFileNames(fileNames []string) *CompileBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.compileArgs.fileNames = fileNames
+	})
+	return b
+}
+
+//WithOutputFilePath adds path to the compiled file to executor.
+//It is used by SDKs where the compiler receives the output path as an argument (i.e. go build -o {outputFilePath})
+func (b *CompileBuilder) WithOutputFilePath(outputFilePath string) *CompileBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.compileArgs.outputFilePath = outputFilePath
+	})
+	return b
+}
+
+//WithWorkingDir adds dir path to executor to compile code from
+func (b *CompileBuilder) WithWorkingDir(dir string) *CompileBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.compileArgs.workingDir = dir
+	})
+	return b
+}
+
 //WithCommand adds run command to executor
 func (b *RunBuilder) WithCommand(runCmd string) *RunBuilder {
 	b.actions = append(b.actions, func(e *Executor) {
@@ -141,10 +225,10 @@ func (b *RunBuilder) WithArgs(runArgs []string) *RunBuilder {
 	return b
 }
 
-//WithGraphOutput adds the need of graph output to executor
-func (b *RunBuilder) WithGraphOutput() *RunBuilder {
+//WithGraphOutput adds pipeline options which make the code save graph of the pipeline while it is run to executor
+func (b *RunBuilder) WithGraphOutput(graphArgs []string) *RunBuilder {
 	b.actions = append(b.actions, func(e *Executor) {
-		//todo
+		e.runArgs.graphArgs = graphArgs
 	})
 	return b
 }
@@ -18,13 +18,19 @@ package executors
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/fs_tool"
 	"beam.apache.org/playground/backend/internal/preparators"
 	"beam.apache.org/playground/backend/internal/validators"
 	"context"
+	"errors"
+	"io"
 	"os"
 	"os/exec"
 	"reflect"
+	"sync"
+	"syscall"
 	"testing"
+	"time"
 )
 
 const defaultBeamJarsPath = "pathToJars"
@@ -36,7 +42,7 @@ var (
 		[]string{"-cp", "bin:" + defaultBeamJarsPath},
 		[]string{"-cp", "bin:" + defaultBeamJarsPath, "JUnit"},
 	)
-	env = environment.NewEnvironment(environment.NetworkEnvs{}, *environment.NewBeamEnvs(pb.Sdk_SDK_JAVA, executorConfig, ""), environment.ApplicationEnvs{})
+	env = environment.NewEnvironment(environment.NetworkEnvs{}, map[pb.Sdk]*environment.BeamEnvs{pb.Sdk_SDK_JAVA: environment.NewBeamEnvs(pb.Sdk_SDK_JAVA, executorConfig, "")}, environment.ApplicationEnvs{})
 )
 
 // BaseExecutorBuilder fills up an executor with base parameters
@@ -70,6 +76,106 @@ func BaseExecutorBuilder(envs environment.BeamEnvs, workingDir string, filePath
 	return &builder
 }
 
+// fakeValidator is a validator which returns result and err after delay
+type fakeValidator struct {
+	name   string
+	delay  time.Duration
+	result bool
+	err    error
+}
+
+func (v *fakeValidator) Name() string {
+	return v.name
+}
+
+func (v *fakeValidator) Validate(_ context.Context, _ *fs_tool.LifeCycle) (bool, error) {
+	time.Sleep(v.delay)
+	return v.result, v.err
+}
+
+// testValidator returns validator which returns result and err after delay
+func testValidator(name string, delay time.Duration, result bool, err error) validators.Validator {
+	return &fakeValidator{name: name, delay: delay, result: result, err: err}
+}
+
+func TestExecutor_Validate(t *testing.T) {
+	errFirst := errors.New("first validator failed")
+	errSecond := errors.New("second validator failed")
+	tests := []struct {
+		name        string
+		validators  []validators.Validator
+		wantOk      bool
+		wantErr     error
+		wantResults map[string]bool
+	}{
+		{
+			// Test case with calling Validate method with validators which pass.
+			// As a result, want to receive true and results of all validators.
+			name: "all validators pass",
+			validators: []validators.Validator{
+				testValidator("first", 0, true, nil),
+				testValidator(validators.UnitTestValidatorName, 0, false, nil),
+			},
+			wantOk:      true,
+			wantErr:     nil,
+			wantResults: map[string]bool{"first": true, validators.UnitTestValidatorName: false},
+		},
+		{
+			// Test case with calling Validate method with validators where one of them fails.
+			// As a result, want to receive false, error of the failed validator and results of all validators.
+			name: "one validator fails",
+			validators: []validators.Validator{
+				testValidator("first", 0, true, nil),
+				testValidator("second", 10*time.Millisecond, false, errSecond),
+				testValidator(validators.UnitTestValidatorName, 0, true, nil),
+			},
+			wantOk:      false,
+			wantErr:     errSecond,
+			wantResults: map[string]bool{"first": true, "second": false, validators.UnitTestValidatorName: true},
+		},
+		{
+			// Test case with calling Validate method with validators where several of them fail,
+			//	the second validator fails before the first one.
+			// As a result, want to receive false and error of the first validator.
+			name: "several validators fail",
+			validators: []validators.Validator{
+				testValidator("first", 50*time.Millisecond, false, errFirst),
+				testValidator("second", 0, false, errSecond),
+			},
+			wantOk:      false,
+			wantErr:     errFirst,
+			wantResults: map[string]bool{"first": false, "second": false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex := &Executor{validators: tt.validators}
+			doneCh := make(chan bool, 1)
+			errCh := make(chan error, 1)
+			var valRes sync.Map
+
+			ex.Validate(context.Background(), nil)(doneCh, errCh, &valRes)
+
+			if ok := <-doneCh; ok != tt.wantOk {
+				t.Errorf("Validate() ok = %v, want %v", ok, tt.wantOk)
+			}
+			var err error
+			select {
+			case err = <-errCh:
+			default:
+			}
+			if err != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			for name, want := range tt.wantResults {
+				if got, ok := valRes.Load(name); !ok || got != want {
+					t.Errorf("Validate() result of %s = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
 func TestExecutor_Compile(t *testing.T) {
 	type fields struct {
 		compileArgs CmdConfiguration
@@ -106,6 +212,60 @@ func TestExecutor_Compile(t *testing.T) {
 				ProcessState: nil,
 			},
 		},
+		{
+			name: "TestCompile with output file path",
+			fields: fields{
+				compileArgs: CmdConfiguration{
+					fileName:        "filePath",
+					outputFilePath:  "bin/out",
+					workingDir:      "./",
+					commandName:     "testCommand",
+					commandArgs:     []string{"build", "-o"},
+					pipelineOptions: []string{""},
+				},
+			},
+			want: &exec.Cmd{
+				Path:         "testCommand",
+				Args:         []string{"go", "build", "-o", "bin/out", "filePath"},
+				Env:          nil,
+				Dir:          "",
+				Stdin:        nil,
+				Stdout:       nil,
+				Stderr:       nil,
+				ExtraFiles:   nil,
+				SysProcAttr:  nil,
+				Process:      nil,
+				ProcessState: nil,
+			},
+		},
+		{
+			// Test case with calling Compile method with several files.
+			// As a result all files should be compiled together.
+			name: "TestCompile with several files",
+			fields: fields{
+				compileArgs: CmdConfiguration{
+					fileName:        "filePath",
+					fileNames:       []string{"filePath", "helperFilePath"},
+					workingDir:      "./",
+					commandName:     "testCommand",
+					commandArgs:     []string{"-d", "bin"},
+					pipelineOptions: []string{""},
+				},
+			},
+			want: &exec.Cmd{
+				Path:         "testCommand",
+				Args:         []string{"javac", "-d", "bin", "filePath", "helperFilePath"},
+				Env:          nil,
+				Dir:          "",
+				Stdin:        nil,
+				Stdout:       nil,
+				Stderr:       nil,
+				ExtraFiles:   nil,
+				SysProcAttr:  nil,
+				Process:      nil,
+				ProcessState: nil,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -114,14 +274,51 @@ func TestExecutor_Compile(t *testing.T) {
 				runArgs:     tt.fields.runArgs,
 				validators:  tt.fields.validators,
 			}
-			if got := ex.Compile(context.Background()); !reflect.DeepEqual(got.String(), tt.want.String()) {
+			got := ex.Compile(context.Background())
+			if !reflect.DeepEqual(got.String(), tt.want.String()) {
 				t.Errorf("WithCompiler() = %v, want %v", got, tt.want)
 			}
+			if got.SysProcAttr == nil || !got.SysProcAttr.Setpgid {
+				t.Errorf("Compile() should start the compiler in its own process group")
+			}
 		})
 	}
 }
 
+func TestSignalCmd(t *testing.T) {
+	// Test case with calling SignalCmd method with the command which is started in its own process group.
+	// As a result, the process started by the command should be stopped together with it.
+	// The started process keeps stdout of the command open, so the output is closed only when both of them are stopped.
+	cmd := exec.Command("sh", "-c", "sleep 60 & wait")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("error during get stdout: %s", err.Error())
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("error during start command: %s", err.Error())
+	}
+	defer cmd.Wait()
+	// waits until the shell starts the process
+	time.Sleep(100 * time.Millisecond)
+	if err := SignalCmd(cmd, syscall.SIGTERM); err != nil {
+		t.Fatalf("SignalCmd() error = %v", err)
+	}
+	closed := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(io.Discard, stdout)
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		t.Errorf("SignalCmd() didn't stop the process started by the command")
+	}
+}
+
 func TestExecutor_Run(t *testing.T) {
+	shPath, _ := exec.LookPath("sh")
 	type fields struct {
 		compileArgs CmdConfiguration
 		runArgs     CmdConfiguration
@@ -159,6 +356,148 @@ func TestExecutor_Run(t *testing.T) {
 				ProcessState: nil,
 			},
 		},
+		{
+			// Test case with calling Run method with memory limit.
+			// As a result the command should be executed by the shell which limits the data segment of the command.
+			name: "TestRun with memory limit",
+			fields: fields{
+				runArgs: CmdConfiguration{
+					fileName:        "HelloWorld",
+					workingDir:      "./",
+					commandName:     "testCommand",
+					commandArgs:     []string{"-cp", "bin:"},
+					pipelineOptions: []string{""},
+					memoryLimit:     512,
+				},
+			},
+			want: &exec.Cmd{
+				Path:         shPath,
+				Args:         []string{"sh", "-c", "ulimit -d 524288 && exec \"$0\" \"$@\"", "testCommand", "-cp", "bin:", "HelloWorld"},
+				Env:          nil,
+				Dir:          "",
+				Stdin:        nil,
+				Stdout:       nil,
+				Stderr:       nil,
+				ExtraFiles:   nil,
+				SysProcAttr:  nil,
+				Process:      nil,
+				ProcessState: nil,
+			},
+		},
+		{
+			// Test case with calling Run method with memory and cpu time limits.
+			// As a result the command should be executed by the shell which limits the data segment and cpu time of the command.
+			name: "TestRun with memory and cpu time limits",
+			fields: fields{
+				runArgs: CmdConfiguration{
+					fileName:        "HelloWorld",
+					workingDir:      "./",
+					commandName:     "testCommand",
+					commandArgs:     []string{"-cp", "bin:"},
+					pipelineOptions: []string{""},
+					memoryLimit:     512,
+					cpuTimeLimit:    10,
+				},
+			},
+			want: &exec.Cmd{
+				Path:         shPath,
+				Args:         []string{"sh", "-c", "ulimit -d 524288 && ulimit -t 10 && exec \"$0\" \"$@\"", "testCommand", "-cp", "bin:", "HelloWorld"},
+				Env:          nil,
+				Dir:          "",
+				Stdin:        nil,
+				Stdout:       nil,
+				Stderr:       nil,
+				ExtraFiles:   nil,
+				SysProcAttr:  nil,
+				Process:      nil,
+				ProcessState: nil,
+			},
+		},
+		{
+			// Test case with calling Run method with JVM flags.
+			// As a result JVM flags should be placed before the classpath args.
+			name: "TestRun with jvm flags",
+			fields: fields{
+				runArgs: CmdConfiguration{
+					fileName:        "HelloWorld",
+					workingDir:      "./",
+					commandName:     "testCommand",
+					commandArgs:     []string{"-cp", "bin:"},
+					jvmArgs:         []string{"-Xmx512m", "-XX:+UseSerialGC"},
+					pipelineOptions: []string{"--opt1=valOpt"},
+				},
+			},
+			want: &exec.Cmd{
+				Path:         "testCommand",
+				Args:         []string{"java", "-Xmx512m", "-XX:+UseSerialGC", "-cp", "bin:", "HelloWorld", "--opt1=valOpt"},
+				Env:          nil,
+				Dir:          "",
+				Stdin:        nil,
+				Stdout:       nil,
+				Stderr:       nil,
+				ExtraFiles:   nil,
+				SysProcAttr:  nil,
+				Process:      nil,
+				ProcessState: nil,
+			},
+		},
+		{
+			// Test case with calling Run method with graph args.
+			// As a result the graph args should be placed after the pipeline options, so the graph is saved by the same run.
+			name: "TestRun with graph args",
+			fields: fields{
+				runArgs: CmdConfiguration{
+					fileName:        "HelloWorld",
+					workingDir:      "./",
+					commandName:     "testCommand",
+					commandArgs:     []string{"-cp", "bin:"},
+					pipelineOptions: []string{"--opt1=valOpt"},
+					graphArgs:       []string{"--graph=pipeline_graph.dot"},
+				},
+			},
+			want: &exec.Cmd{
+				Path:         "testCommand",
+				Args:         []string{"java", "-cp", "bin:", "HelloWorld", "--opt1=valOpt", "--graph=pipeline_graph.dot"},
+				Env:          nil,
+				Dir:          "",
+				Stdin:        nil,
+				Stdout:       nil,
+				Stderr:       nil,
+				ExtraFiles:   nil,
+				SysProcAttr:  nil,
+				Process:      nil,
+				ProcessState: nil,
+			},
+		},
+		{
+			// Test case with calling Run method with sandbox command.
+			// As a result the command should be wrapped with the sandbox command instead of running in a new network namespace.
+			name: "TestRun with sandbox command",
+			fields: fields{
+				runArgs: CmdConfiguration{
+					fileName:         "HelloWorld",
+					workingDir:       "./",
+					commandName:      "testCommand",
+					commandArgs:      []string{"-cp", "bin:"},
+					pipelineOptions:  []string{""},
+					networkIsolation: true,
+					sandboxCmd:       []string{"testSandbox", "--net=none"},
+				},
+			},
+			want: &exec.Cmd{
+				Path:         "testSandbox",
+				Args:         []string{"testSandbox", "--net=none", "testCommand", "-cp", "bin:", "HelloWorld"},
+				Env:          nil,
+				Dir:          "",
+				Stdin:        nil,
+				Stdout:       nil,
+				Stderr:       nil,
+				ExtraFiles:   nil,
+				SysProcAttr:  nil,
+				Process:      nil,
+				ProcessState: nil,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -174,8 +513,111 @@ func TestExecutor_Run(t *testing.T) {
 	}
 }
 
+func Test_commandWithLimits(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skipf("%s isn't installed", "python3")
+	}
+	tests := []struct {
+		name       string
+		args       []string
+		wantOutput string
+		wantErr    bool
+	}{
+		{
+			// Test case with calling commandWithLimits method with memory limit.
+			// As a result, want to receive the command which data segment is limited by the memory limit in kilobytes.
+			name:       "data segment is limited",
+			args:       []string{"sh", "-c", "ulimit -d"},
+			wantOutput: "524288\n",
+			wantErr:    false,
+		},
+		{
+			// Test case with calling commandWithLimits method with the command which reserves more address space
+			// than the memory limit without allocating it (as the JVM does for the heap).
+			// As a result, want the command to succeed.
+			name:       "reserved address space isn't limited",
+			args:       []string{"python3", "-c", "import mmap; mmap.mmap(-1, 2 << 30, flags=mmap.MAP_PRIVATE | mmap.MAP_ANONYMOUS, prot=0)"},
+			wantOutput: "",
+			wantErr:    false,
+		},
+		{
+			// Test case with calling commandWithLimits method with the command which allocates more memory than the memory limit.
+			// As a result, want the command to fail.
+			name:       "allocated memory is limited",
+			args:       []string{"python3", "-c", "bytearray(1 << 30)"},
+			wantOutput: "",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := commandWithLimits(context.Background(), 512, 0, tt.args[0], tt.args[1:]...).Output()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("commandWithLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(output) != tt.wantOutput {
+				t.Errorf("commandWithLimits() output = %q, want %q", output, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func Test_commandWithLimitsRunsJava(t *testing.T) {
+	if _, err := exec.LookPath("java"); err != nil {
+		t.Skipf("%s isn't installed", "java")
+	}
+	// Test case with calling commandWithLimits method with memory limit which is less than the address space
+	// reserved by the JVM for the heap and the code cache.
+	// As a result, want the JVM to start, since only the allocated memory is limited.
+	output, err := commandWithLimits(context.Background(), 512, 0, "java", "-Xmx256m", "-version").CombinedOutput()
+	if err != nil {
+		t.Errorf("commandWithLimits() error = %v, output = %s", err, output)
+	}
+}
+
+func Test_cmdEnv(t *testing.T) {
+	// keep only PATH from the base environment of the server to receive the predictable result
+	for _, key := range baseEnvKeys {
+		if value, ok := os.LookupEnv(key); ok {
+			os.Unsetenv(key)
+			defer os.Setenv(key, value)
+		}
+	}
+	os.Setenv("PATH", "/usr/bin")
+	os.Setenv("MOCK_SERVER_ENV", "MOCK_VALUE")
+	defer os.Unsetenv("MOCK_SERVER_ENV")
+	tests := []struct {
+		name string
+		env  map[string]string
+		want []string
+	}{
+		{
+			// Test case with calling cmdEnv method without environment variables for the code.
+			// As a result, want to receive only the base environment of the server.
+			name: "base env",
+			env:  nil,
+			want: []string{"PATH=/usr/bin"},
+		},
+		{
+			// Test case with calling cmdEnv method with environment variables for the code.
+			// As a result, want to receive the base environment merged with environment variables for the code.
+			name: "base env with injected variables",
+			env:  map[string]string{"PYTHONHASHSEED": "0", "PATH": "/opt/bin"},
+			want: []string{"PATH=/opt/bin", "PYTHONHASHSEED=0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cmdEnv(tt.env); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cmdEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestBaseExecutorBuilder(t *testing.T) {
-	validatorsFuncs := validators.GetJavaValidators("filePath")
+	javaValidators, _ := validators.GetSdkValidators(pb.Sdk_SDK_JAVA)
+	validatorsFuncs := &javaValidators
 	preparatorsFuncs := preparators.GetJavaPreparators("filePath")
 
 	type args struct {
@@ -193,7 +635,7 @@ func TestBaseExecutorBuilder(t *testing.T) {
 		{
 			name: "NewCmdProvider",
 			args: args{
-				envs:             env.BeamSdkEnvs,
+				envs:             *env.BeamSdkEnvs[pb.Sdk_SDK_JAVA],
 				workingDir:       "./",
 				filePath:         "filePath",
 				validatorsFuncs:  validatorsFuncs,
@@ -0,0 +1,293 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package executors
+
+import (
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+const (
+	// warmLauncherClassName is the main class of the pre-started JVMs
+	warmLauncherClassName = "PlaygroundWarmLauncher"
+	// classpathPlaceholderPrefix is the prefix of the placeholders of the classpath (i.e. {compiledDir})
+	//	which are filled with the values of the code when the code is dispatched
+	classpathPlaceholderPrefix = "{"
+)
+
+// warmLauncherSource is the source code of the main class of the pre-started JVMs
+//go:embed PlaygroundWarmLauncher.java
+var warmLauncherSource []byte
+
+// JvmWarmPool keeps pre-started JVMs which wait for the code to run, so the code is run without the cold start of the JVM.
+// Each JVM runs the code only once: a new JVM is started instead of the JVM which the code is dispatched to.
+// The JVMs are started with the same command, environment, limits and isolation as the code, except the working dir.
+// The pipeline ID of the code (PipelineIdEnvKey variable) isn't known when the JVM is started,
+//	so it isn't set in the environment of the code which is dispatched to the JVM.
+type JvmWarmPool struct {
+	ctx       context.Context
+	cmdConfig CmdConfiguration
+	// classpath is the classpath of the code in which the entries with placeholders match any value
+	classpath []string
+	jvms      chan *WarmJvm
+	mu        sync.Mutex
+	closed    bool
+}
+
+// WarmJvm is a pre-started JVM of JvmWarmPool
+type WarmJvm struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	stderr io.ReadCloser
+	// exited is closed when the JVM is waited for
+	exited chan struct{}
+}
+
+// NewJvmWarmPool compiles the launcher of the pre-started JVMs with compileCmd into launcherDir and starts size JVMs.
+// The JVMs are started by runCmd with the classpath from runArgs (entries with placeholders are skipped),
+//	env and the memory limit, the CPU time limit and the network isolation of the code.
+// The JVMs are killed when ctx is done.
+func NewJvmWarmPool(ctx context.Context, size int, launcherDir, compileCmd, runCmd string, runArgs []string, env map[string]string, memoryLimit, cpuTimeLimit int, networkIsolation bool, sandboxCmd []string) (*JvmWarmPool, error) {
+	classpath, ok := jvmClasspath(runArgs)
+	if !ok {
+		return nil, fmt.Errorf("run args %v don't contain the classpath of the code", runArgs)
+	}
+	if err := compileWarmLauncher(ctx, launcherDir, compileCmd); err != nil {
+		return nil, err
+	}
+	launcherClasspath := []string{launcherDir}
+	for _, entry := range classpath {
+		if entry != "" && !strings.Contains(entry, classpathPlaceholderPrefix) {
+			launcherClasspath = append(launcherClasspath, entry)
+		}
+	}
+	pool := &JvmWarmPool{
+		ctx: ctx,
+		cmdConfig: CmdConfiguration{
+			fileName:         warmLauncherClassName,
+			workingDir:       launcherDir,
+			commandName:      runCmd,
+			commandArgs:      []string{"-cp", strings.Join(launcherClasspath, string(os.PathListSeparator))},
+			env:              env,
+			memoryLimit:      memoryLimit,
+			cpuTimeLimit:     cpuTimeLimit,
+			networkIsolation: networkIsolation,
+			sandboxCmd:       sandboxCmd,
+		},
+		classpath: classpath,
+		jvms:      make(chan *WarmJvm, size),
+	}
+	for i := 0; i < size; i++ {
+		if err := pool.startJvm(); err != nil {
+			pool.Close()
+			return nil, err
+		}
+	}
+	return pool, nil
+}
+
+// compileWarmLauncher saves the source code of the launcher to launcherDir and compiles it there
+func compileWarmLauncher(ctx context.Context, launcherDir, compileCmd string) error {
+	if err := os.MkdirAll(launcherDir, os.ModePerm); err != nil {
+		return fmt.Errorf("couldn't create the dir of the JVM launcher: %w", err)
+	}
+	sourcePath := filepath.Join(launcherDir, warmLauncherClassName+".java")
+	if err := os.WriteFile(sourcePath, warmLauncherSource, 0600); err != nil {
+		return fmt.Errorf("couldn't save the source code of the JVM launcher: %w", err)
+	}
+	cmd := exec.CommandContext(ctx, compileCmd, "-d", launcherDir, sourcePath)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("couldn't compile the JVM launcher: %s, output: %s", err.Error(), output)
+	}
+	return nil
+}
+
+// startJvm starts a new JVM and adds it to the pool
+func (p *JvmWarmPool) startJvm() error {
+	cmd := SubprocessCmd(p.ctx, p.cmdConfig.executionSpec(append(append([]string{}, p.cmdConfig.commandArgs...), p.cmdConfig.fileName)...))
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("couldn't start the JVM: %w", err)
+	}
+	p.jvms <- &WarmJvm{cmd: cmd, stdin: stdin, stdout: stdout, stderr: stderr, exited: make(chan struct{})}
+	return nil
+}
+
+// take returns a pre-started JVM and starts a new one instead of it.
+// Returns false if there is no pre-started JVM or the pool is closed.
+func (p *JvmWarmPool) take() (*WarmJvm, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, false
+	}
+	select {
+	case jvm := <-p.jvms:
+		if err := p.startJvm(); err != nil {
+			logger.Errorf("JvmWarmPool: error during starting the JVM: %s\n", err.Error())
+		}
+		return jvm, true
+	default:
+		return nil, false
+	}
+}
+
+// Close kills the pre-started JVMs which the code isn't dispatched to and waits until they exit.
+// Close of nil pool does nothing.
+func (p *JvmWarmPool) Close() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	for {
+		select {
+		case jvm := <-p.jvms:
+			jvm.kill()
+		default:
+			return
+		}
+	}
+}
+
+// accepts checks if the code with cmdConfig could be run by the JVMs of the pool:
+//	the code is run by the same command with the same environment, limits and isolation,
+//	and the classpath of the code differs from the classpath of the pool only in the entries with placeholders.
+func (p *JvmWarmPool) accepts(cmdConfig *CmdConfiguration, classpath []string) bool {
+	if cmdConfig.commandName != p.cmdConfig.commandName ||
+		!reflect.DeepEqual(envWithoutPipelineId(cmdConfig.env), envWithoutPipelineId(p.cmdConfig.env)) ||
+		cmdConfig.memoryLimit != p.cmdConfig.memoryLimit ||
+		cmdConfig.cpuTimeLimit != p.cmdConfig.cpuTimeLimit ||
+		cmdConfig.networkIsolation != p.cmdConfig.networkIsolation ||
+		!reflect.DeepEqual(cmdConfig.sandboxCmd, p.cmdConfig.sandboxCmd) ||
+		len(classpath) != len(p.classpath) {
+		return false
+	}
+	for i, entry := range p.classpath {
+		if entry != classpath[i] && !strings.Contains(entry, classpathPlaceholderPrefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// envWithoutPipelineId returns a copy of env without PipelineIdEnvKey variable
+func envWithoutPipelineId(env map[string]string) map[string]string {
+	result := make(map[string]string, len(env))
+	for key, value := range env {
+		if key != PipelineIdEnvKey {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// Cmd returns the command of the JVM, it is already started
+func (j *WarmJvm) Cmd() *exec.Cmd {
+	return j.cmd
+}
+
+// Stdout returns the pipe of the standard output of the JVM
+func (j *WarmJvm) Stdout() io.Reader {
+	return j.stdout
+}
+
+// Stderr returns the pipe of the standard error of the JVM
+func (j *WarmJvm) Stderr() io.Reader {
+	return j.stderr
+}
+
+// Wait waits for the JVM to exit the same way as exec.Cmd.Wait.
+// All reads from Stdout and Stderr should be completed before Wait is called.
+func (j *WarmJvm) Wait() error {
+	defer close(j.exited)
+	return j.cmd.Wait()
+}
+
+// dispatch sends the request to run the main class with the classpath, the system properties and the args to the JVM
+//	and then sends stdin to the code. The JVM is killed if ctx is done before the JVM exits.
+func (j *WarmJvm) dispatch(ctx context.Context, classpath string, properties []string, mainClass string, args []string, stdin string) error {
+	fields := append(append(append([]string{classpath, strconv.Itoa(len(properties))}, properties...), mainClass), args...)
+	encoded := make([]string, 0, len(fields))
+	for _, field := range fields {
+		encoded = append(encoded, base64.StdEncoding.EncodeToString([]byte(field)))
+	}
+	if _, err := io.WriteString(j.stdin, strings.Join(encoded, " ")+"\n"); err != nil {
+		return fmt.Errorf("couldn't dispatch the code to the JVM: %w", err)
+	}
+	go func() {
+		// the code could not read the whole input, so it is written concurrently like the input of exec.Cmd
+		if stdin != "" {
+			_, _ = io.WriteString(j.stdin, stdin)
+		}
+		_ = j.stdin.Close()
+	}()
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = SignalCmd(j.cmd, syscall.SIGKILL)
+		case <-j.exited:
+		}
+	}()
+	return nil
+}
+
+// kill kills the JVM and waits until it exits
+func (j *WarmJvm) kill() {
+	_ = SignalCmd(j.cmd, syscall.SIGKILL)
+	_ = j.stdin.Close()
+	_ = j.Wait()
+}
+
+// jvmClasspath returns entries of the classpath from the args of the java command (-cp, -classpath or --class-path).
+// Returns false if the args don't contain the classpath.
+func jvmClasspath(args []string) ([]string, bool) {
+	for i, arg := range args {
+		if isClasspathFlag(arg) && i+1 < len(args) {
+			return strings.Split(args[i+1], string(os.PathListSeparator)), true
+		}
+	}
+	return nil, false
+}
+
+// isClasspathFlag checks if arg is a flag of the java command which is followed by the classpath
+func isClasspathFlag(arg string) bool {
+	return arg == "-cp" || arg == "-classpath" || arg == "--class-path"
+}
@@ -0,0 +1,318 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeJavaScript is a fake java command which prints the decoded fields of the request line
+//	and then the rest of the standard input
+const fakeJavaScript = `#!/bin/sh
+read -r request
+for field in $request; do
+  printf '%s\n' "$(printf '%s' "$field" | base64 -d)"
+done
+cat
+`
+
+// helloWorldJava is the code which is run by the benchmarks
+const helloWorldJava = `public class HelloWorld {
+  public static void main(String[] args) {
+    System.out.println("Hello World!");
+  }
+}
+`
+
+// newFakeJava saves fakeJavaScript into dir and returns the path to it
+func newFakeJava(t *testing.T, dir string) string {
+	path := filepath.Join(dir, "java")
+	if err := os.WriteFile(path, []byte(fakeJavaScript), 0700); err != nil {
+		t.Fatalf("error during saving the fake java command: %s", err.Error())
+	}
+	return path
+}
+
+func TestExecutor_RunWarm(t *testing.T) {
+	if _, err := exec.LookPath("base64"); err != nil {
+		t.Skipf("base64 isn't installed")
+	}
+	dir := t.TempDir()
+	javaCmd := newFakeJava(t, dir)
+	poolRunArgs := []string{"-cp", "{compiledDir}:/opt/apache/beam/jars/*", "-Djava.util.logging.config.file={logConfigFile}"}
+	codeRunArgs := []string{"-cp", "/tmp/pipeline/bin:/opt/apache/beam/jars/*", "-Djava.util.logging.config.file=/tmp/pipeline/logging.properties"}
+	tests := []struct {
+		name     string
+		usePool  bool
+		runCmd   string
+		runArgs  []string
+		jvmArgs  []string
+		env      map[string]string
+		want     bool
+		wantLogs string
+	}{
+		{
+			// Test case with calling RunWarm method with the code which could be run by the pool.
+			// As a result, want to receive the JVM which the classpath, the properties, the main class,
+			//	the pipeline options and the standard input of the code are sent to.
+			name:    "code is dispatched to the pre-started JVM",
+			usePool: true,
+			runCmd:  javaCmd,
+			runArgs: codeRunArgs,
+			want:    true,
+			wantLogs: "/tmp/pipeline/bin:/opt/apache/beam/jars/*\n1\njava.util.logging.config.file=/tmp/pipeline/logging.properties\n" +
+				"HelloWorld\n--output=out\ninput\n",
+		},
+		{
+			// Test case with calling RunWarm method without the pool.
+			// As a result, want to receive false.
+			name:    "pool is nil",
+			usePool: false,
+			runCmd:  javaCmd,
+			runArgs: codeRunArgs,
+			want:    false,
+		},
+		{
+			// Test case with calling RunWarm method with the JVM flags of the code.
+			// As a result, want to receive false.
+			name:    "jvm args are set",
+			usePool: true,
+			runCmd:  javaCmd,
+			runArgs: codeRunArgs,
+			jvmArgs: []string{"-Xss4m"},
+			want:    false,
+		},
+		{
+			// Test case with calling RunWarm method with the flag of the java command which couldn't be applied to the pre-started JVM.
+			// As a result, want to receive false.
+			name:    "unsupported flag of the run command",
+			usePool: true,
+			runCmd:  javaCmd,
+			runArgs: append([]string{"-Xmx1g"}, codeRunArgs...),
+			want:    false,
+		},
+		{
+			// Test case with calling RunWarm method with the classpath which differs from the classpath of the pool.
+			// As a result, want to receive false.
+			name:    "classpath differs",
+			usePool: true,
+			runCmd:  javaCmd,
+			runArgs: []string{"-cp", "/tmp/pipeline/bin:/opt/apache/beam/jars/*:/tmp/dependency.jar"},
+			want:    false,
+		},
+		{
+			// Test case with calling RunWarm method with the run command which differs from the command of the pool.
+			// As a result, want to receive false.
+			name:    "run command differs",
+			usePool: true,
+			runCmd:  "java",
+			runArgs: codeRunArgs,
+			want:    false,
+		},
+		{
+			// Test case with calling RunWarm method with the environment which differs from the environment of the pool.
+			// As a result, want to receive false.
+			name:    "env differs",
+			usePool: true,
+			runCmd:  javaCmd,
+			runArgs: codeRunArgs,
+			env:     map[string]string{"KEY": "value"},
+			want:    false,
+		},
+		{
+			// Test case with calling RunWarm method with the environment which differs only by the pipeline ID.
+			// As a result, want to receive the JVM which the code is sent to.
+			name:    "env differs by pipeline id",
+			usePool: true,
+			runCmd:  javaCmd,
+			runArgs: codeRunArgs,
+			env:     map[string]string{PipelineIdEnvKey: "pipelineId"},
+			want:    true,
+			wantLogs: "/tmp/pipeline/bin:/opt/apache/beam/jars/*\n1\njava.util.logging.config.file=/tmp/pipeline/logging.properties\n" +
+				"HelloWorld\n--output=out\ninput\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pool *JvmWarmPool
+			if tt.usePool {
+				var err error
+				pool, err = NewJvmWarmPool(context.Background(), 1, filepath.Join(t.TempDir(), "launcher"), "true", javaCmd, poolRunArgs, nil, 0, 0, false, nil)
+				if err != nil {
+					t.Fatalf("NewJvmWarmPool() error = %v", err)
+				}
+				defer pool.Close()
+			}
+			ex := NewExecutorBuilder().
+				WithExecutableFileName("HelloWorld").
+				WithStdin("input\n").
+				WithEnv(tt.env).
+				WithJvmArgs(tt.jvmArgs).
+				WithRunner().
+				WithCommand(tt.runCmd).
+				WithArgs(tt.runArgs).
+				WithPipelineOptions([]string{"--output=out"}).
+				Build()
+			jvm, got := ex.RunWarm(context.Background(), pool)
+			if got != tt.want {
+				t.Fatalf("RunWarm() got = %v, want %v", got, tt.want)
+			}
+			if !got {
+				return
+			}
+			logs, err := ioutil.ReadAll(jvm.Stdout())
+			if err != nil {
+				t.Fatalf("error during reading the output of the JVM: %s", err.Error())
+			}
+			if _, err := ioutil.ReadAll(jvm.Stderr()); err != nil {
+				t.Fatalf("error during reading the error output of the JVM: %s", err.Error())
+			}
+			if err := jvm.Wait(); err != nil {
+				t.Errorf("JVM exited with error: %s", err.Error())
+			}
+			if string(logs) != tt.wantLogs {
+				t.Errorf("RunWarm() sent to the JVM %q, want %q", logs, tt.wantLogs)
+			}
+			if len(pool.jvms) != 1 {
+				t.Errorf("RunWarm() left %d pre-started JVMs in the pool, want 1", len(pool.jvms))
+			}
+		})
+	}
+}
+
+func TestJvmWarmPool_Close(t *testing.T) {
+	if _, err := exec.LookPath("base64"); err != nil {
+		t.Skipf("base64 isn't installed")
+	}
+	dir := t.TempDir()
+	pool, err := NewJvmWarmPool(context.Background(), 2, filepath.Join(dir, "launcher"), "true", newFakeJava(t, dir), []string{"-cp", "{compiledDir}:"}, nil, 0, 0, false, nil)
+	if err != nil {
+		t.Fatalf("NewJvmWarmPool() error = %v", err)
+	}
+	pool.Close()
+	if len(pool.jvms) != 0 {
+		t.Errorf("Close() left %d pre-started JVMs in the pool, want 0", len(pool.jvms))
+	}
+	if _, ok := pool.take(); ok {
+		t.Errorf("take() returned the JVM of the closed pool")
+	}
+	// Close of nil pool does nothing
+	var nilPool *JvmWarmPool
+	nilPool.Close()
+}
+
+func TestNewJvmWarmPool(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name       string
+		compileCmd string
+		runArgs    []string
+		wantErr    bool
+	}{
+		{
+			// Test case with calling NewJvmWarmPool method with the run args without the classpath.
+			// As a result, want to receive an error.
+			name:       "run args without classpath",
+			compileCmd: "true",
+			runArgs:    []string{"-Dkey=value"},
+			wantErr:    true,
+		},
+		{
+			// Test case with calling NewJvmWarmPool method with the failing compile command.
+			// As a result, want to receive an error.
+			name:       "launcher isn't compiled",
+			compileCmd: "false",
+			runArgs:    []string{"-cp", "{compiledDir}:"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, err := NewJvmWarmPool(context.Background(), 1, filepath.Join(dir, "launcher"), tt.compileCmd, "java", tt.runArgs, nil, 0, 0, false, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewJvmWarmPool() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			pool.Close()
+		})
+	}
+}
+
+// newBenchmarkExecutor compiles helloWorldJava and returns the executor which runs it with the real java command
+func newBenchmarkExecutor(b *testing.B) (Executor, []string) {
+	for _, cmd := range []string{"java", "javac"} {
+		if _, err := exec.LookPath(cmd); err != nil {
+			b.Skipf("%s isn't installed", cmd)
+		}
+	}
+	dir := b.TempDir()
+	sourcePath := filepath.Join(dir, "HelloWorld.java")
+	if err := os.WriteFile(sourcePath, []byte(helloWorldJava), 0600); err != nil {
+		b.Fatalf("error during saving the code: %s", err.Error())
+	}
+	if output, err := exec.Command("javac", "-d", dir, sourcePath).CombinedOutput(); err != nil {
+		b.Fatalf("error during compiling the code: %s, output: %s", err.Error(), output)
+	}
+	runArgs := []string{"-cp", dir}
+	ex := NewExecutorBuilder().
+		WithExecutableFileName("HelloWorld").
+		WithWorkingDir(dir).
+		WithRunner().
+		WithCommand("java").
+		WithArgs(runArgs).
+		WithPipelineOptions([]string{""}).
+		Build()
+	return ex, runArgs
+}
+
+// BenchmarkExecutor_RunCold measures the run of the code by a new JVM
+func BenchmarkExecutor_RunCold(b *testing.B) {
+	ex, _ := newBenchmarkExecutor(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		output, err := ex.Run(context.Background()).Output()
+		if err != nil || !strings.Contains(string(output), "Hello World!") {
+			b.Fatalf("unexpected result of the run: %v, output: %s", err, output)
+		}
+	}
+}
+
+// BenchmarkExecutor_RunWarm measures the run of the code by a pre-started JVM of JvmWarmPool
+func BenchmarkExecutor_RunWarm(b *testing.B) {
+	ex, runArgs := newBenchmarkExecutor(b)
+	pool, err := NewJvmWarmPool(context.Background(), 2, filepath.Join(b.TempDir(), "launcher"), "javac", "java", runArgs, nil, 0, 0, false, nil)
+	if err != nil {
+		b.Fatalf("NewJvmWarmPool() error = %v", err)
+	}
+	defer pool.Close()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		jvm, ok := ex.RunWarm(context.Background(), pool)
+		if !ok {
+			b.Fatalf("RunWarm() didn't dispatch the code to the pre-started JVM")
+		}
+		output, _ := ioutil.ReadAll(jvm.Stdout())
+		_, _ = ioutil.ReadAll(jvm.Stderr())
+		if err := jvm.Wait(); err != nil || !strings.Contains(string(output), "Hello World!") {
+			b.Fatalf("unexpected result of the run: %v, output: %s", err, output)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"context"
+	"syscall"
+	"testing"
+)
+
+func TestExecutor_RunWithNetworkIsolation(t *testing.T) {
+	tests := []struct {
+		name             string
+		networkIsolation bool
+		sandboxCmd       []string
+		wantNewNetwork   bool
+	}{
+		{
+			// Test case with calling Run method without network isolation.
+			// As a result the command should be run in the network namespace of the server.
+			name:             "network isn't isolated",
+			networkIsolation: false,
+			wantNewNetwork:   false,
+		},
+		{
+			// Test case with calling Run method with network isolation.
+			// As a result the command should be run in a new network namespace.
+			name:             "network is isolated",
+			networkIsolation: true,
+			wantNewNetwork:   true,
+		},
+		{
+			// Test case with calling Run method with network isolation and sandbox command.
+			// As a result the command should be wrapped with the sandbox command instead of a new network namespace.
+			name:             "network is isolated by sandbox command",
+			networkIsolation: true,
+			sandboxCmd:       []string{"testSandbox"},
+			wantNewNetwork:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex := NewExecutorBuilder().
+				WithRunner().
+				WithCommand("testCommand").
+				WithPipelineOptions([]string{""}).
+				ExecutorBuilder.
+				WithNetworkIsolation(tt.networkIsolation, tt.sandboxCmd).
+				Build()
+			cmd := ex.Run(context.Background())
+			gotNewNetwork := cmd.SysProcAttr != nil && cmd.SysProcAttr.Cloneflags&syscall.CLONE_NEWNET != 0
+			if gotNewNetwork != tt.wantNewNetwork {
+				t.Errorf("Run() runs command in a new network namespace = %v, want %v", gotNewNetwork, tt.wantNewNetwork)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+package executors
+
+import (
+	"beam.apache.org/playground/backend/internal/logger"
+	"syscall"
+)
+
+// networkNamespaceAttr returns nil since network namespaces are supported only on Linux.
+// The process isn't isolated from the network, so the sandbox command should be used instead.
+func networkNamespaceAttr() *syscall.SysProcAttr {
+	logger.Warnf("network isolation is supported only on Linux, use the sandbox command to isolate the executed code")
+	return nil
+}
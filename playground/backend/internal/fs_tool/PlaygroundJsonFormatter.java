@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import java.io.PrintWriter;
+import java.io.StringWriter;
+import java.time.Instant;
+import java.util.logging.Formatter;
+import java.util.logging.LogRecord;
+
+/**
+ * Formatter of the Playground backend which prints the records of java.util.logging as JSON lines.
+ *
+ * <p>Each record is printed as one JSON object with the timestamp, level, logger and message of the
+ * record, and the stack trace of the exception if the record has it.
+ */
+public class PlaygroundJsonFormatter extends Formatter {
+
+  @Override
+  public String format(LogRecord record) {
+    StringBuilder json = new StringBuilder("{");
+    appendField(json, "timestamp", Instant.ofEpochMilli(record.getMillis()).toString());
+    json.append(',');
+    appendField(json, "level", record.getLevel().getName());
+    json.append(',');
+    appendField(json, "logger", record.getLoggerName() == null ? "" : record.getLoggerName());
+    json.append(',');
+    appendField(json, "message", formatMessage(record));
+    if (record.getThrown() != null) {
+      StringWriter stackTrace = new StringWriter();
+      record.getThrown().printStackTrace(new PrintWriter(stackTrace));
+      json.append(',');
+      appendField(json, "exception", stackTrace.toString());
+    }
+    return json.append("}\n").toString();
+  }
+
+  private static void appendField(StringBuilder json, String name, String value) {
+    json.append('"').append(name).append("\":\"");
+    for (char c : value.toCharArray()) {
+      switch (c) {
+        case '"':
+          json.append("\\\"");
+          break;
+        case '\\':
+          json.append("\\\\");
+          break;
+        case '\n':
+          json.append("\\n");
+          break;
+        case '\r':
+          json.append("\\r");
+          break;
+        case '\t':
+          json.append("\\t");
+          break;
+        default:
+          if (c < 0x20) {
+            json.append(String.format("\\u%04x", (int) c));
+          } else {
+            json.append(c);
+          }
+      }
+    }
+    json.append('"');
+  }
+}
@@ -17,17 +17,21 @@ package fs_tool
 
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 const (
-	fileMode    = 0600
-	logFileName = "logs.log"
+	fileMode          = 0600
+	logFileName       = "logs.log"
+	runOutputFileName = "run_output.log"
+	graphFileName     = "pipeline_graph.dot"
 )
 
 // Folder contains names of folders with executable and compiled files.
@@ -45,29 +49,50 @@ type Extension struct {
 	ExecutableFileExtension string
 }
 
+// CodeFile represents one file of the code which is split across several files.
+type CodeFile struct {
+	// Name is a name of the file with extension (i.e. Helper.java)
+	Name string
+	// Content is a code of the file
+	Content string
+	// IsMain is true for the main (entry) file of the code
+	IsMain bool
+}
+
 // LifeCycle is used for preparing folders and files to process code for one request.
 // For each SDK folders (Folder) and extensions (Extension) should be set correctly.
 type LifeCycle struct {
-	folderGlobs    []string //folders that should be created to process code
-	Folder         Folder
-	Extension      Extension
-	ExecutableName func(uuid.UUID, string) (string, error)
-	pipelineId     uuid.UUID
+	folderGlobs         []string //folders that should be created to process code
+	Folder              Folder
+	Extension           Extension
+	ExecutableName      func(uuid.UUID, string) (string, error)
+	pipelineId          uuid.UUID
+	additionalFileNames []string //names of the source files which are created in addition to the main source file
+	sourceFileName      string   //name of the main source file if it is set by SetSourceFileName
+	sdk                 pb.Sdk
+	jsonLogs            bool //the logs of the executed code are printed as JSON lines (see EnableJsonLogs)
 }
 
 // NewLifeCycle returns a corresponding LifeCycle depending on the given SDK.
 // workingDir should be existed and be prepared to create/delete/modify folders into him.
 func NewLifeCycle(sdk pb.Sdk, pipelineId uuid.UUID, workingDir string) (*LifeCycle, error) {
+	var lc *LifeCycle
 	switch sdk {
 	case pb.Sdk_SDK_JAVA:
-		return newJavaLifeCycle(pipelineId, workingDir), nil
+		lc = newJavaLifeCycle(pipelineId, workingDir)
 	case pb.Sdk_SDK_GO:
-		return newGoLifeCycle(pipelineId, workingDir), nil
+		lc = newGoLifeCycle(pipelineId, workingDir)
 	case pb.Sdk_SDK_PYTHON:
-		return newPythonLifeCycle(pipelineId, workingDir), nil
+		lc = newPythonLifeCycle(pipelineId, workingDir)
+	case pb.Sdk_SDK_SCIO:
+		lc = newScioLifeCycle(pipelineId, workingDir)
+	case pb.Sdk_SDK_KOTLIN:
+		lc = newKotlinLifeCycle(pipelineId, workingDir)
 	default:
 		return nil, fmt.Errorf("%s isn't supported now", sdk)
 	}
+	lc.sdk = sdk
+	return lc, nil
 }
 
 // CreateFolders creates all folders which will be used for code execution.
@@ -92,13 +117,43 @@ func (l *LifeCycle) DeleteFolders() error {
 	return nil
 }
 
-// CreateSourceCodeFile creates an executable file (i.e. file.{sourceFileExtension}).
+// Cleanup deletes the folder of the pipeline with all its content,
+// including files which are created during the code processing outside of the provisioned folders.
+// It doesn't return an error if the folder is already deleted.
+func (l *LifeCycle) Cleanup() error {
+	return os.RemoveAll(l.Folder.BaseFolder)
+}
+
+// SetSourceFileName sets the name of the main source file of the code (i.e. WordCount.java instead of {pipelineId}.java),
+//	so the code could rely on it (i.e. the public class of Java code should be declared in the file with the same name).
+// The extension of the SDK is added if fileName doesn't have it.
+// It should be called before the source files are created.
+// Returns an error if fileName doesn't match the rules of the language of the SDK (see ValidateSourceFileName).
+func (l *LifeCycle) SetSourceFileName(fileName string) error {
+	fileName, err := ValidateSourceFileName(l.sdk, fileName)
+	if err != nil {
+		return err
+	}
+	l.sourceFileName = fileName
+	return nil
+}
+
+// mainSourceFileName returns the name of the main source file of the code.
+// It is {pipelineId}.{sourceFileExtension} if the name isn't set by SetSourceFileName.
+func (l *LifeCycle) mainSourceFileName() string {
+	if l.sourceFileName != "" {
+		return l.sourceFileName
+	}
+	return l.pipelineId.String() + l.Extension.SourceFileExtension
+}
+
+// CreateSourceCodeFile creates an executable file (i.e. file.{sourceFileExtension} or the file with the name set by SetSourceFileName).
 func (l *LifeCycle) CreateSourceCodeFile(code string) (string, error) {
 	if _, err := os.Stat(l.Folder.SourceFileFolder); os.IsNotExist(err) {
 		return "", err
 	}
 
-	fileName := l.pipelineId.String() + l.Extension.SourceFileExtension
+	fileName := l.mainSourceFileName()
 	filePath := filepath.Join(l.Folder.SourceFileFolder, fileName)
 	err := os.WriteFile(filePath, []byte(code), fileMode)
 	if err != nil {
@@ -107,14 +162,95 @@ func (l *LifeCycle) CreateSourceCodeFile(code string) (string, error) {
 	return fileName, nil
 }
 
-// GetAbsoluteSourceFilePath returns absolute filepath to executable file (/path/to/workingDir/executable_files/{pipelineId}/src/{pipelineId}.{sourceFileExtension}).
-func (l *LifeCycle) GetAbsoluteSourceFilePath() string {
-	fileName := l.pipelineId.String() + l.Extension.SourceFileExtension
+// CreateSourceCodeFiles creates source files for the code which is split across several files.
+// The main file (the file with IsMain or the first file if there is no such file) is created as an executable file
+//	(i.e. file.{sourceFileExtension}), other files are created next to it with their own names.
+// Returns an error if there are several main files or if some file has an incorrect or duplicated name.
+func (l *LifeCycle) CreateSourceCodeFiles(files []CodeFile) error {
+	if len(files) == 0 {
+		return errors.New("at least one source file should be provided")
+	}
+	mainFileIndex := 0
+	mainFilesCount := 0
+	for i, file := range files {
+		if file.IsMain {
+			mainFileIndex = i
+			mainFilesCount++
+		}
+	}
+	if mainFilesCount > 1 {
+		return fmt.Errorf("only one main file should be provided, but got %d", mainFilesCount)
+	}
+
+	fileNames := map[string]bool{l.mainSourceFileName(): true}
+	for i, file := range files {
+		if i == mainFileIndex {
+			continue
+		}
+		if filepath.Base(file.Name) != file.Name || filepath.Ext(file.Name) != l.Extension.SourceFileExtension {
+			return fmt.Errorf("incorrect name of the source file: %s", file.Name)
+		}
+		if fileNames[file.Name] {
+			return fmt.Errorf("duplicated name of the source file: %s", file.Name)
+		}
+		fileNames[file.Name] = true
+	}
+
+	if _, err := l.CreateSourceCodeFile(files[mainFileIndex].Content); err != nil {
+		return err
+	}
+	for i, file := range files {
+		if i == mainFileIndex {
+			continue
+		}
+		filePath := filepath.Join(l.Folder.SourceFileFolder, file.Name)
+		if err := os.WriteFile(filePath, []byte(file.Content), fileMode); err != nil {
+			return err
+		}
+		l.additionalFileNames = append(l.additionalFileNames, file.Name)
+	}
+	return nil
+}
+
+// AddSourceCodeFile creates a source file with fileName next to the main source file,
+//	so it is compiled together with the code (i.e. the code provided by the server, not by the user).
+// Returns an error if fileName is incorrect or the file already exists.
+func (l *LifeCycle) AddSourceCodeFile(fileName string, content []byte) error {
+	if filepath.Base(fileName) != fileName {
+		return fmt.Errorf("incorrect name of the source file: %s", fileName)
+	}
 	filePath := filepath.Join(l.Folder.SourceFileFolder, fileName)
+	if _, err := os.Stat(filePath); err == nil {
+		return fmt.Errorf("source file already exists: %s", fileName)
+	}
+	if err := os.WriteFile(filePath, content, fileMode); err != nil {
+		return err
+	}
+	l.additionalFileNames = append(l.additionalFileNames, fileName)
+	return nil
+}
+
+// GetAbsoluteSourceFilePath returns absolute filepath to the main (entry) source file of the code
+// (/path/to/workingDir/executable_files/{pipelineId}/src/{pipelineId}.{sourceFileExtension}
+// or the file with the name set by SetSourceFileName).
+func (l *LifeCycle) GetAbsoluteSourceFilePath() string {
+	filePath := filepath.Join(l.Folder.SourceFileFolder, l.mainSourceFileName())
 	absoluteFilePath, _ := filepath.Abs(filePath)
 	return absoluteFilePath
 }
 
+// GetAbsoluteSourceFilePaths returns absolute filepaths to all source files of the code.
+// The first one is the main source file (the same as GetAbsoluteSourceFilePath returns),
+//	the rest are files created by CreateSourceCodeFiles in addition to the main source file.
+func (l *LifeCycle) GetAbsoluteSourceFilePaths() []string {
+	filePaths := []string{l.GetAbsoluteSourceFilePath()}
+	for _, fileName := range l.additionalFileNames {
+		absoluteFilePath, _ := filepath.Abs(filepath.Join(l.Folder.SourceFileFolder, fileName))
+		filePaths = append(filePaths, absoluteFilePath)
+	}
+	return filePaths
+}
+
 // CopyFile copies a file with fileName from sourceDir to destinationDir.
 func (l *LifeCycle) CopyFile(fileName, sourceDir, destinationDir string) error {
 	absSourcePath := filepath.Join(sourceDir, fileName)
@@ -146,23 +282,136 @@ func (l *LifeCycle) CopyFile(fileName, sourceDir, destinationDir string) error {
 	return nil
 }
 
+// CopyCodeFiles copies the source files and the compiled files of the code from the folders of source LifeCycle,
+//	so the code processed by the pipeline of source could be run again by the pipeline of l without compilation.
+// For compiled SDKs all files of the source folder (i.e. go.mod) are copied, for interpreted SDKs only the source files.
+// The id of the pipeline of source is replaced by the id of the pipeline of l in the names of the copied files.
+// The folders of l should be created before.
+func (l *LifeCycle) CopyCodeFiles(source *LifeCycle) error {
+	oldName, newName := source.pipelineId.String(), l.pipelineId.String()
+	if source.Folder.SourceFileFolder == source.Folder.BaseFolder {
+		for _, filePath := range source.GetAbsoluteSourceFilePaths() {
+			info, err := os.Stat(filePath)
+			if err != nil {
+				return err
+			}
+			destinationPath := filepath.Join(l.Folder.SourceFileFolder, strings.ReplaceAll(filepath.Base(filePath), oldName, newName))
+			if err := copyFile(filePath, destinationPath, info.Mode().Perm()); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := CopyFolder(source.Folder.SourceFileFolder, l.Folder.SourceFileFolder, oldName, newName); err != nil {
+			return err
+		}
+		if err := CopyFolder(source.Folder.ExecutableFileFolder, l.Folder.ExecutableFileFolder, oldName, newName); err != nil {
+			return err
+		}
+	}
+	l.sourceFileName = source.sourceFileName
+	l.additionalFileNames = append([]string(nil), source.additionalFileNames...)
+	return nil
+}
+
+// CopyFolder copies the regular files from sourceDir to destinationDir keeping their modes and the structure of the folders.
+// oldName is replaced by newName in the paths of the copied files.
+func CopyFolder(sourceDir, destinationDir, oldName, newName string) error {
+	return filepath.WalkDir(sourceDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		destinationPath := filepath.Join(destinationDir, strings.ReplaceAll(relativePath, oldName, newName))
+		if entry.IsDir() {
+			return os.MkdirAll(destinationPath, fs.ModePerm)
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		return copyFile(path, destinationPath, info.Mode().Perm())
+	})
+}
+
+// copyFile copies the file from sourcePath to destinationPath with the mode.
+func copyFile(sourcePath, destinationPath string, mode fs.FileMode) error {
+	sourceFile, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+	destinationFile, err := os.OpenFile(destinationPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(destinationFile, sourceFile); err != nil {
+		_ = destinationFile.Close()
+		return err
+	}
+	return destinationFile.Close()
+}
+
 // GetAbsoluteExecutableFilePath returns absolute filepath to compiled file (/path/to/workingDir/executable_files/{pipelineId}/bin/{pipelineId}.{executableExtension}).
+// For interpreted SDKs the main source file is executed, so its filepath is returned.
 func (l *LifeCycle) GetAbsoluteExecutableFilePath() string {
 	fileName := l.pipelineId.String() + l.Extension.ExecutableFileExtension
+	if l.Extension.ExecutableFileExtension == l.Extension.SourceFileExtension {
+		fileName = l.mainSourceFileName()
+	}
 	filePath := filepath.Join(l.Folder.ExecutableFileFolder, fileName)
 	absoluteFilePath, _ := filepath.Abs(filePath)
 	return absoluteFilePath
 }
 
+// GetAbsoluteCompiledFolderPath returns absolute path to the folder with compiled files (/path/to/workingDir/executable_files/{pipelineId}/bin).
+// Each pipeline has its own folder, so compiled files of pipelines which are processed at the same time don't clash.
+func (l *LifeCycle) GetAbsoluteCompiledFolderPath() string {
+	absoluteFolderPath, _ := filepath.Abs(l.Folder.ExecutableFileFolder)
+	return absoluteFolderPath
+}
+
 // GetAbsoluteBaseFolderPath returns absolute path to executable folder (/path/to/workingDir/executable_files/{pipelineId}).
 func (l *LifeCycle) GetAbsoluteBaseFolderPath() string {
 	absoluteFilePath, _ := filepath.Abs(l.Folder.BaseFolder)
 	return absoluteFilePath
 }
 
+// PipelineId returns id of the pipeline which the folders and files of LifeCycle are prepared for.
+func (l *LifeCycle) PipelineId() uuid.UUID {
+	return l.pipelineId
+}
+
+// WorkingDir returns the dir which the folder of the pipeline is created in (/path/to/workingDir),
+// i.e. the execution root of the server.
+func (l *LifeCycle) WorkingDir() string {
+	return filepath.Dir(filepath.Dir(l.Folder.BaseFolder))
+}
+
 // GetAbsoluteLogFilePath returns absolute path to the logs file (/path/to/workingDir/executable_files/{pipelineId}/logs.log)
 func (l *LifeCycle) GetAbsoluteLogFilePath() string {
 	filePath := filepath.Join(l.Folder.BaseFolder, logFileName)
 	absoluteFilePath, _ := filepath.Abs(filePath)
 	return absoluteFilePath
 }
+
+// GetAbsoluteGraphFilePath returns absolute path to the file which the code saves graph of the pipeline to while it is run
+//	(/path/to/workingDir/executable_files/{pipelineId}/pipeline_graph.dot)
+func (l *LifeCycle) GetAbsoluteGraphFilePath() string {
+	filePath := filepath.Join(l.Folder.BaseFolder, graphFileName)
+	absoluteFilePath, _ := filepath.Abs(filePath)
+	return absoluteFilePath
+}
+
+// GetAbsoluteRunOutputFilePath returns absolute path to the file with the full run output which exceeds the max output size
+//	(/path/to/workingDir/executable_files/{pipelineId}/run_output.log)
+func (l *LifeCycle) GetAbsoluteRunOutputFilePath() string {
+	filePath := filepath.Join(l.Folder.BaseFolder, runOutputFileName)
+	absoluteFilePath, _ := filepath.Abs(filePath)
+	return absoluteFilePath
+}
@@ -24,6 +24,8 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -142,6 +144,174 @@ func TestLifeCycle_CreateExecutableFile(t *testing.T) {
 	}
 }
 
+func TestLifeCycle_CreateSourceCodeFiles(t *testing.T) {
+	pipelineId := uuid.New()
+	baseFileFolder := fmt.Sprintf("%s_%s", baseFileFolder, pipelineId)
+	srcFileFolder := baseFileFolder + "/src"
+	mainFileName := pipelineId.String() + javaSourceFileExtension
+	mainFile := CodeFile{Name: "HelloWorld.java", Content: "MAIN_CODE", IsMain: true}
+	helperFile := CodeFile{Name: "Helper.java", Content: "HELPER_CODE"}
+
+	type args struct {
+		files []CodeFile
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantFiles     map[string]string
+		wantFilePaths []string
+		wantErr       bool
+	}{
+		{
+			// Test case with calling CreateSourceCodeFiles method without files.
+			// As a result, want to receive an error.
+			name:    "no files",
+			args:    args{files: []CodeFile{}},
+			wantErr: true,
+		},
+		{
+			// Test case with calling CreateSourceCodeFiles method with several main files.
+			// As a result, want to receive an error.
+			name:    "several main files",
+			args:    args{files: []CodeFile{mainFile, mainFile}},
+			wantErr: true,
+		},
+		{
+			// Test case with calling CreateSourceCodeFiles method with file which name contains a path.
+			// As a result, want to receive an error.
+			name:    "incorrect file name",
+			args:    args{files: []CodeFile{mainFile, {Name: "../Helper.java", Content: "HELPER_CODE"}}},
+			wantErr: true,
+		},
+		{
+			// Test case with calling CreateSourceCodeFiles method with file which has incorrect extension.
+			// As a result, want to receive an error.
+			name:    "incorrect file extension",
+			args:    args{files: []CodeFile{mainFile, {Name: "Helper.py", Content: "HELPER_CODE"}}},
+			wantErr: true,
+		},
+		{
+			// Test case with calling CreateSourceCodeFiles method with files with the same name.
+			// As a result, want to receive an error.
+			name:    "duplicated file names",
+			args:    args{files: []CodeFile{mainFile, helperFile, helperFile}},
+			wantErr: true,
+		},
+		{
+			// Test case with calling CreateSourceCodeFiles method with the main file and the helper file.
+			// As a result, want to receive the main file created as an executable file and the helper file created with its own name.
+			name: "main and helper files",
+			args: args{files: []CodeFile{helperFile, mainFile}},
+			wantFiles: map[string]string{
+				mainFileName:    mainFile.Content,
+				helperFile.Name: helperFile.Content,
+			},
+			wantFilePaths: []string{filepath.Join(srcFileFolder, mainFileName), filepath.Join(srcFileFolder, helperFile.Name)},
+			wantErr:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.MkdirAll(srcFileFolder, fs.ModePerm); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			defer os.RemoveAll(baseFileFolder)
+			l := &LifeCycle{
+				Folder:     Folder{SourceFileFolder: srcFileFolder},
+				Extension:  Extension{SourceFileExtension: javaSourceFileExtension},
+				pipelineId: pipelineId,
+			}
+			err := l.CreateSourceCodeFiles(tt.args.files)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateSourceCodeFiles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for fileName, wantContent := range tt.wantFiles {
+				content, err := os.ReadFile(filepath.Join(srcFileFolder, fileName))
+				if err != nil || string(content) != wantContent {
+					t.Errorf("CreateSourceCodeFiles() file %s content = %s, want %s", fileName, content, wantContent)
+				}
+			}
+			if !tt.wantErr {
+				for i, filePath := range tt.wantFilePaths {
+					tt.wantFilePaths[i], _ = filepath.Abs(filePath)
+				}
+				if got := l.GetAbsoluteSourceFilePaths(); !reflect.DeepEqual(got, tt.wantFilePaths) {
+					t.Errorf("GetAbsoluteSourceFilePaths() got = %v, want %v", got, tt.wantFilePaths)
+				}
+			}
+		})
+	}
+}
+
+func TestLifeCycle_AddSourceCodeFile(t *testing.T) {
+	pipelineId := uuid.New()
+	baseFileFolder := fmt.Sprintf("%s_%s", baseFileFolder, pipelineId)
+	srcFileFolder := baseFileFolder + "/src"
+	mainFileName := pipelineId.String() + javaSourceFileExtension
+	if err := os.MkdirAll(srcFileFolder, fs.ModePerm); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	defer os.RemoveAll(baseFileFolder)
+	l := &LifeCycle{
+		Folder:     Folder{SourceFileFolder: srcFileFolder},
+		Extension:  Extension{SourceFileExtension: javaSourceFileExtension},
+		pipelineId: pipelineId,
+	}
+	if _, err := l.CreateSourceCodeFile("MAIN_CODE"); err != nil {
+		t.Fatalf("error during prepare the main file: %s", err.Error())
+	}
+
+	tests := []struct {
+		name     string
+		fileName string
+		wantErr  bool
+	}{
+		{
+			// Test case with calling AddSourceCodeFile method with a new file.
+			// As a result, want to receive the file created next to the main file.
+			name:     "new file",
+			fileName: "Helper.java",
+			wantErr:  false,
+		},
+		{
+			// Test case with calling AddSourceCodeFile method with the name of the existing file.
+			// As a result, want to receive an error.
+			name:     "file already exists",
+			fileName: mainFileName,
+			wantErr:  true,
+		},
+		{
+			// Test case with calling AddSourceCodeFile method with file which name contains a path.
+			// As a result, want to receive an error.
+			name:     "incorrect file name",
+			fileName: "../Helper.java",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := l.AddSourceCodeFile(tt.fileName, []byte("HELPER_CODE"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AddSourceCodeFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			content, err := os.ReadFile(filepath.Join(srcFileFolder, tt.fileName))
+			if err != nil || string(content) != "HELPER_CODE" {
+				t.Errorf("AddSourceCodeFile() file %s content = %s, want %s", tt.fileName, content, "HELPER_CODE")
+			}
+		})
+	}
+	mainFilePath, _ := filepath.Abs(filepath.Join(srcFileFolder, mainFileName))
+	helperFilePath, _ := filepath.Abs(filepath.Join(srcFileFolder, "Helper.java"))
+	if got := l.GetAbsoluteSourceFilePaths(); !reflect.DeepEqual(got, []string{mainFilePath, helperFilePath}) {
+		t.Errorf("GetAbsoluteSourceFilePaths() got = %v, want %v", got, []string{mainFilePath, helperFilePath})
+	}
+}
+
 func TestLifeCycle_CreateFolders(t *testing.T) {
 	pipelineId := uuid.New()
 	baseFileFolder := fmt.Sprintf("%s_%s", baseFileFolder, pipelineId)
@@ -223,6 +393,53 @@ func TestLifeCycle_DeleteFolders(t *testing.T) {
 	}
 }
 
+func TestLifeCycle_Cleanup(t *testing.T) {
+	workingDir := t.TempDir()
+	tests := []struct {
+		name        string
+		createFiles bool
+	}{
+		{
+			// Test case with calling Cleanup method when folders of the pipeline contain the code and other files.
+			// As a result, want to receive no error and the folder of the pipeline should be deleted.
+			name:        "folders with files",
+			createFiles: true,
+		},
+		{
+			// Test case with calling Cleanup method when folders of the pipeline aren't created.
+			// As a result, want to receive no error.
+			name:        "folders don't exist",
+			createFiles: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc, _ := NewLifeCycle(pb.Sdk_SDK_JAVA, uuid.New(), workingDir)
+			if tt.createFiles {
+				if err := lc.CreateFolders(); err != nil {
+					t.Fatalf("error during prepare folders: %s", err.Error())
+				}
+				if _, err := lc.CreateSourceCodeFile("MOCK_CODE"); err != nil {
+					t.Fatalf("error during create source file: %s", err.Error())
+				}
+				// the log file is created outside of the source and executable folders
+				if err := os.WriteFile(lc.GetAbsoluteLogFilePath(), []byte("MOCK_LOGS"), 0600); err != nil {
+					t.Fatalf("error during create log file: %s", err.Error())
+				}
+				if err := os.WriteFile(lc.GetAbsoluteRunOutputFilePath(), []byte("MOCK_RUN_OUTPUT"), 0600); err != nil {
+					t.Fatalf("error during create run output file: %s", err.Error())
+				}
+			}
+			if err := lc.Cleanup(); err != nil {
+				t.Errorf("Cleanup() error = %v", err)
+			}
+			if _, err := os.Stat(lc.Folder.BaseFolder); !os.IsNotExist(err) {
+				t.Errorf("Cleanup() didn't delete folder %s", lc.Folder.BaseFolder)
+			}
+		})
+	}
+}
+
 func TestNewLifeCycle(t *testing.T) {
 	pipelineId := uuid.New()
 	workingDir := "workingDir"
@@ -259,8 +476,7 @@ func TestNewLifeCycle(t *testing.T) {
 					SourceFileExtension:     javaSourceFileExtension,
 					ExecutableFileExtension: javaCompiledFileExtension,
 				},
-				ExecutableName: executableName,
-				pipelineId:     pipelineId,
+				pipelineId: pipelineId,
 			},
 			wantErr: false,
 		},
@@ -389,6 +605,32 @@ func TestLifeCycle_GetAbsoluteExecutableFilesFolderPath(t *testing.T) {
 	}
 }
 
+func TestLifeCycle_GetAbsoluteCompiledFolderPath(t *testing.T) {
+	firstLc, _ := NewLifeCycle(pb.Sdk_SDK_JAVA, uuid.New(), "")
+	secondLc, _ := NewLifeCycle(pb.Sdk_SDK_JAVA, uuid.New(), "")
+
+	want, _ := filepath.Abs(filepath.Join(firstLc.Folder.BaseFolder, compiledFolderName))
+	if got := firstLc.GetAbsoluteCompiledFolderPath(); got != want {
+		t.Errorf("GetAbsoluteCompiledFolderPath() got = %v, want %v", got, want)
+	}
+	if firstLc.GetAbsoluteCompiledFolderPath() == secondLc.GetAbsoluteCompiledFolderPath() {
+		t.Errorf("GetAbsoluteCompiledFolderPath() returns the same folder for different pipelines: %v", firstLc.GetAbsoluteCompiledFolderPath())
+	}
+}
+
+func TestLifeCycle_PipelineIdAndWorkingDir(t *testing.T) {
+	pipelineId := uuid.New()
+	for _, sdk := range []pb.Sdk{pb.Sdk_SDK_JAVA, pb.Sdk_SDK_PYTHON} {
+		lc, _ := NewLifeCycle(sdk, pipelineId, "workingDir")
+		if got := lc.PipelineId(); got != pipelineId {
+			t.Errorf("PipelineId() for %s got = %v, want %v", sdk, got, pipelineId)
+		}
+		if got := lc.WorkingDir(); got != "workingDir" {
+			t.Errorf("WorkingDir() for %s got = %v, want %v", sdk, got, "workingDir")
+		}
+	}
+}
+
 func TestLifeCycle_ExecutableName(t *testing.T) {
 	pipelineId := uuid.New()
 	workingDir := "workingDir"
@@ -445,6 +687,109 @@ func TestLifeCycle_ExecutableName(t *testing.T) {
 	}
 }
 
+func TestLifeCycle_CopyCodeFiles(t *testing.T) {
+	workingDir := t.TempDir()
+	tests := []struct {
+		name           string
+		sdk            pb.Sdk
+		sourceFileName string
+		files          []CodeFile
+		// compiledFile is a name of the compiled file of the source pipeline, {pipelineId} is replaced by the id of the pipeline
+		compiledFile string
+		// wantFiles are the paths of the files which should be copied relative to the base folder of the new pipeline
+		wantFiles []string
+	}{
+		{
+			// Test case with calling CopyCodeFiles method with the compiled Go code.
+			// As a result, want to receive the source folder with go.mod and the executable file named by the new pipeline id.
+			name:         "go code",
+			sdk:          pb.Sdk_SDK_GO,
+			files:        []CodeFile{{Name: "main.go", Content: "package main", IsMain: true}},
+			compiledFile: "{pipelineId}",
+			wantFiles:    []string{"src/{pipelineId}.go", "src/go.mod", "bin/{pipelineId}"},
+		},
+		{
+			// Test case with calling CopyCodeFiles method with the Java code of several files with the name of the main file.
+			// As a result, want to receive all source files and compiled classes.
+			name:           "java code with several files",
+			sdk:            pb.Sdk_SDK_JAVA,
+			sourceFileName: "WordCount.java",
+			files:          []CodeFile{{Name: "WordCount.java", Content: "class WordCount {}", IsMain: true}, {Name: "Helper.java", Content: "class Helper {}"}},
+			compiledFile:   "WordCount.class",
+			wantFiles:      []string{"src/WordCount.java", "src/Helper.java", "bin/WordCount.class"},
+		},
+		{
+			// Test case with calling CopyCodeFiles method with the Python code.
+			// As a result, want to receive only the source file without the logs of the source pipeline.
+			name:      "python code",
+			sdk:       pb.Sdk_SDK_PYTHON,
+			files:     []CodeFile{{Name: "main.py", Content: "print(1)", IsMain: true}},
+			wantFiles: []string{"{pipelineId}.py"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourcePipelineId := uuid.New()
+			source, _ := NewLifeCycle(tt.sdk, sourcePipelineId, workingDir)
+			if tt.sourceFileName != "" {
+				if err := source.SetSourceFileName(tt.sourceFileName); err != nil {
+					t.Fatalf("SetSourceFileName() error = %v", err)
+				}
+			}
+			if err := source.CreateFolders(); err != nil {
+				t.Fatalf("CreateFolders() error = %v", err)
+			}
+			if err := source.CreateSourceCodeFiles(tt.files); err != nil {
+				t.Fatalf("CreateSourceCodeFiles() error = %v", err)
+			}
+			if tt.sdk == pb.Sdk_SDK_GO {
+				_ = os.WriteFile(filepath.Join(source.Folder.SourceFileFolder, "go.mod"), []byte("module main"), fileMode)
+			}
+			if tt.compiledFile != "" {
+				compiledFile := strings.ReplaceAll(tt.compiledFile, "{pipelineId}", sourcePipelineId.String())
+				_ = os.WriteFile(filepath.Join(source.Folder.ExecutableFileFolder, compiledFile), []byte("compiled"), 0700)
+			}
+			_ = os.WriteFile(source.GetAbsoluteLogFilePath(), []byte("logs"), fileMode)
+
+			pipelineId := uuid.New()
+			l, _ := NewLifeCycle(tt.sdk, pipelineId, workingDir)
+			if err := l.CreateFolders(); err != nil {
+				t.Fatalf("CreateFolders() error = %v", err)
+			}
+			if err := l.CopyCodeFiles(source); err != nil {
+				t.Fatalf("CopyCodeFiles() error = %v", err)
+			}
+
+			var gotFiles []string
+			_ = filepath.WalkDir(l.Folder.BaseFolder, func(path string, entry fs.DirEntry, err error) error {
+				if err == nil && !entry.IsDir() {
+					relativePath, _ := filepath.Rel(l.Folder.BaseFolder, path)
+					gotFiles = append(gotFiles, relativePath)
+				}
+				return err
+			})
+			var wantFiles []string
+			for _, file := range tt.wantFiles {
+				wantFiles = append(wantFiles, strings.ReplaceAll(file, "{pipelineId}", pipelineId.String()))
+			}
+			sort.Strings(gotFiles)
+			sort.Strings(wantFiles)
+			if !reflect.DeepEqual(gotFiles, wantFiles) {
+				t.Errorf("CopyCodeFiles() copied files = %v, want %v", gotFiles, wantFiles)
+			}
+			if len(l.GetAbsoluteSourceFilePaths()) != len(tt.files) || filepath.Base(l.GetAbsoluteSourceFilePath()) != strings.ReplaceAll(filepath.Base(source.GetAbsoluteSourceFilePath()), sourcePipelineId.String(), pipelineId.String()) {
+				t.Errorf("CopyCodeFiles() source files = %v, want the same files as %v", l.GetAbsoluteSourceFilePaths(), source.GetAbsoluteSourceFilePaths())
+			}
+			if tt.compiledFile != "" {
+				info, err := os.Stat(filepath.Join(l.Folder.ExecutableFileFolder, strings.ReplaceAll(tt.compiledFile, "{pipelineId}", pipelineId.String())))
+				if err != nil || info.Mode().Perm() != 0700 {
+					t.Errorf("CopyCodeFiles() should keep the mode of the compiled file, error = %v", err)
+				}
+			}
+		})
+	}
+}
+
 func TestCopyFile(t *testing.T) {
 	type fields struct {
 		folderGlobs    []string
@@ -20,6 +20,7 @@ import (
 	"github.com/google/uuid"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
@@ -28,15 +29,25 @@ const (
 	javaCompiledFileExtension = ".class"
 )
 
+var (
+	classDeclarationRegexp = regexp.MustCompile(`\bclass\s+(\w+)`)
+	mainMethodRegexp       = regexp.MustCompile(`\bstatic\s+void\s+main\s*\(`)
+	// classNameRegexp matches the fully qualified name of a JVM class, i.e. org.apache.beam.examples.WordCount
+	classNameRegexp = regexp.MustCompile(`^[\p{L}_$][\p{L}\p{N}_$]*(\.[\p{L}_$][\p{L}\p{N}_$]*)*$`)
+)
+
 // newJavaLifeCycle creates LifeCycle with java SDK environment.
 func newJavaLifeCycle(pipelineId uuid.UUID, workingDir string) *LifeCycle {
 	javaLifeCycle := newCompilingLifeCycle(pipelineId, workingDir, javaSourceFileExtension, javaCompiledFileExtension)
-	javaLifeCycle.ExecutableName = executableName
+	javaLifeCycle.ExecutableName = func(pipelineId uuid.UUID, workingDir string) (string, error) {
+		return executableName(pipelineId, workingDir, javaLifeCycle.mainSourceFileName())
+	}
 	return javaLifeCycle
 }
 
-// executableName returns name that should be executed (HelloWorld for HelloWorld.class for java SDK)
-func executableName(pipelineId uuid.UUID, workingDir string) (string, error) {
+// executableName returns name that should be executed (HelloWorld for HelloWorld.class for java SDK).
+// The main class is looked for in the main source file with sourceFileName.
+func executableName(pipelineId uuid.UUID, workingDir, sourceFileName string) (string, error) {
 	baseFileFolder := filepath.Join(workingDir, baseFileFolder, pipelineId.String())
 	binFileFolder := filepath.Join(baseFileFolder, compiledFolderName)
 	dirEntries, err := os.ReadDir(binFileFolder)
@@ -46,6 +57,48 @@ func executableName(pipelineId uuid.UUID, workingDir string) (string, error) {
 	if len(dirEntries) < 1 {
 		return "", errors.New("number of executable files should be at least one")
 	}
+	// in case of several source files the main class is looked for in the main source file
+	srcFilePath := filepath.Join(baseFileFolder, sourceFolderName, sourceFileName)
+	if mainClassName, found := mainClassName(srcFilePath, classDeclarationRegexp, mainMethodRegexp); found {
+		for _, entry := range dirEntries {
+			if entry.Name() == mainClassName+javaCompiledFileExtension {
+				return mainClassName, nil
+			}
+		}
+	}
 	//TODO need to find a class with a main method instead of using the last file
 	return strings.Split(dirEntries[len(dirEntries)-1].Name(), ".")[0], nil
 }
+
+// mainClassName returns name of the class which declares the main method in the source file.
+// The class which is declared the last before the main method is considered as a class which declares it.
+// declarationRegexp should capture the name of the class as the first group.
+func mainClassName(srcFilePath string, declarationRegexp, mainRegexp *regexp.Regexp) (string, bool) {
+	code, err := os.ReadFile(srcFilePath)
+	if err != nil {
+		return "", false
+	}
+	mainMethodIndex := mainRegexp.FindIndex(code)
+	if mainMethodIndex == nil {
+		return "", false
+	}
+	className := ""
+	for _, classIndexes := range declarationRegexp.FindAllSubmatchIndex(code, -1) {
+		if classIndexes[0] > mainMethodIndex[0] {
+			break
+		}
+		className = string(code[classIndexes[2]:classIndexes[3]])
+	}
+	return className, className != ""
+}
+
+// HasCompiledClass checks if the class with the fully qualified className (i.e. org.apache.beam.examples.WordCount)
+// is compiled into the folder with compiled files.
+func (l *LifeCycle) HasCompiledClass(className string) bool {
+	if !classNameRegexp.MatchString(className) {
+		return false
+	}
+	classFilePath := filepath.Join(l.GetAbsoluteCompiledFolderPath(), strings.ReplaceAll(className, ".", string(filepath.Separator))+javaCompiledFileExtension)
+	info, err := os.Stat(classFilePath)
+	return err == nil && !info.IsDir()
+}
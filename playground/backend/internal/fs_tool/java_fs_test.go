@@ -16,6 +16,7 @@
 package fs_tool
 
 import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"fmt"
 	"github.com/google/uuid"
 	"os"
@@ -59,8 +60,7 @@ func Test_newJavaLifeCycle(t *testing.T) {
 					SourceFileExtension:     javaSourceFileExtension,
 					ExecutableFileExtension: javaCompiledFileExtension,
 				},
-				ExecutableName: executableName,
-				pipelineId:     pipelineId,
+				pipelineId: pipelineId,
 			},
 		},
 	}
@@ -121,6 +121,31 @@ func Test_executableName(t *testing.T) {
 			want:    "temp",
 			wantErr: false,
 		},
+		{
+			// Test case with calling sourceFileName method when several classes are compiled from several source files.
+			// As a result, want to receive a name of the class which declares the main method in the main source file.
+			name: "get executable name of several classes",
+			prepare: func() {
+				compiled := filepath.Join(workDir, baseFileFolder, pipelineId.String(), compiledFolderName)
+				for _, className := range []string{"HelloWorld", "Helper"} {
+					filePath := filepath.Join(compiled, className+javaCompiledFileExtension)
+					if err := os.WriteFile(filePath, []byte("TEMP_DATA"), 0600); err != nil {
+						panic(err)
+					}
+				}
+				src := filepath.Join(workDir, baseFileFolder, pipelineId.String(), sourceFolderName)
+				code := "class HelloWorld {\n    public static void main(String[] args) {\n        System.out.println(Helper.hello());\n    }\n}"
+				if err := os.WriteFile(filepath.Join(src, pipelineId.String()+javaSourceFileExtension), []byte(code), 0600); err != nil {
+					panic(err)
+				}
+			},
+			args: args{
+				pipelineId: pipelineId,
+				workingDir: workDir,
+			},
+			want:    "HelloWorld",
+			wantErr: false,
+		},
 		{
 			// Test case with calling sourceFileName method with correct pipelineId and workingDir.
 			// As a result, want to receive an error.
@@ -137,7 +162,7 @@ func Test_executableName(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.prepare()
-			got, err := executableName(tt.args.pipelineId, tt.args.workingDir)
+			got, err := executableName(tt.args.pipelineId, tt.args.workingDir, tt.args.pipelineId.String()+javaSourceFileExtension)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("sourceFileName() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -148,3 +173,118 @@ func Test_executableName(t *testing.T) {
 		})
 	}
 }
+
+func TestLifeCycle_HasCompiledClass(t *testing.T) {
+	pipelineId := uuid.New()
+	workDir := t.TempDir()
+	lc := newJavaLifeCycle(pipelineId, workDir)
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("CreateFolders() error = %v", err)
+	}
+	compiled := lc.GetAbsoluteCompiledFolderPath()
+	for _, classFile := range []string{"HelloWorld.class", filepath.Join("org", "example", "WordCount.class"), "Outer$Inner.class"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(compiled, classFile)), 0700); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(compiled, classFile), []byte("TEMP_DATA"), 0600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		className string
+		want      bool
+	}{
+		{
+			// Test case with calling HasCompiledClass method with the name of the compiled class.
+			// As a result, want to receive true.
+			name:      "class exists",
+			className: "HelloWorld",
+			want:      true,
+		},
+		{
+			// Test case with calling HasCompiledClass method with the fully qualified name of the compiled class from a package.
+			// As a result, want to receive true.
+			name:      "class from package exists",
+			className: "org.example.WordCount",
+			want:      true,
+		},
+		{
+			// Test case with calling HasCompiledClass method with the name of the compiled nested class.
+			// As a result, want to receive true.
+			name:      "nested class exists",
+			className: "Outer$Inner",
+			want:      true,
+		},
+		{
+			// Test case with calling HasCompiledClass method with the name of the class which isn't compiled.
+			// As a result, want to receive false.
+			name:      "class doesn't exist",
+			className: "Helper",
+			want:      false,
+		},
+		{
+			// Test case with calling HasCompiledClass method with the name of the package instead of the class.
+			// As a result, want to receive false.
+			name:      "package instead of class",
+			className: "org.example",
+			want:      false,
+		},
+		{
+			// Test case with calling HasCompiledClass method with the name which points outside of the folder with compiled files.
+			// As a result, want to receive false.
+			name:      "invalid class name",
+			className: "../bin/HelloWorld",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lc.HasCompiledClass(tt.className); got != tt.want {
+				t.Errorf("HasCompiledClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLifeCycle_ExecutableNameWithSourceFileName(t *testing.T) {
+	// Test case with calling ExecutableName method of Java life cycle with the main source file named after its public class
+	//	while the classes of another file are compiled too.
+	// As a result, want to receive the public class of the main source file which is created with the name set by SetSourceFileName.
+	pipelineId := uuid.New()
+	lc, err := NewLifeCycle(pb.Sdk_SDK_JAVA, pipelineId, t.TempDir())
+	if err != nil {
+		t.Fatalf("error during create life cycle: %s", err.Error())
+	}
+	if err := lc.SetSourceFileName("WordCount"); err != nil {
+		t.Fatalf("SetSourceFileName() error = %v", err)
+	}
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	code := "public class WordCount {\n    public static void main(String[] args) {\n        System.out.println(Words.split(\"a b\"));\n    }\n}\n"
+	if err := lc.CreateSourceCodeFiles([]CodeFile{{Content: code, IsMain: true}, {Name: "Words.java", Content: "public class Words {}\n"}}); err != nil {
+		t.Fatalf("CreateSourceCodeFiles() error = %v", err)
+	}
+	for _, className := range []string{"WordCount", "Words"} {
+		if err := os.WriteFile(filepath.Join(lc.Folder.ExecutableFileFolder, className+javaCompiledFileExtension), []byte("TEMP_DATA"), 0600); err != nil {
+			t.Fatalf("error during prepare compiled files: %s", err.Error())
+		}
+	}
+
+	wantSourceFilePath, _ := filepath.Abs(filepath.Join(lc.Folder.SourceFileFolder, "WordCount"+javaSourceFileExtension))
+	if got := lc.GetAbsoluteSourceFilePath(); got != wantSourceFilePath {
+		t.Errorf("GetAbsoluteSourceFilePath() got = %v, want %v", got, wantSourceFilePath)
+	}
+	if content, err := os.ReadFile(wantSourceFilePath); err != nil || string(content) != code {
+		t.Errorf("CreateSourceCodeFiles() main file content = %s, want %s", content, code)
+	}
+	got, err := lc.ExecutableName(pipelineId, lc.WorkingDir())
+	if err != nil {
+		t.Fatalf("ExecutableName() error = %v", err)
+	}
+	if got != "WordCount" {
+		t.Errorf("ExecutableName() got = %v, want %v", got, "WordCount")
+	}
+}
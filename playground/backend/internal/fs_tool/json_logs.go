@@ -0,0 +1,105 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_tool
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	_ "embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// JsonLogsEnvKey is the environment variable which is set for the executed code if its logs are printed as JSON lines
+	JsonLogsEnvKey = "PLAYGROUND_JSON_LOGS"
+
+	javaJsonFormatterFileName  = "PlaygroundJsonFormatter.java"
+	javaJsonFormatterClassName = "PlaygroundJsonFormatter"
+	javaLogConfigFileName      = "logging.properties"
+	pythonPathEnvKey           = "PYTHONPATH"
+	pythonJsonLogsFolderName   = "json_logs"
+	pythonJsonLogsFileName     = "sitecustomize.py"
+)
+
+var (
+	// javaJsonFormatterSource is the source code of the formatter of java.util.logging which prints the records as JSON lines
+	//go:embed PlaygroundJsonFormatter.java
+	javaJsonFormatterSource []byte
+	// pythonJsonLogsSource is the source code of the module which configures the root logger of Python to print the records as JSON lines
+	//go:embed json_logs_sitecustomize.py
+	pythonJsonLogsSource []byte
+)
+
+// EnableJsonLogs configures the logging of the executed code to print the records as JSON lines (one JSON object per record):
+// - for Java SDK the formatter is added to the source files, so it is compiled together with the code,
+//	and the log config file of the pipeline is updated to print the records to the log file and to stderr by the formatter.
+//	The log config file should be already copied to the folder of the pipeline.
+// - for Python SDK the module which configures the root logger is created in the folder of the pipeline
+//	and is imported at the start of the interpreter (see JsonLogsEnv).
+// Returns an error for other SDKs.
+func (l *LifeCycle) EnableJsonLogs() error {
+	switch l.sdk {
+	case pb.Sdk_SDK_JAVA:
+		if err := l.AddSourceCodeFile(javaJsonFormatterFileName, javaJsonFormatterSource); err != nil {
+			return err
+		}
+		logConfigFile, err := os.OpenFile(filepath.Join(l.Folder.BaseFolder, javaLogConfigFileName), os.O_WRONLY|os.O_APPEND, fileMode)
+		if err != nil {
+			return err
+		}
+		defer logConfigFile.Close()
+		// the properties which are appended override the same properties of the file
+		_, err = fmt.Fprintf(logConfigFile, "\nhandlers=java.util.logging.FileHandler, java.util.logging.ConsoleHandler\n"+
+			"java.util.logging.FileHandler.formatter=%[1]s\njava.util.logging.ConsoleHandler.formatter=%[1]s\n", javaJsonFormatterClassName)
+		if err != nil {
+			return err
+		}
+	case pb.Sdk_SDK_PYTHON:
+		folder := filepath.Join(l.Folder.BaseFolder, pythonJsonLogsFolderName)
+		if err := os.MkdirAll(folder, fs.ModePerm); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(folder, pythonJsonLogsFileName), pythonJsonLogsSource, fileMode); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("json logs aren't supported for %s", l.sdk)
+	}
+	l.jsonLogs = true
+	return nil
+}
+
+// JsonLogsEnv returns the environment variables which should be set for the executed code if the logs are printed as JSON lines.
+// JsonLogsEnvKey is set for all SDKs, so the code with JSON logs isn't dispatched to a pre-started JVM
+//	which is configured to print plain text logs. For Python SDK the folder with the module created by EnableJsonLogs
+//	is added to the beginning of PYTHONPATH (env is the environment of the SDK which could already contain PYTHONPATH).
+// Returns nil if EnableJsonLogs isn't called.
+func (l *LifeCycle) JsonLogsEnv(env map[string]string) map[string]string {
+	if !l.jsonLogs {
+		return nil
+	}
+	result := map[string]string{JsonLogsEnvKey: "true"}
+	if l.sdk == pb.Sdk_SDK_PYTHON {
+		pythonPath, _ := filepath.Abs(filepath.Join(l.Folder.BaseFolder, pythonJsonLogsFolderName))
+		if value, ok := env[pythonPathEnvKey]; ok && value != "" {
+			pythonPath += string(os.PathListSeparator) + value
+		}
+		result[pythonPathEnvKey] = pythonPath
+	}
+	return result
+}
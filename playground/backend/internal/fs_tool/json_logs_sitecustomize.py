@@ -0,0 +1,56 @@
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""
+Module of the Playground backend which configures the root logger to print
+the records to stderr as JSON lines.
+
+It is imported at the start of the interpreter as sitecustomize, so the logs of
+the executed code are printed as JSON lines without changes of the code.
+logging.basicConfig called by the code applies the level only, since the
+handler of the root logger is already configured.
+"""
+
+import json
+import logging
+import sys
+
+
+class PlaygroundJsonFormatter(logging.Formatter):
+  """
+  Formatter which prints each record as one JSON object with the timestamp,
+  level, logger and message of the record, and the exception if the record has it
+  """
+  def format(self, record):
+    entry = {
+        "timestamp": self.formatTime(record),
+        "level": record.levelname,
+        "logger": record.name,
+        "message": record.getMessage(),
+    }
+    if record.exc_info:
+      entry["exception"] = self.formatException(record.exc_info)
+    return json.dumps(entry)
+
+
+def _basic_config(**kwargs):
+  if "level" in kwargs:
+    logging.getLogger().setLevel(kwargs["level"])
+
+
+_handler = logging.StreamHandler(sys.stderr)
+_handler.setFormatter(PlaygroundJsonFormatter())
+logging.getLogger().addHandler(_handler)
+logging.basicConfig = _basic_config
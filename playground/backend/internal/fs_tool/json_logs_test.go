@@ -0,0 +1,123 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_tool
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLifeCycle_EnableJsonLogs(t *testing.T) {
+	workingDir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		sdk     pb.Sdk
+		env     map[string]string
+		check   func(lc *LifeCycle) error
+		wantEnv func(lc *LifeCycle) map[string]string
+		wantErr bool
+	}{
+		{
+			// Test case with calling EnableJsonLogs method for Java SDK.
+			// As a result, want to receive the formatter next to the main source file
+			//	and the log config file which prints the records by the formatter.
+			name: "java sdk",
+			sdk:  pb.Sdk_SDK_JAVA,
+			check: func(lc *LifeCycle) error {
+				if _, err := os.Stat(filepath.Join(lc.Folder.SourceFileFolder, javaJsonFormatterFileName)); err != nil {
+					return err
+				}
+				logConfig, err := os.ReadFile(filepath.Join(lc.Folder.BaseFolder, javaLogConfigFileName))
+				if err != nil {
+					return err
+				}
+				for _, property := range []string{"handlers=java.util.logging.FileHandler, java.util.logging.ConsoleHandler",
+					"java.util.logging.FileHandler.formatter=" + javaJsonFormatterClassName,
+					"java.util.logging.ConsoleHandler.formatter=" + javaJsonFormatterClassName} {
+					if !strings.Contains(string(logConfig), property+"\n") {
+						t.Errorf("EnableJsonLogs() log config file = %s, want it to contain %s", logConfig, property)
+					}
+				}
+				return nil
+			},
+			wantEnv: func(lc *LifeCycle) map[string]string {
+				return map[string]string{JsonLogsEnvKey: "true"}
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with calling EnableJsonLogs method for Python SDK with PYTHONPATH in the environment of the SDK.
+			// As a result, want to receive the module which configures the logger in the folder
+			//	which is added to the beginning of PYTHONPATH.
+			name: "python sdk",
+			sdk:  pb.Sdk_SDK_PYTHON,
+			env:  map[string]string{pythonPathEnvKey: "/opt/python"},
+			check: func(lc *LifeCycle) error {
+				_, err := os.Stat(filepath.Join(lc.Folder.BaseFolder, pythonJsonLogsFolderName, pythonJsonLogsFileName))
+				return err
+			},
+			wantEnv: func(lc *LifeCycle) map[string]string {
+				folder, _ := filepath.Abs(filepath.Join(lc.Folder.BaseFolder, pythonJsonLogsFolderName))
+				return map[string]string{JsonLogsEnvKey: "true", pythonPathEnvKey: folder + string(os.PathListSeparator) + "/opt/python"}
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with calling EnableJsonLogs method for Go SDK.
+			// As a result, want to receive an error since JSON logs aren't supported for it.
+			name:    "go sdk",
+			sdk:     pb.Sdk_SDK_GO,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc, _ := NewLifeCycle(tt.sdk, uuid.New(), workingDir)
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			if err := os.WriteFile(filepath.Join(lc.Folder.BaseFolder, javaLogConfigFileName), []byte("handlers=java.util.logging.FileHandler\n"), fileMode); err != nil {
+				t.Fatalf("error during prepare the log config file: %s", err.Error())
+			}
+			if env := lc.JsonLogsEnv(tt.env); env != nil {
+				t.Errorf("JsonLogsEnv() = %v before EnableJsonLogs(), want nil", env)
+			}
+
+			err := lc.EnableJsonLogs()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EnableJsonLogs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if env := lc.JsonLogsEnv(tt.env); env != nil {
+					t.Errorf("JsonLogsEnv() = %v, want nil", env)
+				}
+				return
+			}
+			if err := tt.check(lc); err != nil {
+				t.Errorf("EnableJsonLogs() doesn't prepare necessary files: %s", err.Error())
+			}
+			if env := lc.JsonLogsEnv(tt.env); !reflect.DeepEqual(env, tt.wantEnv(lc)) {
+				t.Errorf("JsonLogsEnv() = %v, want %v", env, tt.wantEnv(lc))
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package fs_tool
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	kotlinSourceFileExtension = ".kt"
+	// top-level functions of the Kotlin file are compiled into the facade class named after the file with the "Kt" suffix
+	kotlinFacadeClassSuffix = "Kt"
+)
+
+var (
+	kotlinDeclarationRegexp = regexp.MustCompile(`\b(?:class|object)\s+(\w+)`)
+	kotlinMainRegexp        = regexp.MustCompile(`\bfun\s+main\s*\(`)
+	// kotlinTopLevelMainRegexp matches the main function which is declared at the top level of the file, not in a class
+	kotlinTopLevelMainRegexp = regexp.MustCompile(`(?m)^fun\s+main\s*\(`)
+)
+
+// newKotlinLifeCycle creates LifeCycle with Kotlin SDK environment.
+func newKotlinLifeCycle(pipelineId uuid.UUID, workingDir string) *LifeCycle {
+	kotlinLifeCycle := newCompilingLifeCycle(pipelineId, workingDir, kotlinSourceFileExtension, javaCompiledFileExtension)
+	kotlinLifeCycle.ExecutableName = func(pipelineId uuid.UUID, workingDir string) (string, error) {
+		return kotlinExecutableName(pipelineId, workingDir, kotlinLifeCycle.mainSourceFileName())
+	}
+	return kotlinLifeCycle
+}
+
+// kotlinExecutableName returns name that should be executed
+//	(i.e. _3f2a9c1e_0b4d_4e8a_9f0e_2c6b1d7a5e43Kt for the top-level main function of the main source file
+//	or HelloWorld for the main function of the HelloWorld object for Kotlin SDK).
+// The main function is looked for in the main source file with sourceFileName.
+func kotlinExecutableName(pipelineId uuid.UUID, workingDir, sourceFileName string) (string, error) {
+	baseFileFolder := filepath.Join(workingDir, baseFileFolder, pipelineId.String())
+	binFileFolder := filepath.Join(baseFileFolder, compiledFolderName)
+	dirEntries, err := os.ReadDir(binFileFolder)
+	if err != nil {
+		return "", err
+	}
+	srcFilePath := filepath.Join(baseFileFolder, sourceFolderName, sourceFileName)
+	mainName, found := mainClassName(srcFilePath, kotlinDeclarationRegexp, kotlinMainRegexp)
+	if code, err := os.ReadFile(srcFilePath); err == nil && kotlinTopLevelMainRegexp.Match(code) {
+		mainName, found = kotlinFacadeClassName(strings.TrimSuffix(sourceFileName, kotlinSourceFileExtension)), true
+	}
+	if found {
+		for _, entry := range dirEntries {
+			if entry.Name() == mainName+javaCompiledFileExtension {
+				return mainName, nil
+			}
+		}
+	}
+	// the facade class is preferred since the main function is usually declared at the top level
+	fallbackName := ""
+	for _, entry := range dirEntries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, javaCompiledFileExtension) || strings.Contains(name, "$") {
+			continue
+		}
+		name = strings.TrimSuffix(name, javaCompiledFileExtension)
+		if strings.HasSuffix(name, kotlinFacadeClassSuffix) {
+			return name, nil
+		}
+		if fallbackName == "" {
+			fallbackName = name
+		}
+	}
+	if fallbackName != "" {
+		return fallbackName, nil
+	}
+	return "", errors.New("number of executable files should be at least one")
+}
+
+// kotlinFacadeClassName returns name of the class which top-level functions of the Kotlin file are compiled into.
+// Characters of the file name which aren't letters or digits are replaced with "_", the first letter is capitalized
+//	and "_" is added before the first character if it can't start the name of the class (i.e. a digit).
+func kotlinFacadeClassName(fileName string) string {
+	name := []rune(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, fileName))
+	if len(name) == 0 {
+		return "_" + kotlinFacadeClassSuffix
+	}
+	if !unicode.IsLetter(name[0]) && name[0] != '_' {
+		return "_" + string(name) + kotlinFacadeClassSuffix
+	}
+	name[0] = unicode.ToUpper(name[0])
+	return string(name) + kotlinFacadeClassSuffix
+}
@@ -0,0 +1,194 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package fs_tool
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_newKotlinLifeCycle(t *testing.T) {
+	pipelineId := uuid.New()
+	workingDir := "workingDir"
+	baseFileFolder := fmt.Sprintf("%s/%s/%s", workingDir, baseFileFolder, pipelineId)
+	srcFileFolder := baseFileFolder + "/src"
+	binFileFolder := baseFileFolder + "/bin"
+
+	// Test case with calling newKotlinLifeCycle method with correct pipelineId and workingDir.
+	// As a result, want to receive an expected Kotlin life cycle.
+	want := &LifeCycle{
+		folderGlobs: []string{baseFileFolder, srcFileFolder, binFileFolder},
+		Folder: Folder{
+			BaseFolder:           baseFileFolder,
+			SourceFileFolder:     srcFileFolder,
+			ExecutableFileFolder: binFileFolder,
+		},
+		Extension: Extension{
+			SourceFileExtension:     kotlinSourceFileExtension,
+			ExecutableFileExtension: javaCompiledFileExtension,
+		},
+		pipelineId: pipelineId,
+	}
+	got := newKotlinLifeCycle(pipelineId, workingDir)
+	if !reflect.DeepEqual(got.folderGlobs, want.folderGlobs) {
+		t.Errorf("newKotlinLifeCycle() folderGlobs = %v, want %v", got.folderGlobs, want.folderGlobs)
+	}
+	if !reflect.DeepEqual(got.Folder, want.Folder) {
+		t.Errorf("newKotlinLifeCycle() Folder = %v, want %v", got.Folder, want.Folder)
+	}
+	if !reflect.DeepEqual(got.Extension, want.Extension) {
+		t.Errorf("newKotlinLifeCycle() Extension = %v, want %v", got.Extension, want.Extension)
+	}
+	if !reflect.DeepEqual(got.pipelineId, want.pipelineId) {
+		t.Errorf("newKotlinLifeCycle() pipelineId = %v, want %v", got.pipelineId, want.pipelineId)
+	}
+}
+
+func Test_kotlinExecutableName(t *testing.T) {
+	workDir := "workingDir"
+	defer os.RemoveAll(workDir)
+
+	// prepare creates compiled files and the main source file of the pipeline
+	prepare := func(pipelineId uuid.UUID, compiledFiles []string, code string) {
+		lc := newKotlinLifeCycle(pipelineId, workDir)
+		if err := lc.CreateFolders(); err != nil {
+			panic(err)
+		}
+		for _, compiledFile := range compiledFiles {
+			if err := os.WriteFile(filepath.Join(lc.Folder.ExecutableFileFolder, compiledFile), []byte("TEMP_DATA"), 0600); err != nil {
+				panic(err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(lc.Folder.SourceFileFolder, pipelineId.String()+kotlinSourceFileExtension), []byte(code), 0600); err != nil {
+			panic(err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		compiledFiles func(pipelineId uuid.UUID) []string
+		code          string
+		want          func(pipelineId uuid.UUID) string
+		wantErr       bool
+	}{
+		{
+			// Test case with calling kotlinExecutableName method when the main function is declared at the top level.
+			// As a result, want to receive a name of the facade class of the main source file.
+			name: "top-level main function",
+			compiledFiles: func(pipelineId uuid.UUID) []string {
+				return []string{"Helper.class", "HelperKt.class", kotlinFacadeClassName(pipelineId.String()) + ".class"}
+			},
+			code: "class Helper {\n    val greeting = \"Hello\"\n}\n\nfun main(args: Array<String>) {\n    println(Helper().greeting)\n}\n",
+			want: func(pipelineId uuid.UUID) string {
+				return kotlinFacadeClassName(pipelineId.String())
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with calling kotlinExecutableName method when the main function is declared in the object.
+			// As a result, want to receive a name of the object which declares the main function.
+			name: "main function of object",
+			compiledFiles: func(pipelineId uuid.UUID) []string {
+				return []string{"Helper.class", "WordCount.class"}
+			},
+			code: "object Helper {\n    val greeting = \"Hello\"\n}\n\nobject WordCount {\n    @JvmStatic\n    fun main(args: Array<String>) {\n        println(Helper.greeting)\n    }\n}\n",
+			want: func(pipelineId uuid.UUID) string {
+				return "WordCount"
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with calling kotlinExecutableName method when the main function isn't found in the source file.
+			// As a result, want to receive a name of the compiled facade class.
+			name: "main function isn't found",
+			compiledFiles: func(pipelineId uuid.UUID) []string {
+				return []string{"Helper$Companion.class", "Helper.class", "MainKt.class"}
+			},
+			code: "",
+			want: func(pipelineId uuid.UUID) string {
+				return "MainKt"
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with calling kotlinExecutableName method when there are no compiled files.
+			// As a result, want to receive an error.
+			name: "no compiled files",
+			compiledFiles: func(pipelineId uuid.UUID) []string {
+				return []string{}
+			},
+			code: "",
+			want: func(pipelineId uuid.UUID) string {
+				return ""
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			prepare(pipelineId, tt.compiledFiles(pipelineId), tt.code)
+			got, err := kotlinExecutableName(pipelineId, workDir, pipelineId.String()+kotlinSourceFileExtension)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("kotlinExecutableName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if want := tt.want(pipelineId); got != want {
+				t.Errorf("kotlinExecutableName() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_kotlinFacadeClassName(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		want     string
+	}{
+		{
+			// Test case with calling kotlinFacadeClassName method with the name which starts with a lowercase letter.
+			// As a result, want to receive the name with the first letter capitalized and "Kt" suffix.
+			name:     "name starts with letter",
+			fileName: "main",
+			want:     "MainKt",
+		},
+		{
+			// Test case with calling kotlinFacadeClassName method with the uuid which starts with a digit.
+			// As a result, want to receive the name with "_" prefix, "-" replaced with "_" and "Kt" suffix.
+			name:     "uuid starts with digit",
+			fileName: "3f2a9c1e-0b4d-4e8a-9f0e-2c6b1d7a5e43",
+			want:     "_3f2a9c1e_0b4d_4e8a_9f0e_2c6b1d7a5e43Kt",
+		},
+		{
+			// Test case with calling kotlinFacadeClassName method with the uuid which starts with a letter.
+			// As a result, want to receive the name with the first letter capitalized, "-" replaced with "_" and "Kt" suffix.
+			name:     "uuid starts with letter",
+			fileName: "a3f29c1e-0b4d-4e8a-9f0e-2c6b1d7a5e43",
+			want:     "A3f29c1e_0b4d_4e8a_9f0e_2c6b1d7a5e43Kt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kotlinFacadeClassName(tt.fileName); got != tt.want {
+				t.Errorf("kotlinFacadeClassName() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_tool
+import (
+	"errors"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	scioSourceFileExtension = ".scala"
+	// scala objects are compiled into JVM classes, the companion class of an object has the "$" suffix
+	scalaNestedClassSeparator = "$"
+)
+
+var (
+	objectDeclarationRegexp = regexp.MustCompile(`\bobject\s+(\w+)`)
+	scalaMainRegexp         = regexp.MustCompile(`\bdef\s+main\s*\(|\bextends\s+App\b`)
+)
+
+// newScioLifeCycle creates LifeCycle with SCIO SDK environment.
+func newScioLifeCycle(pipelineId uuid.UUID, workingDir string) *LifeCycle {
+	scioLifeCycle := newCompilingLifeCycle(pipelineId, workingDir, scioSourceFileExtension, javaCompiledFileExtension)
+	scioLifeCycle.ExecutableName = func(pipelineId uuid.UUID, workingDir string) (string, error) {
+		return scioExecutableName(pipelineId, workingDir, scioLifeCycle.mainSourceFileName())
+	}
+	return scioLifeCycle
+}
+
+// scioExecutableName returns name that should be executed (WordCount for WordCount.class and WordCount$.class for SCIO SDK).
+// The main object is looked for in the main source file with sourceFileName.
+func scioExecutableName(pipelineId uuid.UUID, workingDir, sourceFileName string) (string, error) {
+	baseFileFolder := filepath.Join(workingDir, baseFileFolder, pipelineId.String())
+	binFileFolder := filepath.Join(baseFileFolder, compiledFolderName)
+	dirEntries, err := os.ReadDir(binFileFolder)
+	if err != nil {
+		return "", err
+	}
+	srcFilePath := filepath.Join(baseFileFolder, sourceFolderName, sourceFileName)
+	if mainObjectName, found := mainClassName(srcFilePath, objectDeclarationRegexp, scalaMainRegexp); found {
+		for _, entry := range dirEntries {
+			if entry.Name() == mainObjectName+javaCompiledFileExtension {
+				return mainObjectName, nil
+			}
+		}
+	}
+	for _, entry := range dirEntries {
+		name := entry.Name()
+		if strings.HasSuffix(name, javaCompiledFileExtension) && !strings.Contains(name, scalaNestedClassSeparator) {
+			return strings.TrimSuffix(name, javaCompiledFileExtension), nil
+		}
+	}
+	return "", errors.New("number of executable files should be at least one")
+}
@@ -0,0 +1,162 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_tool
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_newScioLifeCycle(t *testing.T) {
+	pipelineId := uuid.New()
+	workingDir := "workingDir"
+	baseFileFolder := fmt.Sprintf("%s/%s/%s", workingDir, baseFileFolder, pipelineId)
+	srcFileFolder := baseFileFolder + "/src"
+	binFileFolder := baseFileFolder + "/bin"
+
+	type args struct {
+		pipelineId uuid.UUID
+		workingDir string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *LifeCycle
+	}{
+		{
+			// Test case with calling newScioLifeCycle method with correct pipelineId and workingDir.
+			// As a result, want to receive an expected SCIO life cycle.
+			name: "newScioLifeCycle",
+			args: args{
+				pipelineId: pipelineId,
+				workingDir: workingDir,
+			},
+			want: &LifeCycle{
+				folderGlobs: []string{baseFileFolder, srcFileFolder, binFileFolder},
+				Folder: Folder{
+					BaseFolder:           baseFileFolder,
+					SourceFileFolder:     srcFileFolder,
+					ExecutableFileFolder: binFileFolder,
+				},
+				Extension: Extension{
+					SourceFileExtension:     scioSourceFileExtension,
+					ExecutableFileExtension: javaCompiledFileExtension,
+				},
+				pipelineId: pipelineId,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newScioLifeCycle(tt.args.pipelineId, tt.args.workingDir)
+			if !reflect.DeepEqual(got.folderGlobs, tt.want.folderGlobs) {
+				t.Errorf("newScioLifeCycle() folderGlobs = %v, want %v", got.folderGlobs, tt.want.folderGlobs)
+			}
+			if !reflect.DeepEqual(got.Folder, tt.want.Folder) {
+				t.Errorf("newScioLifeCycle() Folder = %v, want %v", got.Folder, tt.want.Folder)
+			}
+			if !reflect.DeepEqual(got.Extension, tt.want.Extension) {
+				t.Errorf("newScioLifeCycle() Extension = %v, want %v", got.Extension, tt.want.Extension)
+			}
+			if !reflect.DeepEqual(got.pipelineId, tt.want.pipelineId) {
+				t.Errorf("newScioLifeCycle() pipelineId = %v, want %v", got.pipelineId, tt.want.pipelineId)
+			}
+		})
+	}
+}
+
+func Test_scioExecutableName(t *testing.T) {
+	workDir := "workingDir"
+	defer os.RemoveAll(workDir)
+
+	// prepare creates compiled files and the main source file of the pipeline
+	prepare := func(pipelineId uuid.UUID, compiledFiles []string, code string) {
+		lc := newScioLifeCycle(pipelineId, workDir)
+		if err := lc.CreateFolders(); err != nil {
+			panic(err)
+		}
+		for _, compiledFile := range compiledFiles {
+			if err := os.WriteFile(filepath.Join(lc.Folder.ExecutableFileFolder, compiledFile), []byte("TEMP_DATA"), 0600); err != nil {
+				panic(err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(lc.Folder.SourceFileFolder, pipelineId.String()+scioSourceFileExtension), []byte(code), 0600); err != nil {
+			panic(err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		compiledFiles []string
+		code          string
+		want          string
+		wantErr       bool
+	}{
+		{
+			// Test case with calling scioExecutableName method when the main object declares the main method.
+			// As a result, want to receive a name of the object which declares the main method.
+			name:          "object with main method",
+			compiledFiles: []string{"Helper$.class", "Helper.class", "WordCount$.class", "WordCount.class"},
+			code:          "object Helper {\n  val greeting = \"Hello\"\n}\n\nobject WordCount {\n  def main(cmdlineArgs: Array[String]): Unit = {\n    println(Helper.greeting)\n  }\n}\n",
+			want:          "WordCount",
+			wantErr:       false,
+		},
+		{
+			// Test case with calling scioExecutableName method when the main object extends App.
+			// As a result, want to receive a name of the object which extends App.
+			name:          "object extends App",
+			compiledFiles: []string{"HelloWorld$.class", "HelloWorld.class"},
+			code:          "object HelloWorld extends App {\n  println(\"Hello world!\")\n}\n",
+			want:          "HelloWorld",
+			wantErr:       false,
+		},
+		{
+			// Test case with calling scioExecutableName method when the main object isn't found in the source file.
+			// As a result, want to receive a name of the compiled class which isn't a companion class.
+			name:          "main object isn't found",
+			compiledFiles: []string{"HelloWorld$.class", "HelloWorld.class"},
+			code:          "",
+			want:          "HelloWorld",
+			wantErr:       false,
+		},
+		{
+			// Test case with calling scioExecutableName method when there are no compiled files.
+			// As a result, want to receive an error.
+			name:          "no compiled files",
+			compiledFiles: []string{},
+			code:          "",
+			want:          "",
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			prepare(pipelineId, tt.compiledFiles, tt.code)
+			got, err := scioExecutableName(pipelineId, workDir, pipelineId.String()+scioSourceFileExtension)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("scioExecutableName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("scioExecutableName() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
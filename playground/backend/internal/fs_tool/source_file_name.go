@@ -0,0 +1,80 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_tool
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const goTestFileSuffix = "_test"
+
+var (
+	// jvmClassNameRegexp matches the simple name of a JVM class, i.e. WordCount
+	jvmClassNameRegexp = regexp.MustCompile(`^[\p{L}_$][\p{L}\p{N}_$]*$`)
+	// pythonModuleNameRegexp matches the name of a Python module which could be imported, i.e. word_count
+	pythonModuleNameRegexp = regexp.MustCompile(`^[\p{L}_][\p{L}\p{N}_]*$`)
+)
+
+// sourceFileExtensions are the extensions of the source files of the SDKs
+var sourceFileExtensions = map[pb.Sdk]string{
+	pb.Sdk_SDK_JAVA:   javaSourceFileExtension,
+	pb.Sdk_SDK_GO:     goSourceFileExtension,
+	pb.Sdk_SDK_PYTHON: pythonExecutableFileExtension,
+	pb.Sdk_SDK_SCIO:   scioSourceFileExtension,
+	pb.Sdk_SDK_KOTLIN: kotlinSourceFileExtension,
+}
+
+// ValidateSourceFileName checks that fileName could be used as the name of the main source file of the code of the sdk
+//	and returns it with the extension of the sdk (i.e. WordCount.java for WordCount).
+// The name should match the rules of the language of the sdk:
+// - for Java, Kotlin and SCIO it should be a valid class name, since the name of the file is used as the name of a class;
+// - for Python it should be a valid module name, so it could be imported;
+// - for Go it shouldn't be ignored by go build (i.e. it shouldn't start with "." or "_" and shouldn't end with "_test").
+func ValidateSourceFileName(sdk pb.Sdk, fileName string) (string, error) {
+	extension, ok := sourceFileExtensions[sdk]
+	if !ok {
+		return "", fmt.Errorf("%s isn't supported now", sdk)
+	}
+	if fileName == "" || filepath.Base(fileName) != fileName {
+		return "", fmt.Errorf("incorrect name of the source file: %s", fileName)
+	}
+	if filepath.Ext(fileName) == "" {
+		fileName += extension
+	}
+	if filepath.Ext(fileName) != extension {
+		return "", fmt.Errorf("the source file of %s should have %s extension: %s", sdk, extension, fileName)
+	}
+	name := strings.TrimSuffix(fileName, extension)
+	switch sdk {
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_KOTLIN, pb.Sdk_SDK_SCIO:
+		if !jvmClassNameRegexp.MatchString(name) {
+			return "", fmt.Errorf("the name of the source file should be a valid class name: %s", fileName)
+		}
+	case pb.Sdk_SDK_PYTHON:
+		if !pythonModuleNameRegexp.MatchString(name) {
+			return "", fmt.Errorf("the name of the source file should be a valid module name: %s", fileName)
+		}
+	case pb.Sdk_SDK_GO:
+		if name == "" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || strings.HasSuffix(name, goTestFileSuffix) {
+			return "", fmt.Errorf("the source file would be ignored by go build: %s", fileName)
+		}
+	}
+	return fileName, nil
+}
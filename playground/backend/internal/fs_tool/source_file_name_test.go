@@ -0,0 +1,113 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_tool
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"testing"
+)
+
+func TestValidateSourceFileName(t *testing.T) {
+	type args struct {
+		sdk      pb.Sdk
+		fileName string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			// Test case with calling ValidateSourceFileName method with the name of the Java file which is named after the public class.
+			// As a result, want to receive the same name.
+			name:    "java class name",
+			args:    args{sdk: pb.Sdk_SDK_JAVA, fileName: "WordCount.java"},
+			want:    "WordCount.java",
+			wantErr: false,
+		},
+		{
+			// Test case with calling ValidateSourceFileName method with the name of the file without the extension.
+			// As a result, want to receive the name with the extension of the sdk.
+			name:    "name without extension",
+			args:    args{sdk: pb.Sdk_SDK_JAVA, fileName: "WordCount"},
+			want:    "WordCount.java",
+			wantErr: false,
+		},
+		{
+			// Test case with calling ValidateSourceFileName method with the name of the file which has the extension of another sdk.
+			// As a result, want to receive an error.
+			name:    "incorrect extension",
+			args:    args{sdk: pb.Sdk_SDK_JAVA, fileName: "WordCount.py"},
+			wantErr: true,
+		},
+		{
+			// Test case with calling ValidateSourceFileName method with the name of the file which contains a path.
+			// As a result, want to receive an error.
+			name:    "name with path",
+			args:    args{sdk: pb.Sdk_SDK_JAVA, fileName: "../WordCount.java"},
+			wantErr: true,
+		},
+		{
+			// Test case with calling ValidateSourceFileName method with the name of the Java file which isn't a valid class name.
+			// As a result, want to receive an error.
+			name:    "incorrect java class name",
+			args:    args{sdk: pb.Sdk_SDK_JAVA, fileName: "word-count.java"},
+			wantErr: true,
+		},
+		{
+			// Test case with calling ValidateSourceFileName method with the name of the Python file which is a valid module name.
+			// As a result, want to receive the same name.
+			name:    "python module name",
+			args:    args{sdk: pb.Sdk_SDK_PYTHON, fileName: "word_count.py"},
+			want:    "word_count.py",
+			wantErr: false,
+		},
+		{
+			// Test case with calling ValidateSourceFileName method with the name of the Python file which couldn't be imported.
+			// As a result, want to receive an error.
+			name:    "incorrect python module name",
+			args:    args{sdk: pb.Sdk_SDK_PYTHON, fileName: "1word_count.py"},
+			wantErr: true,
+		},
+		{
+			// Test case with calling ValidateSourceFileName method with the name of the Go file which is ignored by go build.
+			// As a result, want to receive an error.
+			name:    "go test file",
+			args:    args{sdk: pb.Sdk_SDK_GO, fileName: "main_test.go"},
+			wantErr: true,
+		},
+		{
+			// Test case with calling ValidateSourceFileName method with unsupported sdk.
+			// As a result, want to receive an error.
+			name:    "unsupported sdk",
+			args:    args{sdk: pb.Sdk_SDK_UNSPECIFIED, fileName: "WordCount"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateSourceFileName(tt.args.sdk, tt.args.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSourceFileName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ValidateSourceFileName() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	sourceUrlFetchTimeout   = 10 * time.Second
+	sourceUrlMaxSize        = 1 << 20 // 1MB
+	sourceUrlConnectTimeout = 5 * time.Second
+	sourceUrlMaxRedirects   = 5
+)
+
+// Reasons of SourceUrlLimitError which are machine-readable codes of the exceeded limits of downloading the code
+const (
+	SourceUrlTimeoutReason          = "SOURCE_URL_TIMEOUT"
+	SourceUrlTooLargeReason         = "SOURCE_URL_TOO_LARGE"
+	SourceUrlTooManyRedirectsReason = "SOURCE_URL_TOO_MANY_REDIRECTS"
+	SourceUrlHostNotAllowedReason   = "SOURCE_URL_HOST_NOT_ALLOWED"
+)
+
+// sourceUrlTransport is shared by all downloads of the code, so connections to the allowed hosts are reused
+var sourceUrlTransport = &http.Transport{
+	Proxy:                 http.ProxyFromEnvironment,
+	DialContext:           (&net.Dialer{Timeout: sourceUrlConnectTimeout}).DialContext,
+	TLSHandshakeTimeout:   sourceUrlConnectTimeout,
+	ResponseHeaderTimeout: sourceUrlFetchTimeout,
+}
+
+// SourceUrlLimitError is returned by FetchSourceCode if a limit of downloading the code is exceeded
+//	or the host of the code or of its redirect isn't allowed.
+type SourceUrlLimitError struct {
+	// Reason is a machine-readable code of the exceeded limit, i.e. SOURCE_URL_TIMEOUT
+	Reason string
+	Err    error
+}
+
+func (e *SourceUrlLimitError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Reason, e.Err.Error())
+}
+
+func (e *SourceUrlLimitError) Unwrap() error {
+	return e.Err
+}
+
+// IsSourceUrl checks if the code is a link to the code (http:// or https:// URL) instead of the code itself
+func IsSourceUrl(code string) bool {
+	code = strings.TrimSpace(code)
+	if strings.ContainsAny(code, " \t\n") {
+		return false
+	}
+	if !strings.HasPrefix(code, "http://") && !strings.HasPrefix(code, "https://") {
+		return false
+	}
+	_, err := url.Parse(code)
+	return err == nil
+}
+
+// FetchSourceCode downloads the code by sourceUrl.
+// The host of the URL should be one of allowedHosts, so the server can't be used to send requests to any host,
+//	otherwise returns SourceUrlLimitError with SourceUrlHostNotAllowedReason.
+// Redirects are followed only to allowedHosts and not more than sourceUrlMaxRedirects times.
+// Connecting is limited by sourceUrlConnectTimeout, downloading is limited by sourceUrlFetchTimeout
+//	and the size of the code is limited by sourceUrlMaxSize, the code is read until the limit only.
+// In case any of these limits is exceeded returns SourceUrlLimitError with the reason of the error.
+func FetchSourceCode(ctx context.Context, sourceUrl string, allowedHosts []string) (string, error) {
+	parsedUrl, err := url.Parse(strings.TrimSpace(sourceUrl))
+	if err != nil {
+		return "", err
+	}
+	if parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https" {
+		return "", fmt.Errorf("unsupported scheme: %s", parsedUrl.Scheme)
+	}
+	if !isAllowedHost(parsedUrl.Hostname(), allowedHosts) {
+		return "", &SourceUrlLimitError{Reason: SourceUrlHostNotAllowedReason, Err: fmt.Errorf("host %s isn't allowed", parsedUrl.Hostname())}
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, sourceUrlFetchTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctxWithTimeout, http.MethodGet, parsedUrl.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	client := &http.Client{
+		Transport: sourceUrlTransport,
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			if len(via) > sourceUrlMaxRedirects {
+				return &SourceUrlLimitError{Reason: SourceUrlTooManyRedirectsReason, Err: fmt.Errorf("stopped after %d redirects", sourceUrlMaxRedirects)}
+			}
+			if !isAllowedHost(request.URL.Hostname(), allowedHosts) {
+				return &SourceUrlLimitError{Reason: SourceUrlHostNotAllowedReason, Err: fmt.Errorf("redirect to host %s isn't allowed", request.URL.Hostname())}
+			}
+			return nil
+		},
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return "", sourceUrlError(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected response status: %s", response.Status)
+	}
+	if response.ContentLength > sourceUrlMaxSize {
+		return "", codeTooLargeError()
+	}
+	code, err := io.ReadAll(io.LimitReader(response.Body, sourceUrlMaxSize+1))
+	if err != nil {
+		return "", sourceUrlError(err)
+	}
+	if len(code) > sourceUrlMaxSize {
+		return "", codeTooLargeError()
+	}
+	return string(code), nil
+}
+
+// sourceUrlError returns SourceUrlLimitError if err is caused by the exceeded limit, otherwise returns err
+func sourceUrlError(err error) error {
+	var limitErr *SourceUrlLimitError
+	if errors.As(err, &limitErr) {
+		return limitErr
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &SourceUrlLimitError{Reason: SourceUrlTimeoutReason, Err: errors.New("downloading of the code is timed out")}
+	}
+	return err
+}
+
+// codeTooLargeError returns SourceUrlLimitError about the code which is larger than sourceUrlMaxSize
+func codeTooLargeError() error {
+	return &SourceUrlLimitError{Reason: SourceUrlTooLargeReason, Err: fmt.Errorf("the code is larger than %d bytes", sourceUrlMaxSize)}
+}
+
+// isAllowedHost checks if the host is one of allowedHosts
+func isAllowedHost(host string, allowedHosts []string) bool {
+	for _, allowedHost := range allowedHosts {
+		if strings.EqualFold(host, allowedHost) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSourceUrl returns the link to the code if the main source file contains only the link instead of the code.
+// If the main source file contains the code, returns false.
+func (l *LifeCycle) GetSourceUrl() (string, bool, error) {
+	code, err := os.ReadFile(l.GetAbsoluteSourceFilePath())
+	if err != nil {
+		return "", false, err
+	}
+	if !IsSourceUrl(string(code)) {
+		return "", false, nil
+	}
+	return strings.TrimSpace(string(code)), true, nil
+}
+
+// CreateSourceCodeFileFromUrl downloads the code by sourceUrl and creates the main source file with it.
+// The host of the URL should be one of allowedHosts.
+func (l *LifeCycle) CreateSourceCodeFileFromUrl(ctx context.Context, sourceUrl string, allowedHosts []string) error {
+	code, err := FetchSourceCode(ctx, sourceUrl, allowedHosts)
+	if err != nil {
+		return err
+	}
+	_, err = l.CreateSourceCodeFile(code)
+	return err
+}
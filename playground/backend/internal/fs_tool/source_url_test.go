@@ -0,0 +1,290 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_tool
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sourceUrlCode = "package main\n\nfunc main() {}\n"
+
+func newSourceUrlServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/code.go", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(sourceUrlCode))
+	})
+	mux.HandleFunc("/large.go", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("a", sourceUrlMaxSize+1)))
+	})
+	mux.HandleFunc("/large_with_length.go", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(sourceUrlMaxSize+1))
+		_, _ = w.Write([]byte(strings.Repeat("a", sourceUrlMaxSize+1)))
+	})
+	mux.HandleFunc("/stall_response.go", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("/stall_body.go", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("package main\n"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("/redirect.go", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/code.go", http.StatusFound)
+	})
+	mux.HandleFunc("/loop.go", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop.go", http.StatusFound)
+	})
+	mux.HandleFunc("/redirect_to_localhost.go", func(w http.ResponseWriter, r *http.Request) {
+		_, port, _ := net.SplitHostPort(r.Host)
+		http.Redirect(w, r, "http://localhost:"+port+"/code.go", http.StatusFound)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestIsSourceUrl(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{
+			// Test case with calling IsSourceUrl method with https link.
+			// As a result, want to receive true.
+			name: "https link",
+			code: "https://github.com/apache/beam/blob/master/examples/MinimalWordCount.java\n",
+			want: true,
+		},
+		{
+			// Test case with calling IsSourceUrl method with the code.
+			// As a result, want to receive false.
+			name: "code",
+			code: sourceUrlCode,
+			want: false,
+		},
+		{
+			// Test case with calling IsSourceUrl method with the link which uses unsupported scheme.
+			// As a result, want to receive false.
+			name: "file link",
+			code: "file:///etc/passwd",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSourceUrl(tt.code); got != tt.want {
+				t.Errorf("IsSourceUrl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchSourceCode(t *testing.T) {
+	server := newSourceUrlServer()
+	defer server.Close()
+	serverUrl, _ := url.Parse(server.URL)
+	allowedHosts := []string{serverUrl.Hostname()}
+
+	type args struct {
+		sourceUrl    string
+		allowedHosts []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			// Test case with calling FetchSourceCode method with the link to the allowed host.
+			// As a result, want to receive the code.
+			name:    "allowed host",
+			args:    args{sourceUrl: server.URL + "/code.go", allowedHosts: allowedHosts},
+			want:    sourceUrlCode,
+			wantErr: false,
+		},
+		{
+			// Test case with calling FetchSourceCode method with the link to the host which isn't allowed.
+			// As a result, want to receive an error.
+			name:    "not allowed host",
+			args:    args{sourceUrl: server.URL + "/code.go", allowedHosts: []string{"github.com"}},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling FetchSourceCode method with the link to the code which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "code doesn't exist",
+			args:    args{sourceUrl: server.URL + "/not_exist.go", allowedHosts: allowedHosts},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling FetchSourceCode method with the link to the code which is too large.
+			// As a result, want to receive an error.
+			name:    "code is too large",
+			args:    args{sourceUrl: server.URL + "/large.go", allowedHosts: allowedHosts},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling FetchSourceCode method with the link which is redirected to the code on the allowed host.
+			// As a result, want to receive the code.
+			name:    "redirect to allowed host",
+			args:    args{sourceUrl: server.URL + "/redirect.go", allowedHosts: allowedHosts},
+			want:    sourceUrlCode,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FetchSourceCode(context.Background(), tt.args.sourceUrl, tt.args.allowedHosts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FetchSourceCode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("FetchSourceCode() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchSourceCodeLimits(t *testing.T) {
+	server := newSourceUrlServer()
+	defer server.Close()
+	serverUrl, _ := url.Parse(server.URL)
+	allowedHosts := []string{serverUrl.Hostname()}
+
+	tests := []struct {
+		name       string
+		sourceUrl  string
+		wantReason string
+	}{
+		{
+			// Test case with calling FetchSourceCode method with the link to the server which doesn't respond.
+			// As a result, want to receive SourceUrlLimitError with the timeout reason.
+			name:       "server stalls before response",
+			sourceUrl:  server.URL + "/stall_response.go",
+			wantReason: SourceUrlTimeoutReason,
+		},
+		{
+			// Test case with calling FetchSourceCode method with the link to the server which stops sending the code.
+			// As a result, want to receive SourceUrlLimitError with the timeout reason.
+			name:       "server stalls during body",
+			sourceUrl:  server.URL + "/stall_body.go",
+			wantReason: SourceUrlTimeoutReason,
+		},
+		{
+			// Test case with calling FetchSourceCode method with the link to the code which is too large and is streamed without length.
+			// As a result, want to receive SourceUrlLimitError with the too large reason.
+			name:       "streamed code is too large",
+			sourceUrl:  server.URL + "/large.go",
+			wantReason: SourceUrlTooLargeReason,
+		},
+		{
+			// Test case with calling FetchSourceCode method with the link to the code which length is declared larger than the limit.
+			// As a result, want to receive SourceUrlLimitError with the too large reason.
+			name:       "declared code length is too large",
+			sourceUrl:  server.URL + "/large_with_length.go",
+			wantReason: SourceUrlTooLargeReason,
+		},
+		{
+			// Test case with calling FetchSourceCode method with the link which is redirected to itself.
+			// As a result, want to receive SourceUrlLimitError with the too many redirects reason.
+			name:       "redirect loop",
+			sourceUrl:  server.URL + "/loop.go",
+			wantReason: SourceUrlTooManyRedirectsReason,
+		},
+		{
+			// Test case with calling FetchSourceCode method with the link which is redirected to the host which isn't allowed.
+			// As a result, want to receive SourceUrlLimitError with the host not allowed reason.
+			name:       "redirect to not allowed host",
+			sourceUrl:  server.URL + "/redirect_to_localhost.go",
+			wantReason: SourceUrlHostNotAllowedReason,
+		},
+		{
+			// Test case with calling FetchSourceCode method with the link to the host which isn't allowed.
+			// As a result, want to receive SourceUrlLimitError with the host not allowed reason.
+			name:       "not allowed host",
+			sourceUrl:  "http://localhost:" + serverUrl.Port() + "/code.go",
+			wantReason: SourceUrlHostNotAllowedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			got, err := FetchSourceCode(ctx, tt.sourceUrl, allowedHosts)
+			var limitErr *SourceUrlLimitError
+			if !errors.As(err, &limitErr) {
+				t.Fatalf("FetchSourceCode() error = %v, want SourceUrlLimitError", err)
+			}
+			if limitErr.Reason != tt.wantReason {
+				t.Errorf("FetchSourceCode() error reason = %v, want %v", limitErr.Reason, tt.wantReason)
+			}
+			if got != "" {
+				t.Errorf("FetchSourceCode() got = %v, want empty code", got)
+			}
+		})
+	}
+}
+
+func TestLifeCycle_CreateSourceCodeFileFromUrl(t *testing.T) {
+	server := newSourceUrlServer()
+	defer server.Close()
+	serverUrl, _ := url.Parse(server.URL)
+	sourceUrl := server.URL + "/code.go"
+
+	pipelineId := uuid.New()
+	lc, err := NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, "")
+	if err != nil {
+		t.Fatalf("error during create life cycle: %s", err.Error())
+	}
+	if err = lc.CreateFolders(); err != nil {
+		t.Fatalf("error during create folders: %s", err.Error())
+	}
+	defer os.RemoveAll(baseFileFolder)
+	if _, err = lc.CreateSourceCodeFile(sourceUrl); err != nil {
+		t.Fatalf("error during create source file: %s", err.Error())
+	}
+
+	gotUrl, isSourceUrl, err := lc.GetSourceUrl()
+	if err != nil || !isSourceUrl || gotUrl != sourceUrl {
+		t.Fatalf("GetSourceUrl() got = %v, %v, %v, want %v", gotUrl, isSourceUrl, err, sourceUrl)
+	}
+	if err = lc.CreateSourceCodeFileFromUrl(context.Background(), gotUrl, []string{serverUrl.Hostname()}); err != nil {
+		t.Fatalf("CreateSourceCodeFileFromUrl() error = %v", err)
+	}
+	code, err := os.ReadFile(lc.GetAbsoluteSourceFilePath())
+	if err != nil || string(code) != sourceUrlCode {
+		t.Errorf("CreateSourceCodeFileFromUrl() created file with code = %v, want %v", string(code), sourceUrlCode)
+	}
+	if _, isSourceUrl, _ = lc.GetSourceUrl(); isSourceUrl {
+		t.Errorf("GetSourceUrl() returns true for the code")
+	}
+}
@@ -0,0 +1,147 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+const (
+	// ReadinessPath is the path of the handler which reports if the server is ready to process the code
+	ReadinessPath = "/readyz"
+	// commandCheckTimeout is the time which is given to a command of the SDK to print its version
+	commandCheckTimeout = 30 * time.Second
+)
+
+// SdkStatus is the result of the check of the SDK toolchain
+type SdkStatus struct {
+	Sdk       string `json:"sdk"`
+	Available bool   `json:"available"`
+	Error     string `json:"error,omitempty"`
+}
+
+// readinessResponse is the body of the response of the readiness handler
+type readinessResponse struct {
+	Ready bool        `json:"ready"`
+	Sdks  []SdkStatus `json:"sdks"`
+}
+
+// CheckEnvironment checks that the toolchain of each SDK is present and runnable.
+// Compile, run and test commands of the SDK are run with the version flag, the SDK is available if all of them succeed.
+// Empty commands (i.e. there is no compile command for Python) aren't checked.
+func CheckEnvironment(ctx context.Context, sdkEnvs ...*environment.BeamEnvs) []SdkStatus {
+	statuses := make([]SdkStatus, 0, len(sdkEnvs))
+	for _, sdkEnv := range sdkEnvs {
+		status := SdkStatus{Sdk: sdkEnv.ApacheBeamSdk.String(), Available: true}
+		if err := checkSdk(ctx, sdkEnv); err != nil {
+			status.Available = false
+			status.Error = err.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// checkSdk runs each command of the SDK with the version flag and returns the first error
+func checkSdk(ctx context.Context, sdkEnv *environment.BeamEnvs) error {
+	checked := make(map[string]bool)
+	config := sdkEnv.ExecutorConfig
+	for _, command := range []string{config.CompileCmd, config.RunCmd, config.TestCmd} {
+		if command == "" || checked[command] {
+			continue
+		}
+		checked[command] = true
+		if err := checkCommand(ctx, command, versionArgs(sdkEnv.ApacheBeamSdk)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCommand runs the command and returns an error if the command couldn't be found or fails
+func checkCommand(ctx context.Context, command string, args ...string) error {
+	ctx, cancel := context.WithTimeout(ctx, commandCheckTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, command, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s isn't runnable: %s, output: %s", command, err.Error(), output)
+	}
+	return nil
+}
+
+// versionArgs returns the args which make the commands of the SDK print their version
+func versionArgs(sdk pb.Sdk) []string {
+	switch sdk {
+	case pb.Sdk_SDK_GO:
+		return []string{"version"}
+	case pb.Sdk_SDK_SCIO, pb.Sdk_SDK_KOTLIN:
+		return []string{"-version"}
+	default:
+		return []string{"--version"}
+	}
+}
+
+// readinessHandler reports if the toolchains of all SDKs are available.
+// The toolchains are checked on each request until all of them are available, after that the server stays ready.
+type readinessHandler struct {
+	sdkEnvs  []*environment.BeamEnvs
+	mu       sync.Mutex
+	statuses []SdkStatus
+	ready    bool
+}
+
+// Handler returns http.Handler which responds with 200 if the toolchains of all SDKs are available
+// and with 503 otherwise. The body of the response contains the result of the check of each SDK in JSON format.
+func Handler(sdkEnvs ...*environment.BeamEnvs) http.Handler {
+	return &readinessHandler{sdkEnvs: sdkEnvs}
+}
+
+func (h *readinessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	if !h.ready {
+		h.statuses = CheckEnvironment(r.Context(), h.sdkEnvs...)
+		h.ready = isReady(h.statuses)
+	}
+	response := readinessResponse{Ready: h.ready, Sdks: h.statuses}
+	h.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !response.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Errorf("Readiness: error during write response: %s", err.Error())
+	}
+}
+
+// isReady returns true if all SDKs are available
+func isReady(statuses []SdkStatus) bool {
+	for _, status := range statuses {
+		if !status.Available {
+			return false
+		}
+	}
+	return true
+}
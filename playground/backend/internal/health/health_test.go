@@ -0,0 +1,154 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const absentCommand = "playground-absent-command"
+
+// presentCommand creates an executable which successfully prints its version and returns path to it
+func presentCommand(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "playground-present-command")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho \"version 1.0\"\n"), 0755); err != nil {
+		t.Fatalf("error during create executable: %s", err.Error())
+	}
+	return path
+}
+
+func fakeSdkEnv(compileCmd, runCmd string) *environment.BeamEnvs {
+	executorConfig := environment.NewExecutorConfig(compileCmd, runCmd, "", []string{}, []string{}, []string{})
+	return environment.NewBeamEnvs(pb.Sdk_SDK_JAVA, executorConfig, "")
+}
+
+func TestCheckEnvironment(t *testing.T) {
+	present := presentCommand(t)
+	tests := []struct {
+		name          string
+		sdkEnv        *environment.BeamEnvs
+		wantAvailable bool
+	}{
+		{
+			// Test case with calling CheckEnvironment method with present compile and run commands.
+			// As a result, want to receive available SDK.
+			name:          "present commands",
+			sdkEnv:        fakeSdkEnv(present, present),
+			wantAvailable: true,
+		},
+		{
+			// Test case with calling CheckEnvironment method with empty compile command.
+			// As a result, want to receive available SDK.
+			name:          "empty compile command",
+			sdkEnv:        fakeSdkEnv("", present),
+			wantAvailable: true,
+		},
+		{
+			// Test case with calling CheckEnvironment method with absent run command.
+			// As a result, want to receive unavailable SDK.
+			name:          "absent run command",
+			sdkEnv:        fakeSdkEnv(present, absentCommand),
+			wantAvailable: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckEnvironment(context.Background(), tt.sdkEnv)
+			if len(got) != 1 {
+				t.Fatalf("CheckEnvironment() returned %d statuses, want 1", len(got))
+			}
+			if got[0].Sdk != pb.Sdk_SDK_JAVA.String() {
+				t.Errorf("CheckEnvironment() sdk = %v, want %v", got[0].Sdk, pb.Sdk_SDK_JAVA.String())
+			}
+			if got[0].Available != tt.wantAvailable {
+				t.Errorf("CheckEnvironment() available = %v, want %v, error: %s", got[0].Available, tt.wantAvailable, got[0].Error)
+			}
+			if !tt.wantAvailable && got[0].Error == "" {
+				t.Errorf("CheckEnvironment() error is empty for unavailable SDK")
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	present := presentCommand(t)
+	tests := []struct {
+		name       string
+		sdkEnvs    []*environment.BeamEnvs
+		wantStatus int
+	}{
+		{
+			// Test case with calling readiness handler with all SDKs available.
+			// As a result, want to receive 200.
+			name:       "all SDKs are available",
+			sdkEnvs:    []*environment.BeamEnvs{fakeSdkEnv(present, present)},
+			wantStatus: http.StatusOK,
+		},
+		{
+			// Test case with calling readiness handler with one SDK unavailable.
+			// As a result, want to receive 503.
+			name:       "one SDK is unavailable",
+			sdkEnvs:    []*environment.BeamEnvs{fakeSdkEnv(present, present), fakeSdkEnv(absentCommand, present)},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			Handler(tt.sdkEnvs...).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ReadinessPath, nil))
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("Handler() status = %v, want %v", recorder.Code, tt.wantStatus)
+			}
+			var response readinessResponse
+			if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+				t.Fatalf("Handler() body isn't valid JSON: %s", err.Error())
+			}
+			if len(response.Sdks) != len(tt.sdkEnvs) {
+				t.Errorf("Handler() returned %d statuses, want %d", len(response.Sdks), len(tt.sdkEnvs))
+			}
+		})
+	}
+}
+
+func TestHandlerBecomesReady(t *testing.T) {
+	present := presentCommand(t)
+	installed := filepath.Join(t.TempDir(), "playground-installed-command")
+	handler := Handler(fakeSdkEnv(present, installed))
+	request := httptest.NewRequest(http.MethodGet, ReadinessPath, nil)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("Handler() before install status = %v, want %v", recorder.Code, http.StatusServiceUnavailable)
+	}
+
+	if err := os.Rename(presentCommand(t), installed); err != nil {
+		t.Fatalf("error during install command: %s", err.Error())
+	}
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Handler() after install status = %v, want %v", recorder.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,104 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Field names which are used to correlate log messages of the code processing
+const (
+	PipelineIdField = "pipelineId"
+	SdkField        = "sdk"
+	PhaseField      = "phase"
+)
+
+type entryKey struct{}
+
+// field is a key-value pair which is added to each message logged via Entry
+type field struct {
+	key   string
+	value interface{}
+}
+
+// Entry logs messages with a set of fields, so all messages of one request could be found by the values of the fields.
+// Fields are written before the message in the key=value format in the order they are added.
+// Entry is immutable, With returns a new Entry, so it is safe to share Entry between goroutines.
+type Entry struct {
+	fields []field
+}
+
+// With returns a new Entry with the field
+func With(key string, value interface{}) *Entry {
+	return (&Entry{}).With(key, value)
+}
+
+// With returns a new Entry with the fields of the entry and the new field
+func (e *Entry) With(key string, value interface{}) *Entry {
+	fields := make([]field, 0, len(e.fields)+1)
+	fields = append(fields, e.fields...)
+	return &Entry{fields: append(fields, field{key: key, value: value})}
+}
+
+// NewContext returns a new context which carries the entry
+func NewContext(ctx context.Context, entry *Entry) context.Context {
+	return context.WithValue(ctx, entryKey{}, entry)
+}
+
+// FromContext returns the Entry which is carried by ctx.
+// If ctx doesn't carry an Entry returns an Entry without fields.
+func FromContext(ctx context.Context) *Entry {
+	if entry, ok := ctx.Value(entryKey{}).(*Entry); ok {
+		return entry
+	}
+	return &Entry{}
+}
+
+// Infof formats according to a format specifier and logs a message with the fields at level Info.
+func (e *Entry) Infof(format string, args ...interface{}) {
+	Info(e.message(format, args...))
+}
+
+// Warnf formats according to a format specifier and logs a message with the fields at level Warn.
+func (e *Entry) Warnf(format string, args ...interface{}) {
+	Warn(e.message(format, args...))
+}
+
+// Errorf formats according to a format specifier and logs a message with the fields at level Error.
+func (e *Entry) Errorf(format string, args ...interface{}) {
+	Error(e.message(format, args...))
+}
+
+// Debugf formats according to a format specifier and logs a message with the fields at level Debug.
+func (e *Entry) Debugf(format string, args ...interface{}) {
+	Debug(e.message(format, args...))
+}
+
+// message returns the formatted message prefixed by the fields
+func (e *Entry) message(format string, args ...interface{}) string {
+	message := strings.TrimSuffix(fmt.Sprintf(format, args...), "\n")
+	if len(e.fields) == 0 {
+		return message
+	}
+	var builder strings.Builder
+	for _, f := range e.fields {
+		builder.WriteString(fmt.Sprintf("%s=%v ", f.key, f.value))
+	}
+	builder.WriteString(message)
+	return builder.String()
+}
@@ -0,0 +1,99 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestEntry_Infof(t *testing.T) {
+	tests := []struct {
+		name   string
+		entry  *Entry
+		format string
+		args   []interface{}
+		want   string
+	}{
+		{
+			name:   "entry without fields",
+			entry:  &Entry{},
+			format: "TEST FORMAT %s\n",
+			args:   []interface{}{"TEST_VALUE"},
+			want:   "TEST FORMAT TEST_VALUE",
+		},
+		{
+			name:   "entry with fields",
+			entry:  With(PipelineIdField, "MOCK_ID").With(SdkField, "SDK_JAVA"),
+			format: "TEST FORMAT %s",
+			args:   []interface{}{"TEST_VALUE"},
+			want:   "pipelineId=MOCK_ID sdk=SDK_JAVA TEST FORMAT TEST_VALUE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.entry.Infof(tt.format, tt.args...)
+			expectedValue := preparedHandler.logs[len(preparedHandler.logs)-1]
+			if expectedValue != fmt.Sprint(INFO, tt.want) {
+				t.Errorf("Value %v not added in the logs", expectedValue)
+			}
+		})
+	}
+}
+
+func TestEntry_With(t *testing.T) {
+	entry := With(PipelineIdField, "MOCK_ID")
+	compileEntry := entry.With(PhaseField, "Compile")
+	runEntry := entry.With(PhaseField, "Run")
+
+	if got := entry.message("msg"); got != "pipelineId=MOCK_ID msg" {
+		t.Errorf("With() changed the parent entry: %s", got)
+	}
+	if got := compileEntry.message("msg"); got != "pipelineId=MOCK_ID phase=Compile msg" {
+		t.Errorf("With() got = %s", got)
+	}
+	if got := runEntry.message("msg"); got != "pipelineId=MOCK_ID phase=Run msg" {
+		t.Errorf("With() got = %s", got)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	entry := With(PipelineIdField, "MOCK_ID")
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "context without entry",
+			ctx:  context.Background(),
+			want: "msg",
+		},
+		{
+			name: "context with entry",
+			ctx:  NewContext(context.Background(), entry),
+			want: "pipelineId=MOCK_ID msg",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromContext(tt.ctx).message("msg"); got != tt.want {
+				t.Errorf("FromContext() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
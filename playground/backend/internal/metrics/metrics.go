@@ -0,0 +1,94 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"time"
+)
+
+const (
+	namespace = "playground"
+	// MetricsPath is the path of the handler which exposes metrics
+	MetricsPath = "/metrics"
+)
+
+// durationBuckets are the buckets (in seconds) of the compile and run durations.
+// They are suitable for the interactive code which is executed from sub-second to tens of seconds.
+var durationBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120}
+
+var (
+	// pipelinesTotal is the number of the processed pipelines by the final status
+	pipelinesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pipelines_total",
+		Help:      "The total number of the processed pipelines by the final status.",
+	}, []string{"status"})
+
+	// compileDuration is the duration of the compile step
+	compileDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "compile_duration_seconds",
+		Help:      "The duration of the compile step of the pipelines.",
+		Buckets:   durationBuckets,
+	})
+
+	// runDuration is the duration of the run step
+	runDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "run_duration_seconds",
+		Help:      "The duration of the run step of the pipelines.",
+		Buckets:   durationBuckets,
+	})
+
+	// executingPipelines is the number of the pipelines which are processed at the moment
+	executingPipelines = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "executing_pipelines",
+		Help:      "The number of the pipelines which are processed at the moment.",
+	})
+)
+
+// Handler returns http.Handler which exposes all metrics in Prometheus format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// PipelineStarted increases the number of the pipelines which are processed at the moment
+func PipelineStarted() {
+	executingPipelines.Inc()
+}
+
+// PipelineFinished decreases the number of the pipelines which are processed at the moment
+//	and increases the number of the processed pipelines with the final status
+func PipelineFinished(status pb.Status) {
+	executingPipelines.Dec()
+	pipelinesTotal.WithLabelValues(status.String()).Inc()
+}
+
+// ObserveCompileDuration keeps the duration of the compile step which is started at startTime
+func ObserveCompileDuration(startTime time.Time) {
+	compileDuration.Observe(time.Since(startTime).Seconds())
+}
+
+// ObserveRunDuration keeps the duration of the run step which is started at startTime
+func ObserveRunDuration(startTime time.Time) {
+	runDuration.Observe(time.Since(startTime).Seconds())
+}
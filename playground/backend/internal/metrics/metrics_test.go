@@ -0,0 +1,73 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/goleak"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPipelineMetrics(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	executingBefore := testutil.ToFloat64(executingPipelines)
+	finishedBefore := testutil.ToFloat64(pipelinesTotal.WithLabelValues(pb.Status_STATUS_FINISHED.String()))
+
+	PipelineStarted()
+	if got := testutil.ToFloat64(executingPipelines); got != executingBefore+1 {
+		t.Errorf("PipelineStarted() executing pipelines = %v, want %v", got, executingBefore+1)
+	}
+	PipelineFinished(pb.Status_STATUS_FINISHED)
+	if got := testutil.ToFloat64(executingPipelines); got != executingBefore {
+		t.Errorf("PipelineFinished() executing pipelines = %v, want %v", got, executingBefore)
+	}
+	if got := testutil.ToFloat64(pipelinesTotal.WithLabelValues(pb.Status_STATUS_FINISHED.String())); got != finishedBefore+1 {
+		t.Errorf("PipelineFinished() finished pipelines = %v, want %v", got, finishedBefore+1)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	PipelineStarted()
+	PipelineFinished(pb.Status_STATUS_RUN_ERROR)
+	ObserveCompileDuration(time.Now().Add(-300 * time.Millisecond))
+	ObserveRunDuration(time.Now().Add(-3 * time.Second))
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", MetricsPath, nil))
+	body, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		t.Fatalf("error during read metrics: %s", err.Error())
+	}
+	for _, want := range []string{
+		"playground_pipelines_total{status=\"STATUS_RUN_ERROR\"} 1",
+		"playground_executing_pipelines 0",
+		"playground_compile_duration_seconds_bucket{le=\"0.5\"} 1",
+		"playground_compile_duration_seconds_count 1",
+		"playground_run_duration_seconds_bucket{le=\"2.5\"} 0",
+		"playground_run_duration_seconds_bucket{le=\"5\"} 1",
+		"playground_run_duration_seconds_count 1",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("Handler() metrics don't contain %s", want)
+		}
+	}
+}
@@ -17,8 +17,13 @@ package preparators
 
 import (
 	"errors"
+	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 )
 
 const (
@@ -26,12 +31,89 @@ const (
 	fmtArgs   = "fmt"
 )
 
+var (
+	// goPackageClauseRegexp matches the package clause of the Go code, i.e. "package main"
+	goPackageClauseRegexp = regexp.MustCompile(`(?m)^\s*package\s+\w+`)
+	// goImportRegexp matches the single line import of the bare Go code, i.e. `import "fmt"`
+	goImportRegexp = regexp.MustCompile(`^\s*import\s+(\w+\s+)?"([\w/]+)"\s*$`)
+	// goPackageUsageRegexp matches the usage of the package in the Go code, i.e. "fmt.Println"
+	goPackageUsageRegexp = regexp.MustCompile(`\b([a-z]\w*)\.[A-Z]`)
+)
+
+// goStandardImports are the standard packages which are imported to the bare Go code automatically
+//	if they are used in the code. Keys are the names of the packages, values are their import paths.
+var goStandardImports = map[string]string{
+	"bytes":   "bytes",
+	"errors":  "errors",
+	"fmt":     "fmt",
+	"math":    "math",
+	"rand":    "math/rand",
+	"sort":    "sort",
+	"strconv": "strconv",
+	"strings": "strings",
+	"time":    "time",
+	"unicode": "unicode",
+	"utf8":    "unicode/utf8",
+}
+
 // GetGoPreparators returns reparation methods that should be applied to Go code
 func GetGoPreparators(filePath string) *[]Preparator {
 	preparatorArgs := make([]interface{}, 1)
 	preparatorArgs[0] = filePath
+	wrapBareCodePreparator := Preparator{Prepare: wrapBareCode, Args: preparatorArgs}
 	formatCodePreparator := Preparator{Prepare: formatCode, Args: preparatorArgs}
-	return &[]Preparator{formatCodePreparator}
+	return &[]Preparator{wrapBareCodePreparator, formatCodePreparator}
+}
+
+// wrapBareCode makes the runnable program from the bare Go code, i.e. the code with statements only.
+// If the code doesn't have the package clause, the statements of the code are wrapped in the main function
+//	of the main package and the standard packages which are used in the code (see goStandardImports) are imported.
+// Single line imports of the bare code are kept. The code with the package clause is kept as is.
+func wrapBareCode(args ...interface{}) error {
+	filePath := args[0].(string)
+	code, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	if goPackageClauseRegexp.Match(code) {
+		return nil
+	}
+
+	imports := make(map[string]bool)
+	var statements []string
+	for _, line := range strings.Split(string(code), "\n") {
+		if match := goImportRegexp.FindStringSubmatch(line); match != nil {
+			importLine := `"` + match[2] + `"`
+			if alias := strings.TrimSpace(match[1]); alias != "" {
+				importLine = alias + " " + importLine
+			}
+			imports[importLine] = true
+			continue
+		}
+		statements = append(statements, line)
+	}
+	body := strings.Join(statements, "\n")
+	for _, match := range goPackageUsageRegexp.FindAllStringSubmatch(body, -1) {
+		if importPath, ok := goStandardImports[match[1]]; ok && !isImported(imports, match[1], importPath) {
+			imports[`"`+importPath+`"`] = true
+		}
+	}
+
+	importLines := make([]string, 0, len(imports))
+	for importLine := range imports {
+		importLines = append(importLines, "\t"+importLine)
+	}
+	sort.Strings(importLines)
+	program := fmt.Sprintf("package main\n\nimport (\n%s\n)\n\nfunc main() {\n%s\n}\n", strings.Join(importLines, "\n"), body)
+	if len(importLines) == 0 {
+		program = fmt.Sprintf("package main\n\nfunc main() {\n%s\n}\n", body)
+	}
+	return os.WriteFile(filePath, []byte(program), 0600)
+}
+
+// isImported checks if the package with the name and the import path is imported with or without the alias
+func isImported(imports map[string]bool, name, importPath string) bool {
+	return imports[`"`+importPath+`"`] || imports[name+` "`+importPath+`"`]
 }
 
 // formatCode formats go code
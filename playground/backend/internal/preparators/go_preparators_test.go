@@ -19,6 +19,7 @@ import (
 	"beam.apache.org/playground/backend/internal/logger"
 	"fmt"
 	"os"
+	"os/exec"
 	"reflect"
 	"testing"
 )
@@ -89,7 +90,8 @@ func getPreparedArgs(args ...interface{}) []interface{} {
 }
 
 func TestGetGoPreparators(t *testing.T) {
-	expectedPreparator := Preparator{Prepare: formatCode, Args: nil}
+	wrapBareCodePreparator := Preparator{Prepare: wrapBareCode, Args: nil}
+	formatCodePreparator := Preparator{Prepare: formatCode, Args: nil}
 	type args struct {
 		filePath string
 	}
@@ -102,7 +104,7 @@ func TestGetGoPreparators(t *testing.T) {
 			// getting the expected preparator
 			name: "get expected preparator",
 			args: args{filePath: ""},
-			want: &[]Preparator{expectedPreparator},
+			want: &[]Preparator{wrapBareCodePreparator, formatCodePreparator},
 		},
 	}
 	for _, tt := range tests {
@@ -146,3 +148,82 @@ func Test_formatCode(t *testing.T) {
 		})
 	}
 }
+
+func Test_wrapBareCode(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{
+			// wrapping code with the package clause
+			// the code is kept as is
+			name: "code with package",
+			code: correctCode,
+			want: correctCode,
+		},
+		{
+			// wrapping bare code which uses standard packages
+			// the code is wrapped in the main function and the used packages are imported
+			name: "bare code",
+			code: "greeting := strings.ToUpper(\"hello\")\nfmt.Println(greeting)",
+			want: "package main\n\nimport (\n\t\"fmt\"\n\t\"strings\"\n)\n\nfunc main() {\ngreeting := strings.ToUpper(\"hello\")\nfmt.Println(greeting)\n}\n",
+		},
+		{
+			// wrapping bare code with imports
+			// the imports of the code are kept and aren't duplicated
+			name: "bare code with imports",
+			code: "import \"fmt\"\nimport r \"math/rand\"\nfmt.Println(r.Intn(1), rand.Intn(1))",
+			want: "package main\n\nimport (\n\t\"fmt\"\n\t\"math/rand\"\n\tr \"math/rand\"\n)\n\nfunc main() {\nfmt.Println(r.Intn(1), rand.Intn(1))\n}\n",
+		},
+		{
+			// wrapping bare code which doesn't use packages
+			// the code is wrapped in the main function without imports
+			name: "bare code without imports",
+			code: "x := 1\n_ = x",
+			want: "package main\n\nfunc main() {\nx := 1\n_ = x\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileName := "bare.go"
+			if err := createFile(fileName, tt.code); err != nil {
+				t.Fatalf("error during create file: %s", err.Error())
+			}
+			defer os.Remove(fileName)
+
+			if err := wrapBareCode(fileName); err != nil {
+				t.Fatalf("wrapBareCode() error = %v", err)
+			}
+			got, err := os.ReadFile(fileName)
+			if err != nil {
+				t.Fatalf("error during read file: %s", err.Error())
+			}
+			if string(got) != tt.want {
+				t.Errorf("wrapBareCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_wrapBareCodeRun(t *testing.T) {
+	// bare code which can't be run by itself
+	fileName := "bare_run.go"
+	if err := createFile(fileName, "fmt.Println(strings.Repeat(\"ab\", 2))"); err != nil {
+		t.Fatalf("error during create file: %s", err.Error())
+	}
+	defer os.Remove(fileName)
+
+	for _, preparator := range *GetGoPreparators(fileName) {
+		if err := preparator.Prepare(preparator.Args...); err != nil {
+			t.Fatalf("Prepare() error = %v", err)
+		}
+	}
+	output, err := exec.Command(nameBinGo, "run", fileName).CombinedOutput()
+	if err != nil {
+		t.Fatalf("error during run prepared code: %s, output: %s", err.Error(), output)
+	}
+	if string(output) != "abab\n" {
+		t.Errorf("prepared code prints %q, want %q", output, "abab\n")
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 )
@@ -33,9 +34,16 @@ const (
 	newLinePattern                    = "\n"
 	pathSeparatorPattern              = os.PathSeparator
 	tmpFileSuffix                     = "tmp"
+	javaSourceFileExtension           = ".java"
 )
 
+// javaClassNameRegexp matches the simple name of a Java class, i.e. WordCount
+var javaClassNameRegexp = regexp.MustCompile(`^[\p{L}_$][\p{L}\p{N}_$]*$`)
+
 // GetJavaPreparators returns preparation methods that should be applied to Java code
+// The public modifier of the classes is removed only if the name of the file can't be the name of the public class
+//	(i.e. the file is named after the pipeline id). Otherwise, the file is named by the user,
+//	so the public class should be declared in the file with the same name as javac requires.
 func GetJavaPreparators(filePath string) *[]Preparator {
 	publicClassModification := Preparator{
 		Prepare: replace,
@@ -45,6 +53,10 @@ func GetJavaPreparators(filePath string) *[]Preparator {
 		Prepare: replace,
 		Args:    []interface{}{filePath, packagePattern, importStringPattern},
 	}
+	fileName := filepath.Base(filePath)
+	if filepath.Ext(fileName) == javaSourceFileExtension && javaClassNameRegexp.MatchString(strings.TrimSuffix(fileName, javaSourceFileExtension)) {
+		return &[]Preparator{additionalPackage}
+	}
 	return &[]Preparator{publicClassModification, additionalPackage}
 }
 
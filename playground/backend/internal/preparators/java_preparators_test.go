@@ -99,6 +99,20 @@ func TestGetJavaPreparators(t *testing.T) {
 			args: args{"MOCK_FILEPATH"},
 			want: 2,
 		},
+		{
+			// Test case with calling GetJavaPreparators method with the file which is named after the public class.
+			// As a result, want to receive preparators which keep the public modifier of the class.
+			name: "file named after the class",
+			args: args{"/path/to/src/WordCount.java"},
+			want: 1,
+		},
+		{
+			// Test case with calling GetJavaPreparators method with the file which is named after the pipeline id.
+			// As a result, want to receive preparators which remove the public modifier of the class.
+			name: "file named after the pipeline id",
+			args: args{"/path/to/src/3f2a9c1e-0b4d-4e8a-9f0e-2c6b1d7a5e43.java"},
+			want: 2,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
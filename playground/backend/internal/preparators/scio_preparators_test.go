@@ -0,0 +1,67 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparators
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetScioPreparators(t *testing.T) {
+	codeWithPackage := "package com.spotify.scio.examples\n\nobject WordCount {\n  def main(cmdlineArgs: Array[String]): Unit = {}\n}"
+	codeWithImportedPackage := "import com.spotify.scio.examples._\n\nobject WordCount {\n  def main(cmdlineArgs: Array[String]): Unit = {}\n}"
+
+	path, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_SCIO, uuid.New(), filepath.Join(path, "temp"))
+	_ = lc.CreateFolders()
+	defer os.RemoveAll(filepath.Join(path, "temp"))
+	_, _ = lc.CreateSourceCodeFile(codeWithPackage)
+
+	tests := []struct {
+		name     string
+		filePath string
+		wantCode string
+	}{
+		{
+			// Test that file where package is used changes to import all dependencies from this package
+			name:     "original file with package",
+			filePath: lc.GetAbsoluteSourceFilePath(),
+			wantCode: codeWithImportedPackage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, preparator := range *GetScioPreparators(tt.filePath) {
+				if err := preparator.Prepare(preparator.Args...); err != nil {
+					t.Errorf("GetScioPreparators() preparator returns error = %v", err)
+				}
+			}
+			data, err := os.ReadFile(tt.filePath)
+			if err != nil {
+				t.Errorf("GetScioPreparators() unexpected error = %v", err)
+			}
+			if string(data) != tt.wantCode {
+				t.Errorf("GetScioPreparators() code = {%v}, wantCode {%v}", string(data), tt.wantCode)
+			}
+		})
+	}
+}
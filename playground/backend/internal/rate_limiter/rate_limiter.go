@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package rate_limiter
+
+import (
+	"sync"
+	"time"
+)
+
+// bucket is a token bucket of a client. Each request of the client takes a token from the bucket,
+// tokens are refilled with the rate of the limiter up to its burst.
+type bucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// RateLimiter limits the number of requests received from each client by the token bucket algorithm.
+// Buckets are kept per client identifier, so one client using up its limit doesn't affect the others.
+type RateLimiter struct {
+	// rate is a number of tokens which are added to the bucket per second
+	rate float64
+	// burst is a max number of tokens in the bucket
+	burst float64
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	// lastSweep is the time when the full buckets were removed last time
+	lastSweep time.Time
+	// now returns the current time, it is replaced by the tests
+	now func() time.Time
+}
+
+// New constructor for RateLimiter.
+// requestsPerMinute is a number of requests which could be received from the same client per minute,
+//	0 means that requests are not limited.
+// burst is a max number of requests which could be received from the same client at once,
+//	0 means that it is equal to requestsPerMinute.
+func New(requestsPerMinute, burst int) *RateLimiter {
+	if requestsPerMinute <= 0 {
+		return &RateLimiter{}
+	}
+	if burst <= 0 {
+		burst = requestsPerMinute
+	}
+	return &RateLimiter{
+		rate:    float64(requestsPerMinute) / time.Minute.Seconds(),
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of the client and returns true if the request of the client could be processed.
+// Returns false if the client has used up its limit, the request should be rejected in this case.
+func (rl *RateLimiter) Allow(clientId string) bool {
+	if rl == nil || rl.buckets == nil {
+		return true
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	rl.sweep(now)
+	b, ok := rl.buckets[clientId]
+	if !ok {
+		b = &bucket{tokens: rl.burst, lastRefill: now}
+		rl.buckets[clientId] = b
+	}
+	b.tokens += now.Sub(b.lastRefill).Seconds() * rl.rate
+	if b.tokens > rl.burst {
+		b.tokens = rl.burst
+	}
+	b.lastRefill = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweep removes buckets which are refilled up to the burst since the last request of the client,
+// so buckets of the clients which don't send requests anymore aren't kept forever.
+// A removed bucket is the same as a new one, so the limits of the clients aren't changed.
+func (rl *RateLimiter) sweep(now time.Time) {
+	refillDuration := time.Duration(rl.burst / rl.rate * float64(time.Second))
+	if now.Sub(rl.lastSweep) < refillDuration {
+		return
+	}
+	for clientId, b := range rl.buckets {
+		if now.Sub(b.lastRefill) >= refillDuration {
+			delete(rl.buckets, clientId)
+		}
+	}
+	rl.lastSweep = now
+}
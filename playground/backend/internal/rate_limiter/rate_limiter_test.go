@@ -0,0 +1,154 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package rate_limiter
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is the time of the rate limiter which is moved by the tests
+type fakeClock struct {
+	time time.Time
+}
+
+func (fc *fakeClock) now() time.Time {
+	return fc.time
+}
+
+func newWithClock(requestsPerMinute, burst int) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{time: time.Now()}
+	rl := New(requestsPerMinute, burst)
+	rl.now = clock.now
+	return rl, clock
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	tests := []struct {
+		name              string
+		requestsPerMinute int
+		burst             int
+		requests          int
+		wantAllowed       int
+	}{
+		{
+			// Test case with sending more requests than the burst at once.
+			// As a result, want to receive only the burst number of requests allowed.
+			name:              "requests over the burst are rejected",
+			requestsPerMinute: 60,
+			burst:             5,
+			requests:          10,
+			wantAllowed:       5,
+		},
+		{
+			// Test case with sending requests without the burst.
+			// As a result, want to receive the requests per minute allowed at once.
+			name:              "burst is equal to the requests per minute by default",
+			requestsPerMinute: 3,
+			burst:             0,
+			requests:          5,
+			wantAllowed:       3,
+		},
+		{
+			// Test case with sending requests to the disabled rate limiter.
+			// As a result, want to receive all requests allowed.
+			name:              "requests are not limited",
+			requestsPerMinute: 0,
+			burst:             0,
+			requests:          100,
+			wantAllowed:       100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := New(tt.requestsPerMinute, tt.burst)
+			allowed := 0
+			for i := 0; i < tt.requests; i++ {
+				if rl.Allow("client") {
+					allowed++
+				}
+			}
+			if allowed != tt.wantAllowed {
+				t.Errorf("Allow() allowed %d requests, but expects: %d", allowed, tt.wantAllowed)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_AllowRefill(t *testing.T) {
+	rl, clock := newWithClock(60, 2)
+	if !rl.Allow("client") || !rl.Allow("client") {
+		t.Fatalf("Allow() rejected requests within the burst")
+	}
+	if rl.Allow("client") {
+		t.Fatalf("Allow() allowed request over the burst")
+	}
+
+	// one token is refilled per second
+	clock.time = clock.time.Add(500 * time.Millisecond)
+	if rl.Allow("client") {
+		t.Errorf("Allow() allowed request before the token is refilled")
+	}
+	clock.time = clock.time.Add(500 * time.Millisecond)
+	if !rl.Allow("client") {
+		t.Errorf("Allow() rejected request after the token is refilled")
+	}
+	if rl.Allow("client") {
+		t.Errorf("Allow() allowed request over the refilled tokens")
+	}
+
+	// tokens aren't refilled over the burst
+	clock.time = clock.time.Add(time.Hour)
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if rl.Allow("client") {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Errorf("Allow() allowed %d requests after the bucket is refilled, but expects: %d", allowed, 2)
+	}
+}
+
+func TestRateLimiter_AllowPerClient(t *testing.T) {
+	rl, _ := newWithClock(60, 1)
+	if !rl.Allow("first") {
+		t.Fatalf("Allow() rejected the first request of the first client")
+	}
+	if rl.Allow("first") {
+		t.Errorf("Allow() allowed request of the first client over the burst")
+	}
+	if !rl.Allow("second") {
+		t.Errorf("Allow() rejected request of the second client because of the first one")
+	}
+}
+
+func TestRateLimiter_AllowSweep(t *testing.T) {
+	rl, clock := newWithClock(60, 1)
+	rl.Allow("first")
+	rl.Allow("second")
+
+	// buckets are refilled after a second, so they are removed with the next request
+	clock.time = clock.time.Add(time.Second)
+	if !rl.Allow("third") {
+		t.Fatalf("Allow() rejected the first request of the third client")
+	}
+	if len(rl.buckets) != 1 {
+		t.Errorf("Allow() kept %d buckets, but expects: %d", len(rl.buckets), 1)
+	}
+	if !rl.Allow("first") {
+		t.Errorf("Allow() rejected request of the first client after its bucket is removed")
+	}
+}
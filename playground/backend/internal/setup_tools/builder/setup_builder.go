@@ -19,7 +19,9 @@ import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/executors"
+	"beam.apache.org/playground/backend/internal/fs_tool"
 	"beam.apache.org/playground/backend/internal/utils"
+	"beam.apache.org/playground/backend/internal/validators"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -28,28 +30,55 @@ import (
 const (
 	javaLogConfigFileName        = "logging.properties"
 	javaLogConfigFilePlaceholder = "{logConfigFile}"
+	// compiledDirPlaceholder is replaced in the args of the JVM-based SDKs with the folder of the pipeline with compiled files,
+	//	so the code is compiled into the folder of its pipeline and run from it (i.e. "-d {compiledDir}" and "-cp {compiledDir}:")
+	compiledDirPlaceholder = "{compiledDir}"
+	// graphFilePlaceholder is replaced in the graph args with the file which the code saves graph of the pipeline to,
+	//	so the graph is read after the run of the code (i.e. "--graph={graphFile}")
+	graphFilePlaceholder = "{graphFile}"
 )
 
 // SetupExecutorBuilder return executor with set args for validator, preparator, compiler and runner
-func SetupExecutorBuilder(srcFilePath, baseFolderPath, execFilePath, pipelineOptions string, sdkEnv *environment.BeamEnvs) (*executors.ExecutorBuilder, error) {
+func SetupExecutorBuilder(lc *fs_tool.LifeCycle, pipelineOptions string, sdkEnv *environment.BeamEnvs) (*executors.ExecutorBuilder, error) {
 	sdk := sdkEnv.ApacheBeamSdk
+	srcFilePath := lc.GetAbsoluteSourceFilePath()
+	baseFolderPath := lc.GetAbsoluteBaseFolderPath()
+	execFilePath := lc.GetAbsoluteExecutableFilePath()
 
-	if sdk == pb.Sdk_SDK_JAVA {
+	var jvmArgs []string
+	if sdk == pb.Sdk_SDK_JAVA || sdk == pb.Sdk_SDK_SCIO || sdk == pb.Sdk_SDK_KOTLIN {
+		var err error
+		jvmArgs, pipelineOptions, err = utils.SplitJvmArgs(pipelineOptions)
+		if err != nil {
+			return nil, err
+		}
 		pipelineOptions = utils.ReplaceSpacesWithEquals(pipelineOptions)
 	}
 
-	val, err := utils.GetValidators(sdk, srcFilePath)
+	executorConfig := sdkEnv.ExecutorConfig
+	val, err := utils.GetValidators(sdk)
 	if err != nil {
 		return nil, err
 	}
+	*val = append([]validators.Validator{validators.GetPipelineOptionsValidator(pipelineOptions, executorConfig.AllowedRunners)}, *val...)
+	if len(executorConfig.ForbiddenImports) > 0 {
+		*val = append(*val, validators.GetForbiddenImportsValidator(executorConfig.ForbiddenImports))
+	}
+	if executorConfig.ForbidUnboundedOutput {
+		*val = append(*val, validators.GetUnboundedOutputValidator(sdk))
+	}
+	if executorConfig.WarnMissingPipeline {
+		*val = append(*val, validators.GetBeamPipelineValidator(sdk))
+	}
 	prep, err := utils.GetPreparators(sdk, srcFilePath)
 	if err != nil {
 		return nil, err
 	}
-	executorConfig := sdkEnv.ExecutorConfig
 	builder := executors.NewExecutorBuilder().
 		WithExecutableFileName(execFilePath).
 		WithWorkingDir(baseFolderPath).
+		WithEnv(executorConfig.Env).
+		WithJvmArgs(jvmArgs).
 		WithValidator().
 		WithSdkValidators(val).
 		WithPreparator().
@@ -58,37 +87,68 @@ func SetupExecutorBuilder(srcFilePath, baseFolderPath, execFilePath, pipelineOpt
 		WithCommand(executorConfig.CompileCmd).
 		WithArgs(executorConfig.CompileArgs).
 		WithFileName(srcFilePath).
+		WithFileNames(lc.GetAbsoluteSourceFilePaths()).
 		WithRunner().
 		WithCommand(executorConfig.RunCmd).
 		WithArgs(executorConfig.RunArgs).
 		WithPipelineOptions(strings.Split(pipelineOptions, " ")).
+		WithGraphOutput(replacePlaceholders(executorConfig.GraphArgs, map[string]string{graphFilePlaceholder: lc.GetAbsoluteGraphFilePath()})).
 		WithTestRunner().
 		WithCommand(executorConfig.TestCmd).
 		WithArgs(executorConfig.TestArgs).
 		ExecutorBuilder
 
 	switch sdk {
-	case pb.Sdk_SDK_JAVA: // Executable name for java class will be known after compilation
-		args := make([]string, 0)
-		for _, arg := range executorConfig.RunArgs {
-			if strings.Contains(arg, javaLogConfigFilePlaceholder) {
-				logConfigFilePath := filepath.Join(baseFolderPath, javaLogConfigFileName)
-				arg = strings.Replace(arg, javaLogConfigFilePlaceholder, logConfigFilePath, 1)
-			}
-			args = append(args, arg)
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_KOTLIN: // Executable name for java class will be known after compilation
+		placeholders := map[string]string{
+			javaLogConfigFilePlaceholder: filepath.Join(baseFolderPath, javaLogConfigFileName),
+			compiledDirPlaceholder:       lc.GetAbsoluteCompiledFolderPath(),
 		}
-		builder = builder.WithRunner().WithArgs(args).ExecutorBuilder
+		builder = withReplacedPlaceholders(builder, executorConfig, placeholders)
 	case pb.Sdk_SDK_GO: //go run command is executable file itself
+		// go build is module-aware, so the code should be compiled from the folder with go.mod file
 		builder = builder.
 			WithExecutableFileName("").
+			WithCompiler().
+			WithOutputFilePath(execFilePath).
+			WithWorkingDir(filepath.Dir(srcFilePath)).
 			WithRunner().
 			WithCommand(execFilePath).ExecutorBuilder
 	case pb.Sdk_SDK_PYTHON:
 		// Nothing is needed for Python
 	case pb.Sdk_SDK_SCIO:
-		return nil, fmt.Errorf("SCIO is not supported yet")
+		// Executable name for SCIO object will be known after compilation
+		placeholders := map[string]string{compiledDirPlaceholder: lc.GetAbsoluteCompiledFolderPath()}
+		builder = withReplacedPlaceholders(builder, executorConfig, placeholders)
 	default:
 		return nil, fmt.Errorf("incorrect sdk: %s", sdkEnv.ApacheBeamSdk)
 	}
 	return &builder, nil
 }
+
+// withReplacedPlaceholders sets compile, run and test args of the config with placeholders replaced by their values
+func withReplacedPlaceholders(builder executors.ExecutorBuilder, executorConfig *environment.ExecutorConfig, placeholders map[string]string) executors.ExecutorBuilder {
+	return builder.
+		WithCompiler().
+		WithArgs(replacePlaceholders(executorConfig.CompileArgs, placeholders)).
+		WithRunner().
+		WithArgs(replacePlaceholders(executorConfig.RunArgs, placeholders)).
+		WithTestRunner().
+		WithArgs(replacePlaceholders(executorConfig.TestArgs, placeholders)).
+		ExecutorBuilder
+}
+
+// replacePlaceholders returns copy of args with all placeholders replaced by their values
+func replacePlaceholders(args []string, placeholders map[string]string) []string {
+	if args == nil {
+		return nil
+	}
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		for placeholder, value := range placeholders {
+			arg = strings.ReplaceAll(arg, placeholder, value)
+		}
+		result = append(result, arg)
+	}
+	return result
+}
@@ -21,8 +21,12 @@ import (
 	"beam.apache.org/playground/backend/internal/executors"
 	"beam.apache.org/playground/backend/internal/fs_tool"
 	"beam.apache.org/playground/backend/internal/utils"
+	"beam.apache.org/playground/backend/internal/validators"
+	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -39,6 +43,7 @@ func TestSetupExecutor(t *testing.T) {
 		CompileArgs: []string{"MOCK_COMPILE_ARG"},
 		RunArgs:     []string{"MOCK_RUN_ARG"},
 		TestArgs:    []string{"MOCK_TEST_ARG"},
+		Env:         map[string]string{"MOCK_ENV": "MOCK_VALUE"},
 	}
 	if err != nil {
 		panic(err)
@@ -47,10 +52,11 @@ func TestSetupExecutor(t *testing.T) {
 	srcFilePath := lc.GetAbsoluteSourceFilePath()
 
 	sdkEnv := environment.NewBeamEnvs(sdk, executorConfig, "")
-	val, err := utils.GetValidators(sdk, srcFilePath)
+	val, err := utils.GetValidators(sdk)
 	if err != nil {
 		panic(err)
 	}
+	*val = append([]validators.Validator{validators.GetPipelineOptionsValidator(pipelineOptions, nil)}, *val...)
 	prep, err := utils.GetPreparators(sdk, srcFilePath)
 	if err != nil {
 		panic(err)
@@ -59,6 +65,7 @@ func TestSetupExecutor(t *testing.T) {
 	wantExecutor := executors.NewExecutorBuilder().
 		WithExecutableFileName(lc.GetAbsoluteExecutableFilePath()).
 		WithWorkingDir(lc.GetAbsoluteBaseFolderPath()).
+		WithEnv(executorConfig.Env).
 		WithValidator().
 		WithSdkValidators(val).
 		WithPreparator().
@@ -67,19 +74,136 @@ func TestSetupExecutor(t *testing.T) {
 		WithCommand(executorConfig.CompileCmd).
 		WithArgs(executorConfig.CompileArgs).
 		WithFileName(srcFilePath).
+		WithFileNames(lc.GetAbsoluteSourceFilePaths()).
 		WithRunner().
 		WithCommand(executorConfig.RunCmd).
 		WithArgs(executorConfig.RunArgs).
 		WithPipelineOptions(strings.Split(pipelineOptions, " ")).
+		WithGraphOutput(executorConfig.GraphArgs).
 		WithTestRunner().
 		WithCommand(executorConfig.TestCmd).
 		WithArgs(executorConfig.TestArgs).
 		ExecutorBuilder
 
+	goLc, err := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, "")
+	if err != nil {
+		panic(err)
+	}
+	goSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, executorConfig, "")
+	goVal, err := utils.GetValidators(pb.Sdk_SDK_GO)
+	if err != nil {
+		panic(err)
+	}
+	*goVal = append([]validators.Validator{validators.GetPipelineOptionsValidator(pipelineOptions, nil)}, *goVal...)
+	goPrep, err := utils.GetPreparators(pb.Sdk_SDK_GO, goLc.GetAbsoluteSourceFilePath())
+	if err != nil {
+		panic(err)
+	}
+	wantGoExecutor := executors.NewExecutorBuilder().
+		WithExecutableFileName("").
+		WithWorkingDir(goLc.GetAbsoluteBaseFolderPath()).
+		WithEnv(executorConfig.Env).
+		WithValidator().
+		WithSdkValidators(goVal).
+		WithPreparator().
+		WithSdkPreparators(goPrep).
+		WithCompiler().
+		WithCommand(executorConfig.CompileCmd).
+		WithArgs(executorConfig.CompileArgs).
+		WithFileName(goLc.GetAbsoluteSourceFilePath()).
+		WithFileNames(goLc.GetAbsoluteSourceFilePaths()).
+		WithOutputFilePath(goLc.GetAbsoluteExecutableFilePath()).
+		WithWorkingDir(filepath.Dir(goLc.GetAbsoluteSourceFilePath())).
+		WithRunner().
+		WithCommand(goLc.GetAbsoluteExecutableFilePath()).
+		WithArgs(executorConfig.RunArgs).
+		WithPipelineOptions(strings.Split(pipelineOptions, " ")).
+		WithGraphOutput(executorConfig.GraphArgs).
+		WithTestRunner().
+		WithCommand(executorConfig.TestCmd).
+		WithArgs(executorConfig.TestArgs).
+		ExecutorBuilder
+
+	scioLc, err := fs_tool.NewLifeCycle(pb.Sdk_SDK_SCIO, pipelineId, "")
+	if err != nil {
+		panic(err)
+	}
+	scioSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_SCIO, executorConfig, "")
+	scioVal, err := utils.GetValidators(pb.Sdk_SDK_SCIO)
+	if err != nil {
+		panic(err)
+	}
+	*scioVal = append([]validators.Validator{validators.GetPipelineOptionsValidator(pipelineOptions, nil)}, *scioVal...)
+	scioPrep, err := utils.GetPreparators(pb.Sdk_SDK_SCIO, scioLc.GetAbsoluteSourceFilePath())
+	if err != nil {
+		panic(err)
+	}
+	wantScioExecutor := executors.NewExecutorBuilder().
+		WithExecutableFileName(scioLc.GetAbsoluteExecutableFilePath()).
+		WithWorkingDir(scioLc.GetAbsoluteBaseFolderPath()).
+		WithEnv(executorConfig.Env).
+		WithValidator().
+		WithSdkValidators(scioVal).
+		WithPreparator().
+		WithSdkPreparators(scioPrep).
+		WithCompiler().
+		WithCommand(executorConfig.CompileCmd).
+		WithArgs(executorConfig.CompileArgs).
+		WithFileName(scioLc.GetAbsoluteSourceFilePath()).
+		WithFileNames(scioLc.GetAbsoluteSourceFilePaths()).
+		WithRunner().
+		WithCommand(executorConfig.RunCmd).
+		WithArgs(executorConfig.RunArgs).
+		WithPipelineOptions(strings.Split(pipelineOptions, " ")).
+		WithGraphOutput(executorConfig.GraphArgs).
+		WithTestRunner().
+		WithCommand(executorConfig.TestCmd).
+		WithArgs(executorConfig.TestArgs).
+		ExecutorBuilder
+
+	forbiddenImportsExecutorConfig := *executorConfig
+	forbiddenImportsExecutorConfig.ForbiddenImports = []string{"\"os/exec\""}
+	forbiddenImportsSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, &forbiddenImportsExecutorConfig, "")
+	forbiddenImportsVal := append(*goVal, validators.GetForbiddenImportsValidator(forbiddenImportsExecutorConfig.ForbiddenImports))
+	wantForbiddenImportsExecutor := wantGoExecutor.
+		WithValidator().
+		WithSdkValidators(&forbiddenImportsVal).
+		ExecutorBuilder
+
+	unboundedOutputExecutorConfig := forbiddenImportsExecutorConfig
+	unboundedOutputExecutorConfig.ForbidUnboundedOutput = true
+	unboundedOutputSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, &unboundedOutputExecutorConfig, "")
+	unboundedOutputVal := append(append([]validators.Validator{}, forbiddenImportsVal...), validators.GetUnboundedOutputValidator(pb.Sdk_SDK_GO))
+	wantUnboundedOutputExecutor := wantForbiddenImportsExecutor.
+		WithValidator().
+		WithSdkValidators(&unboundedOutputVal).
+		ExecutorBuilder
+
+	missingPipelineExecutorConfig := unboundedOutputExecutorConfig
+	missingPipelineExecutorConfig.WarnMissingPipeline = true
+	missingPipelineSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, &missingPipelineExecutorConfig, "")
+	missingPipelineVal := append(append([]validators.Validator{}, unboundedOutputVal...), validators.GetBeamPipelineValidator(pb.Sdk_SDK_GO))
+	wantMissingPipelineExecutor := wantUnboundedOutputExecutor.
+		WithValidator().
+		WithSdkValidators(&missingPipelineVal).
+		ExecutorBuilder
+
+	jvmArgsVal, err := utils.GetValidators(sdk)
+	if err != nil {
+		panic(err)
+	}
+	*jvmArgsVal = append([]validators.Validator{validators.GetPipelineOptionsValidator("--opt1=valOpt", nil)}, *jvmArgsVal...)
+	wantJvmArgsExecutor := wantExecutor
+	wantJvmArgsExecutor = wantJvmArgsExecutor.
+		WithJvmArgs([]string{"-Xmx512m", "-XX:+UseSerialGC"}).
+		WithValidator().
+		WithSdkValidators(jvmArgsVal).
+		WithRunner().
+		WithPipelineOptions([]string{"--opt1=valOpt"}).
+		ExecutorBuilder
+
 	type args struct {
-		srcFilePath     string
-		baseFolderPath  string
-		execFilePath    string
+		lc              *fs_tool.LifeCycle
 		pipelineOptions string
 		sdkEnv          *environment.BeamEnvs
 	}
@@ -93,7 +217,7 @@ func TestSetupExecutor(t *testing.T) {
 			// Test case with calling Setup with incorrect SDK.
 			// As a result, want to receive an error.
 			name:    "incorrect sdk",
-			args:    args{lc.GetAbsoluteSourceFilePath(), lc.GetAbsoluteBaseFolderPath(), lc.GetAbsoluteExecutableFilePath(), pipelineOptions, environment.NewBeamEnvs(pb.Sdk_SDK_UNSPECIFIED, executorConfig, "")},
+			args:    args{lc, pipelineOptions, environment.NewBeamEnvs(pb.Sdk_SDK_UNSPECIFIED, executorConfig, "")},
 			want:    nil,
 			wantErr: true,
 		},
@@ -101,14 +225,71 @@ func TestSetupExecutor(t *testing.T) {
 			// Test case with calling Setup with correct SDK.
 			// As a result, want to receive an expected builder.
 			name:    "correct sdk",
-			args:    args{lc.GetAbsoluteSourceFilePath(), lc.GetAbsoluteBaseFolderPath(), lc.GetAbsoluteExecutableFilePath(), pipelineOptions, sdkEnv},
+			args:    args{lc, pipelineOptions, sdkEnv},
 			want:    &wantExecutor,
 			wantErr: false,
 		},
+		{
+			// Test case with calling Setup with Go SDK.
+			// As a result, want to receive a builder which compiles code to the executable file and runs it.
+			name:    "go sdk",
+			args:    args{goLc, pipelineOptions, goSdkEnv},
+			want:    &wantGoExecutor,
+			wantErr: false,
+		},
+		{
+			// Test case with calling Setup with SCIO SDK.
+			// As a result, want to receive a builder which compiles code and runs the compiled object on the JVM.
+			name:    "scio sdk",
+			args:    args{scioLc, pipelineOptions, scioSdkEnv},
+			want:    &wantScioExecutor,
+			wantErr: false,
+		},
+		{
+			// Test case with calling Setup with Go SDK which has forbidden imports in the config.
+			// As a result, want to receive a builder which also checks that the code doesn't use forbidden imports.
+			name:    "go sdk with forbidden imports",
+			args:    args{goLc, pipelineOptions, forbiddenImportsSdkEnv},
+			want:    &wantForbiddenImportsExecutor,
+			wantErr: false,
+		},
+		{
+			// Test case with calling Setup with Go SDK which has forbidden imports and forbids unbounded output in the config.
+			// As a result, want to receive a builder which also checks that the code doesn't print the output in infinite loops.
+			name:    "go sdk with forbidden unbounded output",
+			args:    args{goLc, pipelineOptions, unboundedOutputSdkEnv},
+			want:    &wantUnboundedOutputExecutor,
+			wantErr: false,
+		},
+		{
+			// Test case with calling Setup with Go SDK which has forbidden imports, forbids unbounded output
+			//	and warns about the code without Beam pipeline in the config.
+			// As a result, want to receive a builder which also checks that the code constructs a Beam pipeline.
+			name:    "go sdk with warning about missing pipeline",
+			args:    args{goLc, pipelineOptions, missingPipelineSdkEnv},
+			want:    &wantMissingPipelineExecutor,
+			wantErr: false,
+		},
+		{
+			// Test case with calling Setup with Java SDK and pipeline options which contain allowed JVM flags.
+			// As a result, want to receive a builder which passes JVM flags to the JVM and the rest options to the code.
+			name:    "java sdk with jvm flags",
+			args:    args{lc, "-Xmx512m --opt1 valOpt -XX:+UseSerialGC", sdkEnv},
+			want:    &wantJvmArgsExecutor,
+			wantErr: false,
+		},
+		{
+			// Test case with calling Setup with Java SDK and pipeline options which contain a forbidden JVM flag.
+			// As a result, want to receive an error.
+			name:    "java sdk with forbidden jvm flag",
+			args:    args{lc, "-agentpath:/tmp/agent.so", sdkEnv},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := SetupExecutorBuilder(tt.args.srcFilePath, tt.args.baseFolderPath, tt.args.execFilePath, tt.args.pipelineOptions, tt.args.sdkEnv)
+			got, err := SetupExecutorBuilder(tt.args.lc, tt.args.pipelineOptions, tt.args.sdkEnv)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SetupExecutorBuilder() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -119,3 +300,44 @@ func TestSetupExecutor(t *testing.T) {
 		})
 	}
 }
+
+func TestSetupExecutorWithCompiledDir(t *testing.T) {
+	executorConfig := &environment.ExecutorConfig{
+		CompileCmd:  "javac",
+		RunCmd:      "java",
+		TestCmd:     "java",
+		CompileArgs: []string{"-d", compiledDirPlaceholder, "-classpath", "/opt/apache/beam/jars/*"},
+		RunArgs:     []string{"-cp", compiledDirPlaceholder + ":/opt/apache/beam/jars/*"},
+		TestArgs:    []string{"-cp", compiledDirPlaceholder + ":/opt/apache/beam/jars/*", "JUnit"},
+	}
+	for _, sdk := range []pb.Sdk{pb.Sdk_SDK_JAVA, pb.Sdk_SDK_SCIO, pb.Sdk_SDK_KOTLIN} {
+		t.Run(sdk.String(), func(t *testing.T) {
+			lc, err := fs_tool.NewLifeCycle(sdk, uuid.New(), "")
+			if err != nil {
+				t.Fatalf("error during create life cycle: %s", err.Error())
+			}
+			compiledDir := lc.GetAbsoluteCompiledFolderPath()
+			builder, err := SetupExecutorBuilder(lc, "", environment.NewBeamEnvs(sdk, executorConfig, ""))
+			if err != nil {
+				t.Fatalf("SetupExecutorBuilder() error = %v", err)
+			}
+			executor := builder.WithExecutableFileName("HelloWorld").Build()
+
+			compileArgs := executor.Compile(context.Background()).Args
+			if wantArgs := []string{"-d", compiledDir}; !reflect.DeepEqual(compileArgs[1:3], wantArgs) {
+				t.Errorf("SetupExecutorBuilder() compile args = %v, want to start with %v", compileArgs[1:], wantArgs)
+			}
+			runArgs := executor.Run(context.Background()).Args
+			if wantArgs := []string{"-cp", compiledDir + ":/opt/apache/beam/jars/*", "HelloWorld"}; !reflect.DeepEqual(runArgs[1:], wantArgs) {
+				t.Errorf("SetupExecutorBuilder() run args = %v, want %v", runArgs[1:], wantArgs)
+			}
+			testArgs := executor.RunTest(context.Background()).Args
+			if wantArg := compiledDir + ":/opt/apache/beam/jars/*"; testArgs[2] != wantArg {
+				t.Errorf("SetupExecutorBuilder() test classpath = %s, want %s", testArgs[2], wantArg)
+			}
+			if executorConfig.CompileArgs[1] != compiledDirPlaceholder {
+				t.Errorf("SetupExecutorBuilder() changed args of the config: %v", executorConfig.CompileArgs)
+			}
+		})
+	}
+}
@@ -38,13 +38,24 @@ const (
 
 // Setup returns fs_tool.LifeCycle.
 // Also, prepares files and folders needed to code processing according to sdk
-func Setup(sdk pb.Sdk, code string, pipelineId uuid.UUID, workingDir string, preparedModDir string) (*fs_tool.LifeCycle, error) {
+//	and creates source files with the code (the code could be split across several files).
+// The folders of the code are created in executionRoot, the files which are needed for the code (i.e. the log config of Java)
+//	are copied from workingDir.
+// If sourceFileName isn't empty, the main source file is created with this name (see fs_tool.LifeCycle.SetSourceFileName).
+// If jsonLogs is true, configures the logging of the code to print the records as JSON lines (see fs_tool.LifeCycle.EnableJsonLogs).
+func Setup(sdk pb.Sdk, files []fs_tool.CodeFile, sourceFileName string, pipelineId uuid.UUID, workingDir, executionRoot string, preparedModDir string, jsonLogs bool) (*fs_tool.LifeCycle, error) {
 	// create file system service
-	lc, err := fs_tool.NewLifeCycle(sdk, pipelineId, workingDir)
+	lc, err := fs_tool.NewLifeCycle(sdk, pipelineId, executionRoot)
 	if err != nil {
 		logger.Errorf("%s: error during create new life cycle: %s\n", pipelineId, err.Error())
 		return nil, err
 	}
+	if sourceFileName != "" {
+		if err = lc.SetSourceFileName(sourceFileName); err != nil {
+			logger.Errorf("%s: error during set name of the source file: %s\n", pipelineId, err.Error())
+			return nil, err
+		}
+	}
 
 	// create folders
 	err = lc.CreateFolders()
@@ -56,7 +67,7 @@ func Setup(sdk pb.Sdk, code string, pipelineId uuid.UUID, workingDir string, pre
 	// copy necessary files
 	switch sdk {
 	case pb.Sdk_SDK_GO:
-		if err = prepareGoFiles(lc, preparedModDir, workingDir, pipelineId); err != nil {
+		if err = prepareGoFiles(lc, preparedModDir, pipelineId); err != nil {
 			lc.DeleteFolders()
 			return nil, err
 		}
@@ -67,24 +78,33 @@ func Setup(sdk pb.Sdk, code string, pipelineId uuid.UUID, workingDir string, pre
 		}
 	}
 
-	// create file with code
-	_, err = lc.CreateSourceCodeFile(code)
+	// create files with code
+	err = lc.CreateSourceCodeFiles(files)
 	if err != nil {
-		logger.Errorf("%s: RunCode(): CreateSourceCodeFile(): %s\n", pipelineId, err.Error())
+		logger.Errorf("%s: RunCode(): CreateSourceCodeFiles(): %s\n", pipelineId, err.Error())
 		lc.DeleteFolders()
 		return nil, err
 	}
+
+	if jsonLogs {
+		if err = lc.EnableJsonLogs(); err != nil {
+			logger.Errorf("%s: error during enabling json logs: %s\n", pipelineId, err.Error())
+			lc.DeleteFolders()
+			return nil, err
+		}
+	}
 	return lc, nil
 }
 
 // prepareGoFiles prepares file for Go environment.
-// Copy go.mod and go.sum file from /path/to/preparedModDir to /path/to/workingDir.
-func prepareGoFiles(lc *fs_tool.LifeCycle, preparedModDir, workingDir string, pipelineId uuid.UUID) error {
-	if err := lc.CopyFile(goModFileName, preparedModDir, filepath.Join(workingDir, baseFileFolder)); err != nil {
+// Copy go.mod and go.sum file from /path/to/preparedModDir to /path/to/workingDir/executable_files/{pipelineId}/src
+//	so the source file is compiled as a part of its own module.
+func prepareGoFiles(lc *fs_tool.LifeCycle, preparedModDir string, pipelineId uuid.UUID) error {
+	if err := lc.CopyFile(goModFileName, preparedModDir, lc.Folder.SourceFileFolder); err != nil {
 		logger.Errorf("%s: error during copying %s file: %s\n", pipelineId, goModFileName, err.Error())
 		return err
 	}
-	if err := lc.CopyFile(goSumFileName, preparedModDir, filepath.Join(workingDir, baseFileFolder)); err != nil {
+	if err := lc.CopyFile(goSumFileName, preparedModDir, lc.Folder.SourceFileFolder); err != nil {
 		logger.Errorf("%s: error during copying %s file: %s\n", pipelineId, goSumFileName, err.Error())
 		return err
 	}
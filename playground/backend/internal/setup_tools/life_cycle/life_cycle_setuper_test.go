@@ -51,11 +51,25 @@ func TestSetup(t *testing.T) {
 	if err != nil {
 		panic(err)
 	}
+	// the files of the code are created in the execution root (i.e. tmpfs) which differs from the working dir with configs
+	executionRoot := t.TempDir()
+	executionRootPipelineId := uuid.New()
+	executionRootLc, err := fs_tool.NewLifeCycle(playground.Sdk_SDK_JAVA, executionRootPipelineId, executionRoot)
+	if err != nil {
+		panic(err)
+	}
+	sourceFileNamePipelineId := uuid.New()
+	sourceFileNameLc, err := fs_tool.NewLifeCycle(playground.Sdk_SDK_JAVA, sourceFileNamePipelineId, workingDir)
+	if err != nil {
+		panic(err)
+	}
 	type args struct {
 		sdk            playground.Sdk
-		code           string
+		files          []fs_tool.CodeFile
+		sourceFileName string
 		pipelineId     uuid.UUID
 		workingDir     string
+		executionRoot  string
 		preparedModDir string
 	}
 	tests := []struct {
@@ -71,7 +85,7 @@ func TestSetup(t *testing.T) {
 			name: "incorrect sdk",
 			args: args{
 				sdk:        playground.Sdk_SDK_UNSPECIFIED,
-				code:       "",
+				files:      []fs_tool.CodeFile{{Content: "", IsMain: true}},
 				pipelineId: errorPipelineId,
 				workingDir: workingDir,
 			},
@@ -90,7 +104,7 @@ func TestSetup(t *testing.T) {
 			name: "correct sdk",
 			args: args{
 				sdk:            playground.Sdk_SDK_JAVA,
-				code:           "",
+				files:          []fs_tool.CodeFile{{Content: "", IsMain: true}},
 				pipelineId:     successPipelineId,
 				workingDir:     workingDir,
 				preparedModDir: "",
@@ -113,10 +127,124 @@ func TestSetup(t *testing.T) {
 			want:    lc,
 			wantErr: false,
 		},
+		{
+			// Test case with calling Setup method with code split across several files.
+			// As a result, want to receive an expected life cycle with the main source file and the additional source file.
+			name: "several source files",
+			args: args{
+				sdk:            playground.Sdk_SDK_JAVA,
+				files:          []fs_tool.CodeFile{{Content: "", IsMain: true}, {Name: "Helper.java", Content: ""}},
+				pipelineId:     successPipelineId,
+				workingDir:     workingDir,
+				preparedModDir: "",
+			},
+			check: func() bool {
+				if _, err := os.Stat(filepath.Join(workingDir, baseFileFolder, successPipelineId.String(), sourceFolder, successPipelineId.String()+javaSourceFileExtension)); os.IsNotExist(err) {
+					return false
+				}
+				if _, err := os.Stat(filepath.Join(workingDir, baseFileFolder, successPipelineId.String(), sourceFolder, "Helper.java")); os.IsNotExist(err) {
+					return false
+				}
+				return true
+			},
+			want:    lc,
+			wantErr: false,
+		},
+		{
+			// Test case with calling Setup method with the execution root which differs from the working dir.
+			// As a result, want to receive the life cycle with the folders in the execution root
+			//	and the log config copied from the working dir.
+			name: "execution root differs from working dir",
+			args: args{
+				sdk:            playground.Sdk_SDK_JAVA,
+				files:          []fs_tool.CodeFile{{Content: "", IsMain: true}},
+				pipelineId:     executionRootPipelineId,
+				workingDir:     workingDir,
+				executionRoot:  executionRoot,
+				preparedModDir: "",
+			},
+			check: func() bool {
+				if _, err := os.Stat(filepath.Join(executionRoot, baseFileFolder, executionRootPipelineId.String(), sourceFolder, executionRootPipelineId.String()+javaSourceFileExtension)); os.IsNotExist(err) {
+					return false
+				}
+				if _, err := os.Stat(filepath.Join(executionRoot, baseFileFolder, executionRootPipelineId.String(), javaLogConfigFileName)); os.IsNotExist(err) {
+					return false
+				}
+				if _, err := os.Stat(filepath.Join(workingDir, baseFileFolder, executionRootPipelineId.String())); !os.IsNotExist(err) {
+					return false
+				}
+				return true
+			},
+			want:    executionRootLc,
+			wantErr: false,
+		},
+		{
+			// Test case with calling Setup method with the name of the main source file without the extension.
+			// As a result, want to receive the life cycle with the main source file created with this name and the extension of the SDK.
+			name: "source file name is set",
+			args: args{
+				sdk:            playground.Sdk_SDK_JAVA,
+				files:          []fs_tool.CodeFile{{Content: "", IsMain: true}},
+				sourceFileName: "WordCount",
+				pipelineId:     sourceFileNamePipelineId,
+				workingDir:     workingDir,
+				preparedModDir: "",
+			},
+			check: func() bool {
+				if _, err := os.Stat(filepath.Join(workingDir, baseFileFolder, sourceFileNamePipelineId.String(), sourceFolder, "WordCount"+javaSourceFileExtension)); os.IsNotExist(err) {
+					return false
+				}
+				if _, err := os.Stat(filepath.Join(workingDir, baseFileFolder, sourceFileNamePipelineId.String(), sourceFolder, sourceFileNamePipelineId.String()+javaSourceFileExtension)); !os.IsNotExist(err) {
+					return false
+				}
+				return true
+			},
+			want:    sourceFileNameLc,
+			wantErr: false,
+		},
+		{
+			// Test case with calling Setup method with the name of the main source file which isn't a valid Java class name.
+			// As a result, want to receive an error.
+			name: "incorrect name of main source file",
+			args: args{
+				sdk:            playground.Sdk_SDK_JAVA,
+				files:          []fs_tool.CodeFile{{Content: "", IsMain: true}},
+				sourceFileName: "Word-Count.java",
+				pipelineId:     errorPipelineId,
+				workingDir:     workingDir,
+				preparedModDir: "",
+			},
+			check: func() bool {
+				return true
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling Setup method with the additional file which has incorrect name.
+			// As a result, want to receive an error.
+			name: "incorrect name of source file",
+			args: args{
+				sdk:            playground.Sdk_SDK_JAVA,
+				files:          []fs_tool.CodeFile{{Content: "", IsMain: true}, {Name: "../Helper.java", Content: ""}},
+				pipelineId:     errorPipelineId,
+				workingDir:     workingDir,
+				preparedModDir: "",
+			},
+			check: func() bool {
+				return true
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := Setup(tt.args.sdk, tt.args.code, tt.args.pipelineId, tt.args.workingDir, tt.args.preparedModDir)
+			executionRoot := tt.args.executionRoot
+			if executionRoot == "" {
+				executionRoot = tt.args.workingDir
+			}
+			got, err := Setup(tt.args.sdk, tt.args.files, tt.args.sourceFileName, tt.args.pipelineId, tt.args.workingDir, executionRoot, tt.args.preparedModDir, false)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Setup() error = %v, wantErr %v", err, tt.wantErr)
 				return
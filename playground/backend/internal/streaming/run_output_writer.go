@@ -17,9 +17,16 @@ package streaming
 
 import (
 	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/utils"
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
 )
 
 // RunOutputWriter is used to write the run step's output to cache as a stream.
@@ -27,11 +34,55 @@ type RunOutputWriter struct {
 	Ctx          context.Context
 	CacheService cache.Cache
 	PipelineId   uuid.UUID
+	// SubKey is the subKey of the output in cache. cache.RunOutput is used if it isn't set,
+	//	i.e. cache.CompileOutput is set to stream the compile step's output.
+	SubKey cache.SubKey
+	// MaxSize is a max size (in bytes) of the output which is kept in the cache. 0 means that the size is not limited.
+	MaxSize int
+	// Normalize defines whether ANSI escape sequences are stripped from the output and line endings are normalized to "\n".
+	Normalize bool
+	// SpillFilePath is a path of the file where the full output is written in case MaxSize is exceeded.
+	//	The path is saved as cache.RunOutputFile, so it should be set for the run output only.
+	//	The output which exceeds MaxSize is omitted if it isn't set.
+	SpillFilePath string
+	// FlushLines is a number of lines which are buffered before they are written to the cache by one write.
+	//	0 means that the output is written to the cache on each Write if FlushInterval isn't set.
+	FlushLines int
+	// FlushInterval is a max time the buffered output waits before it is written to the cache.
+	//	0 means that the buffered output waits until FlushLines lines are buffered or until Close.
+	FlushInterval time.Duration
+
+	// mu guards the state of the writer since the buffered output is flushed by FlushInterval in a separate goroutine
+	mu sync.Mutex
+	// closed defines whether the writer is closed. The output is written to the cache without buffering after Close.
+	closed bool
+	// buffered is the output which isn't written to the cache yet because of FlushLines or FlushInterval
+	buffered strings.Builder
+	// bufferedLines is a number of lines in buffered
+	bufferedLines int
+	// stopFlusher and flusherDone are used to stop the goroutine which flushes the buffered output by FlushInterval
+	stopFlusher chan struct{}
+	flusherDone chan struct{}
+	// pending is the tail of the output which is written to the cache with the next part of the output
+	//	because it couldn't be normalized yet
+	pending string
+	// size is a number of bytes which are written to the cache
+	size int
+	// omitted is a number of bytes which are omitted because of MaxSize
+	omitted int
+	// spillFile is the file with the full output which is created when MaxSize is exceeded if SpillFilePath is set
+	spillFile *os.File
 }
 
-// Write writes len(p) bytes from p to cache with cache.RunOutput subKey.
+// Write writes len(p) bytes from p to cache with SubKey (cache.RunOutput by default).
 // In case some error occurs - returns (0, error).
 // In case finished with no error - returns (len(p), nil).
+// In case MaxSize is exceeded - writes only the part of p which fits into MaxSize and omits the rest of the output.
+//	If SpillFilePath is set, the full output is written to the file by SpillFilePath from this moment.
+// In case Normalize is set - writes p without ANSI escape sequences and with line endings normalized to "\n".
+//	The tail of p which could be continued by the next part of the output (i.e. "\r") is kept until the next Write or Close.
+// In case FlushLines or FlushInterval is set - buffers p and writes the buffered output to cache when FlushLines lines
+//	are buffered or FlushInterval is passed, so chatty code doesn't write to cache for each line.
 //
 // As a result new bytes will be added to cache with old run output value.
 // Example:
@@ -42,19 +93,208 @@ func (row *RunOutputWriter) Write(p []byte) (int, error) {
 	if len(p) == 0 {
 		return 0, nil
 	}
+	row.mu.Lock()
+	defer row.mu.Unlock()
 
-	prevOutput, err := row.CacheService.GetValue(row.Ctx, row.PipelineId, cache.RunOutput)
-	if err != nil {
+	output := string(p)
+	if row.Normalize {
+		output = row.pending + output
+		tailIndex := utils.IncompleteOutputTailIndex(output)
+		output, row.pending = utils.NormalizeOutput(output[:tailIndex]), output[tailIndex:]
+	}
+	write := row.write
+	if !row.closed && (row.FlushLines > 0 || row.FlushInterval > 0) {
+		write = row.buffer
+	}
+	if err := write(output); err != nil {
 		return 0, err
 	}
+	return len(p), nil
+}
+
+// buffer appends the output to the buffered output and flushes it if FlushLines lines are buffered.
+// Starts the goroutine which flushes the buffered output by FlushInterval if it isn't started yet.
+func (row *RunOutputWriter) buffer(output string) error {
+	row.buffered.WriteString(output)
+	row.bufferedLines += strings.Count(output, "\n")
+	if row.FlushLines > 0 && row.bufferedLines >= row.FlushLines {
+		return row.flush()
+	}
+	if row.FlushInterval > 0 && row.stopFlusher == nil {
+		row.stopFlusher = make(chan struct{})
+		row.flusherDone = make(chan struct{})
+		go row.flushPeriodically(row.stopFlusher, row.flusherDone)
+	}
+	return nil
+}
+
+// flush writes the buffered output to the cache
+func (row *RunOutputWriter) flush() error {
+	output := row.buffered.String()
+	row.buffered.Reset()
+	row.bufferedLines = 0
+	return row.write(output)
+}
+
+// flushPeriodically flushes the buffered output each FlushInterval until stop is closed or Ctx is done.
+// Closes done when it is finished.
+func (row *RunOutputWriter) flushPeriodically(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(row.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-row.Ctx.Done():
+			return
+		case <-ticker.C:
+			row.mu.Lock()
+			err := row.flush()
+			row.mu.Unlock()
+			if err != nil {
+				logger.Errorf("%s: RunOutputWriter: error during flushing output: %s", row.PipelineId, err.Error())
+			}
+		}
+	}
+}
+
+// write appends the output to the run output in the cache.
+// In case MaxSize is exceeded - writes only the part of the output which fits into MaxSize and omits the rest of the output
+//	(or writes it to the file by SpillFilePath if it is set).
+func (row *RunOutputWriter) write(output string) error {
+	if len(output) == 0 {
+		return nil
+	}
+	if row.omitted > 0 {
+		row.omitted += len(output)
+		return row.spill(output)
+	}
+
+	newOutput := output
+	if row.MaxSize > 0 && row.size+len(output) > row.MaxSize {
+		newOutput = output[:utils.TruncationIndex(output, row.MaxSize-row.size)]
+		row.omitted = len(output) - len(newOutput)
+		if err := row.startSpill(output); err != nil {
+			return err
+		}
+		if len(newOutput) == 0 {
+			return nil
+		}
+	}
+
+	prevOutput, err := row.prevOutput()
+	if err != nil {
+		return err
+	}
 
 	// concat prevValue and new value
-	str := fmt.Sprintf("%s%s", prevOutput.(string), string(p))
+	str := fmt.Sprintf("%s%s", prevOutput, newOutput)
 
 	// set new cache value
-	err = row.CacheService.SetValue(row.Ctx, row.PipelineId, cache.RunOutput, str)
+	err = row.CacheService.SetValue(row.Ctx, row.PipelineId, row.subKey(), str)
 	if err != nil {
-		return 0, err
+		return err
 	}
-	return len(p), nil
+	row.size += len(newOutput)
+	return nil
+}
+
+// startSpill creates the file by SpillFilePath with the output which is kept in the cache and output,
+//	and saves the path of the file as cache.RunOutputFile.
+// It does nothing if SpillFilePath isn't set.
+func (row *RunOutputWriter) startSpill(output string) error {
+	if row.SpillFilePath == "" {
+		return nil
+	}
+	prevOutput, err := row.prevOutput()
+	if err != nil {
+		return err
+	}
+	row.spillFile, err = os.Create(row.SpillFilePath)
+	if err != nil {
+		return err
+	}
+	if err := row.spill(prevOutput + output); err != nil {
+		return err
+	}
+	return row.CacheService.SetValue(row.Ctx, row.PipelineId, cache.RunOutputFile, row.SpillFilePath)
+}
+
+// spill appends the output to the file with the full output if it is created
+func (row *RunOutputWriter) spill(output string) error {
+	if row.spillFile == nil {
+		return nil
+	}
+	_, err := io.WriteString(row.spillFile, output)
+	return err
+}
+
+// Close writes the buffered output and the pending tail of the output to the cache,
+//	closes the file with the full output if it is created
+//	and appends a marker with the number of omitted bytes to the run output in the cache
+//	if some part of the output is omitted because of MaxSize.
+// It does nothing if the writer is already closed.
+func (row *RunOutputWriter) Close() error {
+	row.mu.Lock()
+	if row.closed {
+		row.mu.Unlock()
+		return nil
+	}
+	row.closed = true
+	stopFlusher, flusherDone := row.stopFlusher, row.flusherDone
+	row.mu.Unlock()
+	// the flusher takes the lock, so it is stopped before the lock is taken
+	if stopFlusher != nil {
+		close(stopFlusher)
+		<-flusherDone
+	}
+	row.mu.Lock()
+	defer row.mu.Unlock()
+
+	if row.pending != "" {
+		row.buffered.WriteString(utils.NormalizeOutput(row.pending))
+		row.pending = ""
+	}
+	if err := row.flush(); err != nil {
+		return err
+	}
+	if row.spillFile != nil {
+		err := row.spillFile.Close()
+		row.spillFile = nil
+		if err != nil {
+			return err
+		}
+	}
+	if row.omitted == 0 {
+		return nil
+	}
+
+	prevOutput, err := row.prevOutput()
+	if err != nil {
+		return err
+	}
+	return row.CacheService.SetValue(row.Ctx, row.PipelineId, row.subKey(), prevOutput+utils.TruncatedOutputMarker(row.omitted))
+}
+
+// prevOutput returns the output which is already written to the cache.
+// In case the value in the cache isn't a string - returns an error.
+func (row *RunOutputWriter) prevOutput() (string, error) {
+	value, err := row.CacheService.GetValue(row.Ctx, row.PipelineId, row.subKey())
+	if err != nil {
+		return "", err
+	}
+	output, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("value with pipelineId: %s and subKey: %s isn't a string: %v", row.PipelineId, row.subKey(), value)
+	}
+	return output, nil
+}
+
+// subKey returns the subKey of the output in cache
+func (row *RunOutputWriter) subKey() cache.SubKey {
+	if row.SubKey == "" {
+		return cache.RunOutput
+	}
+	return row.SubKey
 }
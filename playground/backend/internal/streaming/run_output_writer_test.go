@@ -19,10 +19,26 @@ import (
 	"beam.apache.org/playground/backend/internal/cache"
 	"beam.apache.org/playground/backend/internal/cache/local"
 	"context"
+	"fmt"
 	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
+// countingCache counts the writes of the output to the cache
+type countingCache struct {
+	cache.Cache
+	writes int
+}
+
+func (cc *countingCache) SetValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) error {
+	cc.writes++
+	return cc.Cache.SetValue(ctx, pipelineId, subKey, value)
+}
+
 func TestRunOutputWriter_Write(t *testing.T) {
 	pipelineId := uuid.New()
 	cacheService := local.New(context.Background())
@@ -30,11 +46,21 @@ func TestRunOutputWriter_Write(t *testing.T) {
 	if err != nil {
 		panic(err)
 	}
+	err = cacheService.SetValue(context.Background(), pipelineId, cache.CompileOutput, "MOCK_COMPILE_OUTPUT")
+	if err != nil {
+		panic(err)
+	}
+	incorrectOutputPipelineId := uuid.New()
+	err = cacheService.SetValue(context.Background(), incorrectOutputPipelineId, cache.RunOutput, 1)
+	if err != nil {
+		panic(err)
+	}
 
 	type fields struct {
 		Ctx          context.Context
 		CacheService cache.Cache
 		PipelineId   uuid.UUID
+		SubKey       cache.SubKey
 	}
 	type args struct {
 		p []byte
@@ -79,6 +105,23 @@ func TestRunOutputWriter_Write(t *testing.T) {
 			want:    0,
 			wantErr: true,
 		},
+		{
+			// Test case with calling Write method with pipelineId which output in the cache isn't a string.
+			// As a result, want to receive an error.
+			name: "output isn't a string",
+			fields: fields{
+				Ctx:          context.Background(),
+				CacheService: cacheService,
+				PipelineId:   incorrectOutputPipelineId,
+			},
+			args: args{[]byte(" NEW_MOCK_OUTPUT")},
+			check: func() bool {
+				value, err := cacheService.GetValue(context.Background(), incorrectOutputPipelineId, cache.RunOutput)
+				return err == nil && value == 1
+			},
+			want:    0,
+			wantErr: true,
+		},
 		{
 			// Test case with calling Write method with pipelineId which already contains output.
 			// As a result, want to receive len of sent string.
@@ -99,6 +142,27 @@ func TestRunOutputWriter_Write(t *testing.T) {
 			want:    16,
 			wantErr: false,
 		},
+		{
+			// Test case with calling Write method with the subKey of the compile output.
+			// As a result, want to receive the output appended to the compile output instead of the run output.
+			name: "output with subKey",
+			fields: fields{
+				Ctx:          context.Background(),
+				CacheService: cacheService,
+				PipelineId:   pipelineId,
+				SubKey:       cache.CompileOutput,
+			},
+			args: args{[]byte(" NEW_MOCK_OUTPUT")},
+			check: func() bool {
+				value, err := cacheService.GetValue(context.Background(), pipelineId, cache.CompileOutput)
+				if err != nil {
+					return false
+				}
+				return value == "MOCK_COMPILE_OUTPUT NEW_MOCK_OUTPUT"
+			},
+			want:    16,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -106,6 +170,7 @@ func TestRunOutputWriter_Write(t *testing.T) {
 				Ctx:          tt.fields.Ctx,
 				CacheService: tt.fields.CacheService,
 				PipelineId:   tt.fields.PipelineId,
+				SubKey:       tt.fields.SubKey,
 			}
 			got, err := row.Write(tt.args.p)
 			if (err != nil) != tt.wantErr {
@@ -121,3 +186,303 @@ func TestRunOutputWriter_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestRunOutputWriter_WriteWithMaxSize(t *testing.T) {
+	pipelineId := uuid.New()
+	cacheService := local.New(context.Background())
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, "")
+	if err != nil {
+		panic(err)
+	}
+	row := &RunOutputWriter{
+		Ctx:          context.Background(),
+		CacheService: cacheService,
+		PipelineId:   pipelineId,
+		MaxSize:      8,
+	}
+
+	for _, line := range []string{"MOCK\n", "OUTPUT\n", "OMITTED\n"} {
+		got, err := row.Write([]byte(line))
+		if err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if got != len(line) {
+			t.Errorf("Write() got = %v, want %v", got, len(line))
+		}
+	}
+	if err := row.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	value, err := cacheService.GetValue(context.Background(), pipelineId, cache.RunOutput)
+	if err != nil {
+		t.Fatalf("GetValue() error = %v", err)
+	}
+	want := "MOCK\nOUT\n... output truncated (12 bytes omitted)\n"
+	if value != want {
+		t.Errorf("Write() writes %q to cache, want %q", value, want)
+	}
+}
+
+func TestRunOutputWriter_WriteWithSpillFile(t *testing.T) {
+	pipelineId := uuid.New()
+	cacheService := local.New(context.Background())
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, "")
+	if err != nil {
+		panic(err)
+	}
+	spillFilePath := filepath.Join(t.TempDir(), "run_output.log")
+	row := &RunOutputWriter{
+		Ctx:           context.Background(),
+		CacheService:  cacheService,
+		PipelineId:    pipelineId,
+		MaxSize:       8,
+		SpillFilePath: spillFilePath,
+	}
+
+	for _, line := range []string{"MOCK\n", "OUTPUT\n", "OMITTED\n"} {
+		got, err := row.Write([]byte(line))
+		if err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if got != len(line) {
+			t.Errorf("Write() got = %v, want %v", got, len(line))
+		}
+	}
+	if err := row.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	value, err := cacheService.GetValue(context.Background(), pipelineId, cache.RunOutput)
+	if err != nil {
+		t.Fatalf("GetValue() error = %v", err)
+	}
+	want := "MOCK\nOUT\n... output truncated (12 bytes omitted)\n"
+	if value != want {
+		t.Errorf("Write() writes %q to cache, want %q", value, want)
+	}
+	value, err = cacheService.GetValue(context.Background(), pipelineId, cache.RunOutputFile)
+	if err != nil {
+		t.Fatalf("GetValue() error = %v", err)
+	}
+	if value != spillFilePath {
+		t.Errorf("Write() writes %q to cache as the path of the file, want %q", value, spillFilePath)
+	}
+	content, err := os.ReadFile(spillFilePath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if want := "MOCK\nOUTPUT\nOMITTED\n"; string(content) != want {
+		t.Errorf("Write() writes %q to the file, want %q", content, want)
+	}
+}
+
+func TestRunOutputWriter_WriteWithNormalize(t *testing.T) {
+	pipelineId := uuid.New()
+	cacheService := local.New(context.Background())
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, "")
+	if err != nil {
+		panic(err)
+	}
+	row := &RunOutputWriter{
+		Ctx:          context.Background(),
+		CacheService: cacheService,
+		PipelineId:   pipelineId,
+		Normalize:    true,
+	}
+
+	// color codes and "\r\n" are split between the parts of the output
+	for _, part := range []string{"\x1b[32mMOCK\x1b[0m\r", "\nOUTPUT\x1b[", "31m\r\n", "DONE\r"} {
+		got, err := row.Write([]byte(part))
+		if err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if got != len(part) {
+			t.Errorf("Write() got = %v, want %v", got, len(part))
+		}
+	}
+	if err := row.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	value, err := cacheService.GetValue(context.Background(), pipelineId, cache.RunOutput)
+	if err != nil {
+		t.Fatalf("GetValue() error = %v", err)
+	}
+	want := "MOCK\nOUTPUT\nDONE\n"
+	if value != want {
+		t.Errorf("Write() writes %q to cache, want %q", value, want)
+	}
+}
+
+func TestRunOutputWriter_WriteWithFlushLines(t *testing.T) {
+	pipelineId := uuid.New()
+	cacheService := &countingCache{Cache: local.New(context.Background())}
+	err := cacheService.Cache.SetValue(context.Background(), pipelineId, cache.RunOutput, "")
+	if err != nil {
+		panic(err)
+	}
+	row := &RunOutputWriter{
+		Ctx:          context.Background(),
+		CacheService: cacheService,
+		PipelineId:   pipelineId,
+		FlushLines:   4,
+	}
+
+	tests := []struct {
+		line      string
+		wantValue string
+	}{
+		{line: "1\n", wantValue: ""},
+		{line: "2\n", wantValue: ""},
+		{line: "3\n", wantValue: ""},
+		{line: "4\n", wantValue: "1\n2\n3\n4\n"},
+		{line: "5\n", wantValue: "1\n2\n3\n4\n"},
+	}
+	for _, tt := range tests {
+		got, err := row.Write([]byte(tt.line))
+		if err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if got != len(tt.line) {
+			t.Errorf("Write() got = %v, want %v", got, len(tt.line))
+		}
+		value, err := cacheService.GetValue(context.Background(), pipelineId, cache.RunOutput)
+		if err != nil {
+			t.Fatalf("GetValue() error = %v", err)
+		}
+		if value != tt.wantValue {
+			t.Errorf("Write(%q) writes %q to cache, want %q", tt.line, value, tt.wantValue)
+		}
+	}
+	if err := row.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	value, err := cacheService.GetValue(context.Background(), pipelineId, cache.RunOutput)
+	if err != nil {
+		t.Fatalf("GetValue() error = %v", err)
+	}
+	if want := "1\n2\n3\n4\n5\n"; value != want {
+		t.Errorf("Close() writes %q to cache, want %q", value, want)
+	}
+	if cacheService.writes != 2 {
+		t.Errorf("Write() writes to cache %d times, want %d", cacheService.writes, 2)
+	}
+}
+
+func TestRunOutputWriter_WriteWithFlushInterval(t *testing.T) {
+	pipelineId := uuid.New()
+	cacheService := local.New(context.Background())
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, "")
+	if err != nil {
+		panic(err)
+	}
+	row := &RunOutputWriter{
+		Ctx:           context.Background(),
+		CacheService:  cacheService,
+		PipelineId:    pipelineId,
+		FlushLines:    100,
+		FlushInterval: 10 * time.Millisecond,
+	}
+	defer row.Close()
+
+	if _, err := row.Write([]byte("MOCK_OUTPUT\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	// the output is written to cache by the interval without the next Write or Close
+	deadline := time.Now().Add(time.Second)
+	for {
+		value, err := cacheService.GetValue(context.Background(), pipelineId, cache.RunOutput)
+		if err != nil {
+			t.Fatalf("GetValue() error = %v", err)
+		}
+		if value == "MOCK_OUTPUT\n" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Write() writes %q to cache, want %q", value, "MOCK_OUTPUT\n")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRunOutputWriter_WriteHighVolume(t *testing.T) {
+	pipelineId := uuid.New()
+	cacheService := local.New(context.Background())
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, "")
+	if err != nil {
+		panic(err)
+	}
+	row := &RunOutputWriter{
+		Ctx:           context.Background(),
+		CacheService:  cacheService,
+		PipelineId:    pipelineId,
+		Normalize:     true,
+		FlushLines:    64,
+		FlushInterval: time.Millisecond,
+	}
+
+	// the lines are printed with "\r\n" which is split between the writes
+	var want strings.Builder
+	for i := 0; i < 10000; i++ {
+		line := fmt.Sprintf("line %d\r", i)
+		if _, err := row.Write([]byte(line)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if _, err := row.Write([]byte("\n")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		want.WriteString(fmt.Sprintf("line %d\n", i))
+	}
+	if err := row.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	value, err := cacheService.GetValue(context.Background(), pipelineId, cache.RunOutput)
+	if err != nil {
+		t.Fatalf("GetValue() error = %v", err)
+	}
+	if output, _ := value.(string); output != want.String() {
+		t.Errorf("Write() writes %d bytes to cache, want %d bytes of the same output", len(output), want.Len())
+	}
+}
+
+func BenchmarkRunOutputWriter_Write(b *testing.B) {
+	line := []byte("INFO: the element of the pipeline is processed\n")
+	benchmarks := []struct {
+		name          string
+		flushLines    int
+		flushInterval time.Duration
+	}{
+		{name: "line by line"},
+		{name: "flush lines", flushLines: 100},
+		{name: "flush lines and interval", flushLines: 100, flushInterval: 10 * time.Millisecond},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				pipelineId := uuid.New()
+				cacheService := local.New(context.Background())
+				if err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, ""); err != nil {
+					b.Fatal(err)
+				}
+				row := &RunOutputWriter{
+					Ctx:           context.Background(),
+					CacheService:  cacheService,
+					PipelineId:    pipelineId,
+					FlushLines:    bm.flushLines,
+					FlushInterval: bm.flushInterval,
+				}
+				for j := 0; j < 1000; j++ {
+					if _, err := row.Write(line); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if err := row.Close(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
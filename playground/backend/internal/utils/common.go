@@ -15,9 +15,90 @@
 
 package utils
 
-import "regexp"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	truncatedOutputMarker        = "\n... output truncated (%d bytes omitted)\n"
+	truncatedCompileOutputMarker = "\n... compiler output truncated (%d bytes omitted)\n"
+)
+
+// maxAnsiEscapeLength is the max length of the ANSI escape sequence which is waited to be completed
+//	by the next part of the output, longer unterminated sequences are kept in the output as is.
+const maxAnsiEscapeLength = 64
+
+// ansiEscapeRegexp matches ANSI escape sequences, i.e. color codes "\x1b[31m" or terminal titles "\x1b]0;title\x07"
+var ansiEscapeRegexp = regexp.MustCompile(`\x1b(\[[0-?]*[ -/]*[@-~]|\][^\x07\x1b]*(\x07|\x1b\\)|[@-Z\\-_])`)
 
 func ReduceWhiteSpacesToSinge(s string) string {
 	re := regexp.MustCompile(`\s+`)
 	return re.ReplaceAllString(s, " ")
 }
+
+// TruncateOutput returns the output cut to maxSize bytes with a marker containing the number of omitted bytes.
+// If maxSize is 0 or the output doesn't exceed maxSize, returns the output as is.
+func TruncateOutput(output string, maxSize int) string {
+	return truncate(output, maxSize, truncatedOutputMarker)
+}
+
+// TruncateCompileOutput returns the compile output cut to maxSize bytes with a marker containing the number of omitted bytes.
+// If maxSize is 0 or the output doesn't exceed maxSize, returns the output as is.
+func TruncateCompileOutput(output string, maxSize int) string {
+	return truncate(output, maxSize, truncatedCompileOutputMarker)
+}
+
+// truncate returns the output cut to maxSize bytes with the marker formatted with the number of omitted bytes
+func truncate(output string, maxSize int, marker string) string {
+	if maxSize <= 0 || len(output) <= maxSize {
+		return output
+	}
+	kept := TruncationIndex(output, maxSize)
+	return output[:kept] + fmt.Sprintf(marker, len(output)-kept)
+}
+
+// TruncationIndex returns the index where the output should be cut to keep at most maxSize bytes
+//	without breaking a multibyte UTF-8 character.
+func TruncationIndex(output string, maxSize int) int {
+	if maxSize >= len(output) {
+		return len(output)
+	}
+	index := maxSize
+	for index > 0 && !utf8.RuneStart(output[index]) {
+		index--
+	}
+	return index
+}
+
+// NormalizeOutput returns the output without ANSI escape sequences and with line endings normalized to "\n".
+// Both "\r\n" and single "\r" are replaced with "\n".
+func NormalizeOutput(output string) string {
+	output = ansiEscapeRegexp.ReplaceAllString(output, "")
+	output = strings.ReplaceAll(output, "\r\n", "\n")
+	return strings.ReplaceAll(output, "\r", "\n")
+}
+
+// IncompleteOutputTailIndex returns the index of the tail of the output which couldn't be normalized yet
+//	because it could be continued by the next part of the output, i.e. "\r" which could be followed by "\n"
+//	or the beginning of an ANSI escape sequence. Returns len(output) if there is no such tail.
+func IncompleteOutputTailIndex(output string) int {
+	escapeIndex := strings.LastIndex(output, "\x1b")
+	if escapeIndex >= 0 && len(output)-escapeIndex <= maxAnsiEscapeLength {
+		tail := output[escapeIndex:]
+		if match := ansiEscapeRegexp.FindStringIndex(tail); match == nil || match[0] != 0 {
+			return escapeIndex
+		}
+	}
+	if strings.HasSuffix(output, "\r") {
+		return len(output) - 1
+	}
+	return len(output)
+}
+
+// TruncatedOutputMarker returns the marker which is appended to the truncated output
+func TruncatedOutputMarker(omittedBytes int) string {
+	return fmt.Sprintf(truncatedOutputMarker, omittedBytes)
+}
@@ -15,7 +15,10 @@
 
 package utils
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestReduceWhiteSpacesToSinge(t *testing.T) {
 	type args struct {
@@ -37,3 +40,90 @@ func TestReduceWhiteSpacesToSinge(t *testing.T) {
 		})
 	}
 }
+
+func TestTruncateOutput(t *testing.T) {
+	type args struct {
+		output  string
+		maxSize int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{name: "size isn't limited", args: args{"MOCK_OUTPUT", 0}, want: "MOCK_OUTPUT"},
+		{name: "output doesn't exceed limit", args: args{"MOCK_OUTPUT", 11}, want: "MOCK_OUTPUT"},
+		{name: "output exceeds limit", args: args{"MOCK_OUTPUT", 4}, want: "MOCK\n... output truncated (7 bytes omitted)\n"},
+		{name: "limit in the middle of multibyte character", args: args{"abécd", 3}, want: "ab\n... output truncated (4 bytes omitted)\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateOutput(tt.args.output, tt.args.maxSize); got != tt.want {
+				t.Errorf("TruncateOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateCompileOutput(t *testing.T) {
+	type args struct {
+		output  string
+		maxSize int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{name: "size isn't limited", args: args{"MOCK_OUTPUT", 0}, want: "MOCK_OUTPUT"},
+		{name: "output doesn't exceed limit", args: args{"MOCK_OUTPUT", 11}, want: "MOCK_OUTPUT"},
+		{name: "output exceeds limit", args: args{"MOCK_OUTPUT", 4}, want: "MOCK\n... compiler output truncated (7 bytes omitted)\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateCompileOutput(tt.args.output, tt.args.maxSize); got != tt.want {
+				t.Errorf("TruncateCompileOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{name: "plain output", output: "MOCK_OUTPUT\n", want: "MOCK_OUTPUT\n"},
+		{name: "color codes", output: "\x1b[32m- test passed\x1b[0m\n\x1b[1;31m- test failed\x1b[0m\n", want: "- test passed\n- test failed\n"},
+		{name: "terminal title", output: "\x1b]0;MOCK_TITLE\x07MOCK_OUTPUT", want: "MOCK_OUTPUT"},
+		{name: "carriage returns", output: "line1\r\nline2\rline3\n", want: "line1\nline2\nline3\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeOutput(tt.output); got != tt.want {
+				t.Errorf("NormalizeOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncompleteOutputTailIndex(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   int
+	}{
+		{name: "complete output", output: "MOCK\x1b[0m\n", want: 9},
+		{name: "carriage return at the end", output: "MOCK\r", want: 4},
+		{name: "incomplete escape sequence", output: "MOCK\x1b[3", want: 4},
+		{name: "too long escape sequence", output: "MOCK\x1b[" + strings.Repeat("1", maxAnsiEscapeLength), want: 6 + maxAnsiEscapeLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IncompleteOutputTailIndex(tt.output); got != tt.want {
+				t.Errorf("IncompleteOutputTailIndex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -19,9 +19,22 @@ import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/preparators"
 	"fmt"
+	"path/filepath"
 	"regexp"
+	"strings"
 )
 
+// allowedJvmArgs are patterns of JVM flags which could be passed by users with pipeline options.
+// Other flags (i.e. -agentpath, -javaagent or -XX:OnOutOfMemoryError) could run arbitrary code
+//	or write files outside the working directory, so they are rejected.
+var allowedJvmArgs = []*regexp.Regexp{
+	regexp.MustCompile(`^-Xm[sx][0-9]+[kKmMgG]?$`),
+	regexp.MustCompile(`^-Xss[0-9]+[kKmMgG]?$`),
+	regexp.MustCompile(`^-XX:[+-](UseG1GC|UseSerialGC|UseParallelGC|UseStringDeduplication)$`),
+	regexp.MustCompile(`^-XX:(MetaspaceSize|MaxMetaspaceSize|MaxDirectMemorySize)=[0-9]+[kKmMgG]?$`),
+	regexp.MustCompile(`^-XX:(InitialRAMPercentage|MaxRAMPercentage)=[0-9]+(\.[0-9]+)?$`),
+}
+
 // GetPreparators returns slice of preparators.Preparator according to sdk
 func GetPreparators(sdk pb.Sdk, filepath string) (*[]preparators.Preparator, error) {
 	var prep *[]preparators.Preparator
@@ -32,6 +45,10 @@ func GetPreparators(sdk pb.Sdk, filepath string) (*[]preparators.Preparator, err
 		prep = preparators.GetGoPreparators(filepath)
 	case pb.Sdk_SDK_PYTHON:
 		prep = preparators.GetPythonPreparators(filepath)
+	case pb.Sdk_SDK_SCIO:
+		prep = preparators.GetScioPreparators(filepath)
+	case pb.Sdk_SDK_KOTLIN:
+		prep = preparators.GetKotlinPreparators(filepath)
 	default:
 		return nil, fmt.Errorf("incorrect sdk: %s", sdk)
 	}
@@ -43,3 +60,152 @@ func ReplaceSpacesWithEquals(pipelineOptions string) string {
 	re := regexp.MustCompile(`(--[A-z0-9]+)\s([A-z0-9]+)`)
 	return re.ReplaceAllString(pipelineOptions, "$1=$2")
 }
+
+// SplitJvmArgs separates JVM flags (options with a single leading dash, i.e. -Xmx512m) from pipelineOptions.
+// Returns JVM flags and the rest of pipeline options.
+// If some JVM flag isn't allowed (see allowedJvmArgs) - returns an error.
+func SplitJvmArgs(pipelineOptions string) ([]string, string, error) {
+	var jvmArgs []string
+	var options []string
+	for _, option := range strings.Fields(pipelineOptions) {
+		if !strings.HasPrefix(option, "-") || strings.HasPrefix(option, "--") {
+			options = append(options, option)
+			continue
+		}
+		if !isAllowedJvmArg(option) {
+			return nil, "", fmt.Errorf("JVM flag isn't allowed: %s", option)
+		}
+		jvmArgs = append(jvmArgs, option)
+	}
+	return jvmArgs, strings.Join(options, " "), nil
+}
+
+// pipelineOption is a flag of the pipeline options together with its value
+type pipelineOption struct {
+	// name is the flag without the value (i.e. "--output" for "--output=/tmp/out"), empty for values which don't follow any flag
+	name string
+	// tokens are the flag and its value as they are written in the pipeline options
+	tokens []string
+}
+
+// MergePipelineOptions merges default baseOptions (i.e. from the config of the SDK or from the example)
+//	with pipelineOptions provided by the user.
+// Options are compared by the flag, so "--output=/tmp/out" and "--output /tmp/out" set the same option.
+// If the option is set several times, only the last value is kept, so values from pipelineOptions override baseOptions.
+// Options from baseOptions which aren't overridden go first, followed by options from pipelineOptions.
+func MergePipelineOptions(baseOptions, pipelineOptions string) string {
+	options := append(parsePipelineOptions(baseOptions), parsePipelineOptions(pipelineOptions)...)
+	lastIndexes := make(map[string]int)
+	for i, option := range options {
+		if option.name != "" {
+			lastIndexes[option.name] = i
+		}
+	}
+	var merged []string
+	for i, option := range options {
+		if option.name != "" && lastIndexes[option.name] != i {
+			continue
+		}
+		merged = append(merged, option.tokens...)
+	}
+	return strings.Join(merged, " ")
+}
+
+// parsePipelineOptions splits pipelineOptions into options.
+// A value which follows the flag without "=" is considered as the value of this flag (i.e. "--output /tmp/out").
+func parsePipelineOptions(pipelineOptions string) []pipelineOption {
+	var options []pipelineOption
+	for _, token := range strings.Fields(pipelineOptions) {
+		if strings.HasPrefix(token, "-") {
+			name := strings.SplitN(token, "=", 2)[0]
+			options = append(options, pipelineOption{name: name, tokens: []string{token}})
+			continue
+		}
+		if last := len(options) - 1; last >= 0 && options[last].name != "" && len(options[last].tokens) == 1 && !strings.Contains(options[last].tokens[0], "=") {
+			options[last].tokens = append(options[last].tokens, token)
+			continue
+		}
+		options = append(options, pipelineOption{tokens: []string{token}})
+	}
+	return options
+}
+
+// ConstrainOutputPaths checks the paths of the output which are set by outputOptions (i.e. "--output=counts") in pipelineOptions.
+// Names of outputOptions are compared without leading dashes, so "output" matches both "--output" and "-output".
+// Relative paths are resolved against baseFolder (the folder of the pipeline) and replaced with the absolute ones,
+//	so the output is written into the folder of the pipeline regardless of the working directory of the code.
+// Returns pipelineOptions with the replaced paths.
+// If some path isn't local or is outside baseFolder and allowedFolders (i.e. "/etc/passwd" or "../../counts") - returns an error.
+func ConstrainOutputPaths(pipelineOptions string, outputOptions []string, baseFolder string, allowedFolders []string) (string, error) {
+	if len(outputOptions) == 0 {
+		return pipelineOptions, nil
+	}
+	var constrained []string
+	for _, option := range parsePipelineOptions(pipelineOptions) {
+		if !isOutputOption(option.name, outputOptions) {
+			constrained = append(constrained, option.tokens...)
+			continue
+		}
+		switch {
+		case strings.Contains(option.tokens[0], "="):
+			path, err := constrainOutputPath(strings.SplitN(option.tokens[0], "=", 2)[1], baseFolder, allowedFolders)
+			if err != nil {
+				return "", err
+			}
+			constrained = append(constrained, option.name+"="+path)
+		case len(option.tokens) == 2:
+			path, err := constrainOutputPath(option.tokens[1], baseFolder, allowedFolders)
+			if err != nil {
+				return "", err
+			}
+			constrained = append(constrained, option.name, path)
+		default:
+			// the flag without the value is kept as is, so the error is reported by the code itself
+			constrained = append(constrained, option.tokens...)
+		}
+	}
+	return strings.Join(constrained, " "), nil
+}
+
+// isOutputOption checks if the flag of the pipeline option is one of outputOptions
+func isOutputOption(name string, outputOptions []string) bool {
+	if name == "" {
+		return false
+	}
+	name = strings.TrimLeft(name, "-")
+	for _, outputOption := range outputOptions {
+		if name == outputOption {
+			return true
+		}
+	}
+	return false
+}
+
+// constrainOutputPath returns the absolute path of the output which is resolved against baseFolder.
+// If the path isn't local or isn't inside baseFolder or one of allowedFolders - returns an error.
+func constrainOutputPath(path, baseFolder string, allowedFolders []string) (string, error) {
+	if strings.Contains(path, "://") {
+		return "", fmt.Errorf("output path should be a local path: %s", path)
+	}
+	absolutePath := path
+	if !filepath.IsAbs(absolutePath) {
+		absolutePath = filepath.Join(baseFolder, absolutePath)
+	}
+	absolutePath = filepath.Clean(absolutePath)
+	for _, folder := range append([]string{baseFolder}, allowedFolders...) {
+		if rel, err := filepath.Rel(folder, absolutePath); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return absolutePath, nil
+		}
+	}
+	return "", fmt.Errorf("output path is outside the folder of the pipeline: %s", path)
+}
+
+// isAllowedJvmArg checks if the JVM flag matches one of allowedJvmArgs
+func isAllowedJvmArg(jvmArg string) bool {
+	for _, allowed := range allowedJvmArgs {
+		if allowed.MatchString(jvmArg) {
+			return true
+		}
+	}
+	return false
+}
@@ -15,7 +15,10 @@
 
 package utils
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestSpacesToEqualsOption(t *testing.T) {
 	type args struct {
@@ -50,3 +53,239 @@ func TestSpacesToEqualsOption(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitJvmArgs(t *testing.T) {
+	tests := []struct {
+		name            string
+		pipelineOptions string
+		wantJvmArgs     []string
+		wantOptions     string
+		wantErr         bool
+	}{
+		{
+			name:            "args is empty string",
+			pipelineOptions: "",
+			wantJvmArgs:     nil,
+			wantOptions:     "",
+			wantErr:         false,
+		},
+		{
+			name:            "args without jvm flags",
+			pipelineOptions: "--opt1 valOpt --opt2=valOpt",
+			wantJvmArgs:     nil,
+			wantOptions:     "--opt1 valOpt --opt2=valOpt",
+			wantErr:         false,
+		},
+		{
+			name:            "args with allowed jvm flags",
+			pipelineOptions: "-Xmx512m --opt1 valOpt -Xms256M -Xss1m -XX:+UseG1GC -XX:MaxMetaspaceSize=128m -XX:MaxRAMPercentage=75.0",
+			wantJvmArgs:     []string{"-Xmx512m", "-Xms256M", "-Xss1m", "-XX:+UseG1GC", "-XX:MaxMetaspaceSize=128m", "-XX:MaxRAMPercentage=75.0"},
+			wantOptions:     "--opt1 valOpt",
+			wantErr:         false,
+		},
+		{
+			name:            "args with agentpath",
+			pipelineOptions: "-agentpath:/tmp/agent.so --opt1 valOpt",
+			wantErr:         true,
+		},
+		{
+			name:            "args with javaagent",
+			pipelineOptions: "-javaagent:/tmp/agent.jar",
+			wantErr:         true,
+		},
+		{
+			name:            "args with command on out of memory error",
+			pipelineOptions: "-XX:OnOutOfMemoryError=sh",
+			wantErr:         true,
+		},
+		{
+			name:            "args with classpath",
+			pipelineOptions: "-cp /tmp",
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jvmArgs, options, err := SplitJvmArgs(tt.pipelineOptions)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SplitJvmArgs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(jvmArgs, tt.wantJvmArgs) {
+				t.Errorf("SplitJvmArgs() jvmArgs = %v, want %v", jvmArgs, tt.wantJvmArgs)
+			}
+			if options != tt.wantOptions {
+				t.Errorf("SplitJvmArgs() options = %v, want %v", options, tt.wantOptions)
+			}
+		})
+	}
+}
+
+func TestMergePipelineOptions(t *testing.T) {
+	tests := []struct {
+		name            string
+		baseOptions     string
+		pipelineOptions string
+		want            string
+	}{
+		{
+			name:            "both options are empty",
+			baseOptions:     "",
+			pipelineOptions: "",
+			want:            "",
+		},
+		{
+			name:            "only base options",
+			baseOptions:     "--output=/tmp/out --runner DirectRunner",
+			pipelineOptions: "",
+			want:            "--output=/tmp/out --runner DirectRunner",
+		},
+		{
+			name:            "only user options",
+			baseOptions:     "",
+			pipelineOptions: "--output /tmp/user",
+			want:            "--output /tmp/user",
+		},
+		{
+			name:            "user option overrides base option",
+			baseOptions:     "--output=/tmp/out --runner=DirectRunner",
+			pipelineOptions: "--output=/tmp/user",
+			want:            "--runner=DirectRunner --output=/tmp/user",
+		},
+		{
+			name:            "user option overrides base option written in another form",
+			baseOptions:     "--output /tmp/out --runner=DirectRunner",
+			pipelineOptions: "--output=/tmp/user",
+			want:            "--runner=DirectRunner --output=/tmp/user",
+		},
+		{
+			name:            "user option is added to base options",
+			baseOptions:     "--output=/tmp/out",
+			pipelineOptions: "--inputFile gs://bucket/input.txt",
+			want:            "--output=/tmp/out --inputFile gs://bucket/input.txt",
+		},
+		{
+			name:            "conflicting user options",
+			baseOptions:     "--output=/tmp/out",
+			pipelineOptions: "--output=/tmp/first --output /tmp/second",
+			want:            "--output /tmp/second",
+		},
+		{
+			name:            "boolean flags",
+			baseOptions:     "--streaming --output=/tmp/out",
+			pipelineOptions: "--streaming=false",
+			want:            "--output=/tmp/out --streaming=false",
+		},
+		{
+			name:            "jvm flags",
+			baseOptions:     "-Xmx512m --output=/tmp/out",
+			pipelineOptions: "-XX:MaxRAMPercentage=50 -XX:MaxRAMPercentage=75",
+			want:            "-Xmx512m --output=/tmp/out -XX:MaxRAMPercentage=75",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergePipelineOptions(tt.baseOptions, tt.pipelineOptions); got != tt.want {
+				t.Errorf("MergePipelineOptions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstrainOutputPaths(t *testing.T) {
+	baseFolder := "/app/executable_files/6ba7b810"
+	tests := []struct {
+		name            string
+		pipelineOptions string
+		outputOptions   []string
+		allowedFolders  []string
+		want            string
+		wantErr         bool
+	}{
+		{
+			name:            "output paths aren't checked",
+			pipelineOptions: "--output=/etc/passwd",
+			outputOptions:   nil,
+			want:            "--output=/etc/passwd",
+		},
+		{
+			name:            "relative path in the folder of the pipeline",
+			pipelineOptions: "--runner=DirectRunner --output=counts/out.txt",
+			outputOptions:   []string{"output"},
+			want:            "--runner=DirectRunner --output=/app/executable_files/6ba7b810/counts/out.txt",
+		},
+		{
+			name:            "relative path separated by space",
+			pipelineOptions: "--output counts --runner DirectRunner",
+			outputOptions:   []string{"output"},
+			want:            "--output /app/executable_files/6ba7b810/counts --runner DirectRunner",
+		},
+		{
+			name:            "absolute path in the folder of the pipeline",
+			pipelineOptions: "-output=/app/executable_files/6ba7b810/bin/../counts",
+			outputOptions:   []string{"output"},
+			want:            "-output=/app/executable_files/6ba7b810/counts",
+		},
+		{
+			name:            "absolute path outside the folder of the pipeline",
+			pipelineOptions: "--output=/etc/passwd",
+			outputOptions:   []string{"output"},
+			wantErr:         true,
+		},
+		{
+			name:            "absolute path in the folder with the common prefix",
+			pipelineOptions: "--output=/app/executable_files/6ba7b810-other/counts",
+			outputOptions:   []string{"output"},
+			wantErr:         true,
+		},
+		{
+			name:            "relative traversal outside the folder of the pipeline",
+			pipelineOptions: "--output ../../configs/SDK_JAVA.json",
+			outputOptions:   []string{"output"},
+			wantErr:         true,
+		},
+		{
+			name:            "relative traversal which stays in the folder of the pipeline",
+			pipelineOptions: "--output=src/../counts",
+			outputOptions:   []string{"output"},
+			want:            "--output=/app/executable_files/6ba7b810/counts",
+		},
+		{
+			name:            "absolute path in the allowed folder",
+			pipelineOptions: "--output=/tmp/out/counts",
+			outputOptions:   []string{"output"},
+			allowedFolders:  []string{"/tmp/out"},
+			want:            "--output=/tmp/out/counts",
+		},
+		{
+			name:            "path which isn't local",
+			pipelineOptions: "--output=gs://bucket/counts",
+			outputOptions:   []string{"output"},
+			wantErr:         true,
+		},
+		{
+			name:            "other options are kept, the second output option is checked",
+			pipelineOptions: "--input=/etc/hosts --temp_location ../tmp",
+			outputOptions:   []string{"output", "temp_location"},
+			wantErr:         true,
+		},
+		{
+			name:            "output flag without the value",
+			pipelineOptions: "--output",
+			outputOptions:   []string{"output"},
+			want:            "--output",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConstrainOutputPaths(tt.pipelineOptions, tt.outputOptions, baseFolder, tt.allowedFolders)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConstrainOutputPaths() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ConstrainOutputPaths() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
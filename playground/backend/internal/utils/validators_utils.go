@@ -21,18 +21,11 @@ import (
 	"fmt"
 )
 
-// GetValidators returns slice of validators.Validator according to sdk
-func GetValidators(sdk pb.Sdk, filepath string) (*[]validators.Validator, error) {
-	var val *[]validators.Validator
-	switch sdk {
-	case pb.Sdk_SDK_JAVA:
-		val = validators.GetJavaValidators(filepath)
-	case pb.Sdk_SDK_GO:
-		val = validators.GetGoValidators()
-	case pb.Sdk_SDK_PYTHON:
-		val = validators.GetPythonValidators()
-	default:
+// GetValidators returns slice of validators.Validator registered for sdk
+func GetValidators(sdk pb.Sdk) (*[]validators.Validator, error) {
+	val, ok := validators.GetSdkValidators(sdk)
+	if !ok {
 		return nil, fmt.Errorf("incorrect sdk: %s", sdk)
 	}
-	return val, nil
+	return &val, nil
 }
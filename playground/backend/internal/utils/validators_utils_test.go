@@ -24,13 +24,12 @@ import (
 
 func TestGetValidators(t *testing.T) {
 	type args struct {
-		sdk      playground.Sdk
-		filepath string
+		sdk playground.Sdk
 	}
 	tests := []struct {
 		name    string
 		args    args
-		want    *[]validators.Validator
+		want    []string
 		wantErr bool
 	}{
 		{
@@ -38,42 +37,37 @@ func TestGetValidators(t *testing.T) {
 			// As a result, want to receive an error.
 			name: "incorrect sdk",
 			args: args{
-				sdk:      playground.Sdk_SDK_UNSPECIFIED,
-				filepath: "",
+				sdk: playground.Sdk_SDK_UNSPECIFIED,
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
 			// Test case with calling GetValidators method with correct SDK.
-			// As a result, want to receive an expected slice of validators.
+			// As a result, want to receive the validators of the path and the unit tests of the Java code in this order.
 			name: "correct sdk",
 			args: args{
-				sdk:      playground.Sdk_SDK_JAVA,
-				filepath: "",
+				sdk: playground.Sdk_SDK_JAVA,
 			},
-			want:    validators.GetJavaValidators(""),
+			want:    []string{"Valid path", validators.UnitTestValidatorName},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := GetValidators(tt.args.sdk, tt.args.filepath)
+			got, err := GetValidators(tt.args.sdk)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetValidators() err = %v, wantErr %v", err, tt.wantErr)
 			}
+			var gotNames []string
 			if got != nil {
-				if !reflect.DeepEqual(len(*got), len(*tt.want)) {
-					t.Errorf("GetValidators() len = %v, want %v", len(*got), len(*tt.want))
-				}
-				for i := range *got {
-					gotVal := (*got)[i]
-					wantVal := (*tt.want)[i]
-					if !reflect.DeepEqual(gotVal.Args, wantVal.Args) {
-						t.Errorf("GetValidators() %d = %v, want %v", i, gotVal.Args, wantVal.Args)
-					}
+				for _, validator := range *got {
+					gotNames = append(gotNames, validator.Name())
 				}
 			}
+			if !reflect.DeepEqual(gotNames, tt.want) {
+				t.Errorf("GetValidators() = %v, want %v", gotNames, tt.want)
+			}
 		})
 	}
 }
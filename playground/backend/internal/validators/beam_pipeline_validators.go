@@ -0,0 +1,106 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package validators
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+)
+
+const BeamPipelineValidatorName = "BeamPipeline"
+
+// beamPipelinePattern finds the construction of the Beam pipeline in the code of the SDK and hint is shown to the user
+//	if the pipeline isn't constructed
+type beamPipelinePattern struct {
+	construction *regexp.Regexp
+	hint         string
+}
+
+var sdkBeamPipelinePatterns = map[pb.Sdk]beamPipelinePattern{
+	pb.Sdk_SDK_JAVA: {
+		construction: regexp.MustCompile(`\b(Test)?Pipeline\s*\.\s*create\s*\(`),
+		hint:         "Pipeline.create",
+	},
+	pb.Sdk_SDK_GO: {
+		construction: regexp.MustCompile(`\bbeam\s*\.\s*NewPipeline(WithRoot)?\s*\(`),
+		hint:         "beam.NewPipeline",
+	},
+	pb.Sdk_SDK_PYTHON: {
+		construction: regexp.MustCompile(`\b(beam\s*\.\s*)?(Test)?Pipeline\s*\(`),
+		hint:         "beam.Pipeline",
+	},
+	pb.Sdk_SDK_SCIO: {
+		construction: regexp.MustCompile(`\bContextAndArgs\s*\(|\bScioContext\s*(\.\s*\w+\s*)?\(`),
+		hint:         "ContextAndArgs",
+	},
+	pb.Sdk_SDK_KOTLIN: {
+		construction: regexp.MustCompile(`\b(Test)?Pipeline\s*\.\s*create\s*\(`),
+		hint:         "Pipeline.create",
+	},
+}
+
+// beamPipelineValidator checks that the source files construct a Beam pipeline
+type beamPipelineValidator struct {
+	sdk pb.Sdk
+}
+
+// GetBeamPipelineValidator returns validator which checks that the source files of the pipeline construct a Beam pipeline
+//	(i.e. Pipeline.create for Java code or beam.Pipeline for Python code).
+// The validator doesn't fail the validation: the result is false if the pipeline isn't constructed,
+//	so the user could be warned that the code doesn't produce any Beam output (see MissingBeamPipelineWarning).
+func GetBeamPipelineValidator(sdk pb.Sdk) Validator {
+	return beamPipelineValidator{sdk: sdk}
+}
+
+func (v beamPipelineValidator) Name() string {
+	return BeamPipelineValidatorName
+}
+
+func (v beamPipelineValidator) Validate(_ context.Context, lc *fs_tool.LifeCycle) (bool, error) {
+	return CheckBeamPipeline(lc.GetAbsoluteSourceFilePaths(), v.sdk)
+}
+
+// CheckBeamPipeline checks that some of the source files constructs a Beam pipeline.
+// Returns false without an error if the pipeline isn't constructed.
+// The code of SDKs without known patterns is always considered as constructing the pipeline.
+func CheckBeamPipeline(args ...interface{}) (bool, error) {
+	filePaths := args[0].([]string)
+	sdk := args[1].(pb.Sdk)
+	pattern, ok := sdkBeamPipelinePatterns[sdk]
+	if !ok {
+		return true, nil
+	}
+	for _, filePath := range filePaths {
+		code, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			logger.Errorf("Validation: Error during open file: %s, err: %s\n", filePath, err.Error())
+			return false, err
+		}
+		if pattern.construction.Match(code) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MissingBeamPipelineWarning returns the warning which is shown to the user if the code of the SDK doesn't construct a Beam pipeline
+func MissingBeamPipelineWarning(sdk pb.Sdk) string {
+	return fmt.Sprintf("The code doesn't seem to construct a Beam pipeline (i.e. with %s), so it doesn't produce any Beam output", sdkBeamPipelinePatterns[sdk].hint)
+}
@@ -0,0 +1,132 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package validators
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"testing"
+)
+
+const javaPipelineCodeFilePath = "pipelineCode.java"
+const javaPipelineCode = "import org.apache.beam.sdk.Pipeline;\nimport org.apache.beam.sdk.options.PipelineOptionsFactory;\nimport org.apache.beam.sdk.transforms.Create;\n\nclass WordCount {\n    public static void main(String[] args) {\n        Pipeline p = Pipeline.create(PipelineOptionsFactory.fromArgs(args).create());\n        p.apply(Create.of(\"Hello\", \"Beam\"));\n        p.run().waitUntilFinish();\n    }\n}"
+const javaHelloWorldCodeFilePath = "helloWorldCode.java"
+const javaHelloWorldCode = "class HelloWorld {\n    public static void main(String[] args) {\n        System.out.println(\"Hello World!\");\n    }\n}"
+const pythonPipelineCodeFilePath = "pipelineCode.py"
+const pythonPipelineCode = "import apache_beam as beam\n\nwith beam.Pipeline() as p:\n    p | beam.Create(['Hello', 'Beam']) | beam.Map(print)\n"
+const pythonHelloWorldCodeFilePath = "helloWorldCode.py"
+const pythonHelloWorldCode = "print('Hello World!')\n"
+const goPipelineCodeFilePath = "pipelineCode.go"
+const goPipelineCode = "package main\n\nimport (\n\t\"context\"\n\n\t\"github.com/apache/beam/sdks/v2/go/pkg/beam\"\n\t\"github.com/apache/beam/sdks/v2/go/pkg/beam/x/beamx\"\n)\n\nfunc main() {\n\tbeam.Init()\n\tp, s := beam.NewPipelineWithRoot()\n\tbeam.Create(s, \"Hello\", \"Beam\")\n\tbeamx.Run(context.Background(), p)\n}\n"
+
+func TestCheckBeamPipeline(t *testing.T) {
+	files := map[string]string{
+		javaPipelineCodeFilePath:     javaPipelineCode,
+		javaHelloWorldCodeFilePath:   javaHelloWorldCode,
+		pythonPipelineCodeFilePath:   pythonPipelineCode,
+		pythonHelloWorldCodeFilePath: pythonHelloWorldCode,
+		goPipelineCodeFilePath:       goPipelineCode,
+	}
+	for path, code := range files {
+		writeFile(path, code)
+		defer removeFile(path)
+	}
+
+	type args struct {
+		args []interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			// Test case with calling CheckBeamPipeline method with Java code which creates a Beam pipeline.
+			// As a result, want to receive true.
+			name:    "java pipeline",
+			args:    args{[]interface{}{[]string{javaPipelineCodeFilePath}, pb.Sdk_SDK_JAVA}},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckBeamPipeline method with Java Hello World which doesn't create a Beam pipeline.
+			// As a result, want to receive false without an error.
+			name:    "java hello world",
+			args:    args{[]interface{}{[]string{javaHelloWorldCodeFilePath}, pb.Sdk_SDK_JAVA}},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckBeamPipeline method with several files and one of them creates a Beam pipeline.
+			// As a result, want to receive true.
+			name:    "pipeline in one of files",
+			args:    args{[]interface{}{[]string{javaHelloWorldCodeFilePath, javaPipelineCodeFilePath}, pb.Sdk_SDK_JAVA}},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckBeamPipeline method with Python code which creates a Beam pipeline.
+			// As a result, want to receive true.
+			name:    "python pipeline",
+			args:    args{[]interface{}{[]string{pythonPipelineCodeFilePath}, pb.Sdk_SDK_PYTHON}},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckBeamPipeline method with Python Hello World which doesn't create a Beam pipeline.
+			// As a result, want to receive false without an error.
+			name:    "python hello world",
+			args:    args{[]interface{}{[]string{pythonHelloWorldCodeFilePath}, pb.Sdk_SDK_PYTHON}},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckBeamPipeline method with Go code which creates a Beam pipeline.
+			// As a result, want to receive true.
+			name:    "go pipeline",
+			args:    args{[]interface{}{[]string{goPipelineCodeFilePath}, pb.Sdk_SDK_GO}},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckBeamPipeline method with the sdk without known patterns.
+			// As a result, want to receive true.
+			name:    "unspecified sdk",
+			args:    args{[]interface{}{[]string{javaHelloWorldCodeFilePath}, pb.Sdk_SDK_UNSPECIFIED}},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckBeamPipeline method with file which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "file doesn't exist",
+			args:    args{[]interface{}{[]string{"notExistingCode.java"}, pb.Sdk_SDK_JAVA}},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckBeamPipeline(tt.args.args...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckBeamPipeline() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CheckBeamPipeline() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
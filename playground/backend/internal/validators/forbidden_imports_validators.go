@@ -0,0 +1,92 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+const ForbiddenImportsValidatorName = "ForbiddenImports"
+
+// forbiddenImportsValidator checks that the source files don't use any of forbiddenImports
+type forbiddenImportsValidator struct {
+	forbiddenImports []string
+}
+
+// GetForbiddenImportsValidator returns validator which checks that the source files of the pipeline don't use
+//	any of forbiddenImports (i.e. java.lang.Runtime or java.net.ServerSocket for Java code)
+func GetForbiddenImportsValidator(forbiddenImports []string) Validator {
+	return forbiddenImportsValidator{forbiddenImports: forbiddenImports}
+}
+
+func (v forbiddenImportsValidator) Name() string {
+	return ForbiddenImportsValidatorName
+}
+
+func (v forbiddenImportsValidator) Validate(_ context.Context, lc *fs_tool.LifeCycle) (bool, error) {
+	return CheckForbiddenImports(lc.GetAbsoluteSourceFilePaths(), v.forbiddenImports)
+}
+
+// CheckForbiddenImports checks that the code doesn't contain any forbidden import.
+// Imports are matched as whole names, so java.lang.Runtime doesn't match java.lang.RuntimeException.
+// In case some forbidden import is found returns false and an error with the name of the import.
+func CheckForbiddenImports(args ...interface{}) (bool, error) {
+	filePaths := args[0].([]string)
+	forbiddenImports := args[1].([]string)
+	for _, filePath := range filePaths {
+		code, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			logger.Errorf("Validation: Error during open file: %s, err: %s\n", filePath, err.Error())
+			return false, err
+		}
+		for _, forbiddenImport := range forbiddenImports {
+			if forbiddenImport == "" {
+				continue
+			}
+			if forbiddenImportRegexp(forbiddenImport).Match(code) {
+				return false, fmt.Errorf("forbidden import %s is used in %s", forbiddenImport, filepath.Base(filePath))
+			}
+		}
+	}
+	return true, nil
+}
+
+// forbiddenImportRegexp returns regexp to find the import in the code.
+// Word boundaries are added only to the ends of the import which are letters, digits or underscores,
+//	so imports with quotes (i.e. "os/exec" for Go code) are also found.
+func forbiddenImportRegexp(forbiddenImport string) *regexp.Regexp {
+	pattern := regexp.QuoteMeta(forbiddenImport)
+	if first, _ := utf8.DecodeRuneInString(forbiddenImport); isWordRune(first) {
+		pattern = `\b` + pattern
+	}
+	if last, _ := utf8.DecodeLastRuneInString(forbiddenImport); isWordRune(last) {
+		pattern = pattern + `\b`
+	}
+	return regexp.MustCompile(pattern)
+}
+
+// isWordRune checks if the rune is a part of a word according to the \b assertion of regexp
+func isWordRune(r rune) bool {
+	return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
@@ -0,0 +1,95 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"testing"
+)
+
+const forbiddenImportFilePath = "forbiddenImportCode.java"
+const forbiddenImportCode = "import java.net.ServerSocket;\n\npublic class Class {\n    public static void main(String[] args) throws Exception {\n        new ServerSocket(8080).accept();\n    }\n}"
+const similarImportFilePath = "similarImportCode.java"
+const similarImportCode = "public class Class {\n    public static void main(String[] args) {\n        throw new java.lang.RuntimeException(\"error\");\n    }\n}"
+
+func TestCheckForbiddenImports(t *testing.T) {
+	writeFile(forbiddenImportFilePath, forbiddenImportCode)
+	writeFile(similarImportFilePath, similarImportCode)
+	defer removeFile(forbiddenImportFilePath)
+	defer removeFile(similarImportFilePath)
+	forbiddenImports := []string{"java.lang.Runtime", "java.net.ServerSocket"}
+
+	type args struct {
+		args []interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			// Test case with calling CheckForbiddenImports method with code which doesn't use forbidden imports.
+			// As a result, want to receive true.
+			name:    "code without forbidden imports",
+			args:    args{[]interface{}{[]string{filePath}, forbiddenImports}},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckForbiddenImports method with several files and one of them uses forbidden import.
+			// As a result, want to receive an error with the name of the forbidden import.
+			name:    "code with forbidden import",
+			args:    args{[]interface{}{[]string{filePath, forbiddenImportFilePath}, forbiddenImports}},
+			want:    false,
+			wantErr: true,
+			errMsg:  "forbidden import java.net.ServerSocket is used in forbiddenImportCode.java",
+		},
+		{
+			// Test case with calling CheckForbiddenImports method with code which uses class with a name
+			// 	starting with a forbidden import (java.lang.RuntimeException).
+			// As a result, want to receive true.
+			name:    "code with import similar to forbidden",
+			args:    args{[]interface{}{[]string{similarImportFilePath}, forbiddenImports}},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckForbiddenImports method with file which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "file doesn't exist",
+			args:    args{[]interface{}{[]string{"notExistingCode.java"}, forbiddenImports}},
+			want:    false,
+			wantErr: true,
+			errMsg:  "open notExistingCode.java: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckForbiddenImports(tt.args.args...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckForbiddenImports() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && err.Error() != tt.errMsg {
+				t.Errorf("CheckForbiddenImports() error = %v, want %v", err, tt.errMsg)
+			}
+			if got != tt.want {
+				t.Errorf("CheckForbiddenImports() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,7 +15,9 @@
 
 package validators
 
-// GetGoValidators return validators methods that should be applied to Go code
-func GetGoValidators() *[]Validator {
-	return &[]Validator{}
+import pb "beam.apache.org/playground/backend/internal/api/v1"
+
+// Go code has no own validators
+func init() {
+	Register(pb.Sdk_SDK_GO)
 }
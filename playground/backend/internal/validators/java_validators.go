@@ -16,8 +16,10 @@
 package validators
 
 import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/fs_tool"
 	"beam.apache.org/playground/backend/internal/logger"
+	"context"
 	"io/ioutil"
 	"strings"
 )
@@ -27,24 +29,33 @@ const (
 	javaUnitTestPattern = "@Test"
 )
 
-// GetJavaValidators return validators methods that should be applied to Java code
-// The last validator should check that the code is unit tests or not
-func GetJavaValidators(filePath string) *[]Validator {
-	validatorArgs := make([]interface{}, 2)
-	validatorArgs[0] = filePath
-	validatorArgs[1] = javaExtension
-	pathCheckerValidator := Validator{
-		Validator: fs_tool.CheckPathIsValid,
-		Args:      validatorArgs,
-		Name:      "Valid path",
-	}
-	unitTestValidator := Validator{
-		Validator: CheckIsUnitTests,
-		Args:      validatorArgs,
-		Name:      UnitTestValidatorName,
-	}
-	validators := []Validator{pathCheckerValidator, unitTestValidator}
-	return &validators
+// Java code is checked to have a valid path and the last validator checks that the code is unit tests or not
+func init() {
+	Register(pb.Sdk_SDK_JAVA, pathValidator{extension: javaExtension}, javaUnitTestValidator{})
+}
+
+// pathValidator checks that the source file exists and has the extension
+type pathValidator struct {
+	extension string
+}
+
+func (v pathValidator) Name() string {
+	return "Valid path"
+}
+
+func (v pathValidator) Validate(_ context.Context, lc *fs_tool.LifeCycle) (bool, error) {
+	return fs_tool.CheckPathIsValid(lc.GetAbsoluteSourceFilePath(), v.extension)
+}
+
+// javaUnitTestValidator checks whether the Java code is unit tests
+type javaUnitTestValidator struct{}
+
+func (v javaUnitTestValidator) Name() string {
+	return UnitTestValidatorName
+}
+
+func (v javaUnitTestValidator) Validate(_ context.Context, lc *fs_tool.LifeCycle) (bool, error) {
+	return CheckIsUnitTests(lc.GetAbsoluteSourceFilePath())
 }
 
 func CheckIsUnitTests(args ...interface{}) (bool, error) {
@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	PipelineOptionsValidatorName = "PipelineOptions"
+	runnerOptionName             = "runner"
+)
+
+// pipelineOptionRegexp matches pipeline option in "--name" or "--name=value" format
+var pipelineOptionRegexp = regexp.MustCompile(`^--([A-Za-z][A-Za-z0-9_.-]*)(=(.*))?$`)
+
+// defaultAllowedRunners are names of the runners which could be used in the sandbox (in lower case)
+//	if the allowed runners aren't configured for the SDK.
+// The code is executed by the direct runner only, so other runners (i.e. DataflowRunner) are rejected.
+var defaultAllowedRunners = []string{"direct", "directrunner", "org.apache.beam.runners.direct.directrunner"}
+
+// GetPipelineOptionsValidator returns validator which checks that pipelineOptions are well-formed
+//	and don't contain a runner which couldn't be used in the sandbox.
+// allowedRunners are names of the runners which could be set by pipelineOptions (case-insensitive),
+//	only the direct runner is allowed if they are empty.
+func GetPipelineOptionsValidator(pipelineOptions string, allowedRunners []string) Validator {
+	return pipelineOptionsValidator{pipelineOptions: pipelineOptions, allowedRunners: allowedRunners}
+}
+
+// pipelineOptionsValidator checks pipeline options which the code is run with
+type pipelineOptionsValidator struct {
+	pipelineOptions string
+	allowedRunners  []string
+}
+
+func (v pipelineOptionsValidator) Name() string {
+	return PipelineOptionsValidatorName
+}
+
+func (v pipelineOptionsValidator) Validate(_ context.Context, _ *fs_tool.LifeCycle) (bool, error) {
+	return CheckPipelineOptions(v.pipelineOptions, v.allowedRunners)
+}
+
+// CheckPipelineOptions checks that pipeline options could be parsed and the runner (if it is set) is allowed.
+// The second argument is the list of the allowed runners (optional), only the direct runner is allowed if it is empty.
+// In case the options are malformed or the runner isn't allowed returns false and an error with the reason.
+func CheckPipelineOptions(args ...interface{}) (bool, error) {
+	pipelineOptions := args[0].(string)
+	var allowedRunners []string
+	if len(args) > 1 {
+		allowedRunners, _ = args[1].([]string)
+	}
+	options, err := parsePipelineOptions(pipelineOptions)
+	if err != nil {
+		return false, err
+	}
+	runner, ok := options[runnerOptionName]
+	if !ok || isAllowedRunner(runner, allowedRunners) {
+		return true, nil
+	}
+	if len(allowedRunners) == 0 {
+		return false, fmt.Errorf("runner %s isn't allowed, only DirectRunner could be used", runner)
+	}
+	return false, fmt.Errorf("runner %s isn't allowed, allowed runners: %s", runner, strings.Join(allowedRunners, ", "))
+}
+
+// parsePipelineOptions parses pipeline options in "--name=value", "--name value" or "--name" (boolean flag) formats
+//	separated by spaces.
+// Returns map with names of the options as keys and their values as values.
+func parsePipelineOptions(pipelineOptions string) (map[string]string, error) {
+	options := make(map[string]string)
+	tokens := strings.Fields(pipelineOptions)
+	for i := 0; i < len(tokens); i++ {
+		match := pipelineOptionRegexp.FindStringSubmatch(tokens[i])
+		if match == nil {
+			return nil, fmt.Errorf("malformed pipeline option: %s", tokens[i])
+		}
+		name, value := match[1], match[3]
+		if match[2] == "" && i+1 < len(tokens) && !strings.HasPrefix(tokens[i+1], "--") {
+			i++
+			value = tokens[i]
+		}
+		if name == runnerOptionName && value == "" {
+			return nil, fmt.Errorf("malformed pipeline option: --%s should have a value", runnerOptionName)
+		}
+		options[name] = value
+	}
+	return options, nil
+}
+
+// isAllowedRunner checks if the runner is one of allowedRunners (case-insensitive)
+//	or one of defaultAllowedRunners if allowedRunners are empty
+func isAllowedRunner(runner string, allowedRunners []string) bool {
+	if len(allowedRunners) == 0 {
+		allowedRunners = defaultAllowedRunners
+	}
+	for _, allowed := range allowedRunners {
+		if strings.EqualFold(runner, allowed) {
+			return true
+		}
+	}
+	return false
+}
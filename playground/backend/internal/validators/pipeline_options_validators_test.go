@@ -0,0 +1,145 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"testing"
+)
+
+func TestCheckPipelineOptions(t *testing.T) {
+	tests := []struct {
+		name            string
+		pipelineOptions string
+		allowedRunners  []string
+		want            bool
+		wantErr         bool
+		errMsg          string
+	}{
+		{
+			// Test case with calling CheckPipelineOptions method with empty pipeline options.
+			// As a result, want to receive true.
+			name:            "empty pipeline options",
+			pipelineOptions: "",
+			want:            true,
+			wantErr:         false,
+		},
+		{
+			// Test case with calling CheckPipelineOptions method with valid pipeline options and the direct runner.
+			// As a result, want to receive true.
+			name:            "valid pipeline options",
+			pipelineOptions: "--runner=DirectRunner --inputFile gs://bucket/input.txt --streaming --temp_location=/tmp",
+			want:            true,
+			wantErr:         false,
+		},
+		{
+			// Test case with calling CheckPipelineOptions method with the direct runner separated by space (Go style).
+			// As a result, want to receive true.
+			name:            "direct runner separated by space",
+			pipelineOptions: "--runner direct",
+			want:            true,
+			wantErr:         false,
+		},
+		{
+			// Test case with calling CheckPipelineOptions method with runner which couldn't be used in the sandbox.
+			// As a result, want to receive an error with the name of the runner.
+			name:            "unknown runner",
+			pipelineOptions: "--inputFile=input.txt --runner=DataflowRunner",
+			want:            false,
+			wantErr:         true,
+			errMsg:          "runner DataflowRunner isn't allowed, only DirectRunner could be used",
+		},
+		{
+			// Test case with calling CheckPipelineOptions method with option without leading dashes.
+			// As a result, want to receive an error with the malformed option.
+			name:            "malformed pipeline options",
+			pipelineOptions: "--inputFile=input.txt runner=DirectRunner",
+			want:            false,
+			wantErr:         true,
+			errMsg:          "malformed pipeline option: runner=DirectRunner",
+		},
+		{
+			// Test case with calling CheckPipelineOptions method with option which has no name.
+			// As a result, want to receive an error with the malformed option.
+			name:            "option without name",
+			pipelineOptions: "--=DirectRunner",
+			want:            false,
+			wantErr:         true,
+			errMsg:          "malformed pipeline option: --=DirectRunner",
+		},
+		{
+			// Test case with calling CheckPipelineOptions method with runner option without value.
+			// As a result, want to receive an error.
+			name:            "runner without value",
+			pipelineOptions: "--runner --streaming",
+			want:            false,
+			wantErr:         true,
+			errMsg:          "malformed pipeline option: --runner should have a value",
+		},
+		{
+			// Test case with calling CheckPipelineOptions method with the runner which is allowed by the config of the SDK.
+			// As a result, want to receive true.
+			name:            "configured allowed runner",
+			pipelineOptions: "--runner=directrunner --streaming",
+			allowedRunners:  []string{"DirectRunner"},
+			want:            true,
+			wantErr:         false,
+		},
+		{
+			// Test case with calling CheckPipelineOptions method with the runner which isn't allowed by the config of the SDK.
+			// As a result, want to receive an error with the name of the runner and the allowed runners.
+			name:            "configured disallowed runner",
+			pipelineOptions: "--runner=FlinkRunner",
+			allowedRunners:  []string{"DirectRunner", "org.apache.beam.runners.direct.DirectRunner"},
+			want:            false,
+			wantErr:         true,
+			errMsg:          "runner FlinkRunner isn't allowed, allowed runners: DirectRunner, org.apache.beam.runners.direct.DirectRunner",
+		},
+		{
+			// Test case with calling CheckPipelineOptions method with the default runner which isn't in the config of the SDK.
+			// As a result, want to receive an error with the name of the runner and the allowed runners.
+			name:            "default runner isn't configured",
+			pipelineOptions: "--runner=direct",
+			allowedRunners:  []string{"DirectRunner"},
+			want:            false,
+			wantErr:         true,
+			errMsg:          "runner direct isn't allowed, allowed runners: DirectRunner",
+		},
+		{
+			// Test case with calling CheckPipelineOptions method without runner and with the allowed runners in the config of the SDK.
+			// As a result, want to receive true because the default runner is used.
+			name:            "no runner with configured allowed runners",
+			pipelineOptions: "--inputFile=input.txt",
+			allowedRunners:  []string{"DirectRunner"},
+			want:            true,
+			wantErr:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckPipelineOptions(tt.pipelineOptions, tt.allowedRunners)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckPipelineOptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && err.Error() != tt.errMsg {
+				t.Errorf("CheckPipelineOptions() error message = %v, want %v", err.Error(), tt.errMsg)
+			}
+			if got != tt.want {
+				t.Errorf("CheckPipelineOptions() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
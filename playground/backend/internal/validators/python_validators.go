@@ -15,8 +15,10 @@
 
 package validators
 
-// GetPythonValidators return validators methods that should be applied to Go code
-func GetPythonValidators() *[]Validator {
+import pb "beam.apache.org/playground/backend/internal/api/v1"
+
+// Python code has no own validators
+func init() {
 	//TODO: Will be added in task [BEAM-13292]
-	return &[]Validator{}
+	Register(pb.Sdk_SDK_PYTHON)
 }
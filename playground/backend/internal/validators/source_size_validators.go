@@ -0,0 +1,70 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const SourceSizeValidatorName = "SourceSize"
+
+// ErrSourceTooLarge is returned if the size of the source files exceeds the max source size
+var ErrSourceTooLarge = errors.New("source too large")
+
+// sourceSizeValidator checks that the source files aren't larger than maxSourceSize
+type sourceSizeValidator struct {
+	maxSourceSize int
+}
+
+// GetSourceSizeValidator returns validator which checks that the total size of the source files of the pipeline
+//	doesn't exceed maxSourceSize (in bytes). 0 means that the size isn't limited.
+func GetSourceSizeValidator(maxSourceSize int) Validator {
+	return sourceSizeValidator{maxSourceSize: maxSourceSize}
+}
+
+func (v sourceSizeValidator) Name() string {
+	return SourceSizeValidatorName
+}
+
+func (v sourceSizeValidator) Validate(_ context.Context, lc *fs_tool.LifeCycle) (bool, error) {
+	return CheckSourceSize(lc.GetAbsoluteSourceFilePaths(), v.maxSourceSize)
+}
+
+// CheckSourceSize checks that the total size of the files doesn't exceed the max size.
+// In case the size is exceeded returns false and an error which wraps ErrSourceTooLarge with the size of the files.
+func CheckSourceSize(args ...interface{}) (bool, error) {
+	filePaths := args[0].([]string)
+	maxSourceSize := args[1].(int)
+	if maxSourceSize <= 0 {
+		return true, nil
+	}
+	var size int64
+	for _, filePath := range filePaths {
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return false, err
+		}
+		size += info.Size()
+	}
+	if size > int64(maxSourceSize) {
+		return false, fmt.Errorf("%w: %d bytes, the max size is %d bytes", ErrSourceTooLarge, size, maxSourceSize)
+	}
+	return true, nil
+}
@@ -0,0 +1,109 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckSourceSize(t *testing.T) {
+	dir, err := os.MkdirTemp("", "source_size")
+	if err != nil {
+		t.Fatalf("error during prepare folder: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+	mainFile := filepath.Join(dir, "main.java")
+	additionalFile := filepath.Join(dir, "Utils.java")
+	if err := os.WriteFile(mainFile, []byte(strings.Repeat("a", 10)), 0600); err != nil {
+		t.Fatalf("error during prepare file: %s", err.Error())
+	}
+	if err := os.WriteFile(additionalFile, []byte(strings.Repeat("b", 5)), 0600); err != nil {
+		t.Fatalf("error during prepare file: %s", err.Error())
+	}
+
+	tests := []struct {
+		name          string
+		filePaths     []string
+		maxSourceSize int
+		want          bool
+		wantErr       bool
+	}{
+		{
+			// Test case with calling CheckSourceSize method with the file which is smaller than the max size.
+			// As a result, want to receive true.
+			name:          "just under the limit",
+			filePaths:     []string{mainFile},
+			maxSourceSize: 11,
+			want:          true,
+			wantErr:       false,
+		},
+		{
+			// Test case with calling CheckSourceSize method with the file which has the max size.
+			// As a result, want to receive true.
+			name:          "at the limit",
+			filePaths:     []string{mainFile},
+			maxSourceSize: 10,
+			want:          true,
+			wantErr:       false,
+		},
+		{
+			// Test case with calling CheckSourceSize method with the file which is larger than the max size.
+			// As a result, want to receive ErrSourceTooLarge.
+			name:          "just over the limit",
+			filePaths:     []string{mainFile},
+			maxSourceSize: 9,
+			want:          false,
+			wantErr:       true,
+		},
+		{
+			// Test case with calling CheckSourceSize method with several files which are larger than the max size in total.
+			// As a result, want to receive ErrSourceTooLarge.
+			name:          "several files over the limit",
+			filePaths:     []string{mainFile, additionalFile},
+			maxSourceSize: 14,
+			want:          false,
+			wantErr:       true,
+		},
+		{
+			// Test case with calling CheckSourceSize method without the max size.
+			// As a result, want to receive true.
+			name:          "size isn't limited",
+			filePaths:     []string{mainFile, additionalFile},
+			maxSourceSize: 0,
+			want:          true,
+			wantErr:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckSourceSize(tt.filePaths, tt.maxSourceSize)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckSourceSize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, ErrSourceTooLarge) {
+				t.Errorf("CheckSourceSize() error = %v, want %v", err, ErrSourceTooLarge)
+			}
+			if got != tt.want {
+				t.Errorf("CheckSourceSize() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
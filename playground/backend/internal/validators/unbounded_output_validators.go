@@ -0,0 +1,202 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package validators
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const UnboundedOutputValidatorName = "UnboundedOutput"
+
+// unboundedOutputPatterns are patterns to find loops which print the output and are never left.
+// The body of each loop found by infinite is checked to contain output and to not contain exit.
+type unboundedOutputPatterns struct {
+	// infinite finds the header of a trivially-infinite loop, i.e. while(true)
+	infinite *regexp.Regexp
+	// output finds a call which prints the output, i.e. System.out.println(
+	output *regexp.Regexp
+	// exit finds a statement which could leave the loop, i.e. break or return
+	exit *regexp.Regexp
+	// indentedBody defines whether the body of the loop is defined by indentation (Python) instead of braces
+	indentedBody bool
+}
+
+var sdkUnboundedOutputPatterns = map[pb.Sdk]unboundedOutputPatterns{
+	pb.Sdk_SDK_JAVA: {
+		infinite: regexp.MustCompile(`\bwhile\s*\(\s*true\s*\)|\bfor\s*\(\s*;\s*;\s*\)`),
+		output:   regexp.MustCompile(`\bSystem\s*\.\s*(out|err)\s*\.\s*print(ln|f)?\s*\(`),
+		exit:     regexp.MustCompile(`\b(break|return|throw)\b|\bSystem\s*\.\s*exit\s*\(`),
+	},
+	pb.Sdk_SDK_GO: {
+		infinite: regexp.MustCompile(`\bfor\s*(true\s*)?\{|\bfor\s*;\s*;\s*\{`),
+		output:   regexp.MustCompile(`\b(fmt\s*\.\s*F?Print(ln|f)?|log\s*\.\s*Print(ln|f)?|println|print)\s*\(`),
+		exit:     regexp.MustCompile(`\b(break|return|goto|panic)\b|\bos\s*\.\s*Exit\s*\(|\blog\s*\.\s*Fatal`),
+	},
+	pb.Sdk_SDK_PYTHON: {
+		infinite:     regexp.MustCompile(`\bwhile\s*\(?\s*(True|1)\s*\)?\s*:`),
+		output:       regexp.MustCompile(`\bprint\s*\(|\bsys\s*\.\s*(stdout|stderr)\s*\.\s*write\s*\(`),
+		exit:         regexp.MustCompile(`\b(break|return|raise)\b|\b(sys\s*\.\s*)?exit\s*\(`),
+		indentedBody: true,
+	},
+	pb.Sdk_SDK_SCIO: {
+		infinite: regexp.MustCompile(`\bwhile\s*\(\s*true\s*\)`),
+		output:   regexp.MustCompile(`\b(println|print|printf)\s*\(`),
+		exit:     regexp.MustCompile(`\b(return|throw)\b|\b(sys\s*\.\s*exit|System\s*\.\s*exit|break)\s*\(`),
+	},
+	pb.Sdk_SDK_KOTLIN: {
+		infinite: regexp.MustCompile(`\bwhile\s*\(\s*true\s*\)`),
+		output:   regexp.MustCompile(`\b(println|print)\s*\(|\bSystem\s*\.\s*(out|err)\s*\.\s*print(ln|f)?\s*\(`),
+		exit:     regexp.MustCompile(`\b(break|return|throw)\b|\b(exitProcess|System\s*\.\s*exit)\s*\(`),
+	},
+}
+
+// unboundedOutputValidator checks that the source files don't contain loops which print the output and are never left
+type unboundedOutputValidator struct {
+	sdk pb.Sdk
+}
+
+// GetUnboundedOutputValidator returns validator which checks that the source files of the pipeline don't contain
+//	trivially-infinite loops which print the output (i.e. while(true) with System.out.println inside for Java code).
+// The check is a static heuristic, so loops which are left in a way not known to the validator are also reported.
+func GetUnboundedOutputValidator(sdk pb.Sdk) Validator {
+	return unboundedOutputValidator{sdk: sdk}
+}
+
+func (v unboundedOutputValidator) Name() string {
+	return UnboundedOutputValidatorName
+}
+
+func (v unboundedOutputValidator) Validate(_ context.Context, lc *fs_tool.LifeCycle) (bool, error) {
+	return CheckUnboundedOutput(lc.GetAbsoluteSourceFilePaths(), v.sdk)
+}
+
+// CheckUnboundedOutput checks that the code doesn't contain a trivially-infinite loop which prints the output
+//	and doesn't contain any statement to leave the loop.
+// In case such loop is found returns false and an error with the header of the loop and the matched output call.
+// The code of SDKs without known patterns is always valid.
+func CheckUnboundedOutput(args ...interface{}) (bool, error) {
+	filePaths := args[0].([]string)
+	sdk := args[1].(pb.Sdk)
+	patterns, ok := sdkUnboundedOutputPatterns[sdk]
+	if !ok {
+		return true, nil
+	}
+	for _, filePath := range filePaths {
+		code, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			logger.Errorf("Validation: Error during open file: %s, err: %s\n", filePath, err.Error())
+			return false, err
+		}
+		if loop, output, found := findUnboundedOutputLoop(string(code), patterns); found {
+			return false, fmt.Errorf("unbounded output loop %s with %s is used in %s", loop, output, filepath.Base(filePath))
+		}
+	}
+	return true, nil
+}
+
+// findUnboundedOutputLoop returns the header of the first infinite loop which prints the output
+//	and the matched output call if the body of the loop doesn't contain any statement to leave the loop.
+func findUnboundedOutputLoop(code string, patterns unboundedOutputPatterns) (string, string, bool) {
+	for _, loc := range patterns.infinite.FindAllStringIndex(code, -1) {
+		var body string
+		if patterns.indentedBody {
+			body = indentedLoopBody(code, loc[0], loc[1])
+		} else {
+			body = bracedLoopBody(code, loc[1])
+		}
+		output := patterns.output.FindString(body)
+		if output == "" || patterns.exit.MatchString(body) {
+			continue
+		}
+		return compactSpaces(code[loc[0]:loc[1]]), strings.TrimRight(compactSpaces(output), "( "), true
+	}
+	return "", "", false
+}
+
+// bracedLoopBody returns the body of the loop which header ends at start. The body is enclosed in braces
+//	or is a single statement up to ';' if it isn't enclosed in braces.
+func bracedLoopBody(code string, start int) string {
+	i := start
+	if start > 0 && code[start-1] == '{' {
+		// the opening brace of the loop is a part of the header of the Go loop
+		i = start - 1
+	}
+	for i < len(code) && (code[i] == ' ' || code[i] == '\t' || code[i] == '\n' || code[i] == '\r') {
+		i++
+	}
+	if i >= len(code) || code[i] != '{' {
+		end := strings.IndexByte(code[i:], ';')
+		if end < 0 {
+			return code[i:]
+		}
+		return code[i : i+end]
+	}
+	depth := 0
+	for j := i; j < len(code); j++ {
+		switch code[j] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return code[i+1 : j]
+			}
+		}
+	}
+	return code[i+1:]
+}
+
+// indentedLoopBody returns the body of the loop which header is placed between start and end.
+// The body is the rest of the header line or the following lines which are indented deeper than the header.
+func indentedLoopBody(code string, start, end int) string {
+	lineStart := strings.LastIndexByte(code[:start], '\n') + 1
+	headerIndent := indentation(code[lineStart:start])
+	lineEnd := strings.IndexByte(code[end:], '\n')
+	if lineEnd < 0 {
+		return code[end:]
+	}
+	if rest := strings.TrimSpace(code[end : end+lineEnd]); rest != "" && !strings.HasPrefix(rest, "#") {
+		return rest
+	}
+	var body []string
+	for _, line := range strings.Split(code[end+lineEnd+1:], "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if indentation(line) <= headerIndent {
+			break
+		}
+		body = append(body, line)
+	}
+	return strings.Join(body, "\n")
+}
+
+// indentation returns the number of leading spaces and tabs of the line
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// compactSpaces replaces sequences of whitespaces with a single space
+func compactSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,155 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package validators
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"testing"
+)
+
+const cancelCodeFilePath = "cancelCode.java"
+const cancelCode = "class HelloWorld {\n    public static void main(String[] args) {\n        while(true){}\n    }\n}"
+const unboundedOutputCodeFilePath = "unboundedOutputCode.java"
+const unboundedOutputCode = "class HelloWorld {\n    public static void main(String[] args) {\n        while (true) {\n            System.out.println(\"Hello World!\");\n        }\n    }\n}"
+const benignLoopCodeFilePath = "benignLoopCode.java"
+const benignLoopCode = "class HelloWorld {\n    public static void main(String[] args) {\n        for (int i = 0; i < 10; i++) {\n            System.out.println(i);\n        }\n        while (true) {\n            System.out.println(\"Hello World!\");\n            break;\n        }\n    }\n}"
+const unboundedOutputGoCodeFilePath = "unboundedOutputCode.go"
+const unboundedOutputGoCode = "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfor {\n\t\tfmt.Println(\"Hello World!\")\n\t}\n}\n"
+const unboundedOutputPythonCodeFilePath = "unboundedOutputCode.py"
+const unboundedOutputPythonCode = "while True:\n    print('Hello World!')\n"
+const unboundedOutputKotlinCodeFilePath = "unboundedOutputCode.kt"
+const unboundedOutputKotlinCode = "fun main() {\n    while (true) {\n        println(\"Hello World!\")\n    }\n}\n"
+const benignLoopPythonCodeFilePath = "benignLoopCode.py"
+const benignLoopPythonCode = "while True:\n    line = input()\n    if not line:\n        break\n    print(line)\nprint('done')\n"
+
+func TestCheckUnboundedOutput(t *testing.T) {
+	files := map[string]string{
+		cancelCodeFilePath:                cancelCode,
+		unboundedOutputCodeFilePath:       unboundedOutputCode,
+		benignLoopCodeFilePath:            benignLoopCode,
+		unboundedOutputGoCodeFilePath:     unboundedOutputGoCode,
+		unboundedOutputPythonCodeFilePath: unboundedOutputPythonCode,
+		unboundedOutputKotlinCodeFilePath: unboundedOutputKotlinCode,
+		benignLoopPythonCodeFilePath:      benignLoopPythonCode,
+	}
+	for path, code := range files {
+		writeFile(path, code)
+		defer removeFile(path)
+	}
+
+	type args struct {
+		args []interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			// Test case with calling CheckUnboundedOutput method with Java code with an infinite loop which doesn't print anything.
+			// As a result, want to receive true.
+			name:    "infinite loop without output",
+			args:    args{[]interface{}{[]string{cancelCodeFilePath}, pb.Sdk_SDK_JAVA}},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckUnboundedOutput method with Java code with bounded loops which print the output.
+			// As a result, want to receive true.
+			name:    "bounded loops with output",
+			args:    args{[]interface{}{[]string{benignLoopCodeFilePath}, pb.Sdk_SDK_JAVA}},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckUnboundedOutput method with several files and one of them has while(true) with println inside.
+			// As a result, want to receive an error with the matched loop and output call.
+			name:    "java infinite loop with output",
+			args:    args{[]interface{}{[]string{cancelCodeFilePath, unboundedOutputCodeFilePath}, pb.Sdk_SDK_JAVA}},
+			want:    false,
+			wantErr: true,
+			errMsg:  "unbounded output loop while (true) with System.out.println is used in unboundedOutputCode.java",
+		},
+		{
+			// Test case with calling CheckUnboundedOutput method with Go code which has for loop without condition with fmt.Println inside.
+			// As a result, want to receive an error with the matched loop and output call.
+			name:    "go infinite loop with output",
+			args:    args{[]interface{}{[]string{unboundedOutputGoCodeFilePath}, pb.Sdk_SDK_GO}},
+			want:    false,
+			wantErr: true,
+			errMsg:  "unbounded output loop for { with fmt.Println is used in unboundedOutputCode.go",
+		},
+		{
+			// Test case with calling CheckUnboundedOutput method with Python code which has while True with print inside.
+			// As a result, want to receive an error with the matched loop and output call.
+			name:    "python infinite loop with output",
+			args:    args{[]interface{}{[]string{unboundedOutputPythonCodeFilePath}, pb.Sdk_SDK_PYTHON}},
+			want:    false,
+			wantErr: true,
+			errMsg:  "unbounded output loop while True: with print is used in unboundedOutputCode.py",
+		},
+		{
+			// Test case with calling CheckUnboundedOutput method with Python code which has while True which is left by break.
+			// As a result, want to receive true.
+			name:    "python loop with break",
+			args:    args{[]interface{}{[]string{benignLoopPythonCodeFilePath}, pb.Sdk_SDK_PYTHON}},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckUnboundedOutput method with Kotlin code which has while (true) with println inside.
+			// As a result, want to receive an error with the matched loop and output call.
+			name:    "kotlin infinite loop with output",
+			args:    args{[]interface{}{[]string{unboundedOutputKotlinCodeFilePath}, pb.Sdk_SDK_KOTLIN}},
+			want:    false,
+			wantErr: true,
+			errMsg:  "unbounded output loop while (true) with println is used in unboundedOutputCode.kt",
+		},
+		{
+			// Test case with calling CheckUnboundedOutput method with the sdk without known patterns.
+			// As a result, want to receive true.
+			name:    "unspecified sdk",
+			args:    args{[]interface{}{[]string{unboundedOutputCodeFilePath}, pb.Sdk_SDK_UNSPECIFIED}},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckUnboundedOutput method with file which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "file doesn't exist",
+			args:    args{[]interface{}{[]string{"notExistingCode.java"}, pb.Sdk_SDK_JAVA}},
+			want:    false,
+			wantErr: true,
+			errMsg:  "open notExistingCode.java: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckUnboundedOutput(tt.args.args...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckUnboundedOutput() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && err.Error() != tt.errMsg {
+				t.Errorf("CheckUnboundedOutput() error = %v, want %v", err, tt.errMsg)
+			}
+			if got != tt.want {
+				t.Errorf("CheckUnboundedOutput() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
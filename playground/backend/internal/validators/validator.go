@@ -15,10 +15,48 @@
 
 package validators
 
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"context"
+	"sync"
+)
+
 const UnitTestValidatorName = "UnitTest"
 
-type Validator struct {
-	Validator func(args ...interface{}) (bool, error)
-	Args      []interface{}
-	Name      string
+// Validator checks the code of the pipeline before it is prepared and compiled
+type Validator interface {
+	// Name returns the name of the validator. The result of the validator is stored by this name.
+	Name() string
+	// Validate checks the code from the source files of lc.
+	// Returns the result of the check and an error in case the code isn't valid.
+	Validate(ctx context.Context, lc *fs_tool.LifeCycle) (bool, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[pb.Sdk][]Validator)
+)
+
+// Register adds validators to the list of validators of the sdk.
+// Validators of the sdk are applied in the order they are registered.
+// Register without validators only declares that the sdk is supported and has no own validators.
+func Register(sdk pb.Sdk, validators ...Validator) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[sdk] = append(registry[sdk], validators...)
+}
+
+// GetSdkValidators returns validators registered for the sdk in the order of registration.
+// The second value is false if the sdk isn't registered.
+func GetSdkValidators(sdk pb.Sdk) ([]Validator, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	registered, ok := registry[sdk]
+	if !ok {
+		return nil, false
+	}
+	validators := make([]Validator, len(registered))
+	copy(validators, registered)
+	return validators, true
 }
@@ -0,0 +1,164 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"context"
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+)
+
+// testSdk is the sdk which is used only by tests of the registry
+const testSdk = pb.Sdk(100)
+
+// namedValidator is a validator which records its name into calls
+type namedValidator struct {
+	name  string
+	calls *[]string
+}
+
+func (v *namedValidator) Name() string {
+	return v.name
+}
+
+func (v *namedValidator) Validate(_ context.Context, _ *fs_tool.LifeCycle) (bool, error) {
+	*v.calls = append(*v.calls, v.name)
+	return true, nil
+}
+
+func validatorNames(validators []Validator) []string {
+	names := make([]string, 0, len(validators))
+	for _, validator := range validators {
+		names = append(names, validator.Name())
+	}
+	return names
+}
+
+func TestRegister(t *testing.T) {
+	defer func() {
+		registryMu.Lock()
+		delete(registry, testSdk)
+		registryMu.Unlock()
+	}()
+	if _, ok := GetSdkValidators(testSdk); ok {
+		t.Fatalf("GetSdkValidators() ok = true for not registered sdk")
+	}
+
+	var calls []string
+	Register(testSdk)
+	Register(testSdk, &namedValidator{name: "first", calls: &calls}, &namedValidator{name: "second", calls: &calls})
+	Register(testSdk, &namedValidator{name: "third", calls: &calls})
+
+	got, ok := GetSdkValidators(testSdk)
+	if !ok {
+		t.Fatalf("GetSdkValidators() ok = false for registered sdk")
+	}
+	want := []string{"first", "second", "third"}
+	if names := validatorNames(got); !reflect.DeepEqual(names, want) {
+		t.Errorf("GetSdkValidators() = %v, want %v", names, want)
+	}
+	for _, validator := range got {
+		if _, err := validator.Validate(context.Background(), nil); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("validators are called in order %v, want %v", calls, want)
+	}
+
+	got[0] = &namedValidator{name: "replaced", calls: &calls}
+	again, _ := GetSdkValidators(testSdk)
+	if names := validatorNames(again); !reflect.DeepEqual(names, want) {
+		t.Errorf("GetSdkValidators() after changing the returned slice = %v, want %v", names, want)
+	}
+}
+
+func TestGetSdkValidators(t *testing.T) {
+	tests := []struct {
+		name string
+		sdk  pb.Sdk
+		want []string
+	}{
+		{
+			// Test case with calling GetSdkValidators method for Java.
+			// As a result, want to receive the path validator followed by the unit test validator.
+			name: "java",
+			sdk:  pb.Sdk_SDK_JAVA,
+			want: []string{"Valid path", UnitTestValidatorName},
+		},
+		{
+			// Test case with calling GetSdkValidators method for Go.
+			// As a result, want to receive no validators.
+			name: "go",
+			sdk:  pb.Sdk_SDK_GO,
+			want: []string{},
+		},
+		{
+			name: "python",
+			sdk:  pb.Sdk_SDK_PYTHON,
+			want: []string{},
+		},
+		{
+			name: "scio",
+			sdk:  pb.Sdk_SDK_SCIO,
+			want: []string{},
+		},
+		{
+			// Test case with calling GetSdkValidators method for Kotlin.
+			// As a result, want to receive the same validators as for Java.
+			name: "kotlin",
+			sdk:  pb.Sdk_SDK_KOTLIN,
+			want: []string{"Valid path", UnitTestValidatorName},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GetSdkValidators(tt.sdk)
+			if !ok {
+				t.Fatalf("GetSdkValidators() ok = false, want true")
+			}
+			if names := validatorNames(got); !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("GetSdkValidators() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestJavaValidators_Validate(t *testing.T) {
+	lc, err := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, uuid.New(), t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = lc.CreateFolders(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = lc.CreateSourceCodeFile(unitTestCode); err != nil {
+		t.Fatal(err)
+	}
+	javaValidators, _ := GetSdkValidators(pb.Sdk_SDK_JAVA)
+	for _, validator := range javaValidators {
+		got, err := validator.Validate(context.Background(), lc)
+		if err != nil {
+			t.Fatalf("%s Validate() error = %v", validator.Name(), err)
+		}
+		if !got {
+			t.Errorf("%s Validate() = false, want true", validator.Name())
+		}
+	}
+}